@@ -0,0 +1,14 @@
+// Command aicof is the pre-rename name of samuel, kept so existing scripts
+// keep working until it is removed. It runs the same command tree and
+// warns that the name is deprecated.
+package main
+
+import (
+	"os"
+
+	"github.com/ar4mirez/samuel/internal/commands"
+)
+
+func main() {
+	os.Exit(commands.Run("aicof"))
+}
@@ -10,7 +10,7 @@ import (
 )
 
 var addCmd = &cobra.Command{
-	Use:   "add <type> <name>",
+	Use:   "add <type> <name> | add --auto",
 	Short: "Add a component to your project",
 	Long: `Add a language guide, framework guide, or workflow to your project.
 
@@ -19,29 +19,50 @@ Types:
   framework  Add a framework guide (e.g., django, rails)
   workflow   Add a workflow (e.g., security-audit)
 
+With --auto, the languages and frameworks detected in the project (from
+marker files and the dependencies resolved in its lockfiles) that are not
+installed yet are listed and added after confirmation.
+
 Examples:
   samuel add language rust
   samuel add framework django
-  samuel add workflow security-audit`,
-	Args: cobra.ExactArgs(2),
+  samuel add workflow security-audit
+  samuel add --auto`,
+	Args: addArgs,
 	RunE: runAdd,
 }
 
 func init() {
+	addCmd.Flags().Bool("auto", false, "Add the detected languages and frameworks that are not installed")
+	addPolicyOverrideFlag(addCmd)
 	rootCmd.AddCommand(addCmd)
 }
 
-func runAdd(cmd *cobra.Command, args []string) error {
-	componentType := args[0]
-	componentName := args[1]
+// addArgs requires <type> <name>, or no arguments with --auto.
+func addArgs(cmd *cobra.Command, args []string) error {
+	if auto, _ := cmd.Flags().GetBool("auto"); auto {
+		if len(args) > 0 {
+			return fmt.Errorf("--auto takes no arguments")
+		}
+		return nil
+	}
+	return cobra.ExactArgs(2)(cmd, args)
+}
 
-	config, err := core.LoadConfig()
+func runAdd(cmd *cobra.Command, args []string) error {
+	config, dir, err := loadProjectConfig(cmd)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
 		}
 		return fmt.Errorf("failed to load config: %w", err)
 	}
+	if len(args) == 0 { // --auto, checked by addArgs
+		return runAddAuto(cmd, dir, config)
+	}
+
+	componentType := args[0]
+	componentName := args[1]
 
 	component, alreadyInstalled, err := resolveComponent(componentType, componentName, config)
 	if err != nil {
@@ -52,11 +73,16 @@ func runAdd(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	if err := downloadAndInstall(config.Version, component); err != nil {
+	if config.Trust, err = confirmOverlayTrust(config.Trust, config.Overlay); err != nil {
 		return err
 	}
 
-	return updateAddConfig(config, componentType, componentName, component.Path)
+	return withProjectLock(cmd, dir, func() error {
+		if err := downloadAndInstall(cmd, dir, config, component); err != nil {
+			return err
+		}
+		return updateAddConfig(dir, config, componentType, componentName, component.Path)
+	})
 }
 
 // resolveComponent validates the component type, finds it in the registry,
@@ -86,8 +112,10 @@ func resolveComponent(componentType, componentName string, config *core.Config)
 	}
 }
 
-// downloadAndInstall downloads the framework version and copies the component to the current directory.
-func downloadAndInstall(version string, component *core.Component) error {
+// downloadAndInstall downloads the framework version and copies the component to the project directory.
+// Overlay files for the component take precedence over the base registry, and
+// the overlay's organization policy is enforced first.
+func downloadAndInstall(cmd *cobra.Command, dir string, config *core.Config, component *core.Component) error {
 	spinner := ui.NewSpinner(fmt.Sprintf("Downloading %s...", component.Name))
 	spinner.Start()
 
@@ -97,19 +125,27 @@ func downloadAndInstall(version string, component *core.Component) error {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
 
-	cachePath, err := downloader.DownloadVersion(version)
+	tmpl, err := downloader.ResolveTemplate(config.Version, config.Overlay)
 	if err != nil {
 		spinner.Error("Download failed")
 		return fmt.Errorf("failed to download: %w", err)
 	}
 	spinner.Stop()
+	if err := checkAddPolicy(cmd, dir, config, component, tmpl); err != nil {
+		return err
+	}
 
-	cwd, err := os.Getwd()
+	// Stop at the first failed file; the component is then not recorded.
+	extractor := core.NewExtractor(tmpl.Path, dir)
+	extractor.SetPolicy(core.ExtractFailFast)
+	extractor.SetFinalNewline(config.FinalNewline())
+	result, err := extractor.Extract([]string{component.Path}, true)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return fmt.Errorf("failed to install %s: %w", component.Name, err)
 	}
-
-	if err := core.CopyFromCache(cachePath, cwd, component.Path); err != nil {
+	reportIgnoredFiles(result)
+	reportExtractFailures(result)
+	if err := result.Err(); err != nil {
 		return fmt.Errorf("failed to install %s: %w", component.Name, err)
 	}
 
@@ -117,7 +153,7 @@ func downloadAndInstall(version string, component *core.Component) error {
 }
 
 // updateAddConfig adds the component to the project config and saves it.
-func updateAddConfig(config *core.Config, componentType, componentName, componentPath string) error {
+func updateAddConfig(dir string, config *core.Config, componentType, componentName, componentPath string) error {
 	switch componentType {
 	case "language", "lang", "l":
 		config.AddLanguage(componentName)
@@ -127,14 +163,10 @@ func updateAddConfig(config *core.Config, componentType, componentName, componen
 		config.AddWorkflow(componentName)
 	}
 
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
-	}
-
-	if err := config.Save(cwd); err != nil {
+	if err := config.Save(dir); err != nil {
 		return fmt.Errorf("failed to update config: %w", err)
 	}
+	refreshSkillIndex(dir)
 
 	ui.Success("Installed %s", componentPath)
 	ui.Success("Updated samuel.yaml")
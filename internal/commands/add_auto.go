@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// runAddAuto installs the detected languages and frameworks that are not
+// installed yet, after confirmation.
+func runAddAuto(cmd *cobra.Command, dir string, config *core.Config) error {
+	missing := missingDetectedComponents(core.DetectProject(dir), config)
+	if len(missing) == 0 {
+		ui.Success("Every detected language and framework is already installed")
+		return nil
+	}
+
+	ui.Section("Detected components not installed")
+	for _, c := range missing {
+		ui.ListItem(1, "%s %s  %s", c.Kind, c.Name, detectedFrom(c))
+	}
+	confirmed, err := approve(promptAddAuto, "\nAdd these components?", true)
+	if err != nil || !confirmed {
+		ui.Warn("Nothing added")
+		return nil
+	}
+
+	if config.Trust, err = confirmOverlayTrust(config.Trust, config.Overlay); err != nil {
+		return err
+	}
+	return withProjectLock(cmd, dir, func() error {
+		for _, c := range missing {
+			component, _, err := resolveComponent(c.Kind, c.Name, config)
+			if err != nil {
+				return err
+			}
+			if err := downloadAndInstall(cmd, dir, config, component); err != nil {
+				return err
+			}
+			if err := updateAddConfig(dir, config, c.Kind, c.Name, component.Path); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// missingDetectedComponents returns the detected languages, then
+// frameworks, that config does not list as installed.
+func missingDetectedComponents(d *core.ProjectDetection, config *core.Config) []core.DetectedComponent {
+	var missing []core.DetectedComponent
+	for _, c := range d.Languages {
+		if !config.HasLanguage(c.Name) {
+			missing = append(missing, c)
+		}
+	}
+	for _, c := range d.Frameworks {
+		if !config.HasFramework(c.Name) {
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
+
+// detectedFrom describes where a component was detected, e.g.
+// "(14.1.0, package-lock.json)".
+func detectedFrom(c core.DetectedComponent) string {
+	if c.Version != "" {
+		return fmt.Sprintf("(%s, %s)", c.Version, c.Source)
+	}
+	return fmt.Sprintf("(%s)", c.Source)
+}
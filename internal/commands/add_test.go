@@ -2,10 +2,13 @@ package commands
 
 import (
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
 )
 
 func TestResolveComponent(t *testing.T) {
@@ -171,7 +174,7 @@ func TestUpdateAddConfig(t *testing.T) {
 		config := core.NewConfig("1.0.0")
 		dir := setupConfigTestDir(t, config)
 
-		err := updateAddConfig(config, "language", "rust", ".claude/skills/rust-guide")
+		err := updateAddConfig(".", config, "language", "rust", ".claude/skills/rust-guide")
 		if err != nil {
 			t.Fatalf("updateAddConfig() error = %v", err)
 		}
@@ -189,7 +192,7 @@ func TestUpdateAddConfig(t *testing.T) {
 		config := core.NewConfig("1.0.0")
 		dir := setupConfigTestDir(t, config)
 
-		err := updateAddConfig(config, "framework", "django", ".claude/skills/django")
+		err := updateAddConfig(".", config, "framework", "django", ".claude/skills/django")
 		if err != nil {
 			t.Fatalf("updateAddConfig() error = %v", err)
 		}
@@ -207,7 +210,7 @@ func TestUpdateAddConfig(t *testing.T) {
 		config := core.NewConfig("1.0.0")
 		dir := setupConfigTestDir(t, config)
 
-		err := updateAddConfig(config, "workflow", "security-audit", ".claude/skills/security-audit")
+		err := updateAddConfig(".", config, "workflow", "security-audit", ".claude/skills/security-audit")
 		if err != nil {
 			t.Fatalf("updateAddConfig() error = %v", err)
 		}
@@ -225,7 +228,7 @@ func TestUpdateAddConfig(t *testing.T) {
 		config := core.NewConfig("1.0.0")
 		dir := setupConfigTestDir(t, config)
 
-		err := updateAddConfig(config, "lang", "python", ".claude/skills/python-guide")
+		err := updateAddConfig(".", config, "lang", "python", ".claude/skills/python-guide")
 		if err != nil {
 			t.Fatalf("updateAddConfig() error = %v", err)
 		}
@@ -243,7 +246,7 @@ func TestUpdateAddConfig(t *testing.T) {
 		config := core.NewConfig("1.0.0")
 		dir := setupConfigTestDir(t, config)
 
-		err := updateAddConfig(config, "fw", "react", ".claude/skills/react")
+		err := updateAddConfig(".", config, "fw", "react", ".claude/skills/react")
 		if err != nil {
 			t.Fatalf("updateAddConfig() error = %v", err)
 		}
@@ -261,7 +264,7 @@ func TestUpdateAddConfig(t *testing.T) {
 		config := core.NewConfig("1.0.0")
 		dir := setupConfigTestDir(t, config)
 
-		err := updateAddConfig(config, "wf", "code-review", ".claude/skills/code-review")
+		err := updateAddConfig(".", config, "wf", "code-review", ".claude/skills/code-review")
 		if err != nil {
 			t.Fatalf("updateAddConfig() error = %v", err)
 		}
@@ -281,7 +284,7 @@ func TestUpdateAddConfig(t *testing.T) {
 		config.Installed.Frameworks = []string{"react"}
 		dir := setupConfigTestDir(t, config)
 
-		err := updateAddConfig(config, "language", "rust", ".claude/skills/rust-guide")
+		err := updateAddConfig(".", config, "language", "rust", ".claude/skills/rust-guide")
 		if err != nil {
 			t.Fatalf("updateAddConfig() error = %v", err)
 		}
@@ -371,3 +374,63 @@ func TestRunAdd_AlreadyInstalled(t *testing.T) {
 		t.Errorf("runAdd() for already installed component should not error, got: %v", err)
 	}
 }
+
+func TestAddArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		auto    bool
+		args    []string
+		wantErr bool
+	}{
+		{"type and name", false, []string{"language", "go"}, false},
+		{"missing name", false, []string{"language"}, true},
+		{"auto alone", true, nil, false},
+		{"auto with arguments", true, []string{"language", "go"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("auto", tt.auto, "")
+			if err := addArgs(cmd, tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("addArgs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestMissingDetectedComponents(t *testing.T) {
+	config := core.NewConfig("1.0.0")
+	config.Installed.Languages = []string{"go"}
+	config.Installed.Frameworks = []string{"gin"}
+	detected := &core.ProjectDetection{
+		Languages: []core.DetectedComponent{
+			{Name: "typescript", Kind: core.ComponentLanguage},
+			{Name: "go", Kind: core.ComponentLanguage},
+		},
+		Frameworks: []core.DetectedComponent{
+			{Name: "nextjs", Kind: core.ComponentFramework},
+			{Name: "gin", Kind: core.ComponentFramework},
+		},
+	}
+
+	var got []string
+	for _, c := range missingDetectedComponents(detected, config) {
+		got = append(got, c.Name)
+	}
+	if want := []string{"typescript", "nextjs"}; !slices.Equal(got, want) {
+		t.Errorf("missingDetectedComponents() = %v, want %v", got, want)
+	}
+}
+
+func TestRunAdd_AutoNothingMissing(t *testing.T) {
+	config := core.NewConfig("1.0.0")
+	config.Installed.Languages = []string{"go"}
+	dir := setupConfigTestDir(t, config)
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runAdd(nil, nil); err != nil {
+		t.Errorf("runAdd() with everything installed should not error, got: %v", err)
+	}
+}
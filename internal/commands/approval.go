@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// approveEnv pre-answers prompts when no approval flag is given: "yes",
+// "no", or a comma-separated list of prompt names to approve.
+const approveEnv = "SAMUEL_APPROVE"
+
+// Named confirmation prompts. --approve and SAMUEL_APPROVE select them by
+// name, so scripts can pre-approve one prompt and leave the rest interactive.
+const (
+	promptInitProceed     = "init-proceed"
+	promptRemove          = "remove"
+	promptAddAuto         = "add-auto"
+	promptAutoStart       = "auto-start"
+	promptPilotStart      = "pilot-start"
+	promptAutoBench       = "auto-bench"
+	promptRegistryTrust   = "registry-trust"
+	promptGitInit         = "git-init"
+	promptGitIdentity     = "git-identity"
+	promptGitCommit       = "git-initial-commit"
+	promptAutoReconstruct = "auto-reconstruct"
+	promptDeinit          = "deinit"
+)
+
+// supportedPrompts returns the names accepted by --approve.
+func supportedPrompts() []string {
+	return []string{
+		promptInitProceed, promptRemove, promptAddAuto, promptAutoStart, promptPilotStart,
+		promptAutoBench, promptRegistryTrust, promptGitInit, promptGitIdentity, promptGitCommit,
+		promptAutoReconstruct, promptDeinit,
+	}
+}
+
+// confirmPrompt asks the user; tests replace it to simulate answers.
+var confirmPrompt = ui.Confirm
+
+// approvalPolicy decides which prompts are answered without asking.
+type approvalPolicy struct {
+	// all answers every prompt not in approved: "yes", "no", or "" to ask.
+	all      string
+	approved []string
+}
+
+// approvals is the policy of the running command, set by the root pre-run
+// hook. The zero value asks every prompt.
+var approvals approvalPolicy
+
+// answer returns the pre-set answer for the named prompt, if there is one.
+func (p approvalPolicy) answer(name string) (bool, bool) {
+	if slices.Contains(p.approved, name) {
+		return true, true
+	}
+	switch p.all {
+	case "yes":
+		return true, true
+	case "no":
+		return false, true
+	}
+	return false, false
+}
+
+// approve answers the named confirmation prompt from the approval policy,
+// or asks the user when the policy leaves it open.
+func approve(name, label string, defaultYes bool) (bool, error) {
+	if ok, set := approvals.answer(name); set {
+		answer := "no"
+		if ok {
+			answer = "yes"
+		}
+		ui.Dim("%s %s (%s, pre-answered)", strings.TrimSpace(label), answer, name)
+		return ok, nil
+	}
+	return confirmPrompt(label, defaultYes)
+}
+
+// setApprovalPolicy resolves the approval policy from --yes, --no and
+// --approve, falling back to SAMUEL_APPROVE when no flag is given.
+func setApprovalPolicy(cmd *cobra.Command) error {
+	approvals = approvalPolicy{}
+	yes, _ := cmd.Flags().GetBool("yes")
+	no, _ := cmd.Flags().GetBool("no")
+	names, _ := cmd.Flags().GetStringSlice("approve")
+	if yes && no {
+		return fmt.Errorf("--yes and --no cannot be used together")
+	}
+
+	policy := approvalPolicy{approved: names}
+	switch {
+	case yes:
+		policy.all = "yes"
+	case no:
+		policy.all = "no"
+	case len(names) == 0:
+		policy = parseApproveEnv(os.Getenv(approveEnv))
+	}
+	for _, name := range policy.approved {
+		if !slices.Contains(supportedPrompts(), name) {
+			return fmt.Errorf("invalid prompt: %s (supported: %v)", name, supportedPrompts())
+		}
+	}
+	approvals = policy
+	return nil
+}
+
+// parseApproveEnv parses the SAMUEL_APPROVE value.
+func parseApproveEnv(value string) approvalPolicy {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "":
+		return approvalPolicy{}
+	case "yes", "all":
+		return approvalPolicy{all: "yes"}
+	case "no", "none":
+		return approvalPolicy{all: "no"}
+	}
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return approvalPolicy{approved: names}
+}
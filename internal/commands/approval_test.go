@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newApprovalCmd(t *testing.T, args ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{}
+	cmd.Flags().BoolP("yes", "y", false, "")
+	cmd.Flags().Bool("no", false, "")
+	cmd.Flags().StringSlice("approve", nil, "")
+	if err := cmd.Flags().Parse(args); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestApprove(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		prompt  string
+		want    bool
+		asked   bool
+		wantErr bool
+	}{
+		{name: "asks by default", prompt: promptRemove, want: true, asked: true},
+		{name: "yes", args: []string{"--yes"}, prompt: promptRemove, want: true},
+		{name: "no", args: []string{"--no"}, prompt: promptInitProceed, want: false},
+		{name: "approved by name", args: []string{"--approve", "remove,git-init"}, prompt: promptGitInit, want: true},
+		{name: "other prompt still asks", args: []string{"--approve", "remove"}, prompt: promptAutoStart, want: true, asked: true},
+		{name: "approve overrides no", args: []string{"--no", "--approve", "remove"}, prompt: promptRemove, want: true},
+		{name: "no declines the rest", args: []string{"--no", "--approve", "remove"}, prompt: promptAutoStart, want: false},
+		{name: "env yes", env: "yes", prompt: promptRegistryTrust, want: true},
+		{name: "env list", env: "auto-start, remove", prompt: promptAutoStart, want: true},
+		{name: "flags override env", args: []string{"--no"}, env: "yes", prompt: promptRemove, want: false},
+		{name: "yes and no", args: []string{"--yes", "--no"}, wantErr: true},
+		{name: "unknown prompt", args: []string{"--approve", "everything"}, wantErr: true},
+		{name: "unknown prompt in env", env: "overwrite-all", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(approveEnv, tt.env)
+			orig := confirmPrompt
+			t.Cleanup(func() {
+				confirmPrompt = orig
+				approvals = approvalPolicy{}
+			})
+			asked := false
+			confirmPrompt = func(string, bool) (bool, error) {
+				asked = true
+				return true, nil
+			}
+
+			err := setApprovalPolicy(newApprovalCmd(t, tt.args...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setApprovalPolicy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := approve(tt.prompt, "Proceed?", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want || asked != tt.asked {
+				t.Errorf("approve() = %v (asked %v), want %v (asked %v)", got, asked, tt.want, tt.asked)
+			}
+		})
+	}
+}
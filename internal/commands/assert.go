@@ -0,0 +1,209 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var assertCmd = &cobra.Command{
+	Use:   "assert",
+	Short: "Check project policies with exit codes for CI",
+	Long: `Check a single policy about the Samuel installation and exit with a
+code scripts can rely on, instead of parsing human-oriented output.
+
+Exit codes:
+  0  Assertion passed
+  2  Invalid arguments (e.g. an unknown version operator)
+  4  No samuel.yaml, or it could not be read
+  5  Assertion failed
+  1  Any other error (e.g. the template could not be downloaded)
+
+Assertions compose with the shell, and each one can be used directly as an
+auto loop quality check.
+
+Examples:
+  samuel assert skill-installed go-guide
+  samuel assert version ">= 1.4"
+  samuel assert no-modified-managed-files
+  samuel assert skill-installed go-guide && samuel assert version ">= 2.0"
+  samuel assert version "< 3" --json`,
+}
+
+var assertSkillInstalledCmd = &cobra.Command{
+	Use:   "skill-installed <name>...",
+	Short: "Assert that skills are installed",
+	Long: `Pass when every named skill is listed in samuel.yaml and its SKILL.md
+exists under .claude/skills/.`,
+	Args: assertArgs(cobra.MinimumNArgs(1)),
+	RunE: runAssertSkillInstalled,
+}
+
+var assertVersionCmd = &cobra.Command{
+	Use:   "version <constraint>",
+	Short: "Assert the installed framework version",
+	Long: `Pass when the framework version in samuel.yaml satisfies the constraint.
+Operators: >=, <=, ==, !=, >, <. Quote the constraint or pass the operator
+and version as separate arguments.`,
+	Args: assertArgs(cobra.RangeArgs(1, 2)),
+	RunE: runAssertVersion,
+}
+
+var assertNoModifiedCmd = &cobra.Command{
+	Use:   "no-modified-managed-files",
+	Short: "Assert that installed framework files have no local edits",
+	Long: `Pass when CLAUDE.md, AGENTS.md, and the installed skill files match the
+template of the installed version. The generated skills block in CLAUDE.md
+passes as long as it was not edited by hand. The template is downloaded to
+the cache if it is not already there.`,
+	Args: assertArgs(cobra.NoArgs),
+	RunE: runAssertNoModified,
+}
+
+func init() {
+	rootCmd.AddCommand(assertCmd)
+	assertCmd.PersistentFlags().Bool("json", false, "Print the result as JSON")
+	assertCmd.AddCommand(assertSkillInstalledCmd, assertVersionCmd, assertNoModifiedCmd)
+}
+
+// assertionResult is the outcome of one check.
+type assertionResult struct {
+	Assertion string `json:"assertion"`
+	Passed    bool   `json:"passed"`
+	Message   string `json:"message"`
+}
+
+// assertArgs gives argument validation errors the invalid-arguments code.
+func assertArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		return withExitCode(exitInvalidArgs, validate(cmd, args))
+	}
+}
+
+// loadAssertConfig loads samuel.yaml and returns it with the project
+// directory, failing with the config error code.
+func loadAssertConfig(cmd *cobra.Command) (*core.Config, string, error) {
+	config, dir, err := loadProjectConfig(cmd)
+	if os.IsNotExist(err) {
+		return nil, "", withExitCode(exitConfigError, fmt.Errorf("no Samuel installation found. Run 'samuel init' first"))
+	}
+	if err != nil {
+		return nil, "", withExitCode(exitConfigError, fmt.Errorf("failed to load config: %w", err))
+	}
+	return config, dir, nil
+}
+
+func runAssertSkillInstalled(cmd *cobra.Command, args []string) error {
+	config, cwd, err := loadAssertConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	var results []assertionResult
+	for _, name := range args {
+		results = append(results, checkSkillInstalled(cwd, config, name))
+	}
+	return reportAssertions(cmd, results)
+}
+
+func checkSkillInstalled(cwd string, config *core.Config, name string) assertionResult {
+	result := assertionResult{Assertion: "skill-installed " + name}
+	skillMD := filepath.Join(".claude", "skills", name, "SKILL.md")
+	switch {
+	case !config.HasSkill(name):
+		result.Message = fmt.Sprintf("skill %s is not listed in samuel.yaml", name)
+	case !fileExists(filepath.Join(cwd, skillMD)):
+		result.Message = fmt.Sprintf("skill %s is listed but %s is missing", name, filepath.ToSlash(skillMD))
+	default:
+		result.Passed = true
+		result.Message = fmt.Sprintf("skill %s is installed", name)
+	}
+	return result
+}
+
+func runAssertVersion(cmd *cobra.Command, args []string) error {
+	op, want, err := core.ParseVersionConstraint(strings.Join(args, " "))
+	if err != nil {
+		return withExitCode(exitInvalidArgs, err)
+	}
+	config, _, err := loadAssertConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	ok, err := core.CheckVersionConstraint(config.Version, op, want)
+	if err != nil {
+		return fmt.Errorf("cannot compare installed version %s: %w", config.Version, err)
+	}
+	result := assertionResult{Assertion: fmt.Sprintf("version %s %s", op, want), Passed: ok}
+	if ok {
+		result.Message = fmt.Sprintf("installed version %s satisfies %s %s", config.Version, op, want)
+	} else {
+		result.Message = fmt.Sprintf("installed version %s does not satisfy %s %s", config.Version, op, want)
+	}
+	return reportAssertions(cmd, []assertionResult{result})
+}
+
+func runAssertNoModified(cmd *cobra.Command, args []string) error {
+	config, cwd, err := loadAssertConfig(cmd)
+	if err != nil {
+		return err
+	}
+	downloader, err := core.NewDownloader()
+	if err != nil {
+		return fmt.Errorf("failed to initialize: %w", err)
+	}
+	tmpl, err := downloader.ResolveTemplate(config.Version, config.Overlay)
+	if err != nil {
+		return fmt.Errorf("failed to get template for v%s: %w", config.Version, err)
+	}
+
+	paths := core.MergeOverlayPaths(core.GetComponentPaths(
+		config.Installed.Languages, config.Installed.Frameworks, config.Installed.Workflows,
+	), tmpl)
+	modified, err := core.FindModifiedManagedFiles(cwd, tmpl.Path, paths)
+	if err != nil {
+		return err
+	}
+	result := assertionResult{Assertion: "no-modified-managed-files", Passed: len(modified) == 0}
+	if result.Passed {
+		result.Message = fmt.Sprintf("managed files match v%s", config.Version)
+	} else {
+		result.Message = fmt.Sprintf("%d managed files differ from v%s: %s",
+			len(modified), config.Version, strings.Join(modified, ", "))
+	}
+	return reportAssertions(cmd, []assertionResult{result})
+}
+
+// reportAssertions prints the results and returns an assertion-failed
+// error naming the first failure, if any.
+func reportAssertions(cmd *cobra.Command, results []assertionResult) error {
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		for _, r := range results {
+			if r.Passed {
+				ui.SuccessItem(0, "%s", r.Message)
+			} else {
+				ui.ErrorItem(0, "%s", r.Message)
+			}
+		}
+	}
+
+	for _, r := range results {
+		if !r.Passed {
+			return withExitCode(exitAssertionFailed, fmt.Errorf("assertion failed: %s", r.Assertion))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// setupAssertProject creates a project with samuel.yaml at version 2.0.0
+// and the go-guide skill installed, and changes into it.
+func setupAssertProject(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	config := core.NewConfig("2.0.0")
+	config.AddSkill("go-guide")
+	config.AddSkill("missing-files")
+	if err := config.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := filepath.Join(dir, ".claude", "skills", "go-guide", "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(skillMD), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(skillMD, []byte("---\nname: go-guide\n---\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(oldDir) })
+}
+
+func newAssertTestCmd(run func(*cobra.Command, []string) error) *cobra.Command {
+	cmd := &cobra.Command{RunE: run}
+	cmd.Flags().Bool("json", false, "")
+	return cmd
+}
+
+func TestAssertCommands(t *testing.T) {
+	tests := []struct {
+		name     string
+		run      func(*cobra.Command, []string) error
+		args     []string
+		noConfig bool
+		wantCode int
+	}{
+		{name: "skill_installed", run: runAssertSkillInstalled, args: []string{"go-guide"}},
+		{name: "skill_not_in_config", run: runAssertSkillInstalled, args: []string{"go-guide", "rust-guide"}, wantCode: exitAssertionFailed},
+		{name: "skill_files_missing", run: runAssertSkillInstalled, args: []string{"missing-files"}, wantCode: exitAssertionFailed},
+		{name: "version_satisfied", run: runAssertVersion, args: []string{">= 1.4"}},
+		{name: "version_split_args", run: runAssertVersion, args: []string{"<", "3"}},
+		{name: "version_not_satisfied", run: runAssertVersion, args: []string{">=", "2.1"}, wantCode: exitAssertionFailed},
+		{name: "version_bad_operator", run: runAssertVersion, args: []string{"~> 2"}, wantCode: exitInvalidArgs},
+		{name: "no_config", run: runAssertSkillInstalled, args: []string{"go-guide"}, noConfig: true, wantCode: exitConfigError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupAssertProject(t)
+			if tt.noConfig {
+				if err := os.Remove(core.ConfigFileName); err != nil {
+					t.Fatal(err)
+				}
+			}
+			cmd := newAssertTestCmd(tt.run)
+			err := cmd.RunE(cmd, tt.args)
+			if tt.wantCode == 0 {
+				if err != nil {
+					t.Fatalf("expected pass, got: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := ExitCode(err); got != tt.wantCode {
+				t.Errorf("ExitCode = %d, want %d (err: %v)", got, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestAssertArgs(t *testing.T) {
+	err := assertArgs(cobra.NoArgs)(&cobra.Command{Use: "x"}, []string{"extra"})
+	if ExitCode(err) != exitInvalidArgs {
+		t.Errorf("ExitCode = %d, want %d", ExitCode(err), exitInvalidArgs)
+	}
+	if err := assertArgs(cobra.NoArgs)(&cobra.Command{Use: "x"}, nil); err != nil {
+		t.Errorf("expected nil for valid args, got %v", err)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	if got := ExitCode(errors.New("plain")); got != exitGeneral {
+		t.Errorf("ExitCode(plain) = %d, want %d", got, exitGeneral)
+	}
+	wrapped := errors.Join(errors.New("context"), withExitCode(exitAssertionFailed, errors.New("failed")))
+	if got := ExitCode(wrapped); got != exitAssertionFailed {
+		t.Errorf("ExitCode(wrapped) = %d, want %d", got, exitAssertionFailed)
+	}
+	if withExitCode(exitConfigError, nil) != nil {
+		t.Error("withExitCode(nil) should be nil")
+	}
+}
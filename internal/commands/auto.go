@@ -18,8 +18,14 @@ Subcommands:
   convert   Convert markdown PRD/tasks to prd.json
   status    Show loop progress and current state
   start     Begin or resume the autonomous loop
+  next      Show the task the loop would pick next
   pilot     Fully autonomous discover-and-implement loop (zero setup)
-  task      Manage individual tasks (list, complete, skip, reset, add)
+  sandbox   Manage sandbox containers (prune strays from interrupted runs)
+  task      Manage individual tasks (list, complete, skip, reset, add, import)
+  renumber  Normalize task IDs after heavy editing
+  reconstruct Rebuild a lost prd.json from progress.md and git history
+  health    Check the heartbeat of a running loop
+  bench     Compare AI tools on the same tasks
 
 Workflow:
   1. samuel auto init --prd .claude/tasks/0001-prd-feature.md
@@ -33,6 +39,7 @@ Examples:
   samuel auto convert .claude/tasks/0001-prd-auth.md
   samuel auto status
   samuel auto start --iterations 20
+  samuel auto next --explain
   samuel auto task list
   samuel auto task complete 1.1`,
 }
@@ -49,6 +56,14 @@ Creates .claude/auto/ with:
 
 If --prd is provided, converts the PRD and associated task file to prd.json.
 
+Before initializing, verifies the project is a git repository with at least
+one commit and a configured user.name/user.email (the agent commits each
+task), and offers to set up whatever is missing.
+
+Without --ai-tool, the AI tool is inferred from the agent CLIs on PATH and
+their configured keys (e.g. ANTHROPIC_API_KEY, OPENAI_API_KEY), falling
+back to claude when none is found.
+
 Examples:
   samuel auto init
   samuel auto init --prd .claude/tasks/0001-prd-auth.md
@@ -77,8 +92,16 @@ var autoStatusCmd = &cobra.Command{
 	Long: `Display the current state of the autonomous loop including
 task progress, iteration count, and recent activity.
 
+Use --milestone or --label to scope progress to a subset of tasks.
+Per-milestone completion is shown when tasks declare a milestone.
+
+Use --detailed to also list the files each recent iteration added,
+modified, and deleted, to spot agents that touch unrelated files early.
+
 Examples:
-  samuel auto status`,
+  samuel auto status
+  samuel auto status --milestone M1
+  samuel auto status --detailed`,
 	RunE: runAutoStatus,
 }
 
@@ -90,9 +113,20 @@ var autoStartCmd = &cobra.Command{
 The loop runs natively in Go, invoking the configured AI tool on each
 iteration until all tasks are completed or the max iteration count is reached.
 
+With --duration, the run is time-boxed: when the budget is nearly exhausted,
+a final wrap-up iteration asks the agent to commit work in progress, update
+progress.md, and leave the workspace clean before the loop stops.
+
+With --report (or config.report in prd.json), a digest summarizing the run
+(tasks done, failures, diff stats, agent time) is written to a file and/or
+emailed over SMTP when the loop finishes.
+
 Examples:
   samuel auto start
   samuel auto start --iterations 20
+  samuel auto start --duration 2h
+  samuel auto start --milestone M1
+  samuel auto start --yes --report .claude/auto/digest.md
   samuel auto start --dry-run
   samuel auto start --yes`,
 	RunE: runAutoStart,
@@ -109,19 +143,30 @@ Subcommands:
   skip      Mark a task as skipped
   reset     Reset a task to pending
   add       Add a new task
+  import    Import tasks from a CSV or JSON export
+  note      Add a note or attachment to a task
 
 Examples:
   samuel auto task list
   samuel auto task complete 1.1
   samuel auto task skip 2.3
   samuel auto task reset 1.1
-  samuel auto task add "3.0" "New parent task"`,
+  samuel auto task add "3.0" "New parent task"
+  samuel auto task add --parent 3 "New subtask"
+  samuel auto task import --from-csv backlog.csv
+  samuel auto task note 2.1 "Blocked on API credentials"`,
 }
 
 var autoTaskListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all tasks with status",
-	RunE:  runAutoTaskList,
+	Long: `List tasks with their status, milestone, and labels.
+
+Examples:
+  samuel auto task list
+  samuel auto task list --milestone M1
+  samuel auto task list --label api --label db`,
+	RunE: runAutoTaskList,
 }
 
 var autoTaskCompleteCmd = &cobra.Command{
@@ -146,10 +191,21 @@ var autoTaskResetCmd = &cobra.Command{
 }
 
 var autoTaskAddCmd = &cobra.Command{
-	Use:   "add <task-id> <title>",
+	Use:   "add [task-id] <title>",
 	Short: "Add a new task",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runAutoTaskAdd,
+	Long: `Add a task to prd.json.
+
+Task IDs are dotted numbers (3, 3.1, 3.1.2). Without a task ID, the next free
+one is generated: the next top-level ID, or with --parent the next child ID
+(--parent 3 gives 3.1, then 3.2, ...). An explicit ID must be unused and,
+with --parent, a direct child of that parent.
+
+Examples:
+  samuel auto task add "Add rate limiting"
+  samuel auto task add --parent 3 "Write integration tests"
+  samuel auto task add 4.2 "Document the API"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAutoTaskAdd,
 }
 
 func init() {
@@ -160,25 +216,48 @@ func init() {
 	autoCmd.AddCommand(autoStartCmd)
 	autoCmd.AddCommand(autoTaskCmd)
 	registerPilotCmd()
+	registerNextCmd()
+	registerReadinessCmd()
+	registerSandboxCmd()
+	registerSyncCmd()
+	registerRenumberCmd()
+	registerReconstructCmd()
+	registerHealthCmd()
+	registerBenchCmd()
+	registerContextCmd()
 	autoTaskCmd.AddCommand(autoTaskListCmd)
 	autoTaskCmd.AddCommand(autoTaskCompleteCmd)
 	autoTaskCmd.AddCommand(autoTaskSkipCmd)
 	autoTaskCmd.AddCommand(autoTaskResetCmd)
 	autoTaskCmd.AddCommand(autoTaskAddCmd)
+	registerTaskImportCmd()
+	registerTaskNoteCmd()
+	addTaskFilterFlags(autoTaskListCmd)
+	addTaskFilterFlags(autoStatusCmd)
+	autoStatusCmd.Flags().Bool("detailed", false, "Show per-iteration file changes from the event log")
+	autoTaskAddCmd.Flags().String("milestone", "", "Milestone the task belongs to")
+	autoTaskAddCmd.Flags().StringSlice("label", nil, "Label to attach to the task (repeatable)")
+	autoTaskAddCmd.Flags().String("parent", "", "Parent task ID; the new task gets the next child ID")
 
 	// init flags
 	autoInitCmd.Flags().String("prd", "", "Path to PRD markdown file to convert")
-	autoInitCmd.Flags().String("ai-tool", "claude", "AI tool to use (claude, amp, cursor, codex)")
+	autoInitCmd.Flags().String("ai-tool", "", "AI tool to use (claude, amp, cursor, codex; default: detected from installed CLIs and keys)")
 	autoInitCmd.Flags().Int("max-iterations", 50, "Maximum loop iterations")
-	autoInitCmd.Flags().String("sandbox", "none", "Sandbox mode (none, docker, docker-sandbox)")
+	autoInitCmd.Flags().String("sandbox", "none", "Sandbox mode (none, docker, docker-sandbox, process)")
 	autoInitCmd.Flags().String("sandbox-image", "", "Docker image for docker mode (default: node:lts)")
 	autoInitCmd.Flags().String("sandbox-template", "", "Docker sandbox template (e.g., python:3-alpine)")
+	autoInitCmd.Flags().Bool("sandbox-offline", false, "Block the agent's network access in process mode")
+	autoInitCmd.Flags().String("scoring", "", "Task scoring strategy (priority, wsjf)")
+	autoInitCmd.Flags().Bool("skip-git-check", false, "Skip verifying the git repository, identity, and initial commit")
 
 	// start flags
 	autoStartCmd.Flags().Int("iterations", 0, "Override max iterations for this run")
-	autoStartCmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
 	autoStartCmd.Flags().Bool("dry-run", false, "Show what would happen without executing")
-	autoStartCmd.Flags().String("sandbox", "", "Override sandbox mode for this run (none, docker, docker-sandbox)")
+	autoStartCmd.Flags().String("milestone", "", "Restrict the loop to tasks in this milestone")
+	autoStartCmd.Flags().String("report", "", "Write a run digest to this file when the loop finishes")
+	autoStartCmd.Flags().Duration("duration", 0, "Time budget for this run (e.g., 90m, 2h); ends with a wrap-up iteration")
+	autoStartCmd.Flags().String("sandbox", "", "Override sandbox mode for this run (none, docker, docker-sandbox, process)")
 	autoStartCmd.Flags().String("sandbox-image", "", "Override Docker image for docker mode")
 	autoStartCmd.Flags().String("sandbox-template", "", "Override Docker sandbox template for this run")
+	autoStartCmd.Flags().Bool("sandbox-offline", false, "Block the agent's network access in process mode for this run")
 }
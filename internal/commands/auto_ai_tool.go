@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// resolveAITool returns the --ai-tool value when given, warning if that
+// CLI is not installed; then the user's preferred tool when set; otherwise
+// it infers the tool from the installed agent CLIs and configured keys and
+// explains the choice.
+func resolveAITool(cmd *cobra.Command, preferred string) string {
+	statuses := core.DetectAITools()
+
+	if cmd != nil && cmd.Flags().Changed("ai-tool") {
+		tool, _ := cmd.Flags().GetString("ai-tool")
+		status := core.FindAIToolStatus(statuses, strings.ToLower(tool))
+		if core.IsValidAITool(tool) && !status.Installed() {
+			ui.Warn("%s was not found in PATH; install it before running the loop outside a sandbox", tool)
+		}
+		return tool
+	}
+	if preferred != "" {
+		ui.Info("Using AI tool %s from %s. Override with --ai-tool", preferred, userAutoSettingsLabel)
+		return preferred
+	}
+
+	tool, reason, found := core.InferAITool(statuses)
+	if found {
+		ui.Info("Using AI tool %s: %s. Override with --ai-tool", tool, reason)
+	} else {
+		ui.Warn("Defaulting to AI tool %s: %s", tool, reason)
+		ui.Info("Install an agent CLI or pass --ai-tool before running 'samuel auto start'")
+	}
+	return tool
+}
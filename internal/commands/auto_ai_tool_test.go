@@ -0,0 +1,27 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveAITool(t *testing.T) {
+	t.Run("explicit_flag_wins", func(t *testing.T) {
+		cmd := &cobra.Command{Use: "init"}
+		cmd.Flags().String("ai-tool", "", "")
+		if err := cmd.Flags().Set("ai-tool", "amp"); err != nil {
+			t.Fatal(err)
+		}
+		if got := resolveAITool(cmd, ""); got != "amp" {
+			t.Errorf("resolveAITool() = %q, want amp", got)
+		}
+	})
+
+	t.Run("inferred_tool_is_supported", func(t *testing.T) {
+		if got := resolveAITool(nil, ""); !core.IsValidAITool(got) {
+			t.Errorf("resolveAITool() = %q, want a supported tool", got)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoBenchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Compare AI tools on the same tasks",
+	Long: `Run the same small task set with each AI tool and compare the results.
+
+The first --tasks pending tasks of prd.json are copied into a fresh git
+worktree per tool, checked out at HEAD, and the loop runs there with that
+tool for up to --iterations iterations. Tools run one after another; the
+project's own prd.json and working tree are not touched. Uncommitted
+changes are not part of the benchmark.
+
+For each tool the comparison shows tasks completed, iterations used,
+duration, and how many of the configured quality checks pass in the
+worktree afterwards.
+
+Examples:
+  samuel auto bench --tools claude,codex --tasks 5
+  samuel auto bench --tools claude,amp --tasks 3 --iterations 10 --keep
+  samuel auto bench --json`,
+	Args: cobra.NoArgs,
+	RunE: runAutoBench,
+}
+
+func registerBenchCmd() {
+	autoCmd.AddCommand(autoBenchCmd)
+	autoBenchCmd.Flags().StringSlice("tools", nil, "AI tools to compare (default: every installed tool)")
+	autoBenchCmd.Flags().Int("tasks", 5, "Number of pending tasks to run")
+	autoBenchCmd.Flags().Int("iterations", 0, "Maximum iterations per tool (default: twice the task count)")
+	autoBenchCmd.Flags().Bool("keep", false, "Keep each tool's worktree for inspection")
+	autoBenchCmd.Flags().Bool("json", false, "Print the results as JSON")
+}
+
+func runAutoBench(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	store.Close()
+
+	cfg, err := benchConfigFromFlags(cmd, cwd, prd)
+	if err != nil {
+		return err
+	}
+
+	ui.Info("Benchmarking %v on %d task(s), up to %d iterations each", cfg.Tools, cfg.Tasks, cfg.MaxIterations)
+	confirmed, confirmErr := approve(promptAutoBench, "Run the agents now?", false)
+	if confirmErr != nil || !confirmed {
+		ui.Info("Cancelled")
+		return nil
+	}
+
+	results, err := core.RunBench(cfg)
+	if err != nil {
+		return err
+	}
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode results: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+	printBenchResults(results)
+	return nil
+}
+
+// benchConfigFromFlags reads and validates the bench flags. Without
+// --tools, every installed agent CLI is compared.
+func benchConfigFromFlags(cmd *cobra.Command, cwd string, prd *core.AutoPRD) (core.BenchConfig, error) {
+	tools, _ := cmd.Flags().GetStringSlice("tools")
+	tasks, _ := cmd.Flags().GetInt("tasks")
+	iterations, _ := cmd.Flags().GetInt("iterations")
+	keep, _ := cmd.Flags().GetBool("keep")
+
+	if len(tools) == 0 {
+		for _, status := range core.DetectAITools() {
+			if status.Installed() {
+				tools = append(tools, status.Tool)
+			}
+		}
+		if len(tools) == 0 {
+			return core.BenchConfig{}, fmt.Errorf("no AI tool CLI found on PATH; pass --tools")
+		}
+	}
+	for _, tool := range tools {
+		if !core.IsValidAITool(tool) {
+			return core.BenchConfig{}, fmt.Errorf("invalid AI tool: %s (supported: %v)", tool, core.GetSupportedAITools())
+		}
+	}
+	if tasks < 1 {
+		return core.BenchConfig{}, fmt.Errorf("invalid task count: %d (must be at least 1)", tasks)
+	}
+	if iterations <= 0 {
+		iterations = 2 * tasks
+	}
+
+	return core.BenchConfig{
+		ProjectDir: cwd, PRD: prd, Tools: tools, Tasks: tasks, MaxIterations: iterations, Keep: keep,
+		Configure: func(tool string, cfg *core.LoopConfig) {
+			cfg.OnIterStart = func(iter int, _ string) {
+				ui.Info("[%s] Iteration %d of %d", tool, iter, cfg.MaxIterations)
+			}
+			cfg.OnIterEvent = reportIterationEvent
+		},
+	}, nil
+}
+
+// printBenchResults prints the comparison table and any per-tool errors.
+func printBenchResults(results []core.BenchResult) {
+	ui.Section("Benchmark results")
+	ui.Print("  %-8s %-12s %-10s %-10s %s", "Tool", "Completed", "Iterations", "Duration", "Checks")
+	for _, r := range results {
+		ui.Print("  %-8s %-12s %-10d %-10s %s", r.Tool,
+			fmt.Sprintf("%d/%d %3.0f%%", r.TasksCompleted, r.TasksTotal, 100*r.CompletionRate()),
+			r.Iterations, r.Duration.Round(time.Second),
+			fmt.Sprintf("%d/%d", r.ChecksPassed, r.ChecksTotal))
+	}
+	for _, r := range results {
+		if r.Error != "" {
+			ui.WarnItem(1, "%s: %s", r.Tool, r.Error)
+		}
+		if len(r.FailedChecks) > 0 {
+			ui.WarnItem(1, "%s: failed checks: %v", r.Tool, r.FailedChecks)
+		}
+		if r.Worktree != "" {
+			ui.Dim("  %s worktree kept at %s", r.Tool, r.Worktree)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestBenchConfigFromFlags(t *testing.T) {
+	tests := []struct {
+		name           string
+		tools          []string
+		tasks          int
+		iterations     int
+		wantTools      []string
+		wantIterations int
+		wantErr        bool
+	}{
+		{name: "defaults iterations to twice the tasks", tools: []string{"claude", "codex"}, tasks: 3, wantTools: []string{"claude", "codex"}, wantIterations: 6},
+		{name: "explicit iterations", tools: []string{"amp"}, tasks: 2, iterations: 9, wantTools: []string{"amp"}, wantIterations: 9},
+		{name: "invalid tool", tools: []string{"gpt"}, tasks: 1, wantErr: true},
+		{name: "no tasks", tools: []string{"claude"}, tasks: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().StringSlice("tools", tt.tools, "")
+			cmd.Flags().Int("tasks", tt.tasks, "")
+			cmd.Flags().Int("iterations", tt.iterations, "")
+			cmd.Flags().Bool("keep", false, "")
+
+			cfg, err := benchConfigFromFlags(cmd, t.TempDir(), core.NewAutoPRD("test", ""))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("benchConfigFromFlags() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(cfg.Tools, tt.wantTools) || cfg.MaxIterations != tt.wantIterations {
+				t.Errorf("tools = %v, iterations = %d, want %v, %d", cfg.Tools, cfg.MaxIterations, tt.wantTools, tt.wantIterations)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoContextCmd = &cobra.Command{
+	Use:   "context",
+	Short: "Manage the context files the agent reads every iteration",
+	Long: `Register files the agent reads at the start of every iteration, such as
+architecture docs and API specs. They are stored in prd.json under
+config.context_files and listed in prompt.md, which is regenerated on
+every change, instead of being hand-edited into prompt.md and lost when it
+is regenerated.
+
+Subcommands:
+  add       Register files, directories or globs
+  list      Show the registered paths and the files they match
+  remove    Unregister paths`,
+}
+
+var autoContextAddCmd = &cobra.Command{
+	Use:   "add <path>...",
+	Short: "Register context files, directories or globs",
+	Long: `Register context paths relative to the project root. A directory stands
+for every file below it, and a glob may use "**" for any number of
+directories. Each path must match at least one file. Globs are listed in
+prompt.md as given, so files created later are read too.
+
+The agent reads every context file on every iteration, so a warning is
+shown once they add up to more than 64 KB.
+
+Examples:
+  samuel auto context add docs/architecture.md
+  samuel auto context add api/openapi.yaml "docs/adr/*.md"
+  samuel auto context add "docs/**/design-*.md"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runAutoContextAdd,
+}
+
+var autoContextListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show the registered context paths",
+	Args:  cobra.NoArgs,
+	RunE:  runAutoContextList,
+}
+
+var autoContextRemoveCmd = &cobra.Command{
+	Use:   "remove <path>...",
+	Short: "Unregister context paths",
+	Args:  cobra.MinimumNArgs(1),
+	RunE:  runAutoContextRemove,
+}
+
+func registerContextCmd() {
+	autoCmd.AddCommand(autoContextCmd)
+	autoContextCmd.AddCommand(autoContextAddCmd)
+	autoContextCmd.AddCommand(autoContextListCmd)
+	autoContextCmd.AddCommand(autoContextRemoveCmd)
+}
+
+func runAutoContextAdd(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	added, err := prd.Config.AddContextFiles(cwd, args)
+	if err != nil {
+		return err
+	}
+	if len(added) == 0 {
+		ui.Info("Already registered")
+		return nil
+	}
+	if err := saveAutoContext(cwd, store, prd); err != nil {
+		return err
+	}
+	ui.Success("Registered %d context path(s)", len(added))
+	for _, m := range added {
+		ui.SuccessItem(1, "%s (%s)", m.Pattern, describeContextMatch(m))
+	}
+	if matches, err := prd.Config.ResolveContextFiles(cwd); err == nil {
+		if total := core.ContextBytes(matches); total > core.ContextSizeWarnBytes {
+			ui.Warn("Context files add up to %s (~%d tokens), read on every iteration; consider registering summaries instead",
+				formatFileSize(total), total/4)
+		}
+	}
+	return nil
+}
+
+func runAutoContextList(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	store.Close()
+
+	if len(prd.Config.ContextFiles) == 0 {
+		ui.Info("No context files. Register some with 'samuel auto context add <path>'")
+		return nil
+	}
+	matches, err := prd.Config.ResolveContextFiles(cwd)
+	if err != nil {
+		return err
+	}
+	ui.Section("Context files")
+	for _, m := range matches {
+		if len(m.Files) == 0 {
+			ui.WarnItem(1, "%s: no files match", m.Pattern)
+			continue
+		}
+		ui.ListItem(1, "%s (%s)", m.Pattern, describeContextMatch(m))
+	}
+	total := core.ContextBytes(matches)
+	ui.Dim("  %s in total, ~%d tokens per iteration", formatFileSize(total), total/4)
+	return nil
+}
+
+func runAutoContextRemove(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	if err := prd.Config.RemoveContextFiles(args); err != nil {
+		return err
+	}
+	if err := saveAutoContext(cwd, store, prd); err != nil {
+		return err
+	}
+	ui.Success("Unregistered %d context path(s)", len(args))
+	return nil
+}
+
+// saveAutoContext saves the PRD and regenerates the prompts that list
+// the context files.
+func saveAutoContext(cwd string, store core.AutoStore, prd *core.AutoPRD) error {
+	if err := store.SavePRD(prd); err != nil {
+		return fmt.Errorf("failed to save prd.json: %w", err)
+	}
+	promptFile := prd.Config.PromptFile
+	if promptFile == "" {
+		promptFile = filepath.Join(core.AutoDir, core.AutoPromptFile)
+	}
+	if err := os.WriteFile(filepath.Join(cwd, promptFile), []byte(core.GeneratePromptFile(prd.Config)), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", promptFile, err)
+	}
+	if prd.Config.DiscoveryPrompt != "" {
+		content := core.GenerateDiscoveryPrompt(prd.Config, prd.Config.PilotConfig)
+		if err := os.WriteFile(filepath.Join(cwd, prd.Config.DiscoveryPrompt), []byte(content), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", prd.Config.DiscoveryPrompt, err)
+		}
+	}
+	ui.Info("Regenerated %s", promptFile)
+	return nil
+}
+
+func describeContextMatch(m core.ContextMatch) string {
+	if len(m.Files) == 1 && m.Files[0].Path == m.Pattern {
+		return formatFileSize(m.Files[0].Size)
+	}
+	return fmt.Sprintf("%d files, %s", len(m.Files), formatFileSize(core.ContextBytes([]core.ContextMatch{m})))
+}
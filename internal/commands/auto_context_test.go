@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestAutoContextCommands(t *testing.T) {
+	dir, prdPath := setupTestPRD(t, nil)
+	if err := os.MkdirAll(filepath.Join(dir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "docs", "architecture.md"), []byte("# Architecture\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	origDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	if err := runAutoContextAdd(&cobra.Command{}, []string{"docs/missing.md"}); err == nil {
+		t.Error("adding a missing file succeeded")
+	}
+	if err := runAutoContextAdd(&cobra.Command{}, []string{"docs/architecture.md"}); err != nil {
+		t.Fatal(err)
+	}
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(prd.Config.ContextFiles, []string{"docs/architecture.md"}) {
+		t.Errorf("context_files = %v", prd.Config.ContextFiles)
+	}
+	prompt, err := os.ReadFile(filepath.Join(dir, core.AutoDir, core.AutoPromptFile))
+	if err != nil || !strings.Contains(string(prompt), "- `docs/architecture.md`") {
+		t.Errorf("prompt.md does not list the context file: %v", err)
+	}
+	if err := runAutoContextList(&cobra.Command{}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runAutoContextRemove(&cobra.Command{}, []string{"docs/architecture.md"}); err != nil {
+		t.Fatal(err)
+	}
+	prompt, _ = os.ReadFile(filepath.Join(dir, core.AutoDir, core.AutoPromptFile))
+	if strings.Contains(string(prompt), "Context Files") {
+		t.Error("prompt.md still lists context files after removing them")
+	}
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// autoInitCommitMessage is used when samuel creates the root commit.
+const autoInitCommitMessage = "chore: initial commit"
+
+// gitInput prompts for text; tests replace it to simulate user answers.
+var gitInput = ui.Input
+
+// ensureGitReady verifies the project can take agent commits: a git repo
+// with a configured identity and at least one commit. Missing pieces are
+// offered interactively; declining returns an error explaining the fix.
+func ensureGitReady(cmd *cobra.Command, cwd string) error {
+	if skip, _ := cmd.Flags().GetBool("skip-git-check"); skip {
+		return nil
+	}
+
+	state := core.InspectGitState(cwd)
+	if len(state.Problems()) == 0 {
+		return nil
+	}
+
+	ui.Warn("Git is not ready for autonomous commits:")
+	for _, problem := range state.Problems() {
+		ui.WarnItem(1, "%s", problem)
+	}
+
+	if !state.IsRepo {
+		if err := offerGitFix(promptGitInit, "Initialize a git repository here?", "git init",
+			func() error { return core.GitInit(cwd) }); err != nil {
+			return err
+		}
+		state = core.InspectGitState(cwd)
+	}
+	if !state.HasUserIdentity() {
+		if err := configureGitIdentity(cwd, state); err != nil {
+			return err
+		}
+	}
+	if !state.HasCommits {
+		if err := offerGitFix(promptGitCommit, "Create an empty initial commit?",
+			fmt.Sprintf("git commit --allow-empty -m %q", autoInitCommitMessage),
+			func() error { return core.GitInitialCommit(cwd, autoInitCommitMessage) }); err != nil {
+			return err
+		}
+	}
+
+	ui.Success("Git repository ready")
+	return nil
+}
+
+// offerGitFix asks before running fix; declining yields an error that
+// names the manual command.
+func offerGitFix(prompt, question, manual string, fix func() error) error {
+	confirmed, err := approve(prompt, question, true)
+	if err != nil || !confirmed {
+		return fmt.Errorf("git setup incomplete. Run '%s' or pass --skip-git-check", manual)
+	}
+	return fix()
+}
+
+// configureGitIdentity prompts for missing user.name/user.email and stores
+// them in the repository's local config.
+func configureGitIdentity(cwd string, state core.GitState) error {
+	manual := "git config user.name <name> && git config user.email <email>"
+	confirmed, err := approve(promptGitIdentity, "Configure git user.name and user.email for this repository?", true)
+	if err != nil || !confirmed {
+		return fmt.Errorf("git setup incomplete. Run '%s' or pass --skip-git-check", manual)
+	}
+
+	name, err := gitInput("Git user.name", state.UserName, requireNonEmpty)
+	if err != nil {
+		return fmt.Errorf("git setup cancelled: %w", err)
+	}
+	email, err := gitInput("Git user.email", state.UserEmail, requireNonEmpty)
+	if err != nil {
+		return fmt.Errorf("git setup cancelled: %w", err)
+	}
+	return core.GitSetUserIdentity(cwd, strings.TrimSpace(name), strings.TrimSpace(email))
+}
+
+func requireNonEmpty(s string) error {
+	if strings.TrimSpace(s) == "" {
+		return fmt.Errorf("value is required")
+	}
+	return nil
+}
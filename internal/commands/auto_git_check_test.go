@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// stubGitPrompts answers every confirm with confirm and every input with
+// the given value, restoring the real prompts afterwards.
+func stubGitPrompts(t *testing.T, confirm bool, input string) {
+	t.Helper()
+	origConfirm, origInput := confirmPrompt, gitInput
+	t.Cleanup(func() { confirmPrompt, gitInput = origConfirm, origInput })
+
+	confirmPrompt = func(string, bool) (bool, error) { return confirm, nil }
+	gitInput = func(string, string, func(string) error) (string, error) { return input, nil }
+}
+
+func newGitCheckCmd(skip bool) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("skip-git-check", skip, "")
+	return cmd
+}
+
+func setupGitCheckEnv(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+	return t.TempDir()
+}
+
+func TestEnsureGitReady_AcceptsFixes(t *testing.T) {
+	dir := setupGitCheckEnv(t)
+	stubGitPrompts(t, true, "dev@example.com")
+
+	if err := ensureGitReady(newGitCheckCmd(false), dir); err != nil {
+		t.Fatalf("ensureGitReady returned error: %v", err)
+	}
+
+	state := core.InspectGitState(dir)
+	if problems := state.Problems(); len(problems) != 0 {
+		t.Errorf("expected ready repo, got problems %v", problems)
+	}
+}
+
+func TestEnsureGitReady_Declined(t *testing.T) {
+	dir := setupGitCheckEnv(t)
+	stubGitPrompts(t, false, "")
+
+	err := ensureGitReady(newGitCheckCmd(false), dir)
+	if err == nil || !strings.Contains(err.Error(), "git init") {
+		t.Errorf("expected error naming 'git init', got %v", err)
+	}
+	if core.InspectGitState(dir).IsRepo {
+		t.Error("declining should not initialize a repository")
+	}
+}
+
+func TestEnsureGitReady_Skip(t *testing.T) {
+	dir := setupGitCheckEnv(t)
+	stubGitPrompts(t, false, "")
+
+	if err := ensureGitReady(newGitCheckCmd(true), dir); err != nil {
+		t.Errorf("--skip-git-check should bypass the check, got %v", err)
+	}
+}
+
+func TestRequireNonEmpty(t *testing.T) {
+	if requireNonEmpty("  ") == nil {
+		t.Error("expected error for blank value")
+	}
+	if requireNonEmpty("Dev") != nil {
+		t.Error("expected no error for non-empty value")
+	}
+}
@@ -11,21 +11,30 @@ import (
 )
 
 func runAutoInit(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	if !core.ConfigExists(cwd) {
 		return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
 	}
 
-	aiTool, _ := cmd.Flags().GetString("ai-tool")
-	maxIter, _ := cmd.Flags().GetInt("max-iterations")
+	if err := ensureGitReady(cmd, cwd); err != nil {
+		return err
+	}
+
+	user, err := core.LoadUserAutoSettings()
+	if err != nil {
+		return err
+	}
+	aiTool := resolveAITool(cmd, user.AITool)
+	maxIter := flagOrUserInt(cmd, "max-iterations", user.MaxIterations)
 	prdPath, _ := cmd.Flags().GetString("prd")
-	sandbox, _ := cmd.Flags().GetString("sandbox")
-	sandboxImage, _ := cmd.Flags().GetString("sandbox-image")
-	sandboxTemplate, _ := cmd.Flags().GetString("sandbox-template")
+	sandbox := flagOrUserString(cmd, "sandbox", user.Sandbox)
+	sandboxImage := flagOrUserString(cmd, "sandbox-image", user.SandboxImage)
+	sandboxTemplate := flagOrUserString(cmd, "sandbox-template", user.SandboxTemplate)
+	scoring := flagOrUserString(cmd, "scoring", user.ScoringStrategy)
 
 	if !core.IsValidAITool(aiTool) {
 		return fmt.Errorf("unsupported AI tool: %s (supported: %v)", aiTool, core.GetSupportedAITools())
@@ -35,13 +44,8 @@ func runAutoInit(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("unsupported sandbox mode: %s (supported: %v)", sandbox, core.GetSupportedSandboxModes())
 	}
 
-	return initAutoDir(cwd, prdPath, aiTool, maxIter, sandbox, sandboxImage, sandboxTemplate)
-}
-
-func initAutoDir(cwd, prdPath, aiTool string, maxIter int, sandbox, sandboxImage, sandboxTemplate string) error {
-	autoDir := core.GetAutoDir(cwd)
-	if err := os.MkdirAll(autoDir, 0755); err != nil {
-		return fmt.Errorf("failed to create auto directory: %w", err)
+	if !core.IsValidScoringStrategy(scoring) {
+		return fmt.Errorf("unsupported scoring strategy: %s (supported: %v)", scoring, core.GetSupportedScoringStrategies())
 	}
 
 	config := core.AutoConfig{
@@ -52,12 +56,27 @@ func initAutoDir(cwd, prdPath, aiTool string, maxIter int, sandbox, sandboxImage
 		Sandbox:         sandbox,
 		SandboxImage:    sandboxImage,
 		SandboxTemplate: sandboxTemplate,
+		ScoringStrategy: scoring,
+	}
+	config.SandboxOffline, _ = cmd.Flags().GetBool("sandbox-offline")
+
+	return initAutoDir(cwd, prdPath, config)
+}
+
+func initAutoDir(cwd, prdPath string, config core.AutoConfig) error {
+	autoDir := core.GetAutoDir(cwd)
+	if err := os.MkdirAll(autoDir, 0755); err != nil {
+		return fmt.Errorf("failed to create auto directory: %w", err)
 	}
 
 	if err := writeAutoFiles(autoDir, config); err != nil {
 		return err
 	}
 
+	if _, err := core.WriteProjectStats(cwd); err != nil {
+		ui.Warn("Could not generate project stats: %v", err)
+	}
+
 	if prdPath != "" {
 		if err := convertAndSavePRD(cwd, prdPath); err != nil {
 			return err
@@ -99,6 +118,7 @@ func printInitSummary(autoDir, prdPath string) {
 	ui.Print("    %s", filepath.Join(core.AutoDir, core.AutoPRDFile))
 	ui.Print("    %s", filepath.Join(core.AutoDir, core.AutoProgressFile))
 	ui.Print("    %s", filepath.Join(core.AutoDir, core.AutoPromptFile))
+	ui.Print("    %s", filepath.Join(core.AutoDir, core.AutoContextDir, core.AutoProjectStatsFile))
 	ui.Print("")
 
 	if prdPath != "" {
@@ -133,9 +153,9 @@ func detectQualityChecks(cwd string) []string {
 }
 
 func runAutoConvert(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	return convertAndSavePRD(cwd, args[0])
@@ -173,23 +193,27 @@ func convertAndSavePRD(cwd, prdPath string) error {
 }
 
 func runAutoStatus(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
-	prdPath := core.GetAutoPRDPath(cwd)
-	prd, err := core.LoadAutoPRD(prdPath)
+	store, prd, err := openAutoState(cwd)
 	if err != nil {
-		return fmt.Errorf("no auto loop found. Run 'samuel auto init' first")
+		return err
 	}
+	defer store.Close()
 
 	prd.RecalculateProgress()
-	printStatus(prd)
+	printStatus(prd, taskFilterFromFlags(cmd))
+	printProgressHeader(cwd)
+	if detailed, _ := cmd.Flags().GetBool("detailed"); detailed {
+		printIterationChanges(store)
+	}
 	return nil
 }
 
-func printStatus(prd *core.AutoPRD) {
+func printStatus(prd *core.AutoPRD, filter core.TaskFilter) {
 	ui.Header("Auto Loop Status")
 
 	ui.TableRow("Project", prd.Project.Name)
@@ -198,12 +222,10 @@ func printStatus(prd *core.AutoPRD) {
 	}
 	ui.TableRow("Status", prd.Progress.Status)
 
-	pct := 0
-	if prd.Progress.TotalTasks > 0 {
-		pct = (prd.Progress.CompletedTasks * 100) / prd.Progress.TotalTasks
-	}
-	ui.TableRow("Progress", fmt.Sprintf("%d/%d tasks (%d%%)",
-		prd.Progress.CompletedTasks, prd.Progress.TotalTasks, pct))
+	scoped := &core.AutoPRD{Tasks: prd.FilterTasks(filter)}
+	scoped.RecalculateProgress()
+	ui.TableRow("Progress", formatTaskProgress(
+		scoped.Progress.CompletedTasks, scoped.Progress.TotalTasks))
 	ui.TableRow("AI Tool", prd.Config.AITool)
 	ui.TableRow("Sandbox", prd.Config.Sandbox)
 	if prd.Config.Sandbox == core.SandboxDocker && prd.Config.SandboxImage != "" {
@@ -213,6 +235,9 @@ func printStatus(prd *core.AutoPRD) {
 		ui.TableRow("Sandbox Template", prd.Config.SandboxTemplate)
 	}
 	ui.TableRow("Max Iterations", fmt.Sprintf("%d", prd.Config.MaxIterations))
+	if prd.Config.ScoringStrategy != "" {
+		ui.TableRow("Scoring", prd.Config.ScoringStrategy)
+	}
 
 	if prd.Progress.TotalIterationsRun > 0 {
 		ui.TableRow("Iterations Run", fmt.Sprintf("%d", prd.Progress.TotalIterationsRun))
@@ -222,20 +247,32 @@ func printStatus(prd *core.AutoPRD) {
 	}
 
 	printPilotStatus(prd)
+	if filter.IsEmpty() {
+		printMilestoneProgress(prd)
+	}
 
 	// Count by status
-	counts := countTaskStatuses(prd)
+	counts := countTaskStatuses(scoped)
 	ui.Print("")
 	ui.Print("  Pending: %d  Completed: %d  Blocked: %d  Skipped: %d",
 		counts["pending"], counts["completed"], counts["blocked"], counts["skipped"])
 
-	next := prd.GetNextTask()
+	next := prd.GetNextTaskFor(filter)
 	if next != nil {
 		ui.Print("")
 		ui.Info("Next task: %s %s", next.ID, next.Title)
 	}
 }
 
+// formatTaskProgress renders "completed/total tasks (pct%)".
+func formatTaskProgress(completed, total int) string {
+	pct := 0
+	if total > 0 {
+		pct = (completed * 100) / total
+	}
+	return fmt.Sprintf("%d/%d tasks (%d%%)", completed, total, pct)
+}
+
 func printPilotStatus(prd *core.AutoPRD) {
 	if !prd.Config.PilotMode || prd.Config.PilotConfig == nil {
 		return
@@ -266,6 +303,11 @@ func countTaskStatuses(prd *core.AutoPRD) map[string]int {
 }
 
 func validateSandbox(sandbox string) error {
+	if sandbox == core.SandboxProcess {
+		if err := core.CheckProcessSandboxAvailable(); err != nil {
+			return fmt.Errorf("process sandbox unavailable: %w", err)
+		}
+	}
 	if sandbox == core.SandboxDocker {
 		if err := core.CheckDockerAvailable(); err != nil {
 			return fmt.Errorf("docker sandbox unavailable: %w", err)
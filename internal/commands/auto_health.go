@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check the heartbeat of a running loop",
+	Long: `Check the heartbeat file of the autonomous loop and exit non-zero unless
+the loop is running and refreshed the heartbeat recently.
+
+While 'samuel auto start' or 'samuel auto pilot' runs, it rewrites
+.claude/auto/heartbeat every 30 seconds with its PID, the current
+iteration and a timestamp, also while the agent is working. A heartbeat
+older than --max-age (default: three refresh intervals) means the loop
+process died. Use this command as a liveness probe for systemd, Kubernetes
+or cron watchers, or read the JSON file directly.
+
+Examples:
+  samuel auto health
+  samuel auto health --max-age 5m
+  samuel auto health --json`,
+	Args: cobra.NoArgs,
+	RunE: runAutoHealth,
+}
+
+func registerHealthCmd() {
+	autoCmd.AddCommand(autoHealthCmd)
+	autoHealthCmd.Flags().Duration("max-age", 0, "Heartbeat age after which the loop counts as dead (default: 3 intervals)")
+	autoHealthCmd.Flags().Bool("json", false, "Print the heartbeat as JSON")
+}
+
+func runAutoHealth(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	maxAge, _ := cmd.Flags().GetDuration("max-age")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	hb, err := core.LoadHeartbeat(cwd)
+	if os.IsNotExist(err) {
+		return fmt.Errorf("no heartbeat found; the loop has not run in this project")
+	}
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(hb, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode heartbeat: %w", err)
+		}
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		displayHeartbeat(hb)
+	}
+	return hb.CheckHealth(time.Now(), maxAge)
+}
+
+func displayHeartbeat(hb *core.Heartbeat) {
+	ui.TableRow("Status", hb.Status)
+	ui.TableRow("PID", fmt.Sprintf("%d", hb.PID))
+	ui.TableRow("Iteration", fmt.Sprintf("%d/%d", hb.Iteration, hb.MaxIterations))
+	ui.TableRow("Started", hb.StartedAt)
+	ui.TableRow("Last beat", hb.UpdatedAt)
+	if hb.IterationStartedAt != "" {
+		ui.TableRow("Iteration started", hb.IterationStartedAt)
+	}
+}
@@ -0,0 +1,50 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestRunAutoHealth(t *testing.T) {
+	tests := []struct {
+		name      string
+		heartbeat string
+		wantErr   bool
+	}{
+		{name: "no heartbeat", wantErr: true},
+		{name: "running", heartbeat: `{"status":"running","updated_at":"` + time.Now().UTC().Format(time.RFC3339) + `","interval_seconds":30}`},
+		{name: "stale", heartbeat: `{"status":"running","updated_at":"2020-01-01T00:00:00Z","interval_seconds":30}`, wantErr: true},
+		{name: "finished", heartbeat: `{"status":"finished","updated_at":"2020-01-01T00:00:00Z"}`, wantErr: true},
+		{name: "corrupt", heartbeat: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.heartbeat != "" {
+				if err := os.MkdirAll(filepath.Join(dir, core.AutoDir), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(core.GetAutoHeartbeatPath(dir), []byte(tt.heartbeat), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			orig, _ := os.Getwd()
+			defer os.Chdir(orig)
+			os.Chdir(dir)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Duration("max-age", 0, "")
+			cmd.Flags().Bool("json", true, "")
+			err := runAutoHealth(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runAutoHealth() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
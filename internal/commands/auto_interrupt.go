@@ -0,0 +1,33 @@
+package commands
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// interruptExitCode is the conventional exit status after SIGINT.
+const interruptExitCode = 130
+
+// notifyInterrupt returns a channel that is closed on the first SIGINT or
+// SIGTERM, for use as LoopConfig.Stop. The loop then returns through its
+// normal path so deferred cleanup runs; a second signal terminates the
+// process as usual. The returned function must be deferred by the caller.
+func notifyInterrupt() (<-chan struct{}, func()) {
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		if _, ok := <-signals; ok {
+			signal.Stop(signals)
+			ui.Warn("Interrupted - stopping after the current iteration (press Ctrl+C again to force)")
+			close(stop)
+		}
+	}()
+	return stop, func() {
+		signal.Stop(signals)
+		close(signals)
+	}
+}
@@ -0,0 +1,41 @@
+package commands
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestNotifyInterrupt(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals are not delivered this way on windows")
+	}
+	stop, release := notifyInterrupt()
+	defer release()
+
+	select {
+	case <-stop:
+		t.Fatal("stop closed before any signal")
+	default:
+	}
+	signalSelf(t, syscall.SIGTERM)
+	select {
+	case <-stop:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop not closed after SIGTERM")
+	}
+}
+
+// signalSelf sends sig to the test process.
+func signalSelf(t *testing.T, sig os.Signal) {
+	t.Helper()
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Signal(sig); err != nil {
+		t.Fatal(err)
+	}
+}
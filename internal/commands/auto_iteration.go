@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// pilotLoopConfig builds the loop config for pilot mode, reporting the
+// outcome of every iteration the same way auto start does.
+func pilotLoopConfig(cwd string, prd *core.AutoPRD, autoCfg core.AutoConfig) core.LoopConfig {
+	cfg := core.NewLoopConfig(cwd, prd)
+	cfg.MaxIterations = autoCfg.MaxIterations
+	cfg.OnIterEnd = func(iter int, err error) {
+		if err != nil {
+			ui.Warn("[iteration:%d] Agent exited with error: %v", iter, err)
+		}
+	}
+	cfg.OnIterEvent = reportIterationEvent
+	cfg.OnCommitPolicy = reportCommitPolicy
+	cfg.OnPathGuard = reportPathGuard
+	cfg.OnSync = reportSync
+	cfg.OnHook = reportIterationHook
+	cfg.OnTelemetry = reportTelemetry
+	return cfg
+}
+
+// runPilotIteration runs one pilot iteration through the loop's shared
+// iteration, so pilot mode gets the same hooks, guards, policies and
+// progress header as auto start. Failed iterations count towards
+// MaxConsecFails; the state is then pushed when sync is configured.
+func runPilotIteration(cfg core.LoopConfig, iter int, iterType string, consecutiveFailures *int) error {
+	_, err := core.RunIteration(cfg, iter, iterType)
+	return core.FinishIteration(cfg, iter, err, consecutiveFailures)
+}
+
+// reportIterationEvent warns when the event log could not be written or
+// the iteration touched an unusually large number of files.
+func reportIterationEvent(event core.IterationEvent, err error) {
+	if err != nil {
+		ui.Warn("[iteration:%d] Failed to record iteration event: %v", event.Iteration, err)
+		return
+	}
+	if n := event.Changes.Total(); n > core.FileChangeWarnThreshold {
+		ui.Warn("[iteration:%d] Agent touched %d files (+%d ~%d -%d) - check for runaway changes",
+			event.Iteration, n, event.Changes.Added, event.Changes.Modified, event.Changes.Deleted)
+	}
+}
+
+// reportPathGuard prints the out-of-scope changes found after an iteration.
+func reportPathGuard(iter int, v *core.PathGuardViolation, err error) {
+	if err != nil {
+		ui.Warn("[iteration:%d] Could not check allowed paths: %v", iter, err)
+		return
+	}
+	ui.Warn("[iteration:%d] Changes outside allowed paths: %s", iter, strings.Join(v.Paths, ", "))
+	if len(v.Reverted) > 0 {
+		ui.Info("[iteration:%d] Reverted: %s", iter, strings.Join(v.Reverted, ", "))
+	}
+	if v.Blocked {
+		ui.Warn("[iteration:%d] Task %s blocked for review", iter, v.TaskID)
+	}
+}
+
+// reportCommitPolicy prints the tasks reopened by the commit policy.
+func reportCommitPolicy(iter int, violations []core.CommitPolicyViolation, err error) {
+	if err != nil {
+		ui.Warn("[iteration:%d] Could not check the commit policy: %v", iter, err)
+		return
+	}
+	for _, v := range violations {
+		ui.Warn("[iteration:%d] Task %s reopened, commit policy violated: %s",
+			iter, v.TaskID, strings.Join(v.Problems, "; "))
+	}
+}
+
+// reportSync prints the outcome of pushing the loop state.
+func reportSync(iter int, bundle *core.SyncBundle, err error) {
+	if err != nil {
+		ui.Warn("[iteration:%d] Could not sync auto state: %v", iter, err)
+		return
+	}
+	ui.Dim("[iteration:%d] Synced auto state (revision %d)", iter, bundle.Revision)
+}
+
+// reportIterationHook prints the outcome of a pre- or post-iteration hook.
+func reportIterationHook(iter int, phase, command string, err error) {
+	if err != nil {
+		ui.Warn("[iteration:%d] %v", iter, err)
+		return
+	}
+	ui.Dim("[iteration:%d] %s hook passed: %s", iter, phase, command)
+}
+
+// reportTelemetry warns about a failed trace export; the loop carries on.
+func reportTelemetry(err error) {
+	ui.Warn("Telemetry export failed: %v", err)
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+// setupPilotIteration puts a "claude" on PATH that touches agent-ran and
+// returns a pilot loop config for a git project with one pending task.
+func setupPilotIteration(t *testing.T, agentCode string) core.LoopConfig {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("pilot iteration tests use sh")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	bin := t.TempDir()
+	script := "#!/bin/sh\ntouch agent-ran\nexit " + agentCode + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	dir, prdPath := setupTestPRD(t, []core.AutoTask{{ID: "1", Title: "First task", Status: core.TaskStatusPending}})
+	if err := core.GitInit(dir); err != nil {
+		t.Fatal(err)
+	}
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := pilotLoopConfig(dir, prd, core.AutoConfig{MaxIterations: 1})
+	cfg.AITool = "claude"
+	cfg.PromptPath = filepath.Join(dir, "prompt.md")
+	if err := os.WriteFile(cfg.PromptPath, []byte("Do the next task."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestRunPilotIteration_RunsHooks(t *testing.T) {
+	cfg := setupPilotIteration(t, "0")
+	cfg.PreIteration = []string{"touch pre-ran"}
+	cfg.PostIteration = []string{"touch post-ran"}
+
+	failures := 0
+	if err := runPilotIteration(cfg, 1, core.IterationTypeImplementation, &failures); err != nil {
+		t.Fatalf("runPilotIteration() error = %v", err)
+	}
+	for _, name := range []string{"pre-ran", "agent-ran", "post-ran"} {
+		if _, err := os.Stat(filepath.Join(cfg.ProjectDir, name)); err != nil {
+			t.Errorf("%s missing: %v", name, err)
+		}
+	}
+}
+
+func TestRunPilotIteration_CountsFailures(t *testing.T) {
+	cfg := setupPilotIteration(t, "1")
+	cfg.MaxConsecFails = 2
+
+	failures := 0
+	if err := runPilotIteration(cfg, 1, core.IterationTypeImplementation, &failures); err != nil || failures != 1 {
+		t.Fatalf("first failure: err = %v, failures = %d", err, failures)
+	}
+	if err := runPilotIteration(cfg, 2, core.IterationTypeImplementation, &failures); err == nil {
+		t.Error("expected an error once MaxConsecFails is reached")
+	}
+}
+
+func TestRunPilotIteration_UpdatesProgressHeader(t *testing.T) {
+	cfg := setupPilotIteration(t, "0")
+
+	failures := 0
+	if err := runPilotIteration(cfg, 3, core.IterationTypeDiscovery, &failures); err != nil {
+		t.Fatalf("runPilotIteration() error = %v", err)
+	}
+	header, err := core.ReadProgressHeader(filepath.Join(core.GetAutoDir(cfg.ProjectDir), core.AutoProgressFile))
+	if err != nil || header == nil {
+		t.Fatalf("ReadProgressHeader() = %v, %v", header, err)
+	}
+	if header.LastIteration != 3 || header.LastIterationType != core.IterationTypeDiscovery {
+		t.Errorf("header = %+v, want iteration 3 (discovery)", header)
+	}
+	if header.CurrentTask != "1" || header.TotalTasks != 1 {
+		t.Errorf("header = %+v, want current task 1 of 1", header)
+	}
+}
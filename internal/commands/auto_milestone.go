@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// addTaskFilterFlags registers --milestone and --label on a command.
+func addTaskFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().String("milestone", "", "Only include tasks in this milestone")
+	cmd.Flags().StringSlice("label", nil, "Only include tasks with this label (repeatable)")
+}
+
+// taskFilterFromFlags builds a task filter from --milestone and --label.
+// A nil command yields an empty filter.
+func taskFilterFromFlags(cmd *cobra.Command) core.TaskFilter {
+	if cmd == nil {
+		return core.TaskFilter{}
+	}
+	milestone, _ := cmd.Flags().GetString("milestone")
+	labels, _ := cmd.Flags().GetStringSlice("label")
+	return core.TaskFilter{Milestone: milestone, Labels: labels}
+}
+
+// formatTaskTags renders a task's milestone and labels for list output,
+// e.g. " [M1] #api #db". Returns "" when the task has neither.
+func formatTaskTags(t core.AutoTask) string {
+	var sb strings.Builder
+	if t.Milestone != "" {
+		fmt.Fprintf(&sb, " [%s]", t.Milestone)
+	}
+	for _, label := range t.Labels {
+		fmt.Fprintf(&sb, " #%s", label)
+	}
+	return sb.String()
+}
+
+// printMilestoneProgress prints per-milestone completion percentages.
+func printMilestoneProgress(prd *core.AutoPRD) {
+	progress := prd.GetMilestoneProgress()
+	if len(progress) == 0 {
+		return
+	}
+	ui.Section("Milestones")
+	for _, m := range progress {
+		ui.TableRow(m.Name, formatTaskProgress(m.Completed, m.Total))
+	}
+}
+
+// applyMilestoneScope restricts the loop to one milestone: the loop stops
+// when the milestone has no available tasks, and the agent gets a prompt
+// that tells it to ignore tasks outside the milestone.
+func applyMilestoneScope(cfg *core.LoopConfig, prd *core.AutoPRD, milestone string) error {
+	if err := prd.ValidateMilestone(milestone); err != nil {
+		return err
+	}
+
+	base, err := os.ReadFile(cfg.PromptPath)
+	if err != nil {
+		return fmt.Errorf("failed to read prompt file: %w", err)
+	}
+	scoped := string(base) + core.GenerateMilestonePromptSection(milestone)
+	scopedPath := filepath.Join(filepath.Dir(cfg.PRDPath), core.AutoMilestonePromptFile)
+	if err := os.WriteFile(scopedPath, []byte(scoped), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", core.AutoMilestonePromptFile, err)
+	}
+
+	cfg.PromptPath = scopedPath
+	cfg.Milestone = milestone
+	return nil
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestFormatTaskTags(t *testing.T) {
+	tests := []struct {
+		name string
+		task core.AutoTask
+		want string
+	}{
+		{"none", core.AutoTask{}, ""},
+		{"milestone only", core.AutoTask{Milestone: "M1"}, " [M1]"},
+		{"labels only", core.AutoTask{Labels: []string{"api", "db"}}, " #api #db"},
+		{"both", core.AutoTask{Milestone: "M2", Labels: []string{"ui"}}, " [M2] #ui"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatTaskTags(tt.task); got != tt.want {
+				t.Errorf("formatTaskTags() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTaskFilterFromFlags(t *testing.T) {
+	if f := taskFilterFromFlags(nil); !f.IsEmpty() {
+		t.Errorf("nil command should yield empty filter, got %+v", f)
+	}
+
+	cmd := &cobra.Command{}
+	addTaskFilterFlags(cmd)
+	cmd.Flags().Set("milestone", "M1")
+	cmd.Flags().Set("label", "api")
+	cmd.Flags().Set("label", "db")
+
+	f := taskFilterFromFlags(cmd)
+	if f.Milestone != "M1" || len(f.Labels) != 2 {
+		t.Errorf("taskFilterFromFlags() = %+v", f)
+	}
+}
+
+func TestApplyMilestoneScope(t *testing.T) {
+	dir, prdPath := setupTestPRD(t, []core.AutoTask{
+		{ID: "1", Title: "API", Status: core.TaskStatusPending, Milestone: "M1"},
+	})
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatalf("failed to load prd: %v", err)
+	}
+
+	cfg := core.NewLoopConfig(dir, prd)
+	cfg.PromptPath = filepath.Join(dir, core.AutoDir, core.AutoPromptFile)
+	if err := os.WriteFile(cfg.PromptPath, []byte("# Base prompt\n"), 0644); err != nil {
+		t.Fatalf("failed to write prompt: %v", err)
+	}
+
+	if err := applyMilestoneScope(&cfg, prd, "M9"); err == nil {
+		t.Error("expected error for unknown milestone")
+	}
+	if err := applyMilestoneScope(&cfg, prd, "M1"); err != nil {
+		t.Fatalf("applyMilestoneScope returned error: %v", err)
+	}
+
+	if cfg.Milestone != "M1" {
+		t.Errorf("cfg.Milestone = %q, want M1", cfg.Milestone)
+	}
+	content, err := os.ReadFile(cfg.PromptPath)
+	if err != nil {
+		t.Fatalf("scoped prompt not written: %v", err)
+	}
+	if !strings.HasPrefix(string(content), "# Base prompt") ||
+		!strings.Contains(string(content), "## Milestone Scope") {
+		t.Errorf("scoped prompt should extend the base prompt:\n%s", content)
+	}
+}
+
+func TestRunAutoTaskAdd_WithMilestoneAndLabels(t *testing.T) {
+	dir, prdPath := setupTestPRD(t, nil)
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("milestone", "M1", "")
+	cmd.Flags().StringSlice("label", []string{"api"}, "")
+
+	if err := runAutoTaskAdd(cmd, []string{"1", "Tagged task"}); err != nil {
+		t.Fatalf("runAutoTaskAdd returned error: %v", err)
+	}
+
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatalf("failed to load prd: %v", err)
+	}
+	task := prd.Tasks[0]
+	if task.Milestone != "M1" || !task.HasLabel("api") {
+		t.Errorf("task = %+v, want milestone M1 and label api", task)
+	}
+}
+
+func TestRunAutoTaskList_Filtered(t *testing.T) {
+	dir, _ := setupTestPRD(t, []core.AutoTask{
+		{ID: "1", Title: "API", Status: core.TaskStatusPending, Milestone: "M1"},
+		{ID: "2", Title: "UI", Status: core.TaskStatusPending, Milestone: "M2"},
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	cmd := &cobra.Command{}
+	addTaskFilterFlags(cmd)
+	cmd.Flags().Set("milestone", "M1")
+
+	if err := runAutoTaskList(cmd, nil); err != nil {
+		t.Fatalf("runAutoTaskList returned error: %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// maxExplainCandidates limits how many runner-up tasks --explain shows.
+const maxExplainCandidates = 5
+
+var autoNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "Show the task the loop would pick next",
+	Long: `Show which pending task the autonomous loop would select next.
+
+Tasks are ranked with the scoring strategy configured in prd.json
+(config.scoring_strategy). Supported strategies:
+  priority  Priority first, then lowest task ID (default)
+  wsjf      Weighted Shortest Job First: (value + unlocks + age) / size
+
+Use --explain to see the score breakdown for the top candidates.
+
+Examples:
+  samuel auto next
+  samuel auto next --explain
+  samuel auto next --strategy wsjf --explain`,
+	RunE: runAutoNext,
+}
+
+func registerNextCmd() {
+	autoCmd.AddCommand(autoNextCmd)
+
+	autoNextCmd.Flags().Bool("explain", false, "Show why the task was chosen")
+	autoNextCmd.Flags().String("strategy", "", "Override scoring strategy (priority, wsjf)")
+}
+
+func runAutoNext(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	strategy := prd.Config.ScoringStrategy
+	if flagStrategy, _ := cmd.Flags().GetString("strategy"); flagStrategy != "" {
+		strategy = flagStrategy
+	}
+	if !core.IsValidScoringStrategy(strategy) {
+		return fmt.Errorf("unsupported scoring strategy: %s (supported: %v)",
+			strategy, core.GetSupportedScoringStrategies())
+	}
+	if strategy == "" {
+		strategy = core.ScoringStrategyPriority
+	}
+
+	scores := prd.ScoreTasks(strategy, time.Now().UTC())
+	if len(scores) == 0 {
+		ui.Info("No available tasks (all done, blocked, or waiting on dependencies)")
+		return nil
+	}
+
+	next := scores[0].Task
+	ui.Success("Next task: %s %s", next.ID, next.Title)
+
+	explain, _ := cmd.Flags().GetBool("explain")
+	if explain {
+		printScoreExplanation(strategy, scores)
+	}
+	return nil
+}
+
+// printScoreExplanation prints the score breakdown for the top candidates.
+func printScoreExplanation(strategy string, scores []core.TaskScore) {
+	ui.Section(fmt.Sprintf("Candidates (strategy: %s)", strategy))
+	for i, s := range scores {
+		if i >= maxExplainCandidates {
+			ui.Dim("  ... %d more", len(scores)-maxExplainCandidates)
+			break
+		}
+		ui.ListItem(1, "%d. %s %s", i+1, s.Task.ID, s.Task.Title)
+		ui.Dim("       %s", formatScoreReason(strategy, s))
+	}
+}
+
+// formatScoreReason renders a one-line explanation of a task's score.
+func formatScoreReason(strategy string, s core.TaskScore) string {
+	priority := s.Task.Priority
+	if priority == "" {
+		priority = core.TaskPriorityMedium
+	}
+	if strategy != core.ScoringStrategyWSJF {
+		return fmt.Sprintf("priority=%s, id=%s", priority, s.Task.ID)
+	}
+	return fmt.Sprintf("score=%.2f (value=%.0f [%s], unlocks=%d, age=%.1fd, size=%.0f)",
+		s.Score, s.Value, priority, s.Unlocks, s.AgeDays, s.JobSize)
+}
@@ -0,0 +1,96 @@
+package commands
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newAutoNextTestCmd(strategy string, explain bool) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("explain", explain, "")
+	cmd.Flags().String("strategy", strategy, "")
+	return cmd
+}
+
+func TestRunAutoNext(t *testing.T) {
+	dir, _ := setupTestPRD(t, []core.AutoTask{
+		{ID: "1", Title: "First", Status: core.TaskStatusPending},
+		{ID: "2", Title: "Second", Status: core.TaskStatusPending, DependsOn: []string{"1"}},
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	if err := runAutoNext(newAutoNextTestCmd("wsjf", true), nil); err != nil {
+		t.Fatalf("runAutoNext returned error: %v", err)
+	}
+}
+
+func TestRunAutoNext_InvalidStrategy(t *testing.T) {
+	dir, _ := setupTestPRD(t, []core.AutoTask{
+		{ID: "1", Title: "First", Status: core.TaskStatusPending},
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	if err := runAutoNext(newAutoNextTestCmd("random", false), nil); err == nil {
+		t.Fatal("expected error for invalid strategy, got nil")
+	}
+}
+
+func TestRunAutoNext_NoPRD(t *testing.T) {
+	dir := t.TempDir()
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	if err := runAutoNext(newAutoNextTestCmd("", false), nil); err == nil {
+		t.Fatal("expected error when prd.json missing, got nil")
+	}
+}
+
+func TestFormatScoreReason(t *testing.T) {
+	score := core.TaskScore{
+		Task:    &core.AutoTask{ID: "1.2", Priority: core.TaskPriorityHigh},
+		Score:   4.5,
+		Value:   8,
+		Unlocks: 2,
+		AgeDays: 1.5,
+		JobSize: 3,
+	}
+
+	got := formatScoreReason(core.ScoringStrategyWSJF, score)
+	for _, want := range []string{"score=4.50", "value=8 [high]", "unlocks=2", "age=1.5d", "size=3"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatScoreReason() = %q, want contains %q", got, want)
+		}
+	}
+
+	got = formatScoreReason(core.ScoringStrategyPriority, score)
+	if got != "priority=high, id=1.2" {
+		t.Errorf("formatScoreReason(priority) = %q", got)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
 
 	"github.com/ar4mirez/samuel/internal/core"
 	"github.com/ar4mirez/samuel/internal/ui"
@@ -47,21 +46,21 @@ func registerPilotCmd() {
 	autoPilotCmd.Flags().String("ai-tool", "claude",
 		"AI tool (claude, amp, codex)")
 	autoPilotCmd.Flags().String("sandbox", "none",
-		"Sandbox mode: none, docker, docker-sandbox")
+		"Sandbox mode: none, docker, docker-sandbox, process")
 	autoPilotCmd.Flags().String("sandbox-image", "",
 		"Docker image for docker mode")
 	autoPilotCmd.Flags().String("sandbox-template", "",
 		"Docker sandbox template")
+	autoPilotCmd.Flags().Bool("sandbox-offline", false,
+		"Block the agent's network access in process mode")
 	autoPilotCmd.Flags().Bool("dry-run", false,
 		"Preview without executing")
-	autoPilotCmd.Flags().BoolP("yes", "y", false,
-		"Skip confirmation prompt")
 }
 
 func runAutoPilot(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	if !core.ConfigExists(cwd) {
@@ -87,76 +86,45 @@ func runAutoPilot(cmd *cobra.Command, args []string) error {
 		return printPilotDryRun(autoCfg, pilotCfg, cwd)
 	}
 
-	skipConfirm, _ := cmd.Flags().GetBool("yes")
-	if !skipConfirm {
-		confirmed, confirmErr := ui.Confirm("Start pilot mode? This will analyze and modify your project.", false)
-		if confirmErr != nil || !confirmed {
-			ui.Info("Cancelled")
-			return nil
-		}
-	}
-
-	return executePilotLoop(cwd, autoCfg, pilotCfg)
-}
-
-func parsePilotFlags(cmd *cobra.Command) (*core.PilotConfig, error) {
-	cfg := core.NewPilotConfig()
-
-	if v, _ := cmd.Flags().GetInt("discover-interval"); v > 0 {
-		cfg.DiscoverInterval = v
-	}
-	if v, _ := cmd.Flags().GetInt("max-tasks"); v > 0 {
-		cfg.MaxDiscoveryTasks = v
-	}
-	if v, _ := cmd.Flags().GetString("focus"); v != "" {
-		cfg.Focus = v
-	}
-
-	return cfg, nil
-}
-
-func parseAutoFlags(cmd *cobra.Command, cwd string) (core.AutoConfig, error) {
-	aiTool, _ := cmd.Flags().GetString("ai-tool")
-	if !core.IsValidAITool(aiTool) {
-		return core.AutoConfig{}, fmt.Errorf(
-			"unsupported AI tool: %s (supported: %v)", aiTool, core.GetSupportedAITools())
-	}
-
-	sandbox, _ := cmd.Flags().GetString("sandbox")
-	if !core.IsValidSandboxMode(sandbox) {
-		return core.AutoConfig{}, fmt.Errorf(
-			"unsupported sandbox mode: %s (supported: %v)", sandbox, core.GetSupportedSandboxModes())
+	confirmed, confirmErr := approve(promptPilotStart, "Start pilot mode? This will analyze and modify your project.", false)
+	if confirmErr != nil || !confirmed {
+		ui.Info("Cancelled")
+		return nil
 	}
 
-	maxIter, _ := cmd.Flags().GetInt("iterations")
-	sandboxImage, _ := cmd.Flags().GetString("sandbox-image")
-	sandboxTpl, _ := cmd.Flags().GetString("sandbox-template")
-
-	return core.AutoConfig{
-		MaxIterations:   maxIter,
-		QualityChecks:   detectQualityChecks(cwd),
-		AITool:          aiTool,
-		Sandbox:         sandbox,
-		SandboxImage:    sandboxImage,
-		SandboxTemplate: sandboxTpl,
-		PilotMode:       true,
-	}, nil
+	return withProjectLock(cmd, cwd, func() error { return executePilotLoop(cwd, autoCfg, pilotCfg) })
 }
 
-func executePilotLoop(cwd string, autoCfg core.AutoConfig, pilotCfg *core.PilotConfig) error {
+func executePilotLoop(cwd string, autoCfg core.AutoConfig, pilotCfg *core.PilotConfig) (err error) {
 	prd, err := initPilotMode(cwd, autoCfg, pilotCfg)
 	if err != nil {
 		return fmt.Errorf("failed to initialize pilot mode: %w", err)
 	}
 
+	defer installSandboxCleanup(cwd, autoCfg.Sandbox)()
+	defer enforceRetention(cwd, core.GCEvents)
+
 	prdPath := core.GetAutoPRDPath(cwd)
 	autoDir := core.GetAutoDir(cwd)
 
 	implPromptPath := filepath.Join(autoDir, core.AutoPromptFile)
 	discoveryPromptPath := filepath.Join(autoDir, core.AutoDiscoveryPromptFile)
 
-	loopCfg := core.NewLoopConfig(cwd, prd)
-	loopCfg.MaxIterations = autoCfg.MaxIterations
+	loopCfg := pilotLoopConfig(cwd, prd, autoCfg)
+	stop, releaseInterrupt := notifyInterrupt()
+	defer releaseInterrupt()
+	loopCfg.Stop = stop
+
+	store, err := core.OpenProjectAutoStore(cwd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	loopCfg.Store = store
+	hb := core.StartHeartbeat(&loopCfg)
+	defer func() { hb.Stop(err) }()
+	trace := core.StartLoopTrace(&loopCfg)
+	defer func() { trace.Finish(err) }()
 
 	lastDiscoveryIter := 0
 	emptyDiscoveries := 0
@@ -164,21 +132,19 @@ func executePilotLoop(cwd string, autoCfg core.AutoConfig, pilotCfg *core.PilotC
 
 	stats := pilotStats{}
 
-	ui.Info("Pilot mode starting...")
-	ui.Print("  AI Tool:     %s", autoCfg.AITool)
-	ui.Print("  Iterations:  %d", autoCfg.MaxIterations)
-	ui.Print("  Discover:    every %d iterations", pilotCfg.DiscoverInterval)
-	if pilotCfg.Focus != "" {
-		ui.Print("  Focus:       %s", pilotCfg.Focus)
-	}
-	ui.Print("")
+	printPilotBanner(autoCfg, pilotCfg)
 
-	for i := 1; i <= autoCfg.MaxIterations; i++ {
-		currentPRD, loadErr := core.LoadAutoPRD(prdPath)
+	for i := 1; i <= autoCfg.MaxIterations && !loopCfg.Stopped(); i++ {
+		reloadThemeIfChanged(cwd)
+		currentPRD, loadErr := store.LoadPRD()
 		if loadErr != nil {
 			return fmt.Errorf("iteration %d: failed to reload prd.json: %w", i, loadErr)
 		}
 
+		if err := core.RefreshProjectStats(cwd, i, loopCfg.StatsRefreshInterval); err != nil {
+			ui.Warn("[iteration:%d] Failed to refresh project stats: %v", i, err)
+		}
+
 		isDiscovery := core.ShouldRunDiscovery(
 			currentPRD, i, lastDiscoveryIter, pilotCfg.DiscoverInterval)
 
@@ -189,11 +155,11 @@ func executePilotLoop(cwd string, autoCfg core.AutoConfig, pilotCfg *core.PilotC
 			stats.discoveryCount++
 
 			tasksBefore := len(currentPRD.Tasks)
-			if err := runSingleIteration(loopCfg, &consecutiveFailures); err != nil {
+			if err := runPilotIteration(loopCfg, i, core.IterationTypeDiscovery, &consecutiveFailures); err != nil {
 				return err
 			}
 
-			reloaded, reloadErr := core.LoadAutoPRD(prdPath)
+			reloaded, reloadErr := store.LoadPRD()
 			if reloadErr != nil {
 				ui.Warn("[iteration:%d] Failed to reload prd.json after discovery: %v", i, reloadErr)
 			}
@@ -219,13 +185,13 @@ func executePilotLoop(cwd string, autoCfg core.AutoConfig, pilotCfg *core.PilotC
 			loopCfg.PromptPath = implPromptPath
 			stats.implCount++
 
-			if err := runSingleIteration(loopCfg, &consecutiveFailures); err != nil {
+			if err := runPilotIteration(loopCfg, i, core.IterationTypeImplementation, &consecutiveFailures); err != nil {
 				return err
 			}
 		}
 
 		if emptyDiscoveries >= core.MaxEmptyDiscoveries {
-			reloaded, reloadErr := core.LoadAutoPRD(prdPath)
+			reloaded, reloadErr := store.LoadPRD()
 			if reloadErr != nil {
 				ui.Warn("Failed to reload prd.json for empty discovery check: %v", reloadErr)
 			}
@@ -236,11 +202,14 @@ func executePilotLoop(cwd string, autoCfg core.AutoConfig, pilotCfg *core.PilotC
 		}
 
 		if i < autoCfg.MaxIterations {
-			time.Sleep(time.Duration(loopCfg.PauseSecs) * time.Second)
+			loopCfg.Pause()
 		}
 	}
 
 	printPilotSummary(prdPath, stats)
+	if loopCfg.Stopped() {
+		return withExitCode(interruptExitCode, core.ErrInterrupted)
+	}
 	return nil
 }
 
@@ -276,21 +245,9 @@ func initPilotMode(cwd string, autoCfg core.AutoConfig, pilotCfg *core.PilotConf
 		}
 	}
 
-	return prd, nil
-}
-
-func runSingleIteration(cfg core.LoopConfig, consecutiveFailures *int) error {
-	if err := core.InvokeAgent(cfg); err != nil {
-		*consecutiveFailures++
-		ui.Warn("Agent error (%d consecutive): %v", *consecutiveFailures, err)
-		if *consecutiveFailures >= cfg.MaxConsecFails {
-			return fmt.Errorf(
-				"%d consecutive failures — aborting. Check AI tool auth/config",
-				cfg.MaxConsecFails)
-		}
-		return nil
+	if _, err := core.WriteProjectStats(cwd); err != nil {
+		ui.Warn("Could not generate project stats: %v", err)
 	}
-	*consecutiveFailures = 0
-	return nil
-}
 
+	return prd, nil
+}
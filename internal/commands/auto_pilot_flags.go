@@ -0,0 +1,60 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func parsePilotFlags(cmd *cobra.Command) (*core.PilotConfig, error) {
+	cfg := core.NewPilotConfig()
+
+	if v, _ := cmd.Flags().GetInt("discover-interval"); v > 0 {
+		cfg.DiscoverInterval = v
+	}
+	if v, _ := cmd.Flags().GetInt("max-tasks"); v > 0 {
+		cfg.MaxDiscoveryTasks = v
+	}
+	if v, _ := cmd.Flags().GetString("focus"); v != "" {
+		cfg.Focus = v
+	}
+
+	return cfg, nil
+}
+
+// parseAutoFlags builds the loop config from the pilot flags, taking the
+// settings not given on the command line from the user's auto defaults.
+func parseAutoFlags(cmd *cobra.Command, cwd string) (core.AutoConfig, error) {
+	user, err := core.LoadUserAutoSettings()
+	if err != nil {
+		return core.AutoConfig{}, err
+	}
+	aiTool := flagOrUserString(cmd, "ai-tool", user.AITool)
+	if !core.IsValidAITool(aiTool) {
+		return core.AutoConfig{}, fmt.Errorf(
+			"unsupported AI tool: %s (supported: %v)", aiTool, core.GetSupportedAITools())
+	}
+
+	sandbox := flagOrUserString(cmd, "sandbox", user.Sandbox)
+	if !core.IsValidSandboxMode(sandbox) {
+		return core.AutoConfig{}, fmt.Errorf(
+			"unsupported sandbox mode: %s (supported: %v)", sandbox, core.GetSupportedSandboxModes())
+	}
+
+	maxIter, _ := cmd.Flags().GetInt("iterations")
+	sandboxImage := flagOrUserString(cmd, "sandbox-image", user.SandboxImage)
+	sandboxTpl := flagOrUserString(cmd, "sandbox-template", user.SandboxTemplate)
+	offline, _ := cmd.Flags().GetBool("sandbox-offline")
+
+	return core.AutoConfig{
+		MaxIterations:   maxIter,
+		QualityChecks:   detectQualityChecks(cwd),
+		AITool:          aiTool,
+		Sandbox:         sandbox,
+		SandboxImage:    sandboxImage,
+		SandboxTemplate: sandboxTpl,
+		SandboxOffline:  offline,
+		PilotMode:       true,
+	}, nil
+}
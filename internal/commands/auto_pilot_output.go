@@ -12,6 +12,17 @@ type pilotStats struct {
 	implCount      int
 }
 
+func printPilotBanner(autoCfg core.AutoConfig, pilotCfg *core.PilotConfig) {
+	ui.Info("Pilot mode starting...")
+	ui.Print("  AI Tool:     %s", autoCfg.AITool)
+	ui.Print("  Iterations:  %d", autoCfg.MaxIterations)
+	ui.Print("  Discover:    every %d iterations", pilotCfg.DiscoverInterval)
+	if pilotCfg.Focus != "" {
+		ui.Print("  Focus:       %s", pilotCfg.Focus)
+	}
+	ui.Print("")
+}
+
 func printPilotDryRun(autoCfg core.AutoConfig, pilotCfg *core.PilotConfig, cwd string) error {
 	ui.Header("Dry Run - Pilot Mode")
 	ui.Print("  AI Tool:           %s", autoCfg.AITool)
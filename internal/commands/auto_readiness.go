@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoReadinessCmd = &cobra.Command{
+	Use:   "readiness",
+	Short: "Check whether prd.json is ready for the autonomous loop",
+	Long: `Score prd.json for loop-friendliness before spending iterations on it.
+
+Every open task is checked for:
+  size        complex or many-file tasks without subtasks
+  acceptance  no acceptance_criteria (or "acceptance"/"done when" in the description)
+  title       titles under three words or with placeholders like "misc" or "wip"
+  dependency  depends_on entries that name no task (error)
+  ordering    modifying a file another task creates without depending on it
+
+The score starts at 100 and loses 15 points per error and 5 per warning.
+Use --min-score to fail in CI when the plan needs work.
+
+Examples:
+  samuel auto readiness
+  samuel auto readiness --min-score 80`,
+	RunE: runAutoReadiness,
+}
+
+func registerReadinessCmd() {
+	autoCmd.AddCommand(autoReadinessCmd)
+
+	autoReadinessCmd.Flags().Int("min-score", 0, "Exit with an error when the score is below this value")
+}
+
+func runAutoReadiness(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	report := prd.AnalyzeReadiness()
+	printReadinessReport(report)
+
+	minScore, _ := cmd.Flags().GetInt("min-score")
+	if report.Score < minScore {
+		return fmt.Errorf("readiness score %d is below the minimum of %d", report.Score, minScore)
+	}
+	return nil
+}
+
+// printReadinessReport shows the score followed by errors, then warnings.
+func printReadinessReport(report *core.ReadinessReport) {
+	ui.Header("PRD Readiness")
+	ui.TableRow("Score", fmt.Sprintf("%d/100", report.Score))
+	ui.TableRow("Open tasks", fmt.Sprintf("%d", report.Tasks))
+
+	if len(report.Findings) == 0 {
+		fmt.Println()
+		ui.Success("Plan looks ready for the loop")
+		return
+	}
+
+	ui.Section("Findings")
+	for _, severity := range []string{core.ReadinessError, core.ReadinessWarning} {
+		for _, f := range report.Findings {
+			if f.Severity != severity {
+				continue
+			}
+			if severity == core.ReadinessError {
+				ui.ErrorItem(1, "%s [%s] %s", f.TaskID, f.Check, f.Message)
+			} else {
+				ui.WarnItem(1, "%s [%s] %s", f.TaskID, f.Check, f.Message)
+			}
+		}
+	}
+	fmt.Println()
+	ui.Info("%d errors, %d warnings", report.Errors(), len(report.Findings)-report.Errors())
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newAutoReadinessTestCmd(minScore int) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().Int("min-score", minScore, "")
+	return cmd
+}
+
+func TestRunAutoReadiness(t *testing.T) {
+	tests := []struct {
+		name     string
+		minScore int
+		wantErr  bool
+	}{
+		{name: "reports without minimum", minScore: 0},
+		{name: "passes at score", minScore: 95},
+		{name: "fails below minimum", minScore: 96, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, _ := setupTestPRD(t, []core.AutoTask{
+				{ID: "1", Title: "Add user model", Status: core.TaskStatusPending,
+					AcceptanceCriteria: []string{"model saved"}},
+				{ID: "2", Title: "Misc", Status: core.TaskStatusPending,
+					AcceptanceCriteria: []string{"done"}},
+			})
+			origDir, err := os.Getwd()
+			if err != nil {
+				t.Fatalf("failed to get working directory: %v", err)
+			}
+			if err := os.Chdir(dir); err != nil {
+				t.Fatalf("failed to chdir: %v", err)
+			}
+			t.Cleanup(func() { os.Chdir(origDir) })
+
+			err = runAutoReadiness(newAutoReadinessTestCmd(tt.minScore), nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("runAutoReadiness() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRunAutoReadiness_NoPRD(t *testing.T) {
+	dir := t.TempDir()
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	if err := runAutoReadiness(newAutoReadinessTestCmd(0), nil); err == nil {
+		t.Fatal("expected error when prd.json missing, got nil")
+	}
+}
@@ -0,0 +1,148 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// corruptPRDSuffix is appended to an unreadable prd.json set aside by
+// 'auto reconstruct'.
+const corruptPRDSuffix = ".corrupt"
+
+var autoReconstructCmd = &cobra.Command{
+	Use:   "reconstruct",
+	Short: "Rebuild a lost or corrupted prd.json from progress.md and git history",
+	Long: `Rebuild prd.json when it was deleted or corrupted and its backup is
+unusable too.
+
+Tasks are recovered from the [task:ID] entries of progress.md and its front
+section (current task, blockers), and from commit subjects that reference a
+task ID, such as "feat(auth): task 1.1 - create user schema". A task with a
+COMPLETED entry or a commit is marked completed with its latest commit; one
+that was only STARTED is in progress. Each recovered task is shown with where
+it was found and must be confirmed (--yes accepts all).
+
+Priorities, dependencies and acceptance criteria are not recorded anywhere
+else and are lost; review prd.json before restarting the loop. An unreadable
+prd.json is kept as prd.json.corrupt.
+
+Examples:
+  samuel auto reconstruct --dry-run   # List what can be recovered
+  samuel auto reconstruct`,
+	Args: cobra.NoArgs,
+	RunE: runAutoReconstruct,
+}
+
+func registerReconstructCmd() {
+	autoCmd.AddCommand(autoReconstructCmd)
+	autoReconstructCmd.Flags().Bool("dry-run", false, "List the recovered tasks without saving")
+	autoReconstructCmd.Flags().Bool("force", false, "Rebuild even if prd.json or its backup can still be read")
+}
+
+func runAutoReconstruct(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	force, _ := cmd.Flags().GetBool("force")
+
+	return withProjectLock(cmd, cwd, func() error {
+		store, err := core.OpenProjectAutoStore(cwd)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+		if err := checkPRDLost(store, force); err != nil {
+			return err
+		}
+
+		recovered, err := core.ReconstructTasks(cwd)
+		if err != nil {
+			return err
+		}
+		if len(recovered) == 0 {
+			return fmt.Errorf("no tasks found in progress.md or the git history; run 'samuel auto init' to start over")
+		}
+		ui.Section(fmt.Sprintf("Recovered %d task(s)", len(recovered)))
+		if dryRun {
+			for _, r := range recovered {
+				printRecoveredTask(r)
+			}
+			return nil
+		}
+		tasks, err := confirmRecoveredTasks(recovered)
+		if err != nil || len(tasks) == 0 {
+			ui.Info("Nothing restored")
+			return err
+		}
+		return saveReconstructedPRD(cwd, store, tasks)
+	})
+}
+
+// checkPRDLost refuses to replace a plan that can still be loaded, from
+// prd.json or its backup, unless forced.
+func checkPRDLost(store core.AutoStore, force bool) error {
+	prd, err := store.LoadPRD()
+	switch {
+	case err != nil || force:
+		return nil
+	case prd.RecoveredFromBackup():
+		return fmt.Errorf("prd.json is damaged but its backup can be read; any auto command uses the backup and the next save restores prd.json (--force to rebuild anyway)")
+	default:
+		return fmt.Errorf("prd.json is intact; nothing to reconstruct (--force to rebuild anyway)")
+	}
+}
+
+func printRecoveredTask(r core.RecoveredTask) {
+	ui.ListItem(1, "%s  [%s] %s", r.Task.ID, r.Task.Status, r.Task.Title)
+	ui.Dim("      from %s", strings.Join(r.Sources, ", "))
+}
+
+// confirmRecoveredTasks asks about each recovered task and returns the
+// accepted ones.
+func confirmRecoveredTasks(recovered []core.RecoveredTask) ([]core.AutoTask, error) {
+	var tasks []core.AutoTask
+	for _, r := range recovered {
+		printRecoveredTask(r)
+		ok, err := approve(promptAutoReconstruct, fmt.Sprintf("Restore task %s?", r.Task.ID), true)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			tasks = append(tasks, r.Task)
+		}
+	}
+	return tasks, nil
+}
+
+// saveReconstructedPRD writes a new prd.json with the default config and
+// tasks, setting an unreadable prd.json aside first.
+func saveReconstructedPRD(cwd string, store core.AutoStore, tasks []core.AutoTask) error {
+	prdPath := core.GetAutoPRDPath(cwd)
+	if _, err := os.Stat(prdPath); err == nil {
+		if err := os.Rename(prdPath, prdPath+corruptPRDSuffix); err != nil {
+			return fmt.Errorf("failed to set aside the damaged prd.json: %w", err)
+		}
+		ui.Info("Kept the damaged file as %s%s", core.AutoPRDFile, corruptPRDSuffix)
+	}
+	if err := os.MkdirAll(filepath.Dir(prdPath), 0755); err != nil {
+		return fmt.Errorf("failed to create auto directory: %w", err)
+	}
+
+	prd := core.NewAutoPRD(filepath.Base(cwd), "Reconstructed from progress.md and git history")
+	prd.Tasks = tasks
+	if err := store.SavePRD(prd); err != nil {
+		return fmt.Errorf("failed to save prd.json: %w", err)
+	}
+	ui.Success("Rebuilt prd.json with %d task(s)", len(tasks))
+	ui.Warn("Config is reset to the defaults, and priorities, dependencies and acceptance criteria were lost")
+	ui.Info("Review .claude/auto/prd.json before running 'samuel auto start'")
+	return nil
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newReconstructCmd(dir string, force bool) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("target", dir, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().Bool("force", force, "")
+	return cmd
+}
+
+func TestRunAutoReconstruct(t *testing.T) {
+	dir := t.TempDir()
+	autoDir := core.GetAutoDir(dir)
+	if err := os.MkdirAll(autoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	progress := "[2026-03-01T10:00:00Z] [iteration:1] [task:1] STARTED: Set up CI\n" +
+		"[2026-03-01T10:30:00Z] [iteration:2] [task:2] STARTED: Add linting\n"
+	if err := os.WriteFile(filepath.Join(autoDir, core.AutoProgressFile), []byte(progress), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prdPath := core.GetAutoPRDPath(dir)
+	if err := os.WriteFile(prdPath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var asked []string
+	orig := confirmPrompt
+	t.Cleanup(func() { confirmPrompt = orig })
+	confirmPrompt = func(label string, _ bool) (bool, error) {
+		asked = append(asked, label)
+		return label == "Restore task 1?", nil
+	}
+
+	if err := runAutoReconstruct(newReconstructCmd(dir, false), nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(asked) != 2 {
+		t.Errorf("asked %v, want one prompt per task", asked)
+	}
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prd.Tasks) != 1 || prd.Tasks[0].ID != "1" || prd.Tasks[0].Status != core.TaskStatusInProgress {
+		t.Errorf("tasks = %+v, want only the confirmed task 1", prd.Tasks)
+	}
+	if data, _ := os.ReadFile(prdPath + corruptPRDSuffix); string(data) != "{not json" {
+		t.Errorf("damaged prd.json should be kept, got %q", data)
+	}
+
+	if err := runAutoReconstruct(newReconstructCmd(dir, false), nil); err == nil {
+		t.Error("an intact prd.json should not be rebuilt without --force")
+	}
+}
@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoRenumberCmd = &cobra.Command{
+	Use:   "renumber",
+	Short: "Normalize task IDs in prd.json",
+	Long: `Rewrite task IDs into a gapless hierarchical scheme, in list order.
+
+Top-level tasks become 1, 2, 3 (or 1.0, 2.0, 3.0 when prd.json uses that
+form) and subtasks become 1.1, 1.2, 1.2.1. A task's parent is its parent_id,
+or else the task its ID nests under. Parent and dependency references are
+updated to the new IDs; progress.md and the event log keep the old ones.
+
+Examples:
+  samuel auto renumber --dry-run   # Show the new IDs without saving
+  samuel auto renumber`,
+	Args: cobra.NoArgs,
+	RunE: runAutoRenumber,
+}
+
+func registerRenumberCmd() {
+	autoCmd.AddCommand(autoRenumberCmd)
+	autoRenumberCmd.Flags().Bool("dry-run", false, "Show the new IDs without saving")
+}
+
+func runAutoRenumber(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	return withProjectLock(cmd, cwd, func() error {
+		store, prd, err := openAutoState(cwd)
+		if err != nil {
+			return err
+		}
+		defer store.Close()
+
+		renames := prd.RenumberTasks()
+		if len(renames) == 0 {
+			ui.Success("Task IDs are already normalized")
+			return nil
+		}
+		for _, r := range renames {
+			ui.ListItem(1, "%s -> %s", r.Old, r.New)
+		}
+		if dryRun {
+			ui.Info("Dry run: %d task ID(s) would change", len(renames))
+			return nil
+		}
+
+		if err := store.SavePRD(prd); err != nil {
+			return fmt.Errorf("failed to save prd.json: %w", err)
+		}
+		ui.Success("Renumbered %d task(s)", len(renames))
+		return nil
+	})
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestRunAutoRenumber(t *testing.T) {
+	dir, prdPath := setupTestPRD(t, []core.AutoTask{
+		{ID: "2", Title: "Parent", Status: core.TaskStatusPending},
+		{ID: "2.4", Title: "Child", Status: core.TaskStatusPending, ParentID: "2"},
+		{ID: "7", Title: "Later", Status: core.TaskStatusPending, DependsOn: []string{"2.4"}},
+	})
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	for _, dryRun := range []bool{true, false} {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("dry-run", dryRun, "")
+		if err := runAutoRenumber(cmd, nil); err != nil {
+			t.Fatalf("runAutoRenumber(dry-run=%v) error = %v", dryRun, err)
+		}
+
+		prd, err := core.LoadAutoPRD(prdPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantLast, wantDep := "2", "1.1"
+		if dryRun {
+			wantLast, wantDep = "7", "2.4"
+		}
+		if last := prd.Tasks[2]; last.ID != wantLast || last.DependsOn[0] != wantDep {
+			t.Errorf("dry-run=%v: last task = %s depending on %v, want %s depending on %s",
+				dryRun, last.ID, last.DependsOn, wantLast, wantDep)
+		}
+	}
+}
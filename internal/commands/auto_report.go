@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// runReporter collects loop statistics and delivers the post-run digest.
+type runReporter struct {
+	cwd             string
+	prdPath         string
+	digestPath      string
+	email           *core.EmailReportConfig
+	webhooks        []string
+	startSHA        string
+	completedBefore map[string]bool
+	report          *core.RunReport
+}
+
+// newRunReporter returns a reporter when a digest file, email report or
+// webhook is configured via --report or config.report, and nil otherwise.
+func newRunReporter(cmd *cobra.Command, cwd string, prd *core.AutoPRD) *runReporter {
+	digestPath, _ := cmd.Flags().GetString("report")
+	var email *core.EmailReportConfig
+	var webhooks []string
+	if prd.Config.Report != nil {
+		if digestPath == "" {
+			digestPath = prd.Config.Report.DigestFile
+		}
+		email = prd.Config.Report.Email
+		webhooks = prd.Config.Report.Webhooks
+	}
+	if digestPath == "" && email == nil && len(webhooks) == 0 {
+		return nil
+	}
+	if digestPath != "" && !filepath.IsAbs(digestPath) {
+		digestPath = filepath.Join(cwd, digestPath)
+	}
+
+	return &runReporter{
+		cwd:             cwd,
+		prdPath:         core.GetAutoPRDPath(cwd),
+		digestPath:      digestPath,
+		email:           email,
+		webhooks:        webhooks,
+		startSHA:        core.GitHeadSHA(cwd),
+		completedBefore: core.CompletedTaskIDs(prd),
+		report:          &core.RunReport{StartedAt: time.Now()},
+	}
+}
+
+// track wraps the loop callbacks to count iterations, failures, and
+// time spent inside the agent.
+func (r *runReporter) track(cfg *core.LoopConfig) {
+	onStart, onEnd := cfg.OnIterStart, cfg.OnIterEnd
+	var iterStarted time.Time
+
+	cfg.OnIterStart = func(iter int, iterType string) {
+		r.report.Iterations++
+		iterStarted = time.Now()
+		if onStart != nil {
+			onStart(iter, iterType)
+		}
+	}
+	cfg.OnIterEnd = func(iter int, err error) {
+		if !iterStarted.IsZero() {
+			r.report.AgentTime += time.Since(iterStarted)
+			iterStarted = time.Time{}
+		}
+		if err != nil {
+			r.report.Failures++
+		}
+		if onEnd != nil {
+			onEnd(iter, err)
+		}
+	}
+}
+
+// deliver finalizes the report and writes/sends the digest. Delivery
+// problems are warnings: they must not mask the outcome of the run.
+func (r *runReporter) deliver(loopErr error) {
+	prd, err := core.LoadAutoPRD(r.prdPath)
+	if err != nil {
+		ui.Warn("Failed to load prd.json for run report: %v", err)
+		return
+	}
+	r.report.Finish(prd, r.completedBefore, time.Now())
+	r.report.DiffStat = core.GitDiffShortStat(r.cwd, r.startSHA)
+	if loopErr != nil {
+		r.report.ExitError = loopErr.Error()
+	}
+
+	if r.digestPath != "" {
+		if err := core.WriteRunDigest(r.digestPath, r.report); err != nil {
+			ui.Warn("Failed to write run digest: %v", err)
+		} else {
+			ui.Success("Run digest written to %s", r.digestPath)
+		}
+	}
+	if r.email != nil {
+		if err := core.SendRunDigestEmail(r.email, r.report); err != nil {
+			ui.Warn("Failed to email run digest: %v", err)
+		} else {
+			ui.Success("Run digest emailed to %d recipient(s)", len(r.email.To))
+		}
+	}
+	for i, hook := range r.webhooks {
+		if err := core.PostRunDigestWebhook(hook, r.report); err != nil {
+			ui.Warn("Failed to post run digest to webhook %d: %v", i+1, err)
+		} else {
+			ui.Success("Run digest posted to webhook %d", i+1)
+		}
+	}
+}
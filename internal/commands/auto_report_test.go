@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newReportTestCmd(report string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("report", report, "")
+	return cmd
+}
+
+func TestNewRunReporter_Disabled(t *testing.T) {
+	prd := core.NewAutoPRD("test", "desc")
+	if r := newRunReporter(newReportTestCmd(""), t.TempDir(), prd); r != nil {
+		t.Error("expected nil reporter when no report is configured")
+	}
+}
+
+func TestNewRunReporter_DigestPath(t *testing.T) {
+	dir := t.TempDir()
+	prd := core.NewAutoPRD("test", "desc")
+	prd.Config.Report = &core.ReportConfig{DigestFile: "from-config.md"}
+
+	r := newRunReporter(newReportTestCmd(""), dir, prd)
+	if r == nil || r.digestPath != filepath.Join(dir, "from-config.md") {
+		t.Fatalf("expected config digest path resolved against project dir, got %+v", r)
+	}
+
+	r = newRunReporter(newReportTestCmd("flag.md"), dir, prd)
+	if r.digestPath != filepath.Join(dir, "flag.md") {
+		t.Errorf("--report should override config, got %s", r.digestPath)
+	}
+}
+
+func TestRunReporter_TrackAndDeliver(t *testing.T) {
+	dir, prdPath := setupTestPRD(t, []core.AutoTask{
+		{ID: "1", Title: "Already done", Status: core.TaskStatusCompleted},
+		{ID: "2", Title: "Todo", Status: core.TaskStatusPending},
+	})
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatalf("failed to load prd: %v", err)
+	}
+
+	r := newRunReporter(newReportTestCmd("digest.md"), dir, prd)
+	var cfg core.LoopConfig
+	var endCalls int
+	cfg.OnIterEnd = func(int, error) { endCalls++ }
+	r.track(&cfg)
+
+	cfg.OnIterStart(1, core.IterationTypeImplementation)
+	cfg.OnIterEnd(1, errors.New("agent crashed"))
+	cfg.OnIterStart(2, core.IterationTypeImplementation)
+	cfg.OnIterEnd(2, nil)
+
+	if r.report.Iterations != 2 || r.report.Failures != 1 {
+		t.Errorf("iterations=%d failures=%d, want 2 and 1", r.report.Iterations, r.report.Failures)
+	}
+	if endCalls != 2 {
+		t.Errorf("original OnIterEnd called %d times, want 2", endCalls)
+	}
+
+	prd.Tasks[1].Status = core.TaskStatusCompleted
+	if err := prd.Save(prdPath); err != nil {
+		t.Fatalf("failed to save prd: %v", err)
+	}
+	r.deliver(nil)
+
+	content, err := os.ReadFile(filepath.Join(dir, "digest.md"))
+	if err != nil {
+		t.Fatalf("digest not written: %v", err)
+	}
+	if !strings.Contains(string(content), "**Tasks completed**: 1") {
+		t.Errorf("digest should count only tasks completed during the run:\n%s", content)
+	}
+}
+
+func TestExecuteAutoLoop_InterruptWritesDigest(t *testing.T) {
+	cfg := setupPilotIteration(t, "0")
+	cfg.MaxIterations, cfg.PauseSecs = 3, 1
+	prd, err := core.LoadAutoPRD(cfg.PRDPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	reporter := newRunReporter(newReportTestCmd("digest.md"), cfg.ProjectDir, prd)
+	iterations := 0
+	cfg.OnIterStart = func(int, string) {
+		iterations++
+		signalSelf(t, syscall.SIGTERM)
+	}
+
+	err = executeAutoLoop(cfg, reporter)
+	if !errors.Is(err, core.ErrInterrupted) || ExitCode(err) != interruptExitCode {
+		t.Fatalf("executeAutoLoop() error = %v (exit %d), want interrupted", err, ExitCode(err))
+	}
+	if iterations != 1 {
+		t.Errorf("ran %d iterations, want the interrupted one only", iterations)
+	}
+	content, err := os.ReadFile(filepath.Join(cfg.ProjectDir, "digest.md"))
+	if err != nil {
+		t.Fatalf("digest not written on interrupt: %v", err)
+	}
+	if !strings.Contains(string(content), "**Exit error**: "+core.ErrInterrupted.Error()) {
+		t.Errorf("digest should record the interrupt:\n%s", content)
+	}
+}
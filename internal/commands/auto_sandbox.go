@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoSandboxCmd = &cobra.Command{
+	Use:   "sandbox",
+	Short: "Manage sandbox containers started by the loop",
+	Long: `Manage Docker containers and sandbox VMs started by samuel.
+
+Every container started in docker mode is labeled with the project it
+belongs to, so containers left behind by interrupted runs can be removed.
+
+Subcommands:
+  prune     Remove stray sandbox containers (and optionally images)`,
+}
+
+var autoSandboxPruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove stray sandbox containers",
+	Long: `Remove containers and sandbox VMs started by samuel for this project.
+
+Examples:
+  samuel auto sandbox prune
+  samuel auto sandbox prune --dry-run
+  samuel auto sandbox prune --all
+  samuel auto sandbox prune --images`,
+	RunE: runAutoSandboxPrune,
+}
+
+func registerSandboxCmd() {
+	autoCmd.AddCommand(autoSandboxCmd)
+	autoSandboxCmd.AddCommand(autoSandboxPruneCmd)
+
+	autoSandboxPruneCmd.Flags().Bool("all", false, "Prune containers from all projects, not just this one")
+	autoSandboxPruneCmd.Flags().Bool("images", false, "Also remove the configured sandbox image")
+	autoSandboxPruneCmd.Flags().Bool("dry-run", false, "Show what would be removed without removing")
+}
+
+func runAutoSandboxPrune(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	if err := core.CheckDockerAvailable(); err != nil {
+		return err
+	}
+
+	opts := core.SandboxPruneOptions{ProjectDir: cwd}
+	if all, _ := cmd.Flags().GetBool("all"); all {
+		opts.ProjectDir = ""
+	}
+	opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+	if withImages, _ := cmd.Flags().GetBool("images"); withImages {
+		opts.Images = []string{configuredSandboxImage(cwd)}
+	}
+
+	result, err := core.PruneSandboxes(opts)
+	if err != nil {
+		return err
+	}
+	printPruneResult(result, opts.DryRun)
+	return nil
+}
+
+// configuredSandboxImage returns the project's docker image, falling back
+// to the default image when prd.json is missing or leaves it unset.
+func configuredSandboxImage(cwd string) string {
+	prd, err := core.LoadAutoPRD(core.GetAutoPRDPath(cwd))
+	if err == nil && prd.Config.SandboxImage != "" {
+		return prd.Config.SandboxImage
+	}
+	return core.DefaultSandboxImage
+}
+
+func printPruneResult(result *core.SandboxPruneResult, dryRun bool) {
+	verb := "Removed"
+	if dryRun {
+		verb = "Would remove"
+	}
+
+	total := len(result.Containers) + len(result.Sandboxes) + len(result.Images)
+	if total == 0 && len(result.Errors) == 0 {
+		ui.Info("No stray sandbox containers found")
+		return
+	}
+	for _, id := range result.Containers {
+		ui.SuccessItem(1, "%s container %s", verb, id)
+	}
+	for _, name := range result.Sandboxes {
+		ui.SuccessItem(1, "%s sandbox %s", verb, name)
+	}
+	for _, image := range result.Images {
+		ui.SuccessItem(1, "%s image %s", verb, image)
+	}
+	for _, msg := range result.Errors {
+		ui.ErrorItem(1, "%s", msg)
+	}
+}
+
+// installSandboxCleanup removes the project's sandbox containers when the
+// loop exits, including after an interrupt (see notifyInterrupt). The
+// returned function must be deferred by the caller; it is a no-op without
+// a container sandbox.
+func installSandboxCleanup(cwd, sandbox string) func() {
+	if sandbox == "" || sandbox == core.SandboxNone || sandbox == core.SandboxProcess {
+		return func() {}
+	}
+	return func() {
+		result, err := core.PruneSandboxes(core.SandboxPruneOptions{ProjectDir: cwd})
+		if err != nil {
+			ui.Warn("Sandbox cleanup failed: %v", err)
+			return
+		}
+		for _, msg := range result.Errors {
+			ui.Warn("Sandbox cleanup: %s", msg)
+		}
+	}
+}
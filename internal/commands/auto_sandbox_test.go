@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+func TestConfiguredSandboxImage(t *testing.T) {
+	if got := configuredSandboxImage(t.TempDir()); got != core.DefaultSandboxImage {
+		t.Errorf("without prd.json got %q, want default %q", got, core.DefaultSandboxImage)
+	}
+
+	dir, prdPath := setupTestPRD(t, nil)
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatalf("failed to load prd: %v", err)
+	}
+	prd.Config.SandboxImage = "golang:1.22"
+	if err := prd.Save(prdPath); err != nil {
+		t.Fatalf("failed to save prd: %v", err)
+	}
+
+	if got := configuredSandboxImage(dir); got != "golang:1.22" {
+		t.Errorf("configuredSandboxImage() = %q, want golang:1.22", got)
+	}
+}
+
+func TestInstallSandboxCleanup_NoSandbox(t *testing.T) {
+	for _, mode := range []string{"", core.SandboxNone} {
+		cleanup := installSandboxCleanup(t.TempDir(), mode)
+		if cleanup == nil {
+			t.Fatalf("cleanup for mode %q is nil", mode)
+		}
+		cleanup()
+	}
+}
+
+func TestPrintPruneResult(t *testing.T) {
+	// Output only; verify every branch runs without panicking.
+	printPruneResult(&core.SandboxPruneResult{}, false)
+	printPruneResult(&core.SandboxPruneResult{
+		Containers: []string{"abc"},
+		Sandboxes:  []string{"samuel-app-1234abcd"},
+		Images:     []string{"node:lts"},
+		Errors:     []string{"def: no such container"},
+	}, true)
+}
@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// userAutoSettingsLabel names the user defaults file in messages.
+const userAutoSettingsLabel = "~/.config/samuel/" + core.UserAutoSettingsFile
+
+// flagOrUserString returns the flag value when given on the command line,
+// else the user's default when set, else the flag's default.
+func flagOrUserString(cmd *cobra.Command, name, userValue string) string {
+	value, _ := cmd.Flags().GetString(name)
+	if !cmd.Flags().Changed(name) && userValue != "" {
+		return userValue
+	}
+	return value
+}
+
+// flagOrUserInt is flagOrUserString for int flags; a zero user value is
+// unset.
+func flagOrUserInt(cmd *cobra.Command, name string, userValue int) int {
+	value, _ := cmd.Flags().GetInt(name)
+	if !cmd.Flags().Changed(name) && userValue != 0 {
+		return userValue
+	}
+	return value
+}
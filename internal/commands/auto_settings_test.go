@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFlagOrUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		userStr string
+		userInt int
+		wantStr string
+		wantInt int
+	}{
+		{name: "flag defaults", wantStr: "none", wantInt: 50},
+		{name: "user defaults", userStr: "docker", userInt: 80, wantStr: "docker", wantInt: 80},
+		{name: "flags win", args: []string{"--sandbox", "none", "--max-iterations", "10"}, userStr: "docker", userInt: 80, wantStr: "none", wantInt: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("sandbox", "none", "")
+			cmd.Flags().Int("max-iterations", 50, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatal(err)
+			}
+			if got := flagOrUserString(cmd, "sandbox", tt.userStr); got != tt.wantStr {
+				t.Errorf("flagOrUserString() = %q, want %q", got, tt.wantStr)
+			}
+			if got := flagOrUserInt(cmd, "max-iterations", tt.userInt); got != tt.wantInt {
+				t.Errorf("flagOrUserInt() = %d, want %d", got, tt.wantInt)
+			}
+		})
+	}
+}
+
+func TestResolveAITool_UserPreference(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("ai-tool", "", "")
+	if got := resolveAITool(cmd, "codex"); got != "codex" {
+		t.Errorf("resolveAITool() = %q, want the user's codex", got)
+	}
+	if err := cmd.ParseFlags([]string{"--ai-tool", "amp"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := resolveAITool(cmd, "codex"); got != "amp" {
+		t.Errorf("resolveAITool() = %q, want --ai-tool amp", got)
+	}
+}
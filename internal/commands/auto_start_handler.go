@@ -1,8 +1,11 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/ar4mirez/samuel/internal/core"
 	"github.com/ar4mirez/samuel/internal/ui"
@@ -10,18 +13,28 @@ import (
 )
 
 func runAutoStart(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
+	}
+
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
 	}
+	defer store.Close()
 
-	prdPath := core.GetAutoPRDPath(cwd)
-	prd, err := core.LoadAutoPRD(prdPath)
+	user, err := core.LoadUserAutoSettings()
 	if err != nil {
-		return fmt.Errorf("failed to load prd.json. Run 'samuel auto init' first: %w", err)
+		return err
 	}
+	user.ApplyTo(&prd.Config)
+	user.ApplyWebhooks(&prd.Config)
 
 	sandbox, sandboxImage, sandboxTemplate := resolveSandboxFlags(cmd, prd)
+	if offline, _ := cmd.Flags().GetBool("sandbox-offline"); offline {
+		prd.Config.SandboxOffline = true
+	}
 
 	if !core.IsValidSandboxMode(sandbox) {
 		return fmt.Errorf("unsupported sandbox mode: %s (supported: %v)", sandbox, core.GetSupportedSandboxModes())
@@ -31,32 +44,77 @@ func runAutoStart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	scope, err := resolveRunScope(cmd, prd)
+	if err != nil {
+		return err
+	}
+
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 	if dryRun {
-		return printStartDryRun(prd, cwd, sandbox, sandboxImage, sandboxTemplate)
+		return printStartDryRun(prd, cwd, sandbox, sandboxImage, sandboxTemplate, scope)
 	}
 
-	skipConfirm, _ := cmd.Flags().GetBool("yes")
-	if !skipConfirm {
-		confirmed, confirmErr := ui.Confirm("Start autonomous loop?", false)
-		if confirmErr != nil || !confirmed {
-			ui.Info("Cancelled")
-			return nil
-		}
+	confirmed, confirmErr := approve(promptAutoStart, "Start autonomous loop?", false)
+	if confirmErr != nil || !confirmed {
+		ui.Info("Cancelled")
+		return nil
 	}
 
 	cfg := buildLoopConfig(cmd, cwd, prd, sandbox, sandboxImage, sandboxTemplate)
+	cfg.Store = store
+	if err := applyRunScope(&cfg, prd, scope); err != nil {
+		return err
+	}
 
+	return withProjectLock(cmd, cwd, func() error {
+		return executeAutoLoop(cfg, newRunReporter(cmd, cwd, prd))
+	})
+}
+
+// executeAutoLoop runs the loop, delivers the optional run report, and
+// prints the final summary.
+func executeAutoLoop(cfg core.LoopConfig, reporter *runReporter) error {
 	ui.Info("Starting auto loop...")
 	ui.Print("  AI Tool:  %s", cfg.AITool)
-	ui.Print("  Sandbox:  %s", sandbox)
+	ui.Print("  Sandbox:  %s", cfg.Sandbox)
+	if cfg.Milestone != "" {
+		ui.Print("  Milestone: %s", cfg.Milestone)
+	}
+	if len(cfg.SandboxMounts) > 0 && cfg.Sandbox != core.SandboxDocker {
+		ui.Warn("sandbox_mounts only apply to the docker sandbox mode; ignoring them")
+	}
+	if cfg.SandboxOffline && cfg.Sandbox != core.SandboxProcess {
+		ui.Warn("sandbox_offline only applies to the process sandbox mode; ignoring it")
+	}
+	if !cfg.Deadline.IsZero() {
+		ui.Print("  Budget:   wrap-up by %s", cfg.Deadline.Format(time.Kitchen))
+	}
 	ui.Print("")
 
-	if err := core.RunAutoLoop(cfg); err != nil {
-		return fmt.Errorf("auto loop exited with error: %w", err)
+	if reporter != nil {
+		reporter.track(&cfg)
+	}
+
+	stop, releaseInterrupt := notifyInterrupt()
+	defer releaseInterrupt()
+	cfg.Stop = stop
+
+	cleanupSandbox := installSandboxCleanup(cfg.ProjectDir, cfg.Sandbox)
+	loopErr := core.RunAutoLoop(cfg)
+	cleanupSandbox()
+	enforceRetention(cfg.ProjectDir, core.GCEvents)
+	if reporter != nil {
+		reporter.deliver(loopErr)
+	}
+	if errors.Is(loopErr, core.ErrInterrupted) {
+		printLoopSummary(cfg.PRDPath)
+		return withExitCode(interruptExitCode, loopErr)
+	}
+	if loopErr != nil {
+		return fmt.Errorf("auto loop exited with error: %w", loopErr)
 	}
 
-	printLoopSummary(prdPath)
+	printLoopSummary(cfg.PRDPath)
 	return nil
 }
 
@@ -90,6 +148,11 @@ func buildLoopConfig(cmd *cobra.Command, cwd string, prd *core.AutoPRD, sandbox,
 	}
 
 	cfg.OnIterStart = func(iter int, iterType string) {
+		reloadThemeIfChanged(cwd)
+		if iterType == core.IterationTypeWrapUp {
+			ui.Warn("[iteration:%d] Time budget nearly exhausted - running wrap-up iteration", iter)
+			return
+		}
 		ui.Info("[iteration:%d] Starting iteration %d of %d", iter, iter, cfg.MaxIterations)
 	}
 	cfg.OnIterEnd = func(iter int, err error) {
@@ -99,14 +162,76 @@ func buildLoopConfig(cmd *cobra.Command, cwd string, prd *core.AutoPRD, sandbox,
 			ui.Info("[iteration:%d] Iteration %d complete.", iter, iter)
 		}
 	}
+	cfg.OnIterEvent = reportIterationEvent
+	cfg.OnCommitPolicy = reportCommitPolicy
+	cfg.OnPathGuard = reportPathGuard
+	cfg.OnSync = reportSync
+	cfg.OnHook = reportIterationHook
+	cfg.OnTelemetry = reportTelemetry
 
 	return cfg
 }
 
-func printStartDryRun(prd *core.AutoPRD, cwd, sandbox, sandboxImage, sandboxTemplate string) error {
+// runScope holds the optional limits of a single run.
+type runScope struct {
+	duration  time.Duration
+	milestone string
+}
+
+// resolveRunScope reads and validates --duration and --milestone.
+func resolveRunScope(cmd *cobra.Command, prd *core.AutoPRD) (runScope, error) {
+	var scope runScope
+	scope.duration, _ = cmd.Flags().GetDuration("duration")
+	if scope.duration < 0 {
+		return scope, fmt.Errorf("invalid duration: %s (must be positive)", scope.duration)
+	}
+	scope.milestone, _ = cmd.Flags().GetString("milestone")
+	if scope.milestone != "" {
+		if err := prd.ValidateMilestone(scope.milestone); err != nil {
+			return scope, err
+		}
+	}
+	return scope, nil
+}
+
+// applyRunScope applies the milestone and time budget limits to the loop.
+func applyRunScope(cfg *core.LoopConfig, prd *core.AutoPRD, scope runScope) error {
+	if scope.milestone != "" {
+		if err := applyMilestoneScope(cfg, prd, scope.milestone); err != nil {
+			return err
+		}
+	}
+	if scope.duration > 0 {
+		return applyTimeBudget(cfg, prd, scope.duration)
+	}
+	return nil
+}
+
+// applyTimeBudget sets the loop deadline for a time-boxed run and writes
+// the wrap-up prompt used for its final iteration.
+func applyTimeBudget(cfg *core.LoopConfig, prd *core.AutoPRD, duration time.Duration) error {
+	wrapUpPath := filepath.Join(filepath.Dir(cfg.PRDPath), core.AutoWrapUpPromptFile)
+	prompt := core.GenerateWrapUpPrompt(prd.Config)
+	if err := os.WriteFile(wrapUpPath, []byte(prompt), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", core.AutoWrapUpPromptFile, err)
+	}
+
+	cfg.Deadline = time.Now().Add(duration)
+	cfg.WrapUpReserve = core.WrapUpReserve(duration)
+	cfg.WrapUpPromptPath = wrapUpPath
+	return nil
+}
+
+func printStartDryRun(prd *core.AutoPRD, cwd, sandbox, sandboxImage, sandboxTemplate string, scope runScope) error {
 	ui.Header("Dry Run - Auto Loop")
 	ui.Print("  AI Tool:    %s", prd.Config.AITool)
 	ui.Print("  Iterations: %d", prd.Config.MaxIterations)
+	if scope.duration > 0 {
+		ui.Print("  Budget:     %s (wrap-up reserve: %s)", scope.duration, core.WrapUpReserve(scope.duration))
+	}
+	if scope.milestone != "" {
+		ui.Print("  Milestone:  %s", scope.milestone)
+	}
 	ui.Print("  Sandbox:    %s", sandbox)
 	if sandbox == core.SandboxDocker {
 		image := sandboxImage
@@ -114,6 +239,15 @@ func printStartDryRun(prd *core.AutoPRD, cwd, sandbox, sandboxImage, sandboxTemp
 			image = core.DefaultSandboxImage
 		}
 		ui.Print("  Image:      %s", image)
+		for _, m := range prd.Config.SandboxMounts {
+			ui.Print("  Mount:      %s", formatSandboxMount(m))
+		}
+	}
+	if sandbox == core.SandboxProcess {
+		ui.Print("  Writable:   %s, temp directories, %s state", cwd, prd.Config.AITool)
+		if prd.Config.SandboxOffline {
+			ui.Print("  Network:    blocked")
+		}
 	}
 	if sandbox == core.SandboxDockerSandbox {
 		ui.Print("  Workspace:  %s (same path inside VM)", cwd)
@@ -122,17 +256,41 @@ func printStartDryRun(prd *core.AutoPRD, cwd, sandbox, sandboxImage, sandboxTemp
 		}
 		ui.Print("  Note:       API keys read from shell config (~/.bashrc, ~/.zshrc)")
 	}
-	ui.Print("  Tasks:      %d pending", countTaskStatuses(prd)["pending"])
+	scoped := &core.AutoPRD{Tasks: prd.FilterTasks(core.TaskFilter{Milestone: scope.milestone})}
+	ui.Print("  Tasks:      %d pending", countTaskStatuses(scoped)["pending"])
 	ui.Print("")
 	ui.Print("  Quality checks:")
 	for _, check := range prd.Config.QualityChecks {
 		ui.Print("    - %s", check)
 	}
+	printDryRunHooks("Pre-iteration hooks", prd.Config.PreIteration)
+	printDryRunHooks("Post-iteration hooks", prd.Config.PostIteration)
 	ui.Print("")
 	ui.Info("Run without --dry-run to execute")
 	return nil
 }
 
+// printDryRunHooks lists the commands of one hook phase, if any.
+func printDryRunHooks(label string, commands []string) {
+	if len(commands) == 0 {
+		return
+	}
+	ui.Print("")
+	ui.Print("  %s:", label)
+	for _, command := range commands {
+		ui.Print("    - %s", command)
+	}
+}
+
+// formatSandboxMount renders a mount as "source -> target (ro)".
+func formatSandboxMount(m core.SandboxMount) string {
+	mode := "rw"
+	if m.ReadOnly {
+		mode = "ro"
+	}
+	return fmt.Sprintf("%s -> %s (%s)", m.Source, m.Target, mode)
+}
+
 func printLoopSummary(prdPath string) {
 	finalPRD, err := core.LoadAutoPRD(prdPath)
 	if err != nil {
@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+func TestApplyTimeBudget(t *testing.T) {
+	dir, prdPath := setupTestPRD(t, []core.AutoTask{
+		{ID: "1", Title: "First", Status: core.TaskStatusPending},
+	})
+	prd, err := core.LoadAutoPRD(prdPath)
+	if err != nil {
+		t.Fatalf("failed to load prd: %v", err)
+	}
+
+	cfg := core.NewLoopConfig(dir, prd)
+	before := time.Now()
+	if err := applyTimeBudget(&cfg, prd, 2*time.Hour); err != nil {
+		t.Fatalf("applyTimeBudget returned error: %v", err)
+	}
+
+	if cfg.Deadline.Before(before.Add(2 * time.Hour)) {
+		t.Errorf("deadline %v is earlier than expected", cfg.Deadline)
+	}
+	if cfg.WrapUpReserve != core.DefaultWrapUpReserve {
+		t.Errorf("WrapUpReserve = %v, want %v", cfg.WrapUpReserve, core.DefaultWrapUpReserve)
+	}
+
+	wantPath := filepath.Join(dir, core.AutoDir, core.AutoWrapUpPromptFile)
+	if cfg.WrapUpPromptPath != wantPath {
+		t.Errorf("WrapUpPromptPath = %s, want %s", cfg.WrapUpPromptPath, wantPath)
+	}
+	content, err := os.ReadFile(wantPath)
+	if err != nil {
+		t.Fatalf("wrap-up prompt not written: %v", err)
+	}
+	if !strings.Contains(string(content), "Wrap-Up Iteration Prompt") {
+		t.Error("wrap-up prompt has unexpected content")
+	}
+}
+
+func TestRunAutoStart_NegativeDuration(t *testing.T) {
+	dir, _ := setupTestPRD(t, []core.AutoTask{
+		{ID: "1", Title: "First", Status: core.TaskStatusPending},
+	})
+
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	if err := autoStartCmd.Flags().Set("duration", "-1h"); err != nil {
+		t.Fatalf("failed to set flag: %v", err)
+	}
+	t.Cleanup(func() { autoStartCmd.Flags().Set("duration", "0s") })
+
+	err = runAutoStart(autoStartCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "invalid duration") {
+		t.Errorf("expected invalid duration error, got %v", err)
+	}
+}
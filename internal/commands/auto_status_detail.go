@@ -0,0 +1,84 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// recentIterationEvents is how many iterations 'auto status --detailed'
+// shows.
+const recentIterationEvents = 10
+
+// printIterationChanges lists the file changes of the most recent
+// iterations, flagging those that touched unusually many files.
+func printIterationChanges(store core.AutoStore) {
+	ui.Section("Recent Iterations")
+	events, err := store.LoadEvents()
+	if err != nil {
+		ui.Warn("Failed to read event log: %v", err)
+		return
+	}
+	if len(events) == 0 {
+		ui.Dim("No iterations recorded yet")
+		return
+	}
+
+	if len(events) > recentIterationEvents {
+		events = events[len(events)-recentIterationEvents:]
+	}
+	for _, e := range events {
+		line := fmt.Sprintf("#%d %s %s: +%d ~%d -%d (%d files)", e.Iteration, e.Type,
+			e.Timestamp, e.Changes.Added, e.Changes.Modified, e.Changes.Deleted, e.Changes.Total())
+		switch {
+		case e.Changes.Total() > core.FileChangeWarnThreshold:
+			ui.WarnItem(1, "%s - unusually many files", line)
+		case e.Error != "":
+			ui.ErrorItem(1, "%s - %s", line, e.Error)
+		default:
+			ui.ListItem(1, "%s", line)
+		}
+		for _, path := range e.Changes.Sample {
+			ui.ListItem(2, "%s", path)
+		}
+		if hidden := e.Changes.Total() - len(e.Changes.Sample); hidden > 0 {
+			ui.ListItem(2, "... and %d more", hidden)
+		}
+	}
+}
+
+// printProgressHeader shows the last iteration and the blockers recorded
+// in the front section of progress.md, if the loop has written one.
+func printProgressHeader(cwd string) {
+	header, err := core.ReadProgressHeader(filepath.Join(core.GetAutoDir(cwd), core.AutoProgressFile))
+	if err != nil {
+		ui.Warn("%v", err)
+		return
+	}
+	if header == nil {
+		return
+	}
+	if header.LastIteration > 0 {
+		ui.Print("")
+		line := fmt.Sprintf("Last iteration: #%d %s %s (%s)", header.LastIteration,
+			header.LastIterationType, header.LastIterationStatus, header.UpdatedAt)
+		if header.LastIterationError != "" {
+			ui.Warn("%s - %s", line, header.LastIterationError)
+		} else {
+			ui.Info("%s", line)
+		}
+	}
+	if len(header.Blockers) == 0 {
+		return
+	}
+	ui.Section("Blockers")
+	for _, b := range header.Blockers {
+		if b.Reason != "" {
+			ui.WarnItem(1, "%s %s: %s", b.Task, b.Title, b.Reason)
+		} else {
+			ui.WarnItem(1, "%s %s", b.Task, b.Title)
+		}
+	}
+}
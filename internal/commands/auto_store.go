@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+// openAutoState opens the auto-loop store configured in samuel.yaml
+// (auto.storage) and loads the PRD. The caller closes the store.
+func openAutoState(cwd string) (core.AutoStore, *core.AutoPRD, error) {
+	store, err := core.OpenProjectAutoStore(cwd)
+	if err != nil {
+		return nil, nil, err
+	}
+	prd, err := store.LoadPRD()
+	if err != nil {
+		store.Close()
+		return nil, nil, fmt.Errorf("no auto loop found. Run 'samuel auto init' first")
+	}
+	return store, prd, nil
+}
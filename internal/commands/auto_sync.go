@@ -0,0 +1,195 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Share auto-loop state with teammates",
+	Long: `Share prd.json, progress.md and the event log through a git branch or
+an HTTP endpoint so teammates can follow a long-running loop or take it over
+from another machine.
+
+The target comes from config.sync in prd.json; the flags override it, which
+lets a fresh clone pull state before it has a prd.json. When config.sync is
+set, 'auto start' and 'auto pilot' push after every iteration and stop if
+another writer updated the shared state.
+
+Targets:
+  git   Commit the state to a dedicated branch (default samuel/auto-state)
+  http  GET/PUT a URL with ETag preconditions (S3 presigned URL, object store,
+        or any server honoring If-Match); SAMUEL_SYNC_TOKEN is sent as a
+        bearer token
+
+Examples:
+  samuel auto sync status
+  samuel auto sync push
+  samuel auto sync pull --sync-target git --branch samuel/auto-state
+  samuel auto sync pull --sync-target http --url https://state.example.com/loop.json`,
+}
+
+var autoSyncPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Upload the local auto state",
+	RunE:  runAutoSyncPush,
+}
+
+var autoSyncPullCmd = &cobra.Command{
+	Use:   "pull",
+	Short: "Replace the local auto state with the shared copy",
+	RunE:  runAutoSyncPull,
+}
+
+var autoSyncStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Compare the local auto state with the shared copy",
+	RunE:  runAutoSyncStatus,
+}
+
+func registerSyncCmd() {
+	autoCmd.AddCommand(autoSyncCmd)
+	autoSyncCmd.AddCommand(autoSyncPushCmd, autoSyncPullCmd, autoSyncStatusCmd)
+
+	autoSyncCmd.PersistentFlags().String("sync-target", "", "Sync target: git or http (default: config.sync.target)")
+	autoSyncCmd.PersistentFlags().String("remote", "", "Git remote for the git target (default: origin)")
+	autoSyncCmd.PersistentFlags().String("branch", "", "Branch for the git target (default: samuel/auto-state)")
+	autoSyncCmd.PersistentFlags().String("url", "", "URL for the http target")
+	autoSyncPushCmd.Flags().Bool("force", false, "Overwrite the shared state even if another writer updated it")
+	autoSyncPullCmd.Flags().Bool("force", false, "Discard local changes made since the last sync")
+}
+
+// resolveSyncConfig merges config.sync from prd.json with flag overrides.
+func resolveSyncConfig(cmd *cobra.Command, cwd string) (*core.SyncConfig, error) {
+	cfg := &core.SyncConfig{}
+	if prd, err := core.LoadAutoPRD(core.GetAutoPRDPath(cwd)); err == nil && prd.Config.Sync != nil {
+		*cfg = *prd.Config.Sync
+	}
+	if v, _ := cmd.Flags().GetString("sync-target"); v != "" {
+		cfg.Target = v
+	}
+	if v, _ := cmd.Flags().GetString("remote"); v != "" {
+		cfg.Remote = v
+	}
+	if v, _ := cmd.Flags().GetString("branch"); v != "" {
+		cfg.Branch = v
+	}
+	if v, _ := cmd.Flags().GetString("url"); v != "" {
+		cfg.URL = v
+	}
+	if cfg.Target == "" {
+		return nil, fmt.Errorf("no sync target configured. Set config.sync in prd.json or pass --sync-target")
+	}
+	return cfg, cfg.Validate()
+}
+
+func runAutoSyncPush(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := resolveSyncConfig(cmd, cwd)
+	if err != nil {
+		return err
+	}
+	force, _ := cmd.Flags().GetBool("force")
+
+	bundle, err := core.PushAutoState(cwd, cfg, force)
+	if err != nil {
+		return err
+	}
+	ui.Success("Pushed auto state revision %d to %s", bundle.Revision, syncTargetLabel(cfg))
+	return nil
+}
+
+func runAutoSyncPull(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := resolveSyncConfig(cmd, cwd)
+	if err != nil {
+		return err
+	}
+	force, _ := cmd.Flags().GetBool("force")
+
+	return withProjectLock(cmd, cwd, func() error {
+		bundle, err := core.PullAutoState(cwd, cfg, force)
+		if err != nil {
+			return err
+		}
+		if bundle == nil {
+			ui.Info("Nothing shared yet at %s", syncTargetLabel(cfg))
+			return nil
+		}
+		ui.Success("Pulled auto state revision %d (by %s, %s)", bundle.Revision, bundle.Writer, bundle.UpdatedAt)
+		ui.Info("Run 'samuel auto start' to take over the loop")
+		return nil
+	})
+}
+
+func runAutoSyncStatus(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	cfg, err := resolveSyncConfig(cmd, cwd)
+	if err != nil {
+		return err
+	}
+
+	status, err := core.GetSyncStatus(cwd, cfg)
+	if err != nil {
+		return err
+	}
+	printSyncStatus(cfg, status)
+	return nil
+}
+
+// printSyncStatus shows the shared revision and whether either side moved
+// since the last sync.
+func printSyncStatus(cfg *core.SyncConfig, status *core.SyncStatus) {
+	ui.Header("Auto State Sync")
+	ui.TableRow("Target", syncTargetLabel(cfg))
+	if status.Remote == nil {
+		ui.TableRow("Shared", "nothing shared yet")
+	} else {
+		ui.TableRow("Shared", fmt.Sprintf("revision %d by %s at %s",
+			status.Remote.Revision, status.Remote.Writer, status.Remote.UpdatedAt))
+	}
+	if status.Record == nil {
+		ui.TableRow("Last sync", "never")
+		return
+	}
+	ui.TableRow("Last sync", fmt.Sprintf("revision %d at %s", status.Record.Revision, status.Record.SyncedAt))
+
+	fmt.Println()
+	switch {
+	case status.LocalChanged && status.RemoteMoved:
+		ui.Warn("Both sides changed since the last sync; resolve with push or pull --force")
+	case status.RemoteMoved:
+		ui.Warn("Another writer updated the shared state; run 'samuel auto sync pull'")
+	case status.LocalChanged:
+		ui.Info("Local changes not pushed yet")
+	default:
+		ui.Success("In sync")
+	}
+}
+
+func syncTargetLabel(cfg *core.SyncConfig) string {
+	if cfg.Target == core.SyncTargetHTTP {
+		return cfg.URL
+	}
+	remote, branch := cfg.Remote, cfg.Branch
+	if remote == "" {
+		remote = core.DefaultSyncRemote
+	}
+	if branch == "" {
+		branch = core.DefaultSyncBranch
+	}
+	return remote + "/" + branch
+}
@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newAutoSyncTestCmd(flags map[string]string) *cobra.Command {
+	cmd := &cobra.Command{}
+	for _, name := range []string{"sync-target", "remote", "branch", "url"} {
+		cmd.Flags().String(name, flags[name], "")
+	}
+	return cmd
+}
+
+func TestResolveSyncConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured *core.SyncConfig
+		flags      map[string]string
+		want       core.SyncConfig
+		wantErr    bool
+	}{
+		{
+			name:       "from prd config",
+			configured: &core.SyncConfig{Target: core.SyncTargetGit, Branch: "team/state"},
+			want:       core.SyncConfig{Target: core.SyncTargetGit, Branch: "team/state"},
+		},
+		{
+			name:       "flags override prd config",
+			configured: &core.SyncConfig{Target: core.SyncTargetGit},
+			flags:      map[string]string{"sync-target": "http", "url": "https://example.com/state"},
+			want:       core.SyncConfig{Target: core.SyncTargetHTTP, URL: "https://example.com/state"},
+		},
+		{
+			name:  "flags without prd config",
+			flags: map[string]string{"sync-target": "git", "remote": "upstream"},
+			want:  core.SyncConfig{Target: core.SyncTargetGit, Remote: "upstream"},
+		},
+		{name: "nothing configured", wantErr: true},
+		{name: "http without url", flags: map[string]string{"sync-target": "http"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, prdPath := setupTestPRD(t, nil)
+			if tt.configured != nil {
+				prd, err := core.LoadAutoPRD(prdPath)
+				if err != nil {
+					t.Fatal(err)
+				}
+				prd.Config.Sync = tt.configured
+				if err := prd.Save(prdPath); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := resolveSyncConfig(newAutoSyncTestCmd(tt.flags), dir)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveSyncConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("resolveSyncConfig() = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSyncTargetLabel(t *testing.T) {
+	tests := []struct {
+		cfg  core.SyncConfig
+		want string
+	}{
+		{core.SyncConfig{Target: core.SyncTargetGit}, "origin/samuel/auto-state"},
+		{core.SyncConfig{Target: core.SyncTargetGit, Remote: "up", Branch: "b"}, "up/b"},
+		{core.SyncConfig{Target: core.SyncTargetHTTP, URL: "https://x/s"}, "https://x/s"},
+	}
+	for _, tt := range tests {
+		if got := syncTargetLabel(&tt.cfg); got != tt.want {
+			t.Errorf("syncTargetLabel(%+v) = %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package commands
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/ar4mirez/samuel/internal/core"
 	"github.com/ar4mirez/samuel/internal/ui"
@@ -10,31 +9,36 @@ import (
 )
 
 func runAutoTaskList(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
-	prd, err := core.LoadAutoPRD(core.GetAutoPRDPath(cwd))
+	store, prd, err := openAutoState(cwd)
 	if err != nil {
-		return fmt.Errorf("no auto loop found. Run 'samuel auto init' first")
+		return err
 	}
+	defer store.Close()
+
+	tasks := prd.FilterTasks(taskFilterFromFlags(cmd))
 
 	ui.Header("Tasks")
-	for _, t := range prd.Tasks {
+	completed := 0
+	for _, t := range tasks {
 		icon := taskStatusIcon(t.Status)
 		indent := 0
 		if t.ParentID != "" {
 			indent = 1
 		}
-		ui.ListItem(indent, "%s %s %s", icon, t.ID, t.Title)
+		ui.ListItem(indent, "%s %s %s%s", icon, t.ID, t.Title, formatTaskTags(t))
+		if t.Status == core.TaskStatusCompleted {
+			completed++
+		}
 	}
 
 	ui.Print("")
-	prd.RecalculateProgress()
 	ui.Print("Total: %d  Completed: %d  Pending: %d",
-		prd.Progress.TotalTasks, prd.Progress.CompletedTasks,
-		prd.Progress.TotalTasks-prd.Progress.CompletedTasks)
+		len(tasks), completed, len(tasks)-completed)
 	return nil
 }
 
@@ -54,40 +58,40 @@ func taskStatusIcon(status string) string {
 }
 
 func runAutoTaskComplete(cmd *cobra.Command, args []string) error {
-	return updateTaskStatus(args[0], func(prd *core.AutoPRD, id string) error {
+	return updateTaskStatus(cmd, args[0], func(prd *core.AutoPRD, id string) error {
 		return prd.CompleteTask(id, "", 0)
 	}, "completed")
 }
 
 func runAutoTaskSkip(cmd *cobra.Command, args []string) error {
-	return updateTaskStatus(args[0], func(prd *core.AutoPRD, id string) error {
+	return updateTaskStatus(cmd, args[0], func(prd *core.AutoPRD, id string) error {
 		return prd.SkipTask(id)
 	}, "skipped")
 }
 
 func runAutoTaskReset(cmd *cobra.Command, args []string) error {
-	return updateTaskStatus(args[0], func(prd *core.AutoPRD, id string) error {
+	return updateTaskStatus(cmd, args[0], func(prd *core.AutoPRD, id string) error {
 		return prd.ResetTask(id)
 	}, "reset to pending")
 }
 
-func updateTaskStatus(id string, fn func(*core.AutoPRD, string) error, label string) error {
-	cwd, err := os.Getwd()
+func updateTaskStatus(cmd *cobra.Command, id string, fn func(*core.AutoPRD, string) error, label string) error {
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
-	prdPath := core.GetAutoPRDPath(cwd)
-	prd, err := core.LoadAutoPRD(prdPath)
+	store, prd, err := openAutoState(cwd)
 	if err != nil {
-		return fmt.Errorf("no auto loop found. Run 'samuel auto init' first")
+		return err
 	}
+	defer store.Close()
 
 	if err := fn(prd, id); err != nil {
 		return err
 	}
 
-	if err := prd.Save(prdPath); err != nil {
+	if err := store.SavePRD(prd); err != nil {
 		return fmt.Errorf("failed to save prd.json: %w", err)
 	}
 
@@ -96,32 +100,64 @@ func updateTaskStatus(id string, fn func(*core.AutoPRD, string) error, label str
 }
 
 func runAutoTaskAdd(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
-	prdPath := core.GetAutoPRDPath(cwd)
-	prd, err := core.LoadAutoPRD(prdPath)
+	store, prd, err := openAutoState(cwd)
 	if err != nil {
-		return fmt.Errorf("no auto loop found. Run 'samuel auto init' first")
+		return err
 	}
+	defer store.Close()
 
 	task := core.AutoTask{
-		ID:       args[0],
-		Title:    args[1],
+		Title:    args[len(args)-1],
 		Status:   core.TaskStatusPending,
 		Priority: core.TaskPriorityMedium,
 	}
+	var parent string
+	if cmd != nil {
+		task.Milestone, _ = cmd.Flags().GetString("milestone")
+		task.Labels, _ = cmd.Flags().GetStringSlice("label")
+		parent, _ = cmd.Flags().GetString("parent")
+	}
+	if task.ID, err = newTaskID(prd, args[:len(args)-1], parent); err != nil {
+		return err
+	}
+	if parent != "" {
+		task.ParentID = prd.ResolveTaskID(parent)
+		task.DependsOn = []string{task.ParentID}
+	}
 
 	if err := prd.AddTask(task); err != nil {
 		return err
 	}
 
-	if err := prd.Save(prdPath); err != nil {
+	if err := store.SavePRD(prd); err != nil {
 		return fmt.Errorf("failed to save prd.json: %w", err)
 	}
 
 	ui.Success("Task %s added: %s", task.ID, task.Title)
 	return nil
 }
+
+// newTaskID returns the explicit ID in args after validating it, or the
+// next free ID under parent.
+func newTaskID(prd *core.AutoPRD, args []string, parent string) (string, error) {
+	if len(args) == 0 {
+		return prd.NextTaskID(parent)
+	}
+	id := args[0]
+	if err := core.ValidateTaskID(id); err != nil {
+		return "", err
+	}
+	if parent != "" && !prd.IsChildID(parent, id) {
+		next, err := prd.NextTaskID(parent)
+		if err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("task ID %s is not a child of %s (next free: %s)", id, parent, next)
+	}
+	return id, nil
+}
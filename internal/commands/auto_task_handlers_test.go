@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
 )
 
 func TestTaskStatusIcon(t *testing.T) {
@@ -70,7 +71,7 @@ func TestUpdateTaskStatus_Complete(t *testing.T) {
 	}
 	t.Cleanup(func() { os.Chdir(origDir) })
 
-	err = updateTaskStatus("1", func(prd *core.AutoPRD, id string) error {
+	err = updateTaskStatus(&cobra.Command{}, "1", func(prd *core.AutoPRD, id string) error {
 		return prd.CompleteTask(id, "abc123", 1)
 	}, "completed")
 	if err != nil {
@@ -111,7 +112,7 @@ func TestUpdateTaskStatus_Skip(t *testing.T) {
 	}
 	t.Cleanup(func() { os.Chdir(origDir) })
 
-	err = updateTaskStatus("1", func(prd *core.AutoPRD, id string) error {
+	err = updateTaskStatus(&cobra.Command{}, "1", func(prd *core.AutoPRD, id string) error {
 		return prd.SkipTask(id)
 	}, "skipped")
 	if err != nil {
@@ -142,7 +143,7 @@ func TestUpdateTaskStatus_Reset(t *testing.T) {
 	}
 	t.Cleanup(func() { os.Chdir(origDir) })
 
-	err = updateTaskStatus("1", func(prd *core.AutoPRD, id string) error {
+	err = updateTaskStatus(&cobra.Command{}, "1", func(prd *core.AutoPRD, id string) error {
 		return prd.ResetTask(id)
 	}, "reset to pending")
 	if err != nil {
@@ -177,7 +178,7 @@ func TestUpdateTaskStatus_TaskNotFound(t *testing.T) {
 	}
 	t.Cleanup(func() { os.Chdir(origDir) })
 
-	err = updateTaskStatus("nonexistent", func(prd *core.AutoPRD, id string) error {
+	err = updateTaskStatus(&cobra.Command{}, "nonexistent", func(prd *core.AutoPRD, id string) error {
 		return prd.CompleteTask(id, "", 0)
 	}, "completed")
 	if err == nil {
@@ -197,7 +198,7 @@ func TestUpdateTaskStatus_NoPRD(t *testing.T) {
 	}
 	t.Cleanup(func() { os.Chdir(origDir) })
 
-	err = updateTaskStatus("1", func(prd *core.AutoPRD, id string) error {
+	err = updateTaskStatus(&cobra.Command{}, "1", func(prd *core.AutoPRD, id string) error {
 		return prd.CompleteTask(id, "", 0)
 	}, "completed")
 	if err == nil {
@@ -442,7 +443,7 @@ func TestUpdateTaskStatus_CorruptPRD(t *testing.T) {
 	}
 	t.Cleanup(func() { os.Chdir(origDir) })
 
-	err = updateTaskStatus("1", func(prd *core.AutoPRD, id string) error {
+	err = updateTaskStatus(&cobra.Command{}, "1", func(prd *core.AutoPRD, id string) error {
 		return prd.CompleteTask(id, "", 0)
 	}, "completed")
 	if err == nil {
@@ -467,7 +468,7 @@ func TestUpdateTaskStatus_ProgressRecalculated(t *testing.T) {
 	t.Cleanup(func() { os.Chdir(origDir) })
 
 	// Complete task 1
-	err = updateTaskStatus("1", func(prd *core.AutoPRD, id string) error {
+	err = updateTaskStatus(&cobra.Command{}, "1", func(prd *core.AutoPRD, id string) error {
 		return prd.CompleteTask(id, "sha456", 2)
 	}, "completed")
 	if err != nil {
@@ -529,3 +530,57 @@ func TestRunAutoTaskAdd_SavedCorrectly(t *testing.T) {
 			tasks[0].ID, tasks[0].Title, "100", "Brand new task")
 	}
 }
+
+func TestRunAutoTaskAdd_GeneratedIDs(t *testing.T) {
+	dir, prdPath := setupTestPRD(t, []core.AutoTask{
+		{ID: "3.0", Title: "Parent", Status: core.TaskStatusPending},
+		{ID: "3.1", Title: "First child", Status: core.TaskStatusPending, ParentID: "3.0"},
+	})
+	origDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(origDir) })
+
+	tests := []struct {
+		name    string
+		args    []string
+		parent  string
+		wantID  string
+		wantErr bool
+	}{
+		{name: "next child", args: []string{"Second child"}, parent: "3", wantID: "3.2"},
+		{name: "next top level", args: []string{"New parent"}, wantID: "4.0"},
+		{name: "malformed ID", args: []string{"3-x", "Bad"}, wantErr: true},
+		{name: "ID outside parent", args: []string{"4.1", "Misplaced"}, parent: "3", wantErr: true},
+		{name: "unknown parent", args: []string{"Orphan"}, parent: "9", wantErr: true},
+		{name: "collision", args: []string{"3.1", "Again"}, parent: "3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("parent", tt.parent, "")
+			err := runAutoTaskAdd(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runAutoTaskAdd() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			prd, err := core.LoadAutoPRD(prdPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			added := prd.Tasks[len(prd.Tasks)-1]
+			if added.ID != tt.wantID {
+				t.Errorf("added ID = %q, want %q", added.ID, tt.wantID)
+			}
+			if tt.parent != "" && added.ParentID != "3.0" {
+				t.Errorf("ParentID = %q, want 3.0", added.ParentID)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoTaskImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import tasks from a CSV or JSON export",
+	Long: `Bulk-import tasks into prd.json from a project tracker or spreadsheet export.
+
+CSV files need a header row. JSON files may be an array of task objects or
+an object with a "tasks" array; nested fields are addressed with dot paths
+(e.g. --title-field fields.summary).
+
+Use the --*-field flags when the export's column names differ from
+id, title, priority, and parent. Tracker priorities such as P0-P4, Highest,
+Blocker, or Minor are mapped onto critical/high/medium/low; an empty
+priority defaults to medium. Every record is validated before anything is
+written, and a parent must exist in prd.json or in the import.
+
+Duplicate IDs within the file are always rejected. IDs that already exist
+in prd.json follow --on-duplicate:
+  error   Reject the import (default)
+  skip    Keep the existing task
+  update  Refresh title, priority, and parent; keep status
+
+Examples:
+  samuel auto task import --from-csv backlog.csv
+  samuel auto task import --from-csv jira.csv --id-field "Issue key" --title-field Summary --parent-field "Parent id"
+  samuel auto task import --from-json issues.json --title-field fields.summary --on-duplicate update
+  samuel auto task import --from-json tasks.json --dry-run`,
+	RunE: runAutoTaskImport,
+}
+
+func registerTaskImportCmd() {
+	autoTaskCmd.AddCommand(autoTaskImportCmd)
+
+	defaults := core.DefaultTaskImportMapping()
+	autoTaskImportCmd.Flags().String("from-csv", "", "CSV file to import")
+	autoTaskImportCmd.Flags().String("from-json", "", "JSON file to import")
+	autoTaskImportCmd.Flags().String("id-field", defaults.ID, "Column or field holding the task ID")
+	autoTaskImportCmd.Flags().String("title-field", defaults.Title, "Column or field holding the task title")
+	autoTaskImportCmd.Flags().String("priority-field", defaults.Priority, "Column or field holding the priority")
+	autoTaskImportCmd.Flags().String("parent-field", defaults.Parent, "Column or field holding the parent task ID")
+	autoTaskImportCmd.Flags().String("on-duplicate", core.DuplicateError,
+		fmt.Sprintf("How to handle IDs already in prd.json (%s)", strings.Join(core.GetSupportedDuplicatePolicies(), ", ")))
+	autoTaskImportCmd.Flags().Bool("dry-run", false, "Validate and show what would be imported without saving")
+}
+
+func runAutoTaskImport(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	records, source, err := readImportRecords(cmd)
+	if err != nil {
+		return err
+	}
+	mapping := core.TaskImportMapping{}
+	mapping.ID, _ = cmd.Flags().GetString("id-field")
+	mapping.Title, _ = cmd.Flags().GetString("title-field")
+	mapping.Priority, _ = cmd.Flags().GetString("priority-field")
+	mapping.Parent, _ = cmd.Flags().GetString("parent-field")
+	policy, _ := cmd.Flags().GetString("on-duplicate")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	tasks, err := core.TasksFromRecords(records, mapping)
+	if err != nil {
+		return fmt.Errorf("invalid records in %s:\n%w", source, err)
+	}
+	result, err := prd.ImportTasks(tasks, strings.ToLower(policy))
+	if err != nil {
+		return fmt.Errorf("cannot import %s:\n%w", source, err)
+	}
+
+	printImportResult(result, dryRun)
+	if dryRun {
+		return nil
+	}
+	if err := store.SavePRD(prd); err != nil {
+		return fmt.Errorf("failed to save prd.json: %w", err)
+	}
+	return nil
+}
+
+// readImportRecords loads records from whichever of --from-csv or
+// --from-json was given and returns them with the source file name.
+func readImportRecords(cmd *cobra.Command) ([]map[string]string, string, error) {
+	csvPath, _ := cmd.Flags().GetString("from-csv")
+	jsonPath, _ := cmd.Flags().GetString("from-json")
+	if (csvPath == "") == (jsonPath == "") {
+		return nil, "", fmt.Errorf("specify exactly one of --from-csv or --from-json")
+	}
+
+	path, read := csvPath, core.ReadTaskRecordsCSV
+	if jsonPath != "" {
+		path, read = jsonPath, core.ReadTaskRecordsJSON
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	records, err := read(f)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return records, path, nil
+}
+
+func printImportResult(result *core.TaskImportResult, dryRun bool) {
+	verb := "Imported"
+	if dryRun {
+		verb = "Would import"
+	}
+	ui.Success("%s %d tasks (%d added, %d updated, %d skipped)", verb,
+		len(result.Added)+len(result.Updated), len(result.Added), len(result.Updated), len(result.Skipped))
+	if len(result.Updated) > 0 {
+		ui.Dim("  Updated: %s", strings.Join(result.Updated, ", "))
+	}
+	if len(result.Skipped) > 0 {
+		ui.Dim("  Skipped existing: %s", strings.Join(result.Skipped, ", "))
+	}
+}
@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newTaskImportCmd(flags map[string]string) *cobra.Command {
+	cmd := &cobra.Command{Use: "import"}
+	defaults := core.DefaultTaskImportMapping()
+	cmd.Flags().String("from-csv", "", "")
+	cmd.Flags().String("from-json", "", "")
+	cmd.Flags().String("id-field", defaults.ID, "")
+	cmd.Flags().String("title-field", defaults.Title, "")
+	cmd.Flags().String("priority-field", defaults.Priority, "")
+	cmd.Flags().String("parent-field", defaults.Parent, "")
+	cmd.Flags().String("on-duplicate", core.DuplicateError, "")
+	cmd.Flags().Bool("dry-run", false, "")
+	for name, value := range flags {
+		cmd.Flags().Set(name, value)
+	}
+	return cmd
+}
+
+func TestRunAutoTaskImport(t *testing.T) {
+	csv := "Key,Summary,Prio,Parent\n1,Parent,P1,\n1.1,Child,,1\n"
+
+	tests := []struct {
+		name      string
+		flags     map[string]string
+		wantErr   bool
+		wantTasks int
+	}{
+		{"csv_with_mapping", map[string]string{"id-field": "Key", "title-field": "Summary", "priority-field": "Prio"}, false, 3},
+		{"dry_run_saves_nothing", map[string]string{"id-field": "Key", "title-field": "Summary", "priority-field": "Prio", "dry-run": "true"}, false, 1},
+		{"missing_mapped_title", map[string]string{"id-field": "Key"}, true, 1},
+		{"no_source", map[string]string{"from-csv": ""}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, prdPath := setupTestPRD(t, []core.AutoTask{{ID: "0", Title: "Existing", Status: core.TaskStatusPending}})
+			csvPath := filepath.Join(dir, "backlog.csv")
+			if err := os.WriteFile(csvPath, []byte(csv), 0644); err != nil {
+				t.Fatal(err)
+			}
+			origDir, _ := os.Getwd()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Chdir(origDir) })
+
+			flags := map[string]string{"from-csv": csvPath}
+			for k, v := range tt.flags {
+				flags[k] = v
+			}
+			err := runAutoTaskImport(newTaskImportCmd(flags), nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runAutoTaskImport error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			prd, err := core.LoadAutoPRD(prdPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(prd.Tasks) != tt.wantTasks {
+				t.Errorf("prd.json has %d tasks, want %d", len(prd.Tasks), tt.wantTasks)
+			}
+		})
+	}
+}
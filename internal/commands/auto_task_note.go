@@ -0,0 +1,88 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var autoTaskNoteCmd = &cobra.Command{
+	Use:   "note <task-id> [text]",
+	Short: "Add a note to a task, or list its notes",
+	Long: `Record context on a task in prd.json, such as why it is blocked or what
+was tried, so it is not lost in progress.md prose. Notes are timestamped
+and attributed to a human (the default) or the agent.
+
+Files passed with --attach are copied to .claude/auto/attachments/<task-id>/
+and referenced from the note. Without text, the task's notes are listed.
+
+Examples:
+  samuel auto task note 2.1 "Blocked on API credentials from ops"
+  samuel auto task note 2.1 "Repro log attached" --attach build.log
+  samuel auto task note 2.1 "Flaky test, retried twice" --author agent
+  samuel auto task note 2.1`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runAutoTaskNote,
+}
+
+func registerTaskNoteCmd() {
+	autoTaskCmd.AddCommand(autoTaskNoteCmd)
+	autoTaskNoteCmd.Flags().String("author", core.NoteAuthorHuman,
+		fmt.Sprintf("Who wrote the note (%s)", strings.Join(core.GetSupportedNoteAuthors(), ", ")))
+	autoTaskNoteCmd.Flags().StringSlice("attach", nil, "File to attach to the note (repeatable)")
+}
+
+func runAutoTaskNote(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	store, prd, err := openAutoState(cwd)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	if len(args) == 1 {
+		return listTaskNotes(prd, args[0])
+	}
+
+	note := core.TaskNote{Text: args[1]}
+	note.Author, _ = cmd.Flags().GetString("author")
+	attach, _ := cmd.Flags().GetStringSlice("attach")
+	if err := prd.AddTaskNote(cwd, args[0], note, attach); err != nil {
+		return err
+	}
+
+	if err := store.SavePRD(prd); err != nil {
+		return fmt.Errorf("failed to save prd.json: %w", err)
+	}
+	ui.Success("Note added to task %s", args[0])
+	notes := prd.GetTask(args[0]).Notes
+	for _, a := range notes[len(notes)-1].Attachments {
+		ui.ListItem(1, "attached %s", a)
+	}
+	return nil
+}
+
+func listTaskNotes(prd *core.AutoPRD, id string) error {
+	task := prd.GetTask(id)
+	if task == nil {
+		return fmt.Errorf("task not found: %s", id)
+	}
+	ui.Header(fmt.Sprintf("Notes for %s: %s", task.ID, task.Title))
+	if len(task.Notes) == 0 {
+		ui.Dim("No notes")
+		return nil
+	}
+	for _, n := range task.Notes {
+		ui.ListItem(0, "%s [%s] %s", n.Timestamp, n.Author, n.Text)
+		for _, a := range n.Attachments {
+			ui.ListItem(1, "%s", a)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newTaskNoteTestCmd() *cobra.Command {
+	cmd := &cobra.Command{RunE: runAutoTaskNote}
+	cmd.Flags().String("author", core.NoteAuthorHuman, "")
+	cmd.Flags().StringSlice("attach", nil, "")
+	return cmd
+}
+
+func TestRunAutoTaskNote(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		flags     map[string]string
+		wantErr   bool
+		wantNotes int
+		wantAuth  string
+	}{
+		{name: "add_human_note", args: []string{"1", "Blocked on credentials"}, wantNotes: 1, wantAuth: core.NoteAuthorHuman},
+		{name: "add_agent_note", args: []string{"1", "Retried twice"}, flags: map[string]string{"author": "agent"}, wantNotes: 1, wantAuth: core.NoteAuthorAgent},
+		{name: "list_notes", args: []string{"1"}},
+		{name: "unknown_task", args: []string{"7", "text"}, wantErr: true},
+		{name: "bad_author", args: []string{"1", "text"}, flags: map[string]string{"author": "robot"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, prdPath := setupTestPRD(t, []core.AutoTask{
+				{ID: "1", Title: "Wire up API", Status: core.TaskStatusBlocked},
+			})
+			origDir, _ := os.Getwd()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Chdir(origDir) })
+
+			cmd := newTaskNoteTestCmd()
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := cmd.RunE(cmd, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			prd, err := core.LoadAutoPRD(prdPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			notes := prd.Tasks[0].Notes
+			if len(notes) != tt.wantNotes {
+				t.Fatalf("got %d notes, want %d", len(notes), tt.wantNotes)
+			}
+			if tt.wantNotes > 0 && notes[0].Author != tt.wantAuth {
+				t.Errorf("author = %q, want %q", notes[0].Author, tt.wantAuth)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var badgeCmd = &cobra.Command{
+	Use:   "badge",
+	Short: "Generate a project health badge",
+	Long: `Generate a status badge from the doctor checks and template freshness.
+
+The badge reads:
+  healthy · v1.8.0              all doctor checks pass (green)
+  outdated · v1.7.0 → v1.8.0    checks pass, a newer release exists (yellow)
+  3 issues                      doctor checks failed (red)
+
+The svg format is a ready-to-commit image. The json format follows the
+shields.io endpoint schema: serve it and point
+https://img.shields.io/endpoint?url=<url> at it. The badge is written to
+stdout unless --output is given. Use --offline to skip the release check.
+
+Examples:
+  samuel badge --output .github/samuel-badge.svg
+  samuel badge --format json --output public/samuel-badge.json
+  samuel badge --offline > badge.svg`,
+	Args: cobra.NoArgs,
+	RunE: runBadge,
+}
+
+func init() {
+	rootCmd.AddCommand(badgeCmd)
+	badgeCmd.Flags().String("format", core.BadgeFormatSVG, "Output format: svg, json")
+	badgeCmd.Flags().StringP("output", "o", "", "Write the badge to this file instead of stdout")
+	badgeCmd.Flags().Bool("offline", false, "Skip checking for a newer framework release")
+}
+
+func runBadge(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	output, _ := cmd.Flags().GetString("output")
+	offline, _ := cmd.Flags().GetBool("offline")
+	if !slices.Contains(core.GetSupportedBadgeFormats(), format) {
+		return fmt.Errorf("unsupported badge format: %s (supported: %v)", format, core.GetSupportedBadgeFormats())
+	}
+
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	failed, config := countDoctorFailures(cwd)
+	var version, latest string
+	if config != nil {
+		version = config.Version
+		if !offline {
+			latest = latestFrameworkVersion(cmd.ErrOrStderr(), config)
+		}
+	}
+
+	badge := core.NewHealthBadge(failed, version, latest)
+	data := []byte(badge.SVG())
+	if format == core.BadgeFormatJSON {
+		if data, err = badge.EndpointJSON(); err != nil {
+			return fmt.Errorf("failed to encode badge: %w", err)
+		}
+	}
+
+	if output == "" {
+		_, err = cmd.OutOrStdout().Write(data)
+		return err
+	}
+	if err := os.WriteFile(output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write badge: %w", err)
+	}
+	ui.Success("Wrote %s badge (%s) to %s", format, badge.Message, output)
+	return nil
+}
+
+// countDoctorFailures runs every doctor check without printing and returns
+// how many failed, along with the loaded config.
+func countDoctorFailures(cwd string) (int, *core.Config) {
+	configResult, config := checkConfigFile(cwd)
+	results := []checkResult{configResult}
+	for _, o := range runDoctorChecks(doctorChecks, newDoctorEnv(cwd, config), defaultDoctorBudget) {
+		results = append(results, o.results...)
+	}
+
+	failed := 0
+	for _, r := range results {
+		if !r.passed {
+			failed++
+		}
+	}
+	return failed, config
+}
+
+// latestFrameworkVersion returns the latest release on the project's
+// channel, or "" when it cannot be determined; the badge then leaves
+// freshness out rather than failing.
+func latestFrameworkVersion(warn io.Writer, config *core.Config) string {
+	channel, err := core.ResolveChannel("", config.Channel)
+	if err != nil {
+		return ""
+	}
+	downloader, err := core.NewDownloader()
+	if err == nil {
+		var latest string
+		if latest, err = downloader.GetLatestVersionForChannel(channel); err == nil {
+			return latest
+		}
+	}
+	fmt.Fprintf(warn, "Could not check for a newer release: %v\n", err)
+	return ""
+}
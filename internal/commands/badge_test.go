@@ -0,0 +1,85 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newBadgeTestCmd(format, output string) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", format, "")
+	cmd.Flags().String("output", output, "")
+	cmd.Flags().Bool("offline", true, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestRunBadge(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		output  string
+		want    string
+		wantErr bool
+	}{
+		{name: "svg to stdout", format: "svg", want: "<svg"},
+		{name: "json to stdout", format: "json", want: `"schemaVersion": 1`},
+		{name: "svg to file", format: "svg", output: "badge.svg", want: "<svg"},
+		{name: "unsupported format", format: "png", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup := setupSkillTestDir(t)
+			defer cleanup()
+
+			cmd, out := newBadgeTestCmd(tt.format, tt.output)
+			err := runBadge(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runBadge() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			got := out.String()
+			if tt.output != "" {
+				data, err := os.ReadFile(filepath.Join(dir, tt.output))
+				if err != nil {
+					t.Fatalf("badge not written: %v", err)
+				}
+				got = string(data)
+			}
+			if !strings.Contains(got, tt.want) {
+				t.Errorf("badge missing %q:\n%s", tt.want, got)
+			}
+			// The bare test project has no CLAUDE.md, so doctor reports issues.
+			if !strings.Contains(got, "issue") {
+				t.Errorf("expected an issues badge:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestCountDoctorFailures_NoConfig(t *testing.T) {
+	dir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("failed to chdir: %v", err)
+	}
+	defer func() { _ = os.Chdir(oldDir) }()
+
+	failed, config := countDoctorFailures(dir)
+	if config != nil {
+		t.Error("expected no config")
+	}
+	if failed == 0 {
+		t.Error("expected failures without samuel.yaml")
+	}
+}
@@ -0,0 +1,167 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark the performance of Samuel itself",
+	Long: `Measure the performance of Samuel itself. These are developer commands
+for validating performance work on the CLI, run on any machine without a
+project or network access.`,
+}
+
+var benchExtractCmd = &cobra.Command{
+	Use:   "extract",
+	Short: "Benchmark template download, verification and extraction",
+	Long: `Synthesize a template archive and measure how fast it is downloaded,
+verified and extracted into a scratch cache.
+
+The archive holds --files files of --file-size bytes spread over --depth
+directory levels; text content compresses like real guides, random
+content not at all. It is served over HTTP on the loopback interface, and
+each run fetches --archives copies, as a multi-version diff does, in
+every mode:
+
+  buffered   download to a file, verify its SHA-256, then extract
+  streaming  extract the response as it arrives, hashing it on the way
+
+Each mode runs one archive at a time and --parallel at a time. Every mode
+runs --runs times and the median run is reported, with the time of each
+phase summed over the archives for buffered modes.
+
+--json prints the results, with the config and platform, as JSON, and
+--output saves them to a file. Pass a saved file as --baseline to show
+the speedup of each mode over it, e.g. before and after a change.
+
+Examples:
+  samuel bench extract
+  samuel bench extract --files 2000 --file-size 16KB --content random
+  samuel bench extract --output before.json
+  samuel bench extract --baseline before.json`,
+	Args: cobra.NoArgs,
+	RunE: runBenchExtract,
+}
+
+func init() {
+	rootCmd.AddCommand(benchCmd)
+	benchCmd.AddCommand(benchExtractCmd)
+	addBenchExtractFlags(benchExtractCmd)
+}
+
+func addBenchExtractFlags(cmd *cobra.Command) {
+	cmd.Flags().Int("files", 500, "Files per archive")
+	cmd.Flags().String("file-size", "4KB", "Size of each file (e.g. 512B, 16KB, 1MB)")
+	cmd.Flags().Int("depth", 3, "Directory levels the files are spread over")
+	cmd.Flags().String("content", core.BenchContentText, "File content: text or random")
+	cmd.Flags().Int("archives", 4, "Archives fetched per run")
+	cmd.Flags().Int("parallel", core.MaxConcurrentDownloads, "Archives fetched at once in the parallel modes")
+	cmd.Flags().Int("runs", 3, "Runs per mode; the median is reported")
+	cmd.Flags().Bool("json", false, "Print the results as JSON")
+	cmd.Flags().StringP("output", "o", "", "Save the results as JSON to this file")
+	cmd.Flags().String("baseline", "", "Compare with results saved by --output")
+}
+
+func runBenchExtract(cmd *cobra.Command, args []string) error {
+	cfg, err := benchExtractConfig(cmd)
+	if err != nil {
+		return err
+	}
+	var baseline *core.ExtractBenchReport
+	if file, _ := cmd.Flags().GetString("baseline"); file != "" {
+		if baseline, err = core.LoadExtractBenchReport(file); err != nil {
+			return err
+		}
+	}
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if !asJSON {
+		ui.Info("Benchmarking %d archive(s) of %d files of %s, %d run(s) per mode",
+			cfg.Archives, cfg.Files, formatFileSize(cfg.FileSize), cfg.Runs)
+	}
+
+	report, err := core.RunExtractBench(cfg)
+	if err != nil {
+		return err
+	}
+	report.Version = Version
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	if output, _ := cmd.Flags().GetString("output"); output != "" {
+		if err := os.WriteFile(output, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to save results: %w", err)
+		}
+		defer ui.Success("Saved results to %s", output)
+	}
+	if asJSON {
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+		return nil
+	}
+	printExtractBench(report, baseline)
+	return nil
+}
+
+// benchExtractConfig reads and validates the bench extract flags.
+func benchExtractConfig(cmd *cobra.Command) (core.ExtractBenchConfig, error) {
+	var cfg core.ExtractBenchConfig
+	cfg.Files, _ = cmd.Flags().GetInt("files")
+	cfg.Depth, _ = cmd.Flags().GetInt("depth")
+	cfg.Content, _ = cmd.Flags().GetString("content")
+	cfg.Archives, _ = cmd.Flags().GetInt("archives")
+	cfg.Parallel, _ = cmd.Flags().GetInt("parallel")
+	cfg.Runs, _ = cmd.Flags().GetInt("runs")
+	size, _ := cmd.Flags().GetString("file-size")
+	var err error
+	if cfg.FileSize, err = core.ParseByteSize(size); err != nil {
+		return cfg, fmt.Errorf("invalid --file-size: %q (e.g. 4KB)", size)
+	}
+	return cfg, cfg.Validate()
+}
+
+// printExtractBench prints the results table, with the speedup of each
+// mode over the baseline when there is one.
+func printExtractBench(report *core.ExtractBenchReport, baseline *core.ExtractBenchReport) {
+	ui.Section("Extraction benchmark")
+	ui.TableRow("Archive", fmt.Sprintf("%s compressed, %s extracted",
+		formatFileSize(report.ArchiveBytes), formatFileSize(report.ExtractedBytes)))
+	ui.TableRow("Platform", fmt.Sprintf("%s, %d CPUs, %s", report.Platform, report.CPUs, report.GoVersion))
+	if baseline != nil && baseline.Config != report.Config {
+		ui.Warn("The baseline ran with a different config; speedups are not comparable")
+	}
+
+	fmt.Println()
+	header := fmt.Sprintf("  %-14s %10s %10s %10s %10s %9s", "Mode", "Total", "Download", "Verify", "Extract", "MB/s")
+	if baseline != nil {
+		header += fmt.Sprintf(" %8s", "Speedup")
+	}
+	ui.Print("%s", header)
+	for _, r := range report.Results {
+		row := fmt.Sprintf("  %-14s %10s %10s %10s %10s %9.1f", fmt.Sprintf("%s x%d", r.Mode, r.Parallel),
+			benchDuration(r.Total), benchDuration(r.Download), benchDuration(r.Verify), benchDuration(r.Extract), r.MBPerSec)
+		if baseline != nil {
+			speedup := "-"
+			if base := baseline.Result(r.Mode, r.Parallel); base != nil {
+				speedup = fmt.Sprintf("%.2fx", base.Total.Seconds()/r.Total.Seconds())
+			}
+			row += fmt.Sprintf(" %8s", speedup)
+		}
+		ui.Print("%s", row)
+	}
+}
+
+// benchDuration formats a duration in milliseconds; "-" when not measured.
+func benchDuration(d time.Duration) string {
+	if d == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1fms", float64(d)/float64(time.Millisecond))
+}
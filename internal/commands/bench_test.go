@@ -0,0 +1,35 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestBenchExtractConfig(t *testing.T) {
+	cfg, err := benchExtractConfig(newBenchExtractTestCmd(t))
+	if err != nil {
+		t.Fatalf("defaults: %v", err)
+	}
+	if cfg.FileSize != 4<<10 || cfg.Content != core.BenchContentText || cfg.Parallel != core.MaxConcurrentDownloads {
+		t.Errorf("defaults = %+v", cfg)
+	}
+
+	for flag, value := range map[string]string{"file-size": "lots", "content": "zeros", "runs": "0"} {
+		cmd := newBenchExtractTestCmd(t)
+		if err := cmd.Flags().Set(flag, value); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := benchExtractConfig(cmd); err == nil {
+			t.Errorf("--%s %s: expected an error", flag, value)
+		}
+	}
+}
+
+func newBenchExtractTestCmd(t *testing.T) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "extract"}
+	addBenchExtractFlags(cmd)
+	return cmd
+}
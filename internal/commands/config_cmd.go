@@ -24,6 +24,8 @@ Available subcommands:
 Valid configuration keys:
   version              Framework version
   registry             GitHub repository URL
+  overlay.registry     GitHub repository applied on top of the registry
+  overlay.branch       Overlay branch to track (default: main)
   installed.languages  Comma-separated list of installed languages
   installed.frameworks Comma-separated list of installed frameworks
   installed.workflows  Comma-separated list of installed workflows
@@ -54,7 +56,7 @@ var configGetCmd = &cobra.Command{
 	Long: `Get a specific configuration value by key.
 
 Valid keys:
-  version, registry, installed.languages, installed.frameworks, installed.workflows
+  version, registry, overlay.registry, overlay.branch, installed.languages, installed.frameworks, installed.workflows
 
 Examples:
   samuel config get version
@@ -72,6 +74,7 @@ For list values (installed.*), use comma-separated format.
 
 Examples:
   samuel config set registry https://github.com/myorg/myrepo
+  samuel config set overlay.registry https://github.com/myorg/samuel-overlay
   samuel config set installed.languages typescript,python,go
   samuel config set installed.frameworks react,nextjs`,
 	Args: cobra.ExactArgs(2),
@@ -86,10 +89,10 @@ func init() {
 }
 
 func runConfigList(cmd *cobra.Command, args []string) error {
-	config, err := core.LoadConfig()
+	config, _, err := loadProjectConfig(cmd)
 	if err != nil {
 		if os.IsNotExist(err) {
-			ui.Warn("No Samuel installation found in current directory")
+			ui.Warn("No Samuel installation found in project directory")
 			ui.Info("Run 'samuel init' to initialize a project")
 			return nil
 		}
@@ -102,7 +105,7 @@ func runConfigList(cmd *cobra.Command, args []string) error {
 	values := config.GetAllValues()
 
 	// Display in consistent order
-	keys := []string{"version", "registry", "installed.languages", "installed.frameworks", "installed.workflows"}
+	keys := []string{"version", "registry", "overlay.registry", "overlay.branch", "installed.languages", "installed.frameworks", "installed.workflows"}
 	for _, key := range keys {
 		value := values[key]
 		displayValue := formatConfigValue(value)
@@ -125,10 +128,10 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid config key: %s", key)
 	}
 
-	config, err := core.LoadConfig()
+	config, _, err := loadProjectConfig(cmd)
 	if err != nil {
 		if os.IsNotExist(err) {
-			ui.Warn("No Samuel installation found in current directory")
+			ui.Warn("No Samuel installation found in project directory")
 			return nil
 		}
 		return fmt.Errorf("failed to load config: %w", err)
@@ -155,17 +158,15 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid config key: %s", key)
 	}
 
-	// Validate registry URL scheme before loading config
-	if key == "registry" {
-		if err := validateRegistryURL(value); err != nil {
-			return fmt.Errorf("invalid registry value: %w", err)
-		}
+	// Validate registry URLs before loading config
+	if err := validateConfigValue(key, value); err != nil {
+		return err
 	}
 
-	config, err := core.LoadConfig()
+	config, cwd, err := loadProjectConfig(cmd)
 	if err != nil {
 		if os.IsNotExist(err) {
-			ui.Warn("No Samuel installation found in current directory")
+			ui.Warn("No Samuel installation found in project directory")
 			ui.Info("Run 'samuel init' to initialize a project")
 			return nil
 		}
@@ -181,11 +182,6 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Save config
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get current directory: %w", err)
-	}
-
 	if err := config.Save(cwd); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
 	}
@@ -223,6 +219,24 @@ func isValidConfigKey(key string) bool {
 	return false
 }
 
+// validateConfigValue rejects malformed registry and overlay values.
+// An empty overlay value is allowed and clears the setting.
+func validateConfigValue(key, value string) error {
+	var err error
+	switch {
+	case key == "registry":
+		err = validateRegistryURL(value)
+	case key == "overlay.registry" && value != "":
+		_, _, err = core.ParseGitHubRegistry(value)
+	case key == "overlay.branch":
+		err = core.ValidateOverlayBranch(value)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s value: %w", key, err)
+	}
+	return nil
+}
+
 // validateRegistryURL checks that a registry value is a valid HTTPS URL.
 func validateRegistryURL(value string) error {
 	u, err := url.Parse(value)
@@ -253,6 +253,30 @@ func TestRunConfigSet(t *testing.T) {
 		}
 	})
 
+	t.Run("invalid_overlay_registry", func(t *testing.T) {
+		setupConfigTestDir(t, core.NewConfig("1.0.0"))
+
+		err := runConfigSet(nil, []string{"overlay.registry", "https://gitlab.com/acme/overlay"})
+		if err == nil || !strings.Contains(err.Error(), "invalid overlay.registry value") {
+			t.Errorf("expected overlay registry error, got: %v", err)
+		}
+	})
+
+	t.Run("overlay_registry_saved", func(t *testing.T) {
+		dir := setupConfigTestDir(t, core.NewConfig("1.0.0"))
+
+		if err := runConfigSet(nil, []string{"overlay.registry", "https://github.com/acme/overlay"}); err != nil {
+			t.Fatalf("runConfigSet() error: %v", err)
+		}
+		loaded, err := core.LoadConfigFrom(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if loaded.Overlay == nil || loaded.Overlay.Registry != "https://github.com/acme/overlay" {
+			t.Errorf("overlay not saved, got %+v", loaded.Overlay)
+		}
+	})
+
 	t.Run("no_config_file", func(t *testing.T) {
 		setupConfigTestDir(t, nil)
 		err := runConfigSet(nil, []string{"version", "2.0.0"})
@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var deinitCmd = &cobra.Command{
+	Use:   "deinit",
+	Short: "Remove Samuel from your project",
+	Long: `Remove everything Samuel installed or generated in the project: the core
+files, the registry skills, the auto loop, the update notes, the skill index,
+AGENTS-COMPACT.md, folder instructions generated by 'samuel sync', and
+samuel.yaml.
+
+Your own work is saved before it is deleted. Skills that did not come from
+the registry (created, imported, or variants) and CLAUDE.md or AGENTS.md
+with content outside the managed blocks are copied to a backup bundle,
+samuel-deinit-backup-<timestamp>/ in the project unless --backup-dir is
+given, keeping their paths. --purge deletes them without a bundle.
+
+Folder instructions you took over from 'samuel sync' and the backups of
+'samuel update' are left in place and listed at the end.
+
+Examples:
+  samuel deinit --dry-run              # Show what would be removed and kept
+  samuel deinit                        # Remove Samuel, bundling your files
+  samuel deinit --backup-dir ../keep   # Bundle your files outside the project
+  samuel deinit --purge --yes          # Remove everything without a bundle`,
+	Args: cobra.NoArgs,
+	RunE: runDeinit,
+}
+
+func init() {
+	rootCmd.AddCommand(deinitCmd)
+	addDeinitFlags(deinitCmd)
+}
+
+func addDeinitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "Show what would be removed and kept without deleting")
+	cmd.Flags().Bool("purge", false, "Delete your skills and customizations instead of bundling them")
+	cmd.Flags().String("backup-dir", "", "Directory to bundle your skills and customizations in")
+}
+
+func runDeinit(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	purge, _ := cmd.Flags().GetBool("purge")
+	backupDir, _ := cmd.Flags().GetString("backup-dir")
+	if purge && backupDir != "" {
+		return fmt.Errorf("--purge and --backup-dir cannot be used together")
+	}
+
+	_, cwd, err := loadProjectConfig(cmd)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
+		}
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	plan, err := core.PlanDeinit(cwd)
+	if err != nil {
+		return err
+	}
+	if !purge && backupDir == "" {
+		backupDir = filepath.Join(cwd, core.DeinitBackupPrefix+time.Now().Format("20060102-150405"))
+	}
+	displayDeinitPlan(plan, backupDir, purge)
+	if dryRun {
+		ui.Info("Dry run: nothing was removed")
+		return nil
+	}
+
+	question := "Remove Samuel from this project?"
+	if purge && len(plan.UserSkills)+len(plan.Customized) > 0 {
+		question = "Remove Samuel from this project, including your skills and customizations?"
+	}
+	confirmed, err := approve(promptDeinit, question, false)
+	if err != nil || !confirmed {
+		ui.Info("Deinit cancelled")
+		return nil
+	}
+
+	if purge || len(plan.UserSkills)+len(plan.Customized) == 0 {
+		backupDir = ""
+	}
+	return withProjectLock(cmd, cwd, func() error {
+		if err := core.ApplyDeinit(cwd, plan, backupDir); err != nil {
+			return err
+		}
+		displayDeinitResult(plan, backupDir)
+		return nil
+	})
+}
+
+// displayDeinitPlan lists what deinit removes, bundles and keeps.
+func displayDeinitPlan(plan *core.DeinitPlan, backupDir string, purge bool) {
+	ui.Section("Remove")
+	for _, path := range plan.Remove {
+		ui.ListItem(1, "%s", path)
+	}
+	if len(plan.UserSkills)+len(plan.Customized) > 0 {
+		if purge {
+			ui.Section("Remove (yours, not bundled)")
+		} else {
+			ui.Section("Bundle in " + backupDir)
+		}
+		for _, path := range plan.UserSkills {
+			ui.ListItem(1, "%s (your skill)", path)
+		}
+		for _, path := range plan.Customized {
+			ui.ListItem(1, "%s (customized)", path)
+		}
+	}
+	displayDeinitKept(plan)
+	fmt.Println()
+}
+
+// displayDeinitKept lists the files deinit leaves in place.
+func displayDeinitKept(plan *core.DeinitPlan) {
+	if len(plan.Kept) == 0 {
+		return
+	}
+	ui.Section("Left in place")
+	for _, path := range plan.Kept {
+		ui.ListItem(1, "%s", path)
+	}
+}
+
+// displayDeinitResult reports what deinit did and what it left behind.
+func displayDeinitResult(plan *core.DeinitPlan, backupDir string) {
+	ui.Success("Removed %d Samuel file(s) and directories", len(plan.Remove))
+	if backupDir != "" {
+		ui.Success("Saved %d skill(s) and %d customized file(s) to %s",
+			len(plan.UserSkills), len(plan.Customized), backupDir)
+	} else if n := len(plan.UserSkills) + len(plan.Customized); n > 0 {
+		ui.Warn("Deleted %d of your file(s) without a bundle (--purge)", n)
+	}
+	displayDeinitKept(plan)
+}
@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newDeinitTestCmd(t *testing.T, dir string, flags ...string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{RunE: runDeinit}
+	cmd.Flags().String("target", dir, "")
+	addDeinitFlags(cmd)
+	if err := cmd.ParseFlags(flags); err != nil {
+		t.Fatal(err)
+	}
+	return cmd
+}
+
+func TestRunDeinit(t *testing.T) {
+	t.Cleanup(func() { approvals = approvalPolicy{} })
+	approvals = approvalPolicy{all: "yes"}
+
+	dir := t.TempDir()
+	if err := runDeinit(newDeinitTestCmd(t, dir), nil); err == nil || !strings.Contains(err.Error(), "samuel init") {
+		t.Errorf("runDeinit() without an installation = %v", err)
+	}
+
+	for path, content := range map[string]string{
+		"samuel.yaml":                        "version: 2.0.0\n",
+		".claude/skills/our-deploy/SKILL.md": "deploy",
+	} {
+		full := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := runDeinit(newDeinitTestCmd(t, dir, "--dry-run"), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "samuel.yaml")); err != nil {
+		t.Errorf("dry run removed the config: %v", err)
+	}
+	if err := runDeinit(newDeinitTestCmd(t, dir, "--purge", "--backup-dir", "x"), nil); err == nil {
+		t.Error("--purge with --backup-dir should fail")
+	}
+
+	backup := filepath.Join(t.TempDir(), "bundle")
+	if err := runDeinit(newDeinitTestCmd(t, dir, "--backup-dir", backup), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "samuel.yaml")); !os.IsNotExist(err) {
+		t.Error("samuel.yaml should be removed")
+	}
+	if _, err := os.Stat(filepath.Join(backup, ".claude", "skills", "our-deploy", "SKILL.md")); err != nil {
+		t.Errorf("user skill not bundled: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// activeDeprecations returns the deprecation registry; replaced in tests.
+var activeDeprecations = core.GetDeprecations
+
+// applyDeprecations registers hidden aliases for renamed commands and
+// flags so old invocations keep working until they are removed. Entries
+// whose replacement does not exist are ignored.
+func applyDeprecations(root *cobra.Command, deps []core.Deprecation) {
+	for _, d := range deps {
+		switch d.Kind {
+		case core.DeprecatedCommand:
+			addDeprecatedCommand(root, d)
+		case core.DeprecatedFlag:
+			addDeprecatedFlag(root, d)
+		}
+	}
+}
+
+func addDeprecatedCommand(root *cobra.Command, d core.Deprecation) {
+	oldPath := strings.Fields(d.Old)
+	target, ok := findCommandPath(root, d.New)
+	if len(oldPath) < 2 || !ok {
+		return
+	}
+	parent, ok := findCommandPath(root, strings.Join(oldPath[:len(oldPath)-1], " "))
+	if !ok {
+		return
+	}
+
+	alias := &cobra.Command{
+		Use:    oldPath[len(oldPath)-1],
+		Short:  target.Short,
+		Hidden: true,
+		Args:   target.Args,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			printDeprecation(cmd, d)
+		},
+		Run:  target.Run,
+		RunE: target.RunE,
+	}
+	alias.Flags().AddFlagSet(target.Flags())
+	parent.AddCommand(alias)
+}
+
+func addDeprecatedFlag(root *cobra.Command, d core.Deprecation) {
+	oldCmd, oldFlag, ok := splitFlagPath(d.Old)
+	newCmd, newFlag, okNew := splitFlagPath(d.New)
+	if !ok || !okNew || oldCmd != newCmd {
+		return
+	}
+	cmd, ok := findCommandPath(root, newCmd)
+	if !ok {
+		return
+	}
+	target := cmd.Flags().Lookup(newFlag)
+	if target == nil || cmd.Flags().Lookup(oldFlag) != nil {
+		return
+	}
+	// Sharing the Value means setting the old flag sets the new one.
+	cmd.Flags().AddFlag(&pflag.Flag{
+		Name:        oldFlag,
+		Usage:       target.Usage,
+		Value:       target.Value,
+		DefValue:    target.DefValue,
+		NoOptDefVal: target.NoOptDefVal,
+		Hidden:      true,
+	})
+}
+
+// splitFlagPath splits "samuel init --old" into "samuel init" and "old".
+func splitFlagPath(path string) (string, string, bool) {
+	fields := strings.Fields(path)
+	if len(fields) < 2 || !strings.HasPrefix(fields[len(fields)-1], "--") {
+		return "", "", false
+	}
+	return strings.Join(fields[:len(fields)-1], " "), strings.TrimPrefix(fields[len(fields)-1], "--"), true
+}
+
+func findCommandPath(root *cobra.Command, path string) (*cobra.Command, bool) {
+	fields := strings.Fields(path)
+	if len(fields) == 0 || (fields[0] != root.Name() && fields[0] != canonicalBinary) {
+		return nil, false
+	}
+	cmd, rest, err := root.Find(fields[1:])
+	return cmd, err == nil && len(rest) == 0
+}
+
+// warnDeprecations runs before every command. It reports deprecated flags
+// the command was given, plus legacy config files and environment
+// variables in the current project. The aicof binary name itself is not
+// reported on each run; 'samuel migrate' rewrites scripts that call it.
+// Warnings go to stderr so they do not corrupt output that scripts parse.
+func warnDeprecations(cmd *cobra.Command, args []string) {
+	for _, d := range activeDeprecations() {
+		if d.Kind != core.DeprecatedFlag {
+			continue
+		}
+		path, flag, ok := splitFlagPath(d.Old)
+		if ok && path == canonicalCommandPath(cmd) && cmd.Flags().Changed(flag) {
+			printDeprecation(cmd, d)
+		}
+	}
+
+	if cmd == migrateCmd {
+		return
+	}
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return
+	}
+	findings := core.DetectDeprecatedSettings(cwd)
+	for _, f := range findings {
+		printDeprecation(cmd, f.Deprecation)
+	}
+	if len(findings) > 0 {
+		fmt.Fprintln(cmd.ErrOrStderr(), "  Run 'samuel migrate' to update them")
+	}
+}
+
+func printDeprecation(cmd *cobra.Command, d core.Deprecation) {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s %s\n", ui.WarnSymbol, d.Warning())
+}
@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newDeprecationTestRoot(binary string, ran *string) *cobra.Command {
+	root := &cobra.Command{Use: binary, PersistentPreRun: warnDeprecations}
+	start := &cobra.Command{
+		Use: "start",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			n, _ := cmd.Flags().GetInt("max-iterations")
+			*ran = cmd.Name() + ":" + strings.Repeat("i", n)
+			return nil
+		},
+	}
+	start.Flags().Int("max-iterations", 0, "Iterations")
+	root.AddCommand(start)
+	return root
+}
+
+func TestApplyDeprecations(t *testing.T) {
+	deps := []core.Deprecation{
+		{Kind: core.DeprecatedCommand, Old: "samuel run", New: "samuel start", Since: "2.1.0", RemovedIn: "3.0.0"},
+		{Kind: core.DeprecatedFlag, Old: "samuel start --iterations", New: "samuel start --max-iterations", Since: "2.1.0", RemovedIn: "3.0.0"},
+		{Kind: core.DeprecatedCommand, Old: "samuel gone", New: "samuel missing"},
+		{Kind: core.DeprecatedBinary, Old: "aicof", New: "samuel", Since: "2.0.0", RemovedIn: "3.0.0"},
+	}
+	orig := activeDeprecations
+	defer func() { activeDeprecations = orig }()
+	activeDeprecations = func() []core.Deprecation { return deps }
+
+	tests := []struct {
+		name     string
+		binary   string
+		args     []string
+		wantRan  string
+		wantWarn string
+	}{
+		{"new_names", "samuel", []string{"start", "--max-iterations", "2"}, "start:ii", ""},
+		{"old_command", "samuel", []string{"run", "--max-iterations", "1"}, "run:i", `command "samuel run"`},
+		{"old_flag", "samuel", []string{"start", "--iterations", "3"}, "start:iii", `flag "samuel start --iterations"`},
+		{"alias_binary", "aicof", []string{"start", "--max-iterations", "1"}, "start:i", ""},
+		{"alias_old_command", "aicof", []string{"run"}, "run:", `command "samuel run"`},
+		{"alias_old_flag", "aicof", []string{"start", "--iterations", "2"}, "start:ii", `flag "samuel start --iterations"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ran string
+			root := newDeprecationTestRoot(tt.binary, &ran)
+			applyDeprecations(root, deps)
+
+			var stderr bytes.Buffer
+			root.SetErr(&stderr)
+			root.SetArgs(tt.args)
+			if err := root.Execute(); err != nil {
+				t.Fatalf("Execute(%v) returned error: %v", tt.args, err)
+			}
+			if ran != tt.wantRan {
+				t.Errorf("ran = %q, want %q", ran, tt.wantRan)
+			}
+			if tt.wantWarn == "" && strings.Contains(stderr.String(), "DEPRECATED") {
+				t.Errorf("unexpected warning: %q", stderr.String())
+			}
+			if !strings.Contains(stderr.String(), tt.wantWarn) {
+				t.Errorf("stderr = %q, want warning containing %q", stderr.String(), tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestSplitFlagPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		wantCmd  string
+		wantFlag string
+		wantOK   bool
+	}{
+		{"samuel init --old", "samuel init", "old", true},
+		{"samuel auto start --max", "samuel auto start", "max", true},
+		{"samuel init", "", "", false},
+		{"--old", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			cmd, flag, ok := splitFlagPath(tt.path)
+			if cmd != tt.wantCmd || flag != tt.wantFlag || ok != tt.wantOK {
+				t.Errorf("splitFlagPath(%q) = %q, %q, %v", tt.path, cmd, flag, ok)
+			}
+		})
+	}
+}
+
+func TestCanonicalCommandPath(t *testing.T) {
+	tests := []struct {
+		binary string
+		want   string
+	}{
+		{"samuel", "samuel start"},
+		{"aicof", "samuel start"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.binary, func(t *testing.T) {
+			var ran string
+			root := newDeprecationTestRoot(tt.binary, &ran)
+			start, _, err := root.Find([]string{"start"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := canonicalCommandPath(start); got != tt.want {
+				t.Errorf("canonicalCommandPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRootLong(t *testing.T) {
+	for _, binary := range []string{"samuel", "aicof"} {
+		t.Run(binary, func(t *testing.T) {
+			long := rootLong(binary)
+			if !strings.Contains(long, "  "+binary+" init my-project") {
+				t.Errorf("rootLong(%q) examples do not use the binary name:\n%s", binary, long)
+			}
+			other := map[string]string{"samuel": "aicof", "aicof": "samuel"}[binary]
+			if strings.Contains(long, other+" ") {
+				t.Errorf("rootLong(%q) mentions %q", binary, other)
+			}
+		})
+	}
+}
@@ -60,7 +60,7 @@ func runDiff(cmd *cobra.Command, args []string) error {
 		diff, err = compareVersions(args[0], args[1])
 	} else {
 		// Compare installed with latest
-		diff, err = compareInstalledWithLatest()
+		diff, err = compareInstalledWithLatest(cmd)
 	}
 
 	if err != nil {
@@ -77,12 +77,12 @@ func runDiff(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func compareInstalledWithLatest() (*VersionDiff, error) {
+func compareInstalledWithLatest(cmd *cobra.Command) (*VersionDiff, error) {
 	// Load config to get installed version
-	config, err := core.LoadConfig()
+	config, dir, err := loadProjectConfig(cmd)
 	if err != nil {
 		if os.IsNotExist(err) {
-			ui.Warn("No Samuel installation found in current directory")
+			ui.Warn("No Samuel installation found in project directory")
 			return nil, fmt.Errorf("no installation found")
 		}
 		return nil, fmt.Errorf("failed to load config: %w", err)
@@ -110,10 +110,10 @@ func compareInstalledWithLatest() (*VersionDiff, error) {
 	}
 
 	// Compare installed files with latest version
-	return compareLocalWithVersion(installedVersion, latestVersion, downloader)
+	return compareLocalWithVersion(dir, installedVersion, latestVersion, downloader)
 }
 
-func compareLocalWithVersion(installedVersion, latestVersion string, downloader *core.Downloader) (*VersionDiff, error) {
+func compareLocalWithVersion(dir, installedVersion, latestVersion string, downloader *core.Downloader) (*VersionDiff, error) {
 	ui.Info("Comparing installed (%s) with latest (%s)...", installedVersion, latestVersion)
 	fmt.Println()
 
@@ -129,7 +129,7 @@ func compareLocalWithVersion(installedVersion, latestVersion string, downloader
 	spinner.Success("Downloaded latest version")
 
 	// Get file hashes for local installation
-	localFiles := getLocalFileHashes(".")
+	localFiles := getLocalFileHashes(dir)
 
 	// Get file hashes for latest version
 	latestFiles := getVersionFileHashes(latestPath)
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/ar4mirez/samuel/internal/core"
 	"github.com/ar4mirez/samuel/internal/ui"
@@ -22,15 +25,22 @@ Checks performed:
 - No broken file references
 - Directory structure is correct
 
+Checks run concurrently; the time each one took is shown after the results.
+Checks still running when the --timeout budget expires are reported as failed.
+
 Examples:
-  samuel doctor           # Run health check
-  samuel doctor --fix     # Auto-fix issues where possible`,
+  samuel doctor                        # Run health check
+  samuel doctor --fix                  # Auto-fix issues where possible
+  samuel doctor --only skills,auto     # Run only selected checks
+  samuel doctor --timeout 5s           # Cap the total check time`,
 	RunE: runDoctor,
 }
 
 func init() {
 	rootCmd.AddCommand(doctorCmd)
 	doctorCmd.Flags().Bool("fix", false, "Auto-fix issues where possible")
+	doctorCmd.Flags().StringSlice("only", nil, "Run only these checks: "+strings.Join(doctorCheckIDs(), ", "))
+	doctorCmd.Flags().Duration("timeout", defaultDoctorBudget, "Overall time budget for all checks")
 }
 
 type checkResult struct {
@@ -42,46 +52,54 @@ type checkResult struct {
 
 func runDoctor(cmd *cobra.Command, args []string) error {
 	autoFix, _ := cmd.Flags().GetBool("fix")
+	rawOnly, _ := cmd.Flags().GetStringSlice("only")
+	budget, _ := cmd.Flags().GetDuration("timeout")
+	only, err := normalizeDoctorOnly(rawOnly)
+	if err != nil {
+		return err
+	}
+	if budget <= 0 {
+		budget = defaultDoctorBudget
+	}
 	ui.Header("Samuel Health Check")
 
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
-	var results []checkResult
-
-	configResult, config := checkConfigFile()
-	results = append(results, configResult)
-	results = append(results, checkCLAUDEMD(cwd))
-	results = append(results, checkAGENTSMD(cwd))
-
-	dirResult, missingDirs := checkDirectoryStructure(cwd)
-	results = append(results, dirResult)
-
-	if config != nil {
-		results = append(results, checkInstalledComponents(cwd, config)...)
+	started := time.Now()
+	configResult, config := checkConfigFile(cwd)
+	configOutcome := doctorOutcome{
+		id:      doctorConfigCheckID,
+		results: []checkResult{configResult},
+		elapsed: time.Since(started),
 	}
 
-	results = append(results, checkSkillsIntegrity(cwd)...)
-
-	autoDir := core.GetAutoDir(cwd)
-	if _, err := os.Stat(autoDir); err == nil {
-		results = append(results, checkAutoHealth(cwd)...)
+	outcomes := runDoctorChecks(selectDoctorChecks(doctorChecks, only),
+		newDoctorEnv(cwd, config), budget)
+	if len(only) == 0 || slices.Contains(only, doctorConfigCheckID) {
+		outcomes = append([]doctorOutcome{configOutcome}, outcomes...)
 	}
 
-	if config != nil {
-		results = append(results, checkLocalModifications(cwd, config)...)
+	var results []checkResult
+	var missingDirs []string
+	for _, o := range outcomes {
+		results = append(results, o.results...)
+		missingDirs = append(missingDirs, o.missingDirs...)
 	}
 
 	passedCount, failedCount, fixableCount := printCheckResults(results)
+	printDoctorTiming(outcomes, time.Since(started))
 	printCheckSummary(passedCount, failedCount, fixableCount, autoFix)
 
-	if autoFix && fixableCount > 0 {
-		performAutoFix(cwd, config, missingDirs)
+	if !autoFix || fixableCount == 0 {
+		return nil
 	}
-
-	return nil
+	return withProjectLock(cmd, cwd, func() error {
+		performAutoFix(cwd, config, missingDirs)
+		return nil
+	})
 }
 
 // printCheckResults displays each check result and returns pass/fail/fixable counts.
@@ -144,13 +162,13 @@ func performAutoFix(cwd string, config *core.Config, missingDirs []string) {
 		return
 	}
 
-	cachePath, err := downloader.DownloadVersion(config.Version)
+	tmpl, err := downloader.ResolveTemplate(config.Version, config.Overlay)
 	if err != nil {
 		ui.Error("Failed to download version: %v", err)
 		return
 	}
 
-	restoreMissingComponents(cwd, cachePath, config)
+	restoreMissingComponents(cwd, tmpl.Path, config)
 	ui.Success("Fix complete. Run 'samuel doctor' again to verify.")
 }
 
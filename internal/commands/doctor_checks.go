@@ -4,16 +4,16 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/ar4mirez/samuel/internal/core"
 	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/ar4mirez/samuel/pkg/parse"
 )
 
 // checkConfigFile validates that samuel.yaml exists and is parseable.
-func checkConfigFile() (checkResult, *core.Config) {
-	config, configErr := core.LoadConfig()
+func checkConfigFile(cwd string) (checkResult, *core.Config) {
+	config, configErr := core.LoadConfigFrom(cwd)
 	if configErr != nil {
 		msg := "samuel.yaml not found"
 		if !os.IsNotExist(configErr) {
@@ -26,10 +26,14 @@ func checkConfigFile() (checkResult, *core.Config) {
 			fixable: false,
 		}, nil
 	}
+	msg := fmt.Sprintf("samuel.yaml found (v%s)", config.Version)
+	if config.Embedded {
+		msg += "; installed from the embedded fallback template, run 'samuel update' when online"
+	}
 	return checkResult{
 		name:    "Config file",
 		passed:  true,
-		message: fmt.Sprintf("samuel.yaml found (v%s)", config.Version),
+		message: msg,
 	}, config
 }
 
@@ -50,7 +54,7 @@ func checkCLAUDEMD(cwd string) checkResult {
 		ui.Warn("Could not read CLAUDE.md: %v", readErr)
 	}
 
-	version := extractVersion(string(content))
+	version := parse.CLAUDEMDVersion(string(content))
 	msg := "Present"
 	if version != "" {
 		msg = fmt.Sprintf("Present (v%s)", version)
@@ -172,54 +176,6 @@ func checkInstalledSkills(
 	}
 }
 
-// checkSkillsIntegrity scans and validates all installed skills.
-func checkSkillsIntegrity(cwd string) []checkResult {
-	skillsDir := filepath.Join(cwd, ".claude", "skills")
-	if _, err := os.Stat(skillsDir); os.IsNotExist(err) {
-		return nil
-	}
-
-	skills, err := core.ScanSkillsDirectory(skillsDir)
-	if err != nil {
-		return []checkResult{{
-			name:    "Skills",
-			passed:  false,
-			message: fmt.Sprintf("Failed to scan skills: %v", err),
-		}}
-	}
-
-	if len(skills) == 0 {
-		return []checkResult{{
-			name:    "Skills",
-			passed:  true,
-			message: "No skills installed",
-		}}
-	}
-
-	validCount := 0
-	invalidCount := 0
-	for _, skill := range skills {
-		if len(skill.Errors) == 0 {
-			validCount++
-		} else {
-			invalidCount++
-		}
-	}
-
-	if invalidCount == 0 {
-		return []checkResult{{
-			name:    "Skills",
-			passed:  true,
-			message: fmt.Sprintf("%d skill(s) installed, all valid", validCount),
-		}}
-	}
-	return []checkResult{{
-		name:    "Skills",
-		passed:  false,
-		message: fmt.Sprintf("%d skill(s) installed, %d invalid", len(skills), invalidCount),
-	}}
-}
-
 // checkAutoHealth validates the auto loop directory and files.
 func checkAutoHealth(cwd string) []checkResult {
 	var results []checkResult
@@ -234,6 +190,13 @@ func checkAutoHealth(cwd string) []checkResult {
 		})
 		return results
 	}
+	if prd.RecoveredFromBackup() {
+		results = append(results, checkResult{
+			name:    "Auto loop",
+			passed:  false,
+			message: "prd.json is unreadable; the loop will resume from prd.json" + core.BackupSuffix + " and rewrite it on the next save",
+		})
+	}
 
 	errs := core.ValidateAutoPRD(prd)
 	if len(errs) > 0 {
@@ -273,19 +236,31 @@ func checkModification(filePath string) bool {
 	return err == nil
 }
 
-// extractVersion extracts version from CLAUDE.md content.
-func extractVersion(content string) string {
-	re := regexp.MustCompile(`\*\*Current Version\*\*:\s*(\d+\.\d+\.\d+)`)
-	matches := re.FindStringSubmatch(content)
-	if len(matches) > 1 {
-		return matches[1]
+// checkIgnoredPaths reports samuel-managed paths that the project's
+// .gitignore ignores, since update skips writing them and they are not
+// shared with the rest of the team.
+func checkIgnoredPaths(cwd string) checkResult {
+	ignore := core.LoadGitIgnore(cwd)
+	var ignored []string
+	for _, p := range []string{"CLAUDE.md", "AGENTS.md", core.ConfigFileName} {
+		if ignore.Ignored(p) {
+			ignored = append(ignored, p)
+		}
 	}
-
-	re = regexp.MustCompile(`Current Version:\s*(\d+\.\d+\.\d+)`)
-	matches = re.FindStringSubmatch(content)
-	if len(matches) > 1 {
-		return matches[1]
+	if ignore.IgnoredDir(".claude/skills") {
+		ignored = append(ignored, ".claude/skills/")
 	}
 
-	return ""
+	if len(ignored) == 0 {
+		return checkResult{
+			name:    "Ignored paths",
+			passed:  true,
+			message: "No samuel files ignored by .gitignore",
+		}
+	}
+	return checkResult{
+		name:    "Ignored paths",
+		passed:  false,
+		message: fmt.Sprintf("Ignored by .gitignore: %s", strings.Join(ignored, ", ")),
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ar4mirez/samuel/internal/core"
@@ -96,6 +97,37 @@ func TestCheckAGENTSMD(t *testing.T) {
 	})
 }
 
+func TestCheckIgnoredPaths(t *testing.T) {
+	tests := []struct {
+		name      string
+		gitignore string
+		passed    bool
+		mentions  string
+	}{
+		{"no_gitignore", "", true, ""},
+		{"unrelated_rules", "*.log\nbin/\n", true, ""},
+		{"agents_md_ignored", "AGENTS.md\n", false, "AGENTS.md"},
+		{"claude_dir_ignored", ".claude/\n", false, ".claude/skills/"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.gitignore != "" {
+				if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte(tt.gitignore), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			result := checkIgnoredPaths(dir)
+			if result.passed != tt.passed {
+				t.Errorf("passed = %v, want %v (%s)", result.passed, tt.passed, result.message)
+			}
+			if !strings.Contains(result.message, tt.mentions) {
+				t.Errorf("message = %q, want it to mention %q", result.message, tt.mentions)
+			}
+		})
+	}
+}
+
 func TestCheckDirectoryStructure(t *testing.T) {
 	t.Run("all_present", func(t *testing.T) {
 		dir := t.TempDir()
@@ -268,7 +300,7 @@ func TestCheckInstalledComponents(t *testing.T) {
 func TestCheckSkillsIntegrity(t *testing.T) {
 	t.Run("no_skills_directory", func(t *testing.T) {
 		dir := t.TempDir()
-		results := checkSkillsIntegrity(dir)
+		results := checkSkillsIntegrity(projectSkillCache(dir))
 		if results != nil {
 			t.Errorf("expected nil results when skills dir doesn't exist, got %v", results)
 		}
@@ -279,7 +311,7 @@ func TestCheckSkillsIntegrity(t *testing.T) {
 		if err := os.MkdirAll(filepath.Join(dir, ".claude", "skills"), 0755); err != nil {
 			t.Fatal(err)
 		}
-		results := checkSkillsIntegrity(dir)
+		results := checkSkillsIntegrity(projectSkillCache(dir))
 		if len(results) != 1 {
 			t.Fatalf("expected 1 result, got %d", len(results))
 		}
@@ -308,7 +340,7 @@ Body content here.`
 		if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0644); err != nil {
 			t.Fatal(err)
 		}
-		results := checkSkillsIntegrity(dir)
+		results := checkSkillsIntegrity(projectSkillCache(dir))
 		if len(results) != 1 {
 			t.Fatalf("expected 1 result, got %d", len(results))
 		}
@@ -327,7 +359,7 @@ Body content here.`
 		if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("# No metadata"), 0644); err != nil {
 			t.Fatal(err)
 		}
-		results := checkSkillsIntegrity(dir)
+		results := checkSkillsIntegrity(projectSkillCache(dir))
 		if len(results) != 1 {
 			t.Fatalf("expected 1 result, got %d", len(results))
 		}
@@ -365,7 +397,7 @@ Body.`
 			t.Fatal(err)
 		}
 
-		results := checkSkillsIntegrity(dir)
+		results := checkSkillsIntegrity(projectSkillCache(dir))
 		if len(results) != 1 {
 			t.Fatalf("expected 1 result, got %d", len(results))
 		}
@@ -436,6 +468,34 @@ func TestCheckAutoHealth(t *testing.T) {
 		}
 	})
 
+	t.Run("recovered_from_backup", func(t *testing.T) {
+		dir := t.TempDir()
+		prdPath := core.GetAutoPRDPath(dir)
+		prd := core.NewAutoPRD("test", "test project")
+		prd.Tasks = []core.AutoTask{{ID: "1", Title: "Task 1", Status: "pending"}}
+		if err := prd.Save(prdPath); err != nil {
+			t.Fatal(err)
+		}
+		prd.Tasks[0].Status = "completed"
+		if err := prd.Save(prdPath); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(prdPath, []byte(`{"version": "1.0", "tas`), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		results := checkAutoHealth(dir)
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(results))
+		}
+		if results[0].passed || !strings.Contains(results[0].message, "prd.json.bak") {
+			t.Errorf("expected a failed recovery result, got: %+v", results[0])
+		}
+		if !results[1].passed {
+			t.Errorf("expected the backup to validate, got: %s", results[1].message)
+		}
+	})
+
 	t.Run("missing_prd", func(t *testing.T) {
 		dir := t.TempDir()
 		results := checkAutoHealth(dir)
@@ -524,50 +584,15 @@ func TestCheckModification(t *testing.T) {
 	})
 }
 
-func TestExtractVersion(t *testing.T) {
-	tests := []struct {
-		name    string
-		content string
-		want    string
-	}{
-		{
-			name:    "bold_version",
-			content: "**Current Version**: 1.2.3",
-			want:    "1.2.3",
-		},
-		{
-			name:    "plain_version",
-			content: "Current Version: 4.5.6",
-			want:    "4.5.6",
-		},
-		{
-			name:    "no_version",
-			content: "# Some content\nNo version here",
-			want:    "",
-		},
-		{
-			name:    "empty_content",
-			content: "",
-			want:    "",
-		},
-		{
-			name:    "version_in_multiline",
-			content: "# Header\n\n**Current Version**: 10.20.30\n\nOther stuff",
-			want:    "10.20.30",
-		},
-		{
-			name:    "bold_preferred_over_plain",
-			content: "**Current Version**: 1.0.0\nCurrent Version: 2.0.0",
-			want:    "1.0.0",
-		},
+func TestCheckConfigFile_EmbeddedTemplate(t *testing.T) {
+	dir := t.TempDir()
+	config := core.NewConfig("1.2.3")
+	config.Embedded = true
+	if err := config.Save(dir); err != nil {
+		t.Fatal(err)
 	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := extractVersion(tt.content)
-			if got != tt.want {
-				t.Errorf("extractVersion() = %q, want %q", got, tt.want)
-			}
-		})
+	result, _ := checkConfigFile(dir)
+	if !result.passed || !strings.Contains(result.message, "samuel update") {
+		t.Errorf("checkConfigFile() = %+v, want a passing check suggesting an update", result)
 	}
 }
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// defaultDoctorBudget is the overall time allowed for all doctor checks.
+const defaultDoctorBudget = 30 * time.Second
+
+// doctorEnv is the shared, read-only input to every doctor check.
+type doctorEnv struct {
+	cwd    string
+	config *core.Config
+	skills *core.SkillCache
+}
+
+// newDoctorEnv builds the check input for the project in cwd.
+func newDoctorEnv(cwd string, config *core.Config) doctorEnv {
+	return doctorEnv{cwd: cwd, config: config, skills: projectSkillCache(cwd)}
+}
+
+// doctorOutcome is what a single check reports back to the runner.
+type doctorOutcome struct {
+	id          string
+	results     []checkResult
+	missingDirs []string
+	elapsed     time.Duration
+	timedOut    bool
+}
+
+// doctorCheck is a named, independently runnable health check.
+type doctorCheck struct {
+	id   string
+	name string
+	run  func(env doctorEnv) doctorOutcome
+}
+
+// doctorChecks lists the checks that run concurrently after the config
+// has been loaded. Order here is the order results are printed in.
+var doctorChecks = []doctorCheck{
+	{id: "claude-md", name: "CLAUDE.md", run: func(env doctorEnv) doctorOutcome {
+		return doctorOutcome{results: []checkResult{checkCLAUDEMD(env.cwd)}}
+	}},
+	{id: "agents-md", name: "AGENTS.md", run: func(env doctorEnv) doctorOutcome {
+		return doctorOutcome{results: []checkResult{checkAGENTSMD(env.cwd)}}
+	}},
+	{id: "dirs", name: "Directory structure", run: func(env doctorEnv) doctorOutcome {
+		result, missing := checkDirectoryStructure(env.cwd)
+		return doctorOutcome{results: []checkResult{result}, missingDirs: missing}
+	}},
+	{id: "components", name: "Installed components", run: func(env doctorEnv) doctorOutcome {
+		if env.config == nil {
+			return doctorOutcome{}
+		}
+		return doctorOutcome{results: checkInstalledComponents(env.cwd, env.config)}
+	}},
+	{id: "gitignore", name: "Ignored paths", run: func(env doctorEnv) doctorOutcome {
+		return doctorOutcome{results: []checkResult{checkIgnoredPaths(env.cwd)}}
+	}},
+	{id: "skills", name: "Skills", run: func(env doctorEnv) doctorOutcome {
+		return doctorOutcome{results: checkSkillsIntegrity(env.skills)}
+	}},
+	{id: "skills-section", name: "Skills section", run: func(env doctorEnv) doctorOutcome {
+		return doctorOutcome{results: checkSkillsSection(env.cwd)}
+	}},
+	{id: "auto", name: "Auto loop", run: func(env doctorEnv) doctorOutcome {
+		if _, err := os.Stat(core.GetAutoDir(env.cwd)); err != nil {
+			return doctorOutcome{}
+		}
+		return doctorOutcome{results: checkAutoHealth(env.cwd)}
+	}},
+	{id: "modifications", name: "Local modifications", run: func(env doctorEnv) doctorOutcome {
+		if env.config == nil {
+			return doctorOutcome{}
+		}
+		return doctorOutcome{results: checkLocalModifications(env.cwd, env.config)}
+	}},
+}
+
+// doctorConfigCheckID selects the config check, which always runs first
+// because the other checks depend on the loaded config.
+const doctorConfigCheckID = "config"
+
+// doctorCheckIDs returns the IDs accepted by 'samuel doctor --only'.
+func doctorCheckIDs() []string {
+	ids := []string{doctorConfigCheckID}
+	for _, c := range doctorChecks {
+		ids = append(ids, c.id)
+	}
+	return ids
+}
+
+// normalizeDoctorOnly lowercases the --only IDs and rejects unknown ones.
+func normalizeDoctorOnly(only []string) ([]string, error) {
+	normalized := make([]string, 0, len(only))
+	for _, id := range only {
+		id = strings.ToLower(strings.TrimSpace(id))
+		if !slices.Contains(doctorCheckIDs(), id) {
+			return nil, fmt.Errorf("unknown check: %s (supported: %v)", id, doctorCheckIDs())
+		}
+		normalized = append(normalized, id)
+	}
+	return normalized, nil
+}
+
+// selectDoctorChecks filters checks to the requested IDs; empty means all.
+func selectDoctorChecks(checks []doctorCheck, only []string) []doctorCheck {
+	if len(only) == 0 {
+		return checks
+	}
+	var selected []doctorCheck
+	for _, c := range checks {
+		if slices.Contains(only, c.id) {
+			selected = append(selected, c)
+		}
+	}
+	return selected
+}
+
+// runDoctorChecks runs checks concurrently and returns their outcomes in
+// declaration order. Checks still running when the budget expires are
+// reported as failed; their goroutines are abandoned since checks only read.
+func runDoctorChecks(checks []doctorCheck, env doctorEnv, budget time.Duration) []doctorOutcome {
+	type indexed struct {
+		i       int
+		outcome doctorOutcome
+	}
+	done := make(chan indexed, len(checks))
+	for i, c := range checks {
+		go func(i int, c doctorCheck) {
+			start := time.Now()
+			outcome := c.run(env)
+			outcome.id, outcome.elapsed = c.id, time.Since(start)
+			done <- indexed{i, outcome}
+		}(i, c)
+	}
+
+	outcomes := make([]doctorOutcome, len(checks))
+	finished := make([]bool, len(checks))
+	timer := time.NewTimer(budget)
+	defer timer.Stop()
+
+	for remaining := len(checks); remaining > 0; remaining-- {
+		select {
+		case r := <-done:
+			outcomes[r.i], finished[r.i] = r.outcome, true
+		case <-timer.C:
+			markTimedOut(checks, outcomes, finished, budget)
+			return outcomes
+		}
+	}
+	return outcomes
+}
+
+func markTimedOut(checks []doctorCheck, outcomes []doctorOutcome, finished []bool, budget time.Duration) {
+	for i, c := range checks {
+		if finished[i] {
+			continue
+		}
+		outcomes[i] = doctorOutcome{
+			id:       c.id,
+			elapsed:  budget,
+			timedOut: true,
+			results: []checkResult{{
+				name:    c.name,
+				message: fmt.Sprintf("did not finish within the %s budget", budget),
+			}},
+		}
+	}
+}
+
+// printDoctorTiming shows how long each check took and the overall time.
+func printDoctorTiming(outcomes []doctorOutcome, total time.Duration) {
+	ui.Section("Timing")
+	for _, o := range outcomes {
+		elapsed := formatCheckDuration(o.elapsed)
+		if o.timedOut {
+			elapsed += " (timed out)"
+		}
+		ui.TableRow(o.id, elapsed)
+	}
+	ui.TableRow("total", formatCheckDuration(total))
+}
+
+// formatCheckDuration keeps sub-millisecond checks from showing as "0s".
+func formatCheckDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return d.Round(time.Microsecond).String()
+	}
+	return d.Round(time.Millisecond).String()
+}
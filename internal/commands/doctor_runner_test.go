@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func fakeDoctorCheck(id string, delay time.Duration, passed bool) doctorCheck {
+	return doctorCheck{id: id, name: id, run: func(env doctorEnv) doctorOutcome {
+		time.Sleep(delay)
+		return doctorOutcome{results: []checkResult{{name: id, passed: passed, message: env.cwd}}}
+	}}
+}
+
+func TestRunDoctorChecks_PreservesOrder(t *testing.T) {
+	checks := []doctorCheck{
+		fakeDoctorCheck("slow", 30*time.Millisecond, true),
+		fakeDoctorCheck("fast", 0, false),
+	}
+
+	outcomes := runDoctorChecks(checks, doctorEnv{cwd: "/proj"}, time.Second)
+
+	if len(outcomes) != 2 || outcomes[0].id != "slow" || outcomes[1].id != "fast" {
+		t.Fatalf("outcomes = %+v, want slow then fast", outcomes)
+	}
+	if outcomes[0].elapsed < 30*time.Millisecond {
+		t.Errorf("slow check elapsed = %s, want >= 30ms", outcomes[0].elapsed)
+	}
+	if outcomes[1].results[0].message != "/proj" {
+		t.Errorf("check did not receive env, got %+v", outcomes[1].results)
+	}
+}
+
+func TestRunDoctorChecks_Concurrent(t *testing.T) {
+	const delay = 50 * time.Millisecond
+	checks := []doctorCheck{
+		fakeDoctorCheck("a", delay, true),
+		fakeDoctorCheck("b", delay, true),
+		fakeDoctorCheck("c", delay, true),
+	}
+
+	start := time.Now()
+	runDoctorChecks(checks, doctorEnv{}, time.Second)
+	if elapsed := time.Since(start); elapsed >= 3*delay {
+		t.Errorf("checks took %s, expected them to run concurrently", elapsed)
+	}
+}
+
+func TestRunDoctorChecks_Budget(t *testing.T) {
+	checks := []doctorCheck{
+		fakeDoctorCheck("quick", 0, true),
+		fakeDoctorCheck("hang", time.Second, true),
+	}
+
+	outcomes := runDoctorChecks(checks, doctorEnv{}, 20*time.Millisecond)
+
+	if outcomes[0].timedOut || !outcomes[0].results[0].passed {
+		t.Errorf("quick check should finish, got %+v", outcomes[0])
+	}
+	hang := outcomes[1]
+	if !hang.timedOut || hang.results[0].passed {
+		t.Errorf("hanging check should be reported as timed out, got %+v", hang)
+	}
+	if !strings.Contains(hang.results[0].message, "budget") {
+		t.Errorf("unexpected timeout message %q", hang.results[0].message)
+	}
+}
+
+func TestNormalizeDoctorOnly(t *testing.T) {
+	got, err := normalizeDoctorOnly([]string{"Skills", " auto "})
+	if err != nil {
+		t.Fatalf("normalizeDoctorOnly returned error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "skills" || got[1] != "auto" {
+		t.Errorf("normalizeDoctorOnly = %v, want [skills auto]", got)
+	}
+
+	if _, err := normalizeDoctorOnly([]string{"bogus"}); err == nil {
+		t.Error("expected error for unknown check ID")
+	}
+}
+
+func TestSelectDoctorChecks(t *testing.T) {
+	if got := selectDoctorChecks(doctorChecks, nil); len(got) != len(doctorChecks) {
+		t.Errorf("empty selection should keep all checks, got %d", len(got))
+	}
+
+	got := selectDoctorChecks(doctorChecks, []string{"skills", "config"})
+	if len(got) != 1 || got[0].id != "skills" {
+		t.Errorf("selectDoctorChecks = %+v, want only skills", got)
+	}
+}
+
+func TestDoctorCheckIDs_Unique(t *testing.T) {
+	seen := make(map[string]bool)
+	for _, id := range doctorCheckIDs() {
+		if seen[id] {
+			t.Errorf("duplicate check ID %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestFormatCheckDuration(t *testing.T) {
+	tests := []struct {
+		in   time.Duration
+		want string
+	}{
+		{1500 * time.Microsecond, "2ms"},
+		{250*time.Microsecond + 300, "250µs"},
+		{2 * time.Second, "2s"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCheckDuration(tt.in); got != tt.want {
+			t.Errorf("formatCheckDuration(%s) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
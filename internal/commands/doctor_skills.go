@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+// checkSkillsIntegrity scans and validates all installed skills.
+func checkSkillsIntegrity(cache *core.SkillCache) []checkResult {
+	if _, err := os.Stat(cache.Dir()); os.IsNotExist(err) {
+		return nil
+	}
+
+	skills, err := cache.Skills()
+	if err != nil {
+		return []checkResult{{
+			name:    "Skills",
+			passed:  false,
+			message: fmt.Sprintf("Failed to scan skills: %v", err),
+		}}
+	}
+
+	if len(skills) == 0 {
+		return []checkResult{{
+			name:    "Skills",
+			passed:  true,
+			message: "No skills installed",
+		}}
+	}
+
+	validCount := 0
+	invalidCount := 0
+	for _, skill := range skills {
+		if len(skill.Errors) == 0 {
+			validCount++
+		} else {
+			invalidCount++
+		}
+	}
+
+	if invalidCount == 0 {
+		return []checkResult{{
+			name:    "Skills",
+			passed:  true,
+			message: fmt.Sprintf("%d skill(s) installed, all valid", validCount),
+		}}
+	}
+	return []checkResult{{
+		name:    "Skills",
+		passed:  false,
+		message: fmt.Sprintf("%d skill(s) installed, %d invalid", len(skills), invalidCount),
+	}}
+}
+
+// checkSkillsSection reports a hand-edited managed skills block in
+// CLAUDE.md, which regeneration will refuse to overwrite.
+func checkSkillsSection(cwd string) []checkResult {
+	claudeMDPath := filepath.Join(cwd, "CLAUDE.md")
+	if _, err := os.Stat(claudeMDPath); err != nil {
+		return nil
+	}
+
+	modified, err := core.IsSkillsSectionModified(claudeMDPath)
+	if err != nil {
+		return []checkResult{{
+			name:    "Skills section",
+			passed:  false,
+			message: fmt.Sprintf("Failed to verify: %v", err),
+		}}
+	}
+	if modified {
+		return []checkResult{{
+			name:    "Skills section",
+			passed:  false,
+			message: "Managed block in CLAUDE.md was edited by hand; 'samuel init --overwrite-managed' will discard those edits",
+		}}
+	}
+	return []checkResult{{
+		name:    "Skills section",
+		passed:  true,
+		message: "No manual edits inside the managed block",
+	}}
+}
@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+func TestCheckSkillsSection(t *testing.T) {
+	t.Run("no_claude_md", func(t *testing.T) {
+		if results := checkSkillsSection(t.TempDir()); results != nil {
+			t.Errorf("expected nil results without CLAUDE.md, got %v", results)
+		}
+	})
+
+	skills := []*core.SkillInfo{{Metadata: core.SkillMetadata{Name: "s", Description: "Skill."}}}
+	setup := func(t *testing.T) (string, string) {
+		t.Helper()
+		dir := t.TempDir()
+		path := filepath.Join(dir, "CLAUDE.md")
+		managed := core.SkillsStartMarker + "\n" + core.SkillsEndMarker + "\n"
+		if err := os.WriteFile(path, []byte(managed), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := core.UpdateCLAUDEMDSkillsSection(path, skills); err != nil {
+			t.Fatal(err)
+		}
+		return dir, path
+	}
+
+	t.Run("generated_block_passes", func(t *testing.T) {
+		dir, _ := setup(t)
+		results := checkSkillsSection(dir)
+		if len(results) != 1 || !results[0].passed {
+			t.Errorf("expected passing result, got %v", results)
+		}
+	})
+
+	t.Run("hand_edited_block_fails", func(t *testing.T) {
+		dir, path := setup(t)
+		content, _ := os.ReadFile(path)
+		edited := strings.Replace(string(content), "Skill.", "Edited skill.", 1)
+		if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		results := checkSkillsSection(dir)
+		if len(results) != 1 || results[0].passed || results[0].fixable {
+			t.Errorf("expected failing, non-fixable result, got %v", results)
+		}
+	})
+}
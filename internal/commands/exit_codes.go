@@ -0,0 +1,38 @@
+package commands
+
+import "errors"
+
+// Process exit codes, documented in docs/reference/cli.md.
+const (
+	exitGeneral         = 1
+	exitInvalidArgs     = 2
+	exitConfigError     = 4
+	exitAssertionFailed = 5
+)
+
+// exitCodeError attaches a process exit code to an error.
+type exitCodeError struct {
+	code int
+	err  error
+}
+
+func (e *exitCodeError) Error() string { return e.err.Error() }
+func (e *exitCodeError) Unwrap() error { return e.err }
+
+// withExitCode makes the process exit with code when err reaches main.
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{code: code, err: err}
+}
+
+// ExitCode returns the process exit code for an error returned by
+// Execute: the code attached with withExitCode, or 1.
+func ExitCode(err error) int {
+	var e *exitCodeError
+	if errors.As(err, &e) {
+		return e.code
+	}
+	return exitGeneral
+}
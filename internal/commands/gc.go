@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove old update backups and log entries",
+	Long: `Apply retention policies to artifacts that accumulate in long-lived
+projects:
+
+  backups    .samuel-backup-* directories created by 'samuel update'
+  events     entries of .claude/auto/events.jsonl, the auto loop event log
+  overrides  entries of .claude/policy-overrides.jsonl, the policy audit log
+
+An artifact is removed when it is beyond the newest 'keep', older than
+'max_age', or would push the total past 'max_size'. The newest artifact of
+each kind is always kept. Log entries are aged by their timestamp and
+dropped by rewriting the log. Policies are set in samuel.yaml:
+
+  gc:
+    backups:
+      keep: 5
+      max_age: 90d
+      max_size: 500MB
+
+Defaults: backups keep 5 / 90d / 500MB; events keep 5000 / 30d / 50MB;
+overrides keep 1000 / 365d / 10MB. Backups are also pruned after
+'samuel update' and events after auto loop runs.
+
+Examples:
+  samuel gc --dry-run        # Show what would be removed
+  samuel gc                  # Remove expired artifacts
+  samuel gc --only backups   # Only prune update backups`,
+	Args: cobra.NoArgs,
+	RunE: runGC,
+}
+
+func init() {
+	rootCmd.AddCommand(gcCmd)
+	gcCmd.Flags().Bool("dry-run", false, "Show what would be removed without deleting")
+	gcCmd.Flags().StringSlice("only", nil, "Artifact kinds to prune: backups, events, overrides")
+}
+
+func runGC(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	only, _ := cmd.Flags().GetStringSlice("only")
+	kinds := core.GetSupportedGCKinds()
+	if len(only) > 0 {
+		kinds = nil
+		for _, kind := range only {
+			kind = strings.ToLower(strings.TrimSpace(kind))
+			if !slices.Contains(core.GetSupportedGCKinds(), kind) {
+				return fmt.Errorf("unsupported artifact kind: %s (supported: %v)", kind, core.GetSupportedGCKinds())
+			}
+			kinds = append(kinds, kind)
+		}
+	}
+
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	run := func() error {
+		result, err := core.CollectGarbage(cwd, loadGCConfig(cwd), kinds, time.Now(), dryRun)
+		if result != nil {
+			displayGCResult(result, dryRun)
+		}
+		return err
+	}
+	if dryRun {
+		return run()
+	}
+	return withProjectLock(cmd, cwd, run)
+}
+
+// loadGCConfig returns the project's retention policies, or nil for the
+// defaults when there is no readable samuel.yaml.
+func loadGCConfig(dir string) *core.GCConfig {
+	config, err := core.LoadConfigFrom(dir)
+	if err != nil {
+		return nil
+	}
+	return config.GC
+}
+
+func displayGCResult(result *core.GCResult, dryRun bool) {
+	if len(result.Removed) == 0 {
+		ui.Success("Nothing to remove")
+		return
+	}
+	for _, a := range result.Removed {
+		ui.ListItem(1, "%-10s %s (%s)", a.Kind, a.Location(), formatFileSize(a.Size))
+	}
+	fmt.Println()
+	if dryRun {
+		ui.Info("Would remove %d artifacts, freeing %s", len(result.Removed), formatFileSize(result.FreedBytes))
+		return
+	}
+	ui.Success("Removed %d artifacts, freed %s", len(result.Removed), formatFileSize(result.FreedBytes))
+}
+
+// enforceRetention prunes artifacts of the given kinds after a command
+// created more of them. It stays quiet unless something was removed and
+// only warns on failure.
+func enforceRetention(dir string, kinds ...string) {
+	result, err := core.CollectGarbage(dir, loadGCConfig(dir), kinds, time.Now(), false)
+	if err != nil {
+		ui.Warn("Retention cleanup failed: %v", err)
+		return
+	}
+	if len(result.Removed) > 0 {
+		ui.Dim("Removed %d old %s per retention policy (freed %s)",
+			len(result.Removed), strings.Join(kinds, "/"), formatFileSize(result.FreedBytes))
+	}
+}
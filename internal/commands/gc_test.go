@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newGCTestCmd() *cobra.Command {
+	cmd := &cobra.Command{RunE: runGC}
+	cmd.Flags().Bool("dry-run", false, "")
+	cmd.Flags().StringSlice("only", nil, "")
+	return cmd
+}
+
+func TestRunGC(t *testing.T) {
+	tests := []struct {
+		name         string
+		flags        map[string]string
+		wantErr      bool
+		wantOldEvent bool
+	}{
+		{name: "removes_stale_events", wantOldEvent: false},
+		{name: "dry_run_keeps_files", flags: map[string]string{"dry-run": "true"}, wantOldEvent: true},
+		{name: "only_backups", flags: map[string]string{"only": "backups"}, wantOldEvent: true},
+		{name: "unknown_kind", flags: map[string]string{"only": "caches"}, wantErr: true, wantOldEvent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			logPath := core.GetAutoEventLogPath(dir)
+			if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+				t.Fatal(err)
+			}
+			old := time.Now().Add(-60 * 24 * time.Hour).UTC().Format(time.RFC3339)
+			recent := time.Now().UTC().Format(time.RFC3339)
+			content := `{"timestamp":"` + old + `","task_id":"old"}` + "\n" +
+				`{"timestamp":"` + recent + `","task_id":"new"}` + "\n"
+			if err := os.WriteFile(logPath, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			oldDir, _ := os.Getwd()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(oldDir)
+
+			cmd := newGCTestCmd()
+			for k, v := range tt.flags {
+				cmd.Flags().Set(k, v)
+			}
+			err := cmd.RunE(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			data, _ := os.ReadFile(logPath)
+			if exists := strings.Contains(string(data), `"old"`); exists != tt.wantOldEvent {
+				t.Errorf("old event kept = %v, want %v", exists, tt.wantOldEvent)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// defaultGrepLimit is the maximum number of matching lines shown by default.
+const defaultGrepLimit = 50
+
+var grepCmd = &cobra.Command{
+	Use:   "grep <query>",
+	Short: "Search the content of installed skills",
+	Long: `Search the text of installed skills (.claude/skills/**/*.md) and print
+matching lines.
+
+A line matches when it contains every word of the query; each word also
+matches longer words it starts, so "migrat" finds "migration".
+
+Results come from an on-disk index (.claude/.samuel-index.json) that is
+built on first use, rebuilt when skill files change, and refreshed by
+init, update, add, and remove. The index is kept out of git. Use
+--no-index to scan the files directly without writing an index.
+
+Examples:
+  samuel grep "error handling"          # Lines mentioning both words
+  samuel grep migrat --skill go-guide   # Only search one skill
+  samuel grep docker --json             # Machine-readable output`,
+	Args: cobra.ExactArgs(1),
+	RunE: runGrep,
+}
+
+func init() {
+	rootCmd.AddCommand(grepCmd)
+	grepCmd.Flags().StringP("skill", "s", "", "Only search this skill")
+	grepCmd.Flags().IntP("limit", "n", defaultGrepLimit, "Maximum number of matching lines (0 for no limit)")
+	grepCmd.Flags().Bool("no-index", false, "Scan skill files directly instead of using the index")
+	grepCmd.Flags().Bool("json", false, "Print matches as JSON")
+}
+
+func runGrep(cmd *cobra.Command, args []string) error {
+	skill, _ := cmd.Flags().GetString("skill")
+	limit, _ := cmd.Flags().GetInt("limit")
+	noIndex, _ := cmd.Flags().GetBool("no-index")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	matches, err := searchSkillContent(cwd, args[0], skill, limit, noIndex)
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		if matches == nil {
+			matches = []core.ContentMatch{}
+		}
+		data, err := json.MarshalIndent(matches, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode matches: %w", err)
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	displayGrepMatches(args[0], matches, limit)
+	return nil
+}
+
+// searchSkillContent queries the project's skill index, building it when
+// needed. With noIndex the index is built in memory and not saved.
+func searchSkillContent(dir, query, skill string, limit int, noIndex bool) ([]core.ContentMatch, error) {
+	var idx *core.SkillIndex
+	var err error
+	if noIndex {
+		idx, err = core.BuildSkillIndex(dir)
+	} else {
+		idx, err = core.OpenSkillIndex(dir)
+		if idx != nil && err != nil {
+			ui.Warn("Could not save skill index: %v", err)
+			err = nil
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to index skills: %w", err)
+	}
+	return idx.Search(dir, query, skill, limit)
+}
+
+func displayGrepMatches(query string, matches []core.ContentMatch, limit int) {
+	if len(matches) == 0 {
+		ui.Warn("No skill content found matching '%s'", query)
+		ui.Info("Try fewer or shorter words, or 'samuel search' to find skills to install")
+		return
+	}
+
+	skill := ""
+	for _, m := range matches {
+		if m.Skill != skill {
+			skill = m.Skill
+			ui.Section(skill)
+		}
+		ui.Print("  %s:%d: %s", m.Path, m.Line, m.Text)
+	}
+	fmt.Println()
+	if limit > 0 && len(matches) == limit {
+		ui.Dim("Showing the first %d matches (use --limit to see more)", limit)
+		return
+	}
+	ui.Dim("%d matching line(s)", len(matches))
+}
+
+// refreshSkillIndex updates the content index after skills were installed
+// or removed. Failures only warn: the index is rebuilt on the next query.
+func refreshSkillIndex(dir string) {
+	if err := core.RefreshSkillIndex(dir); err != nil {
+		ui.Warn("Could not refresh skill index: %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+func TestSearchSkillContent(t *testing.T) {
+	tests := []struct {
+		name      string
+		noIndex   bool
+		wantSaved bool
+	}{
+		{"builds_and_saves_index", false, true},
+		{"no_index_leaves_no_file", true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			skillsDir := filepath.Join(dir, ".claude", "skills")
+			createSkillDir(t, skillsDir, "go-guide", validSkillMD("go-guide", "Go")+"Wrap errors with context.\n")
+
+			matches, err := searchSkillContent(dir, "wrap error", "", 0, tt.noIndex)
+			if err != nil {
+				t.Fatalf("searchSkillContent returned error: %v", err)
+			}
+			if len(matches) != 1 || matches[0].Skill != "go-guide" {
+				t.Errorf("matches = %+v, want one go-guide line", matches)
+			}
+			_, err = os.Stat(core.GetSkillIndexPath(dir))
+			if saved := err == nil; saved != tt.wantSaved {
+				t.Errorf("index saved = %v, want %v", saved, tt.wantSaved)
+			}
+		})
+	}
+}
+
+func TestSearchInstalledContent(t *testing.T) {
+	dir := t.TempDir()
+	skillsDir := filepath.Join(dir, ".claude", "skills")
+	createSkillDir(t, skillsDir, "go-guide", validSkillMD("go-guide", "Go")+"Retry with backoff.\n")
+	createSkillDir(t, skillsDir, "commit-message", validSkillMD("commit-message", "Commits")+"Never retry a push.\n")
+
+	existing := []SearchResult{{Name: "commit-message", Type: "skill", Score: 80}}
+	results := searchInstalledContent(dir, "retry", existing)
+
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want the existing result plus go-guide", results)
+	}
+	added := results[1]
+	if added.Name != "go-guide" || added.Score != contentMatchScore || !added.Installed {
+		t.Errorf("content result = %+v", added)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -59,7 +60,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("component not found")
 	}
 
-	config, configErr := core.LoadConfig()
+	config, dir, configErr := loadProjectConfig(cmd)
 	if configErr != nil && !os.IsNotExist(configErr) {
 		ui.Warn("Could not load config: %v", configErr)
 	}
@@ -67,7 +68,7 @@ func runInfo(cmd *cobra.Command, args []string) error {
 
 	displayComponentInfo(component, componentType, installed)
 	displayRelatedComponents(config, component, componentType, noRelated)
-	displayPreview(component.Path, previewLines, installed)
+	displayPreview(filepath.Join(dir, component.Path), previewLines, installed)
 
 	return nil
 }
@@ -214,22 +215,7 @@ func getFrameworksForLanguage(langName string) []RelatedComponent {
 func getLanguageForFramework(fwName string) []RelatedComponent {
 	var related []RelatedComponent
 
-	// Map frameworks to their languages
-	frameworkLang := map[string]string{
-		"react": "typescript", "nextjs": "typescript", "express": "typescript",
-		"django": "python", "fastapi": "python", "flask": "python",
-		"gin": "go", "echo": "go", "fiber": "go",
-		"axum": "rust", "actix-web": "rust", "rocket": "rust",
-		"spring-boot-kotlin": "kotlin", "ktor": "kotlin", "android-compose": "kotlin",
-		"spring-boot-java": "java", "quarkus": "java", "micronaut": "java",
-		"aspnet-core": "csharp", "blazor": "csharp", "unity": "csharp",
-		"laravel": "php", "symfony": "php", "wordpress": "php",
-		"swiftui": "swift", "uikit": "swift", "vapor": "swift",
-		"rails": "ruby", "sinatra": "ruby", "hanami": "ruby",
-		"flutter": "dart", "shelf": "dart", "dart-frog": "dart",
-	}
-
-	if langName, ok := frameworkLang[fwName]; ok {
+	if langName := core.FrameworkLanguage(fwName); langName != "" {
 		if lang := core.FindLanguage(langName); lang != nil {
 			related = append(related, RelatedComponent{
 				Name:        lang.Name,
@@ -25,7 +25,11 @@ Examples:
   samuel init my-project              # Create new project
   samuel init .                       # Initialize in current directory
   samuel init --template minimal      # Use minimal template
-  samuel init --languages ts,py,go    # Select specific languages`,
+  samuel init --minimal               # Minimal template, works offline
+  samuel init --languages ts,py,go    # Select specific languages
+  samuel init --overlay https://github.com/acme/samuel-overlay  # Apply a company overlay
+  samuel init --channel beta          # Install the latest prerelease
+  samuel init --language es           # Core instructions in Spanish`,
 	RunE: runInit,
 }
 
@@ -36,6 +40,14 @@ func init() {
 	initCmd.Flags().StringSlice("frameworks", nil, "Frameworks to install (comma-separated)")
 	initCmd.Flags().BoolP("force", "f", false, "Overwrite existing files")
 	initCmd.Flags().Bool("non-interactive", false, "Skip prompts, use defaults")
+	initCmd.Flags().Bool("minimal", false, "Install the minimal template without prompts, from the copy built into the CLI when offline")
+	initCmd.Flags().Bool("overwrite-managed", false, "Regenerate the CLAUDE.md skills section even if it was edited by hand")
+	initCmd.Flags().String("overlay", "", "GitHub registry whose files are applied on top of the base template")
+	initCmd.Flags().String("overlay-branch", "", "Overlay branch to track (default: main)")
+	initCmd.Flags().String("overlay-fingerprint", "", "Expected overlay fingerprint, pinned without prompting (see 'samuel registry trust')")
+	initCmd.Flags().String("channel", "", "Release channel: stable or beta (beta includes prereleases)")
+	initCmd.Flags().String("language", "", "Language of the core instructions in CLAUDE.md and AGENTS.md (e.g., es; default: en)")
+	addPolicyOverrideFlag(initCmd)
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
@@ -43,6 +55,9 @@ func runInit(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := applyMinimalFlag(cmd, flags); err != nil {
+		return err
+	}
 
 	if err := validateInitTarget(flags); err != nil {
 		return err
@@ -57,16 +72,34 @@ func runInit(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	version, cachePath, err := downloadFramework()
-	if err != nil {
+	if flags.trust, err = confirmOverlayTrust(flags.trust, flags.overlay); err != nil {
 		return err
 	}
 
-	if err := installAndSetup(flags, sel, version, cachePath); err != nil {
+	version, tmpl, err := downloadFramework(flags.overlay, flags.channel)
+	if err != nil && flags.minimal {
+		version, tmpl, err = embeddedFramework(err)
+		sel.embedded = err == nil
+	}
+	if err != nil {
+		return err
+	}
+	reportOverlay(tmpl)
+	if err := checkInitPolicy(cmd, flags, sel, tmpl); err != nil {
 		return err
 	}
 
-	return saveInitConfig(flags, sel, version)
+	install := func() error {
+		if err := installAndSetup(flags, sel, version, tmpl, projectSkillCache(flags.absTargetDir)); err != nil {
+			return err
+		}
+		refreshSkillIndex(flags.absTargetDir)
+		return saveInitConfig(flags, sel, version)
+	}
+	if flags.createDir {
+		return install() // a directory we are about to create cannot be locked yet
+	}
+	return withProjectLock(cmd, flags.absTargetDir, install)
 }
 
 // expandLanguages expands short language names.
@@ -179,23 +212,57 @@ func getRelevantFrameworks(languages []string) []core.Component {
 }
 
 // reportInitResults displays the installation summary to the user.
-func reportInitResults(result *core.ExtractResult, version string, sel *initSelections, installedSkills []*core.SkillInfo) {
+func reportInitResults(result *core.ExtractResult, tmpl *core.LayeredTemplate, version string, sel *initSelections, installedSkills []*core.SkillInfo) {
 	ui.Success("Installed CLAUDE.md (v%s)", version)
 	ui.Success("Installed AGENTS.md (cross-tool compatibility)")
 	ui.Success("Installed %d language guides", len(sel.languages))
 	ui.Success("Installed %d framework guides", len(sel.frameworks))
-	ui.Success("Installed %d workflows", len(core.Workflows))
+	workflows := len(core.Workflows)
+	if sel.embedded {
+		workflows = len(core.EmbeddedWorkflows)
+	}
+	ui.Success("Installed %d workflows", workflows)
 	if len(installedSkills) > 0 {
 		ui.Success("Installed %d skills", len(installedSkills))
 	}
 	if len(result.FilesSkipped) > 0 {
 		ui.Warn("Skipped %d existing files (use --force to overwrite)", len(result.FilesSkipped))
-	}
-	if len(result.Errors) > 0 {
-		for _, e := range result.Errors {
-			ui.Error("%v", e)
+		if len(tmpl.OverlayFiles()) > 0 {
+			for _, f := range result.FilesSkipped {
+				ui.WarnItem(1, "%s %s", f, layerTag(tmpl, f))
+			}
 		}
 	}
+	reportIgnoredFiles(result)
+	reportExtractFailures(result)
+}
+
+// downloadFramework downloads the latest framework version on the channel
+// and any overlay.
+func downloadFramework(overlay *core.OverlayConfig, channel string) (version string, tmpl *core.LayeredTemplate, err error) {
+	spinner := ui.NewSpinner("Downloading framework...")
+	spinner.Start()
+
+	downloader, err := core.NewDownloader()
+	if err != nil {
+		spinner.Error("Failed to initialize")
+		return "", nil, fmt.Errorf("failed to initialize downloader: %w", err)
+	}
+
+	version, err = downloader.GetLatestVersionForChannel(channel)
+	if err != nil {
+		spinner.Error("Failed to get latest version")
+		return "", nil, fmt.Errorf("failed to get latest version: %w", err)
+	}
+
+	tmpl, err = downloader.ResolveTemplate(version, overlay)
+	if err != nil {
+		spinner.Error("Download failed")
+		return "", nil, fmt.Errorf("failed to download framework: %w", err)
+	}
+	spinner.Success(fmt.Sprintf("Downloaded Samuel v%s", version))
+
+	return version, tmpl, nil
 }
 
 // saveInitConfig creates and saves the samuel.yaml config file and shows next steps.
@@ -204,6 +271,18 @@ func saveInitConfig(flags *initFlags, sel *initSelections, version string) error
 	config.Installed.Languages = sel.languages
 	config.Installed.Frameworks = sel.frameworks
 	config.Installed.Workflows = []string{"all"}
+	if sel.embedded {
+		config.Installed.Workflows = core.EmbeddedWorkflows
+		config.Embedded = true
+	}
+	config.Overlay = flags.overlay
+	config.Trust = flags.trust
+	if flags.channel != core.ChannelStable {
+		config.Channel = flags.channel
+	}
+	if flags.language != core.DefaultLanguage {
+		config.Language = flags.language
+	}
 
 	if err := config.Save(flags.absTargetDir); err != nil {
 		return fmt.Errorf("failed to save config: %w", err)
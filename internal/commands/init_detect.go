@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// reportDetectedComponents detects the languages and frameworks of the
+// project in dir and lists them before the interactive selection.
+func reportDetectedComponents(dir string) *core.ProjectDetection {
+	detected := core.DetectProject(dir)
+	components := append(append([]core.DetectedComponent{}, detected.Languages...), detected.Frameworks...)
+	if len(components) == 0 {
+		return detected
+	}
+	ui.Section("Detected in this project")
+	for _, c := range components {
+		ui.ListItem(1, "%s %s  %s", c.Kind, c.Name, detectedFrom(c))
+	}
+	return detected
+}
+
+// suggestedLanguages preselects the detected languages, or the template's
+// when none were detected.
+func suggestedLanguages(templateLanguages []string, detected *core.ProjectDetection) []string {
+	if names := detected.LanguageNames(); len(names) > 0 {
+		return names
+	}
+	return templateLanguages
+}
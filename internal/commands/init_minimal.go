@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// applyMinimalFlag turns --minimal into a non-interactive install of the
+// minimal template. It rejects the flags the embedded template cannot
+// honour, so falling back to it never drops part of the request.
+func applyMinimalFlag(cmd *cobra.Command, flags *initFlags) error {
+	flags.minimal, _ = cmd.Flags().GetBool("minimal")
+	if !flags.minimal {
+		return nil
+	}
+	switch {
+	case flags.templateName != "" && flags.templateName != "minimal":
+		return fmt.Errorf("--minimal cannot be combined with --template %s", flags.templateName)
+	case len(flags.languageFlags) > 0 || len(flags.frameworkFlags) > 0:
+		return fmt.Errorf("--minimal installs no languages or frameworks; add them later with 'samuel add'")
+	case flags.overlay != nil:
+		return fmt.Errorf("--minimal cannot be combined with --overlay")
+	}
+	flags.templateName = "minimal"
+	flags.cliProvided = true
+	return nil
+}
+
+// embeddedFramework returns the template built into the CLI after the
+// download of the minimal template failed, e.g. without network access.
+func embeddedFramework(downloadErr error) (string, *core.LayeredTemplate, error) {
+	ui.Warn("%v", downloadErr)
+	downloader, err := core.NewDownloader()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to initialize downloader: %w", err)
+	}
+	tmpl, err := downloader.EmbeddedTemplate(Version)
+	if err != nil {
+		return "", nil, err
+	}
+	ui.Info("Using the minimal template built into samuel %s; run 'samuel update' when online", Version)
+	return Version, tmpl, nil
+}
+
+// initComponentPaths returns the paths init extracts for sel.
+func initComponentPaths(sel *initSelections) []string {
+	if sel.embedded {
+		return core.EmbeddedTemplatePaths()
+	}
+	return core.GetComponentPaths(sel.languages, sel.frameworks, []string{"all"})
+}
@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+func TestApplyMinimalFlag(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   map[string]string
+		wantErr bool
+	}{
+		{name: "minimal", flags: map[string]string{"minimal": "true"}},
+		{name: "with template minimal", flags: map[string]string{"minimal": "true", "template": "minimal"}},
+		{name: "with other template", flags: map[string]string{"minimal": "true", "template": "starter"}, wantErr: true},
+		{name: "with languages", flags: map[string]string{"minimal": "true", "languages": "go"}, wantErr: true},
+		{name: "with overlay", flags: map[string]string{"minimal": "true", "overlay": "https://github.com/acme/overlay"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newInitCmd()
+			for k, v := range tt.flags {
+				if err := cmd.Flags().Set(k, v); err != nil {
+					t.Fatal(err)
+				}
+			}
+			flags, err := parseInitFlags(cmd, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = applyMinimalFlag(cmd, flags)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("applyMinimalFlag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && (flags.templateName != "minimal" || !flags.cliProvided) {
+				t.Errorf("flags = %+v, want the minimal template without prompts", flags)
+			}
+		})
+	}
+}
+
+func TestInitComponentPaths(t *testing.T) {
+	sel := &initSelections{embedded: true}
+	if got := initComponentPaths(sel); len(got) != len(core.CoreFiles)+len(core.EmbeddedWorkflows) {
+		t.Errorf("embedded paths = %v, want the core files and embedded workflows", got)
+	}
+	sel.embedded = false
+	if got := initComponentPaths(sel); len(got) != len(core.CoreFiles)+len(core.Workflows) {
+		t.Errorf("paths = %v, want the core files and all workflows", got)
+	}
+}
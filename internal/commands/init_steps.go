@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,14 +13,20 @@ import (
 
 // initFlags holds parsed command-line flags for the init command.
 type initFlags struct {
-	force          bool
-	nonInteractive bool
-	templateName   string
-	languageFlags  []string
-	frameworkFlags []string
-	cliProvided    bool
-	absTargetDir   string
-	createDir      bool
+	force            bool
+	nonInteractive   bool
+	overwriteManaged bool
+	templateName     string
+	languageFlags    []string
+	frameworkFlags   []string
+	cliProvided      bool
+	absTargetDir     string
+	createDir        bool
+	overlay          *core.OverlayConfig
+	trust            *core.TrustPolicy
+	channel          string
+	language         string
+	minimal          bool
 }
 
 // initSelections holds the user's component selections.
@@ -27,6 +34,8 @@ type initSelections struct {
 	template   *core.Template
 	languages  []string
 	frameworks []string
+	embedded   bool // installing the template built into the CLI
+	policy     installPolicy
 }
 
 // parseInitFlags extracts CLI flags and resolves the target directory.
@@ -34,12 +43,37 @@ func parseInitFlags(cmd *cobra.Command, args []string) (*initFlags, error) {
 	flags := &initFlags{}
 	flags.force, _ = cmd.Flags().GetBool("force")
 	flags.nonInteractive, _ = cmd.Flags().GetBool("non-interactive")
+	flags.overwriteManaged, _ = cmd.Flags().GetBool("overwrite-managed")
 	flags.templateName, _ = cmd.Flags().GetString("template")
 	flags.languageFlags, _ = cmd.Flags().GetStringSlice("languages")
 	flags.frameworkFlags, _ = cmd.Flags().GetStringSlice("frameworks")
+	var err error
+	if flags.overlay, err = parseOverlayFlags(cmd); err != nil {
+		return nil, err
+	}
+	if fingerprint, _ := cmd.Flags().GetString("overlay-fingerprint"); fingerprint != "" {
+		if flags.overlay == nil {
+			return nil, fmt.Errorf("--overlay-fingerprint requires --overlay")
+		}
+		flags.trust = flags.trust.Pin(flags.overlay.Registry, fingerprint)
+	}
+	flags.channel, _ = cmd.Flags().GetString("channel")
+	if err := core.ValidateChannel(flags.channel); err != nil {
+		return nil, err
+	}
+	flags.language, _ = cmd.Flags().GetString("language")
+	if err := core.ValidateLanguage(flags.language); err != nil {
+		return nil, err
+	}
 	flags.cliProvided = flags.templateName != "" || len(flags.languageFlags) > 0 || len(flags.frameworkFlags) > 0
 
 	targetDir := "."
+	if target, _ := cmd.Flags().GetString("target"); target != "" {
+		if len(args) > 0 && args[0] != target {
+			return nil, fmt.Errorf("pass the directory either as an argument or with --target, not both")
+		}
+		targetDir = target
+	}
 	if len(args) > 0 {
 		targetDir = args[0]
 	}
@@ -111,17 +145,17 @@ func selectComponents(flags *initFlags) (*initSelections, error) {
 	if len(flags.frameworkFlags) > 0 {
 		sel.frameworks = expandFrameworks(flags.frameworkFlags)
 	}
-	// Interactive language selection
+	// Interactive selection, suggesting what the project already uses
 	if !flags.nonInteractive && !flags.cliProvided && sel.template != nil && sel.template.Name != "full" {
-		langs, err := selectLanguagesInteractive(sel.languages)
+		detected := reportDetectedComponents(flags.absTargetDir)
+		langs, err := selectLanguagesInteractive(suggestedLanguages(sel.languages, detected))
 		if err != nil {
 			return nil, err
 		}
 		sel.languages = langs
-	}
-	// Interactive framework selection
-	if !flags.nonInteractive && !flags.cliProvided && sel.template != nil && sel.template.Name != "full" && len(sel.languages) > 0 {
-		sel.frameworks = selectFrameworksInteractive(sel.languages)
+		if len(sel.languages) > 0 {
+			sel.frameworks = selectFrameworksInteractive(sel.languages, detected.FrameworkNames())
+		}
 	}
 	// Default to starter template if nothing selected
 	if sel.template == nil && len(sel.languages) == 0 {
@@ -154,7 +188,7 @@ func selectLanguagesInteractive(defaults []string) ([]string, error) {
 }
 
 // selectFrameworksInteractive presents a multi-select prompt for frameworks.
-func selectFrameworksInteractive(selectedLangs []string) []string {
+func selectFrameworksInteractive(selectedLangs, defaults []string) []string {
 	relevantFrameworks := getRelevantFrameworks(selectedLangs)
 	if len(relevantFrameworks) == 0 {
 		return []string{}
@@ -167,7 +201,7 @@ func selectFrameworksInteractive(selectedLangs []string) []string {
 		}
 	}
 
-	selected, err := ui.MultiSelect("Select frameworks (optional)", fwOptions, nil)
+	selected, err := ui.MultiSelect("Select frameworks (optional)", fwOptions, defaults)
 	if err != nil {
 		return []string{}
 	}
@@ -188,7 +222,7 @@ func displayAndConfirm(flags *initFlags, sel *initSelections) bool {
 	ui.TableRow("Workflows", "all (13)")
 
 	if !flags.nonInteractive && !flags.cliProvided {
-		confirmed, err := ui.Confirm("\nProceed with installation?", true)
+		confirmed, err := approve(promptInitProceed, "\nProceed with installation?", true)
 		if err != nil || !confirmed {
 			ui.Info("Installation cancelled")
 			return false
@@ -197,35 +231,10 @@ func displayAndConfirm(flags *initFlags, sel *initSelections) bool {
 	return true
 }
 
-// downloadFramework downloads the latest framework version from GitHub.
-func downloadFramework() (version string, cachePath string, err error) {
-	spinner := ui.NewSpinner("Downloading framework...")
-	spinner.Start()
-
-	downloader, err := core.NewDownloader()
-	if err != nil {
-		spinner.Error("Failed to initialize")
-		return "", "", fmt.Errorf("failed to initialize downloader: %w", err)
-	}
-
-	version, err = downloader.GetLatestVersion()
-	if err != nil {
-		spinner.Error("Failed to get latest version")
-		return "", "", fmt.Errorf("failed to get latest version: %w", err)
-	}
-
-	cachePath, err = downloader.DownloadVersion(version)
-	if err != nil {
-		spinner.Error("Download failed")
-		return "", "", fmt.Errorf("failed to download framework: %w", err)
-	}
-	spinner.Success(fmt.Sprintf("Downloaded Samuel v%s", version))
-
-	return version, cachePath, nil
-}
-
 // installAndSetup extracts framework files and performs post-install setup.
-func installAndSetup(flags *initFlags, sel *initSelections, version, cachePath string) error {
+// skills is the run's skills cache; it is invalidated after extraction,
+// which may add or replace skills.
+func installAndSetup(flags *initFlags, sel *initSelections, version string, tmpl *core.LayeredTemplate, skills *core.SkillCache) error {
 	if flags.createDir {
 		if err := os.MkdirAll(flags.absTargetDir, 0755); err != nil {
 			return fmt.Errorf("failed to create directory: %w", err)
@@ -233,15 +242,19 @@ func installAndSetup(flags *initFlags, sel *initSelections, version, cachePath s
 		ui.Success("Created %s/", filepath.Base(flags.absTargetDir))
 	}
 
-	workflows := []string{"all"}
-	paths := core.GetComponentPaths(sel.languages, sel.frameworks, workflows)
-	extractor := core.NewExtractor(cachePath, flags.absTargetDir)
+	paths := initComponentPaths(sel)
+	paths = core.MergeOverlayPaths(paths, tmpl)
+	paths = sel.policy.filter(paths)
+	extractor := core.NewExtractor(tmpl.Path, flags.absTargetDir)
+	extractor.SetLanguage(flags.language)
+	warnUntranslated(tmpl.Path, flags.language)
 	result, err := extractor.Extract(paths, flags.force)
 	if err != nil {
 		return fmt.Errorf("failed to extract files: %w", err)
 	}
 
-	installedSkills := updateSkillsAndAgentsMD(flags.absTargetDir)
+	skills.Invalidate()
+	installedSkills := updateSkillsAndAgentsMD(flags.absTargetDir, skills, flags.overwriteManaged)
 
 	syncResult, syncErr := core.SyncFolderCLAUDEMDs(core.SyncOptions{
 		RootDir:  flags.absTargetDir,
@@ -253,21 +266,31 @@ func installAndSetup(flags *initFlags, sel *initSelections, version, cachePath s
 		ui.Success("Created %d per-folder CLAUDE.md/AGENTS.md files", len(syncResult.Created))
 	}
 
-	reportInitResults(result, version, sel, installedSkills)
+	reportInitResults(result, tmpl, version, sel, installedSkills)
+	if err := result.Err(); err != nil {
+		return fmt.Errorf("installation incomplete: %w; fix the errors above and rerun 'samuel init'", err)
+	}
 	return nil
 }
 
-// updateSkillsAndAgentsMD updates the skills section in CLAUDE.md and copies it to AGENTS.md.
-func updateSkillsAndAgentsMD(absTargetDir string) []*core.SkillInfo {
-	skillsDir := filepath.Join(absTargetDir, ".claude", "skills")
+// updateSkillsAndAgentsMD updates the skills section in CLAUDE.md and copies it to AGENTS.md,
+// regenerating AGENTS-COMPACT.md when skills.compact is set. A hand-edited skills section is left alone unless overwriteManaged is set.
+func updateSkillsAndAgentsMD(absTargetDir string, skills *core.SkillCache, overwriteManaged bool) []*core.SkillInfo {
 	claudeMDPath := filepath.Join(absTargetDir, "CLAUDE.md")
 
-	installedSkills, scanErr := core.ScanSkillsDirectory(skillsDir)
+	installedSkills, scanErr := skills.Skills()
 	if scanErr != nil {
 		ui.Warn("Could not scan skills directory: %v", scanErr)
 	}
 	if len(installedSkills) > 0 {
-		if err := core.UpdateCLAUDEMDSkillsSection(claudeMDPath, installedSkills); err != nil {
+		update := core.UpdateCLAUDEMDSkillsSection
+		if overwriteManaged {
+			update = core.OverwriteCLAUDEMDSkillsSection
+		}
+		if err := update(claudeMDPath, installedSkills); errors.Is(err, core.ErrSkillsSectionModified) {
+			ui.Warn("Skills section in CLAUDE.md has manual edits; left unchanged")
+			ui.Info("Move your edits outside the SKILLS_START/END markers, then re-run with --overwrite-managed")
+		} else if err != nil {
 			ui.Warn("Could not update skills section in CLAUDE.md: %v", err)
 		}
 	}
@@ -278,6 +301,9 @@ func updateSkillsAndAgentsMD(absTargetDir string) []*core.SkillInfo {
 			ui.Warn("Could not create AGENTS.md: %v", err)
 		}
 	}
+	if _, err := core.WriteCompactAgentsMD(absTargetDir, installedSkills); err != nil {
+		ui.Warn("Could not create %s: %v", core.CompactAgentsFile, err)
+	}
 
 	return installedSkills
 }
@@ -3,6 +3,7 @@ package commands
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/ar4mirez/samuel/internal/core"
@@ -16,7 +17,12 @@ func newInitCmd() *cobra.Command {
 	cmd.Flags().StringSlice("languages", nil, "Languages")
 	cmd.Flags().StringSlice("frameworks", nil, "Frameworks")
 	cmd.Flags().BoolP("force", "f", false, "Force")
+	cmd.Flags().Bool("overwrite-managed", false, "Overwrite managed")
 	cmd.Flags().Bool("non-interactive", false, "Non-interactive")
+	cmd.Flags().Bool("minimal", false, "Minimal")
+	cmd.Flags().String("overlay", "", "Overlay")
+	cmd.Flags().String("overlay-branch", "", "Overlay branch")
+	cmd.Flags().String("channel", "", "Channel")
 	return cmd
 }
 
@@ -380,7 +386,7 @@ func TestUpdateSkillsAndAgentsMD(t *testing.T) {
 			t.Fatal(err)
 		}
 		// No skills directory — should still copy CLAUDE.md to AGENTS.md
-		updateSkillsAndAgentsMD(dir)
+		updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
 
 		agentsContent, err := os.ReadFile(filepath.Join(dir, "AGENTS.md"))
 		if err != nil {
@@ -421,7 +427,7 @@ Instructions here.
 			t.Fatal(err)
 		}
 
-		skills := updateSkillsAndAgentsMD(dir)
+		skills := updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
 		if len(skills) == 0 {
 			t.Error("expected at least 1 skill to be found")
 		}
@@ -435,7 +441,7 @@ Instructions here.
 	t.Run("without_claude_md", func(t *testing.T) {
 		dir := t.TempDir()
 		// No CLAUDE.md — should not create AGENTS.md
-		skills := updateSkillsAndAgentsMD(dir)
+		skills := updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
 		if len(skills) != 0 {
 			t.Errorf("expected 0 skills, got %d", len(skills))
 		}
@@ -460,10 +466,26 @@ Instructions here.
 		if err := os.WriteFile(filepath.Join(dir, "CLAUDE.md"), []byte("# Test"), 0644); err != nil {
 			t.Fatal(err)
 		}
-		skills := updateSkillsAndAgentsMD(dir)
+		skills := updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
 		if len(skills) != 2 {
 			t.Errorf("expected 2 skills, got %d", len(skills))
 		}
+		if _, err := os.Stat(filepath.Join(dir, core.CompactAgentsFile)); err == nil {
+			t.Errorf("%s should not be created unless skills.compact is set", core.CompactAgentsFile)
+		}
+	})
+
+	t.Run("compact_digest", func(t *testing.T) {
+		dir := t.TempDir()
+		createSkillDir(t, filepath.Join(dir, ".claude", "skills"), "skill-a", validSkillMD("skill-a", "Test skill"))
+		if err := os.WriteFile(filepath.Join(dir, "samuel.yaml"), []byte("version: \"1.0.0\"\nskills:\n  compact: true\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
+		data, err := os.ReadFile(filepath.Join(dir, core.CompactAgentsFile))
+		if err != nil || !strings.Contains(string(data), "**skill-a**: Test skill.") {
+			t.Errorf("%s = %q, %v", core.CompactAgentsFile, data, err)
+		}
 	})
 }
 
@@ -482,7 +504,7 @@ func TestInstallAndSetup_CreateDir(t *testing.T) {
 
 		// installAndSetup will fail at the extractor stage since there's
 		// no cached download, but the directory creation happens first
-		_ = installAndSetup(flags, sel, "1.0.0", filepath.Join(parent, "nonexistent-cache"))
+		_ = installAndSetup(flags, sel, "1.0.0", &core.LayeredTemplate{Path: filepath.Join(parent, "nonexistent-cache")}, projectSkillCache(flags.absTargetDir))
 
 		// The directory should have been created
 		info, err := os.Stat(newDir)
@@ -495,6 +517,21 @@ func TestInstallAndSetup_CreateDir(t *testing.T) {
 	})
 }
 
+func TestInstallAndSetup_PartialFailure(t *testing.T) {
+	dir := t.TempDir()
+	flags := &initFlags{absTargetDir: dir}
+	sel := &initSelections{languages: []string{}, frameworks: []string{}}
+
+	// Every template path is missing, so nothing can be installed.
+	err := installAndSetup(flags, sel, "1.0.0", &core.LayeredTemplate{Path: filepath.Join(dir, "empty-cache")}, projectSkillCache(dir))
+	if err == nil || !strings.Contains(err.Error(), "installation incomplete") {
+		t.Fatalf("installAndSetup() error = %v, want an incomplete-installation error", err)
+	}
+	if !strings.Contains(err.Error(), "not-found") {
+		t.Errorf("error should name the failure kind: %v", err)
+	}
+}
+
 func TestInitSelections_Struct(t *testing.T) {
 	t.Run("zero_value_is_usable", func(t *testing.T) {
 		sel := &initSelections{}
@@ -527,3 +564,37 @@ func TestInitSelections_Struct(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdateSkillsAndAgentsMD_ManagedEdits(t *testing.T) {
+	dir := t.TempDir()
+	skillDir := filepath.Join(dir, ".claude", "skills", "test-skill")
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	skillContent := "---\nname: test-skill\ndescription: A test skill\n---\n# Test Skill\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skillContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	claudeMDPath := filepath.Join(dir, "CLAUDE.md")
+	managed := "# CLAUDE.md\n\n" + core.SkillsStartMarker + "\n" + core.SkillsEndMarker + "\n"
+	if err := os.WriteFile(claudeMDPath, []byte(managed), 0644); err != nil {
+		t.Fatal(err)
+	}
+	updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
+
+	generated, _ := os.ReadFile(claudeMDPath)
+	edited := strings.Replace(string(generated), "A test skill", "My own notes", 1)
+	if err := os.WriteFile(claudeMDPath, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
+	if content, _ := os.ReadFile(claudeMDPath); string(content) != edited {
+		t.Error("manual edits inside the managed block should be preserved without --overwrite-managed")
+	}
+
+	updateSkillsAndAgentsMD(dir, projectSkillCache(dir), true)
+	if content, _ := os.ReadFile(claudeMDPath); strings.Contains(string(content), "My own notes") {
+		t.Error("--overwrite-managed should regenerate the managed block")
+	}
+}
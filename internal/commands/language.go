@@ -0,0 +1,21 @@
+package commands
+
+import (
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// warnUntranslated notes when the template at templatePath has no
+// translation for the configured language, so the core instructions are
+// installed in English.
+func warnUntranslated(templatePath, language string) {
+	if core.HasLanguage(templatePath, language) {
+		return
+	}
+	available := core.AvailableLanguages(templatePath)
+	if len(available) == 0 {
+		ui.Warn("This template ships no translations; core instructions stay in English")
+		return
+	}
+	ui.Warn("No %s translation in this template; core instructions stay in English (available: %v)", language, available)
+}
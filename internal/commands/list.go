@@ -33,18 +33,23 @@ func runList(cmd *cobra.Command, args []string) error {
 	showAvailable, _ := cmd.Flags().GetBool("available")
 	typeFilter, _ := cmd.Flags().GetString("type")
 
+	dir, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
 	if showAvailable {
-		return listAvailable(typeFilter)
+		return listAvailable(dir, typeFilter)
 	}
 
-	return listInstalled(typeFilter)
+	return listInstalled(dir, typeFilter)
 }
 
-func listInstalled(typeFilter string) error {
-	config, err := core.LoadConfig()
+func listInstalled(dir, typeFilter string) error {
+	config, err := core.LoadConfigFrom(dir)
 	if err != nil {
 		if os.IsNotExist(err) {
-			ui.Warn("No Samuel installation found in current directory")
+			ui.Warn("No Samuel installation found in project directory")
 			ui.Info("Run 'samuel init' to initialize or 'samuel list --available' to see available components")
 			return nil
 		}
@@ -114,12 +119,12 @@ func listInstalled(typeFilter string) error {
 	return nil
 }
 
-func listAvailable(typeFilter string) error {
+func listAvailable(dir, typeFilter string) error {
 	ui.Bold("Available Samuel Components")
 	fmt.Println()
 
 	// Check if installed to mark installed items
-	config, configErr := core.LoadConfig()
+	config, configErr := core.LoadConfigFrom(dir)
 	if configErr != nil && !os.IsNotExist(configErr) {
 		ui.Warn("Could not load config: %v", configErr)
 	}
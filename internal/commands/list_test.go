@@ -35,7 +35,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, nil)
 		defer cleanup()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -53,7 +53,7 @@ func TestListInstalled(t *testing.T) {
 		}
 		defer func() { _ = os.Chdir(oldDir) }()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err == nil {
 			t.Error("expected error for corrupt config, got nil")
 		}
@@ -71,7 +71,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -89,7 +89,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -107,7 +107,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -125,7 +125,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -143,7 +143,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -162,7 +162,7 @@ func TestListInstalled(t *testing.T) {
 		defer cleanup()
 
 		// Should not error — unknown names are displayed without descriptions
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -181,7 +181,7 @@ func TestListInstalled(t *testing.T) {
 		defer cleanup()
 
 		// Should only display languages section
-		err := listInstalled("languages")
+		err := listInstalled(".", "languages")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -199,7 +199,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("frameworks")
+		err := listInstalled(".", "frameworks")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -217,7 +217,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("workflows")
+		err := listInstalled(".", "workflows")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -235,7 +235,7 @@ func TestListInstalled(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listInstalled("")
+		err := listInstalled(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -250,7 +250,7 @@ func TestListAvailable(t *testing.T) {
 		defer cleanup()
 
 		// Should not error — missing config is expected
-		err := listAvailable("")
+		err := listAvailable(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -268,7 +268,7 @@ func TestListAvailable(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listAvailable("")
+		err := listAvailable(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -284,7 +284,7 @@ func TestListAvailable(t *testing.T) {
 		_, cleanup := setupListTestDir(t, config)
 		defer cleanup()
 
-		err := listAvailable("")
+		err := listAvailable(".", "")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -294,7 +294,7 @@ func TestListAvailable(t *testing.T) {
 		_, cleanup := setupListTestDir(t, nil)
 		defer cleanup()
 
-		err := listAvailable("languages")
+		err := listAvailable(".", "languages")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -304,7 +304,7 @@ func TestListAvailable(t *testing.T) {
 		_, cleanup := setupListTestDir(t, nil)
 		defer cleanup()
 
-		err := listAvailable("frameworks")
+		err := listAvailable(".", "frameworks")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -314,7 +314,7 @@ func TestListAvailable(t *testing.T) {
 		_, cleanup := setupListTestDir(t, nil)
 		defer cleanup()
 
-		err := listAvailable("workflows")
+		err := listAvailable(".", "workflows")
 		if err != nil {
 			t.Errorf("expected nil error, got: %v", err)
 		}
@@ -333,7 +333,7 @@ func TestListAvailable(t *testing.T) {
 		defer func() { _ = os.Chdir(oldDir) }()
 
 		// Corrupt config should warn but not error — listAvailable is best-effort
-		err := listAvailable("")
+		err := listAvailable(".", "")
 		if err != nil {
 			t.Errorf("expected nil error for corrupt config, got: %v", err)
 		}
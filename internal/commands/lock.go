@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// withProjectLock runs fn while holding the project lock, so commands that
+// modify the project cannot interleave. With --force-unlock any existing
+// lock is removed first.
+func withProjectLock(cmd *cobra.Command, dir string, fn func() error) error {
+	command := "samuel"
+	if cmd != nil {
+		command = cmd.CommandPath()
+		if forceUnlock, _ := cmd.Flags().GetBool("force-unlock"); forceUnlock {
+			holder, err := core.ForceUnlock(dir)
+			if err != nil {
+				return err
+			}
+			if holder != nil {
+				ui.Warn("Removed lock held by PID %d (%s) since %s",
+					holder.PID, holder.Command, holder.Since.Local().Format(time.DateTime))
+			}
+		}
+	}
+
+	lock, err := core.AcquireProjectLock(dir, command)
+	if err != nil {
+		var held *core.LockHeldError
+		if errors.As(err, &held) {
+			ui.Info("Wait for it to finish, or re-run with --force-unlock if it is no longer running")
+		}
+		return err
+	}
+	defer func() {
+		if err := lock.Release(); err != nil {
+			ui.Warn("%v", err)
+		}
+	}()
+
+	return fn()
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func newLockTestCmd(forceUnlock bool) *cobra.Command {
+	cmd := &cobra.Command{Use: "update"}
+	cmd.Flags().Bool("force-unlock", forceUnlock, "Force unlock")
+	return cmd
+}
+
+func writeForeignLock(t *testing.T, dir string) {
+	t.Helper()
+	data, _ := json.Marshal(core.LockInfo{PID: os.Getppid(), Command: "samuel auto start", Hostname: "elsewhere.invalid"})
+	if err := os.WriteFile(core.GetProjectLockPath(dir), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWithProjectLock(t *testing.T) {
+	t.Run("runs_and_releases", func(t *testing.T) {
+		dir := t.TempDir()
+		ran := false
+		err := withProjectLock(nil, dir, func() error {
+			holder, _ := core.ReadProjectLock(dir)
+			ran = holder != nil && holder.PID == os.Getpid()
+			return nil
+		})
+		if err != nil || !ran {
+			t.Fatalf("withProjectLock err = %v, fn ran under lock = %v", err, ran)
+		}
+		if holder, _ := core.ReadProjectLock(dir); holder != nil {
+			t.Errorf("lock should be released, still held by %+v", holder)
+		}
+	})
+
+	t.Run("returns_fn_error", func(t *testing.T) {
+		want := errors.New("boom")
+		if err := withProjectLock(nil, t.TempDir(), func() error { return want }); !errors.Is(err, want) {
+			t.Errorf("withProjectLock err = %v, want %v", err, want)
+		}
+	})
+
+	t.Run("held_lock_blocks", func(t *testing.T) {
+		dir := t.TempDir()
+		writeForeignLock(t, dir)
+
+		err := withProjectLock(newLockTestCmd(false), dir, func() error {
+			t.Error("fn must not run while another process holds the lock")
+			return nil
+		})
+		var held *core.LockHeldError
+		if !errors.As(err, &held) {
+			t.Errorf("expected LockHeldError, got %v", err)
+		}
+	})
+
+	t.Run("force_unlock_takes_over", func(t *testing.T) {
+		dir := t.TempDir()
+		writeForeignLock(t, dir)
+
+		ran := false
+		err := withProjectLock(newLockTestCmd(true), dir, func() error {
+			ran = true
+			return nil
+		})
+		if err != nil || !ran {
+			t.Errorf("withProjectLock with --force-unlock err = %v, ran = %v", err, ran)
+		}
+	})
+}
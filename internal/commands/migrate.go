@@ -0,0 +1,97 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Replace deprecated names in configs and scripts",
+	Long: `Find and replace names deprecated by renames, such as the old aicof
+binary and aicof.yaml config file.
+
+Migrate renames legacy config files and rewrites references in project
+scripts it recognizes: Makefile, justfile, Taskfile.yml, package.json,
+*.sh and scripts/*.sh, .gitlab-ci.yml, .pre-commit-config.yaml, and
+.github/workflows/*.yml. Legacy environment variables and config files
+that conflict with an existing samuel.yaml are listed for manual action.
+
+Deprecated names still work until the release listed in their warning.
+
+Examples:
+  samuel migrate --dry-run    # Show what would change
+  samuel migrate              # Apply the changes`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().Bool("dry-run", false, "Show what would change without modifying files")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	steps, err := core.PlanMigration(cwd)
+	if err != nil {
+		return fmt.Errorf("failed to scan for deprecated names: %w", err)
+	}
+	if len(steps) == 0 {
+		ui.Success("No deprecated names found")
+		return nil
+	}
+
+	printMigrationSteps(steps)
+	if dryRun {
+		fmt.Println()
+		ui.Info("Dry run: no files were changed")
+		return nil
+	}
+
+	if err := withProjectLock(cmd, cwd, func() error {
+		return core.ApplyMigration(cwd, steps)
+	}); err != nil {
+		return err
+	}
+	fmt.Println()
+	ui.Success("Migration complete")
+	return nil
+}
+
+func printMigrationSteps(steps []core.MigrationStep) {
+	ui.Header("Deprecated names")
+	for _, step := range steps {
+		d := step.Deprecation
+		switch step.Action {
+		case core.MigrationRename:
+			ui.ListItem(1, "Rename %s → %s", step.Path, step.Target)
+		case core.MigrationRewrite:
+			ui.ListItem(1, "Replace %s with %s in %s (%s)", d.Old, d.Rewrite, step.Path, formatLines(step.Lines))
+		default:
+			ui.WarnItem(1, "%s: %s", step.Path, step.Reason)
+		}
+		ui.Dim("      %s", d.Warning())
+	}
+}
+
+func formatLines(lines []int) string {
+	parts := make([]string, len(lines))
+	for i, line := range lines {
+		parts[i] = fmt.Sprint(line)
+	}
+	if len(parts) == 1 {
+		return "line " + parts[0]
+	}
+	return "lines " + strings.Join(parts, ", ")
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunMigrate(t *testing.T) {
+	tests := []struct {
+		name        string
+		dryRun      bool
+		wantScript  string
+		wantRenamed bool
+	}{
+		{"dry_run_changes_nothing", true, "aicof update\n", false},
+		{"applies_changes", false, "samuel update\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			os.WriteFile(filepath.Join(dir, "aicof.yaml"), []byte("version: 1.8.0\n"), 0644)
+			os.WriteFile(filepath.Join(dir, "update.sh"), []byte("aicof update\n"), 0755)
+			origDir, _ := os.Getwd()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { os.Chdir(origDir) })
+
+			cmd := &cobra.Command{Use: "migrate"}
+			cmd.Flags().Bool("dry-run", tt.dryRun, "")
+			if err := runMigrate(cmd, nil); err != nil {
+				t.Fatalf("runMigrate returned error: %v", err)
+			}
+
+			data, _ := os.ReadFile(filepath.Join(dir, "update.sh"))
+			if string(data) != tt.wantScript {
+				t.Errorf("update.sh = %q, want %q", data, tt.wantScript)
+			}
+			_, err := os.Stat(filepath.Join(dir, "samuel.yaml"))
+			if renamed := err == nil; renamed != tt.wantRenamed {
+				t.Errorf("samuel.yaml exists = %v, want %v", renamed, tt.wantRenamed)
+			}
+		})
+	}
+}
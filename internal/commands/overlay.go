@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// parseOverlayFlags reads --overlay and --overlay-branch. It returns nil
+// when no overlay was requested.
+func parseOverlayFlags(cmd *cobra.Command) (*core.OverlayConfig, error) {
+	registry, _ := cmd.Flags().GetString("overlay")
+	if registry == "" {
+		return nil, nil
+	}
+	branch, _ := cmd.Flags().GetString("overlay-branch")
+	overlay := &core.OverlayConfig{Registry: registry, Branch: branch}
+	if err := core.ValidateOverlay(overlay); err != nil {
+		return nil, fmt.Errorf("invalid --overlay: %w", err)
+	}
+	return overlay, nil
+}
+
+// reportOverlay lists the files an overlay replaced or added so it is
+// clear which layer each installed file comes from.
+func reportOverlay(tmpl *core.LayeredTemplate) {
+	files := tmpl.OverlayFiles()
+	if len(files) == 0 {
+		return
+	}
+	overrides := tmpl.Overrides()
+	ui.Info("Overlay supplies %d files (%d override the base template)", len(files), len(overrides))
+	for _, f := range overrides {
+		ui.ListItem(1, "%s (overrides base)", f)
+	}
+}
+
+// layerTag labels a template file with the layer that supplied it.
+func layerTag(tmpl *core.LayeredTemplate, rel string) string {
+	return fmt.Sprintf("[%s]", tmpl.LayerOf(rel))
+}
+
+// withLayer appends the layer tag to a file name when an overlay is in use,
+// leaving single-layer output unchanged.
+func withLayer(tmpl *core.LayeredTemplate, rel string) string {
+	if len(tmpl.OverlayFiles()) == 0 {
+		return rel
+	}
+	return rel + " " + layerTag(tmpl, rel)
+}
@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// policyOverrideFlag lets init, add and update proceed despite violations
+// of the organization policy, recording the reason given.
+const policyOverrideFlag = "policy-override"
+
+func addPolicyOverrideFlag(cmd *cobra.Command) {
+	cmd.Flags().String(policyOverrideFlag, "", "Proceed despite organization policy violations, recording this reason in "+core.PolicyAuditFile)
+}
+
+// loadOrgPolicy reads the policy shipped by the overlay of tmpl; nil when
+// there is no overlay or it ships none.
+func loadOrgPolicy(tmpl *core.LayeredTemplate, overlay *core.OverlayConfig) (*core.OrgPolicy, error) {
+	if overlay == nil || overlay.Registry == "" {
+		return nil, nil
+	}
+	policy, err := core.LoadOrgPolicy(tmpl, overlay.Registry)
+	if err == nil && policy != nil {
+		ui.Info("Enforcing the organization policy of %s", overlay.Registry)
+	}
+	return policy, err
+}
+
+// installPolicy is the organization policy as enforced on one install.
+// The zero value installs everything.
+type installPolicy struct {
+	policy *core.OrgPolicy
+	// allowed lists the banned skills installed by a policy override.
+	allowed []string
+}
+
+// filter leaves the files of banned skills out of the install paths.
+func (p installPolicy) filter(paths []string) []string {
+	kept, dropped := p.policy.DropBannedSkills(paths, p.allowed)
+	for _, skill := range dropped {
+		ui.Info("Skipped skill %s, banned by the organization policy", skill)
+	}
+	return kept
+}
+
+// checkInitPolicy enforces the organization policy on the components
+// selected for init. Init installs every workflow, so only banned
+// languages and frameworks and, without --force, local changes to
+// protected files that would be kept are violations.
+func checkInitPolicy(cmd *cobra.Command, flags *initFlags, sel *initSelections, tmpl *core.LayeredTemplate) error {
+	policy, err := loadOrgPolicy(tmpl, flags.overlay)
+	if err != nil || policy == nil {
+		return err
+	}
+	var components []*core.Component
+	for _, name := range sel.languages {
+		components = append(components, core.FindLanguage(name))
+	}
+	for _, name := range sel.frameworks {
+		components = append(components, core.FindFramework(name))
+	}
+	skills := componentSkills(components)
+	violations := policy.CheckSkills(skills)
+	if !flags.force {
+		paths := core.MergeOverlayPaths(initComponentPaths(sel), tmpl)
+		violations = append(violations, policy.CheckProtectedFiles(tmpl.Path, flags.absTargetDir, paths)...)
+	}
+	overridden, err := enforceOrgPolicy(cmd, flags.absTargetDir, policy, violations)
+	sel.policy = installPolicy{policy: policy}
+	if overridden {
+		sel.policy.allowed = skills
+	}
+	return err
+}
+
+// checkAddPolicy enforces the organization policy on adding component.
+func checkAddPolicy(cmd *cobra.Command, dir string, config *core.Config, component *core.Component, tmpl *core.LayeredTemplate) error {
+	policy, err := loadOrgPolicy(tmpl, config.Overlay)
+	if err != nil || policy == nil {
+		return err
+	}
+	workflows := config.Installed.Workflows
+	if wf := core.FindWorkflow(component.Name); wf != nil && wf.Path == component.Path {
+		workflows = append(slices.Clone(workflows), component.Name)
+	}
+	violations := policy.CheckSkills(componentSkills([]*core.Component{component}))
+	violations = append(violations, policy.CheckWorkflows(workflows)...)
+	violations = append(violations, checkDoctorScore(policy, dir)...)
+	_, err = enforceOrgPolicy(cmd, dir, policy, violations)
+	return err
+}
+
+// checkUpdatePolicy enforces the organization policy on updating the
+// installed components: banned components installed by name, missing
+// required workflows, kept local changes to protected files and the
+// doctor score are violations.
+func checkUpdatePolicy(cmd *cobra.Command, dir string, config *core.Config, tmpl *core.LayeredTemplate, force bool) (installPolicy, error) {
+	policy, err := loadOrgPolicy(tmpl, config.Overlay)
+	if err != nil || policy == nil {
+		return installPolicy{}, err
+	}
+	var components []*core.Component
+	for _, name := range config.Installed.Languages {
+		components = append(components, core.FindLanguage(name))
+	}
+	for _, name := range config.Installed.Frameworks {
+		components = append(components, core.FindFramework(name))
+	}
+	for _, name := range config.Installed.Workflows {
+		components = append(components, core.FindWorkflow(name))
+	}
+	skills := componentSkills(components)
+	violations := policy.CheckSkills(skills)
+	violations = append(violations, policy.CheckWorkflows(config.Installed.Workflows)...)
+	if !force {
+		violations = append(violations, policy.CheckProtectedFiles(tmpl.Path, dir, updatePaths(config, tmpl))...)
+	}
+	violations = append(violations, checkDoctorScore(policy, dir)...)
+	overridden, err := enforceOrgPolicy(cmd, dir, policy, violations)
+	if err != nil || !overridden {
+		return installPolicy{policy: policy}, err
+	}
+	return installPolicy{policy: policy, allowed: skills}, nil
+}
+
+// enforceOrgPolicy stops the command when it violates the policy, unless
+// --policy-override gives a reason; the override is then recorded with the
+// violations in the project's audit trail. It reports whether violations
+// were overridden.
+func enforceOrgPolicy(cmd *cobra.Command, dir string, policy *core.OrgPolicy, violations []core.PolicyViolation) (bool, error) {
+	if len(violations) == 0 {
+		return false, nil
+	}
+	reason, _ := cmd.Flags().GetString(policyOverrideFlag)
+	if reason == "" {
+		ui.Error("This violates the organization policy of %s:", policy.Registry)
+		for _, v := range violations {
+			ui.ErrorItem(1, "%s: %s", v.Rule, v.Message)
+		}
+		return false, fmt.Errorf("blocked by organization policy; fix the violations above, or rerun with --%s \"<reason>\" to proceed (recorded in %s)",
+			policyOverrideFlag, core.PolicyAuditFile)
+	}
+
+	ui.Warn("Overriding %d organization policy violation(s): %s", len(violations), reason)
+	for _, v := range violations {
+		ui.WarnItem(1, "%s: %s", v.Rule, v.Message)
+	}
+	err := core.RecordPolicyOverride(dir, core.PolicyOverride{
+		Command:    cmd.CommandPath(),
+		User:       policyUser(dir),
+		Registry:   policy.Registry,
+		Reason:     reason,
+		Violations: violations,
+	})
+	if err != nil {
+		return false, err
+	}
+	ui.Info("Recorded the override in %s", core.PolicyAuditFile)
+	return true, nil
+}
+
+// policyUser identifies who overrode the policy: the git identity of the
+// project, else the local user and host.
+func policyUser(dir string) string {
+	state := core.InspectGitState(dir)
+	if state.UserEmail != "" {
+		return state.UserEmail
+	}
+	return core.SyncWriterID()
+}
+
+// componentSkills returns the skill directory names of the components.
+func componentSkills(components []*core.Component) []string {
+	var skills []string
+	for _, c := range components {
+		if c != nil {
+			skills = append(skills, filepath.Base(c.Path))
+		}
+	}
+	return skills
+}
+
+// checkDoctorScore runs the doctor checks when the policy sets a minimum
+// score.
+func checkDoctorScore(policy *core.OrgPolicy, dir string) []core.PolicyViolation {
+	if policy.MinDoctorScore == 0 {
+		return nil
+	}
+	return policy.CheckDoctorScore(doctorScore(dir))
+}
+
+// doctorScore runs every doctor check on the project and returns the
+// share that passed, in percent.
+func doctorScore(dir string) int {
+	configResult, config := checkConfigFile(dir)
+	results := []checkResult{configResult}
+	for _, o := range runDoctorChecks(doctorChecks, newDoctorEnv(dir, config), defaultDoctorBudget) {
+		results = append(results, o.results...)
+	}
+	passed := 0
+	for _, r := range results {
+		if r.passed {
+			passed++
+		}
+	}
+	return passed * 100 / len(results)
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func policyTestCmd(t *testing.T, reason string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "add"}
+	addPolicyOverrideFlag(cmd)
+	if reason != "" {
+		if err := cmd.Flags().Set(policyOverrideFlag, reason); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return cmd
+}
+
+func TestEnforceOrgPolicy(t *testing.T) {
+	policy := &core.OrgPolicy{BannedSkills: []string{"react"}, Registry: "https://github.com/acme/overlay"}
+	violations := policy.CheckSkills([]string{"react"})
+
+	t.Run("no_violations", func(t *testing.T) {
+		dir := t.TempDir()
+		overridden, err := enforceOrgPolicy(policyTestCmd(t, ""), dir, policy, nil)
+		if overridden || err != nil {
+			t.Errorf("enforceOrgPolicy() = %v, %v", overridden, err)
+		}
+	})
+
+	t.Run("blocked", func(t *testing.T) {
+		dir := t.TempDir()
+		_, err := enforceOrgPolicy(policyTestCmd(t, ""), dir, policy, violations)
+		if err == nil || !strings.Contains(err.Error(), "--policy-override") {
+			t.Fatalf("error = %v, want a hint at --policy-override", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, core.PolicyAuditFile)); !os.IsNotExist(err) {
+			t.Error("a blocked command should not write the audit file")
+		}
+	})
+
+	t.Run("overridden", func(t *testing.T) {
+		dir := t.TempDir()
+		overridden, err := enforceOrgPolicy(policyTestCmd(t, "legacy frontend"), dir, policy, violations)
+		if !overridden || err != nil {
+			t.Fatalf("enforceOrgPolicy() = %v, %v", overridden, err)
+		}
+		data, err := os.ReadFile(filepath.Join(dir, core.PolicyAuditFile))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{`"reason":"legacy frontend"`, `"command":"add"`, "skill 'react' is banned"} {
+			if !strings.Contains(string(data), want) {
+				t.Errorf("audit record missing %s: %s", want, data)
+			}
+		}
+	})
+}
+
+func TestInstallPolicyFilter(t *testing.T) {
+	paths := []string{"CLAUDE.md", ".claude/skills/react", ".claude/skills/go-guide"}
+	if got := (installPolicy{}).filter(paths); !slices.Equal(got, paths) {
+		t.Errorf("zero policy filtered %v", got)
+	}
+	p := installPolicy{policy: &core.OrgPolicy{BannedSkills: []string{"react"}}}
+	if got := p.filter(paths); slices.Contains(got, ".claude/skills/react") {
+		t.Errorf("banned skill kept: %v", got)
+	}
+	p.allowed = []string{"react"}
+	if got := p.filter(paths); !slices.Equal(got, paths) {
+		t.Errorf("overridden skill dropped: %v", got)
+	}
+}
+
+func TestDoctorScore(t *testing.T) {
+	if score := doctorScore(t.TempDir()); score < 0 || score >= 100 {
+		t.Errorf("doctorScore() of an empty directory = %d", score)
+	}
+}
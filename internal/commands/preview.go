@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var previewCmd = &cobra.Command{
+	Use:   "preview [type] <name>",
+	Short: "Read a component's SKILL.md without installing it",
+	Long: `Show a language guide, framework guide, workflow or skill as the template
+ships it: a summary of its frontmatter, the reference files that come with it,
+and its SKILL.md, formatted for the terminal (headings, lists, code blocks,
+tables), in your pager ($PAGER, else less). --raw keeps the SKILL.md
+markdown as written, for piping.
+
+The content is read from the downloaded release archive, the same one 'add'
+installs from: the project's version and overlay inside a Samuel project, the
+latest release elsewhere. Nothing in the project is changed.
+
+Without a type, the name is looked up among languages, frameworks, workflows
+and skills, in that order.
+
+Examples:
+  samuel preview rust                  # Rust language guide
+  samuel preview framework django
+  samuel preview skill commit-message
+  samuel preview go --raw --no-pager | grep -n Guardrails`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runPreview,
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+	previewCmd.Flags().Bool("no-pager", false, "Print to stdout instead of the pager")
+	previewCmd.Flags().Bool("raw", false, "Show SKILL.md as markdown instead of formatting it")
+}
+
+func runPreview(cmd *cobra.Command, args []string) error {
+	component, err := resolvePreviewComponent(args)
+	if err != nil {
+		return err
+	}
+	tmpl, version, err := previewTemplate(cmd)
+	if err != nil {
+		return err
+	}
+	preview, err := core.LoadComponentPreview(tmpl.Path, component)
+	if err != nil {
+		return err
+	}
+
+	raw, _ := cmd.Flags().GetBool("raw")
+	content := fmt.Sprintf("%s (samuel %s)\n\n", component.Name, version) + renderPreview(preview, raw)
+	if noPager, _ := cmd.Flags().GetBool("no-pager"); noPager {
+		_, err = fmt.Fprint(cmd.OutOrStdout(), content)
+		return err
+	}
+	return ui.Page(cmd.OutOrStdout(), content)
+}
+
+// resolvePreviewComponent finds the component named by "<name>" or
+// "<type> <name>".
+func resolvePreviewComponent(args []string) (*core.Component, error) {
+	name := strings.ToLower(args[len(args)-1])
+	if len(args) == 2 {
+		componentType := normalizeTypeFilter(args[0])
+		if componentType == "" {
+			return nil, fmt.Errorf("unknown component type: %s\nValid types: language, framework, workflow, skill", args[0])
+		}
+		component := findComponent(componentType, name)
+		if componentType == "skill" {
+			component = core.FindSkill(name)
+		}
+		if component == nil {
+			return nil, fmt.Errorf("unknown %s: %s\nRun 'samuel search %s' to find available components", componentType, name, name)
+		}
+		return component, nil
+	}
+	for _, find := range []func(string) *core.Component{core.FindLanguage, core.FindFramework, core.FindWorkflow, core.FindSkill} {
+		if component := find(name); component != nil {
+			return component, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown component: %s\nRun 'samuel search %s' to find available components", name, name)
+}
+
+// previewTemplate resolves the template a preview reads from: the
+// project's version and overlay, or the latest release outside a project.
+func previewTemplate(cmd *cobra.Command) (*core.LayeredTemplate, string, error) {
+	config, _, err := loadProjectConfig(cmd)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, "", fmt.Errorf("failed to load config: %w", err)
+	}
+	downloader, err := core.NewDownloader()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to initialize: %w", err)
+	}
+
+	version, overlay := "", (*core.OverlayConfig)(nil)
+	if config != nil {
+		version, overlay = config.Version, config.Overlay
+		if _, err := confirmOverlayTrust(config.Trust, overlay); err != nil {
+			return nil, "", err
+		}
+	} else if version, err = downloader.GetLatestVersion(); err != nil {
+		return nil, "", fmt.Errorf("failed to get latest version: %w", err)
+	}
+
+	spinner := ui.NewSpinner(fmt.Sprintf("Fetching samuel %s...", version))
+	spinner.Start()
+	tmpl, err := downloader.ResolveTemplate(version, overlay)
+	if err != nil {
+		spinner.Error("Download failed")
+		return nil, "", fmt.Errorf("failed to download: %w", err)
+	}
+	spinner.Stop()
+	return tmpl, version, nil
+}
+
+// renderPreview formats a preview: the frontmatter, the files shipped with
+// the component, then the SKILL.md body, rendered unless raw.
+func renderPreview(p *core.ComponentPreview, raw bool) string {
+	var sb strings.Builder
+	field := func(label, value string) {
+		if value != "" {
+			fmt.Fprintf(&sb, "  %-16s %s\n", label+":", value)
+		}
+	}
+	field("Name", p.Metadata.Name)
+	field("Description", p.Metadata.Description)
+	field("License", p.Metadata.License)
+	field("Compatibility", p.Metadata.Compatibility)
+	field("Allowed tools", p.Metadata.AllowedTools)
+	keys := make([]string, 0, len(p.Metadata.Metadata))
+	for key := range p.Metadata.Metadata {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		field(key, p.Metadata.Metadata[key])
+	}
+	field("Install path", p.Component.Path)
+
+	if len(p.Files) > 0 {
+		fmt.Fprintf(&sb, "\nReferences (%d):\n", len(p.Files))
+		for _, f := range p.Files {
+			fmt.Fprintf(&sb, "  %s (%s)\n", f.Path, formatFileSize(f.Size))
+		}
+	}
+	sb.WriteString("\n" + strings.Repeat("-", 72) + "\n\n")
+	if raw {
+		sb.WriteString(strings.TrimRight(p.Body, "\n") + "\n")
+	} else {
+		sb.WriteString(ui.RenderMarkdown(p.Body))
+	}
+	return sb.String()
+}
@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+func TestResolvePreviewComponent(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantPath string
+		wantErr  string
+	}{
+		{"language by name", []string{"go"}, ".claude/skills/go-guide", ""},
+		{"framework by name", []string{"Django"}, ".claude/skills/django", ""},
+		{"skill by name", []string{"commit-message"}, ".claude/skills/commit-message", ""},
+		{"typed", []string{"fw", "react"}, ".claude/skills/react", ""},
+		{"typed skill", []string{"skill", "go-guide"}, ".claude/skills/go-guide", ""},
+		{"wrong type", []string{"language", "react"}, "", "unknown language"},
+		{"bad type", []string{"plugin", "react"}, "", "unknown component type"},
+		{"unknown", []string{"cobol"}, "", "unknown component"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component, err := resolvePreviewComponent(tt.args)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if component.Path != tt.wantPath {
+				t.Errorf("path = %q, want %q", component.Path, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestRenderPreview(t *testing.T) {
+	preview := &core.ComponentPreview{
+		Component: core.FindLanguage("go"),
+		Metadata: core.SkillMetadata{
+			Name: "go-guide", Description: "Go guardrails.",
+			Metadata: map[string]string{"version": "1.2", "author": "samuel"},
+		},
+		Body:  "# Go Guide\n\nUse gofmt.\n",
+		Files: []core.PreviewFile{{Path: "references/testing.md", Size: 2048}},
+	}
+
+	got := renderPreview(preview, true)
+	for _, want := range []string{
+		"Name:            go-guide", "Description:     Go guardrails.",
+		"Install path:    .claude/skills/go-guide",
+		"References (1):\n  references/testing.md (2.0 KB)", "# Go Guide\n\nUse gofmt.\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("render missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "License:") {
+		t.Error("empty fields should be left out")
+	}
+	if strings.Index(got, "author:") > strings.Index(got, "version:") {
+		t.Error("metadata keys should be sorted")
+	}
+
+	ui.DisableColors()
+	if got := renderPreview(preview, false); !strings.HasSuffix(got, "Go Guide\n════════\n\nUse gofmt.\n") {
+		t.Errorf("body should be rendered:\n%s", got)
+	}
+}
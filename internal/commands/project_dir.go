@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// projectDir returns the absolute project directory a command operates
+// on: the global --target flag when set, else the working directory.
+// Handlers take every path from it instead of relying on the process
+// working directory. A nil cmd, as some tests pass, means no flags.
+func projectDir(cmd *cobra.Command) (string, error) {
+	var target string
+	if cmd != nil {
+		target, _ = cmd.Flags().GetString("target")
+	}
+	if target == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return "", fmt.Errorf("failed to get working directory: %w", err)
+		}
+		return cwd, nil
+	}
+
+	dir, err := filepath.Abs(target)
+	if err != nil {
+		return "", fmt.Errorf("invalid --target %q: %w", target, err)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("--target %s is not a directory", target)
+	}
+	return dir, nil
+}
+
+// projectSkillCache returns an empty skills cache for the project in dir.
+// Handlers create one per run and pass it to every helper that reads skills.
+func projectSkillCache(dir string) *core.SkillCache {
+	return core.NewSkillCache(filepath.Join(dir, ".claude", "skills"))
+}
+
+// loadProjectConfig loads samuel.yaml from the command's project directory.
+// Errors are returned unwrapped so callers can still test os.IsNotExist.
+func loadProjectConfig(cmd *cobra.Command) (*core.Config, string, error) {
+	dir, err := projectDir(cmd)
+	if err != nil {
+		return nil, "", err
+	}
+	config, err := core.LoadConfigFrom(dir)
+	return config, dir, err
+}
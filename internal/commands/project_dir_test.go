@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestProjectDir(t *testing.T) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := t.TempDir()
+	file := filepath.Join(target, "samuel.yaml")
+	if err := os.WriteFile(file, []byte("version: 1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		target  string
+		want    string
+		wantErr bool
+	}{
+		{name: "defaults to working directory", want: cwd},
+		{name: "explicit directory", target: target, want: target},
+		{name: "missing directory", target: filepath.Join(target, "missing"), wantErr: true},
+		{name: "file is not a directory", target: file, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.Flags().String("target", tt.target, "")
+			got, err := projectDir(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("projectDir() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("projectDir() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
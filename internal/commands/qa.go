@@ -0,0 +1,238 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var qaCmd = &cobra.Command{
+	Use:   "qa",
+	Short: "Check templates against example projects before a release",
+	Long: `Quality checks for the Samuel templates. These are maintainer commands
+for catching template regressions before a release.`,
+}
+
+var qaRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Initialize example projects with every template and check them",
+	Long: `Initialize every fixture project under --projects with every template
+and check the result, printing a pass/fail matrix of projects by templates.
+
+Each subdirectory of --projects is one fixture project. For every template,
+a scratch copy of the fixture goes through these steps, each run by this
+samuel binary as a user would run it:
+
+  init     samuel init --non-interactive (minimal: --minimal, which works offline)
+  doctor   samuel doctor
+  skills   samuel skill validate
+  auto     samuel auto init, then samuel auto start --dry-run
+
+A failed step skips the steps after it, and the tail of its output is
+shown below the matrix. --parallel runs several cases at once: on a
+terminal each case's progress lines are prefixed with its name, and
+otherwise each case's lines are printed together when it finishes. The command exits with code 5 when any case
+fails. --keep leaves the scratch copies in place for inspection.
+
+Examples:
+  samuel qa run --projects examples/
+  samuel qa run --projects examples/ --templates minimal,starter
+  samuel qa run --projects examples/ --parallel 4
+  samuel qa run --projects examples/ --json --output qa.json`,
+	Args: cobra.NoArgs,
+	RunE: runQA,
+}
+
+func init() {
+	rootCmd.AddCommand(qaCmd)
+	qaCmd.AddCommand(qaRunCmd)
+	addQARunFlags(qaRunCmd)
+}
+
+func addQARunFlags(cmd *cobra.Command) {
+	cmd.Flags().String("projects", "examples", "Directory whose subdirectories are the fixture projects")
+	cmd.Flags().StringSlice("templates", core.GetAllTemplateNames(), "Templates to initialize each project with")
+	cmd.Flags().Duration("timeout", core.DefaultQAStepTimeout, "Time limit for each command")
+	cmd.Flags().Bool("keep", false, "Keep the initialized projects for inspection")
+	cmd.Flags().Int("parallel", 1, "Number of cases to run at once")
+	cmd.Flags().Bool("json", false, "Print the results as JSON")
+	cmd.Flags().StringP("output", "o", "", "Save the results as JSON to this file")
+}
+
+func runQA(cmd *cobra.Command, args []string) error {
+	cfg, err := qaRunConfig(cmd)
+	if err != nil {
+		return err
+	}
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if !asJSON {
+		ui.Info("Checking %d project(s) with %d template(s)", len(cfg.Projects), len(cfg.Templates))
+		reportQAProgress(&cfg, ui.NewParallelOutput())
+	}
+
+	report, err := core.RunQA(cfg)
+	if err != nil {
+		return err
+	}
+	report.Version = Version
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	if output, _ := cmd.Flags().GetString("output"); output != "" {
+		if err := os.WriteFile(output, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("failed to save results: %w", err)
+		}
+		if !asJSON {
+			ui.Success("Saved results to %s", output)
+		}
+	}
+	if asJSON {
+		fmt.Fprintln(cmd.OutOrStdout(), string(data))
+	} else {
+		printQAReport(report)
+	}
+	if failed := report.Failed(); failed > 0 {
+		return withExitCode(exitAssertionFailed, fmt.Errorf("%d of %d case(s) failed", failed, len(report.Cases)))
+	}
+	return nil
+}
+
+// qaRunConfig reads the qa run flags and finds the fixture projects.
+func qaRunConfig(cmd *cobra.Command) (core.QAConfig, error) {
+	var cfg core.QAConfig
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return cfg, err
+	}
+	dir, _ := cmd.Flags().GetString("projects")
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cwd, dir)
+	}
+	if cfg.Projects, err = core.DiscoverQAProjects(dir); err != nil {
+		return cfg, err
+	}
+	cfg.Templates, _ = cmd.Flags().GetStringSlice("templates")
+	cfg.StepTimeout, _ = cmd.Flags().GetDuration("timeout")
+	cfg.Keep, _ = cmd.Flags().GetBool("keep")
+	cfg.Parallel, _ = cmd.Flags().GetInt("parallel")
+	if cfg.Binary, err = os.Executable(); err != nil {
+		return cfg, fmt.Errorf("failed to locate the samuel binary: %w", err)
+	}
+	return cfg, cfg.Validate()
+}
+
+// reportQAProgress prints each case's steps as they finish through out,
+// one worker per case, so cases running in parallel do not interleave.
+func reportQAProgress(cfg *core.QAConfig, out *ui.ParallelOutput) {
+	workers := make(map[string]*ui.Worker)
+	for _, project := range cfg.Projects {
+		for _, tmpl := range cfg.Templates {
+			name := qaCaseName(filepath.Base(project), tmpl)
+			workers[name] = out.Worker(name)
+		}
+	}
+	cfg.StepProgress = func(project, tmpl string, step core.QAStepResult) {
+		w := workers[qaCaseName(project, tmpl)]
+		switch step.Status {
+		case core.QAPass:
+			w.Success("%s (%s)", step.Step, formatCheckDuration(step.Duration))
+		case core.QAFail:
+			w.Error("%s failed", step.Step)
+		default:
+			w.Printf("%s skipped", step.Step)
+		}
+	}
+	cfg.Progress = func(c core.QACaseResult) {
+		w := workers[qaCaseName(c.Project, c.Template)]
+		if c.Error != "" {
+			w.Error("%s", c.Error)
+		}
+		w.Done()
+	}
+}
+
+// qaCaseName names a case in progress output and failure sections.
+func qaCaseName(project, tmpl string) string {
+	return fmt.Sprintf("%s / %s", project, tmpl)
+}
+
+// printQAReport prints the matrix and the output of every failed step.
+func printQAReport(report *core.QAReport) {
+	ui.Section("Template QA")
+	fmt.Println()
+	ui.Print("%s", formatQAMatrix(report))
+	for _, c := range report.Cases {
+		if c.Passed() {
+			continue
+		}
+		ui.Section(qaCaseName(c.Project, c.Template))
+		if c.Dir != "" {
+			ui.TableRow("Project", c.Dir)
+		}
+		if c.Error != "" {
+			ui.ErrorItem(1, "%s", c.Error)
+		}
+		for _, s := range c.Steps {
+			if s.Status == core.QAFail {
+				ui.Dim("%s", indentLines(s.Output, "  "))
+			}
+		}
+	}
+}
+
+// formatQAMatrix lays out the cases with a row per project and a column
+// per template.
+func formatQAMatrix(report *core.QAReport) string {
+	width := len("Project")
+	for _, p := range report.Projects {
+		width = max(width, len(p))
+	}
+	row := func(first string, cell func(template string) string) string {
+		line := fmt.Sprintf("  %-*s", width, first)
+		for _, t := range report.Templates {
+			line += fmt.Sprintf("  %-14s", cell(t))
+		}
+		return strings.TrimRight(line, " ")
+	}
+	rows := []string{row("Project", func(t string) string { return t })}
+	for _, p := range report.Projects {
+		rows = append(rows, row(p, func(t string) string { return qaCell(report.Case(p, t)) }))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// qaCell summarizes a case: "pass", or "fail" with the failed step.
+func qaCell(c *core.QACaseResult) string {
+	switch {
+	case c == nil:
+		return "-"
+	case c.Passed():
+		return core.QAPass
+	case c.Error != "":
+		return "fail: setup"
+	}
+	for _, s := range c.Steps {
+		if s.Status == core.QAFail {
+			return "fail: " + s.Step
+		}
+	}
+	return core.QAFail
+}
+
+// indentLines prefixes every non-empty line of s with indent.
+func indentLines(s, indent string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
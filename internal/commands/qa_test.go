@@ -0,0 +1,98 @@
+package commands
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+func TestFormatQAMatrix(t *testing.T) {
+	report := &core.QAReport{
+		Projects:  []string{"go-service", "web"},
+		Templates: []string{"minimal", "starter"},
+		Cases: []core.QACaseResult{
+			{Project: "go-service", Template: "minimal", Steps: []core.QAStepResult{{Step: core.QAStepInit, Status: core.QAPass}}},
+			{Project: "go-service", Template: "starter", Steps: []core.QAStepResult{
+				{Step: core.QAStepInit, Status: core.QAPass},
+				{Step: core.QAStepDoctor, Status: core.QAFail},
+				{Step: core.QAStepSkills, Status: core.QASkip},
+			}},
+			{Project: "web", Template: "minimal", Error: "failed to copy fixture"},
+		},
+	}
+	want := "  Project     minimal         starter\n" +
+		"  go-service  pass            fail: doctor\n" +
+		"  web         fail: setup     -"
+	if got := formatQAMatrix(report); got != want {
+		t.Errorf("formatQAMatrix() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestQARunConfig(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "examples", "api"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := newQARunTestCmd(t, dir)
+	cfg, err := qaRunConfig(cmd)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Projects) != 1 || len(cfg.Templates) != len(core.Templates) || cfg.Binary == "" {
+		t.Errorf("config = %+v", cfg)
+	}
+
+	cmd = newQARunTestCmd(t, dir)
+	_ = cmd.Flags().Set("templates", "minimal,huge")
+	if _, err := qaRunConfig(cmd); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+	cmd = newQARunTestCmd(t, dir)
+	_ = cmd.Flags().Set("projects", "fixtures")
+	if _, err := qaRunConfig(cmd); err == nil {
+		t.Error("expected an error for a missing projects directory")
+	}
+}
+
+func newQARunTestCmd(t *testing.T, dir string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "run"}
+	cmd.Flags().String("target", dir, "")
+	addQARunFlags(cmd)
+	return cmd
+}
+
+func TestReportQAProgress(t *testing.T) {
+	cfg := core.QAConfig{Projects: []string{"/x/api", "/x/web"}, Templates: []string{"minimal"}}
+	var buf bytes.Buffer
+	reportQAProgress(&cfg, ui.NewParallelOutputTo(&buf, false))
+
+	var wg sync.WaitGroup
+	for _, project := range []string{"api", "web"} {
+		wg.Add(1)
+		go func(project string) {
+			defer wg.Done()
+			cfg.StepProgress(project, "minimal", core.QAStepResult{Step: core.QAStepInit, Status: core.QAPass})
+			cfg.StepProgress(project, "minimal", core.QAStepResult{Step: core.QAStepDoctor, Status: core.QAFail})
+			cfg.StepProgress(project, "minimal", core.QAStepResult{Step: core.QAStepSkills, Status: core.QASkip})
+			cfg.Progress(core.QACaseResult{Project: project, Template: "minimal"})
+		}(project)
+	}
+	wg.Wait()
+
+	for _, project := range []string{"api", "web"} {
+		want := fmt.Sprintf("\n%s / minimal:\n  %s init (0s)\n  %s doctor failed\n  skills skipped\n", project, ui.SuccessSymbol, ui.ErrorSymbol)
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output lacks the block of %s:\n%s", project, buf.String())
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var registryCmd = &cobra.Command{
+	Use:   "registry",
+	Short: "Inspect the component registry and trust third-party registries",
+}
+
+var registryDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print the effective registry with each component's source",
+	Long: `Print every component the CLI can install: the built-in registry plus
+what the overlay in samuel.yaml adds or overrides.
+
+Each entry reports its source layer ("base" or "overlay"), the registry and
+version or branch its files are fetched from, and any files the overlay
+supplies under its path. Use it to find out why 'samuel add' installed a
+particular path or version.
+
+When an overlay is configured its template is resolved first, which may
+download it. Without samuel.yaml the built-in registry is printed at the
+latest release.
+
+Examples:
+  samuel registry dump                     # JSON
+  samuel registry dump --format yaml
+  samuel registry dump | jq '.components[] | select(.source == "overlay")'`,
+	Args: cobra.NoArgs,
+	RunE: runRegistryDump,
+}
+
+func init() {
+	rootCmd.AddCommand(registryCmd)
+	registryCmd.AddCommand(registryDumpCmd)
+	registryDumpCmd.Flags().String("format", core.DumpFormatJSON, "Output format: json, yaml")
+}
+
+func runRegistryDump(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	if !slices.Contains(core.GetSupportedDumpFormats(), format) {
+		return fmt.Errorf("unsupported format: %s (supported: %v)", format, core.GetSupportedDumpFormats())
+	}
+
+	config, _, err := loadProjectConfig(cmd)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	var registry, version string
+	var overlay *core.OverlayConfig
+	if config != nil {
+		registry, version, overlay = config.Registry, config.Version, config.Overlay
+	}
+
+	var tmpl *core.LayeredTemplate
+	if overlay != nil {
+		downloader, err := core.NewDownloader()
+		if err != nil {
+			return fmt.Errorf("failed to initialize: %w", err)
+		}
+		if tmpl, err = downloader.ResolveTemplate(version, overlay); err != nil {
+			return fmt.Errorf("failed to resolve overlay %s: %w", overlay.Registry, err)
+		}
+	}
+
+	dump := core.DumpRegistry(registry, version, overlay, tmpl)
+	return writeRegistryDump(cmd, dump, format)
+}
+
+func writeRegistryDump(cmd *cobra.Command, dump *core.RegistryDump, format string) error {
+	var data []byte
+	var err error
+	if format == core.DumpFormatYAML {
+		data, err = yaml.Marshal(dump)
+	} else {
+		data, err = json.MarshalIndent(dump, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode registry: %w", err)
+	}
+	_, err = cmd.OutOrStdout().Write(data)
+	return err
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRunRegistryDump(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  string
+		wantErr bool
+	}{
+		{name: "json", format: "json"},
+		{name: "yaml", format: "yaml"},
+		{name: "unsupported", format: "toml", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := core.NewConfig("2.0.0").Save(dir); err != nil {
+				t.Fatal(err)
+			}
+			oldDir, _ := os.Getwd()
+			if err := os.Chdir(dir); err != nil {
+				t.Fatal(err)
+			}
+			defer os.Chdir(oldDir)
+
+			cmd := &cobra.Command{RunE: runRegistryDump}
+			cmd.Flags().String("format", core.DumpFormatJSON, "")
+			cmd.Flags().Set("format", tt.format)
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+
+			err := cmd.RunE(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			var dump core.RegistryDump
+			if tt.format == "yaml" {
+				err = yaml.Unmarshal(out.Bytes(), &dump)
+			} else {
+				err = json.Unmarshal(out.Bytes(), &dump)
+			}
+			if err != nil {
+				t.Fatalf("output is not valid %s: %v\n%s", tt.format, err, out.String())
+			}
+			if dump.Version != "2.0.0" || len(dump.Components) == 0 {
+				t.Errorf("dump = version %q with %d components", dump.Version, len(dump.Components))
+			}
+			if !strings.Contains(out.String(), "go-guide") {
+				t.Error("dump should list the go-guide skill")
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// verifyRegistryTrust is replaced in tests to avoid the network.
+var verifyRegistryTrust = core.VerifyRegistryTrust
+
+var registryTrustCmd = &cobra.Command{
+	Use:   "trust <registry>",
+	Short: "Verify a third-party registry and pin its fingerprint",
+	Long: `Check a GitHub registry against the trust policy in samuel.yaml and pin
+its fingerprint, so later installs refuse a registry that was replaced.
+
+The fingerprint is derived from GitHub's owner and repository IDs, which
+survive renames but change when a repository is deleted and re-created or
+a look-alike URL is used. Installs from an unpinned registry ask for
+confirmation first; use this command to pin one non-interactively, e.g. in
+CI, after reviewing it.
+
+Trust policy keys:
+  trust.allowed_owners   GitHub users or organizations allowed to publish
+  trust.signing_key      SSH key fingerprint that must sign the branch head
+
+Examples:
+  samuel registry trust https://github.com/acme/samuel-overlay
+  samuel config set trust.allowed_owners acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRegistryTrust,
+}
+
+func init() {
+	registryCmd.AddCommand(registryTrustCmd)
+	registryTrustCmd.Flags().String("branch", "", "Branch whose head signature is checked (default: main)")
+}
+
+func runRegistryTrust(cmd *cobra.Command, args []string) error {
+	branch, _ := cmd.Flags().GetString("branch")
+	config, dir, err := loadProjectConfig(cmd)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
+		}
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	overlay := &core.OverlayConfig{Registry: args[0], Branch: branch}
+	id, err := verifyRegistryTrust(config.Trust, overlay)
+	if err != nil {
+		return err
+	}
+	printRegistryIdentity(id)
+	if id.Pinned {
+		ui.Success("Registry is already pinned")
+		return nil
+	}
+
+	config.Trust = config.Trust.Pin(id.Registry, id.Fingerprint)
+	if err := config.Save(dir); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	ui.Success("Pinned %s", id.Registry)
+	return nil
+}
+
+// confirmOverlayTrust checks the overlay against the trust policy before
+// anything is downloaded from it. On first use it shows who publishes the
+// registry and asks before trusting it; the returned policy carries the
+// new pin, and the caller saves it with the rest of the config. When a
+// signature was verified, the overlay is pinned to that commit so the
+// download cannot pick up a later branch head.
+func confirmOverlayTrust(policy *core.TrustPolicy, overlay *core.OverlayConfig) (*core.TrustPolicy, error) {
+	if overlay == nil || overlay.Registry == "" {
+		return policy, nil
+	}
+	id, err := verifyRegistryTrust(policy, overlay)
+	if err != nil {
+		return nil, err
+	}
+	overlay.Commit = id.Commit
+	if id.Pinned {
+		return policy, nil
+	}
+
+	ui.Warn("First use of third-party registry %s", id.Registry)
+	printRegistryIdentity(id)
+	ok, err := approve(promptRegistryTrust, "Trust this registry and pin its fingerprint?", false)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("registry %s is not trusted; after reviewing it, pin %s with "+
+			"'samuel registry trust %s' (or 'samuel init --overlay-fingerprint')", id.Registry, id.Fingerprint, id.Registry)
+	}
+	return policy.Pin(id.Registry, id.Fingerprint), nil
+}
+
+func printRegistryIdentity(id *core.RegistryIdentity) {
+	ui.TableRow("Repository", id.FullName)
+	ui.TableRow("Owner", id.Owner)
+	ui.TableRow("Fingerprint", id.Fingerprint)
+	if id.SignedBy != "" {
+		ui.TableRow("Signed by", id.SignedBy)
+		ui.TableRow("Commit", id.Commit)
+	}
+}
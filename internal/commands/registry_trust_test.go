@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+const (
+	testOverlayRegistry = "https://github.com/acme/samuel-overlay"
+	testOverlayCommit   = "0123456789abcdef0123456789abcdef01234567"
+)
+
+// stubRegistryTrust replaces the trust lookup and the prompt answer.
+func stubRegistryTrust(t *testing.T, pinned, answer bool) *int {
+	t.Helper()
+	origVerify, origConfirm := verifyRegistryTrust, confirmPrompt
+	t.Cleanup(func() { verifyRegistryTrust, confirmPrompt = origVerify, origConfirm })
+
+	prompts := 0
+	verifyRegistryTrust = func(policy *core.TrustPolicy, overlay *core.OverlayConfig) (*core.RegistryIdentity, error) {
+		return &core.RegistryIdentity{
+			Registry: overlay.Registry, Owner: "acme", FullName: "acme/samuel-overlay",
+			Fingerprint: "SHA256:abc", Pinned: pinned, Commit: testOverlayCommit,
+		}, nil
+	}
+	confirmPrompt = func(string, bool) (bool, error) {
+		prompts++
+		return answer, nil
+	}
+	return &prompts
+}
+
+func TestConfirmOverlayTrust(t *testing.T) {
+	tests := []struct {
+		name        string
+		overlay     *core.OverlayConfig
+		pinned      bool
+		answer      bool
+		wantPrompts int
+		wantPin     bool
+		wantErr     bool
+	}{
+		{name: "no overlay", overlay: nil},
+		{name: "already pinned", overlay: &core.OverlayConfig{Registry: testOverlayRegistry}, pinned: true},
+		{
+			name:    "first use accepted",
+			overlay: &core.OverlayConfig{Registry: testOverlayRegistry},
+			answer:  true, wantPrompts: 1, wantPin: true,
+		},
+		{
+			name:    "first use declined",
+			overlay: &core.OverlayConfig{Registry: testOverlayRegistry},
+			answer:  false, wantPrompts: 1, wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prompts := stubRegistryTrust(t, tt.pinned, tt.answer)
+			policy, err := confirmOverlayTrust(nil, tt.overlay)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("confirmOverlayTrust() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if *prompts != tt.wantPrompts {
+				t.Errorf("prompted %d times, want %d", *prompts, tt.wantPrompts)
+			}
+			gotPin := policy != nil && policy.Pinned["https://github.com/acme/samuel-overlay"] == "SHA256:abc"
+			if gotPin != tt.wantPin {
+				t.Errorf("pinned = %v, want %v (policy %+v)", gotPin, tt.wantPin, policy)
+			}
+			if tt.overlay != nil && !tt.wantErr && tt.overlay.Commit != testOverlayCommit {
+				t.Errorf("overlay commit = %q, want the verified commit", tt.overlay.Commit)
+			}
+		})
+	}
+}
+
+func TestConfirmOverlayTrust_Refused(t *testing.T) {
+	stubRegistryTrust(t, false, true)
+	verifyRegistryTrust = func(*core.TrustPolicy, *core.OverlayConfig) (*core.RegistryIdentity, error) {
+		return nil, errors.New("not in trust.allowed_owners")
+	}
+	if _, err := confirmOverlayTrust(nil, &core.OverlayConfig{Registry: testOverlayRegistry}); err == nil {
+		t.Fatal("expected the policy error")
+	}
+}
+
+func TestRunRegistryTrust(t *testing.T) {
+	_, cleanup := setupSkillTestDir(t)
+	defer cleanup()
+	prompts := stubRegistryTrust(t, false, false)
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("branch", "", "")
+	if err := runRegistryTrust(cmd, []string{testOverlayRegistry}); err != nil {
+		t.Fatalf("runRegistryTrust() error = %v", err)
+	}
+	if *prompts != 0 {
+		t.Error("registry trust should not prompt")
+	}
+
+	config, err := core.LoadConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Trust == nil || !strings.HasPrefix(config.Trust.Pinned[testOverlayRegistry], "SHA256:") {
+		t.Errorf("fingerprint not pinned in samuel.yaml: %+v", config.Trust)
+	}
+}
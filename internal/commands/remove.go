@@ -42,7 +42,7 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 
 	// Load config
-	config, err := core.LoadConfig()
+	config, cwd, err := loadProjectConfig(cmd)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
@@ -92,19 +92,20 @@ func runRemove(cmd *cobra.Command, args []string) error {
 
 	// Confirm removal
 	if !force {
-		confirmed, err := ui.Confirm(fmt.Sprintf("Remove %s '%s'?", componentType, componentName), false)
+		confirmed, err := approve(promptRemove, fmt.Sprintf("Remove %s '%s'?", componentType, componentName), false)
 		if err != nil || !confirmed {
 			ui.Info("Removal cancelled")
 			return nil
 		}
 	}
 
-	// Get current directory
-	cwd, err := os.Getwd()
-	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
-	}
+	return withProjectLock(cmd, cwd, func() error {
+		return removeComponent(cwd, config, component, componentType, componentName)
+	})
+}
 
+// removeComponent deletes the component's files and drops it from the config.
+func removeComponent(cwd string, config *core.Config, component *core.Component, componentType, componentName string) error {
 	// Remove the file (validate path stays within project directory)
 	filePath, err := validateRemovePath(cwd, component.Path)
 	if err != nil {
@@ -132,6 +133,7 @@ func runRemove(cmd *cobra.Command, args []string) error {
 	if err := config.Save(cwd); err != nil {
 		return fmt.Errorf("failed to update config: %w", err)
 	}
+	refreshSkillIndex(cwd)
 
 	ui.Success("Updated samuel.yaml")
 
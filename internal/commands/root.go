@@ -1,9 +1,16 @@
 package commands
 
 import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/ui"
 	"github.com/spf13/cobra"
 )
 
+// canonicalBinary is the primary binary name. Deprecation paths and help
+// text are written against it; other binaries share the same command tree.
+const canonicalBinary = "samuel"
+
 var (
 	// Version information (set at build time)
 	Version   = "dev"
@@ -12,31 +19,91 @@ var (
 )
 
 var rootCmd = &cobra.Command{
-	Use:   "samuel",
-	Short: "Samuel - Artificial Intelligence Coding Framework CLI",
-	Long: `Samuel CLI manages the Artificial Intelligence Coding Framework.
+	Use:               canonicalBinary,
+	Short:             "Samuel - Artificial Intelligence Coding Framework CLI",
+	Long:              rootLong(canonicalBinary),
+	SilenceUsage:      true,
+	SilenceErrors:     true,
+	PersistentPreRunE: rootPreRun,
+	PersistentPostRun: printUpdateNotice,
+}
+
+// rootLong returns the root help text with examples written for binary, so
+// each entry point documents the name it was invoked as.
+func rootLong(binary string) string {
+	return fmt.Sprintf(`Samuel CLI manages the Artificial Intelligence Coding Framework.
 
 It helps you initialize projects with AI coding guardrails, update framework
 versions, and manage language/framework guides without cloning the repository.
 
 Examples:
-  samuel init my-project          # Initialize a new project
-  samuel init .                   # Initialize in current directory
-  samuel update                   # Update to latest framework version
-  samuel add language rust        # Add Rust language guide
-  samuel list --available         # List all available components
-  samuel doctor                   # Check installation health`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
+  %[1]s init my-project          # Initialize a new project
+  %[1]s init .                   # Initialize in current directory
+  %[1]s update                   # Update to latest framework version
+  %[1]s add language rust        # Add Rust language guide
+  %[1]s list --available         # List all available components
+  %[1]s doctor                   # Check installation health`, binary)
 }
 
 // Execute runs the root command
 func Execute() error {
+	applyDeprecations(rootCmd, activeDeprecations())
 	return rootCmd.Execute()
 }
 
+// Run executes the command tree as the named binary, prints any error, and
+// returns the process exit code. Every entry point under cmd/ calls it so
+// the binaries share commands, config discovery, and error handling and
+// differ only in the name shown in usage and help output.
+func Run(binary string) int {
+	rootCmd.Use = binary
+	rootCmd.Long = rootLong(binary)
+	if err := Execute(); err != nil {
+		ui.PrintError(err)
+		return ExitCode(err)
+	}
+	return 0
+}
+
+// rootPreRun is the root pre-run hook. An invalid approval policy fails the
+// command before anything else runs.
+func rootPreRun(cmd *cobra.Command, args []string) error {
+	if noColor, _ := cmd.Flags().GetBool("no-color"); noColor {
+		ui.DisableColors()
+	}
+	if err := setApprovalPolicy(cmd); err != nil {
+		return err
+	}
+	applyTheme(cmd)
+	warnDeprecations(cmd, args)
+	startUpdateCheck(cmd)
+	return nil
+}
+
+// canonicalCommandPath returns cmd's path as if invoked through the
+// canonical binary, e.g. "samuel init" for "aicof init".
+func canonicalCommandPath(cmd *cobra.Command) string {
+	path := cmd.CommandPath()
+	if root := cmd.Root().Name(); root != canonicalBinary {
+		path = canonicalBinary + path[len(root):]
+	}
+	return path
+}
+
+// printBinaryInfo notes in version output when the CLI runs under an alias.
+func printBinaryInfo(cmd *cobra.Command) {
+	if name := cmd.Root().Name(); name != canonicalBinary {
+		ui.TableRow("Binary", fmt.Sprintf("%s (alias of %s)", name, canonicalBinary))
+	}
+}
+
 func init() {
 	// Global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output")
+	rootCmd.PersistentFlags().Bool("force-unlock", false, "Remove a stale project lock before running")
+	rootCmd.PersistentFlags().String("target", "", "Project directory to operate on (default: current directory)")
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "Answer yes to every confirmation prompt")
+	rootCmd.PersistentFlags().Bool("no", false, "Answer no to every confirmation prompt")
+	rootCmd.PersistentFlags().StringSlice("approve", nil, "Pre-approve the named prompts (comma-separated; see 'Approvals' in the docs)")
 }
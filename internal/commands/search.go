@@ -36,6 +36,7 @@ Examples:
   samuel search py                 # Fuzzy match finds "python"
   samuel search "spring boot"      # Multi-word search
   samuel search commit             # Finds commit-message skill
+  samuel search --content retry    # Also search installed skill text
 
 Types (with aliases):
   language   (lang, l)   Language guides
@@ -50,6 +51,7 @@ func init() {
 	rootCmd.AddCommand(searchCmd)
 	searchCmd.Flags().StringP("type", "t", "", "Filter by type: language/lang/l, framework/fw/f, workflow/wf/w, skill/sk/s")
 	searchCmd.Flags().IntP("limit", "n", defaultSearchLimit, "Limit number of results")
+	searchCmd.Flags().Bool("content", false, "Also match the text of installed skills (see 'samuel grep')")
 }
 
 func runSearch(cmd *cobra.Command, args []string) error {
@@ -58,11 +60,14 @@ func runSearch(cmd *cobra.Command, args []string) error {
 	limit, _ := cmd.Flags().GetInt("limit")
 
 	typeFilter = normalizeTypeFilter(typeFilter)
-	config, configErr := core.LoadConfig()
+	config, dir, configErr := loadProjectConfig(cmd)
 	if configErr != nil && !os.IsNotExist(configErr) {
 		ui.Warn("Could not load config: %v", configErr)
 	}
 	results := searchComponents(query, typeFilter, config)
+	if content, _ := cmd.Flags().GetBool("content"); content && (typeFilter == "" || typeFilter == "skill") {
+		results = searchInstalledContent(dir, query, results)
+	}
 
 	if len(results) == 0 {
 		ui.Warn("No components found matching '%s'", query)
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// contentMatchScore ranks skills found only through their content below
+// every name or description match.
+const contentMatchScore = 20
+
+// searchInstalledContent adds installed skills whose content matches the
+// query and that the metadata search did not already find.
+func searchInstalledContent(dir, query string, results []SearchResult) []SearchResult {
+	matches, err := searchSkillContent(dir, query, "", 0, false)
+	if err != nil {
+		ui.Warn("Could not search skill content: %v", err)
+		return results
+	}
+
+	found := make(map[string]bool)
+	for _, r := range results {
+		if r.Type == "skill" {
+			found[r.Name] = true
+		}
+	}
+	counts := make(map[string]int)
+	var order []string
+	first := make(map[string]string)
+	for _, m := range matches {
+		if counts[m.Skill] == 0 {
+			order = append(order, m.Skill)
+			first[m.Skill] = m.Text
+		}
+		counts[m.Skill]++
+	}
+
+	for _, skill := range order {
+		if found[skill] {
+			continue
+		}
+		results = append(results, SearchResult{
+			Name:        skill,
+			Type:        "skill",
+			Description: fmt.Sprintf("%d matching line(s), e.g. %q", counts[skill], truncateContentLine(first[skill])),
+			Score:       contentMatchScore,
+			Installed:   true,
+		})
+	}
+	return results
+}
+
+func truncateContentLine(line string) string {
+	if runes := []rune(line); len(runes) > 60 {
+		return string(runes[:57]) + "..."
+	}
+	return line
+}
@@ -12,8 +12,9 @@ import (
 )
 
 var skillCmd = &cobra.Command{
-	Use:   "skill",
-	Short: "Manage Agent Skills",
+	Use:     "skill",
+	Aliases: []string{"skills"},
+	Short:   "Manage Agent Skills",
 	Long: `Manage Agent Skills - capability modules that give AI agents new abilities.
 
 Skills follow the Agent Skills open standard (https://agentskills.io) and
@@ -22,13 +23,22 @@ are supported by 25+ agent products including Claude Code, Cursor, and VS Code.
 Subcommands:
   create    Create a new skill scaffold
   validate  Validate skill(s) against the specification
+  lint      Check skill markdown encoding and line endings
   list      List installed skills
   info      Show detailed information about a skill
+  cat       Print a skill or one of its sections
+  import    Import a skill from Anthropic's skills repository
+  fixtures  Scaffold test fixtures for a skill
+  deps      Graph dependencies between skills
+  experiment Compare variants of a skill on auto-loop runs
 
 Examples:
   samuel skill create database-ops     # Create a new skill
   samuel skill validate                # Validate all skills
-  samuel skill list                    # List installed skills`,
+  samuel skill lint --fix              # Normalize skill markdown
+  samuel skill list                    # List installed skills
+  samuel skill cat go-guide            # Print a skill's SKILL.md
+  samuel skill import anthropic/pdf    # Import a skill from anthropics/skills`,
 }
 
 var skillCreateCmd = &cobra.Command{
@@ -42,9 +52,13 @@ The skill name must:
   - Not contain consecutive hyphens
   - Be max 64 characters
 
+<skill>@<variant> copies the installed skill to a variant for an
+experiment (see 'samuel skill experiment').
+
 Examples:
   samuel skill create database-ops
-  samuel skill create my-custom-skill`,
+  samuel skill create my-custom-skill
+  samuel skill create go-guide@experimental`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSkillCreate,
 }
@@ -62,6 +76,7 @@ Checks:
   - Name format (lowercase, hyphens, max 64 chars)
   - Description present (max 1024 chars)
   - Compatibility field (max 500 chars if present)
+  - Test fixtures in tests/ are complete (if present)
 
 Examples:
   samuel skill validate                # Validate all skills
@@ -90,12 +105,18 @@ var skillInfoCmd = &cobra.Command{
 Displays:
   - Name and description
   - License and compatibility
-  - Optional directories (scripts, references, assets)
+  - Optional directories (scripts, references, assets, tests)
   - Validation status
   - Line count and estimated tokens
 
+With --render the SKILL.md body follows, formatted for the terminal
+(headings, lists, code blocks, tables) and shown in your pager ($PAGER,
+else less). --raw prints the body as written instead, for piping.
+
 Examples:
-  samuel skill info database-ops`,
+  samuel skill info database-ops
+  samuel skill info database-ops --render
+  samuel skill info database-ops --raw | grep -n TODO`,
 	Args: cobra.ExactArgs(1),
 	RunE: runSkillInfo,
 }
@@ -106,10 +127,25 @@ func init() {
 	skillCmd.AddCommand(skillValidateCmd)
 	skillCmd.AddCommand(skillListCmd)
 	skillCmd.AddCommand(skillInfoCmd)
+	skillInfoCmd.Flags().Bool("render", false, "Show the SKILL.md body formatted, in the pager")
+	skillInfoCmd.Flags().Bool("raw", false, "Show the SKILL.md body unformatted")
+	registerSkillFixturesCmd()
+	registerSkillLintCmd()
+	registerSkillCatCmd()
+	registerSkillImportCmd()
+	registerSkillRelevantCmd()
+	registerSkillExperimentCmd()
 }
 
 func runSkillCreate(cmd *cobra.Command, args []string) error {
 	name := args[0]
+	if strings.Contains(name, core.SkillVariantSeparator) {
+		cwd, err := projectDir(cmd)
+		if err != nil {
+			return err
+		}
+		return createSkillVariant(filepath.Join(cwd, ".claude", "skills"), name)
+	}
 
 	// Validate name first
 	if errors := core.ValidateSkillName(name); len(errors) > 0 {
@@ -120,9 +156,9 @@ func runSkillCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get current directory
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	// Check if Samuel is initialized
@@ -160,9 +196,9 @@ func runSkillCreate(cmd *cobra.Command, args []string) error {
 }
 
 func runSkillValidate(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	skillsDir := filepath.Join(cwd, ".claude", "skills")
@@ -173,16 +209,16 @@ func runSkillValidate(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	cache := projectSkillCache(cwd)
 	var skills []*core.SkillInfo
 
 	if len(args) == 1 {
 		// Validate specific skill
-		skillPath := filepath.Join(skillsDir, args[0])
-		if _, err := os.Stat(skillPath); os.IsNotExist(err) {
+		if _, err := os.Stat(filepath.Join(skillsDir, args[0])); os.IsNotExist(err) {
 			return fmt.Errorf("skill '%s' not found", args[0])
 		}
 
-		info, err := core.LoadSkillInfo(skillPath)
+		info, err := cache.Skill(args[0])
 		if err != nil {
 			return fmt.Errorf("failed to load skill: %w", err)
 		}
@@ -190,7 +226,7 @@ func runSkillValidate(cmd *cobra.Command, args []string) error {
 	} else {
 		// Validate all skills
 		var err error
-		skills, err = core.ScanSkillsDirectory(skillsDir)
+		skills, err = cache.Skills()
 		if err != nil {
 			return fmt.Errorf("failed to scan skills: %w", err)
 		}
@@ -228,9 +264,9 @@ func runSkillValidate(cmd *cobra.Command, args []string) error {
 }
 
 func runSkillList(cmd *cobra.Command, args []string) error {
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	skillsDir := filepath.Join(cwd, ".claude", "skills")
@@ -242,7 +278,7 @@ func runSkillList(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	skills, err := core.ScanSkillsDirectory(skillsDir)
+	skills, err := projectSkillCache(cwd).Skills()
 	if err != nil {
 		return fmt.Errorf("failed to scan skills: %w", err)
 	}
@@ -284,9 +320,9 @@ func runSkillList(cmd *cobra.Command, args []string) error {
 func runSkillInfo(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
-	cwd, err := os.Getwd()
+	cwd, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
 	skillPath := filepath.Join(cwd, ".claude", "skills", name)
@@ -316,5 +352,5 @@ func runSkillInfo(cmd *cobra.Command, args []string) error {
 	}
 
 	ui.Print("")
-	return nil
+	return displaySkillBody(cmd, info)
 }
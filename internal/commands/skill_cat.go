@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+var skillCatCmd = &cobra.Command{
+	Use:   "cat <name>",
+	Short: "Print a skill's SKILL.md or one of its sections",
+	Long: `Print a skill's SKILL.md to stdout, unformatted, so prompts and scripts
+can pull exactly the guidance they need into an agent's context.
+
+With --section, only that section is printed: its heading and everything up
+to the next heading of the same level, subsections included. The section is
+matched by heading title or by heading path, case-insensitively.
+
+Examples:
+  samuel skill cat go-guide                          # Whole SKILL.md
+  samuel skill cat go-guide --section guardrails     # Guardrails section only
+  samuel skill cat go-guide --section "Guardrails > Testing"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillCat,
+}
+
+func registerSkillCatCmd() {
+	skillCmd.AddCommand(skillCatCmd)
+	skillCatCmd.Flags().String("section", "", "Print only the named section (e.g. guardrails)")
+}
+
+func runSkillCat(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	section, _ := cmd.Flags().GetString("section")
+
+	if errs := core.ValidateSkillName(name); len(errs) > 0 {
+		return fmt.Errorf("invalid skill name %q: %s", name, strings.Join(errs, "; "))
+	}
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(filepath.Join(cwd, ".claude", "skills", name, "SKILL.md"))
+	if os.IsNotExist(err) {
+		return fmt.Errorf("skill '%s' not found", name)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read skill: %w", err)
+	}
+
+	normalized, err := core.NormalizeMarkdown(data, core.FinalNewlineEnsure)
+	if err != nil {
+		return fmt.Errorf("skill '%s': %w", name, err)
+	}
+	content := string(normalized)
+	if section != "" {
+		if content, err = core.MarkdownSectionText(content, section); err != nil {
+			return fmt.Errorf("skill '%s': %w", name, err)
+		}
+	}
+	_, err = io.WriteString(cmd.OutOrStdout(), content)
+	return err
+}
@@ -0,0 +1,46 @@
+package commands
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunSkillCat(t *testing.T) {
+	content := "---\r\nname: my-skill\r\ndescription: x\r\n---\r\n\r\n## Guardrails\r\n\r\n- Be careful\r\n\r\n## Usage\r\n"
+	tests := []struct {
+		name    string
+		skill   string
+		section string
+		want    string
+		wantErr bool
+	}{
+		{name: "whole skill", skill: "my-skill", want: "---\nname: my-skill\ndescription: x\n---\n\n## Guardrails\n\n- Be careful\n\n## Usage\n"},
+		{name: "section", skill: "my-skill", section: "guardrails", want: "## Guardrails\n\n- Be careful\n"},
+		{name: "missing section", skill: "my-skill", section: "examples", wantErr: true},
+		{name: "missing skill", skill: "other", wantErr: true},
+		{name: "invalid name", skill: "../my-skill", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup := setupSkillTestDir(t)
+			defer cleanup()
+			createSkillDir(t, filepath.Join(dir, ".claude", "skills"), "my-skill", content)
+
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+			cmd.Flags().String("section", tt.section, "")
+			err := runSkillCat(cmd, []string{tt.skill})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runSkillCat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := out.String(); got != tt.want {
+				t.Errorf("output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
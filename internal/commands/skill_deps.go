@@ -0,0 +1,75 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+var skillDepsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Inspect dependencies between skills",
+}
+
+var skillDepsGraphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Print the skill dependency graph as Mermaid or DOT",
+	Long: `Print how skills depend on each other, for embedding in docs and PRs.
+
+A skill depends on the skills listed in its SKILL.md metadata:
+
+  metadata:
+    depends-on: api-design, testing-strategy
+
+Framework skills also depend on the guide of their metadata language
+(e.g. gin depends on go-guide). Dependencies that are not installed are
+drawn dashed.
+
+By default the installed skills in .claude/skills/ are graphed; --registry
+graphs every skill the CLI can install instead.
+
+Examples:
+  samuel skill deps graph                        # Mermaid flowchart
+  samuel skill deps graph --format dot | dot -Tsvg > skills.svg
+  samuel skill deps graph --registry`,
+	Args: cobra.NoArgs,
+	RunE: runSkillDepsGraph,
+}
+
+func init() {
+	skillCmd.AddCommand(skillDepsCmd)
+	skillDepsCmd.AddCommand(skillDepsGraphCmd)
+	skillDepsGraphCmd.Flags().String("format", core.GraphFormatMermaid, "Output format: mermaid, dot")
+	skillDepsGraphCmd.Flags().Bool("registry", false, "Graph the registry instead of installed skills")
+}
+
+func runSkillDepsGraph(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	registry, _ := cmd.Flags().GetBool("registry")
+
+	var graph *core.SkillGraph
+	if registry {
+		graph = core.BuildRegistrySkillGraph()
+	} else {
+		cwd, err := projectDir(cmd)
+		if err != nil {
+			return err
+		}
+		skills, err := projectSkillCache(cwd).Skills()
+		if err != nil {
+			return err
+		}
+		if len(skills) == 0 {
+			return fmt.Errorf("no skills installed in .claude/skills/ (use --registry to graph the registry)")
+		}
+		graph = core.BuildInstalledSkillGraph(skills)
+	}
+
+	out, err := core.FormatSkillGraph(graph, format)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprint(cmd.OutOrStdout(), out)
+	return err
+}
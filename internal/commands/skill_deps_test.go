@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newSkillDepsGraphTestCmd(format string, registry bool) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("format", format, "")
+	cmd.Flags().Bool("registry", registry, "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	return cmd, &out
+}
+
+func TestRunSkillDepsGraph(t *testing.T) {
+	ginMD := "---\nname: gin\ndescription: Gin.\nmetadata:\n  category: framework\n  language: go\n---\n\nBody.\n"
+
+	tests := []struct {
+		name     string
+		format   string
+		registry bool
+		skills   map[string]string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:   "installed mermaid",
+			format: "mermaid",
+			skills: map[string]string{"gin": ginMD, "go-guide": validSkillMD("go-guide", "Go.")},
+			want:   []string{"flowchart LR", "skill_gin --> skill_go_guide"},
+		},
+		{
+			name:   "installed dot with missing guide",
+			format: "dot",
+			skills: map[string]string{"gin": ginMD},
+			want:   []string{`"gin" -> "go-guide" [style=dashed];`},
+		},
+		{
+			name:     "registry",
+			format:   "dot",
+			registry: true,
+			want:     []string{`"django" -> "python-guide";`},
+		},
+		{name: "no skills installed", format: "mermaid", wantErr: true},
+		{name: "unsupported format", format: "svg", registry: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup := setupSkillTestDir(t)
+			defer cleanup()
+			for name, content := range tt.skills {
+				createSkillDir(t, filepath.Join(dir, ".claude", "skills"), name, content)
+			}
+
+			cmd, out := newSkillDepsGraphTestCmd(tt.format, tt.registry)
+			err := runSkillDepsGraph(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runSkillDepsGraph() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(out.String(), w) {
+					t.Errorf("output missing %q:\n%s", w, out.String())
+				}
+			}
+		})
+	}
+}
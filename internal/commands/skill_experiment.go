@@ -0,0 +1,231 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var skillExperimentCmd = &cobra.Command{
+	Use:   "experiment",
+	Short: "Compare variants of a skill on auto-loop runs",
+	Long: `Compare variants of a skill by the auto-loop results recorded under each.
+
+A variant is a copy of an installed skill in .claude/skills/<skill>@<variant>/,
+created with 'samuel skill create <skill>@<variant>'. Its SKILL.md keeps the
+skill's name. skills.variants in samuel.yaml selects the one listed in the
+CLAUDE.md skills section; the others are left out.
+
+While an experiment runs, every auto-loop iteration is tagged in
+.claude/auto/events.jsonl with the variant active at the time. Switch
+variants between runs with 'experiment start', then compare them with
+'experiment report'.
+
+Examples:
+  samuel skill create go-guide@experimental
+  samuel skill experiment start go-guide experimental
+  samuel auto start
+  samuel skill experiment start go-guide default
+  samuel auto start
+  samuel skill experiment report go-guide
+  samuel skill experiment stop go-guide --keep experimental`,
+}
+
+var skillExperimentStartCmd = &cobra.Command{
+	Use:   "start <skill> [variant]",
+	Short: "Start an experiment, or switch the variant it runs under",
+	Long: `Activate a variant of the skill and start recording auto-loop iterations
+under it. Without a variant the active one is kept; 'default' is the skill
+itself. Running start again on a running experiment only switches the
+variant.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSkillExperimentStart,
+}
+
+var skillExperimentStopCmd = &cobra.Command{
+	Use:   "stop <skill>",
+	Short: "Stop an experiment and show its results",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runSkillExperimentStop,
+}
+
+var skillExperimentReportCmd = &cobra.Command{
+	Use:   "report <skill>",
+	Short: "Show the auto-loop results of each variant",
+	Long: `Show, per variant, the auto-loop iterations recorded during the experiment,
+how many failed, the tasks worked on and completed, and the files changed.
+A task counts as completed under the variant of its last iteration.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillExperimentReport,
+}
+
+func registerSkillExperimentCmd() {
+	skillCmd.AddCommand(skillExperimentCmd)
+	skillExperimentCmd.AddCommand(skillExperimentStartCmd)
+	skillExperimentCmd.AddCommand(skillExperimentStopCmd)
+	skillExperimentCmd.AddCommand(skillExperimentReportCmd)
+	skillExperimentStopCmd.Flags().String("keep", "", "Variant to leave active (default: the current one)")
+	skillExperimentReportCmd.Flags().Bool("json", false, "Print the results as JSON")
+}
+
+func runSkillExperimentStart(cmd *cobra.Command, args []string) error {
+	config, dir, err := loadProjectConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("no Samuel installation found: %w", err)
+	}
+	skill, variant := args[0], config.ActiveSkillVariant(args[0])
+	if len(args) == 2 {
+		variant = args[1]
+	}
+	skills := projectSkillCache(dir)
+	if err := checkSkillVariant(skills, skill, variant, true); err != nil {
+		return err
+	}
+
+	exp := config.StartSkillExperiment(skill, time.Now())
+	config.SetActiveSkillVariant(skill, variant)
+	if err := config.Save(dir); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	updateSkillsAndAgentsMD(dir, skills, false)
+
+	ui.Success("Experiment on %s running since %s with variant %s", skill, exp.Started, variant)
+	ui.Info("Auto-loop iterations are now recorded under %s", variant)
+	return nil
+}
+
+func runSkillExperimentStop(cmd *cobra.Command, args []string) error {
+	config, dir, err := loadProjectConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("no Samuel installation found: %w", err)
+	}
+	skill := args[0]
+	if err := config.StopSkillExperiment(skill, time.Now()); err != nil {
+		return err
+	}
+	skills := projectSkillCache(dir)
+	if keep, _ := cmd.Flags().GetString("keep"); keep != "" {
+		if err := checkSkillVariant(skills, skill, keep, false); err != nil {
+			return err
+		}
+		config.SetActiveSkillVariant(skill, keep)
+	}
+	if err := config.Save(dir); err != nil {
+		return fmt.Errorf("failed to save config: %w", err)
+	}
+	updateSkillsAndAgentsMD(dir, skills, false)
+
+	ui.Success("Stopped the experiment on %s; %s stays active", skill, config.ActiveSkillVariant(skill))
+	return printExperimentResults(dir, skill, config.SkillExperiment(skill))
+}
+
+func runSkillExperimentReport(cmd *cobra.Command, args []string) error {
+	config, dir, err := loadProjectConfig(cmd)
+	if err != nil {
+		return fmt.Errorf("no Samuel installation found: %w", err)
+	}
+	exp := config.SkillExperiment(args[0])
+	if exp == nil {
+		return fmt.Errorf("no experiment on skill '%s'; start one with 'samuel skill experiment start'", args[0])
+	}
+	if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+		results, err := experimentResults(dir, args[0], exp)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+	return printExperimentResults(dir, args[0], exp)
+}
+
+// checkSkillVariant verifies the variant of skill is installed and, for
+// starting an experiment, that the skill has a variant to compare with.
+func checkSkillVariant(cache *core.SkillCache, skill, variant string, needVariants bool) error {
+	skills, err := cache.Skills()
+	if err != nil {
+		return err
+	}
+	variants := core.SkillVariants(skills, skill)
+	if needVariants && len(variants) == 0 {
+		return fmt.Errorf("skill '%s' has no variants; create one with 'samuel skill create %s@<variant>'", skill, skill)
+	}
+	if variant != core.SkillVariantDefault && !slices.Contains(variants, variant) {
+		return fmt.Errorf("variant '%s' of skill '%s' is not installed (installed: %s, %v)",
+			variant, skill, core.SkillVariantDefault, variants)
+	}
+	return nil
+}
+
+// experimentResults aggregates the event log for the experiment on skill.
+func experimentResults(dir, skill string, exp *core.SkillExperiment) ([]core.VariantResult, error) {
+	store, err := core.OpenProjectAutoStore(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer store.Close()
+	events, err := store.LoadEvents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the event log: %w", err)
+	}
+	prd, err := store.LoadPRD()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	return core.SkillExperimentResults(exp, skill, events, prd), nil
+}
+
+func printExperimentResults(dir, skill string, exp *core.SkillExperiment) error {
+	results, err := experimentResults(dir, skill, exp)
+	if err != nil {
+		return err
+	}
+	period := exp.Started + " - now"
+	if !exp.Running() {
+		period = exp.Started + " - " + exp.Stopped
+	}
+	ui.Section(fmt.Sprintf("Experiment on %s (%s)", skill, period))
+	if len(results) == 0 {
+		ui.Dim("  No auto-loop iterations recorded yet; run 'samuel auto start'")
+		return nil
+	}
+	ui.Print("  %-20s %10s %8s %7s %10s %10s %8s", "VARIANT", "ITERATIONS", "FAILED", "TASKS", "COMPLETED", "ITER/TASK", "FILES")
+	for _, r := range results {
+		perTask := "-"
+		if r.Completed > 0 {
+			perTask = fmt.Sprintf("%.1f", r.IterationsPerTask())
+		}
+		ui.Print("  %-20s %10d %8d %7d %10d %10s %8d",
+			r.Variant, r.Iterations, r.Failed, r.Tasks, r.Completed, perTask, r.FilesChanged)
+	}
+	if len(results) < 2 {
+		ui.Dim("  Only one variant has run so far; switch with 'samuel skill experiment start %s <variant>'", skill)
+	}
+	return nil
+}
+
+// createSkillVariant copies an installed skill to <skill>@<variant>.
+func createSkillVariant(skillsDir, name string) error {
+	skill, variant := core.SplitSkillVariant(name)
+	if errs := core.ValidateSkillName(skill); len(errs) > 0 {
+		return fmt.Errorf("invalid skill name: %s", errs[0])
+	}
+	if err := core.CreateSkillVariant(skillsDir, skill, variant); err != nil {
+		return fmt.Errorf("failed to create variant: %w", err)
+	}
+	ui.Success("Created variant %s from %s at %s/", variant, skill, filepath.Join(skillsDir, name))
+	ui.Info("Edit .claude/skills/%s/SKILL.md, then compare it with 'samuel skill experiment start %s %s'", name, skill, variant)
+	return nil
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestSkillExperimentFlow(t *testing.T) {
+	dir, cleanup := setupSkillTestDir(t)
+	defer cleanup()
+	skillsDir := filepath.Join(dir, ".claude", "skills")
+	createSkillDir(t, skillsDir, "go-guide", "---\nname: go-guide\ndescription: Go guide\n---\n")
+	claudeMD := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(claudeMD, []byte(core.SkillsStartMarker+"\n"+core.SkillsEndMarker+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runSkillExperimentStart(nil, []string{"go-guide"}); err == nil {
+		t.Fatal("starting an experiment without variants should fail")
+	}
+	if err := runSkillCreate(nil, []string{"go-guide@experimental"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSkillExperimentStart(nil, []string{"go-guide", "missing"}); err == nil {
+		t.Fatal("starting with an unknown variant should fail")
+	}
+	if err := runSkillExperimentStart(nil, []string{"go-guide", "experimental"}); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := core.LoadConfigFrom(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.ActiveSkillVariant("go-guide") != "experimental" || !config.SkillExperiment("go-guide").Running() {
+		t.Errorf("config after start = %+v", config.Skills)
+	}
+	data, _ := os.ReadFile(claudeMD)
+	if !strings.Contains(string(data), "| go-guide@experimental |") {
+		t.Errorf("CLAUDE.md should list the active variant:\n%s", data)
+	}
+
+	stop := &cobra.Command{}
+	stop.Flags().String("keep", "default", "")
+	if err := runSkillExperimentStop(stop, []string{"go-guide"}); err != nil {
+		t.Fatal(err)
+	}
+	config, _ = core.LoadConfigFrom(dir)
+	if config.ActiveSkillVariant("go-guide") != core.SkillVariantDefault || config.SkillExperiment("go-guide").Running() {
+		t.Errorf("config after stop = %+v", config.Skills)
+	}
+	data, _ = os.ReadFile(claudeMD)
+	if strings.Contains(string(data), "go-guide@experimental") {
+		t.Errorf("CLAUDE.md should list the kept skill again:\n%s", data)
+	}
+	report := &cobra.Command{}
+	report.Flags().Bool("json", false, "")
+	if err := runSkillExperimentReport(report, []string{"go-guide"}); err != nil {
+		t.Errorf("report without loop state: %v", err)
+	}
+	if err := runSkillExperimentReport(report, []string{"python-guide"}); err == nil {
+		t.Error("report on a skill without an experiment should fail")
+	}
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var skillFixturesCmd = &cobra.Command{
+	Use:   "fixtures <name>",
+	Short: "Scaffold test fixtures for a skill",
+	Long: `Scaffold test cases under a skill's tests/ directory.
+
+One case is created per example in the SKILL.md Examples section, using the
+example's **Input** and **Output** as input.md and expected.md. A skill
+without examples gets a single placeholder case to fill in.
+
+Layout:
+  tests/<case>/case.yaml     Description of the case
+  tests/<case>/input.md      Request given to the agent
+  tests/<case>/expected.md   Output the agent should give
+
+Existing files are kept unless --force is given. 'samuel skill validate'
+checks that every case is complete. Running the cases against an agent is
+out of scope: samuel scaffolds and validates them only.
+
+Examples:
+  samuel skill fixtures database-ops
+  samuel skill fixtures database-ops --force   # Regenerate from SKILL.md`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillFixtures,
+}
+
+func registerSkillFixturesCmd() {
+	skillCmd.AddCommand(skillFixturesCmd)
+	skillFixturesCmd.Flags().BoolP("force", "f", false, "Overwrite existing fixture files")
+}
+
+func runSkillFixtures(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	force, _ := cmd.Flags().GetBool("force")
+
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+
+	skillPath := filepath.Join(cwd, ".claude", "skills", name)
+	if _, err := os.Stat(filepath.Join(skillPath, "SKILL.md")); os.IsNotExist(err) {
+		return fmt.Errorf("skill '%s' not found", name)
+	}
+
+	written, err := core.CreateSkillFixtures(skillPath, force)
+	if err != nil {
+		return fmt.Errorf("failed to create fixtures: %w", err)
+	}
+	if len(written) == 0 {
+		ui.Info("Fixtures for '%s' already exist (use --force to regenerate)", name)
+		return nil
+	}
+
+	ui.Success("Created %d fixture files for skill '%s'", len(written), name)
+	ui.Print("")
+	for _, file := range written {
+		ui.Print("    %s/%s", name, filepath.ToSlash(file))
+	}
+	ui.Print("")
+	ui.Info("Review expected.md in each case, then run 'samuel skill validate %s'", name)
+	return nil
+}
@@ -0,0 +1,42 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunSkillFixtures(t *testing.T) {
+	t.Run("missing_skill", func(t *testing.T) {
+		_, cleanup := setupSkillTestDir(t)
+		defer cleanup()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().BoolP("force", "f", false, "")
+		if err := runSkillFixtures(cmd, []string{"nope"}); err == nil {
+			t.Error("expected error for missing skill")
+		}
+	})
+
+	t.Run("scaffolds_placeholder_case", func(t *testing.T) {
+		dir, cleanup := setupSkillTestDir(t)
+		defer cleanup()
+		skillsDir := filepath.Join(dir, ".claude", "skills")
+		createSkillDir(t, skillsDir, "my-skill", validSkillMD("my-skill", "A test skill"))
+
+		cmd := &cobra.Command{}
+		cmd.Flags().BoolP("force", "f", false, "")
+		if err := runSkillFixtures(cmd, []string{"my-skill"}); err != nil {
+			t.Fatalf("runSkillFixtures returned error: %v", err)
+		}
+
+		caseDir := filepath.Join(skillsDir, "my-skill", "tests", "basic-usage")
+		for _, file := range []string{"case.yaml", "input.md", "expected.md"} {
+			if _, err := os.Stat(filepath.Join(caseDir, file)); err != nil {
+				t.Errorf("expected %s: %v", file, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var skillImportCmd = &cobra.Command{
+	Use:   "import <source>",
+	Short: "Import a skill from Anthropic's skills repository",
+	Long: `Import a skill from github.com/anthropics/skills into .claude/skills/.
+
+The ref (default: main) is resolved to a commit and the skill is fetched
+at that commit. Upstream directories are renamed to the Samuel layout
+(reference/ and docs/ to references/, templates/ and resources/ to
+assets/) with links updated, markdown is normalized, and the result must
+pass 'samuel skill validate'. The skill is added to samuel.yaml with its
+source repository, path, ref and commit under skill_sources.
+
+Examples:
+  samuel skill import anthropic/pdf
+  samuel skill import anthropic/mcp-builder --ref 1a2b3c4
+  samuel skill import anthropic/pdf --force     # Replace an installed copy`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSkillImport,
+}
+
+func registerSkillImportCmd() {
+	skillCmd.AddCommand(skillImportCmd)
+	skillImportCmd.Flags().String("ref", core.DefaultSkillImportRef, "Branch, tag or commit to import from")
+	skillImportCmd.Flags().Bool("force", false, "Replace an installed skill of the same name")
+}
+
+func runSkillImport(cmd *cobra.Command, args []string) error {
+	name, err := core.ParseSkillImportSource(args[0])
+	if err != nil {
+		return err
+	}
+	ref, _ := cmd.Flags().GetString("ref")
+	force, _ := cmd.Flags().GetBool("force")
+
+	config, dir, err := loadProjectConfig(cmd)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
+		}
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	return withProjectLock(cmd, dir, func() error {
+		ui.Info("Importing %s from %s/%s@%s...", name, core.AnthropicSkillsOwner, core.AnthropicSkillsRepo, ref)
+		result, err := core.ImportAnthropicSkill(dir, name, ref, config.FinalNewline(), force)
+		if err != nil {
+			return err
+		}
+
+		config.AddImportedSkill(name, result.Source)
+		if err := config.Save(dir); err != nil {
+			return fmt.Errorf("failed to save config: %w", err)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "CLAUDE.md")); err == nil {
+			updateSkillsAndAgentsMD(dir, projectSkillCache(dir), false)
+		}
+
+		displaySkillImport(result)
+		return nil
+	})
+}
+
+// displaySkillImport summarizes an imported skill and where it came from.
+func displaySkillImport(result *core.SkillImport) {
+	ui.Success("Imported skill '%s' to .claude/skills/%s/", result.Name, result.Name)
+	ui.TableRow("Source", result.Source.Repository+"/"+result.Source.Path)
+	ui.TableRow("Commit", result.Source.Commit)
+	if result.Info.Metadata.License != "" {
+		ui.TableRow("License", result.Info.Metadata.License)
+	}
+	for _, change := range result.Adapted {
+		ui.ListItem(1, "Adapted layout: %s", change)
+	}
+}
@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunSkillImport_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		setup   func(t *testing.T, dir string)
+		wantErr string
+	}{
+		{name: "unsupported source", source: "github/pdf", wantErr: "unsupported skill source"},
+		{name: "invalid name", source: "anthropic/PDF", wantErr: "invalid skill name"},
+		{
+			name: "no installation", source: "anthropic/pdf", wantErr: "no Samuel installation found",
+			setup: func(t *testing.T, dir string) {
+				if err := os.Remove(filepath.Join(dir, "samuel.yaml")); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "already installed", source: "anthropic/pdf", wantErr: "already exists",
+			setup: func(t *testing.T, dir string) {
+				createSkillDir(t, filepath.Join(dir, ".claude", "skills"), "pdf", validSkillMD("pdf", "PDF tools"))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup := setupSkillTestDir(t)
+			defer cleanup()
+			if tt.setup != nil {
+				tt.setup(t, dir)
+			}
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("ref", "main", "")
+			cmd.Flags().Bool("force", false, "")
+			err := runSkillImport(cmd, []string{tt.source})
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("runSkillImport() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,10 +2,13 @@ package commands
 
 import (
 	"fmt"
+	"io"
+	"os"
 	"strings"
 
 	"github.com/ar4mirez/samuel/internal/core"
 	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
 )
 
 // displaySkillMetadata renders the metadata section of a skill info display.
@@ -54,6 +57,9 @@ func displaySkillStructure(info *core.SkillInfo) {
 	if info.HasAssets {
 		dirs = append(dirs, "assets/")
 	}
+	if info.HasTests {
+		dirs = append(dirs, "tests/")
+	}
 	if len(dirs) > 0 {
 		ui.TableRow("Directories", strings.Join(dirs, ", "))
 	}
@@ -66,3 +72,20 @@ func displaySkillStructure(info *core.SkillInfo) {
 		}
 	}
 }
+
+// displaySkillBody shows the SKILL.md body after the skill info when asked
+// to: rendered through the pager with --render, as written with --raw.
+func displaySkillBody(cmd *cobra.Command, info *core.SkillInfo) error {
+	render, _ := cmd.Flags().GetBool("render")
+	raw, _ := cmd.Flags().GetBool("raw")
+	switch {
+	case render && raw:
+		return fmt.Errorf("--render and --raw cannot be used together")
+	case raw:
+		_, err := io.WriteString(os.Stdout, strings.TrimRight(info.Body, "\n")+"\n")
+		return err
+	case render:
+		return ui.Page(os.Stdout, ui.RenderMarkdown(info.Body))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var skillLintCmd = &cobra.Command{
+	Use:   "lint [name]",
+	Short: "Check skill markdown for encoding and line ending problems",
+	Long: `Check the markdown files of skill(s) for problems that break markdown
+tooling and managed-block matching.
+
+If no name is provided, lints all skills in .claude/skills/
+
+Checks:
+  - Content is UTF-8 (not UTF-16 or another encoding)
+  - No UTF-8 byte order mark
+  - LF line endings (no CRLF)
+  - Final newline follows markdown.final_newline (not checked by default)
+
+With --fix, files are rewritten normalized, the same way 'samuel init' and
+'samuel add' write them. Files that are not UTF-8 must be converted by hand.
+
+Examples:
+  samuel skill lint                    # Lint all skills
+  samuel skill lint database-ops       # Lint a specific skill
+  samuel skill lint --fix              # Normalize every skill in place`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSkillLint,
+}
+
+func registerSkillLintCmd() {
+	skillCmd.AddCommand(skillLintCmd)
+	skillLintCmd.Flags().Bool("fix", false, "Rewrite files with the problems fixed")
+}
+
+func runSkillLint(cmd *cobra.Command, args []string) error {
+	fix, _ := cmd.Flags().GetBool("fix")
+
+	config, dir, err := loadProjectConfig(cmd)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	names, err := skillsToLint(dir, args)
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		ui.Info("No skills found in .claude/skills/")
+		return nil
+	}
+
+	remaining := 0
+	for _, name := range names {
+		results, err := core.LintSkillMarkdown(filepath.Join(dir, ".claude", "skills", name), config.FinalNewline(), fix)
+		if err != nil {
+			return err
+		}
+		remaining += reportSkillLint(name, results)
+	}
+
+	ui.Print("")
+	if remaining > 0 {
+		if !fix {
+			ui.Info("Run 'samuel skill lint --fix' to normalize the files")
+		}
+		return fmt.Errorf("%d markdown file(s) need attention", remaining)
+	}
+	ui.Success("Linted %d skill(s): all markdown is normalized", len(names))
+	return nil
+}
+
+// skillsToLint returns the skill directory names named in args, or every
+// installed skill.
+func skillsToLint(dir string, args []string) ([]string, error) {
+	if len(args) == 1 {
+		if _, err := os.Stat(filepath.Join(dir, ".claude", "skills", args[0])); os.IsNotExist(err) {
+			return nil, fmt.Errorf("skill '%s' not found", args[0])
+		}
+		return args, nil
+	}
+
+	skills, err := projectSkillCache(dir).Skills()
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan skills: %w", err)
+	}
+	names := make([]string, 0, len(skills))
+	for _, skill := range skills {
+		names = append(names, skill.DirName)
+	}
+	return names, nil
+}
+
+// reportSkillLint prints the lint results of one skill and returns how
+// many files still have problems.
+func reportSkillLint(name string, results []core.SkillLintResult) int {
+	if len(results) == 0 {
+		ui.SuccessItem(0, "%s: clean", name)
+		return 0
+	}
+
+	remaining := 0
+	for _, r := range results {
+		issues := strings.Join(r.Issues, ", ")
+		if r.Fixed {
+			ui.SuccessItem(0, "%s/%s: fixed (%s)", name, r.Path, issues)
+			continue
+		}
+		remaining++
+		ui.ErrorItem(0, "%s/%s: %s", name, r.Path, issues)
+	}
+	return remaining
+}
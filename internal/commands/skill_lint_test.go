@@ -0,0 +1,48 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestRunSkillLint(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		fix     bool
+		wantErr bool
+	}{
+		{name: "clean", content: validSkillMD("my-skill", "A test skill") + "\n"},
+		{name: "crlf reported", content: "---\r\nname: my-skill\r\ndescription: x\r\n---\r\n", wantErr: true},
+		{name: "crlf fixed", content: "---\r\nname: my-skill\r\ndescription: x\r\n---\r\n", fix: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir, cleanup := setupSkillTestDir(t)
+			defer cleanup()
+			skillsDir := filepath.Join(dir, ".claude", "skills")
+			createSkillDir(t, skillsDir, "my-skill", tt.content)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("fix", tt.fix, "")
+			err := runSkillLint(cmd, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("runSkillLint() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.fix {
+				return
+			}
+			got, err := os.ReadFile(filepath.Join(skillsDir, "my-skill", "SKILL.md"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "---\nname: my-skill\ndescription: x\n---\n"; string(got) != want {
+				t.Errorf("SKILL.md = %q, want %q", got, want)
+			}
+		})
+	}
+}
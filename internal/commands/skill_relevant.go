@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// relevantSampleFiles caps the files printed per skill.
+const relevantSampleFiles = 3
+
+var skillRelevantCmd = &cobra.Command{
+	Use:   "relevant",
+	Short: "List the installed skills relevant to the project or a change",
+	Long: `List the installed skills that apply to this project, or with --changed
+to the files changed since HEAD, so an agent or reviewer knows which to
+consult.
+
+A skill applies to the project when it covers a language or framework
+detected from its manifests and lockfiles (see 'samuel add --auto'). It
+applies to a changed file when it is the guide of the file's language, the
+skill of a detected framework built on that language, or when one of the
+globs in its SKILL.md paths metadata matches the file:
+
+  metadata:
+    paths: migrations/**, *.sql
+
+--base compares against the merge base of a branch instead of HEAD, which
+covers every commit of a feature branch.
+
+Examples:
+  samuel skill relevant
+  samuel skill relevant --changed
+  samuel skill relevant --changed --base main`,
+	Args: cobra.NoArgs,
+	RunE: runSkillRelevant,
+}
+
+func registerSkillRelevantCmd() {
+	skillCmd.AddCommand(skillRelevantCmd)
+	skillRelevantCmd.Flags().Bool("changed", false, "Map the files changed since HEAD (or --base) to skills")
+	skillRelevantCmd.Flags().String("base", "", "Compare against the merge base of this ref instead of HEAD")
+}
+
+func runSkillRelevant(cmd *cobra.Command, args []string) error {
+	changed, _ := cmd.Flags().GetBool("changed")
+	base, _ := cmd.Flags().GetString("base")
+	if base != "" && !changed {
+		return withExitCode(exitInvalidArgs, fmt.Errorf("--base requires --changed"))
+	}
+	dir, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	skills, err := projectSkillCache(dir).Skills()
+	if err != nil {
+		return err
+	}
+	if len(skills) == 0 {
+		return fmt.Errorf("no skills installed in .claude/skills/")
+	}
+	detection := core.DetectProject(dir)
+	if !changed {
+		printProjectSkills(skills, detection)
+		return nil
+	}
+	files, err := changedFiles(dir, base)
+	if err != nil {
+		return err
+	}
+	printChangeSkills(core.RelevantSkills(skills, detection, files), len(files))
+	return nil
+}
+
+// changedFiles lists the files changed in the working tree since HEAD, or
+// since the merge base of base and HEAD.
+func changedFiles(dir, base string) ([]string, error) {
+	from := core.GitHeadSHA(dir)
+	if base != "" {
+		var err error
+		if from, err = core.GitMergeBase(dir, base); err != nil {
+			return nil, err
+		}
+	}
+	changes, err := core.GitFileChangeList(dir, from)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, len(changes))
+	for i, c := range changes {
+		files[i] = c.Path
+	}
+	return files, nil
+}
+
+func printProjectSkills(skills []*core.SkillInfo, detection *core.ProjectDetection) {
+	relevance := core.ProjectSkillRelevance(detection)
+	ui.Section("Skills for this project")
+	found := false
+	for _, s := range skills {
+		if component, ok := relevance[s.Metadata.Name]; ok && len(s.Errors) == 0 {
+			ui.ListItem(1, "%s  (%s)", s.Metadata.Name, component)
+			found = true
+		}
+	}
+	if !found {
+		ui.Dim("  No installed skill covers the detected languages and frameworks")
+	}
+}
+
+func printChangeSkills(matches []core.SkillMatch, fileCount int) {
+	if fileCount == 0 {
+		ui.Info("No changed files")
+		return
+	}
+	if len(matches) == 0 {
+		ui.Info("No installed skill applies to the %d changed file(s)", fileCount)
+		return
+	}
+	ui.Section(fmt.Sprintf("Skills for %d changed file(s)", fileCount))
+	for _, m := range matches {
+		ui.ListItem(1, "%s  (%s)", m.Skill, m.Reason)
+		sample := m.Files
+		if len(sample) > relevantSampleFiles {
+			sample = sample[:relevantSampleFiles]
+		}
+		more := ""
+		if extra := len(m.Files) - len(sample); extra > 0 {
+			more = fmt.Sprintf(" and %d more", extra)
+		}
+		ui.Dim("      %s%s", strings.Join(sample, ", "), more)
+	}
+}
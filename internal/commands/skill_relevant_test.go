@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+func TestChangedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if err := core.GitInit(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.GitSetUserIdentity(dir, "Test", "test@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.GitInitialCommit(dir, "init"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := changedFiles(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(files, []string{"main.go"}) {
+		t.Errorf("changedFiles() = %v, want [main.go]", files)
+	}
+
+	if _, err := changedFiles(dir, "no-such-branch"); err == nil {
+		t.Error("changedFiles() with an unknown base should fail")
+	}
+}
+
+func TestRunSkillRelevant_BaseRequiresChanged(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("changed", false, "")
+	cmd.Flags().String("base", "", "")
+	cmd.Flags().Set("base", "main")
+
+	err := runSkillRelevant(cmd, nil)
+	if ExitCode(err) != exitInvalidArgs {
+		t.Errorf("runSkillRelevant() = %v, want exit code %d", err, exitInvalidArgs)
+	}
+}
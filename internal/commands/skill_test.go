@@ -462,4 +462,28 @@ func TestRunSkillInfo(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 	})
+	t.Run("body_flags", func(t *testing.T) {
+		dir, cleanup := setupSkillTestDir(t)
+		defer cleanup()
+		createSkillDir(t, filepath.Join(dir, ".claude", "skills"), "body-skill", validSkillMD("body-skill", "Skill with a body"))
+
+		for _, flags := range [][]string{{"--render"}, {"--raw"}} {
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("render", false, "")
+			cmd.Flags().Bool("raw", false, "")
+			if err := cmd.Flags().Parse(flags); err != nil {
+				t.Fatal(err)
+			}
+			if err := runSkillInfo(cmd, []string{"body-skill"}); err != nil {
+				t.Errorf("%v: unexpected error: %v", flags, err)
+			}
+		}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("render", true, "")
+		cmd.Flags().Bool("raw", true, "")
+		if err := runSkillInfo(cmd, []string{"body-skill"}); err == nil {
+			t.Error("expected an error for --render with --raw")
+		}
+	})
 }
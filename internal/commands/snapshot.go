@@ -0,0 +1,81 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Check generated artifacts against committed snapshots",
+	Long: `Render every generator from fixed inputs and compare the output with
+the committed golden files. This is a developer command for working on
+Samuel itself: run it from the repository root after changing generation
+code to review exactly what changes in generated output.
+
+Covered generators: the auto loop prompts (prompt.md, discovery, wrap-up,
+milestone section), the CLAUDE.md skills section, the per-folder CLAUDE.md
+and AGENTS.md written by sync, and the skill and test fixture scaffolds.
+
+Differences are printed as line diffs and the command exits with code 5.
+--update rewrites the golden files with the current output. The same
+check runs in 'go test ./internal/core' (TestGeneratorSnapshots).
+
+Examples:
+  samuel snapshot                 # Diff against internal/core/testdata/snapshots
+  samuel snapshot --update        # Accept the current output
+  samuel snapshot --dir /tmp/snap --update`,
+	Args: cobra.NoArgs,
+	RunE: runSnapshot,
+}
+
+func init() {
+	rootCmd.AddCommand(snapshotCmd)
+	snapshotCmd.Flags().String("dir", core.DefaultSnapshotDir, "Snapshot directory, relative to the project directory")
+	snapshotCmd.Flags().Bool("update", false, "Rewrite the snapshots with the current output")
+}
+
+func runSnapshot(cmd *cobra.Command, args []string) error {
+	cwd, err := projectDir(cmd)
+	if err != nil {
+		return err
+	}
+	dir, _ := cmd.Flags().GetString("dir")
+	update, _ := cmd.Flags().GetBool("update")
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(cwd, dir)
+	}
+
+	snaps, err := core.RenderSnapshots()
+	if err != nil {
+		return fmt.Errorf("failed to render snapshots: %w", err)
+	}
+	if update {
+		if err := core.WriteSnapshots(dir, snaps); err != nil {
+			return err
+		}
+		ui.Success("Wrote %d snapshot(s) to %s", len(snaps), dir)
+		return nil
+	}
+
+	diffs, err := core.DiffSnapshots(dir, snaps)
+	if err != nil {
+		return err
+	}
+	if len(diffs) == 0 {
+		ui.Success("All %d snapshot(s) match", len(snaps))
+		return nil
+	}
+	for _, d := range diffs {
+		ui.ErrorItem(0, "%s (%s)", d.Name, d.Status)
+		if d.Diff != "" {
+			fmt.Fprint(cmd.OutOrStdout(), d.Diff)
+		}
+	}
+	ui.Info("Run 'samuel snapshot --update' to accept the current output")
+	return withExitCode(exitAssertionFailed, fmt.Errorf("%d snapshot(s) differ", len(diffs)))
+}
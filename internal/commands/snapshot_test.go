@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newSnapshotTestCmd(dir string, update bool) (*cobra.Command, *bytes.Buffer) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	cmd.Flags().String("target", "", "")
+	cmd.Flags().String("dir", dir, "")
+	cmd.Flags().Bool("update", update, "")
+	return cmd, &out
+}
+
+func TestRunSnapshot(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "snapshots")
+
+	cmd, _ := newSnapshotTestCmd(dir, false)
+	if err := runSnapshot(cmd, nil); ExitCode(err) != exitAssertionFailed {
+		t.Fatalf("runSnapshot() without snapshots = %v, want exit code %d", err, exitAssertionFailed)
+	}
+
+	cmd, _ = newSnapshotTestCmd(dir, true)
+	if err := runSnapshot(cmd, nil); err != nil {
+		t.Fatalf("runSnapshot(--update) error = %v", err)
+	}
+	cmd, _ = newSnapshotTestCmd(dir, false)
+	if err := runSnapshot(cmd, nil); err != nil {
+		t.Fatalf("runSnapshot() after update error = %v", err)
+	}
+
+	prompt := filepath.Join(dir, "auto", "prompt.md")
+	data, err := os.ReadFile(prompt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(prompt, append([]byte("stale line\n"), data...), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd, out := newSnapshotTestCmd(dir, false)
+	if err := runSnapshot(cmd, nil); ExitCode(err) != exitAssertionFailed {
+		t.Fatalf("runSnapshot() with a changed snapshot = %v, want exit code %d", err, exitAssertionFailed)
+	}
+	if !strings.Contains(out.String(), "-stale line") {
+		t.Errorf("diff output = %q, want the removed line", out.String())
+	}
+}
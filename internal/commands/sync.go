@@ -40,10 +40,9 @@ func runSync(cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 	dryRun, _ := cmd.Flags().GetBool("dry-run")
 
-	rootDir := "."
-	absRoot, err := filepath.Abs(rootDir)
+	absRoot, err := projectDir(cmd)
 	if err != nil {
-		return fmt.Errorf("invalid path: %w", err)
+		return err
 	}
 
 	if dryRun {
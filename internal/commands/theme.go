@@ -0,0 +1,52 @@
+package commands
+
+import (
+	"os"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+// themeModTime is the modification time of the config file the current
+// theme was loaded from, so loops only reload it after an edit.
+var themeModTime time.Time
+
+// applyTheme applies the theme of the project cmd operates on.
+func applyTheme(cmd *cobra.Command) {
+	if cwd, err := projectDir(cmd); err == nil {
+		applyProjectTheme(cwd)
+	}
+}
+
+// applyProjectTheme switches output to the theme in dir's samuel.yaml,
+// with SAMUEL_THEME* environment overrides. An invalid theme is reported
+// and the previous one is kept: styling must never stop a command.
+func applyProjectTheme(dir string) {
+	var theme ui.Theme
+	if path := core.ConfigFilePath(dir); path != "" {
+		if info, err := os.Stat(path); err == nil {
+			themeModTime = info.ModTime()
+		}
+		if cfg, err := core.LoadConfigFrom(dir); err == nil && cfg.Theme != nil {
+			theme = *cfg.Theme
+		}
+	}
+	if err := ui.ApplyTheme(ui.ThemeFromEnv(theme)); err != nil {
+		ui.Warn("Ignoring theme: %v", err)
+	}
+}
+
+// reloadThemeIfChanged re-applies the project theme when samuel.yaml was
+// modified since the theme was last loaded, so theme edits take effect
+// between iterations of a running loop.
+func reloadThemeIfChanged(dir string) {
+	path := core.ConfigFilePath(dir)
+	if path == "" {
+		return
+	}
+	if info, err := os.Stat(path); err == nil && !info.ModTime().Equal(themeModTime) {
+		applyProjectTheme(dir)
+	}
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+func TestReloadThemeIfChanged(t *testing.T) {
+	t.Cleanup(func() { _ = ui.ApplyTheme(ui.Theme{}) })
+	dir := t.TempDir()
+	cfg := core.NewConfig("1.0.0")
+	cfg.Theme = &ui.Theme{Icons: ui.IconsASCII}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	applyProjectTheme(dir)
+	if ui.SuccessSymbol != "+" {
+		t.Fatalf("SuccessSymbol = %q, want ascii theme", ui.SuccessSymbol)
+	}
+
+	cfg.Theme = &ui.Theme{Icons: ui.IconsUnicode}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(filepath.Join(dir, core.ConfigFileName), later, later); err != nil {
+		t.Fatal(err)
+	}
+	reloadThemeIfChanged(dir)
+	if ui.SuccessSymbol != "✓" {
+		t.Errorf("SuccessSymbol = %q, want reloaded unicode theme", ui.SuccessSymbol)
+	}
+}
@@ -21,12 +21,16 @@ This command will:
 2. Download the new version
 3. Apply updates while preserving local modifications
 4. Create backups of modified files
+5. Merge changed config defaults, asking about settings you customized
+6. Summarize skill guidance changes in .claude/.update-notes/<version>.md
 
 Examples:
   samuel update              # Update to latest version
   samuel update --check      # Check for updates without applying
   samuel update --diff       # Show what will change
-  samuel update --force      # Overwrite local modifications`,
+  samuel update --force      # Overwrite local modifications
+  samuel update --channel beta           # Try the latest prerelease
+  samuel update --config-strategy mine   # Keep customized settings without prompting`,
 	RunE: runUpdate,
 }
 
@@ -36,6 +40,10 @@ func init() {
 	updateCmd.Flags().Bool("diff", false, "Show what files will change")
 	updateCmd.Flags().BoolP("force", "f", false, "Overwrite local modifications")
 	updateCmd.Flags().String("version", "", "Update to specific version")
+	updateCmd.Flags().String("channel", "", "Release channel to update from: stable or beta (default: config channel, else stable)")
+	updateCmd.Flags().String("config-strategy", core.ConfigStrategyPrompt,
+		"Resolve conflicts between customized settings and changed defaults: prompt, mine, or upstream")
+	addPolicyOverrideFlag(updateCmd)
 }
 
 func runUpdate(cmd *cobra.Command, args []string) error {
@@ -43,8 +51,13 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	showDiff, _ := cmd.Flags().GetBool("diff")
 	force, _ := cmd.Flags().GetBool("force")
 	targetVersion, _ := cmd.Flags().GetString("version")
+	channel, _ := cmd.Flags().GetString("channel")
+	configStrategy, _ := cmd.Flags().GetString("config-strategy")
+	if err := validateConfigStrategy(configStrategy); err != nil {
+		return err
+	}
 
-	config, err := core.LoadConfig()
+	config, cwd, err := loadProjectConfig(cmd)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
@@ -52,122 +65,136 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	cachePath, targetVersion, err := downloadTargetVersion(
-		config.Version, targetVersion, checkOnly, force,
-	)
+	if config.Embedded {
+		// Replace the embedded template's few workflows with the full set
+		// the minimal template installs.
+		config.Installed.Workflows = []string{"all"}
+	}
+	tmpl, targetVersion, err := downloadTargetVersion(config, targetVersion, channel, checkOnly, force)
 	if err != nil {
 		return err
 	}
-	if cachePath == "" {
+	if tmpl == nil {
 		return nil // up-to-date or check-only
 	}
 
-	cwd, err := os.Getwd()
+	if err := mergeUpdateConfig(config, tmpl, configStrategy, showDiff); err != nil {
+		return err
+	}
+	layout, err := pendingLayoutChanges(config, tmpl, targetVersion)
 	if err != nil {
-		return fmt.Errorf("failed to get working directory: %w", err)
+		return err
 	}
 
-	paths := core.GetComponentPaths(
-		config.Installed.Languages,
-		config.Installed.Frameworks,
-		config.Installed.Workflows,
-	)
-	extractor := core.NewExtractor(cachePath, cwd)
-	changes := categorizeFileChanges(paths, cwd, cachePath)
-
 	if showDiff {
-		displayChangeDiff(changes, force)
+		displayLayoutChanges(layout)
+		displayChangeDiff(categorizeFileChanges(updatePaths(config, tmpl), cwd, tmpl.Path), tmpl, force)
 		return nil
 	}
 
-	return applyUpdate(extractor, changes, force, cwd, targetVersion, config)
+	policy, err := checkUpdatePolicy(cmd, cwd, config, tmpl, force)
+	if err != nil {
+		return err
+	}
+	return withProjectLock(cmd, cwd, func() error {
+		return updateProject(cwd, tmpl, config, layout, policy, force, targetVersion)
+	})
 }
 
 // downloadTargetVersion resolves the target version, checks if an update is needed,
-// and downloads it. Returns empty cachePath if no update is needed.
-func downloadTargetVersion(currentVersion, targetVersion string, checkOnly, force bool) (string, string, error) {
+// and downloads it along with any overlay. Returns a nil template if no update is
+// needed. An overlay tracks a branch, so it is refreshed even when the base
+// version is current, as is a project installed from the embedded template.
+// Without --version, the latest release on the channel (--channel, else the
+// configured channel) is the target.
+func downloadTargetVersion(
+	config *core.Config, targetVersion, channel string, checkOnly, force bool,
+) (*core.LayeredTemplate, string, error) {
+	currentVersion, overlay := config.Version, config.Overlay
+	channel, err := core.ResolveChannel(channel, config.Channel)
+	if err != nil {
+		return nil, "", err
+	}
 	downloader, err := core.NewDownloader()
 	if err != nil {
-		return "", "", fmt.Errorf("failed to initialize: %w", err)
+		return nil, "", fmt.Errorf("failed to initialize: %w", err)
 	}
 
 	if targetVersion == "" {
-		spinner := ui.NewSpinner("Checking for updates...")
-		spinner.Start()
-		latest, err := downloader.GetLatestVersion()
-		if err != nil {
-			spinner.Error("Failed to check for updates")
-			return "", "", fmt.Errorf("failed to get latest version: %w", err)
+		if targetVersion, err = latestOnChannel(downloader, currentVersion, channel); err != nil {
+			return nil, "", err
 		}
-		spinner.Stop()
-		targetVersion = latest
 	}
 
-	ui.Bold("Samuel Update")
-	ui.TableRow("Current version", currentVersion)
-	ui.TableRow("Target version", targetVersion)
+	printUpdateHeader(currentVersion, targetVersion, channel)
 
-	if currentVersion == targetVersion && !force {
+	if currentVersion == targetVersion && !force && overlay == nil && !config.Embedded {
 		fmt.Println()
 		ui.Success("Already up to date!")
-		return "", targetVersion, nil
+		return nil, targetVersion, nil
 	}
 
 	if checkOnly {
-		if currentVersion != targetVersion {
+		if currentVersion != targetVersion || config.Embedded {
 			fmt.Println()
 			ui.Success("Update available: %s → %s", currentVersion, targetVersion)
 			ui.Info("Run 'samuel update' to apply")
 		}
-		return "", targetVersion, nil
+		return nil, targetVersion, nil
+	}
+
+	if config.Trust, err = confirmOverlayTrust(config.Trust, overlay); err != nil {
+		return nil, "", err
 	}
 
 	spinner := ui.NewSpinner("Downloading...")
 	spinner.Start()
-	cachePath, err := downloader.DownloadVersion(targetVersion)
+	tmpl, err := downloader.ResolveTemplate(targetVersion, overlay)
 	if err != nil {
 		spinner.Error("Download failed")
-		return "", "", fmt.Errorf("failed to download: %w", err)
+		return nil, "", fmt.Errorf("failed to download: %w", err)
 	}
 	spinner.Success(fmt.Sprintf("Downloaded v%s", targetVersion))
+	reportOverlay(tmpl)
 
-	return cachePath, targetVersion, nil
+	return tmpl, targetVersion, nil
 }
 
-// displayChangeDiff prints the file change summary without applying updates.
-func displayChangeDiff(changes fileChanges, force bool) {
-	fmt.Println()
-	ui.Section("Changes")
-
-	if len(changes.newFiles) > 0 {
-		ui.ListItem(1, "%d new files:", len(changes.newFiles))
-		for _, f := range changes.newFiles {
-			ui.SuccessItem(2, "%s", f)
-		}
-	}
-
-	if len(changes.modifiedFiles) > 0 {
-		ui.ListItem(1, "%d files with local modifications:", len(changes.modifiedFiles))
-		for _, f := range changes.modifiedFiles {
-			ui.WarnItem(2, "%s", f)
-		}
+// printUpdateHeader shows the versions involved in an update; the channel
+// is only shown when it is not stable.
+func printUpdateHeader(currentVersion, targetVersion, channel string) {
+	ui.Bold("Samuel Update")
+	ui.TableRow("Current version", currentVersion)
+	ui.TableRow("Target version", targetVersion)
+	if channel != core.ChannelStable {
+		ui.TableRow("Channel", channel)
 	}
+}
 
-	if len(changes.unchangedFiles) > 0 {
-		ui.ListItem(1, "%d files to update:", len(changes.unchangedFiles))
+// latestOnChannel returns the latest version on channel. A current version
+// ahead of it, e.g. a beta installed before switching back to stable, is
+// kept rather than downgraded.
+func latestOnChannel(downloader *core.Downloader, currentVersion, channel string) (string, error) {
+	spinner := ui.NewSpinner("Checking for updates...")
+	spinner.Start()
+	latest, err := downloader.GetLatestVersionForChannel(channel)
+	if err != nil {
+		spinner.Error("Failed to check for updates")
+		return "", fmt.Errorf("failed to get latest version: %w", err)
 	}
-
-	fmt.Println()
-	if !force {
-		ui.Info("Modified files will be preserved. Use --force to overwrite.")
+	spinner.Stop()
+	if cmp, err := core.CompareVersions(currentVersion, latest); err == nil && cmp > 0 {
+		return currentVersion, nil
 	}
+	return latest, nil
 }
 
 // applyUpdate backs up modified files, extracts updates, and saves the config.
 func applyUpdate(
-	extractor *core.Extractor, changes fileChanges,
+	extractor *core.Extractor, tmpl *core.LayeredTemplate, changes fileChanges,
 	force bool, cwd, targetVersion string, config *core.Config,
 ) error {
+	before := core.SnapshotSkills(cwd)
 	var backupDir string
 	if len(changes.modifiedFiles) > 0 && !force {
 		var err error
@@ -190,9 +217,21 @@ func applyUpdate(
 	}
 
 	ui.Success("Updated %d files", len(result.FilesCreated))
-	reportUpdateResults(changes, force, backupDir)
+	reportIgnoredFiles(result)
+	reportUpdateResults(changes, tmpl, force, backupDir)
+	reportExtractFailures(result)
+	if err := result.Err(); err != nil {
+		// Leave the version unchanged so a rerun retries the failed files.
+		return fmt.Errorf("update incomplete: %w; samuel.yaml still records v%s", err, config.Version)
+	}
+	if backupDir != "" {
+		enforceRetention(cwd, core.GCBackups)
+	}
+	writeSkillUpdateNotes(cwd, config.Version, targetVersion, before)
+	refreshSkillIndex(cwd)
 
 	config.Version = targetVersion
+	config.Embedded = false
 	if err := config.Save(cwd); err != nil {
 		return fmt.Errorf("failed to update config: %w", err)
 	}
@@ -223,30 +262,6 @@ func backupModifiedFiles(
 	return backupDir, nil
 }
 
-// reportUpdateResults displays the update summary and preserved file instructions.
-func reportUpdateResults(changes fileChanges, force bool, backupDir string) {
-	if len(changes.newFiles) > 0 {
-		ui.Success("Added %d new files", len(changes.newFiles))
-	}
-
-	if len(changes.modifiedFiles) > 0 && !force {
-		ui.Warn("Preserved %d locally modified files", len(changes.modifiedFiles))
-		if backupDir != "" {
-			ui.Info("Backups saved to: %s", backupDir)
-		}
-	}
-
-	if len(changes.modifiedFiles) > 0 && !force {
-		fmt.Println()
-		ui.Bold("Modified files preserved:")
-		for _, f := range changes.modifiedFiles {
-			ui.WarnItem(1, "%s", f)
-		}
-		ui.Info("\nTo see changes: diff -u %s/<file> <file>", backupDir)
-		ui.Info("To accept new version: cp %s/<file> <file>", backupDir)
-	}
-}
-
 // fileChanges holds the categorized file lists from comparing local vs cached files.
 type fileChanges struct {
 	newFiles       []string
@@ -284,7 +299,7 @@ func categorizeFileChanges(paths []string, cwd, cachePath string) fileChanges {
 			continue
 		}
 
-		if string(localContent) != string(cacheContent) {
+		if !core.SameContent(path, localContent, cacheContent) {
 			changes.modifiedFiles = append(changes.modifiedFiles, path)
 		} else {
 			changes.unchangedFiles = append(changes.unchangedFiles, path)
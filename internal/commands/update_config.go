@@ -0,0 +1,105 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// Prompt hooks, replaced in tests to simulate user answers.
+var (
+	configConflictSelect = ui.Select
+	configConflictInput  = ui.Input
+)
+
+// Choices offered for each config conflict.
+const (
+	configChoiceMine     = "mine"
+	configChoiceUpstream = "upstream"
+	configChoiceEdit     = "edit"
+)
+
+func validateConfigStrategy(strategy string) error {
+	if !slices.Contains(core.GetSupportedConfigStrategies(), strategy) {
+		return fmt.Errorf("unsupported config strategy: %s (supported: %v)",
+			strategy, core.GetSupportedConfigStrategies())
+	}
+	return nil
+}
+
+// mergeUpdateConfig merges config defaults that changed between the
+// installed release and the update into config. Settings still at the old
+// default take the new one; customized settings are resolved by strategy.
+// With preview, conflicts are listed but left unresolved.
+func mergeUpdateConfig(config *core.Config, tmpl *core.LayeredTemplate, strategy string, preview bool) error {
+	base, err := core.LoadReleaseConfigDefaults(config.Version)
+	if err != nil {
+		ui.Warn("Could not read v%s config defaults, using built-in defaults: %v", config.Version, err)
+		base = core.NewConfig("")
+	}
+	upstream, err := core.LoadConfigDefaults(tmpl.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read config defaults: %w", err)
+	}
+
+	merge := core.MergeConfigDefaults(config, base, upstream)
+	for _, key := range merge.Applied {
+		value, _ := config.GetValue(key)
+		if preview {
+			ui.Info("Config %s will take the new default: %v", key, value)
+			continue
+		}
+		ui.Success("Config %s updated to the new default: %v", key, value)
+	}
+	for _, conflict := range merge.Conflicts {
+		displayConfigConflict(conflict)
+		if preview {
+			continue
+		}
+		if err := resolveConfigConflict(config, conflict, strategy); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func displayConfigConflict(c core.ConfigConflict) {
+	fmt.Println()
+	ui.Warn("Config conflict: %s was customized and its default changed", c.Key)
+	ui.TableRow("  Yours", c.Mine)
+	ui.TableRow("  Old default", c.Base)
+	ui.TableRow("  New default", c.Upstream)
+}
+
+// resolveConfigConflict applies the strategy to one conflict, asking the
+// user to keep their value, take the new default, or edit it when the
+// strategy is prompt.
+func resolveConfigConflict(config *core.Config, c core.ConfigConflict, strategy string) error {
+	choice := strategy
+	if strategy == core.ConfigStrategyPrompt {
+		selected, err := configConflictSelect("Resolve "+c.Key, []ui.SelectOption{
+			{Name: "Keep mine", Description: c.Mine, Value: configChoiceMine},
+			{Name: "Take upstream", Description: c.Upstream, Value: configChoiceUpstream},
+			{Name: "Edit", Description: "Enter a new value", Value: configChoiceEdit},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to resolve %s: %w (use --config-strategy mine or upstream when not interactive)", c.Key, err)
+		}
+		choice = selected.Value
+	}
+
+	switch choice {
+	case configChoiceUpstream:
+		return config.SetMergeValue(c.Key, c.Upstream)
+	case configChoiceEdit:
+		value, err := configConflictInput(c.Key+" (comma-separate lists)", c.Mine, nil)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", c.Key, err)
+		}
+		return config.SetMergeValue(c.Key, value)
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+func TestResolveConfigConflict(t *testing.T) {
+	conflict := core.ConfigConflict{
+		Key:      "installed.workflows",
+		Base:     "create-prd",
+		Mine:     "security-audit",
+		Upstream: "create-prd, code-review",
+	}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		selected  string
+		selectErr error
+		input     string
+		want      []string
+		wantErr   bool
+	}{
+		{name: "strategy_mine", strategy: core.ConfigStrategyMine, want: []string{"security-audit"}},
+		{name: "strategy_upstream", strategy: core.ConfigStrategyUpstream, want: []string{"create-prd", "code-review"}},
+		{name: "prompt_keep_mine", strategy: core.ConfigStrategyPrompt, selected: configChoiceMine, want: []string{"security-audit"}},
+		{name: "prompt_take_upstream", strategy: core.ConfigStrategyPrompt, selected: configChoiceUpstream, want: []string{"create-prd", "code-review"}},
+		{name: "prompt_edit", strategy: core.ConfigStrategyPrompt, selected: configChoiceEdit, input: "security-audit, code-review", want: []string{"security-audit", "code-review"}},
+		{name: "prompt_unavailable", strategy: core.ConfigStrategyPrompt, selectErr: errors.New("not a terminal"), want: []string{"security-audit"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origSelect, origInput := configConflictSelect, configConflictInput
+			defer func() { configConflictSelect, configConflictInput = origSelect, origInput }()
+			configConflictSelect = func(string, []ui.SelectOption) (ui.SelectOption, error) {
+				return ui.SelectOption{Value: tt.selected}, tt.selectErr
+			}
+			configConflictInput = func(string, string, func(string) error) (string, error) {
+				return tt.input, nil
+			}
+
+			config := core.NewConfig("1.0.0")
+			config.Installed.Workflows = []string{"security-audit"}
+			err := resolveConfigConflict(config, conflict, tt.strategy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !slices.Equal(config.Installed.Workflows, tt.want) {
+				t.Errorf("workflows = %v, want %v", config.Installed.Workflows, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateConfigStrategy(t *testing.T) {
+	for _, s := range core.GetSupportedConfigStrategies() {
+		if err := validateConfigStrategy(s); err != nil {
+			t.Errorf("validateConfigStrategy(%q) = %v", s, err)
+		}
+	}
+	if err := validateConfigStrategy("theirs"); err == nil {
+		t.Error("expected error for unknown strategy")
+	}
+}
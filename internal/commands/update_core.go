@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+	"github.com/spf13/cobra"
+)
+
+var updateCoreCmd = &cobra.Command{
+	Use:   "core",
+	Short: "Refresh only the core instruction files",
+	Long: `Refresh CLAUDE.md, AGENTS.md and .claude/skills/README.md from the
+latest release without touching any skill directories.
+
+Framework sections of CLAUDE.md and AGENTS.md are wrapped in managed blocks
+(<!-- SAMUEL:BEGIN name --> ... <!-- SAMUEL:END name -->). Only the content
+of those blocks is replaced; your own sections and the generated skills list
+are kept. Files without managed blocks are skipped unless --force is given,
+in which case they are replaced wholesale (keeping the skills list).
+
+Changed files are backed up to .samuel-backup-<timestamp>/ first. The
+installed version in samuel.yaml is not changed, since skills stay at the
+version they were installed from; run 'samuel update' for a full update.
+
+Examples:
+  samuel update core                 # Merge the latest core instructions
+  samuel update core --diff          # Show which blocks would change
+  samuel update core --channel beta  # Take core instructions from the latest prerelease
+  samuel update core --force         # Replace files that have no managed blocks`,
+	RunE: runUpdateCore,
+}
+
+func init() {
+	updateCmd.AddCommand(updateCoreCmd)
+	updateCoreCmd.Flags().Bool("diff", false, "Show what would change without writing")
+	updateCoreCmd.Flags().BoolP("force", "f", false, "Replace core files that have no managed blocks")
+	updateCoreCmd.Flags().String("version", "", "Take core files from a specific version")
+	updateCoreCmd.Flags().String("channel", "", "Release channel to update from: stable or beta (default: config channel, else stable)")
+}
+
+func runUpdateCore(cmd *cobra.Command, args []string) error {
+	showDiff, _ := cmd.Flags().GetBool("diff")
+	force, _ := cmd.Flags().GetBool("force")
+	targetVersion, _ := cmd.Flags().GetString("version")
+	channel, _ := cmd.Flags().GetString("channel")
+
+	config, cwd, err := loadProjectConfig(cmd)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no Samuel installation found. Run 'samuel init' first")
+		}
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Core files may lag behind the installed version, so always fetch.
+	tmpl, _, err := downloadTargetVersion(config, targetVersion, channel, false, true)
+	if err != nil {
+		return err
+	}
+
+	warnUntranslated(tmpl.Path, config.Language)
+	plan, err := core.PlanCoreUpdate(cwd, tmpl.Path, config.Language, force)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	displayCorePlan(plan)
+	if showDiff {
+		return nil
+	}
+
+	return withProjectLock(cmd, cwd, func() error {
+		return applyCoreUpdate(core.NewExtractor(tmpl.Path, cwd), plan, cwd)
+	})
+}
+
+// applyCoreUpdate backs up the core files that will change and writes the
+// planned content.
+func applyCoreUpdate(extractor *core.Extractor, plan []core.CoreFileUpdate, cwd string) error {
+	var changed []string
+	for _, u := range plan {
+		if u.Action == core.CoreActionMerge || u.Action == core.CoreActionReplace {
+			changed = append(changed, u.Path)
+		}
+	}
+	if len(changed) > 0 {
+		if _, err := backupModifiedFiles(extractor, changed, cwd); err != nil {
+			return err
+		}
+	}
+
+	if err := core.ApplyCoreUpdate(cwd, plan); err != nil {
+		return fmt.Errorf("failed to apply core update: %w", err)
+	}
+	ui.Success("Core instructions updated")
+	return nil
+}
+
+// displayCorePlan lists the planned action for each core file.
+func displayCorePlan(plan []core.CoreFileUpdate) {
+	ui.Section("Core files")
+	for _, u := range plan {
+		switch u.Action {
+		case core.CoreActionCreate:
+			ui.SuccessItem(1, "%s (new)", u.Path)
+		case core.CoreActionMerge:
+			ui.SuccessItem(1, "%s: %s", u.Path, strings.Join(u.Blocks, ", "))
+		case core.CoreActionReplace:
+			ui.WarnItem(1, "%s (replaced)", u.Path)
+		case core.CoreActionSkip:
+			ui.WarnItem(1, "%s skipped: %s", u.Path, u.Reason)
+		default:
+			ui.Dim("  %s is up to date", u.Path)
+		}
+	}
+}
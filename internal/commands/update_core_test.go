@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+)
+
+func TestRunUpdateCore_NoConfig(t *testing.T) {
+	dir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(oldDir)
+
+	err := updateCoreCmd.RunE(updateCoreCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "no Samuel installation found") {
+		t.Errorf("expected 'no Samuel installation found', got: %v", err)
+	}
+}
+
+func TestApplyCoreUpdate_BacksUpChangedFiles(t *testing.T) {
+	tmplDir, cwd := t.TempDir(), t.TempDir()
+	block := func(body string) string {
+		return "# custom\n<!-- SAMUEL:BEGIN rules -->\n" + body + "\n<!-- SAMUEL:END rules -->\n"
+	}
+	files := map[string]string{
+		filepath.Join(tmplDir, core.TemplatePrefix, "CLAUDE.md"): block("new"),
+		filepath.Join(cwd, "CLAUDE.md"):                          block("old"),
+	}
+	for path, content := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	plan, err := core.PlanCoreUpdate(cwd, tmplDir, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := applyCoreUpdate(core.NewExtractor(tmplDir, cwd), plan, cwd); err != nil {
+		t.Fatalf("applyCoreUpdate() error: %v", err)
+	}
+
+	got, _ := os.ReadFile(filepath.Join(cwd, "CLAUDE.md"))
+	if string(got) != block("new") {
+		t.Errorf("CLAUDE.md = %q, want merged block", got)
+	}
+	backups, _ := filepath.Glob(filepath.Join(cwd, ".samuel-backup-*", "CLAUDE.md"))
+	if len(backups) != 1 {
+		t.Fatalf("expected one backup of CLAUDE.md, got %v", backups)
+	}
+	old, _ := os.ReadFile(backups[0])
+	if string(old) != block("old") {
+		t.Errorf("backup = %q, want original content", old)
+	}
+}
@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// updateProject applies the layout changes, then updates the installed
+// files. Layout changes go first so files are compared at their new paths.
+// Skills banned by the organization policy are left out.
+func updateProject(
+	cwd string, tmpl *core.LayeredTemplate, config *core.Config,
+	layout []core.LayoutChange, policy installPolicy, force bool, targetVersion string,
+) error {
+	moved, err := applyLayoutChanges(cwd, config, layout)
+	if err != nil {
+		return err
+	}
+	extractor := core.NewExtractor(tmpl.Path, cwd)
+	extractor.SetFinalNewline(config.FinalNewline())
+	extractor.SetLanguage(config.Language)
+	changes := categorizeFileChanges(policy.filter(updatePaths(config, tmpl)), cwd, tmpl.Path)
+	if err := applyUpdate(extractor, tmpl, changes, force, cwd, targetVersion, config); err != nil {
+		return err
+	}
+	if moved {
+		regenerateLayoutDocs(cwd)
+	}
+	return nil
+}
+
+// updatePaths returns the template files of the installed components.
+func updatePaths(config *core.Config, tmpl *core.LayeredTemplate) []string {
+	return core.MergeOverlayPaths(core.GetComponentPaths(
+		config.Installed.Languages, config.Installed.Frameworks, config.Installed.Workflows,
+	), tmpl)
+}
+
+// pendingLayoutChanges returns the layout changes of the releases between
+// the installed version and targetVersion.
+func pendingLayoutChanges(config *core.Config, tmpl *core.LayeredTemplate, targetVersion string) ([]core.LayoutChange, error) {
+	manifest, err := core.LoadLayoutManifest(tmpl.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout changes: %w", err)
+	}
+	return manifest.Pending(config.Version, targetVersion), nil
+}
+
+// displayLayoutChanges lists the layout changes an update will apply.
+func displayLayoutChanges(changes []core.LayoutChange) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Println()
+	ui.Section("Layout changes")
+	for _, change := range changes {
+		ui.ListItem(1, "v%s: %s", change.Version, change.Description)
+		for _, mv := range change.Moves {
+			ui.ListItem(2, "move %s → %s", mv.From, mv.To)
+		}
+		for _, r := range change.Renames {
+			ui.ListItem(2, "rename %s %s → %s", r.Kind, r.From, r.To)
+		}
+	}
+}
+
+// applyLayoutChanges applies the layout changes to the project and reports
+// what changed. It returns whether any file moved, in which case the
+// generated docs need regenerating once the update is extracted.
+func applyLayoutChanges(cwd string, config *core.Config, changes []core.LayoutChange) (bool, error) {
+	if len(changes) == 0 {
+		return false, nil
+	}
+	result, err := core.ApplyLayoutChanges(cwd, config, changes)
+	if err != nil {
+		return false, fmt.Errorf("failed to apply layout changes: %w", err)
+	}
+	for _, mv := range result.Moved {
+		ui.Success("Moved %s → %s", mv.From, mv.To)
+	}
+	for _, r := range result.Renamed {
+		ui.Success("Renamed %s %s → %s in samuel.yaml", r.Kind, r.From, r.To)
+	}
+	for _, doc := range result.Rewritten {
+		ui.Success("Updated moved paths in %s", doc)
+	}
+	for _, path := range result.Conflicts {
+		ui.Warn("Left %s in place: its new location already has a different file", path)
+	}
+	return len(result.Moved) > 0, nil
+}
+
+// regenerateLayoutDocs rebuilds the skills section of CLAUDE.md and
+// AGENTS.md after skills moved.
+func regenerateLayoutDocs(cwd string) {
+	if _, err := os.Stat(filepath.Join(cwd, "CLAUDE.md")); err != nil {
+		return
+	}
+	updateSkillsAndAgentsMD(cwd, projectSkillCache(cwd), false)
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// updateNoticeSkipped lists commands that report versions themselves, or
+// for which a notice would be noise.
+var updateNoticeSkipped = []string{"update", "version", "doctor", "init", "completion", "help", "__complete"}
+
+// latestTemplateVersion looks up the newest template version on a
+// channel. Tests replace it to avoid the network.
+var latestTemplateVersion = func(channel string) (string, error) {
+	downloader, err := core.NewDownloader()
+	if err != nil {
+		return "", err
+	}
+	return downloader.GetLatestVersionForChannel(channel)
+}
+
+// updateNotifier carries the update check from the pre-run hook, where it
+// starts, to the post-run hook, where the notice is printed.
+type updateNotifier struct {
+	installed string
+	channel   string
+	cached    *core.UpdateCheck
+	fresh     chan *core.UpdateCheck
+}
+
+// pendingUpdateNotice is the notifier of the running command, if any.
+var pendingUpdateNotice *updateNotifier
+
+// startUpdateCheck begins a background lookup of the latest template
+// version when the cached result is more than a day old. It never blocks
+// and never fails the command.
+func startUpdateCheck(cmd *cobra.Command) {
+	pendingUpdateNotice = nil
+	if slices.Contains(updateNoticeSkipped, cmd.Name()) || core.UpdateChecksDisabled() {
+		return
+	}
+	config, _, err := loadProjectConfig(cmd)
+	if err != nil || !config.UpdateChecksEnabled() {
+		return
+	}
+	path, err := core.GetUpdateCheckPath()
+	if err != nil {
+		return
+	}
+	channel, err := core.ResolveChannel("", config.Channel)
+	if err != nil {
+		return
+	}
+
+	n := &updateNotifier{installed: config.Version, channel: channel, cached: core.LoadUpdateCheck(path)}
+	if n.cached.Due(channel, time.Now()) {
+		n.fresh = make(chan *core.UpdateCheck, 1)
+		go func() {
+			check := &core.UpdateCheck{CheckedAt: time.Now().UTC(), Channel: channel}
+			if latest, err := latestTemplateVersion(channel); err == nil {
+				check.Latest = latest
+			} else if n.cached != nil && n.cached.Channel == channel {
+				// A failed lookup keeps the last known version; saving the
+				// attempt time still defers the retry by the usual interval.
+				check.Latest = n.cached.Latest
+			}
+			_ = core.SaveUpdateCheck(path, check)
+			n.fresh <- check
+		}()
+	}
+	pendingUpdateNotice = n
+}
+
+// printUpdateNotice is the root post-run hook: after the command's own
+// output, it prints a one-line notice to stderr when a newer template
+// version is known. A lookup still in flight is not waited for; its
+// result is cached for the next command.
+func printUpdateNotice(cmd *cobra.Command, args []string) {
+	n := pendingUpdateNotice
+	if n == nil {
+		return
+	}
+	check := n.cached
+	select {
+	case fresh := <-n.fresh:
+		check = fresh
+	default:
+	}
+	if check == nil || check.Channel != n.channel {
+		return
+	}
+	if notice := core.UpdateNotice(n.installed, check.Latest); notice != "" {
+		fmt.Fprintf(cmd.ErrOrStderr(), "\n%s\n", notice)
+	}
+}
@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/spf13/cobra"
+)
+
+// setupUpdateNoticeTest isolates the update check cache and environment,
+// and stubs the latest version lookup.
+func setupUpdateNoticeTest(t *testing.T, latest string) *int {
+	t.Helper()
+	_, cleanup := setupSkillTestDir(t)
+	t.Cleanup(cleanup)
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range []string{core.NoUpdateCheckEnv, "CI", "GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "CIRCLECI", "JENKINS_URL", "TF_BUILD"} {
+		t.Setenv(name, "")
+	}
+
+	lookups := 0
+	orig := latestTemplateVersion
+	latestTemplateVersion = func(string) (string, error) {
+		lookups++
+		return latest, nil
+	}
+	t.Cleanup(func() {
+		latestTemplateVersion = orig
+		pendingUpdateNotice = nil
+	})
+	return &lookups
+}
+
+func runWithUpdateNotice(name string) string {
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{Use: name}
+	cmd.SetErr(&stderr)
+	startUpdateCheck(cmd)
+	if n := pendingUpdateNotice; n != nil && n.fresh != nil {
+		// Let the background lookup finish so the result is deterministic.
+		n.cached = <-n.fresh
+	}
+	printUpdateNotice(cmd, nil)
+	return stderr.String()
+}
+
+func TestUpdateNotice_FetchesAndCaches(t *testing.T) {
+	lookups := setupUpdateNoticeTest(t, "1.2.0")
+
+	if out := runWithUpdateNotice("list"); !strings.Contains(out, "Template 1.2.0 is available") {
+		t.Errorf("first run stderr = %q, want update notice", out)
+	}
+	if out := runWithUpdateNotice("list"); !strings.Contains(out, "1.2.0") {
+		t.Errorf("second run stderr = %q, want cached update notice", out)
+	}
+	if *lookups != 1 {
+		t.Errorf("lookups = %d, want 1 (second run should use the cache)", *lookups)
+	}
+}
+
+func TestUpdateNotice_FailedLookupIsCached(t *testing.T) {
+	setupUpdateNoticeTest(t, "")
+	lookups := 0
+	latestTemplateVersion = func(string) (string, error) {
+		lookups++
+		return "", errors.New("network unreachable")
+	}
+
+	for i := 0; i < 2; i++ {
+		if out := runWithUpdateNotice("list"); out != "" {
+			t.Errorf("run %d stderr = %q, want no notice", i+1, out)
+		}
+	}
+	if lookups != 1 {
+		t.Errorf("lookups = %d, want 1 (a failed lookup should wait for the interval)", lookups)
+	}
+}
+
+func TestUpdateNotice_Suppressed(t *testing.T) {
+	tests := []struct {
+		name    string
+		command string
+		latest  string
+		env     string
+	}{
+		{"up to date", "list", "1.0.0", ""},
+		{"skipped command", "update", "1.2.0", ""},
+		{"disabled by env", "list", "1.2.0", core.NoUpdateCheckEnv},
+		{"ci", "list", "1.2.0", "CI"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupUpdateNoticeTest(t, tt.latest)
+			if tt.env != "" {
+				t.Setenv(tt.env, "1")
+			}
+			if out := runWithUpdateNotice(tt.command); out != "" {
+				t.Errorf("stderr = %q, want no notice", out)
+			}
+		})
+	}
+}
+
+func TestUpdateNotice_DisabledByConfig(t *testing.T) {
+	lookups := setupUpdateNoticeTest(t, "1.2.0")
+	config, _ := core.LoadConfig()
+	disabled := false
+	config.UpdateCheck = &disabled
+	if err := config.Save("."); err != nil {
+		t.Fatal(err)
+	}
+
+	if out := runWithUpdateNotice("list"); out != "" {
+		t.Errorf("stderr = %q, want no notice", out)
+	}
+	if *lookups != 0 {
+		t.Errorf("lookups = %d, want 0", *lookups)
+	}
+}
@@ -0,0 +1,118 @@
+package commands
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/core"
+	"github.com/ar4mirez/samuel/internal/ui"
+)
+
+// displayChangeDiff prints the file change summary without applying updates.
+func displayChangeDiff(changes fileChanges, tmpl *core.LayeredTemplate, force bool) {
+	fmt.Println()
+	ui.Section("Changes")
+
+	if len(changes.newFiles) > 0 {
+		ui.ListItem(1, "%d new files:", len(changes.newFiles))
+		for _, f := range changes.newFiles {
+			ui.SuccessItem(2, "%s", withLayer(tmpl, f))
+		}
+	}
+
+	if len(changes.modifiedFiles) > 0 {
+		ui.ListItem(1, "%d files with local modifications:", len(changes.modifiedFiles))
+		for _, f := range changes.modifiedFiles {
+			ui.WarnItem(2, "%s", withLayer(tmpl, f))
+		}
+	}
+
+	if len(changes.unchangedFiles) > 0 {
+		ui.ListItem(1, "%d files to update:", len(changes.unchangedFiles))
+	}
+
+	fmt.Println()
+	if !force {
+		ui.Info("Modified files will be preserved. Use --force to overwrite.")
+	}
+}
+
+// reportUpdateResults displays the update summary and preserved file instructions.
+func reportUpdateResults(changes fileChanges, tmpl *core.LayeredTemplate, force bool, backupDir string) {
+	if len(changes.newFiles) > 0 {
+		ui.Success("Added %d new files", len(changes.newFiles))
+	}
+
+	if len(changes.modifiedFiles) > 0 && !force {
+		ui.Warn("Preserved %d locally modified files", len(changes.modifiedFiles))
+		if backupDir != "" {
+			ui.Info("Backups saved to: %s", backupDir)
+		}
+	}
+
+	if len(changes.modifiedFiles) > 0 && !force {
+		fmt.Println()
+		ui.Bold("Modified files preserved:")
+		for _, f := range changes.modifiedFiles {
+			ui.WarnItem(1, "%s", withLayer(tmpl, f))
+		}
+		ui.Info("\nTo see changes: diff -u %s/<file> <file>", backupDir)
+		ui.Info("To accept new version: cp %s/<file> <file>", backupDir)
+	}
+}
+
+// maxListedIgnoredFiles caps how many gitignored files are listed by name.
+const maxListedIgnoredFiles = 10
+
+// reportIgnoredFiles warns about template files that were not written
+// because the project's .gitignore ignores their destination.
+func reportIgnoredFiles(result *core.ExtractResult) {
+	if len(result.FilesIgnored) == 0 {
+		return
+	}
+	ui.Warn("Skipped %d files ignored by .gitignore", len(result.FilesIgnored))
+	for i, f := range result.FilesIgnored {
+		if i == maxListedIgnoredFiles {
+			ui.WarnItem(1, "... and %d more", len(result.FilesIgnored)-i)
+			break
+		}
+		ui.WarnItem(1, "%s", f)
+	}
+}
+
+// reportExtractFailures summarizes the paths an install could not write,
+// grouped by cause, so a partial install is not mistaken for a complete one.
+func reportExtractFailures(result *core.ExtractResult) {
+	if !result.Failed() {
+		return
+	}
+	ui.Error("Failed to install %d of %d files", len(result.Errors), result.Attempted())
+	groups := result.ErrorsByKind()
+	for _, kind := range core.GetExtractErrorKinds() {
+		if len(groups[kind]) == 0 {
+			continue
+		}
+		ui.ListItem(1, "%s (%d)", kind, len(groups[kind]))
+		for _, e := range groups[kind] {
+			ui.ErrorItem(2, "%s: %v", e.Path, e.Err)
+		}
+	}
+}
+
+// writeSkillUpdateNotes writes a per-skill summary of the guidance changes
+// made by an update, so they can be reviewed like code. Failures only warn.
+func writeSkillUpdateNotes(cwd, fromVersion, toVersion string, before core.SkillSnapshot) {
+	diffs := core.DiffSkillSnapshots(before, core.SnapshotSkills(cwd))
+	if len(diffs) == 0 {
+		return
+	}
+	notes := core.FormatUpdateNotes(fromVersion, toVersion, diffs, time.Now())
+	path, err := core.WriteUpdateNotes(cwd, toVersion, notes)
+	if err != nil {
+		ui.Warn("Could not write skill change notes: %v", err)
+		return
+	}
+	rel, _ := filepath.Rel(cwd, path)
+	ui.Success("Summarized changes to %d skills in %s", len(diffs), filepath.ToSlash(rel))
+}
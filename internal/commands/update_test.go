@@ -6,6 +6,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ar4mirez/samuel/internal/core"
 	"github.com/spf13/cobra"
 )
 
@@ -295,6 +296,7 @@ func newUpdateCmd() *cobra.Command {
 	cmd.Flags().Bool("diff", false, "Show what files will change")
 	cmd.Flags().BoolP("force", "f", false, "Overwrite local modifications")
 	cmd.Flags().String("version", "", "Update to specific version")
+	cmd.Flags().String("config-strategy", core.ConfigStrategyPrompt, "Config conflict strategy")
 	return cmd
 }
 
@@ -340,3 +342,44 @@ func TestRunUpdate(t *testing.T) {
 		}
 	})
 }
+
+func TestWriteSkillUpdateNotes(t *testing.T) {
+	dir := t.TempDir()
+	skillPath := filepath.Join(dir, ".claude", "skills", "go-guide", "SKILL.md")
+	if err := os.MkdirAll(filepath.Dir(skillPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(skillPath, []byte("# Go\n\n## Testing\n\nold\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	before := core.SnapshotSkills(dir)
+
+	writeSkillUpdateNotes(dir, "1.0.0", "1.1.0", before)
+	notesPath := core.GetUpdateNotesPath(dir, "1.1.0")
+	if _, err := os.Stat(notesPath); !os.IsNotExist(err) {
+		t.Fatalf("notes should not be written when no skill changed, stat err = %v", err)
+	}
+
+	if err := os.WriteFile(skillPath, []byte("# Go\n\n## Testing\n\nnew\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeSkillUpdateNotes(dir, "1.0.0", "1.1.0", before)
+	data, err := os.ReadFile(notesPath)
+	if err != nil {
+		t.Fatalf("notes not written: %v", err)
+	}
+	if !strings.Contains(string(data), "- Sections changed: Go > Testing") {
+		t.Errorf("notes missing changed section:\n%s", data)
+	}
+}
+
+func TestRunUpdate_InvalidConfigStrategy(t *testing.T) {
+	cmd := newUpdateCmd()
+	if err := cmd.Flags().Set("config-strategy", "theirs"); err != nil {
+		t.Fatal(err)
+	}
+	err := cmd.RunE(cmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "unsupported config strategy") {
+		t.Errorf("expected unsupported config strategy error, got: %v", err)
+	}
+}
@@ -33,9 +33,10 @@ func runVersion(cmd *cobra.Command, args []string) error {
 	ui.TableRow("Version", Version)
 	ui.TableRow("Commit", Commit)
 	ui.TableRow("Built", BuildDate)
+	printBinaryInfo(cmd)
 
 	// Try to load local config for framework version
-	config, err := core.LoadConfig()
+	config, _, err := loadProjectConfig(cmd)
 	if err == nil {
 		fmt.Println()
 		ui.Bold("Installed Framework")
@@ -52,7 +53,7 @@ func runVersion(cmd *cobra.Command, args []string) error {
 		ui.Warn("Could not load framework config: %v", err)
 	} else {
 		fmt.Println()
-		ui.Dim("No Samuel framework installed in current directory")
+		ui.Dim("No Samuel framework installed in project directory")
 	}
 
 	// Check for updates if requested
@@ -0,0 +1,208 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// CompactAgentsFile is a condensed digest of the installed skills for AI
+// tools with small context windows, regenerated alongside AGENTS.md when
+// skills.compact is set.
+const CompactAgentsFile = "AGENTS-COMPACT.md"
+
+// DefaultCompactBudget is the digest's token budget when
+// skills.compact_budget is unset.
+const DefaultCompactBudget = 2000
+
+// minCompactBudget leaves room for the digest header and a few skill names.
+const minCompactBudget = 200
+
+// compactGuardrails is how many guardrail headings a skill keeps once the
+// full digest is over budget.
+const compactGuardrails = 3
+
+const compactHeader = "# Skills Digest\n\n" +
+	"<!-- Generated by samuel from the installed skills; changes are overwritten. -->\n\n" +
+	"A condensed AGENTS.md for tools with small context windows. " +
+	"Before a task a skill covers, read `.claude/skills/<skill-name>/SKILL.md`.\n\n"
+
+// Digest detail levels, tried in order until the digest fits its budget.
+const (
+	digestFull  = iota // full description and every guardrail heading
+	digestShort        // first sentence and the first few guardrails
+	digestBrief        // first sentence only
+	digestNames        // skill names only
+)
+
+// CompactDigestEnabled reports whether AGENTS-COMPACT.md is generated.
+func (c *Config) CompactDigestEnabled() bool {
+	return c != nil && c.Skills != nil && c.Skills.Compact
+}
+
+// CompactBudget returns the digest's token budget, defaulting to
+// DefaultCompactBudget.
+func (c *Config) CompactBudget() int {
+	if c == nil || c.Skills == nil || c.Skills.CompactBudget == 0 {
+		return DefaultCompactBudget
+	}
+	return c.Skills.CompactBudget
+}
+
+// ParseCompactBudget parses a skills.compact_budget value.
+func ParseCompactBudget(value string) (int, error) {
+	budget, err := strconv.Atoi(value)
+	if err != nil || budget < minCompactBudget {
+		return 0, fmt.Errorf("invalid skills.compact_budget: %q (expected a token count of at least %d)", value, minCompactBudget)
+	}
+	return budget, nil
+}
+
+// EstimateTokens approximates the tokens text takes up in a model's
+// context, at four characters per token as for English prose.
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// compactSkill is what the digest says about one skill.
+type compactSkill struct {
+	name        string
+	description string
+	guardrails  []string
+}
+
+// GenerateCompactDigest returns the AGENTS-COMPACT.md content for skills:
+// a paragraph per valid skill with its description and the headings of
+// its Guardrails section. Over budget tokens, the paragraphs shrink to a
+// first sentence and fewer guardrails, then to a list of names. Returns ""
+// without valid skills.
+func GenerateCompactDigest(skills []*SkillInfo, budget int) string {
+	entries := compactSkills(skills)
+	if len(entries) == 0 {
+		return ""
+	}
+	for level := digestFull; level < digestNames; level++ {
+		if digest := renderCompactDigest(entries, level); EstimateTokens(digest) <= budget {
+			return digest
+		}
+	}
+	return renderCompactNames(entries, budget)
+}
+
+// compactSkills returns the digest entries of the valid skills, by name.
+func compactSkills(skills []*SkillInfo) []compactSkill {
+	var entries []compactSkill
+	for _, s := range skills {
+		if len(s.Errors) > 0 {
+			continue
+		}
+		entries = append(entries, compactSkill{
+			name:        skillTableName(s),
+			description: strings.Join(strings.Fields(s.Metadata.Description), " "),
+			guardrails:  skillGuardrails(s.Body),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].name < entries[j].name })
+	return entries
+}
+
+// skillGuardrails returns the subheadings of a skill's Guardrails section.
+func skillGuardrails(body string) []string {
+	headings := markdownHeadings(strings.Split(body, "\n"))
+	var guardrails []string
+	for i, h := range headings {
+		if !strings.EqualFold(h.title, "Guardrails") {
+			continue
+		}
+		for _, sub := range headings[i+1:] {
+			if sub.level <= h.level {
+				break
+			}
+			if sub.level == h.level+1 {
+				guardrails = append(guardrails, sub.title)
+			}
+		}
+		break
+	}
+	return guardrails
+}
+
+func renderCompactDigest(entries []compactSkill, level int) string {
+	var sb strings.Builder
+	sb.WriteString(compactHeader)
+	for _, e := range entries {
+		desc, guardrails := e.description, e.guardrails
+		if level > digestFull {
+			desc = firstSentence(desc)
+			guardrails = guardrails[:min(len(guardrails), compactGuardrails)]
+		}
+		if level > digestShort {
+			guardrails = nil
+		}
+		fmt.Fprintf(&sb, "**%s**: %s", e.name, endSentence(desc))
+		if len(guardrails) > 0 {
+			fmt.Fprintf(&sb, " Guardrails: %s.", strings.Join(guardrails, "; "))
+		}
+		sb.WriteString("\n\n")
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// renderCompactNames lists as many skill names as fit budget, pointing to
+// AGENTS.md for the rest. It always names at least one skill.
+func renderCompactNames(entries []compactSkill, budget int) string {
+	var digest string
+	for n := len(entries); n > 0; n-- {
+		names := make([]string, n)
+		for i, e := range entries[:n] {
+			names[i] = e.name
+		}
+		list := strings.Join(names, ", ")
+		if n < len(entries) {
+			list += fmt.Sprintf(" and %d more (see AGENTS.md)", len(entries)-n)
+		}
+		if digest = compactHeader + "Skills: " + list + ".\n"; EstimateTokens(digest) <= budget {
+			break
+		}
+	}
+	return digest
+}
+
+// firstSentence returns text up to the end of its first sentence.
+func firstSentence(text string) string {
+	if i := strings.Index(text, ". "); i >= 0 {
+		return text[:i+1]
+	}
+	return text
+}
+
+// endSentence terminates text with a period unless it already ends a
+// sentence.
+func endSentence(text string) string {
+	if text == "" || strings.HasSuffix(text, ".") || strings.HasSuffix(text, "!") || strings.HasSuffix(text, "?") {
+		return text
+	}
+	return text + "."
+}
+
+// WriteCompactAgentsMD regenerates the digest of the project in dir when
+// skills.compact is set, listing the active variant of each skill, and
+// reports whether it wrote the file.
+func WriteCompactAgentsMD(dir string, skills []*SkillInfo) (bool, error) {
+	config, _ := LoadConfigFrom(dir) // nil without samuel.yaml: disabled
+	if !config.CompactDigestEnabled() {
+		return false, nil
+	}
+	digest := GenerateCompactDigest(SelectSkillVariants(skills, config), config.CompactBudget())
+	if digest == "" {
+		return false, nil
+	}
+	if err := os.WriteFile(filepath.Join(dir, CompactAgentsFile), []byte(digest), 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", CompactAgentsFile, err)
+	}
+	return true, nil
+}
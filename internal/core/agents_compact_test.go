@@ -0,0 +1,100 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func compactTestSkill(name, description string, guardrails ...string) *SkillInfo {
+	body := "# " + name + "\n\n## Guardrails\n\n"
+	for _, g := range guardrails {
+		body += "### " + g + "\n\n- rule\n\n#### Detail\n\n"
+	}
+	body += "## Testing\n\n### Standards\n"
+	return &SkillInfo{DirName: name, Metadata: SkillMetadata{Name: name, Description: description}, Body: body}
+}
+
+func TestGenerateCompactDigest(t *testing.T) {
+	skills := []*SkillInfo{
+		compactTestSkill("go-guide", "Go language guardrails.\n  Use when working with Go files", "Code Style", "Error Handling"),
+		compactTestSkill("api-design", "REST API design"),
+		{Metadata: SkillMetadata{Name: "broken"}, Errors: []string{"missing description"}},
+	}
+	digest := GenerateCompactDigest(skills, DefaultCompactBudget)
+	for _, want := range []string{
+		"**api-design**: REST API design.\n",
+		"**go-guide**: Go language guardrails. Use when working with Go files. Guardrails: Code Style; Error Handling.\n",
+	} {
+		if !strings.Contains(digest, want) {
+			t.Errorf("digest missing %q:\n%s", want, digest)
+		}
+	}
+	if strings.Contains(digest, "broken") || strings.Contains(digest, "Detail") || strings.Contains(digest, "Standards") {
+		t.Errorf("digest lists invalid skills or other headings:\n%s", digest)
+	}
+	if strings.Index(digest, "api-design") > strings.Index(digest, "go-guide") {
+		t.Error("skills are not sorted by name")
+	}
+	if GenerateCompactDigest(nil, DefaultCompactBudget) != "" {
+		t.Error("digest without skills should be empty")
+	}
+}
+
+func TestGenerateCompactDigest_Budget(t *testing.T) {
+	var skills []*SkillInfo
+	names := []string{"alpha-service", "bravo-service", "charlie-service", "delta-service",
+		"echo-service", "foxtrot-service", "golf-service", "hotel-service"}
+	for _, name := range names {
+		skills = append(skills, compactTestSkill(name, strings.Repeat("Long description. ", 20),
+			"One", "Two", "Three", "Four", "Five"))
+	}
+	full := GenerateCompactDigest(skills, DefaultCompactBudget)
+	if !strings.Contains(full, "Five") {
+		t.Fatalf("full digest lost guardrails:\n%s", full)
+	}
+
+	entries := compactSkills(skills)
+	brief := EstimateTokens(renderCompactDigest(entries, digestBrief))
+	list := "Skills: " + strings.Join(names, ", ") + "."
+	listed := EstimateTokens(compactHeader + list + "\n")
+	for _, tt := range []struct {
+		budget int
+		want   string
+		absent string
+	}{
+		{budget: EstimateTokens(full) - 1, want: "Guardrails: One; Two; Three.", absent: "Four"},
+		{budget: brief, want: "**hotel-service**: Long description.\n", absent: "Guardrails"},
+		{budget: brief - 1, want: list, absent: "**"},
+		{budget: listed - 1, want: "and 2 more (see AGENTS.md)", absent: "golf-service"},
+	} {
+		digest := GenerateCompactDigest(skills, tt.budget)
+		if !strings.Contains(digest, tt.want) || strings.Contains(digest, tt.absent) {
+			t.Errorf("budget %d: want %q without %q, got:\n%s", tt.budget, tt.want, tt.absent, digest)
+		}
+		if EstimateTokens(digest) > tt.budget {
+			t.Errorf("budget %d: digest is %d tokens", tt.budget, EstimateTokens(digest))
+		}
+	}
+}
+
+func TestWriteCompactAgentsMD(t *testing.T) {
+	dir := t.TempDir()
+	skills := []*SkillInfo{compactTestSkill("go-guide", "Go guide", "Code Style")}
+	if wrote, err := WriteCompactAgentsMD(dir, skills); wrote || err != nil {
+		t.Fatalf("WriteCompactAgentsMD() without config = %v, %v", wrote, err)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "samuel.yaml"), "version: \"1.0.0\"\nskills:\n  compact: true\n")
+	if wrote, err := WriteCompactAgentsMD(dir, skills); !wrote || err != nil {
+		t.Fatalf("WriteCompactAgentsMD() = %v, %v", wrote, err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, CompactAgentsFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# Skills Digest\n") || !strings.Contains(string(data), "**go-guide**") {
+		t.Errorf("unexpected digest:\n%s", data)
+	}
+}
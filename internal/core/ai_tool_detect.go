@@ -0,0 +1,136 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultAITool is used when no agent CLI can be detected.
+const DefaultAITool = "claude"
+
+// GetSupportedAITools returns the list of supported AI tools
+func GetSupportedAITools() []string {
+	return []string{"claude", "amp", "cursor", "codex"}
+}
+
+// IsValidAITool checks if the given tool name is supported
+func IsValidAITool(tool string) bool {
+	return slices.Contains(GetSupportedAITools(), strings.ToLower(tool))
+}
+
+// aiToolCredentials lists, per tool, the environment variables and
+// home-relative files that indicate a configured key or login.
+var aiToolCredentials = map[string]struct {
+	envVars []string
+	files   []string
+}{
+	"claude": {envVars: []string{"ANTHROPIC_API_KEY", "CLAUDE_CODE_OAUTH_TOKEN"}, files: []string{".claude.json"}},
+	"codex":  {envVars: []string{"OPENAI_API_KEY"}, files: []string{filepath.Join(".codex", "auth.json")}},
+	"amp":    {envVars: []string{"AMP_API_KEY"}, files: []string{filepath.Join(".config", "amp", "settings.json")}},
+	"cursor": {envVars: []string{"CURSOR_API_KEY"}},
+}
+
+// Probes used by DetectAITools; replaced in tests.
+var (
+	aiToolLookPath    = exec.LookPath
+	aiToolLookupEnv   = os.LookupEnv
+	aiToolUserHomeDir = os.UserHomeDir
+)
+
+// AIToolStatus describes what was found for one supported agent CLI.
+type AIToolStatus struct {
+	Tool string
+	// Path is where the CLI was found in PATH, or empty.
+	Path string
+	// Credential names the environment variable or file that holds a
+	// key or login for the tool, or is empty.
+	Credential string
+}
+
+// Installed reports whether the tool's CLI is on PATH.
+func (s AIToolStatus) Installed() bool {
+	return s.Path != ""
+}
+
+// DetectAITools probes PATH and known credential locations for every
+// supported AI tool, in GetSupportedAITools order.
+func DetectAITools() []AIToolStatus {
+	home, _ := aiToolUserHomeDir()
+	var statuses []AIToolStatus
+	for _, tool := range GetSupportedAITools() {
+		status := AIToolStatus{Tool: tool}
+		status.Path, _ = aiToolLookPath(tool)
+		status.Credential = findAIToolCredential(tool, home)
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func findAIToolCredential(tool, home string) string {
+	creds := aiToolCredentials[tool]
+	for _, name := range creds.envVars {
+		if v, ok := aiToolLookupEnv(name); ok && strings.TrimSpace(v) != "" {
+			return name
+		}
+	}
+	if home == "" {
+		return ""
+	}
+	for _, rel := range creds.files {
+		if _, err := os.Stat(filepath.Join(home, rel)); err == nil {
+			return filepath.Join("~", rel)
+		}
+	}
+	return ""
+}
+
+// InferAITool picks the best detected tool: an installed CLI with a
+// configured key, then any installed CLI, then any configured key.
+// Ties go to the earlier tool in GetSupportedAITools. It falls back to
+// DefaultAITool and returns false when nothing was found. The reason
+// explains the choice for display.
+func InferAITool(statuses []AIToolStatus) (string, string, bool) {
+	best, bestScore := AIToolStatus{}, 0
+	for _, s := range statuses {
+		score := 0
+		if s.Installed() {
+			score += 2
+		}
+		if s.Credential != "" {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = s, score
+		}
+	}
+	if bestScore == 0 {
+		return DefaultAITool, fmt.Sprintf("no agent CLI found in PATH (looked for %s)",
+			strings.Join(GetSupportedAITools(), ", ")), false
+	}
+
+	var evidence []string
+	if best.Installed() {
+		evidence = append(evidence, best.Path)
+	} else {
+		evidence = append(evidence, "not in PATH")
+	}
+	if best.Credential != "" {
+		evidence = append(evidence, best.Credential+" configured")
+	}
+	return best.Tool, fmt.Sprintf("found %s (%s)", best.Tool, strings.Join(evidence, ", ")), true
+}
+
+// FindAIToolStatus returns the status for tool, or a zero status if the
+// tool was not probed.
+func FindAIToolStatus(statuses []AIToolStatus, tool string) AIToolStatus {
+	for _, s := range statuses {
+		if s.Tool == tool {
+			return s
+		}
+	}
+	return AIToolStatus{Tool: tool}
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// stubAIToolProbes makes DetectAITools see only the given binaries,
+// environment variables, and home directory.
+func stubAIToolProbes(t *testing.T, binaries []string, env map[string]string, home string) {
+	t.Helper()
+	origLookPath, origLookupEnv, origHome := aiToolLookPath, aiToolLookupEnv, aiToolUserHomeDir
+	t.Cleanup(func() {
+		aiToolLookPath, aiToolLookupEnv, aiToolUserHomeDir = origLookPath, origLookupEnv, origHome
+	})
+
+	aiToolLookPath = func(name string) (string, error) {
+		for _, b := range binaries {
+			if b == name {
+				return "/usr/local/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+	aiToolLookupEnv = func(name string) (string, bool) {
+		v, ok := env[name]
+		return v, ok
+	}
+	aiToolUserHomeDir = func() (string, error) { return home, nil }
+}
+
+func TestDetectAITools(t *testing.T) {
+	home := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(home, ".codex"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".codex", "auth.json"), []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	stubAIToolProbes(t, []string{"codex"}, map[string]string{"AMP_API_KEY": "k", "ANTHROPIC_API_KEY": " "}, home)
+
+	statuses := DetectAITools()
+
+	if len(statuses) != len(GetSupportedAITools()) {
+		t.Fatalf("got %d statuses, want one per supported tool", len(statuses))
+	}
+	codex := FindAIToolStatus(statuses, "codex")
+	if !codex.Installed() || codex.Credential != filepath.Join("~", ".codex", "auth.json") {
+		t.Errorf("codex status = %+v", codex)
+	}
+	if amp := FindAIToolStatus(statuses, "amp"); amp.Installed() || amp.Credential != "AMP_API_KEY" {
+		t.Errorf("amp status = %+v", amp)
+	}
+	if claude := FindAIToolStatus(statuses, "claude"); claude.Credential != "" {
+		t.Errorf("blank key should not count as configured, got %+v", claude)
+	}
+}
+
+func TestInferAITool(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []AIToolStatus
+		wantTool  string
+		wantFound bool
+	}{
+		{"nothing", []AIToolStatus{{Tool: "claude"}, {Tool: "codex"}}, DefaultAITool, false},
+		{"key_only", []AIToolStatus{{Tool: "claude"}, {Tool: "amp", Credential: "AMP_API_KEY"}}, "amp", true},
+		{"installed_beats_key", []AIToolStatus{{Tool: "amp", Credential: "AMP_API_KEY"}, {Tool: "codex", Path: "/bin/codex"}}, "codex", true},
+		{"installed_with_key_wins", []AIToolStatus{
+			{Tool: "claude", Path: "/bin/claude"},
+			{Tool: "codex", Path: "/bin/codex", Credential: "OPENAI_API_KEY"},
+		}, "codex", true},
+		{"tie_keeps_order", []AIToolStatus{{Tool: "claude", Path: "/bin/claude"}, {Tool: "amp", Path: "/bin/amp"}}, "claude", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tool, reason, found := InferAITool(tt.statuses)
+			if tool != tt.wantTool || found != tt.wantFound {
+				t.Errorf("InferAITool() = %q, %v; want %q, %v", tool, found, tt.wantTool, tt.wantFound)
+			}
+			if reason == "" {
+				t.Error("InferAITool() should explain its choice")
+			}
+		})
+	}
+}
+
+func TestInferAITool_ReasonNamesEvidence(t *testing.T) {
+	_, reason, _ := InferAITool([]AIToolStatus{{Tool: "codex", Path: "/bin/codex", Credential: "OPENAI_API_KEY"}})
+	if !strings.Contains(reason, "/bin/codex") || !strings.Contains(reason, "OPENAI_API_KEY") {
+		t.Errorf("reason %q should mention the binary and the key", reason)
+	}
+}
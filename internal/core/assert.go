@@ -0,0 +1,162 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// GetSupportedVersionOperators returns the comparisons accepted by
+// CheckVersionConstraint. Two-character operators come first so they are
+// matched before their one-character prefixes.
+func GetSupportedVersionOperators() []string {
+	return []string{">=", "<=", "==", "!=", ">", "<"}
+}
+
+// CompareVersions compares dotted numeric versions such as "1.4" and
+// "v2.0.1", returning -1, 0, or 1. Missing components count as zero and a
+// pre-release or build suffix ("-rc1", "+meta") is ignored.
+func CompareVersions(a, b string) (int, error) {
+	pa, err := parseVersionParts(a)
+	if err != nil {
+		return 0, err
+	}
+	pb, err := parseVersionParts(b)
+	if err != nil {
+		return 0, err
+	}
+	for len(pa) < len(pb) {
+		pa = append(pa, 0)
+	}
+	for len(pb) < len(pa) {
+		pb = append(pb, 0)
+	}
+	return slices.Compare(pa, pb), nil
+}
+
+func parseVersionParts(version string) ([]int, error) {
+	v := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	var parts []int
+	for _, p := range strings.Split(v, ".") {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid version: %q", version)
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+// ParseVersionConstraint splits a constraint such as ">= 1.4" or ">=1.4"
+// into its operator and version.
+func ParseVersionConstraint(constraint string) (string, string, error) {
+	constraint = strings.TrimSpace(constraint)
+	for _, op := range GetSupportedVersionOperators() {
+		if strings.HasPrefix(constraint, op) {
+			version := strings.TrimSpace(constraint[len(op):])
+			if _, err := parseVersionParts(version); err != nil {
+				return "", "", err
+			}
+			return op, version, nil
+		}
+	}
+	return "", "", fmt.Errorf("unsupported version constraint: %s (supported operators: %v)",
+		constraint, GetSupportedVersionOperators())
+}
+
+// CheckVersionConstraint reports whether version satisfies op want.
+func CheckVersionConstraint(version, op, want string) (bool, error) {
+	cmp, err := CompareVersions(version, want)
+	if err != nil {
+		return false, err
+	}
+	switch op {
+	case ">=":
+		return cmp >= 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case ">":
+		return cmp > 0, nil
+	case "<":
+		return cmp < 0, nil
+	}
+	return false, fmt.Errorf("unsupported operator: %s (supported: %v)", op, GetSupportedVersionOperators())
+}
+
+// FindModifiedManagedFiles compares the project's copies of the files
+// under paths with the template they were installed from and returns the
+// ones that differ, sorted. Missing files are not reported. In CLAUDE.md
+// the generated skills block is compared by its checksum rather than with
+// the template, since samuel rewrites it after install.
+func FindModifiedManagedFiles(projectDir, templatePath string, paths []string) ([]string, error) {
+	srcRoot := filepath.Join(templatePath, TemplatePrefix)
+	var modified []string
+	for _, p := range paths {
+		err := filepath.WalkDir(filepath.Join(srcRoot, p), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(srcRoot, path)
+			changed, err := managedFileModified(path, filepath.Join(projectDir, rel), filepath.ToSlash(rel))
+			if err != nil {
+				return err
+			}
+			if changed && !slices.Contains(modified, filepath.ToSlash(rel)) {
+				modified = append(modified, filepath.ToSlash(rel))
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s: %w", p, err)
+		}
+	}
+	slices.Sort(modified)
+	return modified, nil
+}
+
+func managedFileModified(templateFile, localFile, rel string) (bool, error) {
+	local, err := os.ReadFile(localFile)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	upstream, err := os.ReadFile(templateFile)
+	if err != nil {
+		return false, err
+	}
+	if rel != "CLAUDE.md" {
+		return !SameContent(rel, local, upstream), nil
+	}
+	if block, ok := findSkillsBlock(string(local)); ok && block.modified() {
+		return true, nil
+	}
+	return withoutSkillsBlock(string(local)) != withoutSkillsBlock(string(upstream)), nil
+}
+
+// withoutSkillsBlock empties the managed skills block, keeping its markers.
+func withoutSkillsBlock(content string) string {
+	block, ok := findSkillsBlock(content)
+	if !ok {
+		return content
+	}
+	return content[:block.start] + SkillsStartMarker + "\n" + content[block.end:]
+}
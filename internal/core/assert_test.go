@@ -0,0 +1,144 @@
+package core
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCompareVersions(t *testing.T) {
+	tests := []struct {
+		a, b    string
+		want    int
+		wantErr bool
+	}{
+		{a: "1.4.0", b: "1.4", want: 0},
+		{a: "v2.0.1", b: "2.0.0", want: 1},
+		{a: "1.10.0", b: "1.9.9", want: 1},
+		{a: "1.4.0-rc1", b: "1.4.0", want: 0},
+		{a: "0.9", b: "1", want: -1},
+		{a: "dev", b: "1.0", wantErr: true},
+		{a: "1.x", b: "1.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"_"+tt.b, func(t *testing.T) {
+			got, err := CompareVersions(tt.a, tt.b)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("CompareVersions(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseVersionConstraint(t *testing.T) {
+	tests := []struct {
+		constraint string
+		op, ver    string
+		wantErr    bool
+	}{
+		{constraint: ">= 1.4", op: ">=", ver: "1.4"},
+		{constraint: ">=1.4", op: ">=", ver: "1.4"},
+		{constraint: "< 3", op: "<", ver: "3"},
+		{constraint: "!= 2.0.0", op: "!=", ver: "2.0.0"},
+		{constraint: "~> 1.4", wantErr: true},
+		{constraint: ">= latest", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			op, ver, err := ParseVersionConstraint(tt.constraint)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if op != tt.op || ver != tt.ver {
+				t.Errorf("got (%q, %q), want (%q, %q)", op, ver, tt.op, tt.ver)
+			}
+		})
+	}
+}
+
+func TestCheckVersionConstraint(t *testing.T) {
+	tests := []struct {
+		version, op, want string
+		ok                bool
+	}{
+		{"2.0.0", ">=", "1.4", true},
+		{"1.3.9", ">=", "1.4", false},
+		{"2.0.0", "<", "3", true},
+		{"2.0.0", "==", "2", true},
+		{"2.0.0", "!=", "2.0.0", false},
+		{"2.0.0", ">", "2.0.0", false},
+		{"2.0.0", "<=", "2.0.0", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version+tt.op+tt.want, func(t *testing.T) {
+			ok, err := CheckVersionConstraint(tt.version, tt.op, tt.want)
+			if err != nil {
+				t.Fatalf("CheckVersionConstraint: %v", err)
+			}
+			if ok != tt.ok {
+				t.Errorf("got %v, want %v", ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFindModifiedManagedFiles(t *testing.T) {
+	tmplDir := t.TempDir()
+	projectDir := t.TempDir()
+	claudeTemplate := "# Guide\n\n" + SkillsStartMarker + "\n" + SkillsEndMarker + "\n"
+	files := map[string][2]string{
+		// path: {template, local}
+		"CLAUDE.md":                         {claudeTemplate, generatedClaudeMD(t)},
+		"AGENTS.md":                         {"# Agents", "# Agents (edited)"},
+		".claude/skills/go-guide/SKILL.md":  {"# Go", "# Go"},
+		".claude/skills/go-guide/extra.md":  {"extra", "extra v2"},
+		".claude/skills/go-guide/absent.md": {"absent", ""},
+	}
+	for rel, content := range files {
+		writeTestFile(t, filepath.Join(tmplDir, TemplatePrefix, rel), content[0])
+		if content[1] != "" {
+			writeTestFile(t, filepath.Join(projectDir, rel), content[1])
+		}
+	}
+
+	modified, err := FindModifiedManagedFiles(projectDir, tmplDir,
+		[]string{"CLAUDE.md", "AGENTS.md", ".claude/skills/go-guide", ".claude/skills/missing"})
+	if err != nil {
+		t.Fatalf("FindModifiedManagedFiles: %v", err)
+	}
+	want := []string{".claude/skills/go-guide/extra.md", "AGENTS.md"}
+	if !slices.Equal(modified, want) {
+		t.Errorf("modified = %v, want %v", modified, want)
+	}
+}
+
+func TestFindModifiedManagedFiles_EditedSkillsBlock(t *testing.T) {
+	tmplDir := t.TempDir()
+	projectDir := t.TempDir()
+	writeTestFile(t, filepath.Join(tmplDir, TemplatePrefix, "CLAUDE.md"),
+		"# Guide\n\n"+SkillsStartMarker+"\n"+SkillsEndMarker+"\n")
+	edited := strings.Replace(generatedClaudeMD(t), "- go-guide", "- go-guide (always use)", 1)
+	writeTestFile(t, filepath.Join(projectDir, "CLAUDE.md"), edited)
+
+	modified, err := FindModifiedManagedFiles(projectDir, tmplDir, []string{"CLAUDE.md"})
+	if err != nil {
+		t.Fatalf("FindModifiedManagedFiles: %v", err)
+	}
+	if !slices.Equal(modified, []string{"CLAUDE.md"}) {
+		t.Errorf("modified = %v, want [CLAUDE.md]", modified)
+	}
+}
+
+// generatedClaudeMD returns the template CLAUDE.md with its skills block
+// filled in the way init does.
+func generatedClaudeMD(t *testing.T) string {
+	t.Helper()
+	section := "- go-guide\n"
+	return "# Guide\n\n" + SkillsStartMarker + "\n" +
+		skillsChecksumPrefix + SkillsSectionChecksum(section) + skillsChecksumSuffix + "\n" +
+		section + SkillsEndMarker + "\n"
+}
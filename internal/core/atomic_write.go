@@ -0,0 +1,75 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// BackupSuffix is appended to a state file's name for the copy of its
+// previous version kept by writeFileAtomic.
+const BackupSuffix = ".bak"
+
+// writeFileAtomic replaces the file at path with data so that a crash or
+// power loss leaves either the old or the new content, never a partial
+// file: data is written to a temporary file in the same directory, synced
+// to disk and renamed over path. With backup set, the current content is
+// first copied to path+BackupSuffix the same way.
+func writeFileAtomic(path string, data []byte, perm os.FileMode, backup bool) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+	if backup {
+		prev, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := replaceFile(path+BackupSuffix, prev, perm); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", filepath.Base(path), err)
+			}
+		case !os.IsNotExist(err):
+			return fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+		}
+	}
+	return replaceFile(path, data, perm)
+}
+
+// replaceFile writes data to path through a synced temporary file and an
+// atomic rename, then syncs the directory so the rename itself is durable.
+func replaceFile(path string, data []byte, perm os.FileMode) error {
+	tmpFile := path + ".tmp"
+	f, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to sync temp file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tmpFile, path); err != nil {
+		os.Remove(tmpFile)
+		return fmt.Errorf("failed to rename temp file: %w", err)
+	}
+	syncDir(filepath.Dir(path))
+	return nil
+}
+
+// syncDir flushes a directory entry to disk. Not every platform can open
+// a directory for syncing (Windows cannot), so failures are ignored.
+func syncDir(dir string) {
+	d, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+	_ = d.Sync()
+	d.Close()
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	tests := []struct {
+		name       string
+		existing   string
+		backup     bool
+		wantBackup string
+	}{
+		{name: "new file", backup: true},
+		{name: "replace with backup", existing: "old", backup: true, wantBackup: "old"},
+		{name: "replace without backup", existing: "old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "state", "file.json")
+			if tt.existing != "" {
+				writeTestFile(t, path, tt.existing)
+			}
+
+			if err := writeFileAtomic(path, []byte("new"), 0600, tt.backup); err != nil {
+				t.Fatalf("writeFileAtomic() error = %v", err)
+			}
+			if data, _ := os.ReadFile(path); string(data) != "new" {
+				t.Errorf("content = %q, want %q", data, "new")
+			}
+			backup, err := os.ReadFile(path + BackupSuffix)
+			if tt.wantBackup == "" && !os.IsNotExist(err) {
+				t.Errorf("expected no backup, got %q (err %v)", backup, err)
+			}
+			if tt.wantBackup != "" && string(backup) != tt.wantBackup {
+				t.Errorf("backup = %q, want %q", backup, tt.wantBackup)
+			}
+			if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+				t.Error("expected no temp file to be left behind")
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic_FailureKeepsOriginal(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "file.json")
+	writeTestFile(t, path, "old")
+	// A directory in place of the temp file makes the write fail.
+	if err := os.Mkdir(path+".tmp", 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := writeFileAtomic(path, []byte("new"), 0644, false); err == nil {
+		t.Fatal("expected an error")
+	}
+	if data, _ := os.ReadFile(path); string(data) != "old" {
+		t.Errorf("content = %q, want the original", data)
+	}
+}
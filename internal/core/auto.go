@@ -2,11 +2,10 @@ package core
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"slices"
-	"strings"
 	"time"
 )
 
@@ -17,6 +16,10 @@ const (
 	AutoProgressFile         = "progress.md"
 	AutoPromptFile           = "prompt.md"
 	AutoDiscoveryPromptFile  = "discovery-prompt.md"
+	AutoWrapUpPromptFile     = "wrapup-prompt.md"
+	AutoMilestonePromptFile  = "milestone-prompt.md"
+	AutoContextDir           = "context"
+	AutoProjectStatsFile     = "project-stats.md"
 	AutoSchemaVer            = "1.0"
 )
 
@@ -24,6 +27,7 @@ const (
 const (
 	IterationTypeDiscovery      = "discovery"
 	IterationTypeImplementation = "implementation"
+	IterationTypeWrapUp         = "wrapup"
 )
 
 // Pilot mode default constants
@@ -77,6 +81,8 @@ type AutoPRD struct {
 	Config   AutoConfig   `json:"config"`
 	Tasks    []AutoTask   `json:"tasks"`
 	Progress AutoProgress `json:"progress"`
+
+	recovered bool // loaded from the backup, see RecoveredFromBackup
 }
 
 // AutoProject holds project metadata
@@ -97,9 +103,21 @@ type AutoConfig struct {
 	Sandbox         string   `json:"sandbox"`
 	SandboxImage    string   `json:"sandbox_image,omitempty"`
 	SandboxTemplate string   `json:"sandbox_template,omitempty"`
+	SandboxOffline  bool     `json:"sandbox_offline,omitempty"`
 	PilotMode       bool     `json:"pilot_mode,omitempty"`
 	PilotConfig     *PilotConfig `json:"pilot_config,omitempty"`
 	DiscoveryPrompt string   `json:"discovery_prompt_file,omitempty"`
+	ScoringStrategy string   `json:"scoring_strategy,omitempty"`
+	Report          *ReportConfig `json:"report,omitempty"`
+	SandboxMounts   []SandboxMount `json:"sandbox_mounts,omitempty"`
+	StatsRefreshInterval int     `json:"stats_refresh_interval,omitempty"`
+	CommitPolicy    *CommitPolicy `json:"commit_policy,omitempty"`
+	PathGuard       *PathGuard    `json:"path_guard,omitempty"`
+	Sync            *SyncConfig   `json:"sync,omitempty"`
+	PreIteration    []string      `json:"pre_iteration,omitempty"`
+	PostIteration   []string      `json:"post_iteration,omitempty"`
+	Telemetry       *TelemetryConfig `json:"telemetry,omitempty"`
+	ContextFiles    []string      `json:"context_files,omitempty"`
 }
 
 // PilotConfig holds pilot-mode specific configuration
@@ -111,21 +129,27 @@ type PilotConfig struct {
 
 // AutoTask represents a single task in the autonomous loop
 type AutoTask struct {
-	ID            string   `json:"id"`
-	Title         string   `json:"title"`
-	Description   string   `json:"description,omitempty"`
-	Status        string   `json:"status"`
-	Priority      string   `json:"priority,omitempty"`
-	Complexity    string   `json:"complexity,omitempty"`
-	ParentID      string   `json:"parent_id,omitempty"`
-	DependsOn     []string `json:"depends_on,omitempty"`
-	FilesToCreate []string `json:"files_to_create,omitempty"`
-	FilesToModify []string `json:"files_to_modify,omitempty"`
-	Guardrails    []string `json:"guardrails,omitempty"`
-	CompletedAt   string   `json:"completed_at,omitempty"`
-	CommitSHA     string   `json:"commit_sha,omitempty"`
-	Iteration     int      `json:"iteration,omitempty"`
-	Source        string   `json:"source,omitempty"`
+	ID                 string     `json:"id"`
+	Title              string     `json:"title"`
+	Description        string     `json:"description,omitempty"`
+	Status             string     `json:"status"`
+	Priority           string     `json:"priority,omitempty"`
+	Complexity         string     `json:"complexity,omitempty"`
+	ParentID           string     `json:"parent_id,omitempty"`
+	DependsOn          []string   `json:"depends_on,omitempty"`
+	FilesToCreate      []string   `json:"files_to_create,omitempty"`
+	FilesToModify      []string   `json:"files_to_modify,omitempty"`
+	Guardrails         []string   `json:"guardrails,omitempty"`
+	AcceptanceCriteria []string   `json:"acceptance_criteria,omitempty"`
+	AllowedPaths       []string   `json:"allowed_paths,omitempty"`
+	Labels             []string   `json:"labels,omitempty"`
+	Milestone          string     `json:"milestone,omitempty"`
+	CompletedAt        string     `json:"completed_at,omitempty"`
+	CommitSHA          string     `json:"commit_sha,omitempty"`
+	Iteration          int        `json:"iteration,omitempty"`
+	Source             string     `json:"source,omitempty"`
+	CreatedAt          string     `json:"created_at,omitempty"`
+	Notes              []TaskNote `json:"notes,omitempty"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for AutoTask.
@@ -180,6 +204,7 @@ const (
 	TaskSourceManual    = "manual"
 	TaskSourcePRD       = "prd"
 	TaskSourceDiscovery = "pilot-discovery"
+	TaskSourceImport    = "import"
 )
 
 // AutoProgress holds summary progress data
@@ -219,8 +244,27 @@ func NewAutoPRD(name, description string) *AutoPRD {
 	}
 }
 
-// LoadAutoPRD loads a prd.json file from disk
+// LoadAutoPRD loads a prd.json file from disk. If the file cannot be
+// parsed, the backup of its previous version written by Save is loaded
+// instead and RecoveredFromBackup reports true.
 func LoadAutoPRD(path string) (*AutoPRD, error) {
+	prd, err := readAutoPRD(path)
+	if err == nil || !errors.Is(err, errPRDParse) {
+		return prd, err
+	}
+	backup, backupErr := readAutoPRD(path + BackupSuffix)
+	if backupErr != nil {
+		return nil, err
+	}
+	backup.recovered = true
+	return backup, nil
+}
+
+// errPRDParse marks a prd.json that was read but could not be parsed.
+var errPRDParse = errors.New("failed to parse prd.json")
+
+// readAutoPRD reads and parses one prd.json file.
+func readAutoPRD(path string) (*AutoPRD, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read prd.json: %w", err)
@@ -228,42 +272,43 @@ func LoadAutoPRD(path string) (*AutoPRD, error) {
 
 	var prd AutoPRD
 	if err := json.Unmarshal(data, &prd); err != nil {
-		return nil, fmt.Errorf("failed to parse prd.json: %w", err)
+		return nil, fmt.Errorf("%w: %v", errPRDParse, err)
 	}
 
 	return &prd, nil
 }
 
-// Save writes the AutoPRD to disk using write-to-temp-then-rename for safety
+// RecoveredFromBackup reports whether the plan was loaded from the backup
+// because prd.json itself could not be parsed. The next Save repairs it.
+func (p *AutoPRD) RecoveredFromBackup() bool {
+	return p.recovered
+}
+
+// Save writes the AutoPRD to disk crash-safely (see writeFileAtomic),
+// keeping the previous version in prd.json.bak. A plan recovered from the
+// backup leaves the backup alone rather than replacing it with the
+// unreadable file.
 func (p *AutoPRD) Save(path string) error {
-	p.Project.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
 	p.RecalculateProgress()
+	p.normalizeLists()
 
-	data, err := json.MarshalIndent(p, "", "  ")
-	if err != nil {
-		return fmt.Errorf("failed to marshal prd.json: %w", err)
-	}
-	data = append(data, '\n')
-
-	dir := filepath.Dir(path)
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return fmt.Errorf("failed to create directory: %w", err)
+	// Saving an unchanged plan keeps its timestamp and leaves the file alone.
+	data, err := p.marshal()
+	if err != nil || fileHasContent(path, data) {
+		return err
 	}
-
-	tmpFile := path + ".tmp"
-	if err := os.WriteFile(tmpFile, data, 0644); err != nil {
-		return fmt.Errorf("failed to write temp file: %w", err)
+	p.Project.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	if data, err = p.marshal(); err != nil {
+		return err
 	}
 
-	if err := os.Rename(tmpFile, path); err != nil {
-		os.Remove(tmpFile)
-		return fmt.Errorf("failed to rename temp file: %w", err)
+	if err := writeFileAtomic(path, data, 0644, !p.recovered); err != nil {
+		return err
 	}
-
+	p.recovered = false
 	return nil
 }
 
-
 // GetAutoPRDPath returns the full path to prd.json in a project directory
 func GetAutoPRDPath(projectDir string) string {
 	return filepath.Join(projectDir, AutoDir, AutoPRDFile)
@@ -273,13 +318,3 @@ func GetAutoPRDPath(projectDir string) string {
 func GetAutoDir(projectDir string) string {
 	return filepath.Join(projectDir, AutoDir)
 }
-
-// GetSupportedAITools returns the list of supported AI tools
-func GetSupportedAITools() []string {
-	return []string{"claude", "amp", "cursor", "codex"}
-}
-
-// IsValidAITool checks if the given tool name is supported
-func IsValidAITool(tool string) bool {
-	return slices.Contains(GetSupportedAITools(), strings.ToLower(tool))
-}
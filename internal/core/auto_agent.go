@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// InvokeAgent calls the AI tool for one iteration of work.
+// It validates cfg.AITool against the allow-list before execution
+// to prevent arbitrary command injection via modified prd.json.
+func InvokeAgent(cfg LoopConfig) error {
+	if !IsValidAITool(cfg.AITool) {
+		return fmt.Errorf(
+			"refused to invoke invalid AI tool %q (allowed: %v)",
+			cfg.AITool, GetSupportedAITools())
+	}
+
+	switch cfg.Sandbox {
+	case SandboxDockerSandbox:
+		return invokeAgentDockerSandbox(cfg)
+	case SandboxDocker:
+		return invokeAgentDocker(cfg)
+	case SandboxProcess:
+		return invokeAgentProcess(cfg)
+	default:
+		return invokeAgentLocal(cfg)
+	}
+}
+
+func invokeAgentLocal(cfg LoopConfig) error {
+	args, err := GetAgentArgs(cfg.AITool, cfg.PromptPath)
+	if err != nil {
+		return fmt.Errorf("failed to build agent args: %w", err)
+	}
+
+	cmd := exec.Command(cfg.AITool, args...)
+	cmd.Dir = cfg.ProjectDir
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+func invokeAgentDocker(cfg LoopConfig) error {
+	promptRel, err := filepath.Rel(cfg.ProjectDir, cfg.PromptPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute relative prompt path: %w", err)
+	}
+
+	agentArgs, err := GetAgentArgs(
+		cfg.AITool,
+		filepath.Join(DockerContainerMount, promptRel),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to build agent args: %w", err)
+	}
+
+	image := cfg.SandboxImage
+	if image == "" {
+		image = DefaultSandboxImage
+	}
+	if !IsValidSandboxImage(image) {
+		return fmt.Errorf(
+			"refused to use invalid sandbox image %q: must match Docker image reference format",
+			image)
+	}
+
+	mountArgs, err := BuildSandboxMountArgs(cfg.SandboxMounts)
+	if err != nil {
+		return fmt.Errorf("refused to use sandbox mounts: %w", err)
+	}
+
+	dockerArgs := buildDockerRunArgs(cfg.ProjectDir, image, cfg.AITool, mountArgs, agentArgs)
+	cmd := exec.Command("docker", dockerArgs...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+func invokeAgentDockerSandbox(cfg LoopConfig) error {
+	agentArgs, err := GetAgentArgs(cfg.AITool, cfg.PromptPath)
+	if err != nil {
+		return fmt.Errorf("failed to build agent args: %w", err)
+	}
+
+	sandboxCfg := DockerSandboxRunConfig{
+		Agent:     cfg.AITool,
+		WorkDir:   cfg.ProjectDir,
+		Template:  cfg.SandboxTpl,
+		Name:      SandboxNameForProject(cfg.ProjectDir),
+		AgentArgs: agentArgs,
+	}
+
+	args := BuildDockerSandboxArgs(sandboxCfg)
+	cmd := exec.Command("docker", args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
+
+// buildDockerRunArgs constructs docker run arguments for agent invocation.
+// mountArgs holds extra "-v" pairs from BuildSandboxMountArgs.
+func buildDockerRunArgs(workDir, image, aiTool string, mountArgs, agentArgs []string) []string {
+	args := []string{"run", "--rm", "--init", "-i"}
+	args = append(args, fmt.Sprintf("--user=%d:%d", os.Getuid(), os.Getgid()))
+	args = append(args, "-v", fmt.Sprintf("%s:%s", workDir, DockerContainerMount))
+	args = append(args, mountArgs...)
+	args = append(args, "-w", DockerContainerMount)
+	args = append(args, sandboxLabelArgs(workDir)...)
+	args = append(args, getAIToolEnvVars()...)
+	args = append(args, image)
+	args = append(args, aiTool)
+	args = append(args, agentArgs...)
+	return args
+}
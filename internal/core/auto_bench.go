@@ -0,0 +1,251 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// BenchConfig configures a comparative run of AI tools on the same tasks.
+type BenchConfig struct {
+	ProjectDir string
+	// PRD is the project's task list; the benchmark tasks are taken from
+	// its pending tasks and its config (quality checks, sandbox) is reused.
+	PRD           *AutoPRD
+	Tools         []string
+	Tasks         int
+	MaxIterations int
+	// Keep leaves each tool's worktree in place for inspection.
+	Keep bool
+	// Configure, when set, adjusts the loop config of each tool's run,
+	// e.g. to attach progress callbacks.
+	Configure func(tool string, cfg *LoopConfig)
+}
+
+// BenchResult holds the outcome of one tool's benchmark run.
+type BenchResult struct {
+	Tool           string        `json:"tool"`
+	TasksTotal     int           `json:"tasks_total"`
+	TasksCompleted int           `json:"tasks_completed"`
+	Iterations     int           `json:"iterations"`
+	Duration       time.Duration `json:"duration_ns"`
+	ChecksTotal    int           `json:"checks_total"`
+	ChecksPassed   int           `json:"checks_passed"`
+	FailedChecks   []string      `json:"failed_checks,omitempty"`
+	Worktree       string        `json:"worktree,omitempty"`
+	Error          string        `json:"error,omitempty"`
+}
+
+// CompletionRate returns the share of benchmark tasks completed, 0 to 1.
+func (r BenchResult) CompletionRate() float64 {
+	return benchRate(r.TasksCompleted, r.TasksTotal)
+}
+
+// CheckPassRate returns the share of quality checks that passed, 0 to 1.
+func (r BenchResult) CheckPassRate() float64 {
+	return benchRate(r.ChecksPassed, r.ChecksTotal)
+}
+
+func benchRate(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
+
+// Hooks used by RunBench; replaced in tests.
+var (
+	benchRunLoop  = RunAutoLoop
+	benchRunCheck = runShellCheck
+)
+
+// SelectBenchTasks returns up to n pending tasks in list order.
+// Dependencies and parents outside the selection are dropped so
+// every tool gets the same self-contained task set.
+func SelectBenchTasks(prd *AutoPRD, n int) []AutoTask {
+	var tasks []AutoTask
+	for _, task := range prd.Tasks {
+		if len(tasks) == n {
+			break
+		}
+		if task.Status == TaskStatusPending {
+			tasks = append(tasks, task)
+		}
+	}
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		ids[i] = task.ID
+	}
+	for i := range tasks {
+		var deps []string
+		for _, dep := range tasks[i].DependsOn {
+			if slices.Contains(ids, dep) {
+				deps = append(deps, dep)
+			}
+		}
+		tasks[i].DependsOn = deps
+		if !slices.Contains(ids, tasks[i].ParentID) {
+			tasks[i].ParentID = ""
+		}
+	}
+	return tasks
+}
+
+// RunBench runs every tool in cfg.Tools on the same pending tasks, one
+// after another, each in its own git worktree checked out at HEAD, and
+// returns one result per tool. A tool whose run fails still gets a
+// result with Error set; only setup problems return an error.
+func RunBench(cfg BenchConfig) ([]BenchResult, error) {
+	for _, tool := range cfg.Tools {
+		if !IsValidAITool(tool) {
+			return nil, fmt.Errorf("invalid AI tool: %s (supported: %v)", tool, GetSupportedAITools())
+		}
+	}
+	if GitHeadSHA(cfg.ProjectDir) == "" {
+		return nil, fmt.Errorf("benchmarks need a git repository with at least one commit")
+	}
+	tasks := SelectBenchTasks(cfg.PRD, cfg.Tasks)
+	if len(tasks) == 0 {
+		return nil, fmt.Errorf("no pending tasks to benchmark")
+	}
+
+	results := make([]BenchResult, 0, len(cfg.Tools))
+	for _, tool := range cfg.Tools {
+		results = append(results, runBenchTool(cfg, tool, tasks))
+	}
+	return results, nil
+}
+
+// runBenchTool runs the loop with one tool in a fresh worktree.
+func runBenchTool(cfg BenchConfig, tool string, tasks []AutoTask) BenchResult {
+	result := BenchResult{Tool: tool, TasksTotal: len(tasks), ChecksTotal: len(cfg.PRD.Config.QualityChecks)}
+	worktree, cleanup, err := addBenchWorktree(cfg.ProjectDir, tool)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	if cfg.Keep {
+		result.Worktree = worktree
+	} else {
+		defer cleanup()
+	}
+
+	prd := benchPRD(cfg.PRD, tool, tasks)
+	if err := writeBenchState(worktree, prd); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	loopCfg := NewLoopConfig(worktree, prd)
+	loopCfg.MaxIterations = cfg.MaxIterations
+	if cfg.Configure != nil {
+		cfg.Configure(tool, &loopCfg)
+	}
+	onEnd := loopCfg.OnIterEnd
+	loopCfg.OnIterEnd = func(iter int, err error) {
+		result.Iterations = iter
+		notifyIterEnd(onEnd, iter, err)
+	}
+
+	started := time.Now()
+	if err := benchRunLoop(loopCfg); err != nil {
+		result.Error = err.Error()
+	}
+	result.Duration = time.Since(started)
+
+	scoreBenchRun(&result, worktree, cfg.PRD.Config.QualityChecks)
+	return result
+}
+
+// scoreBenchRun counts the tasks completed in the worktree and runs the
+// quality checks there.
+func scoreBenchRun(result *BenchResult, worktree string, checks []string) {
+	if final, err := LoadAutoPRD(GetAutoPRDPath(worktree)); err == nil {
+		for _, task := range final.Tasks {
+			if task.Status == TaskStatusCompleted {
+				result.TasksCompleted++
+			}
+		}
+	}
+	for _, check := range checks {
+		if benchRunCheck(worktree, check) == nil {
+			result.ChecksPassed++
+		} else {
+			result.FailedChecks = append(result.FailedChecks, check)
+		}
+	}
+}
+
+// benchPRD returns a copy of prd limited to tasks and set to use tool.
+func benchPRD(prd *AutoPRD, tool string, tasks []AutoTask) *AutoPRD {
+	bench := *prd
+	bench.Config.AITool = tool
+	bench.Config.Sync = nil
+	if bench.Config.PromptFile == "" {
+		bench.Config.PromptFile = filepath.Join(AutoDir, AutoPromptFile)
+	}
+	bench.Tasks = slices.Clone(tasks)
+	bench.RecalculateProgress()
+	return &bench
+}
+
+// writeBenchState writes prd.json, the prompt and an empty progress.md
+// into the worktree's auto directory.
+func writeBenchState(worktree string, prd *AutoPRD) error {
+	if err := os.MkdirAll(GetAutoDir(worktree), 0755); err != nil {
+		return fmt.Errorf("failed to create auto directory: %w", err)
+	}
+	if err := prd.Save(GetAutoPRDPath(worktree)); err != nil {
+		return fmt.Errorf("failed to write prd.json: %w", err)
+	}
+	promptPath := filepath.Join(worktree, prd.Config.PromptFile)
+	if err := os.WriteFile(promptPath, []byte(GeneratePromptFile(prd.Config)), 0644); err != nil {
+		return fmt.Errorf("failed to write prompt: %w", err)
+	}
+	progressPath := filepath.Join(GetAutoDir(worktree), AutoProgressFile)
+	return os.WriteFile(progressPath, nil, 0644)
+}
+
+// addBenchWorktree checks out HEAD of projectDir into a temporary
+// detached worktree and returns it with a function that removes it.
+func addBenchWorktree(projectDir, tool string) (string, func(), error) {
+	tmp, err := os.MkdirTemp("", "samuel-bench-"+tool+"-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	worktree := filepath.Join(tmp, "worktree")
+	if out, err := runGit(projectDir, "worktree", "add", "--detach", worktree, "HEAD"); err != nil {
+		os.RemoveAll(tmp)
+		return "", nil, fmt.Errorf("git worktree add failed: %s", out)
+	}
+	cleanup := func() {
+		_, _ = runGit(projectDir, "worktree", "remove", "--force", worktree)
+		os.RemoveAll(tmp)
+		_, _ = runGit(projectDir, "worktree", "prune")
+	}
+	return worktree, cleanup, nil
+}
+
+// runShellCheck runs a quality check command through the platform shell
+// in dir and returns an error when it fails.
+func runShellCheck(dir, command string) error {
+	cmd := shellCommand(command)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %w: %s", command, err, out)
+	}
+	return nil
+}
+
+// shellCommand returns a command that runs command through the platform
+// shell: sh -c, or cmd /C on Windows.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSelectBenchTasks(t *testing.T) {
+	prd := prdWithIDs("1", "2", "3", "4")
+	prd.Tasks[0].Status = TaskStatusCompleted
+	prd.Tasks[2].DependsOn = []string{"1", "2"}
+	prd.Tasks[3].ParentID = "1"
+
+	tasks := SelectBenchTasks(prd, 2)
+	if len(tasks) != 2 || tasks[0].ID != "2" || tasks[1].ID != "3" {
+		t.Fatalf("SelectBenchTasks() = %+v, want tasks 2 and 3", tasks)
+	}
+	if !reflect.DeepEqual(tasks[1].DependsOn, []string{"2"}) {
+		t.Errorf("DependsOn = %v, want [2]", tasks[1].DependsOn)
+	}
+	if !reflect.DeepEqual(prd.Tasks[2].DependsOn, []string{"1", "2"}) {
+		t.Errorf("source task was modified: %v", prd.Tasks[2].DependsOn)
+	}
+	if got := SelectBenchTasks(prd, 10); len(got) != 3 || got[2].ParentID != "" {
+		t.Errorf("SelectBenchTasks(10) = %+v, want 3 tasks with unknown parent dropped", got)
+	}
+}
+
+func TestRunBench(t *testing.T) {
+	cfg := setupPolicyRepo(t, nil)
+	prd := prdWithIDs("1", "2")
+	prd.Config.QualityChecks = []string{"good", "bad"}
+
+	origLoop, origCheck := benchRunLoop, benchRunCheck
+	t.Cleanup(func() { benchRunLoop, benchRunCheck = origLoop, origCheck })
+	var worktrees []string
+	benchRunLoop = func(loop LoopConfig) error {
+		worktrees = append(worktrees, loop.ProjectDir)
+		loaded, err := LoadAutoPRD(loop.PRDPath)
+		if err != nil {
+			return err
+		}
+		if loaded.Config.AITool == "codex" {
+			return errors.New("agent crashed")
+		}
+		loop.OnIterEnd(1, nil)
+		loaded.Tasks[0].Status = TaskStatusCompleted
+		return loaded.Save(loop.PRDPath)
+	}
+	benchRunCheck = func(dir, check string) error {
+		if check == "bad" {
+			return errors.New("failed")
+		}
+		return nil
+	}
+
+	results, err := RunBench(BenchConfig{ProjectDir: cfg.ProjectDir, PRD: prd, Tools: []string{"claude", "codex"}, Tasks: 5, MaxIterations: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []BenchResult{
+		{Tool: "claude", TasksTotal: 2, TasksCompleted: 1, Iterations: 1, ChecksTotal: 2, ChecksPassed: 1, FailedChecks: []string{"bad"}},
+		{Tool: "codex", TasksTotal: 2, ChecksTotal: 2, ChecksPassed: 1, FailedChecks: []string{"bad"}, Error: "agent crashed"},
+	}
+	for i := range results {
+		results[i].Duration = 0
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("RunBench() = %+v, want %+v", results, want)
+	}
+	if results[0].CompletionRate() != 0.5 || results[0].CheckPassRate() != 0.5 {
+		t.Errorf("rates = %v, %v, want 0.5", results[0].CompletionRate(), results[0].CheckPassRate())
+	}
+	for _, wt := range worktrees {
+		if _, err := os.Stat(wt); !os.IsNotExist(err) {
+			t.Errorf("worktree %s was not removed", wt)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(cfg.ProjectDir, AutoDir, AutoProgressFile)); !os.IsNotExist(err) {
+		t.Error("benchmark wrote into the project's auto directory")
+	}
+}
+
+func TestRunBench_Errors(t *testing.T) {
+	cfg := setupPolicyRepo(t, nil)
+	tests := []struct {
+		name  string
+		dir   string
+		tools []string
+		prd   *AutoPRD
+	}{
+		{"invalid tool", cfg.ProjectDir, []string{"gpt"}, prdWithIDs("1")},
+		{"no pending tasks", cfg.ProjectDir, []string{"claude"}, prdWithIDs()},
+		{"not a repository", t.TempDir(), []string{"claude"}, prdWithIDs("1")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := RunBench(BenchConfig{ProjectDir: tt.dir, PRD: tt.prd, Tools: tt.tools, Tasks: 5}); err == nil {
+				t.Error("RunBench() = nil, want error")
+			}
+		})
+	}
+}
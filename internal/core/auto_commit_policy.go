@@ -0,0 +1,215 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// NoteAuthorSystem marks notes samuel writes itself, such as commit policy
+// violations. It is not accepted from the command line.
+const NoteAuthorSystem = "system"
+
+// conventionalCommitPattern matches a conventional commit subject such as
+// "feat(api): 1.2 - add endpoint" or "fix!: handle nil config".
+var conventionalCommitPattern = regexp.MustCompile(
+	`^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([^()]+\))?!?: \S`)
+
+// CommitPolicy is checked after every iteration for each task the agent
+// completed. A task that violates it is reopened with a note explaining
+// what to fix, so the policy holds even when the prompt is ignored.
+type CommitPolicy struct {
+	// RequireClean requires all changes outside .claude/auto/ to be
+	// committed.
+	RequireClean bool `json:"require_clean,omitempty"`
+	// SingleCommit requires exactly one commit per completed task.
+	SingleCommit bool `json:"single_commit,omitempty"`
+	// RequireTaskID requires every commit subject to contain the task ID.
+	RequireTaskID bool `json:"require_task_id,omitempty"`
+	// Conventional requires conventional-commit subjects
+	// ("type(scope): description").
+	Conventional bool `json:"conventional,omitempty"`
+}
+
+// IterationSnapshot is the state an iteration started from. Dirty holds
+// the paths that were already modified or untracked, so guards only act
+// on what the iteration itself changed.
+type IterationSnapshot struct {
+	HeadSHA    string
+	Completed  map[string]bool
+	NextTaskID string
+	Dirty      map[string]bool
+}
+
+// CommitPolicyViolation lists the policy problems of one completed task.
+type CommitPolicyViolation struct {
+	TaskID   string
+	Problems []string
+}
+
+// SnapshotIteration records HEAD, the dirty paths, the completed tasks,
+// and the task expected to be picked before an iteration runs.
+func SnapshotIteration(cfg LoopConfig) IterationSnapshot {
+	snap := IterationSnapshot{HeadSHA: GitHeadSHA(cfg.ProjectDir), Completed: map[string]bool{}, Dirty: map[string]bool{}}
+	if dirty, err := GitChangedPaths(cfg.ProjectDir); err == nil {
+		for _, p := range dirty {
+			snap.Dirty[p] = true
+		}
+	}
+	if prd, err := cfg.store().LoadPRD(); err == nil {
+		snap.Completed = CompletedTaskIDs(prd)
+		if next := prd.GetNextTaskFor(TaskFilter{Milestone: cfg.Milestone}); next != nil {
+			snap.NextTaskID = next.ID
+		}
+	}
+	return snap
+}
+
+// CheckCommitPolicy returns what the commit subjects and uncommitted
+// paths of one iteration violate for the task with the given ID.
+func (p CommitPolicy) CheckCommitPolicy(taskID string, subjects, dirty []string) []string {
+	var problems []string
+	if p.RequireClean {
+		var uncommitted []string
+		for _, path := range dirty {
+			if !underAnyPath(filepath.ToSlash(path), []string{AutoDir}) {
+				uncommitted = append(uncommitted, path)
+			}
+		}
+		if len(uncommitted) > 0 {
+			problems = append(problems, fmt.Sprintf("uncommitted changes: %s", strings.Join(uncommitted, ", ")))
+		}
+	}
+	if p.SingleCommit && len(subjects) != 1 {
+		problems = append(problems, fmt.Sprintf("expected exactly one commit for the task, found %d", len(subjects)))
+	}
+	for _, s := range subjects {
+		if p.RequireTaskID && !subjectReferencesTask(s, taskID) {
+			problems = append(problems, fmt.Sprintf("commit %q does not reference task %s", s, taskID))
+		}
+		if p.Conventional && !conventionalCommitPattern.MatchString(s) {
+			problems = append(problems, fmt.Sprintf("commit %q is not a conventional commit (type(scope): description)", s))
+		}
+	}
+	return problems
+}
+
+// subjectReferencesTask reports whether subject mentions taskID as a
+// whole ID, so "1.1" is not found in "1.10", "11.1" or "1.1.2". A
+// sentence-ending period after the ID still counts.
+func subjectReferencesTask(subject, taskID string) bool {
+	for i := 0; taskID != ""; {
+		j := strings.Index(subject[i:], taskID)
+		if j < 0 {
+			return false
+		}
+		start, end := i+j, i+j+len(taskID)
+		before := start > 0 && (isDigit(subject[start-1]) || subject[start-1] == '.')
+		after := end < len(subject) && (isDigit(subject[end]) ||
+			(subject[end] == '.' && end+1 < len(subject) && isDigit(subject[end+1])))
+		if !before && !after {
+			return true
+		}
+		i = start + 1
+	}
+	return false
+}
+
+func isDigit(b byte) bool {
+	return '0' <= b && b <= '9'
+}
+
+// attributeCommitSubjects assigns each subject to the task IDs it
+// references. Subjects that reference none of them are attributed to every
+// task, since nothing says which task they belong to; with a single task
+// that is simply all subjects.
+func attributeCommitSubjects(subjects, taskIDs []string) map[string][]string {
+	attributed := make(map[string][]string, len(taskIDs))
+	for _, s := range subjects {
+		var owners []string
+		for _, id := range taskIDs {
+			if subjectReferencesTask(s, id) {
+				owners = append(owners, id)
+			}
+		}
+		if len(owners) == 0 {
+			owners = taskIDs
+		}
+		for _, id := range owners {
+			attributed[id] = append(attributed[id], s)
+		}
+	}
+	return attributed
+}
+
+// EnforceCommitPolicy checks every task completed since snap against
+// cfg.CommitPolicy, each against the commits attributed to it. Paths that
+// were dirty before the iteration are the user's and are not checked.
+// Violating tasks are reset to pending with a system note listing the
+// problems, and prd.json is saved.
+func EnforceCommitPolicy(cfg LoopConfig, snap IterationSnapshot) ([]CommitPolicyViolation, error) {
+	if cfg.CommitPolicy == nil {
+		return nil, nil
+	}
+	prd, err := cfg.store().LoadPRD()
+	if err != nil {
+		return nil, err
+	}
+	subjects, err := GitCommitSubjects(cfg.ProjectDir, snap.HeadSHA)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := GitChangedPaths(cfg.ProjectDir)
+	if err != nil {
+		return nil, err
+	}
+	var dirty []string
+	for _, path := range changed {
+		if !snap.Dirty[path] {
+			dirty = append(dirty, path)
+		}
+	}
+
+	var completed []string
+	for _, task := range prd.Tasks {
+		if task.Status == TaskStatusCompleted && !snap.Completed[task.ID] {
+			completed = append(completed, task.ID)
+		}
+	}
+	attributed := attributeCommitSubjects(subjects, completed)
+
+	var violations []CommitPolicyViolation
+	for i := range prd.Tasks {
+		task := &prd.Tasks[i]
+		if task.Status != TaskStatusCompleted || snap.Completed[task.ID] {
+			continue
+		}
+		problems := cfg.CommitPolicy.CheckCommitPolicy(task.ID, attributed[task.ID], dirty)
+		if len(problems) == 0 {
+			continue
+		}
+		violations = append(violations, CommitPolicyViolation{TaskID: task.ID, Problems: problems})
+		task.Status, task.CompletedAt, task.CommitSHA = TaskStatusPending, "", ""
+		task.Notes = append(task.Notes, TaskNote{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Author:    NoteAuthorSystem,
+			Text:      "Reopened: the commit policy was violated. Fix before completing again: " + strings.Join(problems, "; "),
+		})
+	}
+	if len(violations) == 0 {
+		return nil, nil
+	}
+	prd.RecalculateProgress()
+	return violations, cfg.store().SavePRD(prd)
+}
+
+// ApplyCommitPolicy enforces the commit policy after an iteration and
+// reports the outcome through cfg.OnCommitPolicy.
+func ApplyCommitPolicy(cfg LoopConfig, iter int, snap IterationSnapshot) {
+	violations, err := EnforceCommitPolicy(cfg, snap)
+	if cfg.OnCommitPolicy != nil && (err != nil || len(violations) > 0) {
+		cfg.OnCommitPolicy(iter, violations, err)
+	}
+}
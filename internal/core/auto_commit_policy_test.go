@@ -0,0 +1,268 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCommitPolicy_CheckCommitPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   CommitPolicy
+		subjects []string
+		dirty    []string
+		want     int
+	}{
+		{name: "empty_policy", policy: CommitPolicy{}, subjects: []string{"wip"}, dirty: []string{"main.go"}, want: 0},
+		{name: "clean", policy: CommitPolicy{RequireClean: true}, subjects: []string{"x"}, want: 0},
+		{name: "dirty", policy: CommitPolicy{RequireClean: true}, dirty: []string{"main.go", "go.sum"}, want: 1},
+		{name: "auto_files_ignored", policy: CommitPolicy{RequireClean: true}, dirty: []string{AutoDir + "/prd.json", AutoDir + "/progress.md"}, want: 0},
+		{name: "single_commit", policy: CommitPolicy{SingleCommit: true}, subjects: []string{"feat: 1.2 add"}, want: 0},
+		{name: "no_commit", policy: CommitPolicy{SingleCommit: true}, want: 1},
+		{name: "two_commits", policy: CommitPolicy{SingleCommit: true}, subjects: []string{"a", "b"}, want: 1},
+		{name: "task_id_present", policy: CommitPolicy{RequireTaskID: true}, subjects: []string{"feat(api): 1.2 - add endpoint"}, want: 0},
+		{name: "task_id_missing", policy: CommitPolicy{RequireTaskID: true}, subjects: []string{"feat(api): add endpoint", "fix: 1.2 typo"}, want: 1},
+		{name: "conventional", policy: CommitPolicy{Conventional: true}, subjects: []string{"fix!: handle nil config"}, want: 0},
+		{name: "not_conventional", policy: CommitPolicy{Conventional: true}, subjects: []string{"Added endpoint", "feature: x"}, want: 2},
+		{name: "all_rules", policy: CommitPolicy{RequireClean: true, SingleCommit: true, RequireTaskID: true, Conventional: true}, subjects: []string{"stuff"}, dirty: []string{"a.go"}, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.CheckCommitPolicy("1.2", tt.subjects, tt.dirty)
+			if len(got) != tt.want {
+				t.Errorf("CheckCommitPolicy() = %v, want %d problems", got, tt.want)
+			}
+		})
+	}
+}
+
+// setupPolicyRepo creates a git repository with an initial commit and a
+// prd.json holding the given tasks.
+func setupPolicyRepo(t *testing.T, tasks []AutoTask) LoopConfig {
+	t.Helper()
+	requireGit(t)
+	isolateGitConfig(t)
+	dir := t.TempDir()
+	if err := GitInit(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := GitSetUserIdentity(dir, "Dev", "dev@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if err := GitInitialCommit(dir, "chore: initial commit"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, AutoDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	prd := NewAutoPRD("test", "")
+	prd.Tasks = tasks
+	cfg := LoopConfig{ProjectDir: dir, PRDPath: GetAutoPRDPath(dir)}
+	if err := prd.Save(cfg.PRDPath); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+// commitFile writes name and commits it with the given message.
+func commitFile(t *testing.T, dir, name, message string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(message), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runGit(dir, "add", name); err != nil {
+		t.Fatalf("git add: %s", out)
+	}
+	if out, err := runGit(dir, "commit", "-m", message); err != nil {
+		t.Fatalf("git commit: %s", out)
+	}
+}
+
+// completeTask marks the task with the given ID completed in prd.json.
+func completeTask(t *testing.T, cfg LoopConfig, id string) {
+	t.Helper()
+	prd, err := LoadAutoPRD(cfg.PRDPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prd.GetTask(id).Status = TaskStatusCompleted
+	prd.GetTask(id).CommitSHA = GitHeadSHA(cfg.ProjectDir)
+	if err := prd.Save(cfg.PRDPath); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnforceCommitPolicy(t *testing.T) {
+	policy := &CommitPolicy{RequireClean: true, SingleCommit: true, RequireTaskID: true, Conventional: true}
+	tests := []struct {
+		name       string
+		policy     *CommitPolicy
+		message    string
+		leaveDirty bool
+		wantReopen bool
+	}{
+		{name: "compliant", policy: policy, message: "feat(api): 1 - add endpoint"},
+		{name: "missing_task_id", policy: policy, message: "feat(api): add endpoint", wantReopen: true},
+		{name: "uncommitted_changes", policy: policy, message: "feat: 1 - add endpoint", leaveDirty: true, wantReopen: true},
+		{name: "no_policy", message: "wip", leaveDirty: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := setupPolicyRepo(t, []AutoTask{
+				{ID: "1", Title: "Add endpoint", Status: TaskStatusPending},
+				{ID: "2", Title: "Earlier work", Status: TaskStatusCompleted},
+			})
+			cfg.CommitPolicy = tt.policy
+			snap := SnapshotIteration(cfg)
+
+			commitFile(t, cfg.ProjectDir, "api.go", tt.message)
+			if tt.leaveDirty {
+				os.WriteFile(filepath.Join(cfg.ProjectDir, "scratch.go"), []byte("x"), 0644)
+			}
+			completeTask(t, cfg, "1")
+
+			violations, err := EnforceCommitPolicy(cfg, snap)
+			if err != nil {
+				t.Fatalf("EnforceCommitPolicy: %v", err)
+			}
+			if (len(violations) > 0) != tt.wantReopen {
+				t.Fatalf("violations = %+v, wantReopen %v", violations, tt.wantReopen)
+			}
+
+			prd, err := LoadAutoPRD(cfg.PRDPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			task := prd.GetTask("1")
+			if !tt.wantReopen {
+				if task.Status != TaskStatusCompleted || len(task.Notes) != 0 {
+					t.Errorf("task = %+v, want completed without notes", task)
+				}
+				return
+			}
+			if task.Status != TaskStatusPending || task.CommitSHA != "" {
+				t.Errorf("task = %+v, want reopened as pending", task)
+			}
+			if len(task.Notes) != 1 || task.Notes[0].Author != NoteAuthorSystem {
+				t.Errorf("notes = %+v, want one system note", task.Notes)
+			}
+			if prd.GetTask("2").Status != TaskStatusCompleted {
+				t.Error("task completed before the iteration should not be checked")
+			}
+			if prd.Progress.CompletedTasks != 1 {
+				t.Errorf("completed tasks = %d, want 1", prd.Progress.CompletedTasks)
+			}
+		})
+	}
+}
+
+func TestGitChangedPathsAndSubjects(t *testing.T) {
+	cfg := setupPolicyRepo(t, nil)
+	dir := cfg.ProjectDir
+	base := GitHeadSHA(dir)
+
+	commitFile(t, dir, "a.go", "feat: a")
+	commitFile(t, dir, "b.go", "fix: b")
+	subjects, err := GitCommitSubjects(dir, base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subjects) != 2 || subjects[0] != "feat: a" || subjects[1] != "fix: b" {
+		t.Errorf("GitCommitSubjects() = %v, want [feat: a fix: b]", subjects)
+	}
+
+	os.WriteFile(filepath.Join(dir, "a.go"), []byte("changed"), 0644)
+	os.MkdirAll(filepath.Join(dir, "pkg"), 0755)
+	os.WriteFile(filepath.Join(dir, "pkg", "new.go"), []byte("new"), 0644)
+	paths, err := GitChangedPaths(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]bool{"a.go": true, "pkg/new.go": true, AutoDir + "/prd.json": true}
+	if len(paths) != len(want) {
+		t.Fatalf("GitChangedPaths() = %v, want %v", paths, want)
+	}
+	for _, p := range paths {
+		if !want[p] {
+			t.Errorf("unexpected changed path %q", p)
+		}
+	}
+}
+
+func TestSubjectReferencesTask(t *testing.T) {
+	tests := []struct {
+		subject string
+		id      string
+		want    bool
+	}{
+		{"feat(api): 1.2 - add endpoint", "1.2", true},
+		{"fix: finish 1.2.", "1.2", true},
+		{"1.2: start", "1.2", true},
+		{"feat: 1.20 - other", "1.2", false},
+		{"feat: 11.2 - other", "1.2", false},
+		{"feat: 1.2.3 - subtask", "1.2", false},
+		{"feat: 1.20 and 1.2", "1.2", true},
+		{"feat: add endpoint", "1.2", false},
+	}
+	for _, tt := range tests {
+		if got := subjectReferencesTask(tt.subject, tt.id); got != tt.want {
+			t.Errorf("subjectReferencesTask(%q, %q) = %v, want %v", tt.subject, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestEnforceCommitPolicy_PreexistingDirtyIgnored(t *testing.T) {
+	cfg := setupPolicyRepo(t, []AutoTask{{ID: "1", Title: "Add endpoint", Status: TaskStatusPending}})
+	cfg.CommitPolicy = &CommitPolicy{RequireClean: true}
+	commitFile(t, cfg.ProjectDir, "notes.md", "chore: track notes")
+	os.WriteFile(filepath.Join(cfg.ProjectDir, "notes.md"), []byte("user edit"), 0644)
+	os.WriteFile(filepath.Join(cfg.ProjectDir, "draft.go"), []byte("user draft"), 0644)
+	snap := SnapshotIteration(cfg)
+
+	commitFile(t, cfg.ProjectDir, "api.go", "feat: 1 - add endpoint")
+	completeTask(t, cfg, "1")
+	violations, err := EnforceCommitPolicy(cfg, snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("violations = %+v, want none for changes made before the iteration", violations)
+	}
+
+	os.WriteFile(filepath.Join(cfg.ProjectDir, "scratch.go"), []byte("agent leftover"), 0644)
+	violations, err = EnforceCommitPolicy(cfg, snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0].Problems[0], "scratch.go") {
+		t.Errorf("violations = %+v, want scratch.go reported", violations)
+	}
+}
+
+func TestEnforceCommitPolicy_SeveralTasks(t *testing.T) {
+	cfg := setupPolicyRepo(t, []AutoTask{
+		{ID: "1", Title: "First", Status: TaskStatusPending},
+		{ID: "2", Title: "Second", Status: TaskStatusPending},
+	})
+	cfg.CommitPolicy = &CommitPolicy{SingleCommit: true, RequireTaskID: true}
+	snap := SnapshotIteration(cfg)
+
+	commitFile(t, cfg.ProjectDir, "one.go", "feat: 1 - first")
+	commitFile(t, cfg.ProjectDir, "two.go", "feat: 2 - second")
+	commitFile(t, cfg.ProjectDir, "two_fix.go", "fix: 2 - second again")
+	completeTask(t, cfg, "1")
+	completeTask(t, cfg, "2")
+
+	violations, err := EnforceCommitPolicy(cfg, snap)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || violations[0].TaskID != "2" {
+		t.Fatalf("violations = %+v, want only task 2 (two commits)", violations)
+	}
+	if got := strings.Join(violations[0].Problems, "; "); !strings.Contains(got, "found 2") || strings.Contains(got, "does not reference") {
+		t.Errorf("problems = %s", got)
+	}
+}
@@ -0,0 +1,170 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// ContextSizeWarnBytes is the combined size of the curated context files
+// above which registering more warns: the agent reads all of them into a
+// fresh context window on every iteration.
+const ContextSizeWarnBytes = 64 * 1024
+
+// ContextFile is a file matched by a registered context path or glob.
+type ContextFile struct {
+	Path string
+	Size int64
+}
+
+// ContextMatch is a registered context path or glob with the files it
+// currently matches.
+type ContextMatch struct {
+	Pattern string
+	Files   []ContextFile
+}
+
+// NormalizeContextPattern cleans a context path or glob given relative to
+// the project root, rejecting paths outside the project.
+func NormalizeContextPattern(pattern string) (string, error) {
+	trimmed := strings.TrimSpace(pattern)
+	p := path.Clean(filepath.ToSlash(trimmed))
+	if trimmed == "" || p == "." || p == ".." || strings.HasPrefix(p, "../") || path.IsAbs(p) || filepath.IsAbs(trimmed) {
+		return "", fmt.Errorf("invalid context path: %q (must be inside the project)", pattern)
+	}
+	if _, err := path.Match(p, ""); err != nil {
+		return "", fmt.Errorf("invalid context glob: %q", pattern)
+	}
+	return p, nil
+}
+
+// ResolveContextPattern lists the files in projectDir matching a context
+// path or glob. A directory stands for every file below it and "**"
+// matches any number of directories, as in path_guard.allowed_paths. Only
+// the part of the tree below the pattern's literal prefix is walked.
+func ResolveContextPattern(projectDir, pattern string) (ContextMatch, error) {
+	match := ContextMatch{Pattern: pattern}
+	root := filepath.Join(projectDir, filepath.FromSlash(globLiteralPrefix(pattern)))
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return nil // nothing matches
+			}
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(projectDir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if matchGlobPath(pattern, rel) || matchGlobPath(pattern+"/**", rel) {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			match.Files = append(match.Files, ContextFile{Path: rel, Size: info.Size()})
+		}
+		return nil
+	})
+	if err != nil {
+		return match, fmt.Errorf("failed to resolve context path %s: %w", pattern, err)
+	}
+	return match, nil
+}
+
+// globLiteralPrefix returns the leading path segments of pattern that
+// contain no glob characters.
+func globLiteralPrefix(pattern string) string {
+	var literal []string
+	for _, segment := range strings.Split(pattern, "/") {
+		if strings.ContainsAny(segment, `*?[\`) {
+			break
+		}
+		literal = append(literal, segment)
+	}
+	return path.Join(literal...)
+}
+
+// ResolveContextFiles resolves every context path registered in c.
+func (c *AutoConfig) ResolveContextFiles(projectDir string) ([]ContextMatch, error) {
+	var matches []ContextMatch
+	for _, pattern := range c.ContextFiles {
+		match, err := ResolveContextPattern(projectDir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// AddContextFiles registers context paths or globs, each of which must
+// match at least one file in projectDir. It returns the matches of the
+// patterns that were not registered yet; nothing is registered on error.
+func (c *AutoConfig) AddContextFiles(projectDir string, patterns []string) ([]ContextMatch, error) {
+	var added []ContextMatch
+	for _, raw := range patterns {
+		pattern, err := NormalizeContextPattern(raw)
+		if err != nil {
+			return nil, err
+		}
+		if slices.Contains(c.ContextFiles, pattern) ||
+			slices.ContainsFunc(added, func(m ContextMatch) bool { return m.Pattern == pattern }) {
+			continue
+		}
+		match, err := ResolveContextPattern(projectDir, pattern)
+		if err != nil {
+			return nil, err
+		}
+		if len(match.Files) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		added = append(added, match)
+	}
+	for _, match := range added {
+		c.ContextFiles = append(c.ContextFiles, match.Pattern)
+	}
+	return added, nil
+}
+
+// RemoveContextFiles unregisters context paths; each must be registered.
+func (c *AutoConfig) RemoveContextFiles(patterns []string) error {
+	for _, raw := range patterns {
+		pattern, err := NormalizeContextPattern(raw)
+		if err != nil {
+			return err
+		}
+		i := slices.Index(c.ContextFiles, pattern)
+		if i < 0 {
+			return fmt.Errorf("not a context path: %s (see 'samuel auto context list')", pattern)
+		}
+		c.ContextFiles = slices.Delete(c.ContextFiles, i, i+1)
+	}
+	return nil
+}
+
+// ContextBytes returns the combined size of the files matched, counting a
+// file matched by several patterns once.
+func ContextBytes(matches []ContextMatch) int64 {
+	seen := make(map[string]bool)
+	var total int64
+	for _, m := range matches {
+		for _, f := range m.Files {
+			if !seen[f.Path] {
+				seen[f.Path] = true
+				total += f.Size
+			}
+		}
+	}
+	return total
+}
@@ -0,0 +1,102 @@
+package core
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func setupContextProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "docs", "architecture.md"), "# Architecture\n")
+	writeTestFile(t, filepath.Join(dir, "docs", "adr", "0001-storage.md"), "# ADR 1\n")
+	writeTestFile(t, filepath.Join(dir, "docs", "adr", "old", "0000-draft.md"), "# Draft\n")
+	writeTestFile(t, filepath.Join(dir, "api", "openapi.yaml"), "openapi: 3.1.0\n")
+	writeTestFile(t, filepath.Join(dir, ".git", "docs", "x.md"), "")
+	return dir
+}
+
+func contextPaths(m ContextMatch) []string {
+	var paths []string
+	for _, f := range m.Files {
+		paths = append(paths, f.Path)
+	}
+	return paths
+}
+
+func TestNormalizeContextPattern(t *testing.T) {
+	for pattern, want := range map[string]string{
+		"docs/architecture.md": "docs/architecture.md",
+		"./docs//adr/":         "docs/adr",
+		" api/*.yaml ":         "api/*.yaml",
+	} {
+		if got, err := NormalizeContextPattern(pattern); err != nil || got != want {
+			t.Errorf("NormalizeContextPattern(%q) = %q, %v; want %q", pattern, got, err, want)
+		}
+	}
+	for _, pattern := range []string{"", ".", "../secrets.md", "/etc/passwd", "docs/[", "docs/../../x"} {
+		if _, err := NormalizeContextPattern(pattern); err == nil {
+			t.Errorf("NormalizeContextPattern(%q) succeeded", pattern)
+		}
+	}
+}
+
+func TestResolveContextPattern(t *testing.T) {
+	dir := setupContextProject(t)
+	for pattern, want := range map[string][]string{
+		"docs/architecture.md": {"docs/architecture.md"},
+		"docs/adr":             {"docs/adr/0001-storage.md", "docs/adr/old/0000-draft.md"},
+		"docs/adr/*.md":        {"docs/adr/0001-storage.md"},
+		"**/*.md":              {"docs/adr/0001-storage.md", "docs/adr/old/0000-draft.md", "docs/architecture.md"},
+		"missing/*.md":         nil,
+	} {
+		match, err := ResolveContextPattern(dir, pattern)
+		if err != nil {
+			t.Fatalf("ResolveContextPattern(%q) error = %v", pattern, err)
+		}
+		if got := contextPaths(match); !slices.Equal(got, want) {
+			t.Errorf("ResolveContextPattern(%q) = %v, want %v", pattern, got, want)
+		}
+	}
+}
+
+func TestAutoConfig_ContextFiles(t *testing.T) {
+	dir := setupContextProject(t)
+	var config AutoConfig
+
+	added, err := config.AddContextFiles(dir, []string{"docs/architecture.md", "docs/adr/*.md", "./docs/architecture.md"})
+	if err != nil || len(added) != 2 {
+		t.Fatalf("AddContextFiles() = %v, %v", added, err)
+	}
+	if added, _ := config.AddContextFiles(dir, []string{"docs/architecture.md"}); len(added) != 0 {
+		t.Errorf("re-adding registered %v", added)
+	}
+	if _, err := config.AddContextFiles(dir, []string{"api/openapi.yaml", "docs/missing.md"}); err == nil {
+		t.Error("AddContextFiles() with a missing file succeeded")
+	}
+	if !slices.Equal(config.ContextFiles, []string{"docs/architecture.md", "docs/adr/*.md"}) {
+		t.Errorf("ContextFiles = %v; a failed add should register nothing", config.ContextFiles)
+	}
+
+	if _, err := config.AddContextFiles(dir, []string{"docs"}); err != nil {
+		t.Fatal(err)
+	}
+	matches, err := config.ResolveContextFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total := ContextBytes(matches); total != int64(len("# Architecture\n")+len("# ADR 1\n")+len("# Draft\n")) {
+		t.Errorf("ContextBytes() = %d; files matched twice should count once", total)
+	}
+
+	if err := config.RemoveContextFiles([]string{"docs/adr/*.md", "docs"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.RemoveContextFiles([]string{"docs/adr/*.md"}); err == nil {
+		t.Error("removing an unregistered path succeeded")
+	}
+	if !slices.Equal(config.ContextFiles, []string{"docs/architecture.md"}) {
+		t.Errorf("ContextFiles = %v", config.ContextFiles)
+	}
+}
@@ -4,23 +4,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"regexp"
 	"strings"
 	"time"
-)
 
-// taskLineRegex parses task lines from the generate-tasks skill output format:
-//
-//	"- [ ] 1.0 Task title [~3,000 tokens - Medium]"
-//
-// Groups: (1) indentation, (2) checkbox, (3) task ID, (4) title, (5) complexity
-var taskLineRegex = regexp.MustCompile(
-	`^(\s*)- \[([ xX])\]\s*(\d+\.\d+)\s+(.+?)(?:\s*\[~[\d,]+\s+tokens?\s*-\s*(\w+)\])?\s*$`,
+	"github.com/ar4mirez/samuel/pkg/parse"
 )
 
-// prdTitleRegex extracts the title from a PRD markdown H1 heading
-var prdTitleRegex = regexp.MustCompile(`^#\s+(.+)$`)
-
 // ConvertMarkdownToPRD converts a PRD markdown file and optional task list
 // into a structured AutoPRD. If tasksPath is empty, only project metadata
 // is extracted from the PRD.
@@ -53,15 +42,9 @@ func ConvertMarkdownToPRD(prdPath, tasksPath string) (*AutoPRD, error) {
 
 // extractPRDMetadata extracts name and description from PRD markdown content
 func extractPRDMetadata(content string) (name, description string) {
-	lines := strings.Split(content, "\n")
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-		if matches := prdTitleRegex.FindStringSubmatch(trimmed); matches != nil {
-			name = slugify(matches[1])
-			description = matches[1]
-			break
-		}
+	if title := parse.PRDTitle(content); title != "" {
+		name = slugify(title)
+		description = title
 	}
 
 	if name == "" {
@@ -96,72 +79,94 @@ func slugify(s string) string {
 //   - [ ] 1.0 Parent Task Title
 //   - [ ] 1.1 Sub-task description [~2,000 tokens - Simple]
 func ParseTaskMarkdown(content string) ([]AutoTask, error) {
-	lines := strings.Split(content, "\n")
-	var tasks []AutoTask
-	var currentParentID string
-
-	for _, line := range lines {
-		task, err := parseTaskLine(line)
-		if err != nil {
-			continue // Skip non-task lines leniently
-		}
-		if task == nil {
-			continue
-		}
+	parsed, err := parse.TaskMarkdown(content)
+	if err != nil {
+		return nil, err
+	}
 
-		// Determine parent-child relationship from indentation
-		if isChildTask(line) {
-			task.ParentID = currentParentID
-			if currentParentID != "" {
-				task.DependsOn = []string{currentParentID}
-			}
-		} else {
-			currentParentID = task.ID
+	tasks := make([]AutoTask, 0, len(parsed))
+	for _, p := range parsed {
+		task := newTaskFromMarkdown(p)
+		if p.ParentID != "" {
+			task.ParentID = p.ParentID
+			task.DependsOn = []string{p.ParentID}
 		}
-
 		tasks = append(tasks, *task)
 	}
-
-	if len(tasks) == 0 {
-		return nil, fmt.Errorf("no valid tasks found in markdown")
-	}
 	return tasks, nil
 }
 
 // parseTaskLine parses a single markdown task line into an AutoTask.
 // Returns nil, nil for non-task lines (not an error, just not a match).
 func parseTaskLine(line string) (*AutoTask, error) {
-	matches := taskLineRegex.FindStringSubmatch(line)
-	if matches == nil {
+	p, ok := parse.TaskLine(line)
+	if !ok {
 		return nil, nil
 	}
+	return newTaskFromMarkdown(p), nil
+}
 
-	checkbox := matches[2]
-	taskID := matches[3]
-	title := strings.TrimSpace(matches[4])
-	complexity := strings.ToLower(strings.TrimSpace(matches[5]))
-
+// newTaskFromMarkdown converts a parsed task line into a pending or
+// completed AutoTask, defaulting unknown complexities to medium.
+func newTaskFromMarkdown(p parse.Task) *AutoTask {
 	status := TaskStatusPending
-	if checkbox == "x" || checkbox == "X" {
+	if p.Done {
 		status = TaskStatusCompleted
 	}
 
+	complexity := p.Complexity
 	if !isValidComplexity(complexity) {
 		complexity = TaskComplexityMedium
 	}
 
-	return &AutoTask{
-		ID:         taskID,
-		Title:      title,
+	task := &AutoTask{
+		ID:         p.ID,
+		Title:      p.Title,
 		Status:     status,
 		Complexity: complexity,
 		Priority:   TaskPriorityMedium,
-	}, nil
+	}
+	task.Description, task.AcceptanceCriteria = splitTaskDetails(p.Details)
+	return task
 }
 
-// isChildTask checks if a task line is indented (child task)
-func isChildTask(line string) bool {
-	return len(line) > 0 && (line[0] == ' ' || line[0] == '\t')
+// acceptancePrefixes introduce acceptance criteria in task details.
+var acceptancePrefixes = []string{"acceptance criteria:", "acceptance:", "done when:"}
+
+// splitTaskDetails separates the detail lines under a task into its
+// description and acceptance criteria. A line starting with one of
+// acceptancePrefixes is a criterion; a prefix alone on its line makes
+// every line after it a criterion.
+func splitTaskDetails(details []string) (string, []string) {
+	var description, criteria []string
+	inCriteria := false
+	for _, line := range details {
+		text := strings.TrimSpace(strings.TrimLeft(line, "-*"))
+		if rest, ok := cutAcceptancePrefix(text); ok {
+			if rest == "" {
+				inCriteria = true
+			} else {
+				criteria = append(criteria, rest)
+			}
+			continue
+		}
+		if inCriteria {
+			criteria = append(criteria, text)
+		} else {
+			description = append(description, line)
+		}
+	}
+	return strings.Join(description, "\n"), criteria
+}
+
+func cutAcceptancePrefix(text string) (string, bool) {
+	lower := strings.ToLower(text)
+	for _, prefix := range acceptancePrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return strings.TrimSpace(text[len(prefix):]), true
+		}
+	}
+	return "", false
 }
 
 func isValidComplexity(c string) bool {
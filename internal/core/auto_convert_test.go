@@ -3,6 +3,7 @@ package core
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 )
 
@@ -431,3 +432,25 @@ func TestFindTasksFile(t *testing.T) {
 		t.Errorf("expected %s, got %s", tasksPath, got)
 	}
 }
+
+func TestSplitTaskDetails(t *testing.T) {
+	tests := []struct {
+		name         string
+		details      []string
+		wantDesc     string
+		wantCriteria []string
+	}{
+		{"none", nil, "", nil},
+		{"description", []string{"Add the form.", "Use the design system."}, "Add the form.\nUse the design system.", nil},
+		{"inline", []string{"Add the form.", "- Acceptance: it submits", "* Done when: errors show"}, "Add the form.", []string{"it submits", "errors show"}},
+		{"block", []string{"Add the form.", "Acceptance criteria:", "- it submits", "- errors show"}, "Add the form.", []string{"it submits", "errors show"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, criteria := splitTaskDetails(tt.details)
+			if desc != tt.wantDesc || !slices.Equal(criteria, tt.wantCriteria) {
+				t.Errorf("splitTaskDetails() = %q, %q; want %q, %q", desc, criteria, tt.wantDesc, tt.wantCriteria)
+			}
+		})
+	}
+}
@@ -100,6 +100,8 @@ func GenerateDiscoveryPrompt(config AutoConfig, pilot *PilotConfig) string {
 		}
 	}
 
+	sb.WriteString(contextFilesSection("##", config.ContextFiles))
+
 	if len(config.QualityChecks) > 0 {
 		sb.WriteString("\n## Quality Checks Reference\n\n")
 		sb.WriteString("These are the project's quality check commands:\n\n")
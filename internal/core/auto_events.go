@@ -0,0 +1,186 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AutoEventLogFile is the append-only iteration event log in the auto
+// directory, one JSON object per line.
+const AutoEventLogFile = "events.jsonl"
+
+// FileChangeWarnThreshold is the number of touched files in one iteration
+// above which status output flags the iteration as suspicious.
+const FileChangeWarnThreshold = 100
+
+// maxEventSamplePaths caps the paths kept per change kind in an event.
+const maxEventSamplePaths = 10
+
+// gitEmptyTree is the hash of git's empty tree, used as the base when the
+// repository had no commits before an iteration.
+const gitEmptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// FileChangeSummary counts the files an iteration added, modified, and
+// deleted, committed or not. Paths under .claude/auto/ are excluded.
+// Sample holds up to ten example paths for a quick look.
+type FileChangeSummary struct {
+	Added    int      `json:"added"`
+	Modified int      `json:"modified"`
+	Deleted  int      `json:"deleted"`
+	Sample   []string `json:"sample,omitempty"`
+}
+
+// Total returns the number of files touched.
+func (s FileChangeSummary) Total() int {
+	return s.Added + s.Modified + s.Deleted
+}
+
+// IterationEvent is one entry of the iteration event log. Task is the
+// task the iteration was given, and Variants the active variant of each
+// skill under experiment (see RunningSkillExperiments).
+type IterationEvent struct {
+	Timestamp string            `json:"timestamp"`
+	Iteration int               `json:"iteration"`
+	Type      string            `json:"type"`
+	Task      string            `json:"task,omitempty"`
+	Error     string            `json:"error,omitempty"`
+	Changes   FileChangeSummary `json:"changes"`
+	Variants  map[string]string `json:"variants,omitempty"`
+}
+
+// GetAutoEventLogPath returns the path to the iteration event log.
+func GetAutoEventLogPath(projectDir string) string {
+	return filepath.Join(projectDir, AutoDir, AutoEventLogFile)
+}
+
+// FileChange is one path changed since a commit, with its git status
+// letter: "A" (added), "M" (modified), or "D" (deleted).
+type FileChange struct {
+	Status string
+	Path   string
+}
+
+// GitFileChangeList lists the paths that differ between fromSHA and the
+// working tree, including untracked files. An empty fromSHA compares
+// against the empty tree. Paths under .claude/auto/ are excluded.
+func GitFileChangeList(dir, fromSHA string) ([]FileChange, error) {
+	if fromSHA == "" {
+		fromSHA = gitEmptyTree
+	}
+	out, err := runGit(dir, "diff", "--name-status", "--no-renames", fromSHA)
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %s", out)
+	}
+	untracked, err := runGit(dir, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %s", untracked)
+	}
+
+	lines := strings.Split(out, "\n")
+	for _, path := range strings.Split(untracked, "\n") {
+		lines = append(lines, "A\t"+path)
+	}
+	var changes []FileChange
+	for _, line := range lines {
+		status, path, ok := strings.Cut(line, "\t")
+		if !ok || path == "" || underAnyPath(path, []string{AutoDir}) {
+			continue
+		}
+		if status != "A" && status != "D" {
+			status = "M"
+		}
+		changes = append(changes, FileChange{Status: status, Path: path})
+	}
+	return changes, nil
+}
+
+// GitFileChanges summarizes GitFileChangeList.
+func GitFileChanges(dir, fromSHA string) (FileChangeSummary, error) {
+	var summary FileChangeSummary
+	changes, err := GitFileChangeList(dir, fromSHA)
+	if err != nil {
+		return summary, err
+	}
+	for _, c := range changes {
+		switch c.Status {
+		case "A":
+			summary.Added++
+		case "D":
+			summary.Deleted++
+		default:
+			summary.Modified++
+		}
+		if len(summary.Sample) < maxEventSamplePaths {
+			summary.Sample = append(summary.Sample, c.Path)
+		}
+	}
+	return summary, nil
+}
+
+// RecordIterationEvent summarizes the files changed since snap and appends
+// the iteration to the event log.
+func RecordIterationEvent(cfg LoopConfig, iter int, iterType string, snap IterationSnapshot, iterErr error) (IterationEvent, error) {
+	event := IterationEvent{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Iteration: iter,
+		Type:      iterType,
+		Task:      snap.NextTaskID,
+		Variants:  RunningSkillExperiments(cfg.ProjectDir),
+	}
+	if iterErr != nil {
+		event.Error = iterErr.Error()
+	}
+	changes, err := GitFileChanges(cfg.ProjectDir, snap.HeadSHA)
+	if err != nil {
+		return event, err
+	}
+	event.Changes = changes
+	return event, cfg.store().AppendEvent(event)
+}
+
+// AppendIterationEvent appends one event to the event log.
+func AppendIterationEvent(projectDir string, event IterationEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	path := GetAutoEventLogPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// LoadIterationEvents reads the event log, oldest first. A missing log
+// yields no events; malformed lines are skipped.
+func LoadIterationEvents(projectDir string) ([]IterationEvent, error) {
+	f, err := os.Open(GetAutoEventLogPath(projectDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var events []IterationEvent
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var event IterationEvent
+		if json.Unmarshal(scanner.Bytes(), &event) == nil {
+			events = append(events, event)
+		}
+	}
+	return events, scanner.Err()
+}
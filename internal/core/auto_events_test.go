@@ -0,0 +1,90 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitFileChanges(t *testing.T) {
+	cfg := setupPolicyRepo(t, nil)
+	dir := cfg.ProjectDir
+	commitFile(t, dir, "keep.go", "chore: keep")
+	commitFile(t, dir, "old.go", "chore: old")
+	base := GitHeadSHA(dir)
+
+	commitFile(t, dir, "committed.go", "feat: committed")
+	os.WriteFile(filepath.Join(dir, "keep.go"), []byte("edited"), 0644)
+	os.Remove(filepath.Join(dir, "old.go"))
+	os.WriteFile(filepath.Join(dir, "untracked.go"), []byte("new"), 0644)
+
+	tests := []struct {
+		name                     string
+		from                     string
+		added, modified, deleted int
+	}{
+		{name: "since_snapshot", from: base, added: 2, modified: 1, deleted: 1},
+		{name: "no_base_commit", from: "", added: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GitFileChanges(dir, tt.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Added != tt.added || got.Modified != tt.modified || got.Deleted != tt.deleted {
+				t.Errorf("GitFileChanges() = %+v, want +%d ~%d -%d", got, tt.added, tt.modified, tt.deleted)
+			}
+			if len(got.Sample) != got.Total() {
+				t.Errorf("sample = %v, want %d paths", got.Sample, got.Total())
+			}
+		})
+	}
+}
+
+func TestRecordIterationEvent(t *testing.T) {
+	cfg := setupPolicyRepo(t, nil)
+	snap := SnapshotIteration(cfg)
+	os.WriteFile(filepath.Join(cfg.ProjectDir, "a.go"), []byte("a"), 0644)
+
+	if _, err := RecordIterationEvent(cfg, 1, IterationTypeImplementation, snap, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RecordIterationEvent(cfg, 2, IterationTypeDiscovery, snap, errors.New("agent crashed")); err != nil {
+		t.Fatal(err)
+	}
+
+	events, err := LoadIterationEvents(cfg.ProjectDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+	if events[0].Iteration != 1 || events[0].Changes.Added != 1 || events[0].Error != "" {
+		t.Errorf("first event = %+v, want iteration 1 adding a.go", events[0])
+	}
+	if events[1].Type != IterationTypeDiscovery || events[1].Error != "agent crashed" {
+		t.Errorf("second event = %+v, want failed discovery", events[1])
+	}
+}
+
+func TestLoadIterationEvents_MissingAndMalformed(t *testing.T) {
+	dir := t.TempDir()
+	events, err := LoadIterationEvents(dir)
+	if err != nil || events != nil {
+		t.Fatalf("missing log: events = %v, err = %v", events, err)
+	}
+
+	os.MkdirAll(filepath.Join(dir, AutoDir), 0755)
+	data := "not json\n{\"iteration\":3,\"type\":\"implementation\",\"changes\":{\"added\":1,\"modified\":0,\"deleted\":0}}\n"
+	os.WriteFile(GetAutoEventLogPath(dir), []byte(data), 0644)
+	events, err = LoadIterationEvents(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Iteration != 3 {
+		t.Errorf("events = %+v, want only iteration 3", events)
+	}
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// AutoHeartbeatFile is the liveness file the loop keeps in the auto
+// directory while it runs, so supervisors can detect a dead loop.
+const AutoHeartbeatFile = "heartbeat"
+
+// DefaultHeartbeatInterval is how often a running loop refreshes its
+// heartbeat when LoopConfig.HeartbeatInterval is zero.
+const DefaultHeartbeatInterval = 30 * time.Second
+
+// Heartbeat states.
+const (
+	HeartbeatRunning  = "running"
+	HeartbeatFinished = "finished"
+	HeartbeatFailed   = "failed"
+)
+
+// Heartbeat is the content of the heartbeat file. UpdatedAt is refreshed
+// every interval, also while an iteration is running, so a timestamp
+// older than a few intervals means the loop process is gone.
+type Heartbeat struct {
+	PID                int    `json:"pid"`
+	Status             string `json:"status"`
+	StartedAt          string `json:"started_at"`
+	UpdatedAt          string `json:"updated_at"`
+	Iteration          int    `json:"iteration"`
+	MaxIterations      int    `json:"max_iterations"`
+	IterationStartedAt string `json:"iteration_started_at,omitempty"`
+	IntervalSecs       int    `json:"interval_seconds"`
+	Error              string `json:"error,omitempty"`
+}
+
+// GetAutoHeartbeatPath returns the path to the heartbeat file.
+func GetAutoHeartbeatPath(projectDir string) string {
+	return filepath.Join(projectDir, AutoDir, AutoHeartbeatFile)
+}
+
+// LoadHeartbeat reads the heartbeat file of projectDir.
+func LoadHeartbeat(projectDir string) (*Heartbeat, error) {
+	data, err := os.ReadFile(GetAutoHeartbeatPath(projectDir))
+	if err != nil {
+		return nil, err
+	}
+	var hb Heartbeat
+	if err := json.Unmarshal(data, &hb); err != nil {
+		return nil, fmt.Errorf("failed to parse heartbeat: %w", err)
+	}
+	return &hb, nil
+}
+
+// Age returns how long ago the heartbeat was last refreshed, or -1 when
+// UpdatedAt cannot be parsed.
+func (h *Heartbeat) Age(now time.Time) time.Duration {
+	updated, err := time.Parse(time.RFC3339, h.UpdatedAt)
+	if err != nil {
+		return -1
+	}
+	return now.Sub(updated)
+}
+
+// CheckHealth returns nil when the heartbeat belongs to a running loop
+// refreshed within maxAge; zero maxAge means three intervals.
+func (h *Heartbeat) CheckHealth(now time.Time, maxAge time.Duration) error {
+	if maxAge <= 0 {
+		maxAge = 3 * time.Duration(max(h.IntervalSecs, 1)) * time.Second
+	}
+	switch {
+	case h.Status == HeartbeatFailed:
+		return fmt.Errorf("loop failed at iteration %d: %s", h.Iteration, h.Error)
+	case h.Status != HeartbeatRunning:
+		return fmt.Errorf("loop is not running (status: %s)", h.Status)
+	case h.Age(now) < 0:
+		return fmt.Errorf("heartbeat has an invalid updated_at: %q", h.UpdatedAt)
+	case h.Age(now) > maxAge:
+		return fmt.Errorf("heartbeat is stale: last update %s ago (pid %d)", h.Age(now).Round(time.Second), h.PID)
+	}
+	return nil
+}
+
+// LoopHeartbeat keeps the heartbeat file of one loop run current.
+type LoopHeartbeat struct {
+	mu    sync.Mutex
+	path  string
+	state Heartbeat
+	done  chan struct{}
+	wg    sync.WaitGroup
+}
+
+// StartHeartbeat writes the first heartbeat for cfg, refreshes it every
+// interval in the background and chains the iteration callbacks of cfg to
+// record the current iteration. Call Stop when the loop ends. Write errors
+// are ignored: a missing heartbeat must never stop the loop it reports on.
+func StartHeartbeat(cfg *LoopConfig) *LoopHeartbeat {
+	interval := cfg.HeartbeatInterval
+	if interval <= 0 {
+		interval = DefaultHeartbeatInterval
+	}
+	now := time.Now().UTC().Format(time.RFC3339)
+	hb := &LoopHeartbeat{
+		path: GetAutoHeartbeatPath(cfg.ProjectDir),
+		done: make(chan struct{}),
+		state: Heartbeat{
+			PID: os.Getpid(), Status: HeartbeatRunning, StartedAt: now,
+			MaxIterations: cfg.MaxIterations, IntervalSecs: int(max(interval/time.Second, 1)),
+		},
+	}
+	hb.chain(cfg)
+	hb.update(func(*Heartbeat) {})
+
+	hb.wg.Add(1)
+	go func() {
+		defer hb.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-hb.done:
+				return
+			case <-ticker.C:
+				hb.update(func(*Heartbeat) {})
+			}
+		}
+	}()
+	return hb
+}
+
+// chain wraps the iteration callbacks of cfg to track the iteration.
+func (hb *LoopHeartbeat) chain(cfg *LoopConfig) {
+	onStart := cfg.OnIterStart
+	cfg.OnIterStart = func(iter int, iterType string) {
+		hb.update(func(s *Heartbeat) {
+			s.Iteration = iter
+			s.IterationStartedAt = time.Now().UTC().Format(time.RFC3339)
+		})
+		notifyIterStart(onStart, iter, iterType)
+	}
+	onEnd := cfg.OnIterEnd
+	cfg.OnIterEnd = func(iter int, err error) {
+		hb.update(func(s *Heartbeat) { s.IterationStartedAt = "" })
+		notifyIterEnd(onEnd, iter, err)
+	}
+}
+
+// Stop ends the background refresh and records how the loop ended.
+func (hb *LoopHeartbeat) Stop(loopErr error) {
+	close(hb.done)
+	hb.wg.Wait()
+	hb.update(func(s *Heartbeat) {
+		s.Status = HeartbeatFinished
+		if loopErr != nil {
+			s.Status, s.Error = HeartbeatFailed, loopErr.Error()
+		}
+	})
+}
+
+// update applies fn to the heartbeat, stamps it and rewrites the file
+// through a temporary file so readers never see a partial write.
+func (hb *LoopHeartbeat) update(fn func(*Heartbeat)) {
+	hb.mu.Lock()
+	defer hb.mu.Unlock()
+	fn(&hb.state)
+	hb.state.UpdatedAt = time.Now().UTC().Format(time.RFC3339)
+	data, err := json.MarshalIndent(hb.state, "", "  ")
+	if err != nil {
+		return
+	}
+	tmp := hb.path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, hb.path)
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat_CheckHealth(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+	tests := []struct {
+		name    string
+		hb      Heartbeat
+		maxAge  time.Duration
+		wantErr string
+	}{
+		{name: "fresh", hb: Heartbeat{Status: HeartbeatRunning, UpdatedAt: ago(time.Minute), IntervalSecs: 30}},
+		{name: "stale by interval", hb: Heartbeat{Status: HeartbeatRunning, UpdatedAt: ago(2 * time.Minute), IntervalSecs: 30}, wantErr: "stale"},
+		{name: "max age override", hb: Heartbeat{Status: HeartbeatRunning, UpdatedAt: ago(2 * time.Minute), IntervalSecs: 30}, maxAge: 5 * time.Minute},
+		{name: "finished", hb: Heartbeat{Status: HeartbeatFinished, UpdatedAt: ago(0)}, wantErr: "not running"},
+		{name: "failed", hb: Heartbeat{Status: HeartbeatFailed, Error: "boom", UpdatedAt: ago(0)}, wantErr: "boom"},
+		{name: "bad timestamp", hb: Heartbeat{Status: HeartbeatRunning, UpdatedAt: "yesterday"}, wantErr: "invalid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.hb.CheckHealth(now, tt.maxAge)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("CheckHealth() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CheckHealth() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStartHeartbeat(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, AutoDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	var started []int
+	cfg := LoopConfig{ProjectDir: dir, MaxIterations: 5, HeartbeatInterval: 10 * time.Millisecond}
+	cfg.OnIterStart = func(iter int, _ string) { started = append(started, iter) }
+
+	hb := StartHeartbeat(&cfg)
+	cfg.OnIterStart(2, IterationTypeImplementation)
+	got, err := LoadHeartbeat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.PID != os.Getpid() || got.Status != HeartbeatRunning || got.Iteration != 2 || got.IterationStartedAt == "" {
+		t.Errorf("heartbeat = %+v, want running at iteration 2", got)
+	}
+	if len(started) != 1 || started[0] != 2 {
+		t.Errorf("chained OnIterStart calls = %v, want [2]", started)
+	}
+
+	cfg.OnIterEnd(2, nil)
+	hb.Stop(errors.New("3 consecutive failures"))
+	got, err = LoadHeartbeat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != HeartbeatFailed || got.Error != "3 consecutive failures" || got.IterationStartedAt != "" {
+		t.Errorf("heartbeat after Stop = %+v, want failed", got)
+	}
+}
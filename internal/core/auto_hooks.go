@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Iteration hook phases, named after their keys in prd.json config.
+const (
+	HookPreIteration  = "pre_iteration"
+	HookPostIteration = "post_iteration"
+)
+
+// Iteration outcomes passed to post_iteration hooks.
+const (
+	HookStatusSuccess = "success"
+	HookStatusFailed  = "failed"
+)
+
+// iterationHooks returns the commands configured for phase.
+func (cfg LoopConfig) iterationHooks(phase string) []string {
+	if phase == HookPreIteration {
+		return cfg.PreIteration
+	}
+	return cfg.PostIteration
+}
+
+// RunIterationHooks runs the commands of a hook phase one after another
+// through the platform shell in the project directory, stopping at the
+// first that fails. Hooks run on the host, also when the agent runs in a
+// sandbox, and share the loop's stdout and stderr. Each command is
+// reported to cfg.OnHook and traced as a span of the iteration.
+//
+// The iteration context is passed in the environment: SAMUEL_PHASE,
+// SAMUEL_ITERATION, SAMUEL_MAX_ITERATIONS, SAMUEL_ITERATION_TYPE,
+// SAMUEL_AI_TOOL, SAMUEL_PROJECT_DIR, SAMUEL_PRD_PATH, SAMUEL_TASK_ID (the
+// task the iteration is expected to work on) and SAMUEL_START_SHA (HEAD
+// before the agent ran). Post-iteration hooks also get SAMUEL_HEAD_SHA,
+// SAMUEL_ITERATION_STATUS (success or failed) and, on failure,
+// SAMUEL_AGENT_ERROR.
+func RunIterationHooks(cfg LoopConfig, phase string, iter int, iterType string, snap IterationSnapshot, agentErr error) error {
+	commands := cfg.iterationHooks(phase)
+	if len(commands) == 0 {
+		return nil
+	}
+	env := iterationHookEnv(cfg, phase, iter, iterType, snap, agentErr)
+	for _, command := range commands {
+		cmd := shellCommand(command)
+		cmd.Dir = cfg.ProjectDir
+		cmd.Env = env
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		started := time.Now()
+		err := cmd.Run()
+		if err != nil {
+			err = fmt.Errorf("%s hook %q failed: %w", phase, command, err)
+		}
+		cfg.trace.addSpan(spanHook, started, err,
+			otlpString("samuel.hook.phase", phase), otlpString("samuel.hook.command", command))
+		if cfg.OnHook != nil {
+			cfg.OnHook(iter, phase, command, err)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// iterationHookEnv returns the process environment plus the iteration
+// context variables described at RunIterationHooks.
+func iterationHookEnv(cfg LoopConfig, phase string, iter int, iterType string, snap IterationSnapshot, agentErr error) []string {
+	env := append(os.Environ(),
+		"SAMUEL_PHASE="+phase,
+		"SAMUEL_ITERATION="+strconv.Itoa(iter),
+		"SAMUEL_MAX_ITERATIONS="+strconv.Itoa(cfg.MaxIterations),
+		"SAMUEL_ITERATION_TYPE="+iterType,
+		"SAMUEL_AI_TOOL="+cfg.AITool,
+		"SAMUEL_PROJECT_DIR="+cfg.ProjectDir,
+		"SAMUEL_PRD_PATH="+cfg.PRDPath,
+		"SAMUEL_TASK_ID="+snap.NextTaskID,
+		"SAMUEL_START_SHA="+snap.HeadSHA,
+	)
+	if phase != HookPostIteration {
+		return env
+	}
+	if agentErr != nil {
+		env = append(env, "SAMUEL_AGENT_ERROR="+agentErr.Error())
+	}
+	return append(env,
+		"SAMUEL_HEAD_SHA="+GitHeadSHA(cfg.ProjectDir),
+		"SAMUEL_ITERATION_STATUS="+iterationStatus(agentErr),
+	)
+}
+
+// validateHookCommands reports empty commands of a hook phase.
+func validateHookCommands(phase string, commands []string) []string {
+	var errs []string
+	for i, command := range commands {
+		if strings.TrimSpace(command) == "" {
+			errs = append(errs, fmt.Sprintf("config.%s[%d]: command is empty", phase, i))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,159 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func requireShell(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook tests use sh")
+	}
+}
+
+// installFakeAgent puts a "claude" on PATH that touches agent-ran in the
+// working directory and exits with code.
+func installFakeAgent(t *testing.T, code int) {
+	t.Helper()
+	bin := t.TempDir()
+	script := "#!/bin/sh\ntouch agent-ran\nexit " + strconv.Itoa(code) + "\n"
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func readHookEnv(t *testing.T, path string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("hook did not run: %v", err)
+	}
+	env := make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if key, value, ok := strings.Cut(line, "="); ok {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+func TestRunIterationHooks_Env(t *testing.T) {
+	requireShell(t)
+	dir := t.TempDir()
+	cfg := LoopConfig{
+		ProjectDir: dir, PRDPath: GetAutoPRDPath(dir), AITool: "codex", MaxIterations: 9,
+		PreIteration:  []string{"env | grep ^SAMUEL_ > pre.env"},
+		PostIteration: []string{"env | grep ^SAMUEL_ > post.env"},
+	}
+	snap := IterationSnapshot{HeadSHA: "abc123", NextTaskID: "1.2"}
+
+	if err := RunIterationHooks(cfg, HookPreIteration, 3, IterationTypeImplementation, snap, nil); err != nil {
+		t.Fatalf("pre hooks error = %v", err)
+	}
+	if err := RunIterationHooks(cfg, HookPostIteration, 3, IterationTypeImplementation, snap, errors.New("agent crashed")); err != nil {
+		t.Fatalf("post hooks error = %v", err)
+	}
+
+	pre := readHookEnv(t, filepath.Join(dir, "pre.env"))
+	want := map[string]string{
+		"SAMUEL_PHASE": HookPreIteration, "SAMUEL_ITERATION": "3", "SAMUEL_MAX_ITERATIONS": "9",
+		"SAMUEL_ITERATION_TYPE": IterationTypeImplementation, "SAMUEL_AI_TOOL": "codex",
+		"SAMUEL_PROJECT_DIR": dir, "SAMUEL_TASK_ID": "1.2", "SAMUEL_START_SHA": "abc123",
+	}
+	for key, value := range want {
+		if pre[key] != value {
+			t.Errorf("pre %s = %q, want %q", key, pre[key], value)
+		}
+	}
+	if _, ok := pre["SAMUEL_ITERATION_STATUS"]; ok {
+		t.Error("pre hook got SAMUEL_ITERATION_STATUS")
+	}
+
+	post := readHookEnv(t, filepath.Join(dir, "post.env"))
+	if post["SAMUEL_PHASE"] != HookPostIteration || post["SAMUEL_ITERATION_STATUS"] != HookStatusFailed ||
+		post["SAMUEL_AGENT_ERROR"] != "agent crashed" {
+		t.Errorf("post env = %v", post)
+	}
+}
+
+func TestRunIterationHooks_StopsAtFailure(t *testing.T) {
+	requireShell(t)
+	dir := t.TempDir()
+	var reported []string
+	cfg := LoopConfig{
+		ProjectDir:   dir,
+		PreIteration: []string{"true", "exit 3", "touch ran"},
+		OnHook: func(iter int, phase, command string, err error) {
+			reported = append(reported, command)
+		},
+	}
+
+	err := RunIterationHooks(cfg, HookPreIteration, 1, IterationTypeImplementation, IterationSnapshot{}, nil)
+	if err == nil || !strings.Contains(err.Error(), `"exit 3"`) {
+		t.Fatalf("RunIterationHooks() error = %v, want the failing command", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(dir, "ran")); statErr == nil {
+		t.Error("hooks after the failing one ran")
+	}
+	if strings.Join(reported, ",") != "true,exit 3" {
+		t.Errorf("OnHook reported %v", reported)
+	}
+}
+
+func TestRunIteration_Hooks(t *testing.T) {
+	requireShell(t)
+	tests := []struct {
+		name      string
+		pre       []string
+		post      []string
+		agentCode int
+		wantErr   string
+		wantAgent bool
+	}{
+		{name: "hooks pass", pre: []string{"true"}, post: []string{"true"}, wantAgent: true},
+		{name: "pre hook fails", pre: []string{"exit 1"}, wantErr: "pre_iteration hook", wantAgent: false},
+		{name: "post hook fails", post: []string{"exit 1"}, wantErr: "post_iteration hook", wantAgent: true},
+		{name: "agent fails", post: []string{`test "$SAMUEL_ITERATION_STATUS" = failed`}, agentCode: 1, wantErr: "exit status 1", wantAgent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installFakeAgent(t, tt.agentCode)
+			dir := t.TempDir()
+			cfg := LoopConfig{
+				ProjectDir: dir, PRDPath: GetAutoPRDPath(dir), PromptPath: filepath.Join(dir, "prompt.md"),
+				AITool: "claude", PreIteration: tt.pre, PostIteration: tt.post,
+			}
+			if err := os.WriteFile(cfg.PromptPath, []byte("Do the next task."), 0644); err != nil {
+				t.Fatal(err)
+			}
+			var endErr error
+			cfg.OnIterEnd = func(iter int, err error) { endErr = err }
+
+			_, err := runIteration(cfg, 1, IterationTypeImplementation)
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("runIteration() error = %v, want %q", err, tt.wantErr)
+			}
+			if endErr != err {
+				t.Errorf("OnIterEnd got %v, want %v", endErr, err)
+			}
+			_, statErr := os.Stat(filepath.Join(dir, "agent-ran"))
+			if ran := statErr == nil; ran != tt.wantAgent {
+				t.Errorf("agent ran = %v, want %v", ran, tt.wantAgent)
+			}
+		})
+	}
+}
+
+func TestValidateHookCommands(t *testing.T) {
+	errs := validateHookCommands(HookPostIteration, []string{"make cover", "  "})
+	if len(errs) != 1 || errs[0] != "config.post_iteration[1]: command is empty" {
+		t.Errorf("validateHookCommands() = %v", errs)
+	}
+}
@@ -0,0 +1,245 @@
+package core
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Duplicate-ID policies for task import.
+const (
+	DuplicateError  = "error"
+	DuplicateSkip   = "skip"
+	DuplicateUpdate = "update"
+)
+
+// GetSupportedDuplicatePolicies returns the accepted --on-duplicate values.
+func GetSupportedDuplicatePolicies() []string {
+	return []string{DuplicateError, DuplicateSkip, DuplicateUpdate}
+}
+
+// TaskImportMapping names the source columns (CSV) or fields (JSON) that
+// hold each task attribute. JSON fields may use dot paths such as
+// "fields.summary" to reach nested values.
+type TaskImportMapping struct {
+	ID       string
+	Title    string
+	Priority string
+	Parent   string
+}
+
+// DefaultTaskImportMapping maps fields with the same names as prd.json.
+func DefaultTaskImportMapping() TaskImportMapping {
+	return TaskImportMapping{ID: "id", Title: "title", Priority: "priority", Parent: "parent"}
+}
+
+// TaskImportResult lists the task IDs affected by an import.
+type TaskImportResult struct {
+	Added   []string
+	Updated []string
+	Skipped []string
+}
+
+// importPriorityAliases maps common tracker priority names onto prd.json
+// priorities. Matching is case-insensitive.
+var importPriorityAliases = map[string]string{
+	"":        TaskPriorityMedium,
+	"p0":      TaskPriorityCritical,
+	"blocker": TaskPriorityCritical,
+	"urgent":  TaskPriorityCritical,
+	"highest": TaskPriorityCritical,
+	"p1":      TaskPriorityHigh,
+	"major":   TaskPriorityHigh,
+	"p2":      TaskPriorityMedium,
+	"normal":  TaskPriorityMedium,
+	"p3":      TaskPriorityLow,
+	"p4":      TaskPriorityLow,
+	"minor":   TaskPriorityLow,
+	"lowest":  TaskPriorityLow,
+	"trivial": TaskPriorityLow,
+}
+
+// NormalizeImportPriority converts a tracker priority into a prd.json
+// priority. An empty value defaults to medium.
+func NormalizeImportPriority(value string) (string, error) {
+	v := strings.ToLower(strings.TrimSpace(value))
+	switch v {
+	case TaskPriorityCritical, TaskPriorityHigh, TaskPriorityMedium, TaskPriorityLow:
+		return v, nil
+	}
+	if p, ok := importPriorityAliases[v]; ok {
+		return p, nil
+	}
+	return "", fmt.Errorf("unsupported priority: %s", value)
+}
+
+// ReadTaskRecordsCSV reads a CSV export with a header row. Header names
+// are matched case-insensitively.
+func ReadTaskRecordsCSV(r io.Reader) ([]map[string]string, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("CSV has no header row")
+	}
+
+	header := rows[0]
+	header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	records := make([]map[string]string, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		record := make(map[string]string, len(header))
+		for i, name := range header {
+			record[strings.ToLower(strings.TrimSpace(name))] = strings.TrimSpace(row[i])
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// ReadTaskRecordsJSON reads a JSON export: either an array of objects or
+// an object with a "tasks" array. Nested objects are flattened into dot
+// paths and numbers are kept as written, so numeric IDs stay usable.
+func ReadTaskRecordsJSON(r io.Reader) ([]map[string]string, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	var doc any
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON: %w", err)
+	}
+	if obj, ok := doc.(map[string]any); ok {
+		doc = obj["tasks"]
+	}
+	items, ok := doc.([]any)
+	if !ok {
+		return nil, fmt.Errorf("JSON must be an array of tasks or an object with a \"tasks\" array")
+	}
+
+	records := make([]map[string]string, 0, len(items))
+	for i, item := range items {
+		obj, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("task %d is not an object", i+1)
+		}
+		record := make(map[string]string)
+		flattenImportFields("", obj, record)
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func flattenImportFields(prefix string, obj map[string]any, record map[string]string) {
+	for key, value := range obj {
+		name := strings.ToLower(prefix + key)
+		switch v := value.(type) {
+		case map[string]any:
+			flattenImportFields(name+".", v, record)
+		case nil:
+			record[name] = ""
+		case string:
+			record[name] = strings.TrimSpace(v)
+		default:
+			record[name] = fmt.Sprint(v)
+		}
+	}
+}
+
+// TasksFromRecords builds pending tasks from imported records. Every row
+// is validated and all problems are reported together, identified by
+// their 1-based record number.
+func TasksFromRecords(records []map[string]string, mapping TaskImportMapping) ([]AutoTask, error) {
+	field := func(record map[string]string, name string) string {
+		return record[strings.ToLower(name)]
+	}
+
+	var tasks []AutoTask
+	var errs []error
+	seen := make(map[string]int)
+	for i, record := range records {
+		row := i + 1
+		task := AutoTask{
+			ID:       field(record, mapping.ID),
+			Title:    field(record, mapping.Title),
+			ParentID: field(record, mapping.Parent),
+			Status:   TaskStatusPending,
+			Source:   TaskSourceImport,
+		}
+		priority, err := NormalizeImportPriority(field(record, mapping.Priority))
+		task.Priority = priority
+
+		switch {
+		case task.ID == "":
+			errs = append(errs, fmt.Errorf("record %d: missing %s", row, mapping.ID))
+		case task.Title == "":
+			errs = append(errs, fmt.Errorf("record %d (%s): missing %s", row, task.ID, mapping.Title))
+		case err != nil:
+			errs = append(errs, fmt.Errorf("record %d (%s): %w", row, task.ID, err))
+		case task.ParentID == task.ID:
+			errs = append(errs, fmt.Errorf("record %d (%s): task cannot be its own parent", row, task.ID))
+		case seen[task.ID] > 0:
+			errs = append(errs, fmt.Errorf("record %d: duplicate ID %s (first seen in record %d)", row, task.ID, seen[task.ID]))
+		default:
+			seen[task.ID] = row
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks, errors.Join(errs...)
+}
+
+// ImportTasks merges imported tasks into the PRD. IDs that already exist
+// are handled per policy: DuplicateError rejects the import,
+// DuplicateSkip keeps the existing task, and DuplicateUpdate refreshes its
+// title, priority, and parent while keeping its status. Parents must exist
+// in the PRD or the import. Nothing is changed if validation fails.
+func (p *AutoPRD) ImportTasks(tasks []AutoTask, policy string) (*TaskImportResult, error) {
+	if !slices.Contains(GetSupportedDuplicatePolicies(), policy) {
+		return nil, fmt.Errorf("unsupported duplicate policy: %s (supported: %v)",
+			policy, GetSupportedDuplicatePolicies())
+	}
+
+	known := make(map[string]bool)
+	for _, t := range p.Tasks {
+		known[t.ID] = true
+	}
+	for _, t := range tasks {
+		known[t.ID] = true
+	}
+
+	var errs []error
+	for _, t := range tasks {
+		if t.ParentID != "" && !known[t.ParentID] {
+			errs = append(errs, fmt.Errorf("task %s: unknown parent %s", t.ID, t.ParentID))
+		}
+		if policy == DuplicateError && p.findTask(t.ID) != nil {
+			errs = append(errs, fmt.Errorf("task %s already exists (use --on-duplicate skip or update)", t.ID))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	result := &TaskImportResult{}
+	for _, t := range tasks {
+		existing := p.findTask(t.ID)
+		switch {
+		case existing == nil:
+			if err := p.AddTask(t); err != nil {
+				return nil, err
+			}
+			result.Added = append(result.Added, t.ID)
+		case policy == DuplicateUpdate:
+			existing.Title, existing.Priority, existing.ParentID = t.Title, t.Priority, t.ParentID
+			result.Updated = append(result.Updated, t.ID)
+		default:
+			result.Skipped = append(result.Skipped, t.ID)
+		}
+	}
+	p.RecalculateProgress()
+	return result, nil
+}
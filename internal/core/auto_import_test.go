@@ -0,0 +1,190 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeImportPriority(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", TaskPriorityMedium, false},
+		{"High", TaskPriorityHigh, false},
+		{" critical ", TaskPriorityCritical, false},
+		{"P0", TaskPriorityCritical, false},
+		{"Highest", TaskPriorityCritical, false},
+		{"p1", TaskPriorityHigh, false},
+		{"Normal", TaskPriorityMedium, false},
+		{"Minor", TaskPriorityLow, false},
+		{"someday", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := NormalizeImportPriority(tt.in)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("NormalizeImportPriority(%q) = %q, %v; want %q, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReadTaskRecordsCSV(t *testing.T) {
+	input := "\ufeffIssue Key,Summary,Priority\nAPI-1, Build API ,High\nAPI-2,\"Add auth, tokens\",\n"
+
+	records, err := ReadTaskRecordsCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ReadTaskRecordsCSV returned error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	if records[0]["issue key"] != "API-1" || records[0]["summary"] != "Build API" {
+		t.Errorf("record 1 = %v", records[0])
+	}
+	if records[1]["summary"] != "Add auth, tokens" || records[1]["priority"] != "" {
+		t.Errorf("record 2 = %v", records[1])
+	}
+
+	if _, err := ReadTaskRecordsCSV(strings.NewReader("")); err == nil {
+		t.Error("expected error for empty CSV")
+	}
+}
+
+func TestReadTaskRecordsJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{"array", `[{"id": 1, "fields": {"Summary": "Build API"}, "parent": null}]`, false},
+		{"tasks_object", `{"tasks": [{"id": 1, "fields": {"Summary": "Build API"}}]}`, false},
+		{"not_array", `{"items": []}`, true},
+		{"non_object_task", `[1]`, true},
+		{"malformed", `[{`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := ReadTaskRecordsJSON(strings.NewReader(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ReadTaskRecordsJSON error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if records[0]["id"] != "1" || records[0]["fields.summary"] != "Build API" {
+				t.Errorf("record = %v, want numeric id and flattened summary", records[0])
+			}
+		})
+	}
+}
+
+func TestTasksFromRecords(t *testing.T) {
+	mapping := TaskImportMapping{ID: "Key", Title: "Summary", Priority: "Priority", Parent: "Parent"}
+	records := []map[string]string{
+		{"key": "1", "summary": "Parent", "priority": "P1"},
+		{"key": "1.1", "summary": "Child", "parent": "1"},
+	}
+
+	tasks, err := TasksFromRecords(records, mapping)
+	if err != nil {
+		t.Fatalf("TasksFromRecords returned error: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].Priority != TaskPriorityHigh || tasks[1].ParentID != "1" {
+		t.Fatalf("tasks = %+v", tasks)
+	}
+	if tasks[1].Status != TaskStatusPending || tasks[1].Source != TaskSourceImport {
+		t.Errorf("imported task should be pending with import source, got %+v", tasks[1])
+	}
+}
+
+func TestTasksFromRecords_ReportsEveryInvalidRecord(t *testing.T) {
+	records := []map[string]string{
+		{"id": "1", "title": "ok"},
+		{"id": "", "title": "no id"},
+		{"id": "2", "title": ""},
+		{"id": "3", "title": "bad", "priority": "someday"},
+		{"id": "4", "title": "self", "parent": "4"},
+		{"id": "1", "title": "dup"},
+	}
+
+	_, err := TasksFromRecords(records, DefaultTaskImportMapping())
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	for _, want := range []string{"record 2", "record 3", "record 4", "record 5", "record 6: duplicate ID 1"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error missing %q:\n%v", want, err)
+		}
+	}
+}
+
+func TestImportTasks(t *testing.T) {
+	newPRD := func() *AutoPRD {
+		prd := NewAutoPRD("test", "")
+		prd.Tasks = []AutoTask{{ID: "1", Title: "Existing", Status: TaskStatusCompleted, Priority: TaskPriorityLow}}
+		return prd
+	}
+	imported := []AutoTask{
+		{ID: "1", Title: "Renamed", Priority: TaskPriorityHigh, Status: TaskStatusPending},
+		{ID: "2", Title: "New", ParentID: "1", Status: TaskStatusPending},
+	}
+
+	t.Run("error_policy_rejects_existing", func(t *testing.T) {
+		prd := newPRD()
+		if _, err := prd.ImportTasks(imported, DuplicateError); err == nil {
+			t.Fatal("expected duplicate error")
+		}
+		if len(prd.Tasks) != 1 {
+			t.Errorf("failed import must not change the PRD, got %d tasks", len(prd.Tasks))
+		}
+	})
+
+	t.Run("skip_policy", func(t *testing.T) {
+		prd := newPRD()
+		result, err := prd.ImportTasks(imported, DuplicateSkip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(result.Added, []string{"2"}) || !slices.Equal(result.Skipped, []string{"1"}) {
+			t.Errorf("result = %+v", result)
+		}
+		if prd.Tasks[0].Title != "Existing" || prd.Progress.TotalTasks != 2 {
+			t.Errorf("tasks = %+v, progress = %+v", prd.Tasks, prd.Progress)
+		}
+	})
+
+	t.Run("update_policy_keeps_status", func(t *testing.T) {
+		prd := newPRD()
+		result, err := prd.ImportTasks(imported, DuplicateUpdate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(result.Updated, []string{"1"}) {
+			t.Errorf("result = %+v", result)
+		}
+		got := prd.Tasks[0]
+		if got.Title != "Renamed" || got.Priority != TaskPriorityHigh || got.Status != TaskStatusCompleted {
+			t.Errorf("updated task = %+v", got)
+		}
+	})
+
+	t.Run("unknown_parent", func(t *testing.T) {
+		prd := newPRD()
+		orphan := []AutoTask{{ID: "9", Title: "Orphan", ParentID: "missing", Status: TaskStatusPending}}
+		if _, err := prd.ImportTasks(orphan, DuplicateError); err == nil || !strings.Contains(err.Error(), "unknown parent") {
+			t.Errorf("expected unknown parent error, got %v", err)
+		}
+	})
+
+	t.Run("unsupported_policy", func(t *testing.T) {
+		if _, err := newPRD().ImportTasks(nil, "merge"); err == nil {
+			t.Error("expected error for unsupported policy")
+		}
+	})
+}
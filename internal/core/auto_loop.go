@@ -1,14 +1,18 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"time"
 )
 
+// ErrInterrupted is returned by RunAutoLoop when the loop was stopped
+// through LoopConfig.Stop.
+var ErrInterrupted = errors.New("auto loop interrupted")
+
 // LoopConfig holds all parameters for running the autonomous loop.
 type LoopConfig struct {
 	ProjectDir     string
@@ -19,10 +23,55 @@ type LoopConfig struct {
 	Sandbox        string
 	SandboxImage   string
 	SandboxTpl     string
+	SandboxMounts  []SandboxMount
+	SandboxOffline bool
 	PauseSecs      int
 	MaxConsecFails int
+	// Deadline, when set, time-boxes the run: once the remaining time
+	// drops below WrapUpReserve, a final wrap-up iteration runs using
+	// WrapUpPromptPath and the loop stops.
+	Deadline         time.Time
+	WrapUpReserve    time.Duration
+	WrapUpPromptPath string
+	// Milestone, when set, restricts the loop to that milestone's tasks.
+	Milestone string
+	// StatsRefreshInterval controls how often the project stats context
+	// file is regenerated (see ShouldRefreshProjectStats).
+	StatsRefreshInterval int
+	// CommitPolicy, when set, is enforced after every implementation
+	// iteration (see EnforceCommitPolicy).
+	CommitPolicy *CommitPolicy
+	// PathGuard limits the files an implementation iteration may change
+	// (see EnforcePathGuard). Tasks may declare their own allowed_paths.
+	PathGuard *PathGuard
+	// Sync, when set, pushes the loop state after every iteration so
+	// teammates can follow or take over the run (see SyncLoopState).
+	Sync *SyncConfig
+	// PreIteration and PostIteration are shell commands run around every
+	// iteration (see RunIterationHooks).
+	PreIteration  []string
+	PostIteration []string
+	// Telemetry, when it or the OTEL_EXPORTER_OTLP_* environment names an
+	// endpoint, exports the run as OpenTelemetry traces (see LoopTrace).
+	Telemetry *TelemetryConfig
+	// HeartbeatInterval is how often the heartbeat file is refreshed;
+	// zero means DefaultHeartbeatInterval.
+	HeartbeatInterval time.Duration
+	// Store persists loop state; nil means the file backend at PRDPath.
+	Store AutoStore
+	// Stop, when closed, ends the loop after the current iteration with
+	// ErrInterrupted, so the caller's deferred cleanup still runs.
+	Stop           <-chan struct{}
 	OnIterStart    func(iter int, iterType string)
 	OnIterEnd      func(iter int, err error)
+	OnIterEvent    func(event IterationEvent, err error)
+	OnCommitPolicy func(iter int, violations []CommitPolicyViolation, err error)
+	OnPathGuard    func(iter int, violation *PathGuardViolation, err error)
+	OnSync         func(iter int, bundle *SyncBundle, err error)
+	OnHook         func(iter int, phase, command string, err error)
+	OnTelemetry    func(err error)
+
+	trace *LoopTrace
 }
 
 // NewLoopConfig creates a LoopConfig with defaults from a PRD and project dir.
@@ -50,149 +99,171 @@ func NewLoopConfig(projectDir string, prd *AutoPRD) LoopConfig {
 		Sandbox:        prd.Config.Sandbox,
 		SandboxImage:   prd.Config.SandboxImage,
 		SandboxTpl:     prd.Config.SandboxTemplate,
+		SandboxMounts:  prd.Config.SandboxMounts,
+		SandboxOffline: prd.Config.SandboxOffline,
 		PauseSecs:      pauseSecs,
 		MaxConsecFails: maxConsecFails,
+
+		StatsRefreshInterval: prd.Config.StatsRefreshInterval,
+		CommitPolicy:         prd.Config.CommitPolicy,
+		PathGuard:            prd.Config.PathGuard,
+		Sync:                 prd.Config.Sync,
+		PreIteration:         prd.Config.PreIteration,
+		PostIteration:        prd.Config.PostIteration,
+		Telemetry:            prd.Config.Telemetry,
 	}
 }
 
 // RunAutoLoop executes the autonomous loop using Go-native orchestration.
-// It replaces the bash-based auto.sh script.
+// It replaces the bash-based auto.sh script. While it runs, the heartbeat
+// file in the auto directory is kept current (see Heartbeat) and, when
+// telemetry is configured, the run is exported as a trace (see LoopTrace).
 func RunAutoLoop(cfg LoopConfig) error {
+	hb := StartHeartbeat(&cfg)
+	trace := StartLoopTrace(&cfg)
+	err := runAutoLoop(cfg)
+	trace.Finish(err)
+	hb.Stop(err)
+	return err
+}
+
+func runAutoLoop(cfg LoopConfig) error {
 	consecutiveFailures := 0
+	var elapsed time.Duration
 
 	for i := 1; i <= cfg.MaxIterations; i++ {
-		prd, err := LoadAutoPRD(cfg.PRDPath)
+		if cfg.Stopped() {
+			return ErrInterrupted
+		}
+		prd, err := cfg.store().LoadPRD()
 		if err != nil {
 			return fmt.Errorf("iteration %d: failed to reload prd.json: %w", i, err)
 		}
 
-		if prd.GetNextTask() == nil {
+		if prd.GetNextTaskFor(TaskFilter{Milestone: cfg.Milestone}) == nil {
 			notifyIterEnd(cfg.OnIterEnd, i, nil)
 			return nil
 		}
 
-		notifyIterStart(cfg.OnIterStart, i, IterationTypeImplementation)
+		// Best effort: stale stats are preferable to aborting the run.
+		_ = RefreshProjectStats(cfg.ProjectDir, i, cfg.StatsRefreshInterval)
 
-		err = InvokeAgent(cfg)
-		if err != nil {
-			consecutiveFailures++
-			notifyIterEnd(cfg.OnIterEnd, i, err)
-			if consecutiveFailures >= cfg.MaxConsecFails {
-				return fmt.Errorf(
-					"%d consecutive failures reached — aborting. "+
-						"Check AI tool auth/config", cfg.MaxConsecFails)
+		iterCfg, iterType := cfg, IterationTypeImplementation
+		if cfg.shouldWrapUp(time.Now(), averageDuration(elapsed, i-1)) {
+			iterCfg.PromptPath, iterType = cfg.WrapUpPromptPath, IterationTypeWrapUp
+		}
+
+		took, err := RunIteration(iterCfg, i, iterType)
+		elapsed += took
+
+		if iterType == IterationTypeWrapUp {
+			if err != nil {
+				return fmt.Errorf("wrap-up iteration failed: %w", err)
 			}
-		} else {
-			consecutiveFailures = 0
-			notifyIterEnd(cfg.OnIterEnd, i, nil)
+			return nil
+		}
+
+		if err := FinishIteration(cfg, i, err, &consecutiveFailures); err != nil {
+			return err
 		}
 
 		if i < cfg.MaxIterations {
-			time.Sleep(time.Duration(cfg.PauseSecs) * time.Second)
+			cfg.Pause()
 		}
 	}
 
 	return nil
 }
 
-// InvokeAgent calls the AI tool for one iteration of work.
-// It validates cfg.AITool against the allow-list before execution
-// to prevent arbitrary command injection via modified prd.json.
-func InvokeAgent(cfg LoopConfig) error {
-	if !IsValidAITool(cfg.AITool) {
-		return fmt.Errorf(
-			"refused to invoke invalid AI tool %q (allowed: %v)",
-			cfg.AITool, GetSupportedAITools())
-	}
-
-	switch cfg.Sandbox {
-	case SandboxDockerSandbox:
-		return invokeAgentDockerSandbox(cfg)
-	case SandboxDocker:
-		return invokeAgentDocker(cfg)
+// Stopped reports whether cfg.Stop has been closed.
+func (c LoopConfig) Stopped() bool {
+	select {
+	case <-c.Stop:
+		return true
 	default:
-		return invokeAgentLocal(cfg)
+		return false
 	}
 }
 
-func invokeAgentLocal(cfg LoopConfig) error {
-	args, err := GetAgentArgs(cfg.AITool, cfg.PromptPath)
-	if err != nil {
-		return fmt.Errorf("failed to build agent args: %w", err)
+// Pause waits PauseSecs between iterations, returning early when
+// cfg.Stop is closed.
+func (c LoopConfig) Pause() {
+	select {
+	case <-time.After(time.Duration(c.PauseSecs) * time.Second):
+	case <-c.Stop:
 	}
+}
 
-	cmd := exec.Command(cfg.AITool, args...)
-	cmd.Dir = cfg.ProjectDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
+// RunIteration runs one iteration of the loop and refreshes the progress
+// header afterwards. It is shared by RunAutoLoop and pilot mode, so both
+// run the hooks, guards and policies of runIteration. It returns the time
+// the agent took and the iteration's error.
+func RunIteration(cfg LoopConfig, iter int, iterType string) (time.Duration, error) {
+	notifyIterStart(cfg.OnIterStart, iter, iterType)
+	took, err := runIteration(cfg, iter, iterType)
+	// Best effort, like the stats: the header only mirrors prd.json.
+	_ = UpdateProgressHeader(cfg, iter, iterType, err)
+	return took, err
 }
 
-func invokeAgentDocker(cfg LoopConfig) error {
-	promptRel, err := filepath.Rel(cfg.ProjectDir, cfg.PromptPath)
-	if err != nil {
-		return fmt.Errorf("failed to compute relative prompt path: %w", err)
+// FinishIteration counts a failed iteration towards MaxConsecFails and
+// pushes the loop state when sync is configured. It returns an error when
+// the loop must stop.
+func FinishIteration(cfg LoopConfig, iter int, iterErr error, consecutiveFailures *int) error {
+	if err := trackFailures(iterErr, consecutiveFailures, cfg.MaxConsecFails); err != nil {
+		return err
 	}
+	return SyncLoopState(cfg, iter)
+}
 
-	agentArgs, err := GetAgentArgs(
-		cfg.AITool,
-		filepath.Join(DockerContainerMount, promptRel),
-	)
-	if err != nil {
-		return fmt.Errorf("failed to build agent args: %w", err)
+// runIteration invokes the agent once between the pre- and post-iteration
+// hooks, records the files it touched in the event log and, after a
+// successful implementation iteration, enforces the commit policy. A
+// failing pre-iteration hook skips the agent; a failing hook of either
+// phase fails the iteration.
+func runIteration(cfg LoopConfig, iter int, iterType string) (time.Duration, error) {
+	snap := SnapshotIteration(cfg)
+	cfg.trace.startIteration()
+	if err := RunIterationHooks(cfg, HookPreIteration, iter, iterType, snap, nil); err != nil {
+		notifyIterEnd(cfg.OnIterEnd, iter, err)
+		cfg.trace.endIteration(iter, iterType, snap.NextTaskID, cfg.AITool, FileChangeSummary{}, err)
+		return 0, err
 	}
-
-	image := cfg.SandboxImage
-	if image == "" {
-		image = DefaultSandboxImage
+	started := time.Now()
+	agentErr := InvokeAgent(cfg)
+	took := time.Since(started)
+	cfg.trace.addSpan(spanAgent, started, agentErr, otlpString("samuel.agent", cfg.AITool), otlpString("samuel.sandbox", cfg.Sandbox))
+	err := agentErr
+	if hookErr := RunIterationHooks(cfg, HookPostIteration, iter, iterType, snap, agentErr); err == nil {
+		err = hookErr
 	}
-	if !IsValidSandboxImage(image) {
-		return fmt.Errorf(
-			"refused to use invalid sandbox image %q: must match Docker image reference format",
-			image)
+	event, eventErr := RecordIterationEvent(cfg, iter, iterType, snap, err)
+	if cfg.OnIterEvent != nil {
+		cfg.OnIterEvent(event, eventErr)
 	}
-
-	dockerArgs := buildDockerRunArgs(cfg.ProjectDir, image, cfg.AITool, agentArgs)
-	cmd := exec.Command("docker", dockerArgs...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
+	notifyIterEnd(cfg.OnIterEnd, iter, err)
+	cfg.trace.endIteration(iter, iterType, snap.NextTaskID, cfg.AITool, event.Changes, err)
+	if agentErr == nil && iterType == IterationTypeImplementation {
+		ApplyPathGuard(cfg, iter, snap)
+		ApplyCommitPolicy(cfg, iter, snap)
+	}
+	return took, err
 }
 
-func invokeAgentDockerSandbox(cfg LoopConfig) error {
-	agentArgs, err := GetAgentArgs(cfg.AITool, cfg.PromptPath)
-	if err != nil {
-		return fmt.Errorf("failed to build agent args: %w", err)
+// trackFailures updates the consecutive failure counter for an iteration
+// result and returns an error once the limit is reached.
+func trackFailures(iterErr error, consecutive *int, limit int) error {
+	if iterErr == nil {
+		*consecutive = 0
+		return nil
 	}
-
-	sandboxCfg := DockerSandboxRunConfig{
-		Agent:     cfg.AITool,
-		WorkDir:   cfg.ProjectDir,
-		Template:  cfg.SandboxTpl,
-		AgentArgs: agentArgs,
+	*consecutive++
+	if *consecutive >= limit {
+		return fmt.Errorf(
+			"%d consecutive failures reached — aborting. "+
+				"Check AI tool auth/config", limit)
 	}
-
-	args := BuildDockerSandboxArgs(sandboxCfg)
-	cmd := exec.Command("docker", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	cmd.Stdin = os.Stdin
-	return cmd.Run()
-}
-
-// buildDockerRunArgs constructs docker run arguments for agent invocation.
-func buildDockerRunArgs(workDir, image, aiTool string, agentArgs []string) []string {
-	args := []string{"run", "--rm", "--init", "-i"}
-	args = append(args, fmt.Sprintf("--user=%d:%d", os.Getuid(), os.Getgid()))
-	args = append(args, "-v", fmt.Sprintf("%s:%s", workDir, DockerContainerMount))
-	args = append(args, "-w", DockerContainerMount)
-	args = append(args, getAIToolEnvVars()...)
-	args = append(args, image)
-	args = append(args, aiTool)
-	args = append(args, agentArgs...)
-	return args
+	return nil
 }
 
 func notifyIterStart(fn func(int, string), iter int, iterType string) {
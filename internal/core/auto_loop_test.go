@@ -1,11 +1,13 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewLoopConfig_Defaults(t *testing.T) {
@@ -93,6 +95,53 @@ func TestRunAutoLoop_BadPRDPath(t *testing.T) {
 	}
 }
 
+func TestRunAutoLoop_Stopped(t *testing.T) {
+	dir := t.TempDir()
+	prd := NewAutoPRD("test", "test project")
+	prd.Tasks = []AutoTask{{ID: "1", Title: "Pending", Status: TaskStatusPending}}
+	prdPath := filepath.Join(dir, AutoDir, AutoPRDFile)
+	if err := prd.Save(prdPath); err != nil {
+		t.Fatalf("failed to save prd: %v", err)
+	}
+	stop := make(chan struct{})
+	close(stop)
+
+	iterations := 0
+	cfg := LoopConfig{
+		ProjectDir:     dir,
+		PRDPath:        prdPath,
+		MaxIterations:  3,
+		MaxConsecFails: 3,
+		Stop:           stop,
+		OnIterStart:    func(int, string) { iterations++ },
+	}
+	if err := RunAutoLoop(cfg); !errors.Is(err, ErrInterrupted) {
+		t.Errorf("RunAutoLoop() error = %v, want ErrInterrupted", err)
+	}
+	if iterations != 0 {
+		t.Errorf("ran %d iterations after stop", iterations)
+	}
+}
+
+func TestLoopConfig_PauseReturnsOnStop(t *testing.T) {
+	stop := make(chan struct{})
+	close(stop)
+	cfg := LoopConfig{PauseSecs: 60, Stop: stop}
+	done := make(chan struct{})
+	go func() {
+		cfg.Pause()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Pause() did not return after stop")
+	}
+	if (LoopConfig{}).Stopped() {
+		t.Error("a loop without a stop channel reports stopped")
+	}
+}
+
 func TestNotifyCallbacks(t *testing.T) {
 	startCalled := false
 	endCalled := false
@@ -438,7 +487,7 @@ func TestBuildDockerRunArgs(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			args := buildDockerRunArgs(tt.workDir, tt.image, tt.aiTool, tt.agentArgs)
+			args := buildDockerRunArgs(tt.workDir, tt.image, tt.aiTool, nil, tt.agentArgs)
 
 			joined := strings.Join(args, " ")
 			for _, part := range tt.wantParts {
@@ -465,11 +514,11 @@ func TestBuildDockerRunArgs_Structure(t *testing.T) {
 		os.Unsetenv(name)
 	}
 
-	args := buildDockerRunArgs("/proj", "img:1", "claude", []string{"-p", "hello"})
+	args := buildDockerRunArgs("/proj", "img:1", "claude", nil, []string{"-p", "hello"})
 
-	// Verify fixed structure: run --rm --init -i --user=UID:GID -v MOUNT -w /workspace IMAGE TOOL ARGS...
-	if len(args) < 10 {
-		t.Fatalf("expected at least 10 args, got %d: %v", len(args), args)
+	// Verify fixed structure: run --rm --init -i --user=UID:GID -v MOUNT -w /workspace LABELS IMAGE TOOL ARGS...
+	if len(args) < 17 {
+		t.Fatalf("expected at least 17 args, got %d: %v", len(args), args)
 	}
 
 	if args[0] != "run" || args[1] != "--rm" || args[2] != "--init" || args[3] != "-i" {
@@ -491,15 +540,21 @@ func TestBuildDockerRunArgs_Structure(t *testing.T) {
 		t.Errorf("expected workdir, got args[7:9]=%v", args[7:9])
 	}
 
+	// --label managed, --label project
+	if args[9] != "--label" || args[10] != SandboxLabelManaged+"=true" ||
+		args[11] != "--label" || args[12] != SandboxLabelProject+"=/proj" {
+		t.Errorf("expected samuel labels, got args[9:13]=%v", args[9:13])
+	}
+
 	// Image then tool then args
-	if args[9] != "img:1" {
-		t.Errorf("expected image at arg[9], got %q", args[9])
+	if args[13] != "img:1" {
+		t.Errorf("expected image at arg[13], got %q", args[13])
 	}
-	if args[10] != "claude" {
-		t.Errorf("expected tool at arg[10], got %q", args[10])
+	if args[14] != "claude" {
+		t.Errorf("expected tool at arg[14], got %q", args[14])
 	}
-	if args[11] != "-p" || args[12] != "hello" {
-		t.Errorf("expected agent args at [11:13], got %v", args[11:])
+	if args[15] != "-p" || args[16] != "hello" {
+		t.Errorf("expected agent args at [15:17], got %v", args[15:])
 	}
 }
 
@@ -512,7 +567,7 @@ func TestBuildDockerRunArgs_WithEnvVars(t *testing.T) {
 	t.Setenv("ANTHROPIC_API_KEY", "sk-test")
 	t.Setenv("AI_TOOL", "claude")
 
-	args := buildDockerRunArgs("/proj", "img:1", "claude", []string{"-p", "hi"})
+	args := buildDockerRunArgs("/proj", "img:1", "claude", nil, []string{"-p", "hi"})
 	joined := strings.Join(args, " ")
 
 	if !strings.Contains(joined, "-e ANTHROPIC_API_KEY=sk-test") {
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// TaskFilter selects tasks by milestone and labels. An empty filter
+// matches every task.
+type TaskFilter struct {
+	Milestone string
+	Labels    []string
+}
+
+// MilestoneProgress holds completion counts for a single milestone.
+type MilestoneProgress struct {
+	Name      string
+	Total     int
+	Completed int
+}
+
+// IsEmpty reports whether the filter has no criteria.
+func (f TaskFilter) IsEmpty() bool {
+	return f.Milestone == "" && len(f.Labels) == 0
+}
+
+// Matches reports whether a task belongs to the filter's milestone and
+// carries all of its labels. Label comparison is case-insensitive.
+func (f TaskFilter) Matches(t *AutoTask) bool {
+	if f.Milestone != "" && t.Milestone != f.Milestone {
+		return false
+	}
+	for _, want := range f.Labels {
+		if !t.HasLabel(want) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasLabel reports whether the task carries the given label.
+func (t *AutoTask) HasLabel(label string) bool {
+	return slices.ContainsFunc(t.Labels, func(l string) bool {
+		return strings.EqualFold(l, label)
+	})
+}
+
+// FilterTasks returns the tasks matching the filter, in prd.json order.
+func (p *AutoPRD) FilterTasks(f TaskFilter) []AutoTask {
+	var matched []AutoTask
+	for i := range p.Tasks {
+		if f.Matches(&p.Tasks[i]) {
+			matched = append(matched, p.Tasks[i])
+		}
+	}
+	return matched
+}
+
+// GetNextTaskFor returns the best available task matching the filter,
+// using the configured scoring strategy.
+func (p *AutoPRD) GetNextTaskFor(f TaskFilter) *AutoTask {
+	for _, s := range p.ScoreTasks(p.Config.ScoringStrategy, time.Now().UTC()) {
+		if f.Matches(s.Task) {
+			return s.Task
+		}
+	}
+	return nil
+}
+
+// Milestones returns the distinct milestone names in order of first use.
+func (p *AutoPRD) Milestones() []string {
+	var names []string
+	for _, t := range p.Tasks {
+		if t.Milestone != "" && !slices.Contains(names, t.Milestone) {
+			names = append(names, t.Milestone)
+		}
+	}
+	return names
+}
+
+// GetMilestoneProgress returns completion counts for every milestone,
+// counted the same way as the overall progress summary.
+func (p *AutoPRD) GetMilestoneProgress() []MilestoneProgress {
+	var progress []MilestoneProgress
+	for _, name := range p.Milestones() {
+		m := MilestoneProgress{Name: name}
+		for _, t := range p.Tasks {
+			if t.Milestone != name {
+				continue
+			}
+			m.Total++
+			if t.Status == TaskStatusCompleted {
+				m.Completed++
+			}
+		}
+		progress = append(progress, m)
+	}
+	return progress
+}
+
+// ValidateMilestone checks that the milestone is used by at least one task.
+func (p *AutoPRD) ValidateMilestone(milestone string) error {
+	known := p.Milestones()
+	if !slices.Contains(known, milestone) {
+		return fmt.Errorf("unknown milestone: %s (known: %v)", milestone, known)
+	}
+	return nil
+}
+
+// GenerateMilestonePromptSection returns the prompt addendum that restricts
+// the agent to a single milestone's tasks.
+func GenerateMilestonePromptSection(milestone string) string {
+	var sb strings.Builder
+	sb.WriteString("\n## Milestone Scope\n\n")
+	fmt.Fprintf(&sb, "This run is restricted to milestone **%s**.\n", milestone)
+	fmt.Fprintf(&sb, "Only select tasks whose `milestone` field is \"%s\".\n", milestone)
+	sb.WriteString("Ignore all other pending tasks, even if they have higher priority.\n")
+	return sb.String()
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+func newMilestonePRD() *AutoPRD {
+	prd := NewAutoPRD("test", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "Schema", Status: TaskStatusCompleted, Milestone: "M1", Labels: []string{"db"}},
+		{ID: "2", Title: "API", Status: TaskStatusPending, Milestone: "M1", Labels: []string{"api", "db"}},
+		{ID: "3", Title: "UI", Status: TaskStatusPending, Milestone: "M2", Labels: []string{"ui"}, Priority: TaskPriorityCritical},
+		{ID: "4", Title: "Docs", Status: TaskStatusPending},
+	}
+	return prd
+}
+
+func TestTaskFilter_Matches(t *testing.T) {
+	task := &AutoTask{ID: "1", Milestone: "M1", Labels: []string{"API", "db"}}
+	tests := []struct {
+		name   string
+		filter TaskFilter
+		want   bool
+	}{
+		{"empty filter", TaskFilter{}, true},
+		{"matching milestone", TaskFilter{Milestone: "M1"}, true},
+		{"other milestone", TaskFilter{Milestone: "M2"}, false},
+		{"label case-insensitive", TaskFilter{Labels: []string{"api"}}, true},
+		{"all labels required", TaskFilter{Labels: []string{"api", "ui"}}, false},
+		{"milestone and label", TaskFilter{Milestone: "M1", Labels: []string{"db"}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(task); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAutoPRD_FilterTasks(t *testing.T) {
+	prd := newMilestonePRD()
+
+	got := prd.FilterTasks(TaskFilter{Labels: []string{"db"}})
+	if len(got) != 2 || got[0].ID != "1" || got[1].ID != "2" {
+		t.Errorf("FilterTasks(db) = %v, want tasks 1 and 2", got)
+	}
+	if all := prd.FilterTasks(TaskFilter{}); len(all) != len(prd.Tasks) {
+		t.Errorf("empty filter returned %d tasks, want %d", len(all), len(prd.Tasks))
+	}
+}
+
+func TestAutoPRD_GetNextTaskFor(t *testing.T) {
+	prd := newMilestonePRD()
+
+	if next := prd.GetNextTaskFor(TaskFilter{}); next == nil || next.ID != "3" {
+		t.Errorf("unfiltered next = %v, want critical task 3", next)
+	}
+	if next := prd.GetNextTaskFor(TaskFilter{Milestone: "M1"}); next == nil || next.ID != "2" {
+		t.Errorf("M1 next = %v, want task 2", next)
+	}
+	if next := prd.GetNextTaskFor(TaskFilter{Milestone: "M9"}); next != nil {
+		t.Errorf("unknown milestone next = %v, want nil", next)
+	}
+}
+
+func TestAutoPRD_GetMilestoneProgress(t *testing.T) {
+	progress := newMilestonePRD().GetMilestoneProgress()
+
+	if len(progress) != 2 {
+		t.Fatalf("got %d milestones, want 2", len(progress))
+	}
+	if progress[0] != (MilestoneProgress{Name: "M1", Total: 2, Completed: 1}) {
+		t.Errorf("M1 progress = %+v", progress[0])
+	}
+	if progress[1] != (MilestoneProgress{Name: "M2", Total: 1, Completed: 0}) {
+		t.Errorf("M2 progress = %+v", progress[1])
+	}
+}
+
+func TestAutoPRD_ValidateMilestone(t *testing.T) {
+	prd := newMilestonePRD()
+
+	if err := prd.ValidateMilestone("M2"); err != nil {
+		t.Errorf("ValidateMilestone(M2) returned error: %v", err)
+	}
+	err := prd.ValidateMilestone("M3")
+	if err == nil || !strings.Contains(err.Error(), "unknown milestone: M3") {
+		t.Errorf("expected unknown milestone error, got %v", err)
+	}
+}
+
+func TestGenerateMilestonePromptSection(t *testing.T) {
+	section := GenerateMilestonePromptSection("M1")
+	if !strings.Contains(section, "## Milestone Scope") || !strings.Contains(section, `"M1"`) {
+		t.Errorf("unexpected milestone section:\n%s", section)
+	}
+}
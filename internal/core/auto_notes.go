@@ -0,0 +1,136 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Authors of task notes.
+const (
+	NoteAuthorAgent = "agent"
+	NoteAuthorHuman = "human"
+)
+
+// AutoAttachmentsDir holds files attached to task notes, one directory per
+// task, relative to the project root.
+const AutoAttachmentsDir = AutoDir + "/attachments"
+
+// GetSupportedNoteAuthors returns the accepted note authors.
+func GetSupportedNoteAuthors() []string {
+	return []string{NoteAuthorAgent, NoteAuthorHuman}
+}
+
+// TaskNote is a timestamped remark on a task, such as why it is blocked.
+// Attachments are slash-separated paths relative to the project root.
+type TaskNote struct {
+	Timestamp   string   `json:"timestamp"`
+	Author      string   `json:"author"`
+	Text        string   `json:"text"`
+	Attachments []string `json:"attachments,omitempty"`
+}
+
+// GetTask returns the task with the given ID, or nil.
+func (p *AutoPRD) GetTask(id string) *AutoTask {
+	return p.findTask(id)
+}
+
+// AddTaskNote appends a note to the task with the given ID, stamping it
+// with the current time when Timestamp is empty. Files in attach are
+// copied into the task's attachment directory under projectDir and listed
+// on the note. Every attachment is checked before any is copied, and the
+// copies are removed again when one fails, so an invalid note leaves no
+// files behind.
+func (p *AutoPRD) AddTaskNote(projectDir, id string, note TaskNote, attach []string) error {
+	task := p.findTask(id)
+	if task == nil {
+		return fmt.Errorf("task not found: %s", id)
+	}
+	if !slices.Contains(GetSupportedNoteAuthors(), note.Author) {
+		return fmt.Errorf("unsupported note author: %s (supported: %v)", note.Author, GetSupportedNoteAuthors())
+	}
+	if strings.TrimSpace(note.Text) == "" {
+		return fmt.Errorf("note text is empty")
+	}
+	if note.Timestamp == "" {
+		note.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+	for _, src := range attach {
+		if err := checkAttachment(src); err != nil {
+			return err
+		}
+	}
+	for _, src := range attach {
+		rel, err := StoreTaskAttachment(projectDir, id, src)
+		if err != nil {
+			for _, stored := range note.Attachments {
+				os.Remove(filepath.Join(projectDir, filepath.FromSlash(stored)))
+			}
+			return err
+		}
+		note.Attachments = append(note.Attachments, rel)
+	}
+	task.Notes = append(task.Notes, note)
+	return nil
+}
+
+// StoreTaskAttachment copies src into the task's attachment directory and
+// returns its path relative to projectDir. An existing attachment with the
+// same name is kept and the copy gets a numeric suffix.
+func StoreTaskAttachment(projectDir, taskID, src string) (string, error) {
+	if err := checkAttachment(src); err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(projectDir, filepath.FromSlash(AutoAttachmentsDir), attachmentDirName(taskID))
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create attachment directory: %w", err)
+	}
+	base := filepath.Base(src)
+	ext := filepath.Ext(base)
+	dest := filepath.Join(dir, base)
+	for n := 1; ; n++ {
+		if _, err := os.Stat(dest); os.IsNotExist(err) {
+			break
+		}
+		dest = filepath.Join(dir, strings.TrimSuffix(base, ext)+"-"+strconv.Itoa(n)+ext)
+	}
+	if err := copyFile(src, dest); err != nil {
+		return "", fmt.Errorf("failed to attach %s: %w", src, err)
+	}
+	rel, err := filepath.Rel(projectDir, dest)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// checkAttachment returns an error unless src is a regular file.
+func checkAttachment(src string) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return fmt.Errorf("cannot attach %s: %w", src, err)
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("cannot attach %s: not a regular file", src)
+	}
+	return nil
+}
+
+// attachmentDirName makes a task ID safe to use as a directory name.
+func attachmentDirName(taskID string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, taskID)
+	if name == "." || name == ".." || name == "" {
+		return "_" + name
+	}
+	return name
+}
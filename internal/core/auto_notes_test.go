@@ -0,0 +1,98 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestAddTaskNote(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		note    TaskNote
+		attach  []string
+		wantErr bool
+	}{
+		{name: "human_note", id: "1", note: TaskNote{Author: NoteAuthorHuman, Text: "Blocked on credentials"}},
+		{name: "agent_note_with_attachment", id: "1", note: TaskNote{Author: NoteAuthorAgent, Text: "Log attached"}, attach: []string{"build.log"}},
+		{name: "unknown_task", id: "9", note: TaskNote{Author: NoteAuthorHuman, Text: "x"}, wantErr: true},
+		{name: "unknown_author", id: "1", note: TaskNote{Author: "bot", Text: "x"}, wantErr: true},
+		{name: "empty_text", id: "1", note: TaskNote{Author: NoteAuthorHuman, Text: "  "}, attach: []string{"build.log"}, wantErr: true},
+		{name: "missing_attachment", id: "1", note: TaskNote{Author: NoteAuthorHuman, Text: "x"}, attach: []string{"nope.log"}, wantErr: true},
+		{name: "valid_then_missing_attachment", id: "1", note: TaskNote{Author: NoteAuthorHuman, Text: "x"}, attach: []string{"build.log", "nope.log"}, wantErr: true},
+		{name: "valid_then_directory_attachment", id: "1", note: TaskNote{Author: NoteAuthorHuman, Text: "x"}, attach: []string{"build.log", "."}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.WriteFile(filepath.Join(dir, "build.log"), []byte("FAIL"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			var attach []string
+			for _, a := range tt.attach {
+				attach = append(attach, filepath.Join(dir, a))
+			}
+			prd := NewAutoPRD("test", "")
+			prd.Tasks = []AutoTask{{ID: "1", Title: "Task", Status: TaskStatusBlocked}}
+
+			err := prd.AddTaskNote(dir, tt.id, tt.note, attach)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			notes := prd.Tasks[0].Notes
+			if tt.wantErr {
+				if len(notes) != 0 {
+					t.Errorf("invalid note was recorded: %+v", notes)
+				}
+				if _, err := os.Stat(filepath.Join(dir, AutoAttachmentsDir)); !os.IsNotExist(err) {
+					t.Error("invalid note should not copy attachments")
+				}
+				return
+			}
+			if len(notes) != 1 || notes[0].Text != tt.note.Text || notes[0].Timestamp == "" {
+				t.Fatalf("notes = %+v, want one timestamped note", notes)
+			}
+			if len(notes[0].Attachments) != len(tt.attach) {
+				t.Fatalf("attachments = %v, want %d", notes[0].Attachments, len(tt.attach))
+			}
+			for _, a := range notes[0].Attachments {
+				if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(a))); err != nil {
+					t.Errorf("attachment %s not stored: %v", a, err)
+				}
+			}
+		})
+	}
+}
+
+func TestStoreTaskAttachment(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "trace.txt")
+	if err := os.WriteFile(src, []byte("trace"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		taskID  string
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{name: "first_copy", taskID: "2.1", src: src, want: AutoAttachmentsDir + "/2.1/trace.txt"},
+		{name: "name_taken", taskID: "2.1", src: src, want: AutoAttachmentsDir + "/2.1/trace-1.txt"},
+		{name: "unsafe_task_id", taskID: "../x", src: src, want: AutoAttachmentsDir + "/.._x/trace.txt"},
+		{name: "directory", taskID: "2.1", src: dir, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := StoreTaskAttachment(dir, tt.taskID, tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("StoreTaskAttachment() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Path guard actions for out-of-scope changes.
+const (
+	PathGuardBlock  = "block"
+	PathGuardRevert = "revert"
+)
+
+// GetSupportedPathGuardActions returns the accepted path guard actions.
+func GetSupportedPathGuardActions() []string {
+	return []string{PathGuardBlock, PathGuardRevert}
+}
+
+// PathGuard restricts the files the agent may change. AllowedPaths holds
+// slash-separated globs relative to the project root ("internal/api/**",
+// "docs/*.md"); a plain directory allows everything below it. A task's
+// own allowed_paths replace these for that task.
+//
+// Action decides what happens to changes outside the allowlist: "block"
+// (the default) marks the task blocked, "revert" restores uncommitted
+// out-of-scope files and blocks the task only when some were committed.
+type PathGuard struct {
+	AllowedPaths []string `json:"allowed_paths,omitempty"`
+	Action       string   `json:"action,omitempty"`
+}
+
+// PathGuardViolation describes the out-of-scope changes of one iteration.
+type PathGuardViolation struct {
+	TaskID   string
+	Paths    []string
+	Reverted []string
+	Blocked  bool
+}
+
+// action returns the configured action, defaulting to block. It is safe
+// to call on a nil guard.
+func (g *PathGuard) action() string {
+	if g == nil || g.Action == "" {
+		return PathGuardBlock
+	}
+	return g.Action
+}
+
+// AllowedPathsFor returns the globs that apply to task: its own
+// allowed_paths when set, otherwise the guard's. It is safe to call on a
+// nil guard and with a nil task.
+func (g *PathGuard) AllowedPathsFor(task *AutoTask) []string {
+	if task != nil && len(task.AllowedPaths) > 0 {
+		return task.AllowedPaths
+	}
+	if g == nil {
+		return nil
+	}
+	return g.AllowedPaths
+}
+
+// PathAllowed reports whether the slash-separated path matches one of the
+// globs, either directly or as a file below a matching directory. Files
+// under .claude/auto/ are always allowed.
+func PathAllowed(path string, globs []string) bool {
+	if underAnyPath(path, []string{AutoDir}) {
+		return true
+	}
+	for _, g := range globs {
+		g = strings.Trim(filepath.ToSlash(g), "/")
+		if matchGlobPath(g, path) || matchGlobPath(g+"/**", path) {
+			return true
+		}
+	}
+	return false
+}
+
+// iterationTask returns the task an iteration worked on: one completed or
+// started during the iteration, or else the task that was next when it
+// began.
+func iterationTask(prd *AutoPRD, snap IterationSnapshot) *AutoTask {
+	for i := range prd.Tasks {
+		t := &prd.Tasks[i]
+		if t.Status == TaskStatusInProgress || (t.Status == TaskStatusCompleted && !snap.Completed[t.ID]) {
+			return t
+		}
+	}
+	return prd.findTask(snap.NextTaskID)
+}
+
+// EnforcePathGuard checks the files changed since snap against the
+// allowlist of the iteration's task and applies the guard's action. Paths
+// that were already dirty when the iteration started are left alone, so
+// the user's own uncommitted work is never reverted or blamed on the
+// task. It returns nil when no allowlist applies or every change is in
+// scope.
+func EnforcePathGuard(cfg LoopConfig, snap IterationSnapshot) (*PathGuardViolation, error) {
+	action := cfg.PathGuard.action()
+	if !slices.Contains(GetSupportedPathGuardActions(), action) {
+		return nil, fmt.Errorf("unsupported path guard action: %s (supported: %v)", action, GetSupportedPathGuardActions())
+	}
+	prd, err := cfg.store().LoadPRD()
+	if err != nil {
+		return nil, err
+	}
+	task := iterationTask(prd, snap)
+	globs := cfg.PathGuard.AllowedPathsFor(task)
+	if len(globs) == 0 {
+		return nil, nil
+	}
+	changes, err := GitFileChangeList(cfg.ProjectDir, snap.HeadSHA)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &PathGuardViolation{}
+	var outOfScope []FileChange
+	for _, c := range changes {
+		if !snap.Dirty[c.Path] && !PathAllowed(c.Path, globs) {
+			outOfScope = append(outOfScope, c)
+			v.Paths = append(v.Paths, c.Path)
+		}
+	}
+	if len(outOfScope) == 0 {
+		return nil, nil
+	}
+	if task != nil {
+		v.TaskID = task.ID
+	}
+	if action == PathGuardRevert {
+		if v.Reverted, err = revertUncommitted(cfg.ProjectDir, snap.HeadSHA, outOfScope); err != nil {
+			return v, err
+		}
+		if len(v.Reverted) == len(outOfScope) {
+			return v, nil
+		}
+	}
+	if task == nil {
+		return v, nil
+	}
+	blockOutOfScopeTask(task, v, globs)
+	prd.RecalculateProgress()
+	return v, cfg.store().SavePRD(prd)
+}
+
+// revertUncommitted restores out-of-scope files to their state at fromSHA,
+// skipping those changed by a commit since then: rewriting history is
+// left to a human. It returns the reverted paths.
+func revertUncommitted(dir, fromSHA string, changes []FileChange) ([]string, error) {
+	committed, err := GitCommittedPaths(dir, fromSHA)
+	if err != nil {
+		return nil, err
+	}
+	var reverted []string
+	for _, c := range changes {
+		if slices.Contains(committed, c.Path) {
+			continue
+		}
+		if c.Status == "A" {
+			_, _ = runGit(dir, "rm", "-q", "--cached", "-f", "--", c.Path)
+			if err := os.Remove(filepath.Join(dir, filepath.FromSlash(c.Path))); err != nil && !os.IsNotExist(err) {
+				return reverted, fmt.Errorf("failed to remove %s: %w", c.Path, err)
+			}
+		} else if out, err := runGit(dir, "checkout", fromSHA, "--", c.Path); err != nil {
+			return reverted, fmt.Errorf("failed to restore %s: %s", c.Path, out)
+		}
+		reverted = append(reverted, c.Path)
+	}
+	return reverted, nil
+}
+
+// blockOutOfScopeTask marks task blocked with a system note listing the
+// out-of-scope paths that were not reverted.
+func blockOutOfScopeTask(task *AutoTask, v *PathGuardViolation, globs []string) {
+	var remaining []string
+	for _, p := range v.Paths {
+		if !slices.Contains(v.Reverted, p) {
+			remaining = append(remaining, p)
+		}
+	}
+	task.Status, task.CompletedAt, task.CommitSHA = TaskStatusBlocked, "", ""
+	task.Notes = append(task.Notes, TaskNote{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Author:    NoteAuthorSystem,
+		Text: fmt.Sprintf("Blocked: changed files outside the allowed paths (%s): %s. Undo them or widen allowed_paths, then reset the task.",
+			strings.Join(globs, ", "), strings.Join(remaining, ", ")),
+	})
+	v.Blocked = true
+}
+
+// ApplyPathGuard enforces the path guard after an iteration and reports
+// the outcome through cfg.OnPathGuard.
+func ApplyPathGuard(cfg LoopConfig, iter int, snap IterationSnapshot) {
+	v, err := EnforcePathGuard(cfg, snap)
+	if cfg.OnPathGuard != nil && (err != nil || v != nil) {
+		cfg.OnPathGuard(iter, v, err)
+	}
+}
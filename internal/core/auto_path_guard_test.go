@@ -0,0 +1,161 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPathAllowed(t *testing.T) {
+	tests := []struct {
+		name  string
+		path  string
+		globs []string
+		want  bool
+	}{
+		{name: "directory", path: "internal/api/handler.go", globs: []string{"internal/api"}, want: true},
+		{name: "directory_trailing_slash", path: "internal/api/v1/x.go", globs: []string{"internal/api/"}, want: true},
+		{name: "double_star", path: "internal/api/v1/x.go", globs: []string{"internal/**/*.go"}, want: true},
+		{name: "single_star", path: "docs/guide.md", globs: []string{"docs/*.md"}, want: true},
+		{name: "single_star_nested", path: "docs/sub/guide.md", globs: []string{"docs/*.md"}, want: false},
+		{name: "outside", path: "cmd/main.go", globs: []string{"internal/api", "docs/*.md"}, want: false},
+		{name: "prefix_is_not_directory", path: "internal/apiv2/x.go", globs: []string{"internal/api"}, want: false},
+		{name: "auto_state_always_allowed", path: AutoDir + "/prd.json", globs: []string{"src"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PathAllowed(tt.path, tt.globs); got != tt.want {
+				t.Errorf("PathAllowed(%q, %v) = %v, want %v", tt.path, tt.globs, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPathGuard_AllowedPathsFor(t *testing.T) {
+	guard := &PathGuard{AllowedPaths: []string{"src"}}
+	var nilGuard *PathGuard
+	if got := guard.AllowedPathsFor(&AutoTask{AllowedPaths: []string{"docs"}}); len(got) != 1 || got[0] != "docs" {
+		t.Errorf("task allowlist should win, got %v", got)
+	}
+	if got := guard.AllowedPathsFor(&AutoTask{}); len(got) != 1 || got[0] != "src" {
+		t.Errorf("config allowlist should apply, got %v", got)
+	}
+	if got := nilGuard.AllowedPathsFor(nil); got != nil {
+		t.Errorf("nil guard = %v, want nil", got)
+	}
+}
+
+func TestEnforcePathGuard(t *testing.T) {
+	tests := []struct {
+		name         string
+		guard        *PathGuard
+		taskPaths    []string
+		commitStray  bool
+		wantNil      bool
+		wantBlocked  bool
+		wantReverted int
+	}{
+		{name: "no_allowlist", wantNil: true},
+		{name: "in_scope", guard: &PathGuard{AllowedPaths: []string{"src", "stray.go", "README.md"}}, wantNil: true},
+		{name: "block", guard: &PathGuard{AllowedPaths: []string{"src"}}, wantBlocked: true},
+		{name: "task_allowlist", taskPaths: []string{"src"}, wantBlocked: true},
+		{name: "revert_uncommitted", guard: &PathGuard{AllowedPaths: []string{"src"}, Action: PathGuardRevert}, wantReverted: 2},
+		{name: "revert_committed_blocks", guard: &PathGuard{AllowedPaths: []string{"src"}, Action: PathGuardRevert}, commitStray: true, wantBlocked: true, wantReverted: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := setupPolicyRepo(t, []AutoTask{{ID: "1", Title: "API", Status: TaskStatusPending, AllowedPaths: tt.taskPaths}})
+			commitFile(t, cfg.ProjectDir, "README.md", "docs: readme")
+			cfg.PathGuard = tt.guard
+			snap := SnapshotIteration(cfg)
+
+			dir := cfg.ProjectDir
+			os.MkdirAll(filepath.Join(dir, "src"), 0755)
+			os.WriteFile(filepath.Join(dir, "src", "api.go"), []byte("package src"), 0644)
+			if tt.commitStray {
+				commitFile(t, dir, "stray.go", "chore: stray")
+			} else {
+				os.WriteFile(filepath.Join(dir, "stray.go"), []byte("x"), 0644)
+			}
+			os.WriteFile(filepath.Join(dir, "README.md"), []byte("edited"), 0644)
+
+			v, err := EnforcePathGuard(cfg, snap)
+			if err != nil {
+				t.Fatalf("EnforcePathGuard: %v", err)
+			}
+			if tt.wantNil {
+				if v != nil {
+					t.Fatalf("violation = %+v, want nil", v)
+				}
+				return
+			}
+			if v == nil || v.TaskID != "1" || len(v.Paths) != 2 {
+				t.Fatalf("violation = %+v, want stray.go and README.md for task 1", v)
+			}
+			if v.Blocked != tt.wantBlocked || len(v.Reverted) != tt.wantReverted {
+				t.Errorf("violation = %+v, want blocked=%v reverted=%d", v, tt.wantBlocked, tt.wantReverted)
+			}
+
+			prd, _ := LoadAutoPRD(cfg.PRDPath)
+			task := prd.GetTask("1")
+			if blocked := task.Status == TaskStatusBlocked; blocked != tt.wantBlocked {
+				t.Errorf("task status = %s, wantBlocked %v", task.Status, tt.wantBlocked)
+			}
+			if tt.wantBlocked && (len(task.Notes) != 1 || task.Notes[0].Author != NoteAuthorSystem) {
+				t.Errorf("notes = %+v, want one system note", task.Notes)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "src", "api.go")); err != nil {
+				t.Error("in-scope file should be kept")
+			}
+			if tt.wantReverted > 0 {
+				if data, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(data) != "docs: readme" {
+					t.Errorf("README.md = %q, want restored content", data)
+				}
+			}
+		})
+	}
+}
+
+func TestEnforcePathGuard_UnsupportedAction(t *testing.T) {
+	cfg := LoopConfig{PathGuard: &PathGuard{Action: "delete"}}
+	if _, err := EnforcePathGuard(cfg, IterationSnapshot{}); err == nil {
+		t.Error("expected error for unsupported action")
+	}
+}
+
+func TestEnforcePathGuard_PreexistingChangesUntouched(t *testing.T) {
+	for _, action := range GetSupportedPathGuardActions() {
+		t.Run(action, func(t *testing.T) {
+			cfg := setupPolicyRepo(t, []AutoTask{{ID: "1", Title: "API", Status: TaskStatusPending}})
+			dir := cfg.ProjectDir
+			commitFile(t, dir, "README.md", "docs: readme")
+			cfg.PathGuard = &PathGuard{AllowedPaths: []string{"src"}, Action: action}
+
+			// The user's own uncommitted work, outside the allowlist.
+			os.WriteFile(filepath.Join(dir, "README.md"), []byte("user edit"), 0644)
+			os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("scratch"), 0644)
+			snap := SnapshotIteration(cfg)
+
+			os.MkdirAll(filepath.Join(dir, "src"), 0755)
+			os.WriteFile(filepath.Join(dir, "src", "api.go"), []byte("package src"), 0644)
+
+			v, err := EnforcePathGuard(cfg, snap)
+			if err != nil {
+				t.Fatalf("EnforcePathGuard: %v", err)
+			}
+			if v != nil {
+				t.Fatalf("violation = %+v, want nil for pre-existing changes", v)
+			}
+			if data, _ := os.ReadFile(filepath.Join(dir, "README.md")); string(data) != "user edit" {
+				t.Errorf("README.md = %q, want the user's edit kept", data)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "notes.txt")); err != nil {
+				t.Error("untracked user file should be kept")
+			}
+			prd, _ := LoadAutoPRD(cfg.PRDPath)
+			if task := prd.GetTask("1"); task.Status == TaskStatusBlocked {
+				t.Error("task should not be blocked for pre-existing changes")
+			}
+		})
+	}
+}
@@ -72,6 +72,7 @@ func InitPilotPRD(projectDir string, config AutoConfig, pilot *PilotConfig) *Aut
 			Sandbox:         config.Sandbox,
 			SandboxImage:    config.SandboxImage,
 			SandboxTemplate: config.SandboxTemplate,
+			SandboxOffline:  config.SandboxOffline,
 			PilotMode:       true,
 			PilotConfig:     pilot,
 			DiscoveryPrompt: filepath.Join(AutoDir, AutoDiscoveryPromptFile),
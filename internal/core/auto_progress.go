@@ -44,32 +44,37 @@ func FormatProgressEntry(entry ProgressEntry) string {
 
 // AppendProgress appends a formatted entry to the progress file
 func AppendProgress(path string, entry ProgressEntry) error {
+	return appendLine(path, FormatProgressEntry(entry))
+}
+
+// appendLine appends one line to the progress file, creating it if needed.
+func appendLine(path, line string) error {
 	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
 	if err != nil {
 		return fmt.Errorf("failed to open progress file: %w", err)
 	}
 	defer f.Close()
 
-	line := FormatProgressEntry(entry) + "\n"
-	if _, err := f.WriteString(line); err != nil {
+	if _, err := f.WriteString(line + "\n"); err != nil {
 		return fmt.Errorf("failed to write progress entry: %w", err)
 	}
 	return nil
 }
 
-// ReadProgressTail reads the last N lines from the progress file
+// ReadProgressTail reads the last N lines of notes from the progress file,
+// after its front section (see ProgressHeader)
 func ReadProgressTail(path string, lines int) ([]string, error) {
-	f, err := os.Open(path)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			return nil, nil
 		}
 		return nil, fmt.Errorf("failed to open progress file: %w", err)
 	}
-	defer f.Close()
+	_, body, _ := splitProgressHeader(string(data))
 
 	var allLines []string
-	scanner := bufio.NewScanner(f)
+	scanner := bufio.NewScanner(strings.NewReader(body))
 	for scanner.Scan() {
 		allLines = append(allLines, scanner.Text())
 	}
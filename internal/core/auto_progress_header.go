@@ -0,0 +1,157 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// progressHeaderDelim opens and closes the front section of progress.md.
+const progressHeaderDelim = "---"
+
+// progressHeaderComment is the first line of the front section, telling
+// agents and people not to edit it.
+const progressHeaderComment = "# Maintained by samuel auto after every iteration. Do not edit; append notes below."
+
+// ProgressHeader is the machine-readable front section of progress.md: a
+// YAML block between "---" lines that the loop rewrites after every
+// iteration. Freeform notes follow it and are never touched, so commands
+// and agents read state from here instead of parsing prose.
+type ProgressHeader struct {
+	UpdatedAt           string            `yaml:"updated_at"`
+	CurrentTask         string            `yaml:"current_task,omitempty"`
+	CurrentTaskTitle    string            `yaml:"current_task_title,omitempty"`
+	LastIteration       int               `yaml:"last_iteration,omitempty"`
+	LastIterationType   string            `yaml:"last_iteration_type,omitempty"`
+	LastIterationStatus string            `yaml:"last_iteration_status,omitempty"`
+	LastIterationError  string            `yaml:"last_iteration_error,omitempty"`
+	CompletedTasks      int               `yaml:"completed_tasks"`
+	TotalTasks          int               `yaml:"total_tasks"`
+	Blockers            []ProgressBlocker `yaml:"blockers,omitempty"`
+}
+
+// ProgressBlocker is a blocked task and, if one was recorded, the text of
+// its latest note.
+type ProgressBlocker struct {
+	Task   string `yaml:"task"`
+	Title  string `yaml:"title"`
+	Reason string `yaml:"reason,omitempty"`
+}
+
+// NewProgressHeader summarizes prd after iteration iter (0 before the
+// first) of type iterType ended with iterErr. The current task is the one
+// in progress, else the one the next iteration picks up.
+func NewProgressHeader(prd *AutoPRD, filter TaskFilter, iter int, iterType string, iterErr error) ProgressHeader {
+	prd.RecalculateProgress()
+	h := ProgressHeader{
+		UpdatedAt:      time.Now().UTC().Format(time.RFC3339),
+		CompletedTasks: prd.Progress.CompletedTasks,
+		TotalTasks:     prd.Progress.TotalTasks,
+	}
+	if iter > 0 {
+		h.LastIteration, h.LastIterationType = iter, iterType
+		h.LastIterationStatus = iterationStatus(iterErr)
+		if iterErr != nil {
+			h.LastIterationError = iterErr.Error()
+		}
+	}
+	if task := currentTask(prd, filter); task != nil {
+		h.CurrentTask, h.CurrentTaskTitle = task.ID, task.Title
+	}
+	for _, t := range prd.Tasks {
+		if t.Status != TaskStatusBlocked {
+			continue
+		}
+		b := ProgressBlocker{Task: t.ID, Title: t.Title}
+		if n := len(t.Notes); n > 0 {
+			b.Reason = t.Notes[n-1].Text
+		}
+		h.Blockers = append(h.Blockers, b)
+	}
+	return h
+}
+
+func currentTask(prd *AutoPRD, filter TaskFilter) *AutoTask {
+	for i := range prd.Tasks {
+		if prd.Tasks[i].Status == TaskStatusInProgress && filter.Matches(&prd.Tasks[i]) {
+			return &prd.Tasks[i]
+		}
+	}
+	return prd.GetNextTaskFor(filter)
+}
+
+// iterationStatus returns HookStatusSuccess or HookStatusFailed for the
+// outcome of an iteration.
+func iterationStatus(err error) string {
+	if err != nil {
+		return HookStatusFailed
+	}
+	return HookStatusSuccess
+}
+
+// UpdateProgressHeader rewrites the front section of progress.md for the
+// iteration that just ended, leaving the notes below it unchanged.
+func UpdateProgressHeader(cfg LoopConfig, iter int, iterType string, iterErr error) error {
+	prd, err := cfg.store().LoadPRD()
+	if err != nil {
+		return err
+	}
+	header := NewProgressHeader(prd, TaskFilter{Milestone: cfg.Milestone}, iter, iterType, iterErr)
+	return WriteProgressHeader(filepath.Join(GetAutoDir(cfg.ProjectDir), AutoProgressFile), header)
+}
+
+// WriteProgressHeader replaces the front section of the progress file at
+// path with header, adding one to a file without it.
+func WriteProgressHeader(path string, header ProgressHeader) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read progress file: %w", err)
+	}
+	_, body, _ := splitProgressHeader(string(data))
+	front, err := yaml.Marshal(header)
+	if err != nil {
+		return fmt.Errorf("failed to encode progress header: %w", err)
+	}
+	content := progressHeaderDelim + "\n" + progressHeaderComment + "\n" + string(front) + progressHeaderDelim + "\n" + body
+	return writeFileAtomic(path, []byte(content), 0644, false)
+}
+
+// ReadProgressHeader returns the front section of the progress file at
+// path, or nil when the file or its front section does not exist.
+func ReadProgressHeader(path string) (*ProgressHeader, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress file: %w", err)
+	}
+	front, _, ok := splitProgressHeader(string(data))
+	if !ok {
+		return nil, nil
+	}
+	var header ProgressHeader
+	if err := yaml.Unmarshal([]byte(front), &header); err != nil {
+		return nil, fmt.Errorf("failed to parse progress.md header: %w", err)
+	}
+	return &header, nil
+}
+
+// splitProgressHeader splits progress.md content into its front section
+// (without the delimiters) and the notes that follow. Content without a
+// front section is all body.
+func splitProgressHeader(content string) (front, body string, ok bool) {
+	if !strings.HasPrefix(content, progressHeaderDelim+"\n") {
+		return "", content, false
+	}
+	rest := content[len(progressHeaderDelim)+1:]
+	end := strings.Index(rest, "\n"+progressHeaderDelim+"\n")
+	if end < 0 {
+		return "", content, false
+	}
+	return rest[:end+1], rest[end+len(progressHeaderDelim)+2:], true
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewProgressHeader(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "Done", Status: TaskStatusCompleted},
+		{ID: "2", Title: "Stuck", Status: TaskStatusBlocked, Notes: []TaskNote{{Text: "old"}, {Text: "needs API key"}}},
+		{ID: "3", Title: "Working", Status: TaskStatusInProgress},
+		{ID: "4", Title: "Next", Status: TaskStatusPending},
+	}
+
+	h := NewProgressHeader(prd, TaskFilter{}, 4, IterationTypeImplementation, errors.New("exit status 1"))
+	if h.CurrentTask != "3" || h.CurrentTaskTitle != "Working" {
+		t.Errorf("current task = %s %q, want the task in progress", h.CurrentTask, h.CurrentTaskTitle)
+	}
+	if h.LastIteration != 4 || h.LastIterationStatus != HookStatusFailed || h.LastIterationError != "exit status 1" {
+		t.Errorf("last iteration = %d %s %q", h.LastIteration, h.LastIterationStatus, h.LastIterationError)
+	}
+	if h.CompletedTasks != 1 || h.TotalTasks != 4 {
+		t.Errorf("tasks = %d/%d, want 1/4", h.CompletedTasks, h.TotalTasks)
+	}
+	if len(h.Blockers) != 1 || h.Blockers[0].Task != "2" || h.Blockers[0].Reason != "needs API key" {
+		t.Errorf("blockers = %+v, want task 2 with its latest note", h.Blockers)
+	}
+
+	prd.Tasks[2].Status = TaskStatusCompleted
+	if h := NewProgressHeader(prd, TaskFilter{}, 0, "", nil); h.CurrentTask != "4" || h.LastIteration != 0 || h.LastIterationStatus != "" {
+		t.Errorf("header before the first iteration = %+v", h)
+	}
+}
+
+func TestWriteProgressHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing string
+		wantBody string
+	}{
+		{name: "new file"},
+		{name: "notes only", existing: "[t] LEARNING: a\n", wantBody: "[t] LEARNING: a\n"},
+		{name: "replace header", existing: "---\nlast_iteration: 1\n---\n[t] LEARNING: a\n---\n", wantBody: "[t] LEARNING: a\n---\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), AutoProgressFile)
+			if tt.existing != "" {
+				writeTestFile(t, path, tt.existing)
+			}
+			header := ProgressHeader{LastIteration: 2, CurrentTask: "1.2", Blockers: []ProgressBlocker{{Task: "3", Title: "T"}}}
+
+			if err := WriteProgressHeader(path, header); err != nil {
+				t.Fatalf("WriteProgressHeader() error = %v", err)
+			}
+			data, _ := os.ReadFile(path)
+			if !strings.HasPrefix(string(data), "---\n"+progressHeaderComment+"\n") || !strings.HasSuffix(string(data), "---\n"+tt.wantBody) {
+				t.Errorf("progress.md = %q", data)
+			}
+			got, err := ReadProgressHeader(path)
+			if err != nil || got == nil {
+				t.Fatalf("ReadProgressHeader() = %v, %v", got, err)
+			}
+			if got.LastIteration != 2 || got.CurrentTask != "1.2" || len(got.Blockers) != 1 {
+				t.Errorf("ReadProgressHeader() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestReadProgressHeader_Absent(t *testing.T) {
+	dir := t.TempDir()
+	if h, err := ReadProgressHeader(filepath.Join(dir, "missing.md")); h != nil || err != nil {
+		t.Errorf("missing file: got %v, %v", h, err)
+	}
+	path := filepath.Join(dir, AutoProgressFile)
+	writeTestFile(t, path, "[t] LEARNING: no header\n---\n")
+	if h, err := ReadProgressHeader(path); h != nil || err != nil {
+		t.Errorf("no header: got %v, %v", h, err)
+	}
+}
+
+func TestReadProgressTail_SkipsHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), AutoProgressFile)
+	writeTestFile(t, path, "---\nlast_iteration: 1\n---\nfirst\nsecond\n")
+
+	lines, err := ReadProgressTail(path, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(lines, ",") != "first,second" {
+		t.Errorf("ReadProgressTail() = %v, want only the notes", lines)
+	}
+}
+
+func TestUpdateProgressHeader(t *testing.T) {
+	cfg := setupPolicyRepo(t, []AutoTask{{ID: "1", Title: "Task", Status: TaskStatusPending}})
+	path := filepath.Join(GetAutoDir(cfg.ProjectDir), AutoProgressFile)
+	writeTestFile(t, path, "[t] LEARNING: keep me\n")
+
+	if err := UpdateProgressHeader(cfg, 1, IterationTypeImplementation, nil); err != nil {
+		t.Fatalf("UpdateProgressHeader() error = %v", err)
+	}
+	h, err := ReadProgressHeader(path)
+	if err != nil || h == nil || h.CurrentTask != "1" || h.LastIterationStatus != HookStatusSuccess {
+		t.Fatalf("header = %+v, %v", h, err)
+	}
+	if lines, _ := ReadProgressTail(path, 0); strings.Join(lines, "\n") != "[t] LEARNING: keep me" {
+		t.Errorf("notes = %v, want them unchanged", lines)
+	}
+}
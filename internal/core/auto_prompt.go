@@ -19,8 +19,9 @@ Each iteration is independent — you start with a fresh context window.
 
 1. **Read project context**:
    - Read ` + "`CLAUDE.md`" + ` or ` + "`AGENTS.md`" + ` for project guardrails
-   - Read ` + "`.claude/auto/progress.md`" + ` for learnings from prior iterations
+   - Read ` + "`.claude/auto/progress.md`" + ` for learnings from prior iterations; its front section between the ` + "`---`" + ` lines holds the current task, last iteration and blockers, is rewritten by the loop, and must not be edited
    - Read ` + "`.claude/auto/prd.json`" + ` to find the task list and current state
+   - Run ` + "`samuel skill cat <skill> --section guardrails`" + ` to load the guardrails of a skill relevant to the task
 
 2. **Select the next task**:
    - Find the highest-priority task with status "pending"
@@ -31,6 +32,7 @@ Each iteration is independent — you start with a fresh context window.
 3. **Implement the task**:
    - Update the task's status to "in_progress" in prd.json
    - Follow project guardrails from CLAUDE.md
+   - Only change files matching the task's ` + "`allowed_paths`" + ` (or ` + "`config.path_guard.allowed_paths`" + `) when set; other changes are reverted or block the task
    - Write tests alongside code
    - Keep changes atomic — one task per iteration
 
@@ -50,7 +52,7 @@ Each iteration is independent — you start with a fresh context window.
    - Update ` + "`progress.total_tasks`" + ` and ` + "`progress.completed_tasks`" + `
 
 7. **Document learnings**:
-   - Append any insights, gotchas, or decisions to ` + "`.claude/auto/progress.md`" + `
+   - Append any insights, gotchas, or decisions to the end of ` + "`.claude/auto/progress.md`" + `, below its front section
    - Format: ` + "`[timestamp] [iteration:N] [task:ID] LEARNING: description`" + `
 
 ## Rules
@@ -66,7 +68,8 @@ Each iteration is independent — you start with a fresh context window.
 
 If you encounter errors:
 1. Try to fix them within this iteration
-2. If unfixable, mark the task as "blocked" with a description
+2. If unfixable, mark the task as "blocked" and record why with
+   ` + "`samuel auto task note <id> --author agent \"<reason>\"`" + `
 3. Append the error details to progress.md as a LEARNING entry
 4. The next iteration will have fresh context and can try a different approach
 `
@@ -82,6 +85,10 @@ func GeneratePromptFile(config AutoConfig) string {
 	fmt.Fprintf(&sb, "- **Max Iterations**: %d\n", config.MaxIterations)
 	fmt.Fprintf(&sb, "- **PRD File**: %s\n", filepath.Join(AutoDir, AutoPRDFile))
 	fmt.Fprintf(&sb, "- **Progress File**: %s\n", filepath.Join(AutoDir, AutoProgressFile))
+	fmt.Fprintf(&sb, "- **Project Stats**: %s (languages, key directories, entry points, tests; read it instead of re-exploring the tree)\n",
+		filepath.Join(AutoDir, AutoContextDir, AutoProjectStatsFile))
+
+	sb.WriteString(contextFilesSection("###", config.ContextFiles))
 
 	if len(config.QualityChecks) > 0 {
 		sb.WriteString("\n### Quality Checks\n\n")
@@ -93,6 +100,12 @@ func GeneratePromptFile(config AutoConfig) string {
 		sb.WriteString("```\n")
 	}
 
+	if strings.ToLower(config.ScoringStrategy) == ScoringStrategyWSJF {
+		sb.WriteString("\n### Task Selection\n\n")
+		sb.WriteString("This project ranks tasks with WSJF scoring (value, unlocks, age, size).\n")
+		sb.WriteString("Run `samuel auto next` to get the recommended task instead of picking by priority alone.\n")
+	}
+
 	if config.PilotMode {
 		sb.WriteString("\n## Pilot Mode Note\n\n")
 		sb.WriteString("This loop is running in **pilot mode** — tasks were auto-discovered.\n")
@@ -102,3 +115,20 @@ func GeneratePromptFile(config AutoConfig) string {
 
 	return sb.String()
 }
+
+// contextFilesSection lists the curated context files the agent reads on
+// every iteration under a heading of the given level; "" when there are
+// none. Globs are listed as registered, so files added later are read too.
+func contextFilesSection(level string, files []string) string {
+	if len(files) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "\n%s Context Files\n\n", level)
+	sb.WriteString("Read these files at the start of every iteration. They were curated for this project ")
+	sb.WriteString("(architecture, API specs); a glob stands for every file it matches:\n\n")
+	for _, f := range files {
+		fmt.Fprintf(&sb, "- `%s`\n", f)
+	}
+	return sb.String()
+}
@@ -62,6 +62,7 @@ func TestGeneratePromptFile(t *testing.T) {
 				"- **Max Iterations**: 10",
 				"- **PRD File**: " + filepath.Join(AutoDir, AutoPRDFile),
 				"- **Progress File**: " + filepath.Join(AutoDir, AutoProgressFile),
+				"- **Project Stats**: " + filepath.Join(AutoDir, AutoContextDir, AutoProjectStatsFile),
 			},
 			wantNotContain: []string{
 				"### Quality Checks",
@@ -87,6 +88,18 @@ func TestGeneratePromptFile(t *testing.T) {
 				"## Pilot Mode Note",
 			},
 		},
+		{
+			name: "with wsjf scoring",
+			config: AutoConfig{
+				AITool:          "claude",
+				MaxIterations:   10,
+				ScoringStrategy: ScoringStrategyWSJF,
+			},
+			wantContains: []string{
+				"### Task Selection",
+				"samuel auto next",
+			},
+		},
 		{
 			name: "with pilot mode",
 			config: AutoConfig{
@@ -218,3 +231,20 @@ func TestGeneratePromptFile_QualityChecksOrder(t *testing.T) {
 			firstIdx, secondIdx, thirdIdx)
 	}
 }
+
+func TestGeneratePromptFile_ContextFiles(t *testing.T) {
+	config := AutoConfig{AITool: "claude", MaxIterations: 10}
+	if strings.Contains(GeneratePromptFile(config), "Context Files") {
+		t.Error("prompt without context files should not have a Context Files section")
+	}
+	config.ContextFiles = []string{"docs/architecture.md", "docs/adr/*.md"}
+	result := GeneratePromptFile(config)
+	for _, want := range []string{"### Context Files", "- `docs/architecture.md`\n", "- `docs/adr/*.md`\n"} {
+		if !strings.Contains(result, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+	if !strings.Contains(GenerateDiscoveryPrompt(config, nil), "## Context Files") {
+		t.Error("discovery prompt should list the context files")
+	}
+}
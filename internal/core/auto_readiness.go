@@ -0,0 +1,195 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Readiness finding severities. Errors make the plan unrunnable; warnings
+// make iterations likely to be wasted.
+const (
+	ReadinessError   = "error"
+	ReadinessWarning = "warning"
+)
+
+// Points deducted from a perfect readiness score of 100 per finding.
+const (
+	readinessErrorPenalty   = 15
+	readinessWarningPenalty = 5
+)
+
+// Thresholds for flagging a task as too large to finish in one iteration.
+const (
+	readinessMaxTaskFiles = 8
+	readinessMinTitleWord = 3
+)
+
+// vagueTitleWords are placeholders that say nothing about the work.
+var vagueTitleWords = []string{
+	"misc", "stuff", "things", "todo", "tbd", "wip", "various", "etc", "fixes", "cleanup",
+}
+
+// ReadinessFinding is a single problem found in a PRD.
+type ReadinessFinding struct {
+	TaskID   string
+	Severity string
+	Check    string
+	Message  string
+}
+
+// ReadinessReport scores how well a PRD suits the autonomous loop.
+type ReadinessReport struct {
+	Score    int
+	Tasks    int // open tasks analysed
+	Findings []ReadinessFinding
+}
+
+// Errors returns the number of error findings.
+func (r *ReadinessReport) Errors() int {
+	n := 0
+	for _, f := range r.Findings {
+		if f.Severity == ReadinessError {
+			n++
+		}
+	}
+	return n
+}
+
+// AnalyzeReadiness checks every open (pending, in-progress or blocked) task
+// for problems that tend to waste loop iterations: tasks too large to finish
+// in one pass, described tasks without acceptance criteria, vague titles, unknown
+// dependencies, and tasks that touch files another task creates without
+// depending on it.
+func (p *AutoPRD) AnalyzeReadiness() *ReadinessReport {
+	report := &ReadinessReport{}
+	children := make(map[string]int)
+	for _, t := range p.Tasks {
+		if t.ParentID != "" {
+			children[t.ParentID]++
+		}
+	}
+	creators := fileCreators(p.Tasks)
+
+	for i := range p.Tasks {
+		t := &p.Tasks[i]
+		if t.Status == TaskStatusCompleted || t.Status == TaskStatusSkipped {
+			continue
+		}
+		report.Tasks++
+		report.Findings = append(report.Findings, p.checkTaskReadiness(t, children[t.ID], creators)...)
+	}
+
+	report.Score = 100
+	for _, f := range report.Findings {
+		if f.Severity == ReadinessError {
+			report.Score -= readinessErrorPenalty
+		} else {
+			report.Score -= readinessWarningPenalty
+		}
+	}
+	report.Score = max(report.Score, 0)
+	return report
+}
+
+// checkTaskReadiness runs every readiness check against one open task.
+func (p *AutoPRD) checkTaskReadiness(t *AutoTask, subtasks int, creators map[string]string) []ReadinessFinding {
+	var findings []ReadinessFinding
+	add := func(severity, check, format string, args ...interface{}) {
+		findings = append(findings, ReadinessFinding{
+			TaskID: t.ID, Severity: severity, Check: check, Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	files := len(t.FilesToCreate) + len(t.FilesToModify)
+	if subtasks == 0 && (t.Complexity == TaskComplexityComplex || files > readinessMaxTaskFiles) {
+		add(ReadinessWarning, "size",
+			"%s task touching %d files has no subtasks; split it so one iteration can finish it",
+			complexityOrDefault(t.Complexity), files)
+	}
+	// A task with only a title, as converted from a plain task list, has
+	// nothing to hold criteria; the title check covers it instead.
+	if t.Description != "" && len(t.AcceptanceCriteria) == 0 && !mentionsAcceptance(t.Description) {
+		add(ReadinessWarning, "acceptance",
+			"no acceptance criteria; add acceptance_criteria so the agent knows when it is done")
+	}
+	if isVagueTitle(t.Title) {
+		add(ReadinessWarning, "title", "title %q is too vague to act on", t.Title)
+	}
+
+	for _, dep := range t.DependsOn {
+		if p.findTask(dep) == nil {
+			add(ReadinessError, "dependency", "depends on unknown task %s", dep)
+		}
+	}
+	for _, file := range t.FilesToModify {
+		creator, ok := creators[file]
+		if ok && creator != t.ID && !p.dependsOn(t.ID, creator) {
+			add(ReadinessWarning, "ordering",
+				"modifies %s, which task %s creates, but does not depend on %s", file, creator, creator)
+		}
+	}
+	return findings
+}
+
+// fileCreators maps each file in files_to_create to the task creating it.
+func fileCreators(tasks []AutoTask) map[string]string {
+	creators := make(map[string]string)
+	for _, t := range tasks {
+		for _, f := range t.FilesToCreate {
+			if _, ok := creators[f]; !ok {
+				creators[f] = t.ID
+			}
+		}
+	}
+	return creators
+}
+
+// dependsOn reports whether task id depends on target, directly or
+// through other tasks.
+func (p *AutoPRD) dependsOn(id, target string) bool {
+	seen := make(map[string]bool)
+	queue := []string{id}
+	for len(queue) > 0 {
+		task := p.findTask(queue[0])
+		queue = queue[1:]
+		if task == nil || seen[task.ID] {
+			continue
+		}
+		seen[task.ID] = true
+		if slices.Contains(task.DependsOn, target) {
+			return true
+		}
+		queue = append(queue, task.DependsOn...)
+	}
+	return false
+}
+
+// mentionsAcceptance reports whether a description spells out when the
+// task is done.
+func mentionsAcceptance(description string) bool {
+	lower := strings.ToLower(description)
+	return strings.Contains(lower, "acceptance") || strings.Contains(lower, "done when")
+}
+
+// isVagueTitle reports whether a title is too short or contains a
+// placeholder word.
+func isVagueTitle(title string) bool {
+	words := strings.Fields(strings.ToLower(title))
+	if len(words) < readinessMinTitleWord {
+		return true
+	}
+	for _, w := range words {
+		if slices.Contains(vagueTitleWords, strings.Trim(w, ".,:;!")) {
+			return true
+		}
+	}
+	return false
+}
+
+func complexityOrDefault(c string) string {
+	if c == "" {
+		return TaskComplexityMedium
+	}
+	return c
+}
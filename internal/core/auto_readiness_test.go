@@ -0,0 +1,191 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func readyTask(id, title string) AutoTask {
+	return AutoTask{
+		ID:                 id,
+		Title:              title,
+		Status:             TaskStatusPending,
+		AcceptanceCriteria: []string{"tests pass"},
+	}
+}
+
+func TestAnalyzeReadiness(t *testing.T) {
+	tests := []struct {
+		name       string
+		tasks      func() []AutoTask
+		wantChecks []string
+		wantScore  int
+	}{
+		{
+			name: "well formed plan scores 100",
+			tasks: func() []AutoTask {
+				a := readyTask("1", "Add user model and migration")
+				a.FilesToCreate = []string{"models/user.go"}
+				b := readyTask("2", "Expose user model over REST")
+				b.FilesToModify = []string{"models/user.go"}
+				b.DependsOn = []string{"1"}
+				return []AutoTask{a, b}
+			},
+			wantScore: 100,
+		},
+		{
+			name: "large task without subtasks",
+			tasks: func() []AutoTask {
+				a := readyTask("1", "Build the billing subsystem")
+				a.Complexity = TaskComplexityComplex
+				return []AutoTask{a}
+			},
+			wantChecks: []string{"size"},
+			wantScore:  95,
+		},
+		{
+			name: "large task with subtasks is fine",
+			tasks: func() []AutoTask {
+				a := readyTask("1", "Build the billing subsystem")
+				a.Complexity = TaskComplexityComplex
+				b := readyTask("1.1", "Add invoice data model")
+				b.ParentID = "1"
+				return []AutoTask{a, b}
+			},
+			wantScore: 100,
+		},
+		{
+			name: "described task missing acceptance criteria",
+			tasks: func() []AutoTask {
+				a := readyTask("1", "Add login rate limiting")
+				a.AcceptanceCriteria = nil
+				a.Description = "Throttle repeated failed logins per account."
+				b := readyTask("2", "Add logout endpoint handler")
+				b.AcceptanceCriteria = nil
+				b.Description = "Done when POST /logout clears the session."
+				c := readyTask("3", "Add password reset emails")
+				c.AcceptanceCriteria = nil
+				return []AutoTask{a, b, c}
+			},
+			wantChecks: []string{"acceptance"},
+			wantScore:  95,
+		},
+		{
+			name: "vague titles",
+			tasks: func() []AutoTask {
+				return []AutoTask{readyTask("1", "Fix bugs"), readyTask("2", "Misc backend stuff here")}
+			},
+			wantChecks: []string{"title", "title"},
+			wantScore:  90,
+		},
+		{
+			name: "unknown dependency and missing ordering",
+			tasks: func() []AutoTask {
+				a := readyTask("1", "Create config loader package")
+				a.FilesToCreate = []string{"config/load.go"}
+				b := readyTask("2", "Validate loaded config values")
+				b.FilesToModify = []string{"config/load.go"}
+				b.DependsOn = []string{"9"}
+				return []AutoTask{a, b}
+			},
+			wantChecks: []string{"dependency", "ordering"},
+			wantScore:  80,
+		},
+		{
+			name: "transitive dependency satisfies ordering",
+			tasks: func() []AutoTask {
+				a := readyTask("1", "Create config loader package")
+				a.FilesToCreate = []string{"config/load.go"}
+				b := readyTask("2", "Add config schema types")
+				b.DependsOn = []string{"1"}
+				c := readyTask("3", "Validate loaded config values")
+				c.FilesToModify = []string{"config/load.go"}
+				c.DependsOn = []string{"2"}
+				return []AutoTask{a, b, c}
+			},
+			wantScore: 100,
+		},
+		{
+			name: "finished tasks are ignored",
+			tasks: func() []AutoTask {
+				a := readyTask("1", "wip")
+				a.Status = TaskStatusCompleted
+				return []AutoTask{a}
+			},
+			wantScore: 100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prd := &AutoPRD{Tasks: tt.tasks()}
+			report := prd.AnalyzeReadiness()
+
+			var checks []string
+			for _, f := range report.Findings {
+				checks = append(checks, f.Check)
+			}
+			if len(checks) != len(tt.wantChecks) {
+				t.Fatalf("checks = %v, want %v", checks, tt.wantChecks)
+			}
+			for i := range checks {
+				if checks[i] != tt.wantChecks[i] {
+					t.Errorf("checks = %v, want %v", checks, tt.wantChecks)
+				}
+			}
+			if report.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d", report.Score, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestAnalyzeReadiness_ScoreFloor(t *testing.T) {
+	var tasks []AutoTask
+	for _, id := range []string{"1", "2", "3", "4", "5", "6", "7", "8"} {
+		tasks = append(tasks, AutoTask{ID: id, Title: "todo", Status: TaskStatusPending, DependsOn: []string{"x"}})
+	}
+	report := (&AutoPRD{Tasks: tasks}).AnalyzeReadiness()
+	if report.Score != 0 {
+		t.Errorf("Score = %d, want 0", report.Score)
+	}
+	if report.Errors() != len(tasks) {
+		t.Errorf("Errors() = %d, want %d", report.Errors(), len(tasks))
+	}
+}
+
+func TestAnalyzeReadiness_ConvertedPRD(t *testing.T) {
+	dir := t.TempDir()
+	prdPath := filepath.Join(dir, "0001-prd-auth.md")
+	tasksPath := filepath.Join(dir, "tasks-0001-prd-auth.md")
+	writeTestFile(t, prdPath, "# User Authentication\n\nLet users sign in.\n")
+	writeTestFile(t, tasksPath, `## Tasks
+
+- [ ] 1.0 Build the login flow [~5,000 tokens - Medium]
+  - [ ] 1.1 Add the login form component [~2,000 tokens - Simple]
+  - [ ] 1.2 Validate credentials on the server [~3,000 tokens - Medium]
+    Check the password hash and lock the account after five failures.
+    - Acceptance: a wrong password returns 401
+    - Acceptance: the sixth failure locks the account
+- [ ] 2.0 Add session handling for users [~4,000 tokens - Medium]
+  Sessions expire after a day of inactivity.
+`)
+
+	prd, err := ConvertMarkdownToPRD(prdPath, tasksPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	validate := prd.GetTask("1.2")
+	if validate.Description != "Check the password hash and lock the account after five failures." || len(validate.AcceptanceCriteria) != 2 {
+		t.Errorf("task 1.2 = %+v, want description and two acceptance criteria", validate)
+	}
+
+	report := prd.AnalyzeReadiness()
+	var checks []string
+	for _, f := range report.Findings {
+		checks = append(checks, f.TaskID+":"+f.Check)
+	}
+	if len(checks) != 1 || checks[0] != "2.0:acceptance" || report.Score != 95 {
+		t.Errorf("findings = %v, score = %d; want only 2.0:acceptance and 95", checks, report.Score)
+	}
+}
@@ -0,0 +1,195 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TaskSourceReconstructed marks tasks rebuilt by ReconstructTasks.
+const TaskSourceReconstructed = "reconstructed"
+
+// progressTaskLine matches the progress.md entries that name a task, as
+// written by FormatProgressEntry: "[time] [iteration:N] [task:ID] TYPE: text".
+var progressTaskLine = regexp.MustCompile(`^\[([^\]]+)\](?: \[iteration:\d+\])? \[task:([^\]]+)\] ([A-Z_]+): ?(.*)$`)
+
+// commitTaskRef matches a task reference in a commit subject, such as
+// "task 1.1" in "feat(auth): task 1.1 - create user schema".
+var commitTaskRef = regexp.MustCompile(`(?i)\btask[ #:-]*(\d+(?:\.\d+)*)\b[ :-]*`)
+
+// RecoveredTask is a task rebuilt from the traces the loop leaves behind,
+// with the places it was found in.
+type RecoveredTask struct {
+	Task    AutoTask
+	Sources []string
+}
+
+// ReconstructTasks rebuilds a best-effort task list from progress.md and
+// the commit history of projectDir, for when prd.json is lost. Tasks are
+// in ID order. Only what the loop records survives: IDs, titles where one
+// was written down, status, and completing commits. Dependencies,
+// priorities and acceptance criteria are gone.
+func ReconstructTasks(projectDir string) ([]RecoveredTask, error) {
+	found := make(map[string]*RecoveredTask)
+	path := filepath.Join(GetAutoDir(projectDir), AutoProgressFile)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read progress.md: %w", err)
+	}
+	_, body, _ := splitProgressHeader(string(data))
+	header, _ := ReadProgressHeader(path) // a damaged header only loses its hints
+	recoverFromProgress(found, header, body)
+	if GitHeadSHA(projectDir) != "" {
+		out, err := runGit(projectDir, "log", "--format=%H%x09%cI%x09%s")
+		if err != nil {
+			return nil, fmt.Errorf("git log failed: %s", out)
+		}
+		recoverFromCommits(found, out)
+	}
+	return sortRecoveredTasks(found), nil
+}
+
+// recovered returns the entry for id, creating a pending task.
+func recovered(found map[string]*RecoveredTask, id string) *RecoveredTask {
+	r := found[id]
+	if r == nil {
+		r = &RecoveredTask{Task: AutoTask{ID: id, Status: TaskStatusPending, Source: TaskSourceReconstructed}}
+		found[id] = r
+	}
+	return r
+}
+
+// addSource records where a task was found, once per place.
+func (r *RecoveredTask) addSource(source string) {
+	if !slices.Contains(r.Sources, source) {
+		r.Sources = append(r.Sources, source)
+	}
+}
+
+// recoverFromProgress applies the task entries of the progress.md notes
+// in order, then its front section, which reflects the latest state.
+func recoverFromProgress(found map[string]*RecoveredTask, header *ProgressHeader, body string) {
+	for _, line := range strings.Split(body, "\n") {
+		m := progressTaskLine.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil || ValidateTaskID(m[2]) != nil {
+			continue
+		}
+		r := recovered(found, m[2])
+		r.addSource("progress.md")
+		switch m[3] {
+		case ProgressStarted:
+			if r.Task.Title == "" {
+				r.Task.Title = m[4]
+			}
+			if r.Task.Status == TaskStatusPending {
+				r.Task.Status = TaskStatusInProgress
+			}
+		case ProgressCompleted:
+			r.Task.Status, r.Task.CompletedAt = TaskStatusCompleted, m[1]
+		}
+	}
+	if header == nil {
+		return
+	}
+	if header.CurrentTask != "" && ValidateTaskID(header.CurrentTask) == nil {
+		r := recovered(found, header.CurrentTask)
+		r.addSource("progress.md")
+		r.Task.Title = header.CurrentTaskTitle
+	}
+	for _, b := range header.Blockers {
+		if ValidateTaskID(b.Task) != nil {
+			continue
+		}
+		r := recovered(found, b.Task)
+		r.addSource("progress.md")
+		r.Task.Title, r.Task.Status = b.Title, TaskStatusBlocked
+		if b.Reason != "" {
+			r.Task.Notes = []TaskNote{{Timestamp: time.Now().UTC().Format(time.RFC3339), Author: NoteAuthorSystem, Text: b.Reason}}
+		}
+	}
+}
+
+// recoverFromCommits marks tasks referenced by commit subjects completed.
+// log holds "hash<TAB>date<TAB>subject" lines, newest first, so a task
+// keeps its latest commit.
+func recoverFromCommits(found map[string]*RecoveredTask, log string) {
+	for _, line := range strings.Split(log, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		hash, date, subject := parts[0], parts[1], parts[2]
+		for _, m := range commitTaskRef.FindAllStringSubmatchIndex(subject, -1) {
+			r := recovered(found, subject[m[2]:m[3]])
+			r.addSource("commit " + shortSHA(hash))
+			if r.Task.Title == "" {
+				r.Task.Title = strings.TrimSpace(subject[m[1]:])
+			}
+			if r.Task.CommitSHA != "" || r.Task.Status == TaskStatusBlocked {
+				continue
+			}
+			r.Task.Status, r.Task.CommitSHA = TaskStatusCompleted, hash
+			if t, err := time.Parse(time.RFC3339, date); err == nil && r.Task.CompletedAt == "" {
+				r.Task.CompletedAt = t.UTC().Format(time.RFC3339)
+			}
+		}
+	}
+}
+
+// shortSHA abbreviates a commit hash for display.
+func shortSHA(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// sortRecoveredTasks orders the tasks by ID, fills in missing titles and
+// links subtasks to recovered parents.
+func sortRecoveredTasks(found map[string]*RecoveredTask) []RecoveredTask {
+	list := make([]RecoveredTask, 0, len(found))
+	for id, r := range found {
+		if r.Task.Title == "" {
+			r.Task.Title = "Recovered task " + id
+		}
+		r.Task.ParentID = recoveredParent(found, id)
+		list = append(list, *r)
+	}
+	slices.SortFunc(list, func(a, b RecoveredTask) int { return compareTaskIDs(a.Task.ID, b.Task.ID) })
+	return list
+}
+
+// recoveredParent returns the ID of the recovered parent of id, accepting
+// "3.0" for "3", or "".
+func recoveredParent(found map[string]*RecoveredTask, id string) string {
+	i := strings.LastIndex(id, ".")
+	if i < 0 || (id[i+1:] == "0" && !strings.Contains(id[:i], ".")) {
+		return ""
+	}
+	parent := id[:i]
+	if found[parent] != nil {
+		return parent
+	}
+	if found[parent+".0"] != nil {
+		return parent + ".0"
+	}
+	return ""
+}
+
+// compareTaskIDs orders dotted task IDs numerically, segment by segment.
+func compareTaskIDs(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		x, _ := strconv.Atoi(as[i])
+		y, _ := strconv.Atoi(bs[i])
+		if x != y {
+			return x - y
+		}
+	}
+	return len(as) - len(bs)
+}
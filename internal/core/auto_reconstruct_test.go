@@ -0,0 +1,98 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+const reconstructProgress = `---
+# Maintained by samuel auto after every iteration. Do not edit; append notes below.
+updated_at: "2026-03-01T12:00:00Z"
+current_task: "2.1"
+current_task_title: Add login form
+completed_tasks: 1
+total_tasks: 4
+blockers:
+    - task: "3"
+      title: Configure SSO
+      reason: Needs an IdP test tenant
+---
+# Progress
+[2026-03-01T10:00:00Z] [iteration:1] [task:1.1] STARTED: Create user schema
+[2026-03-01T10:20:00Z] [iteration:1] [task:1.1] COMPLETED: schema and migration
+[2026-03-01T10:30:00Z] [iteration:2] [task:2.1] STARTED: login form
+[2026-03-01T10:40:00Z] [iteration:2] [task:2.1] LEARNING: the form library needs a provider
+free-form note about task 9
+`
+
+func TestReconstructTasks(t *testing.T) {
+	cfg := setupPolicyRepo(t, nil)
+	dir := cfg.ProjectDir
+	if err := os.Remove(cfg.PRDPath); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFile(t, filepath.Join(dir, AutoDir, AutoProgressFile), reconstructProgress)
+	commitFile(t, dir, "schema.sql", "feat(db): task 1.1 - create user schema")
+	commitFile(t, dir, "api.go", "feat(api): Task 1.2: add user endpoints")
+	commitFile(t, dir, "sso.go", "wip: task 3 - SSO scaffolding")
+
+	got, err := ReconstructTasks(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	byID := make(map[string]RecoveredTask)
+	for _, r := range got {
+		ids = append(ids, r.Task.ID)
+		byID[r.Task.ID] = r
+	}
+	if want := []string{"1.1", "1.2", "2.1", "3"}; !slices.Equal(ids, want) {
+		t.Fatalf("recovered IDs = %v, want %v", ids, want)
+	}
+
+	schema := byID["1.1"]
+	if schema.Task.Status != TaskStatusCompleted || schema.Task.Title != "Create user schema" ||
+		schema.Task.CommitSHA == "" || schema.Task.CompletedAt != "2026-03-01T10:20:00Z" {
+		t.Errorf("task 1.1 = %+v", schema.Task)
+	}
+	if len(schema.Sources) != 2 || schema.Sources[0] != "progress.md" {
+		t.Errorf("task 1.1 sources = %v, want progress.md and its commit", schema.Sources)
+	}
+	if api := byID["1.2"].Task; api.Status != TaskStatusCompleted || api.Title != "add user endpoints" {
+		t.Errorf("task 1.2 = %+v", api)
+	}
+	if form := byID["2.1"].Task; form.Status != TaskStatusInProgress || form.Title != "Add login form" {
+		t.Errorf("task 2.1 = %+v, want in progress with the header title", form)
+	}
+	sso := byID["3"].Task
+	if sso.Status != TaskStatusBlocked || sso.CommitSHA != "" || len(sso.Notes) != 1 || sso.Source != TaskSourceReconstructed {
+		t.Errorf("task 3 = %+v, want blocked with its reason despite the commit", sso)
+	}
+}
+
+func TestReconstructTasks_Nothing(t *testing.T) {
+	got, err := ReconstructTasks(t.TempDir())
+	if err != nil || len(got) != 0 {
+		t.Errorf("ReconstructTasks() = %v, %v, want nothing", got, err)
+	}
+}
+
+func TestRecoveredParent(t *testing.T) {
+	found := map[string]*RecoveredTask{"1.0": {}, "1.1": {}, "2": {}, "2.1": {}, "4.2": {}}
+	tests := map[string]string{"1.0": "", "1.1": "1.0", "2.1": "2", "4.2": "", "2": ""}
+	for id, want := range tests {
+		if got := recoveredParent(found, id); got != want {
+			t.Errorf("recoveredParent(%q) = %q, want %q", id, got, want)
+		}
+	}
+}
+
+func TestCompareTaskIDs(t *testing.T) {
+	ids := []string{"10", "2.1", "2", "1.10", "1.2"}
+	slices.SortFunc(ids, compareTaskIDs)
+	if want := []string{"1.2", "1.10", "2", "2.1", "10"}; !slices.Equal(ids, want) {
+		t.Errorf("sorted = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultSMTPPort is used when an email report omits smtp_port.
+const DefaultSMTPPort = 587
+
+// smtpSendMail is swapped out in tests to avoid real network delivery.
+var smtpSendMail = smtp.SendMail
+
+var webhookHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// ReportConfig configures the post-run digest for unattended runs.
+type ReportConfig struct {
+	DigestFile string             `json:"digest_file,omitempty"`
+	Email      *EmailReportConfig `json:"email,omitempty"`
+	// Webhooks receive the digest as a JSON POST (see PostRunDigestWebhook).
+	Webhooks []string `json:"webhooks,omitempty"`
+}
+
+// EmailReportConfig holds SMTP settings for emailing the run digest.
+// The password is never stored in prd.json; PasswordEnv names the
+// environment variable that holds it.
+type EmailReportConfig struct {
+	SMTPHost    string   `json:"smtp_host"`
+	SMTPPort    int      `json:"smtp_port,omitempty"`
+	From        string   `json:"from"`
+	To          []string `json:"to"`
+	Username    string   `json:"username,omitempty"`
+	PasswordEnv string   `json:"password_env,omitempty"`
+}
+
+// RunReport summarizes a single run of the autonomous loop.
+type RunReport struct {
+	Project    string
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Iterations int
+	Failures   int
+	AgentTime  time.Duration
+	Completed  []AutoTask
+	Blocked    []AutoTask
+	Remaining  int
+	DiffStat   string
+	ExitError  string
+}
+
+// CompletedTaskIDs returns the set of task IDs already completed in a PRD.
+func CompletedTaskIDs(prd *AutoPRD) map[string]bool {
+	ids := make(map[string]bool)
+	for _, t := range prd.Tasks {
+		if t.Status == TaskStatusCompleted {
+			ids[t.ID] = true
+		}
+	}
+	return ids
+}
+
+// Finish fills in the task outcome of the run from the final PRD state.
+// Tasks in completedBefore were done before the run and are not counted.
+func (r *RunReport) Finish(prd *AutoPRD, completedBefore map[string]bool, finishedAt time.Time) {
+	r.FinishedAt = finishedAt
+	r.Project = prd.Project.Name
+	for _, t := range prd.Tasks {
+		switch t.Status {
+		case TaskStatusCompleted:
+			if !completedBefore[t.ID] {
+				r.Completed = append(r.Completed, t)
+			}
+		case TaskStatusBlocked:
+			r.Blocked = append(r.Blocked, t)
+		case TaskStatusPending, TaskStatusInProgress:
+			r.Remaining++
+		}
+	}
+}
+
+// FormatRunDigest renders the run report as a markdown digest.
+func FormatRunDigest(r *RunReport) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Auto Run Digest: %s\n\n", r.Project)
+	fmt.Fprintf(&sb, "- **Started**: %s\n", r.StartedAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "- **Finished**: %s\n", r.FinishedAt.Format(time.RFC3339))
+	fmt.Fprintf(&sb, "- **Duration**: %s\n", r.FinishedAt.Sub(r.StartedAt).Round(time.Second))
+	fmt.Fprintf(&sb, "- **Iterations**: %d (%d failed)\n", r.Iterations, r.Failures)
+	fmt.Fprintf(&sb, "- **Agent time**: %s\n", r.AgentTime.Round(time.Second))
+	// The agent CLIs print their usage to the terminal only, so the cost of
+	// a run is not known to samuel; say so rather than leave it out.
+	sb.WriteString("- **Cost**: unavailable (the agent does not report usage to samuel)\n")
+	fmt.Fprintf(&sb, "- **Tasks completed**: %d\n", len(r.Completed))
+	fmt.Fprintf(&sb, "- **Tasks remaining**: %d\n", r.Remaining)
+	if r.DiffStat != "" {
+		fmt.Fprintf(&sb, "- **Changes**: %s\n", r.DiffStat)
+	}
+	if r.ExitError != "" {
+		fmt.Fprintf(&sb, "- **Exit error**: %s\n", r.ExitError)
+	}
+
+	writeDigestTasks(&sb, "Completed", r.Completed, true)
+	writeDigestTasks(&sb, "Blocked", r.Blocked, false)
+	return sb.String()
+}
+
+func writeDigestTasks(sb *strings.Builder, heading string, tasks []AutoTask, withCommit bool) {
+	if len(tasks) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n## %s\n\n", heading)
+	for _, t := range tasks {
+		line := fmt.Sprintf("- %s: %s", t.ID, t.Title)
+		if withCommit && t.CommitSHA != "" {
+			line += fmt.Sprintf(" (%s)", t.CommitSHA)
+		}
+		sb.WriteString(line + "\n")
+	}
+}
+
+// WriteRunDigest writes the markdown digest to path, creating parent dirs.
+func WriteRunDigest(path string, r *RunReport) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create digest directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(FormatRunDigest(r)), 0644); err != nil {
+		return fmt.Errorf("failed to write digest: %w", err)
+	}
+	return nil
+}
+
+// SendRunDigestEmail emails the digest using the configured SMTP server.
+func SendRunDigestEmail(cfg *EmailReportConfig, r *RunReport) error {
+	if errs := validateEmailReport(cfg); len(errs) > 0 {
+		return fmt.Errorf("invalid email report config: %s", strings.Join(errs, "; "))
+	}
+
+	port := cfg.SMTPPort
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+	addr := fmt.Sprintf("%s:%d", cfg.SMTPHost, port)
+
+	var auth smtp.Auth
+	if cfg.Username != "" {
+		auth = smtp.PlainAuth("", cfg.Username, os.Getenv(cfg.PasswordEnv), cfg.SMTPHost)
+	}
+
+	// The project name comes from prd.json: line breaks in it must not start
+	// new headers, and non-ASCII text is encoded as RFC 2047 requires.
+	project := strings.NewReplacer("\r", " ", "\n", " ").Replace(r.Project)
+	subject := mime.QEncoding.Encode("UTF-8", fmt.Sprintf("[samuel] %s: %d completed, %d remaining",
+		project, len(r.Completed), r.Remaining))
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\n"+
+		"Content-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		cfg.From, strings.Join(cfg.To, ", "), subject, FormatRunDigest(r))
+
+	if err := smtpSendMail(addr, auth, cfg.From, cfg.To, []byte(msg)); err != nil {
+		return fmt.Errorf("failed to send digest email: %w", err)
+	}
+	return nil
+}
+
+// validateEmailReport checks required SMTP fields; nil configs are valid.
+func validateEmailReport(cfg *EmailReportConfig) []string {
+	if cfg == nil {
+		return nil
+	}
+	var errors []string
+	if cfg.SMTPHost == "" {
+		errors = append(errors, "config.report.email.smtp_host is required")
+	}
+	if cfg.From == "" {
+		errors = append(errors, "config.report.email.from is required")
+	}
+	if len(cfg.To) == 0 {
+		errors = append(errors, "config.report.email.to requires at least one recipient")
+	}
+	return errors
+}
+
+// runDigestPayload is the JSON body posted to report webhooks. Text holds
+// the markdown digest, which chat webhooks such as Slack's show as is.
+type runDigestPayload struct {
+	Text       string   `json:"text"`
+	Project    string   `json:"project"`
+	Iterations int      `json:"iterations"`
+	Completed  []string `json:"completed"`
+	Blocked    []string `json:"blocked"`
+	Remaining  int      `json:"remaining"`
+	ExitError  string   `json:"exit_error,omitempty"`
+}
+
+// PostRunDigestWebhook posts the digest to a webhook URL as JSON. Errors
+// leave out the URL, which often embeds a secret token.
+func PostRunDigestWebhook(hookURL string, r *RunReport) error {
+	if err := validateWebhookURL(hookURL); err != nil {
+		return err
+	}
+	payload := runDigestPayload{
+		Text: FormatRunDigest(r), Project: r.Project, Iterations: r.Iterations,
+		Completed: taskIDs(r.Completed), Blocked: taskIDs(r.Blocked),
+		Remaining: r.Remaining, ExitError: r.ExitError,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode digest: %w", err)
+	}
+	resp, err := webhookHTTPClient.Post(hookURL, "application/json", bytes.NewReader(body))
+	if urlErr, ok := err.(*url.Error); ok {
+		err = urlErr.Err
+	}
+	if err != nil {
+		return fmt.Errorf("failed to post digest: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post digest: %s", resp.Status)
+	}
+	return nil
+}
+
+func taskIDs(tasks []AutoTask) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
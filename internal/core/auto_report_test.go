@@ -0,0 +1,259 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRunReport() *RunReport {
+	started := time.Date(2026, 3, 1, 22, 0, 0, 0, time.UTC)
+	return &RunReport{
+		Project:    "demo",
+		StartedAt:  started,
+		FinishedAt: started.Add(2 * time.Hour),
+		Iterations: 5,
+		Failures:   1,
+		AgentTime:  90 * time.Minute,
+		Completed:  []AutoTask{{ID: "1.1", Title: "Add schema", CommitSHA: "abc123"}},
+		Blocked:    []AutoTask{{ID: "2.1", Title: "Flaky infra"}},
+		Remaining:  3,
+		DiffStat:   "4 files changed, 120 insertions(+)",
+	}
+}
+
+func TestRunReport_Finish(t *testing.T) {
+	prd := NewAutoPRD("demo", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "Done earlier", Status: TaskStatusCompleted},
+		{ID: "2", Title: "Done now", Status: TaskStatusCompleted},
+		{ID: "3", Title: "Stuck", Status: TaskStatusBlocked},
+		{ID: "4", Title: "Todo", Status: TaskStatusPending},
+		{ID: "5", Title: "Half done", Status: TaskStatusInProgress},
+		{ID: "6", Title: "Skipped", Status: TaskStatusSkipped},
+	}
+	before := map[string]bool{"1": true}
+
+	r := &RunReport{}
+	r.Finish(prd, before, time.Now())
+
+	if len(r.Completed) != 1 || r.Completed[0].ID != "2" {
+		t.Errorf("Completed = %v, want only task 2", r.Completed)
+	}
+	if len(r.Blocked) != 1 || r.Blocked[0].ID != "3" {
+		t.Errorf("Blocked = %v, want only task 3", r.Blocked)
+	}
+	if r.Remaining != 2 {
+		t.Errorf("Remaining = %d, want 2", r.Remaining)
+	}
+	if r.Project != "demo" {
+		t.Errorf("Project = %q, want demo", r.Project)
+	}
+}
+
+func TestCompletedTaskIDs(t *testing.T) {
+	prd := NewAutoPRD("demo", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Status: TaskStatusCompleted},
+		{ID: "2", Status: TaskStatusPending},
+	}
+
+	ids := CompletedTaskIDs(prd)
+	if !ids["1"] || ids["2"] {
+		t.Errorf("CompletedTaskIDs = %v, want only 1", ids)
+	}
+}
+
+func TestFormatRunDigest(t *testing.T) {
+	digest := FormatRunDigest(newTestRunReport())
+
+	for _, want := range []string{
+		"# Auto Run Digest: demo",
+		"**Duration**: 2h0m0s",
+		"**Iterations**: 5 (1 failed)",
+		"**Agent time**: 1h30m0s",
+		"**Cost**: unavailable",
+		"**Changes**: 4 files changed",
+		"## Completed",
+		"- 1.1: Add schema (abc123)",
+		"## Blocked",
+		"- 2.1: Flaky infra",
+	} {
+		if !strings.Contains(digest, want) {
+			t.Errorf("digest missing %q\n%s", want, digest)
+		}
+	}
+	if strings.Contains(digest, "Exit error") {
+		t.Error("digest should not mention exit error for a clean run")
+	}
+}
+
+func TestWriteRunDigest(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "reports", "digest.md")
+
+	if err := WriteRunDigest(path, newTestRunReport()); err != nil {
+		t.Fatalf("WriteRunDigest returned error: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("digest not written: %v", err)
+	}
+	if !strings.Contains(string(content), "Auto Run Digest") {
+		t.Error("digest file has unexpected content")
+	}
+}
+
+func TestSendRunDigestEmail(t *testing.T) {
+	orig := smtpSendMail
+	t.Cleanup(func() { smtpSendMail = orig })
+
+	var gotAddr string
+	var gotTo []string
+	var gotMsg string
+	smtpSendMail = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotTo, gotMsg = addr, to, string(msg)
+		return nil
+	}
+
+	cfg := &EmailReportConfig{
+		SMTPHost: "smtp.example.com",
+		From:     "bot@example.com",
+		To:       []string{"dev@example.com"},
+	}
+	if err := SendRunDigestEmail(cfg, newTestRunReport()); err != nil {
+		t.Fatalf("SendRunDigestEmail returned error: %v", err)
+	}
+
+	if gotAddr != "smtp.example.com:587" {
+		t.Errorf("addr = %s, want default port 587", gotAddr)
+	}
+	if len(gotTo) != 1 || gotTo[0] != "dev@example.com" {
+		t.Errorf("to = %v", gotTo)
+	}
+	if !strings.Contains(gotMsg, "Subject: [samuel] demo: 1 completed, 3 remaining") {
+		t.Errorf("unexpected subject in message:\n%s", gotMsg)
+	}
+}
+
+func TestSendRunDigestEmail_Subject(t *testing.T) {
+	orig := smtpSendMail
+	t.Cleanup(func() { smtpSendMail = orig })
+	var gotMsg string
+	smtpSendMail = func(_ string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+		gotMsg = string(msg)
+		return nil
+	}
+	cfg := &EmailReportConfig{SMTPHost: "h", From: "f@x", To: []string{"t@x"}}
+
+	tests := []struct {
+		name    string
+		project string
+		want    string
+	}{
+		{name: "header_injection", project: "demo\r\nBcc: victim@example.com", want: "Subject: [samuel] demo  Bcc: victim@example.com: 1 completed, 3 remaining\r\n"},
+		{name: "non_ascii", project: "café", want: "Subject: =?UTF-8?q?[samuel]_caf=C3=A9:_1_completed,_3_remaining?=\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRunReport()
+			r.Project = tt.project
+			if err := SendRunDigestEmail(cfg, r); err != nil {
+				t.Fatal(err)
+			}
+			headers, _, _ := strings.Cut(gotMsg, "\r\n\r\n")
+			if !strings.Contains(headers+"\r\n", tt.want) {
+				t.Errorf("headers = %q, want subject line %q", headers, tt.want)
+			}
+			if strings.Contains(headers, "\r\nBcc:") {
+				t.Errorf("project name injected a header: %q", headers)
+			}
+		})
+	}
+}
+
+func TestSendRunDigestEmail_Errors(t *testing.T) {
+	orig := smtpSendMail
+	t.Cleanup(func() { smtpSendMail = orig })
+	smtpSendMail = func(string, smtp.Auth, string, []string, []byte) error {
+		return errors.New("connection refused")
+	}
+
+	if err := SendRunDigestEmail(&EmailReportConfig{}, newTestRunReport()); err == nil {
+		t.Error("expected validation error for empty config")
+	}
+
+	cfg := &EmailReportConfig{SMTPHost: "h", From: "f@x", To: []string{"t@x"}}
+	err := SendRunDigestEmail(cfg, newTestRunReport())
+	if err == nil || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("expected send error, got %v", err)
+	}
+}
+
+func TestValidateEmailReport(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *EmailReportConfig
+		wantErr int
+	}{
+		{"nil config", nil, 0},
+		{"complete", &EmailReportConfig{SMTPHost: "h", From: "f", To: []string{"t"}}, 0},
+		{"missing everything", &EmailReportConfig{}, 3},
+		{"missing recipients", &EmailReportConfig{SMTPHost: "h", From: "f"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validateEmailReport(tt.cfg); len(got) != tt.wantErr {
+				t.Errorf("validateEmailReport() = %v, want %d errors", got, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPostRunDigestWebhook(t *testing.T) {
+	var got runDigestPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if strings.HasSuffix(r.URL.Path, "/fail") {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	if err := PostRunDigestWebhook(server.URL+"/hook", newTestRunReport()); err != nil {
+		t.Fatalf("PostRunDigestWebhook() error = %v", err)
+	}
+	if got.Project != "demo" || got.Remaining != 3 || !reflect.DeepEqual(got.Completed, []string{"1.1"}) ||
+		!reflect.DeepEqual(got.Blocked, []string{"2.1"}) || !strings.Contains(got.Text, "Auto Run Digest") {
+		t.Errorf("payload = %+v", got)
+	}
+
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "server error", url: server.URL + "/fail"},
+		{name: "not http", url: "ftp://example.com/hook"},
+		{name: "relative", url: "/hook"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := PostRunDigestWebhook(tt.url, newTestRunReport()); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
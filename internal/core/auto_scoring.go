@@ -0,0 +1,152 @@
+package core
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Task scoring strategy constants
+const (
+	ScoringStrategyPriority = "priority"
+	ScoringStrategyWSJF     = "wsjf"
+)
+
+// WSJF scoring weights. Cost of delay is the sum of business value,
+// unlock bonus, and age bonus; the score divides it by job size.
+const (
+	wsjfUnlockWeight    = 2.0
+	wsjfAgeWeightPerDay = 0.5
+	wsjfMaxAgeBonus     = 5.0
+)
+
+// priorityRankCount is the number of distinct priority ranks.
+const priorityRankCount = 4
+
+// TaskScore holds the scoring breakdown for a single candidate task.
+type TaskScore struct {
+	Task    *AutoTask
+	Score   float64
+	Value   float64 // business value derived from priority
+	Unlocks int     // pending tasks that directly depend on this task
+	AgeDays float64 // days since the task was created
+	JobSize float64 // relative effort derived from complexity
+}
+
+// GetSupportedScoringStrategies returns the list of supported scoring strategies.
+func GetSupportedScoringStrategies() []string {
+	return []string{ScoringStrategyPriority, ScoringStrategyWSJF}
+}
+
+// IsValidScoringStrategy checks if the given strategy is supported.
+// An empty strategy is valid and means the default priority ordering.
+func IsValidScoringStrategy(strategy string) bool {
+	if strategy == "" {
+		return true
+	}
+	return slices.Contains(GetSupportedScoringStrategies(), strings.ToLower(strategy))
+}
+
+// ScoreTasks scores every available pending task with the given strategy
+// and returns them ordered best-first. Ties fall back to priority, then ID.
+func (p *AutoPRD) ScoreTasks(strategy string, now time.Time) []TaskScore {
+	available := p.getAvailableTasks()
+	unlocks := p.countUnlocks()
+
+	scores := make([]TaskScore, 0, len(available))
+	for _, task := range available {
+		scores = append(scores, scoreTask(task, strategy, unlocks[task.ID], now))
+	}
+
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		pi := priorityRank(scores[i].Task.Priority)
+		pj := priorityRank(scores[j].Task.Priority)
+		if pi != pj {
+			return pi < pj
+		}
+		return scores[i].Task.ID < scores[j].Task.ID
+	})
+	return scores
+}
+
+// scoreTask computes the score breakdown for one task.
+func scoreTask(task *AutoTask, strategy string, unlocks int, now time.Time) TaskScore {
+	score := TaskScore{
+		Task:    task,
+		Value:   priorityValue(task.Priority),
+		Unlocks: unlocks,
+		AgeDays: taskAgeDays(task, now),
+		JobSize: complexitySize(task.Complexity),
+	}
+
+	if strings.ToLower(strategy) != ScoringStrategyWSJF {
+		// Priority ordering: higher priority scores higher, ID breaks ties
+		score.Score = float64(priorityRankCount - priorityRank(task.Priority))
+		return score
+	}
+
+	ageBonus := score.AgeDays * wsjfAgeWeightPerDay
+	if ageBonus > wsjfMaxAgeBonus {
+		ageBonus = wsjfMaxAgeBonus
+	}
+	costOfDelay := score.Value + float64(unlocks)*wsjfUnlockWeight + ageBonus
+	score.Score = costOfDelay / score.JobSize
+	return score
+}
+
+// countUnlocks returns, for each task ID, how many pending tasks list it
+// as a direct dependency.
+func (p *AutoPRD) countUnlocks() map[string]int {
+	unlocks := make(map[string]int)
+	for _, t := range p.Tasks {
+		if t.Status != TaskStatusPending {
+			continue
+		}
+		for _, dep := range t.DependsOn {
+			unlocks[dep]++
+		}
+	}
+	return unlocks
+}
+
+// priorityValue maps a priority to its WSJF business value.
+func priorityValue(priority string) float64 {
+	switch priority {
+	case TaskPriorityCritical:
+		return 10
+	case TaskPriorityHigh:
+		return 8
+	case TaskPriorityLow:
+		return 2
+	default:
+		return 5
+	}
+}
+
+// complexitySize maps a complexity estimate to its WSJF job size.
+func complexitySize(complexity string) float64 {
+	switch complexity {
+	case TaskComplexitySimple:
+		return 1
+	case TaskComplexityComplex:
+		return 8
+	default:
+		return 3
+	}
+}
+
+// taskAgeDays returns the age of a task in days, or 0 if unknown.
+func taskAgeDays(task *AutoTask, now time.Time) float64 {
+	if task.CreatedAt == "" {
+		return 0
+	}
+	created, err := time.Parse(time.RFC3339, task.CreatedAt)
+	if err != nil || created.After(now) {
+		return 0
+	}
+	return now.Sub(created).Hours() / 24
+}
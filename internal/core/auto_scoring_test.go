@@ -0,0 +1,160 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsValidScoringStrategy(t *testing.T) {
+	tests := []struct {
+		strategy string
+		want     bool
+	}{
+		{"", true},
+		{ScoringStrategyPriority, true},
+		{ScoringStrategyWSJF, true},
+		{"WSJF", true},
+		{"random", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.strategy, func(t *testing.T) {
+			if got := IsValidScoringStrategy(tt.strategy); got != tt.want {
+				t.Errorf("IsValidScoringStrategy(%q) = %v, want %v", tt.strategy, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScoreTasks_PriorityMatchesLegacyOrdering(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "2", Title: "Medium B", Status: TaskStatusPending, Priority: TaskPriorityMedium},
+		{ID: "1", Title: "Medium A", Status: TaskStatusPending, Priority: TaskPriorityMedium},
+		{ID: "3", Title: "High", Status: TaskStatusPending, Priority: TaskPriorityHigh},
+		{ID: "4", Title: "Low", Status: TaskStatusPending, Priority: TaskPriorityLow},
+	}
+
+	scores := prd.ScoreTasks(ScoringStrategyPriority, time.Now())
+	want := []string{"3", "1", "2", "4"}
+	if len(scores) != len(want) {
+		t.Fatalf("got %d scores, want %d", len(scores), len(want))
+	}
+	for i, id := range want {
+		if scores[i].Task.ID != id {
+			t.Errorf("scores[%d] = %s, want %s", i, scores[i].Task.ID, id)
+		}
+	}
+}
+
+func TestScoreTasks_WSJFPrefersSmallJobs(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "Big", Status: TaskStatusPending, Priority: TaskPriorityHigh, Complexity: TaskComplexityComplex},
+		{ID: "2", Title: "Small", Status: TaskStatusPending, Priority: TaskPriorityMedium, Complexity: TaskComplexitySimple},
+	}
+
+	scores := prd.ScoreTasks(ScoringStrategyWSJF, time.Now())
+	if scores[0].Task.ID != "2" {
+		t.Errorf("expected small job first, got %s", scores[0].Task.ID)
+	}
+	if scores[1].JobSize != 8 {
+		t.Errorf("complex job size = %v, want 8", scores[1].JobSize)
+	}
+}
+
+func TestScoreTasks_WSJFCountsUnlocks(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "Leaf", Status: TaskStatusPending},
+		{ID: "2", Title: "Enabler", Status: TaskStatusPending},
+		{ID: "3", Title: "Needs 2", Status: TaskStatusPending, DependsOn: []string{"2"}},
+		{ID: "4", Title: "Also needs 2", Status: TaskStatusPending, DependsOn: []string{"2"}},
+	}
+
+	scores := prd.ScoreTasks(ScoringStrategyWSJF, time.Now())
+	if scores[0].Task.ID != "2" {
+		t.Fatalf("expected enabler task first, got %s", scores[0].Task.ID)
+	}
+	if scores[0].Unlocks != 2 {
+		t.Errorf("unlocks = %d, want 2", scores[0].Unlocks)
+	}
+}
+
+func TestScoreTasks_WSJFAgeBonusCapped(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	prd := NewAutoPRD("test", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "Fresh", Status: TaskStatusPending, CreatedAt: now.Format(time.RFC3339)},
+		{ID: "2", Title: "Ancient", Status: TaskStatusPending, CreatedAt: "2020-01-01T00:00:00Z"},
+	}
+
+	scores := prd.ScoreTasks(ScoringStrategyWSJF, now)
+	if scores[0].Task.ID != "2" {
+		t.Fatalf("expected older task first, got %s", scores[0].Task.ID)
+	}
+	wantMax := (priorityValue(TaskPriorityMedium) + wsjfMaxAgeBonus) / complexitySize("")
+	if scores[0].Score != wantMax {
+		t.Errorf("score = %v, want capped %v", scores[0].Score, wantMax)
+	}
+}
+
+func TestTaskAgeDays(t *testing.T) {
+	now := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		createdAt string
+		want      float64
+	}{
+		{"empty", "", 0},
+		{"invalid", "yesterday", 0},
+		{"future", "2026-02-01T00:00:00Z", 0},
+		{"two days", "2026-01-08T00:00:00Z", 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := taskAgeDays(&AutoTask{CreatedAt: tt.createdAt}, now)
+			if got != tt.want {
+				t.Errorf("taskAgeDays(%q) = %v, want %v", tt.createdAt, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetNextTask_UsesConfiguredStrategy(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "Big", Status: TaskStatusPending, Priority: TaskPriorityHigh, Complexity: TaskComplexityComplex},
+		{ID: "2", Title: "Small", Status: TaskStatusPending, Priority: TaskPriorityMedium, Complexity: TaskComplexitySimple},
+	}
+
+	if next := prd.GetNextTask(); next.ID != "1" {
+		t.Errorf("default strategy picked %s, want 1", next.ID)
+	}
+
+	prd.Config.ScoringStrategy = ScoringStrategyWSJF
+	if next := prd.GetNextTask(); next.ID != "2" {
+		t.Errorf("wsjf strategy picked %s, want 2", next.ID)
+	}
+}
+
+func TestAddTask_SetsCreatedAt(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	if err := prd.AddTask(AutoTask{ID: "1", Title: "New"}); err != nil {
+		t.Fatalf("AddTask: %v", err)
+	}
+	if prd.Tasks[0].CreatedAt == "" {
+		t.Error("expected CreatedAt to be set")
+	}
+}
+
+func TestValidateAutoPRD_InvalidScoringStrategy(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	prd.Config.ScoringStrategy = "bogus"
+
+	errs := ValidateAutoPRD(prd)
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %v", errs)
+	}
+}
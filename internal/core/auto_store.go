@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+)
+
+// Storage backends for auto-loop state.
+const (
+	StorageFile   = "file"
+	StorageSQLite = "sqlite"
+)
+
+// AutoStateDBFile is the SQLite database used by the sqlite backend.
+const AutoStateDBFile = "state.db"
+
+// AutoStore persists auto-loop state: the PRD, the progress log, and the
+// iteration event log.
+//
+// Whatever the backend, prd.json and progress.md stay on disk because the
+// agent reads and edits them directly; a store may keep its own record
+// alongside them.
+type AutoStore interface {
+	LoadPRD() (*AutoPRD, error)
+	SavePRD(prd *AutoPRD) error
+	AppendProgress(entry ProgressEntry) error
+	ReadProgressTail(lines int) ([]string, error)
+	AppendEvent(event IterationEvent) error
+	LoadEvents() ([]IterationEvent, error)
+	Close() error
+}
+
+// openSQLiteStore is set by the sqlite build of samuel; plain builds do
+// not link a SQLite driver.
+var openSQLiteStore func(projectDir string) (AutoStore, error)
+
+// GetSupportedStorageBackends returns the list of supported storage backends.
+func GetSupportedStorageBackends() []string {
+	return []string{StorageFile, StorageSQLite}
+}
+
+// OpenAutoStore opens the auto-loop state of projectDir with the given
+// backend. An empty backend means the file backend.
+func OpenAutoStore(projectDir, backend string) (AutoStore, error) {
+	if err := ValidateStorageBackend(backend); err != nil {
+		return nil, err
+	}
+	if backend != StorageSQLite {
+		return NewFileStore(projectDir), nil
+	}
+	if openSQLiteStore == nil {
+		return nil, fmt.Errorf("sqlite storage is not available in this build (rebuild with -tags sqlite)")
+	}
+	return openSQLiteStore(projectDir)
+}
+
+// ValidateStorageBackend checks a backend name from samuel.yaml.
+func ValidateStorageBackend(backend string) error {
+	if backend == "" || slices.Contains(GetSupportedStorageBackends(), backend) {
+		return nil
+	}
+	return fmt.Errorf("unsupported storage backend: %s (supported: %v)",
+		backend, GetSupportedStorageBackends())
+}
+
+// OpenProjectAutoStore opens the backend configured as auto.storage in the
+// project's samuel.yaml, falling back to the file backend when there is no
+// config.
+func OpenProjectAutoStore(projectDir string) (AutoStore, error) {
+	backend := ""
+	if cfg, err := LoadConfigFrom(projectDir); err == nil && cfg.Auto != nil {
+		backend = cfg.Auto.Storage
+	}
+	return OpenAutoStore(projectDir, backend)
+}
+
+// store returns the state store of the loop.
+func (cfg LoopConfig) store() AutoStore {
+	if cfg.Store != nil {
+		return cfg.Store
+	}
+	return &FileStore{projectDir: cfg.ProjectDir, prdPath: cfg.PRDPath}
+}
+
+// FileStore keeps auto-loop state in plain files under .claude/auto:
+// prd.json, progress.md and events.jsonl.
+type FileStore struct {
+	projectDir string
+	prdPath    string
+}
+
+// NewFileStore returns the file backend for projectDir.
+func NewFileStore(projectDir string) *FileStore {
+	return &FileStore{projectDir: projectDir, prdPath: GetAutoPRDPath(projectDir)}
+}
+
+func (s *FileStore) progressPath() string {
+	return filepath.Join(s.projectDir, AutoDir, AutoProgressFile)
+}
+
+// LoadPRD reads prd.json.
+func (s *FileStore) LoadPRD() (*AutoPRD, error) {
+	return LoadAutoPRD(s.prdPath)
+}
+
+// SavePRD writes prd.json.
+func (s *FileStore) SavePRD(prd *AutoPRD) error {
+	return prd.Save(s.prdPath)
+}
+
+// AppendProgress appends an entry to progress.md.
+func (s *FileStore) AppendProgress(entry ProgressEntry) error {
+	return AppendProgress(s.progressPath(), entry)
+}
+
+// ReadProgressTail returns the last lines of progress.md.
+func (s *FileStore) ReadProgressTail(lines int) ([]string, error) {
+	return ReadProgressTail(s.progressPath(), lines)
+}
+
+// AppendEvent appends an event to events.jsonl.
+func (s *FileStore) AppendEvent(event IterationEvent) error {
+	return AppendIterationEvent(s.projectDir, event)
+}
+
+// LoadEvents reads events.jsonl.
+func (s *FileStore) LoadEvents() ([]IterationEvent, error) {
+	return LoadIterationEvents(s.projectDir)
+}
+
+// Close is a no-op for the file backend.
+func (s *FileStore) Close() error {
+	return nil
+}
@@ -0,0 +1,233 @@
+//go:build sqlite
+
+package core
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func init() {
+	openSQLiteStore = func(projectDir string) (AutoStore, error) {
+		return OpenSQLiteStore(projectDir)
+	}
+}
+
+// sqliteSchema creates the state tables. Every saved or agent-edited PRD
+// is kept as a new row of prd_versions, so the latest row is the current
+// plan and older rows are its history.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS prd_versions (
+	id       INTEGER PRIMARY KEY AUTOINCREMENT,
+	saved_at TEXT NOT NULL,
+	document TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS progress (
+	id         INTEGER PRIMARY KEY AUTOINCREMENT,
+	created_at TEXT NOT NULL,
+	iteration  INTEGER NOT NULL,
+	task_id    TEXT NOT NULL,
+	type       TEXT NOT NULL,
+	line       TEXT NOT NULL
+);
+CREATE TABLE IF NOT EXISTS events (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp TEXT NOT NULL,
+	iteration INTEGER NOT NULL,
+	type      TEXT NOT NULL,
+	error     TEXT NOT NULL,
+	files     INTEGER NOT NULL,
+	document  TEXT NOT NULL
+);`
+
+// SQLiteStore keeps auto-loop state in .claude/auto/state.db. WAL mode
+// and a busy timeout let several samuel processes share the database.
+// prd.json and progress.md are still written as working copies for the
+// agent, and agent edits to prd.json are recorded on the next load.
+type SQLiteStore struct {
+	projectDir string
+	db         *sql.DB
+}
+
+// OpenSQLiteStore opens (creating if needed) the state database of
+// projectDir.
+func OpenSQLiteStore(projectDir string) (*SQLiteStore, error) {
+	dir := GetAutoDir(projectDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create auto directory: %w", err)
+	}
+	dsn := filepath.Join(dir, AutoStateDBFile) + "?_busy_timeout=5000&_journal_mode=WAL"
+	db, err := sql.Open("sqlite3", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state database: %w", err)
+	}
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize state database: %w", err)
+	}
+	return &SQLiteStore{projectDir: projectDir, db: db}, nil
+}
+
+// LoadPRD returns the current plan. When prd.json differs from the latest
+// stored version, the agent edited it and the file is recorded as a new
+// version; when prd.json is missing, it is restored from the database.
+func (s *SQLiteStore) LoadPRD() (*AutoPRD, error) {
+	path := GetAutoPRDPath(s.projectDir)
+	latest, err := s.latestPRD()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) && latest != nil {
+		data = latest
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return nil, fmt.Errorf("failed to restore prd.json: %w", err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read prd.json: %w", err)
+	}
+
+	var prd AutoPRD
+	if err := json.Unmarshal(data, &prd); err != nil {
+		return nil, fmt.Errorf("failed to parse prd.json: %w", err)
+	}
+	if !bytes.Equal(data, latest) {
+		if err := s.insertPRD(data); err != nil {
+			return nil, err
+		}
+	}
+	return &prd, nil
+}
+
+// SavePRD writes prd.json and records it as the latest version.
+func (s *SQLiteStore) SavePRD(prd *AutoPRD) error {
+	path := GetAutoPRDPath(s.projectDir)
+	if err := prd.Save(path); err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read prd.json: %w", err)
+	}
+	latest, err := s.latestPRD()
+	if err != nil || bytes.Equal(data, latest) {
+		return err
+	}
+	return s.insertPRD(data)
+}
+
+func (s *SQLiteStore) latestPRD() ([]byte, error) {
+	var doc string
+	err := s.db.QueryRow(`SELECT document FROM prd_versions ORDER BY id DESC LIMIT 1`).Scan(&doc)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prd: %w", err)
+	}
+	return []byte(doc), nil
+}
+
+func (s *SQLiteStore) insertPRD(data []byte) error {
+	_, err := s.db.Exec(`INSERT INTO prd_versions (saved_at, document) VALUES (?, ?)`,
+		time.Now().UTC().Format(time.RFC3339), string(data))
+	if err != nil {
+		return fmt.Errorf("failed to store prd: %w", err)
+	}
+	return nil
+}
+
+// PRDVersionCount returns how many versions of the PRD have been stored.
+func (s *SQLiteStore) PRDVersionCount() (int, error) {
+	var n int
+	err := s.db.QueryRow(`SELECT COUNT(*) FROM prd_versions`).Scan(&n)
+	return n, err
+}
+
+// AppendProgress records an entry and appends it to progress.md.
+func (s *SQLiteStore) AppendProgress(entry ProgressEntry) error {
+	line := FormatProgressEntry(entry)
+	_, err := s.db.Exec(
+		`INSERT INTO progress (created_at, iteration, task_id, type, line) VALUES (?, ?, ?, ?, ?)`,
+		time.Now().UTC().Format(time.RFC3339), entry.Iteration, entry.TaskID, entry.Type, line)
+	if err != nil {
+		return fmt.Errorf("failed to store progress entry: %w", err)
+	}
+	return appendLine(filepath.Join(GetAutoDir(s.projectDir), AutoProgressFile), line)
+}
+
+// ReadProgressTail returns the last stored progress entries, oldest first.
+// A non-positive count returns every entry.
+func (s *SQLiteStore) ReadProgressTail(lines int) ([]string, error) {
+	query := `SELECT line FROM (SELECT id, line FROM progress ORDER BY id DESC LIMIT ?) ORDER BY id`
+	if lines <= 0 {
+		lines = -1
+	}
+	rows, err := s.db.Query(query, lines)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query progress: %w", err)
+	}
+	defer rows.Close()
+
+	var out []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		out = append(out, strings.TrimRight(line, "\n"))
+	}
+	return out, rows.Err()
+}
+
+// AppendEvent records an iteration event.
+func (s *SQLiteStore) AppendEvent(event IterationEvent) error {
+	doc, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(
+		`INSERT INTO events (timestamp, iteration, type, error, files, document) VALUES (?, ?, ?, ?, ?, ?)`,
+		event.Timestamp, event.Iteration, event.Type, event.Error, event.Changes.Total(), string(doc))
+	if err != nil {
+		return fmt.Errorf("failed to store event: %w", err)
+	}
+	return nil
+}
+
+// LoadEvents returns every stored event, oldest first.
+func (s *SQLiteStore) LoadEvents() ([]IterationEvent, error) {
+	rows, err := s.db.Query(`SELECT document FROM events ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []IterationEvent
+	for rows.Next() {
+		var doc string
+		if err := rows.Scan(&doc); err != nil {
+			return nil, err
+		}
+		var event IterationEvent
+		if json.Unmarshal([]byte(doc), &event) == nil {
+			events = append(events, event)
+		}
+	}
+	return events, rows.Err()
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,59 @@
+//go:build sqlite
+
+package core
+
+import (
+	"os"
+	"testing"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenAutoStore(dir, StorageSQLite)
+	if err != nil {
+		t.Fatalf("OpenAutoStore() error: %v", err)
+	}
+	testAutoStore(t, dir, store)
+}
+
+func TestSQLiteStore_TracksAgentEdits(t *testing.T) {
+	dir := t.TempDir()
+	store, err := OpenSQLiteStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+
+	prd := NewAutoPRD("demo", "history")
+	if err := store.SavePRD(prd); err != nil {
+		t.Fatal(err)
+	}
+
+	// The agent completes a task by editing prd.json directly.
+	edited := NewAutoPRD("demo", "history")
+	edited.Tasks = []AutoTask{{ID: "1", Title: "Added by agent", Status: TaskStatusCompleted}}
+	if err := edited.Save(GetAutoPRDPath(dir)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.LoadPRD(); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := store.PRDVersionCount(); n != 2 {
+		t.Errorf("PRDVersionCount() = %d, want 2", n)
+	}
+
+	// A deleted working copy is restored from the database.
+	if err := os.Remove(GetAutoPRDPath(dir)); err != nil {
+		t.Fatal(err)
+	}
+	restored, err := store.LoadPRD()
+	if err != nil {
+		t.Fatalf("LoadPRD() error: %v", err)
+	}
+	if len(restored.Tasks) != 1 {
+		t.Errorf("restored tasks = %+v", restored.Tasks)
+	}
+	if n, _ := store.PRDVersionCount(); n != 2 {
+		t.Errorf("restoring should not add a version, got %d", n)
+	}
+}
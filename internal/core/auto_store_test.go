@@ -0,0 +1,107 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestOpenAutoStore(t *testing.T) {
+	tests := []struct {
+		name    string
+		backend string
+		wantErr string
+	}{
+		{name: "default is file", backend: ""},
+		{name: "file", backend: StorageFile},
+		{name: "unsupported", backend: "postgres", wantErr: "unsupported storage backend"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, err := OpenAutoStore(t.TempDir(), tt.backend)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("OpenAutoStore() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("OpenAutoStore() error: %v", err)
+			}
+			if _, ok := store.(*FileStore); !ok {
+				t.Errorf("OpenAutoStore() = %T, want *FileStore", store)
+			}
+		})
+	}
+}
+
+func TestOpenProjectAutoStore_UsesConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg := NewConfig("1.0.0")
+	cfg.Auto = &AutoYAML{Storage: "postgres"}
+	if err := cfg.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenProjectAutoStore(dir); err == nil {
+		t.Error("expected the configured backend to be validated")
+	}
+}
+
+// testAutoStore exercises the AutoStore contract against any backend.
+func testAutoStore(t *testing.T, dir string, store AutoStore) {
+	t.Helper()
+	if _, err := store.LoadPRD(); err == nil {
+		t.Error("LoadPRD() without a plan should fail")
+	}
+
+	prd := NewAutoPRD("demo", "store test")
+	prd.Tasks = []AutoTask{{ID: "1", Title: "First task", Status: TaskStatusPending}}
+	if err := store.SavePRD(prd); err != nil {
+		t.Fatalf("SavePRD() error: %v", err)
+	}
+	loaded, err := store.LoadPRD()
+	if err != nil {
+		t.Fatalf("LoadPRD() error: %v", err)
+	}
+	if len(loaded.Tasks) != 1 || loaded.Tasks[0].ID != "1" {
+		t.Errorf("LoadPRD() tasks = %+v", loaded.Tasks)
+	}
+	if _, err := LoadAutoPRD(filepath.Join(dir, AutoDir, AutoPRDFile)); err != nil {
+		t.Errorf("prd.json working copy missing: %v", err)
+	}
+
+	for i := 1; i <= 3; i++ {
+		entry := ProgressEntry{Iteration: i, TaskID: "1", Type: ProgressStarted, Message: "go"}
+		if err := store.AppendProgress(entry); err != nil {
+			t.Fatalf("AppendProgress() error: %v", err)
+		}
+	}
+	tail, err := store.ReadProgressTail(2)
+	if err != nil {
+		t.Fatalf("ReadProgressTail() error: %v", err)
+	}
+	if len(tail) != 2 || !strings.Contains(tail[1], "[iteration:3]") {
+		t.Errorf("ReadProgressTail(2) = %v", tail)
+	}
+
+	event := IterationEvent{Timestamp: "2026-01-01T00:00:00Z", Iteration: 1, Type: IterationTypeImplementation}
+	event.Changes.Added = 2
+	if err := store.AppendEvent(event); err != nil {
+		t.Fatalf("AppendEvent() error: %v", err)
+	}
+	events, err := store.LoadEvents()
+	if err != nil {
+		t.Fatalf("LoadEvents() error: %v", err)
+	}
+	if len(events) != 1 || events[0].Changes.Added != 2 {
+		t.Errorf("LoadEvents() = %+v", events)
+	}
+	if err := store.Close(); err != nil {
+		t.Errorf("Close() error: %v", err)
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	dir := t.TempDir()
+	testAutoStore(t, dir, NewFileStore(dir))
+}
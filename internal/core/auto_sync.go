@@ -0,0 +1,302 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"path/filepath"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Sync targets for sharing auto-loop state between machines.
+const (
+	SyncTargetGit  = "git"
+	SyncTargetHTTP = "http"
+)
+
+// Sync defaults and file names.
+const (
+	DefaultSyncBranch = "samuel/auto-state"
+	DefaultSyncRemote = "origin"
+	AutoSyncFile      = "sync.json"
+	syncBundleFile    = "auto-state.json"
+)
+
+// syncedStateFiles are the files in the auto directory that make up the
+// shared state.
+var syncedStateFiles = []string{AutoPRDFile, AutoProgressFile, AutoEventLogFile}
+
+// ErrSyncConflict is returned when the shared state changed since this
+// machine last synced it, i.e. another writer is active.
+var ErrSyncConflict = errors.New("shared auto state was updated by another writer")
+
+// ErrSyncUnpushed is returned by a pull that would discard local changes
+// the shared copy does not have yet.
+var ErrSyncUnpushed = errors.New("local changes not pushed")
+
+// SyncConfig selects where auto-loop state is shared. Git syncs to a
+// dedicated branch of Remote; HTTP uses GET/PUT on URL with ETag
+// preconditions (an S3 presigned URL or any store honoring If-Match).
+type SyncConfig struct {
+	Target string `json:"target"`
+	Remote string `json:"remote,omitempty"`
+	Branch string `json:"branch,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// SyncBundle is the shared state document.
+type SyncBundle struct {
+	Revision  int               `json:"revision"`
+	Writer    string            `json:"writer"`
+	UpdatedAt string            `json:"updated_at"`
+	Files     map[string]string `json:"files"`
+}
+
+// SyncRecord remembers the last sync from this machine in sync.json.
+type SyncRecord struct {
+	Revision      int    `json:"revision"`
+	RemoteVersion string `json:"remote_version"`
+	LocalHash     string `json:"local_hash"`
+	SyncedAt      string `json:"synced_at"`
+}
+
+// SyncStatus compares the local state with the shared copy.
+type SyncStatus struct {
+	Remote       *SyncBundle
+	Record       *SyncRecord
+	LocalChanged bool // local files changed since the last sync
+	RemoteMoved  bool // shared copy changed since the last sync
+}
+
+// GetSupportedSyncTargets returns the list of supported sync targets.
+func GetSupportedSyncTargets() []string {
+	return []string{SyncTargetGit, SyncTargetHTTP}
+}
+
+// Validate checks the target and its required settings.
+func (c *SyncConfig) Validate() error {
+	if !slices.Contains(GetSupportedSyncTargets(), c.Target) {
+		return fmt.Errorf("unsupported sync target: %s (supported: %v)", c.Target, GetSupportedSyncTargets())
+	}
+	if c.Target == SyncTargetHTTP && c.URL == "" {
+		return fmt.Errorf("sync target http requires a url")
+	}
+	return nil
+}
+
+func (c *SyncConfig) remote(projectDir string) (syncRemote, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.Target == SyncTargetHTTP {
+		return &httpSyncRemote{url: c.URL}, nil
+	}
+	remote, branch := c.Remote, c.Branch
+	if remote == "" {
+		remote = DefaultSyncRemote
+	}
+	if branch == "" {
+		branch = DefaultSyncBranch
+	}
+	return &gitSyncRemote{dir: projectDir, remote: remote, branch: branch}, nil
+}
+
+// SyncWriterID identifies this machine and user as a writer.
+func SyncWriterID() string {
+	host, _ := os.Hostname()
+	name := "unknown"
+	if u, err := user.Current(); err == nil {
+		name = u.Username
+	}
+	return name + "@" + host
+}
+
+// PushAutoState uploads the local state. Unless force is set, it fails
+// with ErrSyncConflict when another writer updated the shared copy since
+// this machine last synced.
+func PushAutoState(projectDir string, cfg *SyncConfig, force bool) (*SyncBundle, error) {
+	remote, err := cfg.remote(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	current, version, err := remote.Fetch()
+	if err != nil {
+		return nil, err
+	}
+	record := loadSyncRecord(projectDir)
+	if !force && current != nil && version != record.RemoteVersion {
+		return nil, fmt.Errorf("%w (revision %d by %s); pull it or push with --force",
+			ErrSyncConflict, current.Revision, current.Writer)
+	}
+
+	files, err := readStateFiles(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := files[AutoPRDFile]; !ok {
+		return nil, fmt.Errorf("no auto loop found. Run 'samuel auto init' first")
+	}
+	bundle := &SyncBundle{
+		Revision:  record.Revision + 1,
+		Writer:    SyncWriterID(),
+		UpdatedAt: time.Now().UTC().Format(time.RFC3339),
+		Files:     files,
+	}
+	if current != nil && current.Revision >= bundle.Revision {
+		bundle.Revision = current.Revision + 1
+	}
+	newVersion, err := remote.Store(bundle, version)
+	if err != nil {
+		return nil, err
+	}
+	return bundle, saveSyncRecord(projectDir, bundle.Revision, newVersion, files)
+}
+
+// PullAutoState replaces the local state with the shared copy. Unless
+// force is set, it refuses to overwrite local changes made since the last
+// sync: with ErrSyncConflict when the shared copy changed too, and with
+// ErrSyncUnpushed when it did not.
+func PullAutoState(projectDir string, cfg *SyncConfig, force bool) (*SyncBundle, error) {
+	remote, err := cfg.remote(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	bundle, version, err := remote.Fetch()
+	if err != nil || bundle == nil {
+		return nil, err
+	}
+	local, err := readStateFiles(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	record := loadSyncRecord(projectDir)
+	localChanged := record.LocalHash != "" && hashStateFiles(local) != record.LocalHash
+	if !force && localChanged {
+		if version != record.RemoteVersion {
+			return nil, fmt.Errorf("%w and local state changed too; rerun with --force to keep one side",
+				ErrSyncConflict)
+		}
+		return nil, fmt.Errorf("%w; push them or pull with --force to discard them", ErrSyncUnpushed)
+	}
+	if err := writeStateFiles(projectDir, bundle.Files); err != nil {
+		return nil, err
+	}
+	return bundle, saveSyncRecord(projectDir, bundle.Revision, version, bundle.Files)
+}
+
+// GetSyncStatus fetches the shared copy and compares it with the last sync.
+func GetSyncStatus(projectDir string, cfg *SyncConfig) (*SyncStatus, error) {
+	remote, err := cfg.remote(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	bundle, version, err := remote.Fetch()
+	if err != nil {
+		return nil, err
+	}
+	status := &SyncStatus{Remote: bundle}
+	local, err := readStateFiles(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	if record := loadSyncRecord(projectDir); record.SyncedAt != "" {
+		status.Record = &record
+		status.LocalChanged = hashStateFiles(local) != record.LocalHash
+		status.RemoteMoved = version != record.RemoteVersion
+	}
+	return status, nil
+}
+
+// SyncLoopState pushes the loop state when cfg.Sync is set and reports
+// the result through cfg.OnSync. Only a conflict is returned: another
+// writer took over the shared state, so this loop must stop. Other sync
+// failures are reported and retried after the next iteration.
+func SyncLoopState(cfg LoopConfig, iter int) error {
+	if cfg.Sync == nil {
+		return nil
+	}
+	bundle, err := PushAutoState(cfg.ProjectDir, cfg.Sync, false)
+	if cfg.OnSync != nil {
+		cfg.OnSync(iter, bundle, err)
+	}
+	if errors.Is(err, ErrSyncConflict) {
+		return fmt.Errorf("stopping loop: %w", err)
+	}
+	return nil
+}
+
+func readStateFiles(projectDir string) (map[string]string, error) {
+	files := make(map[string]string)
+	for _, name := range syncedStateFiles {
+		data, err := os.ReadFile(filepath.Join(GetAutoDir(projectDir), name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		files[name] = string(data)
+	}
+	return files, nil
+}
+
+// writeStateFiles writes only the known state files, so a crafted bundle
+// cannot write elsewhere. Each file is replaced crash-safely, and prd.json
+// keeps its previous version in prd.json.bak as AutoPRD.Save does.
+func writeStateFiles(projectDir string, files map[string]string) error {
+	dir := GetAutoDir(projectDir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create auto directory: %w", err)
+	}
+	for _, name := range syncedStateFiles {
+		content, ok := files[name]
+		if !ok {
+			continue
+		}
+		if err := writeFileAtomic(filepath.Join(dir, name), []byte(content), 0644, name == AutoPRDFile); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func hashStateFiles(files map[string]string) string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	h := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(h, "%s\x00%d\x00%s", name, len(files[name]), files[name])
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func loadSyncRecord(projectDir string) SyncRecord {
+	var record SyncRecord
+	if data, err := os.ReadFile(filepath.Join(GetAutoDir(projectDir), AutoSyncFile)); err == nil {
+		_ = json.Unmarshal(data, &record)
+	}
+	return record
+}
+
+func saveSyncRecord(projectDir string, revision int, version string, files map[string]string) error {
+	record := SyncRecord{
+		Revision:      revision,
+		RemoteVersion: version,
+		LocalHash:     hashStateFiles(files),
+		SyncedAt:      time.Now().UTC().Format(time.RFC3339),
+	}
+	data, err := json.MarshalIndent(record, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(GetAutoDir(projectDir), AutoSyncFile), append(data, '\n'), 0644)
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func setupSyncClone(t *testing.T, bare, title string) string {
+	t.Helper()
+	dir := newSyncProject(t, title)
+	if err := GitInit(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := GitSetUserIdentity(dir, "Dev", "dev@example.com"); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := runGit(dir, "remote", "add", "origin", bare); err != nil {
+		t.Fatalf("remote add: %s", out)
+	}
+	return dir
+}
+
+func TestGitSync_PushPullTakeOver(t *testing.T) {
+	requireGit(t)
+	isolateGitConfig(t)
+	bare := t.TempDir()
+	if out, err := runGit(bare, "init", "--bare", "--quiet"); err != nil {
+		t.Fatalf("init bare: %s", out)
+	}
+	cfg := &SyncConfig{Target: SyncTargetGit}
+
+	alice := setupSyncClone(t, bare, "shared")
+	if _, err := PushAutoState(alice, cfg, false); err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+	if out, err := runGit(bare, "rev-parse", "--verify", "refs/heads/"+DefaultSyncBranch); err != nil {
+		t.Fatalf("branch not pushed: %s", out)
+	}
+
+	bob := setupSyncClone(t, bare, "other")
+	if err := os.Remove(GetAutoPRDPath(bob)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PullAutoState(bob, cfg, false); err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+	completeSyncedTask(t, bob)
+	bundle, err := PushAutoState(bob, cfg, false)
+	if err != nil {
+		t.Fatalf("push after take-over: %v", err)
+	}
+	if bundle.Revision != 2 {
+		t.Errorf("revision = %d, want 2", bundle.Revision)
+	}
+
+	if _, err := PushAutoState(alice, cfg, false); !errors.Is(err, ErrSyncConflict) {
+		t.Errorf("stale push error = %v, want ErrSyncConflict", err)
+	}
+	status, err := GetSyncStatus(bob, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.LocalChanged || status.RemoteMoved {
+		t.Errorf("status = %+v, want in sync", status)
+	}
+}
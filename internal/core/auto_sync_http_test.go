@@ -0,0 +1,186 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// fakeStateServer is an in-memory object store honoring If-Match and
+// If-None-Match like S3.
+type fakeStateServer struct {
+	mu      sync.Mutex
+	body    []byte
+	version int
+	token   string
+}
+
+func (s *fakeStateServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != "" && r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	etag := fmt.Sprintf(`"v%d"`, s.version)
+	switch r.Method {
+	case http.MethodGet:
+		if s.body == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		_, _ = w.Write(s.body)
+	case http.MethodPut:
+		match, noneMatch := r.Header.Get("If-Match"), r.Header.Get("If-None-Match")
+		if (noneMatch == "*" && s.body != nil) || (match != "" && match != etag) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		s.body, _ = io.ReadAll(r.Body)
+		s.version++
+		w.Header().Set("ETag", fmt.Sprintf(`"v%d"`, s.version))
+	}
+}
+
+func TestHTTPSync_PushPullTakeOver(t *testing.T) {
+	server := &fakeStateServer{token: "secret"}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	t.Setenv(SyncTokenEnv, "secret")
+	cfg := &SyncConfig{Target: SyncTargetHTTP, URL: ts.URL}
+
+	alice := newSyncProject(t, "shared")
+	bundle, err := PushAutoState(alice, cfg, false)
+	if err != nil {
+		t.Fatalf("first push: %v", err)
+	}
+	if bundle.Revision != 1 {
+		t.Errorf("revision = %d, want 1", bundle.Revision)
+	}
+
+	// Bob takes over on a fresh machine with no local state.
+	bob := t.TempDir()
+	pulled, err := PullAutoState(bob, cfg, false)
+	if err != nil {
+		t.Fatalf("pull: %v", err)
+	}
+	if pulled.Revision != 1 {
+		t.Errorf("pulled revision = %d, want 1", pulled.Revision)
+	}
+	prd, err := LoadAutoPRD(GetAutoPRDPath(bob))
+	if err != nil || prd.Project.Name != "shared" {
+		t.Fatalf("pulled prd = %+v, %v", prd, err)
+	}
+
+	completeSyncedTask(t, bob)
+	if bundle, err = PushAutoState(bob, cfg, false); err != nil {
+		t.Fatalf("push after take-over: %v", err)
+	}
+	if bundle.Revision != 2 {
+		t.Errorf("revision = %d, want 2", bundle.Revision)
+	}
+
+	// Alice is now behind and must not overwrite Bob's state.
+	if _, err := PushAutoState(alice, cfg, false); !errors.Is(err, ErrSyncConflict) {
+		t.Errorf("stale push error = %v, want ErrSyncConflict", err)
+	}
+	if _, err := PushAutoState(alice, cfg, true); err != nil {
+		t.Errorf("forced push: %v", err)
+	}
+}
+
+func TestHTTPSync_PullConflict(t *testing.T) {
+	ts := httptest.NewServer(&fakeStateServer{})
+	defer ts.Close()
+	cfg := &SyncConfig{Target: SyncTargetHTTP, URL: ts.URL}
+
+	alice := newSyncProject(t, "shared")
+	if _, err := PushAutoState(alice, cfg, false); err != nil {
+		t.Fatal(err)
+	}
+	bob := t.TempDir()
+	if _, err := PullAutoState(bob, cfg, false); err != nil {
+		t.Fatal(err)
+	}
+	completeSyncedTask(t, bob)
+	if _, err := PushAutoState(bob, cfg, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Alice changed her copy too: pulling would drop her work.
+	if err := os.WriteFile(filepath.Join(GetAutoDir(alice), AutoProgressFile), []byte("local\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	status, err := GetSyncStatus(alice, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.LocalChanged || !status.RemoteMoved {
+		t.Errorf("status = %+v, want local changed and remote moved", status)
+	}
+	if _, err := PullAutoState(alice, cfg, false); !errors.Is(err, ErrSyncConflict) {
+		t.Errorf("pull error = %v, want ErrSyncConflict", err)
+	}
+	if _, err := PullAutoState(alice, cfg, true); err != nil {
+		t.Fatalf("forced pull: %v", err)
+	}
+	prd, _ := LoadAutoPRD(GetAutoPRDPath(alice))
+	if prd.Tasks[0].Status != TaskStatusCompleted {
+		t.Errorf("task status = %s, want completed", prd.Tasks[0].Status)
+	}
+}
+
+func TestHTTPSync_PullKeepsUnpushedChanges(t *testing.T) {
+	ts := httptest.NewServer(&fakeStateServer{})
+	defer ts.Close()
+	cfg := &SyncConfig{Target: SyncTargetHTTP, URL: ts.URL}
+
+	alice := newSyncProject(t, "shared")
+	if _, err := PushAutoState(alice, cfg, false); err != nil {
+		t.Fatal(err)
+	}
+	completeSyncedTask(t, alice)
+
+	// The shared copy did not move, so pulling it would only lose work.
+	if _, err := PullAutoState(alice, cfg, false); !errors.Is(err, ErrSyncUnpushed) {
+		t.Fatalf("pull error = %v, want ErrSyncUnpushed", err)
+	}
+	prd, _ := LoadAutoPRD(GetAutoPRDPath(alice))
+	if prd.Tasks[0].Status != TaskStatusCompleted {
+		t.Errorf("task status = %s, want completed to survive the refused pull", prd.Tasks[0].Status)
+	}
+
+	if _, err := PullAutoState(alice, cfg, true); err != nil {
+		t.Fatalf("forced pull: %v", err)
+	}
+	prd, _ = LoadAutoPRD(GetAutoPRDPath(alice))
+	if prd.Tasks[0].Status != TaskStatusPending {
+		t.Errorf("task status = %s, want the shared pending status", prd.Tasks[0].Status)
+	}
+	backup, err := LoadAutoPRD(GetAutoPRDPath(alice) + BackupSuffix)
+	if err != nil || backup.Tasks[0].Status != TaskStatusCompleted {
+		t.Errorf("prd.json.bak = %+v, %v; want the overwritten local plan", backup, err)
+	}
+}
+
+func TestHTTPSync_NothingShared(t *testing.T) {
+	ts := httptest.NewServer(&fakeStateServer{})
+	defer ts.Close()
+	cfg := &SyncConfig{Target: SyncTargetHTTP, URL: ts.URL}
+
+	dir := t.TempDir()
+	bundle, err := PullAutoState(dir, cfg, false)
+	if err != nil || bundle != nil {
+		t.Errorf("PullAutoState() = %v, %v; want nil, nil", bundle, err)
+	}
+	if _, err := PushAutoState(dir, cfg, false); err == nil {
+		t.Error("expected push without prd.json to fail")
+	}
+}
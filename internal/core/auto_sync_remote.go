@@ -0,0 +1,175 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// SyncTokenEnv holds a bearer token sent with HTTP sync requests.
+const SyncTokenEnv = "SAMUEL_SYNC_TOKEN"
+
+// syncFetchRef is the local ref the shared state branch is fetched into.
+const syncFetchRef = "refs/samuel/auto-state"
+
+// syncRemote reads and conditionally writes the shared bundle. Fetch
+// returns a nil bundle when nothing was shared yet; Store fails with
+// ErrSyncConflict when the remote version is no longer expected.
+type syncRemote interface {
+	Fetch() (*SyncBundle, string, error)
+	Store(bundle *SyncBundle, expected string) (string, error)
+}
+
+// gitSyncRemote keeps the bundle as the only file on a dedicated branch.
+// The branch head is the version; pushes use --force-with-lease so a
+// concurrent writer is detected instead of overwritten.
+type gitSyncRemote struct {
+	dir    string
+	remote string
+	branch string
+}
+
+func (r *gitSyncRemote) Fetch() (*SyncBundle, string, error) {
+	ref := "refs/heads/" + r.branch
+	out, err := runGit(r.dir, "ls-remote", r.remote, ref)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to query %s: %s", r.remote, out)
+	}
+	if out == "" {
+		return nil, "", nil
+	}
+	if out, err := runGit(r.dir, "fetch", "--quiet", r.remote, "+"+ref+":"+syncFetchRef); err != nil {
+		return nil, "", fmt.Errorf("failed to fetch %s: %s", r.branch, out)
+	}
+	sha, err := runGit(r.dir, "rev-parse", syncFetchRef)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to resolve %s: %s", r.branch, sha)
+	}
+	data, err := runGit(r.dir, "show", sha+":"+syncBundleFile)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read shared state from %s: %s", r.branch, data)
+	}
+	var bundle SyncBundle
+	if err := json.Unmarshal([]byte(data), &bundle); err != nil {
+		return nil, "", fmt.Errorf("failed to parse shared state: %w", err)
+	}
+	return &bundle, sha, nil
+}
+
+func (r *gitSyncRemote) Store(bundle *SyncBundle, expected string) (string, error) {
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	blob, err := runGitInput(r.dir, string(data)+"\n", "hash-object", "-w", "--stdin")
+	if err != nil {
+		return "", fmt.Errorf("failed to store state blob: %s", blob)
+	}
+	tree, err := runGitInput(r.dir, "100644 blob "+blob+"\t"+syncBundleFile+"\n", "mktree")
+	if err != nil {
+		return "", fmt.Errorf("failed to store state tree: %s", tree)
+	}
+	args := []string{"commit-tree", tree, "-m",
+		fmt.Sprintf("samuel: auto state revision %d by %s", bundle.Revision, bundle.Writer)}
+	if expected != "" {
+		args = append(args, "-p", expected)
+	}
+	commit, err := runGit(r.dir, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to commit state: %s", commit)
+	}
+
+	ref := "refs/heads/" + r.branch
+	out, err := runGit(r.dir, "push", "--quiet", "--force-with-lease="+ref+":"+expected, r.remote, commit+":"+ref)
+	if err != nil {
+		if strings.Contains(out, "stale info") || strings.Contains(out, "rejected") {
+			return "", fmt.Errorf("%w: %s moved during push", ErrSyncConflict, r.branch)
+		}
+		return "", fmt.Errorf("failed to push %s: %s", r.branch, out)
+	}
+	return commit, nil
+}
+
+// httpSyncRemote stores the bundle with GET and conditional PUT. The
+// ETag is the version: writes send If-Match (or If-None-Match: * for the
+// first write) and a 412 response means another writer got there first.
+type httpSyncRemote struct {
+	url string
+}
+
+var syncHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+func (r *httpSyncRemote) request(method string, body []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, r.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid sync url: %w", err)
+	}
+	if token := os.Getenv(SyncTokenEnv); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req, nil
+}
+
+func (r *httpSyncRemote) Fetch() (*SyncBundle, string, error) {
+	req, err := r.request(http.MethodGet, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := syncHTTPClient.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to fetch shared state: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("failed to fetch shared state: %s", resp.Status)
+	}
+	var bundle SyncBundle
+	if err := json.NewDecoder(resp.Body).Decode(&bundle); err != nil {
+		return nil, "", fmt.Errorf("failed to parse shared state: %w", err)
+	}
+	return &bundle, resp.Header.Get("ETag"), nil
+}
+
+func (r *httpSyncRemote) Store(bundle *SyncBundle, expected string) (string, error) {
+	data, err := json.Marshal(bundle)
+	if err != nil {
+		return "", err
+	}
+	req, err := r.request(http.MethodPut, data)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if expected == "" {
+		req.Header.Set("If-None-Match", "*")
+	} else {
+		req.Header.Set("If-Match", expected)
+	}
+	resp, err := syncHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to upload shared state: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusPreconditionFailed:
+		return "", fmt.Errorf("%w: %s changed during upload", ErrSyncConflict, r.url)
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return "", fmt.Errorf("failed to upload shared state: %s", resp.Status)
+	}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		return etag, nil
+	}
+	// Not every store returns the new ETag from PUT; read it back.
+	_, etag, err := r.Fetch()
+	return etag, err
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newSyncProject(t *testing.T, title string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(GetAutoDir(dir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	prd := NewAutoPRD(title, "")
+	prd.Tasks = []AutoTask{{ID: "1", Title: "First task", Status: TaskStatusPending}}
+	if err := prd.Save(GetAutoPRDPath(dir)); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func completeSyncedTask(t *testing.T, dir string) {
+	t.Helper()
+	prd, err := LoadAutoPRD(GetAutoPRDPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	prd.Tasks[0].Status = TaskStatusCompleted
+	if err := prd.Save(GetAutoPRDPath(dir)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSyncConfigValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     SyncConfig
+		wantErr bool
+	}{
+		{"git", SyncConfig{Target: SyncTargetGit}, false},
+		{"http with url", SyncConfig{Target: SyncTargetHTTP, URL: "https://example.com/s"}, false},
+		{"http without url", SyncConfig{Target: SyncTargetHTTP}, true},
+		{"unknown target", SyncConfig{Target: "ftp"}, true},
+		{"empty target", SyncConfig{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.cfg.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHashStateFiles_OrderIndependent(t *testing.T) {
+	a := hashStateFiles(map[string]string{"a": "1", "b": "2"})
+	b := hashStateFiles(map[string]string{"b": "2", "a": "1"})
+	if a != b {
+		t.Error("hash depends on map order")
+	}
+	if a == hashStateFiles(map[string]string{"a": "12"}) {
+		t.Error("hash does not separate file boundaries")
+	}
+}
+func TestWriteStateFiles_IgnoresUnknownNames(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{AutoPRDFile: "{}", "../../evil": "x"}
+	if err := writeStateFiles(dir, files); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "evil")); !os.IsNotExist(err) {
+		t.Error("unknown file name was written")
+	}
+	if _, err := os.Stat(GetAutoPRDPath(dir)); err != nil {
+		t.Errorf("prd.json not written: %v", err)
+	}
+}
+func TestSyncLoopState(t *testing.T) {
+	ts := httptest.NewServer(&fakeStateServer{})
+	defer ts.Close()
+	shared := &SyncConfig{Target: SyncTargetHTTP, URL: ts.URL}
+
+	if err := SyncLoopState(LoopConfig{}, 1); err != nil {
+		t.Errorf("no sync config: %v", err)
+	}
+
+	alice := newSyncProject(t, "shared")
+	var reported []error
+	cfg := LoopConfig{ProjectDir: alice, Sync: shared, OnSync: func(_ int, _ *SyncBundle, err error) {
+		reported = append(reported, err)
+	}}
+	if err := SyncLoopState(cfg, 1); err != nil {
+		t.Fatalf("first sync: %v", err)
+	}
+
+	bob := t.TempDir()
+	if _, err := PullAutoState(bob, shared, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := PushAutoState(bob, shared, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := SyncLoopState(cfg, 2); !errors.Is(err, ErrSyncConflict) {
+		t.Errorf("SyncLoopState() = %v, want ErrSyncConflict", err)
+	}
+	if len(reported) != 2 || reported[0] != nil || reported[1] == nil {
+		t.Errorf("reported = %v", reported)
+	}
+
+	unreachable := cfg
+	unreachable.Sync = &SyncConfig{Target: SyncTargetHTTP, URL: "http://127.0.0.1:1/state"}
+	if err := SyncLoopState(unreachable, 3); err != nil {
+		t.Errorf("network failure should not stop the loop: %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// taskIDRegex matches hierarchical task IDs: dotted numbers such as 3,
+// 3.0 (a top-level task in converted PRDs) or 3.1.2.
+var taskIDRegex = regexp.MustCompile(`^\d+(\.\d+)*$`)
+
+// TaskRename records one ID changed by RenumberTasks.
+type TaskRename struct {
+	Old string
+	New string
+}
+
+// ValidateTaskID checks that id follows the hierarchical scheme.
+func ValidateTaskID(id string) error {
+	if !taskIDRegex.MatchString(id) {
+		return fmt.Errorf("invalid task ID: %q (expected dotted numbers such as 3 or 3.1)", id)
+	}
+	return nil
+}
+
+// childPrefix returns the prefix of the IDs of id's children: "3" for the
+// top-level task "3.0", otherwise id itself.
+func childPrefix(id string) string {
+	if first, rest, ok := strings.Cut(id, "."); ok && rest == "0" {
+		return first
+	}
+	return id
+}
+
+// usesZeroSuffix reports whether top-level tasks are numbered "N.0", the
+// form written by 'samuel auto convert'.
+func (p *AutoPRD) usesZeroSuffix() bool {
+	for _, t := range p.Tasks {
+		if first, rest, ok := strings.Cut(t.ID, "."); ok && rest == "0" && taskIDRegex.MatchString(first) {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolveTaskID returns the ID of the task named by id, accepting "3" for
+// "3.0", or "" when there is no such task.
+func (p *AutoPRD) ResolveTaskID(id string) string {
+	if p.findTask(id) != nil {
+		return id
+	}
+	if !strings.Contains(id, ".") && p.findTask(id+".0") != nil {
+		return id + ".0"
+	}
+	return ""
+}
+
+// NextTaskID returns the next free ID under parent, such as 3.4 after
+// 3.3, or the next top-level ID when parent is empty.
+func (p *AutoPRD) NextTaskID(parent string) (string, error) {
+	prefix := ""
+	if parent != "" {
+		resolved := p.ResolveTaskID(parent)
+		if resolved == "" {
+			return "", fmt.Errorf("parent task not found: %s", parent)
+		}
+		prefix = childPrefix(resolved) + "."
+	}
+
+	highest := 0
+	for _, t := range p.Tasks {
+		rest, ok := strings.CutPrefix(t.ID, prefix)
+		if !ok || !taskIDRegex.MatchString(t.ID) {
+			continue
+		}
+		// A deeper ID such as 3.2.1 still reserves 3.2.
+		segment, _, _ := strings.Cut(rest, ".")
+		if n, err := strconv.Atoi(segment); err == nil && n > highest {
+			highest = n
+		}
+	}
+
+	id := prefix + strconv.Itoa(highest+1)
+	if prefix == "" && p.usesZeroSuffix() {
+		id += ".0"
+	}
+	return id, nil
+}
+
+// IsChildID reports whether id is a direct child ID of the task parent.
+func (p *AutoPRD) IsChildID(parent, id string) bool {
+	resolved := p.ResolveTaskID(parent)
+	if resolved == "" {
+		return false
+	}
+	rest, ok := strings.CutPrefix(id, childPrefix(resolved)+".")
+	return ok && !strings.Contains(rest, ".") && rest != "0"
+}
+
+// RenumberTasks rewrites every task ID into the hierarchical scheme in
+// list order: top-level tasks become 1, 2, ... (1.0, 2.0, ... when the
+// PRD uses that form) and children become parent.1, parent.2, ...
+// Parent and dependency references follow the new IDs. A task's parent is
+// its parent_id, or else the task its ID nests under.
+func (p *AutoPRD) RenumberTasks() []TaskRename {
+	parents := p.taskParents()
+	children := make(map[int][]int)
+	var roots []int
+	for i, parent := range parents {
+		if parent < 0 {
+			roots = append(roots, i)
+		} else {
+			children[parent] = append(children[parent], i)
+		}
+	}
+
+	newIDs := make([]string, len(p.Tasks))
+	var assign func(i int, id string)
+	assign = func(i int, id string) {
+		newIDs[i] = id
+		for n, child := range children[i] {
+			assign(child, fmt.Sprintf("%s.%d", childPrefix(id), n+1))
+		}
+	}
+	suffix := ""
+	if p.usesZeroSuffix() {
+		suffix = ".0"
+	}
+	for n, root := range roots {
+		assign(root, fmt.Sprintf("%d%s", n+1, suffix))
+	}
+	return p.applyRenames(newIDs, parents)
+}
+
+// taskParents returns the index of each task's parent, or -1 for
+// top-level tasks. A parent chain that loops is cut so one of its tasks
+// becomes top-level.
+func (p *AutoPRD) taskParents() []int {
+	index := make(map[string]int, len(p.Tasks))
+	for i := len(p.Tasks) - 1; i >= 0; i-- {
+		index[p.Tasks[i].ID] = i
+	}
+	lookup := func(i int, id string) int {
+		if j, ok := index[id]; ok && j != i {
+			return j
+		}
+		return -1
+	}
+
+	parents := make([]int, len(p.Tasks))
+	for i, t := range p.Tasks {
+		parents[i] = -1
+		if t.ParentID != "" {
+			parents[i] = lookup(i, t.ParentID)
+		} else if prefix, ok := parentPrefix(t.ID); ok && childPrefix(t.ID) == t.ID {
+			if parents[i] = lookup(i, prefix); parents[i] < 0 && !strings.Contains(prefix, ".") {
+				parents[i] = lookup(i, prefix+".0")
+			}
+		}
+	}
+	for i := range parents {
+		j := parents[i]
+		for steps := 0; j >= 0 && steps <= len(parents); steps++ {
+			j = parents[j]
+		}
+		if j >= 0 {
+			parents[i] = -1
+		}
+	}
+	return parents
+}
+
+// parentPrefix returns a hierarchical ID without its last segment.
+func parentPrefix(id string) (string, bool) {
+	i := strings.LastIndex(id, ".")
+	if i < 0 || !taskIDRegex.MatchString(id) {
+		return "", false
+	}
+	return id[:i], true
+}
+
+// applyRenames sets the new IDs and rewrites references to the old ones.
+func (p *AutoPRD) applyRenames(newIDs []string, parents []int) []TaskRename {
+	renamed := make(map[string]string)
+	var renames []TaskRename
+	for i, t := range p.Tasks {
+		if _, seen := renamed[t.ID]; !seen {
+			renamed[t.ID] = newIDs[i]
+		}
+		if t.ID != newIDs[i] {
+			renames = append(renames, TaskRename{Old: t.ID, New: newIDs[i]})
+		}
+	}
+
+	for i := range p.Tasks {
+		t := &p.Tasks[i]
+		t.ID = newIDs[i]
+		if t.ParentID != "" && parents[i] >= 0 {
+			t.ParentID = newIDs[parents[i]]
+		}
+		for j, dep := range t.DependsOn {
+			if id, ok := renamed[dep]; ok {
+				t.DependsOn[j] = id
+			}
+		}
+	}
+	return renames
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func prdWithIDs(ids ...string) *AutoPRD {
+	prd := NewAutoPRD("test", "desc")
+	for _, id := range ids {
+		prd.Tasks = append(prd.Tasks, AutoTask{ID: id, Title: "Task " + id, Status: TaskStatusPending})
+	}
+	return prd
+}
+
+func TestValidateTaskID(t *testing.T) {
+	for _, id := range []string{"1", "3.0", "3.1", "10.2.4"} {
+		if err := ValidateTaskID(id); err != nil {
+			t.Errorf("ValidateTaskID(%q) = %v", id, err)
+		}
+	}
+	for _, id := range []string{"", "3.", ".1", "3..1", "a", "3.1b", "T-1"} {
+		if err := ValidateTaskID(id); err == nil {
+			t.Errorf("ValidateTaskID(%q) = nil, want error", id)
+		}
+	}
+}
+
+func TestNextTaskID(t *testing.T) {
+	tests := []struct {
+		name    string
+		ids     []string
+		parent  string
+		want    string
+		wantErr bool
+	}{
+		{"empty prd", nil, "", "1", false},
+		{"top level", []string{"1", "2", "7.3"}, "", "8", false},
+		{"top level zero form", []string{"1.0", "1.1", "2.0"}, "", "3.0", false},
+		{"first child", []string{"3"}, "3", "3.1", false},
+		{"next child", []string{"3.0", "3.1", "3.2"}, "3", "3.3", false},
+		{"parent by full ID", []string{"3.0", "3.1"}, "3.0", "3.2", false},
+		{"gap keeps highest", []string{"3.0", "3.1", "3.5"}, "3", "3.6", false},
+		{"nested", []string{"3.0", "3.1", "3.1.1"}, "3.1", "3.1.2", false},
+		{"deeper ID reserves child", []string{"3", "3.2.1"}, "3", "3.3", false},
+		{"ignores other parents", []string{"3", "31.4"}, "3", "3.1", false},
+		{"missing parent", []string{"1"}, "4", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := prdWithIDs(tt.ids...).NextTaskID(tt.parent)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NextTaskID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NextTaskID(%q) = %q, want %q", tt.parent, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsChildID(t *testing.T) {
+	prd := prdWithIDs("3.0", "3.1")
+	tests := []struct {
+		parent, id string
+		want       bool
+	}{
+		{"3", "3.4", true},
+		{"3.0", "3.4", true},
+		{"3", "3.0", false},
+		{"3", "3.4.1", false},
+		{"3", "4.1", false},
+		{"9", "9.1", false},
+	}
+	for _, tt := range tests {
+		if got := prd.IsChildID(tt.parent, tt.id); got != tt.want {
+			t.Errorf("IsChildID(%q, %q) = %v, want %v", tt.parent, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestRenumberTasks(t *testing.T) {
+	prd := prdWithIDs("2.0", "2.3", "2.7", "5.0", "5.2", "9.0")
+	prd.Tasks[1].ParentID = "2.0"
+	prd.Tasks[2].DependsOn = []string{"2.3"}
+	prd.Tasks[4].DependsOn = []string{"2.7", "unknown"}
+
+	renames := prd.RenumberTasks()
+
+	var ids []string
+	for _, task := range prd.Tasks {
+		ids = append(ids, task.ID)
+	}
+	if want := []string{"1.0", "1.1", "1.2", "2.0", "2.1", "3.0"}; !reflect.DeepEqual(ids, want) {
+		t.Errorf("IDs = %v, want %v", ids, want)
+	}
+	if prd.Tasks[1].ParentID != "1.0" {
+		t.Errorf("ParentID = %q, want 1.0", prd.Tasks[1].ParentID)
+	}
+	if prd.Tasks[2].ParentID != "" {
+		t.Errorf("inferred parent should not be written, got %q", prd.Tasks[2].ParentID)
+	}
+	if !reflect.DeepEqual(prd.Tasks[2].DependsOn, []string{"1.1"}) {
+		t.Errorf("DependsOn = %v, want [1.1]", prd.Tasks[2].DependsOn)
+	}
+	if !reflect.DeepEqual(prd.Tasks[4].DependsOn, []string{"1.2", "unknown"}) {
+		t.Errorf("DependsOn = %v, want [1.2 unknown]", prd.Tasks[4].DependsOn)
+	}
+	if len(renames) != 6 || renames[0] != (TaskRename{Old: "2.0", New: "1.0"}) {
+		t.Errorf("renames = %v", renames)
+	}
+	if errs := validateTasks(prd.Tasks); len(errs) != 1 {
+		t.Errorf("validateTasks() = %v, want only the unknown dependency", errs)
+	}
+}
+
+func TestRenumberTasks_Normalized(t *testing.T) {
+	prd := prdWithIDs("1", "1.1", "1.1.1", "2", "T-9")
+	if renames := prd.RenumberTasks(); len(renames) != 1 || renames[0] != (TaskRename{Old: "T-9", New: "3"}) {
+		t.Errorf("renames = %v, want only T-9 -> 3", renames)
+	}
+}
+
+func TestRenumberTasks_ParentCycle(t *testing.T) {
+	prd := prdWithIDs("a", "b")
+	prd.Tasks[0].ParentID = "b"
+	prd.Tasks[1].ParentID = "a"
+
+	prd.RenumberTasks()
+	if prd.Tasks[0].ID == "" || prd.Tasks[1].ID == "" || prd.Tasks[0].ID == prd.Tasks[1].ID {
+		t.Errorf("IDs = %q, %q, want two distinct IDs", prd.Tasks[0].ID, prd.Tasks[1].ID)
+	}
+}
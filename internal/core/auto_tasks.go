@@ -2,7 +2,6 @@ package core
 
 import (
 	"fmt"
-	"sort"
 	"time"
 )
 
@@ -22,23 +21,14 @@ func priorityRank(priority string) int {
 	}
 }
 
-// GetNextTask returns the highest-priority available pending task
+// GetNextTask returns the best available pending task according to the
+// configured scoring strategy (priority ordering by default).
 func (p *AutoPRD) GetNextTask() *AutoTask {
-	available := p.getAvailableTasks()
-	if len(available) == 0 {
+	scores := p.ScoreTasks(p.Config.ScoringStrategy, time.Now().UTC())
+	if len(scores) == 0 {
 		return nil
 	}
-
-	sort.Slice(available, func(i, j int) bool {
-		pi := priorityRank(available[i].Priority)
-		pj := priorityRank(available[j].Priority)
-		if pi != pj {
-			return pi < pj
-		}
-		return available[i].ID < available[j].ID
-	})
-
-	return available[0]
+	return scores[0].Task
 }
 
 // getAvailableTasks returns pending tasks whose dependencies are all completed
@@ -130,6 +120,9 @@ func (p *AutoPRD) AddTask(task AutoTask) error {
 	if task.Status == "" {
 		task.Status = TaskStatusPending
 	}
+	if task.CreatedAt == "" {
+		task.CreatedAt = time.Now().UTC().Format(time.RFC3339)
+	}
 	p.Tasks = append(p.Tasks, task)
 	return nil
 }
@@ -162,6 +155,30 @@ func ValidateAutoPRD(prd *AutoPRD) []string {
 	if prd.Project.Name == "" {
 		errors = append(errors, "project.name is required")
 	}
+	if !IsValidScoringStrategy(prd.Config.ScoringStrategy) {
+		errors = append(errors, fmt.Sprintf("config.scoring_strategy is invalid: %s", prd.Config.ScoringStrategy))
+	}
+
+	for i, m := range prd.Config.SandboxMounts {
+		if err := ValidateSandboxMount(m); err != nil {
+			errors = append(errors, fmt.Sprintf("config.sandbox_mounts[%d]: %v", i, err))
+		}
+	}
+	errors = append(errors, validateHookCommands(HookPreIteration, prd.Config.PreIteration)...)
+	errors = append(errors, validateHookCommands(HookPostIteration, prd.Config.PostIteration)...)
+	if t := prd.Config.Telemetry; t != nil && t.Endpoint != "" {
+		if err := validateWebhookURL(t.Endpoint); err != nil {
+			errors = append(errors, "config.telemetry.endpoint: invalid URL (must be an http or https URL)")
+		}
+	}
+	if prd.Config.Report != nil {
+		errors = append(errors, validateEmailReport(prd.Config.Report.Email)...)
+		for i, hook := range prd.Config.Report.Webhooks {
+			if err := validateWebhookURL(hook); err != nil {
+				errors = append(errors, fmt.Sprintf("config.report.webhooks[%d]: %v", i, err))
+			}
+		}
+	}
 
 	errors = append(errors, validateTasks(prd.Tasks)...)
 	return errors
@@ -0,0 +1,182 @@
+package core
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultTelemetryService is the service.name of exported spans when
+// TelemetryConfig.ServiceName is empty and OTEL_SERVICE_NAME is unset.
+const DefaultTelemetryService = "samuel"
+
+// telemetryScope is the instrumentation scope of exported spans.
+const telemetryScope = "github.com/ar4mirez/samuel/auto"
+
+// otlpTracesPath is appended to an OTLP/HTTP base endpoint.
+const otlpTracesPath = "/v1/traces"
+
+// Span names. They stay fixed so backends can group on them; the
+// iteration, task and command are attributes.
+const (
+	spanRun       = "auto.run"
+	spanIteration = "auto.iteration"
+	spanAgent     = "auto.agent"
+	spanHook      = "auto.hook"
+)
+
+// TelemetryConfig enables the OpenTelemetry trace export of the auto loop.
+// Endpoint is the base URL of an OTLP/HTTP collector, such as
+// http://localhost:4318; spans are posted as JSON to its /v1/traces path.
+// Credentials do not belong in prd.json: request headers are read from
+// OTEL_EXPORTER_OTLP_HEADERS.
+type TelemetryConfig struct {
+	Endpoint    string `json:"endpoint,omitempty"`
+	ServiceName string `json:"service_name,omitempty"`
+}
+
+// TelemetryEndpoint returns the URL spans are posted to: the endpoint of
+// cfg, else OTEL_EXPORTER_OTLP_TRACES_ENDPOINT as is, else
+// OTEL_EXPORTER_OTLP_ENDPOINT. Empty means export is off.
+func TelemetryEndpoint(cfg *TelemetryConfig) string {
+	base := ""
+	if cfg != nil {
+		base = cfg.Endpoint
+	}
+	if base == "" {
+		if traces := os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"); traces != "" {
+			return traces
+		}
+		base = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	}
+	if base == "" {
+		return ""
+	}
+	return strings.TrimSuffix(base, "/") + otlpTracesPath
+}
+
+// parseOTLPHeaders parses the "key=value,key2=value2" format of
+// OTEL_EXPORTER_OTLP_HEADERS, with URL-encoded values.
+func parseOTLPHeaders(value string) map[string]string {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			continue
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(v)); err == nil {
+			v = decoded
+		}
+		headers[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return headers
+}
+
+// LoopTrace exports one run of the loop as a trace: a run span with an
+// iteration span per iteration, each holding a span for the agent and one
+// for every hook command. An iteration's spans are exported when it ends
+// and the run span by Finish. Export is best effort: failures are reported
+// to LoopConfig.OnTelemetry and never stop the loop. The methods of a nil
+// LoopTrace do nothing, so callers need not check whether export is on.
+type LoopTrace struct {
+	endpoint string
+	headers  map[string]string
+	service  string
+	onError  func(error)
+
+	traceID  string
+	runID    string
+	started  time.Time
+	runAttrs []otlpKeyValue
+
+	iterID    string
+	iterStart time.Time
+	children  []otlpSpan
+}
+
+// StartLoopTrace starts the trace of a run when an endpoint is configured
+// (see TelemetryEndpoint) and attaches it to cfg. It returns nil otherwise.
+func StartLoopTrace(cfg *LoopConfig) *LoopTrace {
+	endpoint := TelemetryEndpoint(cfg.Telemetry)
+	if endpoint == "" {
+		return nil
+	}
+	service := os.Getenv("OTEL_SERVICE_NAME")
+	if cfg.Telemetry != nil && cfg.Telemetry.ServiceName != "" {
+		service = cfg.Telemetry.ServiceName
+	}
+	if service == "" {
+		service = DefaultTelemetryService
+	}
+	t := &LoopTrace{
+		endpoint: endpoint,
+		headers:  parseOTLPHeaders(os.Getenv("OTEL_EXPORTER_OTLP_HEADERS")),
+		service:  service,
+		onError:  cfg.OnTelemetry,
+		traceID:  newOTLPID(16),
+		runID:    newOTLPID(8),
+		started:  time.Now(),
+		runAttrs: []otlpKeyValue{
+			otlpString("samuel.project", filepath.Base(cfg.ProjectDir)),
+			otlpString("samuel.agent", cfg.AITool),
+			otlpInt("samuel.max_iterations", cfg.MaxIterations),
+		},
+	}
+	cfg.trace = t
+	return t
+}
+
+// startIteration opens the span of a new iteration.
+func (t *LoopTrace) startIteration() {
+	if t == nil {
+		return
+	}
+	t.iterID, t.iterStart, t.children = newOTLPID(8), time.Now(), nil
+}
+
+// addSpan records a step of the running iteration that began at start and
+// ends now.
+func (t *LoopTrace) addSpan(name string, start time.Time, err error, attrs ...otlpKeyValue) {
+	if t == nil {
+		return
+	}
+	t.children = append(t.children, newOTLPSpan(t.traceID, t.iterID, name, start, time.Now(), err, attrs...))
+}
+
+// endIteration closes the running iteration and exports it with its steps.
+func (t *LoopTrace) endIteration(iter int, iterType, taskID, agent string, changes FileChangeSummary, err error) {
+	if t == nil {
+		return
+	}
+	span := newOTLPSpan(t.traceID, t.runID, spanIteration, t.iterStart, time.Now(), err,
+		otlpInt("samuel.iteration", iter),
+		otlpString("samuel.iteration.type", iterType),
+		otlpString("samuel.iteration.outcome", iterationStatus(err)),
+		otlpString("samuel.task.id", taskID),
+		otlpString("samuel.agent", agent),
+		otlpInt("samuel.files.added", changes.Added),
+		otlpInt("samuel.files.modified", changes.Modified),
+		otlpInt("samuel.files.deleted", changes.Deleted),
+	)
+	span.SpanID = t.iterID
+	t.export(append([]otlpSpan{span}, t.children...))
+	t.children = nil
+}
+
+// Finish exports the run span with the outcome of the run.
+func (t *LoopTrace) Finish(err error) {
+	if t == nil {
+		return
+	}
+	span := newOTLPSpan(t.traceID, "", spanRun, t.started, time.Now(), err, t.runAttrs...)
+	span.SpanID = t.runID
+	t.export([]otlpSpan{span})
+}
+
+func (t *LoopTrace) export(spans []otlpSpan) {
+	if err := exportOTLPSpans(t.endpoint, t.headers, t.service, spans); err != nil && t.onError != nil {
+		t.onError(err)
+	}
+}
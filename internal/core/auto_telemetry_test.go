@@ -0,0 +1,165 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTelemetryEndpoint(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *TelemetryConfig
+		base   string
+		traces string
+		want   string
+	}{
+		{name: "off", want: ""},
+		{name: "config", cfg: &TelemetryConfig{Endpoint: "http://collector:4318/"}, base: "http://env:4318", want: "http://collector:4318/v1/traces"},
+		{name: "traces env used as is", traces: "http://env:4318/custom", base: "http://base:4318", want: "http://env:4318/custom"},
+		{name: "base env", cfg: &TelemetryConfig{ServiceName: "ci"}, base: "http://base:4318", want: "http://base:4318/v1/traces"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", tt.base)
+			t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", tt.traces)
+			if got := TelemetryEndpoint(tt.cfg); got != tt.want {
+				t.Errorf("TelemetryEndpoint() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOTLPHeaders(t *testing.T) {
+	got := parseOTLPHeaders("x-api-key=abc%3D%3D, Authorization = Bearer%20tok ,broken,=empty")
+	if len(got) != 2 || got["x-api-key"] != "abc==" || got["Authorization"] != "Bearer tok" {
+		t.Errorf("parseOTLPHeaders() = %v", got)
+	}
+}
+
+// collectSpans starts a collector that records the spans posted to it and
+// points the OTLP environment at it.
+func collectSpans(t *testing.T, status int) *[]otlpSpan {
+	t.Helper()
+	var mu sync.Mutex
+	var spans []otlpSpan
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != otlpTracesPath || r.Header.Get("X-Api-Key") != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var req otlpExportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				spans = append(spans, ss.Spans...)
+			}
+		}
+		mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", srv.URL)
+	t.Setenv("OTEL_EXPORTER_OTLP_HEADERS", "x-api-key=secret")
+	return &spans
+}
+
+func spanAttr(span otlpSpan, key string) string {
+	for _, kv := range span.Attributes {
+		if kv.Key != key {
+			continue
+		}
+		if kv.Value.StringValue != nil {
+			return *kv.Value.StringValue
+		}
+		if kv.Value.IntValue != nil {
+			return *kv.Value.IntValue
+		}
+	}
+	return ""
+}
+
+func TestRunIteration_Telemetry(t *testing.T) {
+	requireShell(t)
+	spans := collectSpans(t, http.StatusOK)
+	installFakeAgent(t, 1)
+	dir := t.TempDir()
+	cfg := LoopConfig{
+		ProjectDir: dir, PRDPath: GetAutoPRDPath(dir), PromptPath: filepath.Join(dir, "prompt.md"),
+		AITool: "claude", MaxIterations: 5, PostIteration: []string{"true"},
+	}
+	if err := os.WriteFile(cfg.PromptPath, []byte("Do the next task."), 0644); err != nil {
+		t.Fatal(err)
+	}
+	trace := StartLoopTrace(&cfg)
+	if trace == nil {
+		t.Fatal("StartLoopTrace() = nil with an endpoint set")
+	}
+
+	_, iterErr := runIteration(cfg, 2, IterationTypeImplementation)
+	trace.Finish(iterErr)
+
+	byName := map[string]otlpSpan{}
+	for _, s := range *spans {
+		if s.TraceID != trace.traceID {
+			t.Errorf("span %s has trace %s, want %s", s.Name, s.TraceID, trace.traceID)
+		}
+		byName[s.Name] = s
+	}
+	run, iter, agent, hook := byName[spanRun], byName[spanIteration], byName[spanAgent], byName[spanHook]
+	if len(*spans) != 4 || run.ParentSpanID != "" || iter.ParentSpanID != run.SpanID ||
+		agent.ParentSpanID != iter.SpanID || hook.ParentSpanID != iter.SpanID {
+		t.Fatalf("unexpected span tree: %+v", *spans)
+	}
+	if iter.Status.Code != otlpStatusError || agent.Status.Code != otlpStatusError || hook.Status.Code != otlpStatusOK {
+		t.Errorf("statuses: iteration %d, agent %d, hook %d", iter.Status.Code, agent.Status.Code, hook.Status.Code)
+	}
+	if spanAttr(iter, "samuel.iteration") != "2" || spanAttr(iter, "samuel.agent") != "claude" ||
+		spanAttr(iter, "samuel.iteration.outcome") != HookStatusFailed {
+		t.Errorf("iteration attributes = %+v", iter.Attributes)
+	}
+	if spanAttr(hook, "samuel.hook.command") != "true" || spanAttr(hook, "samuel.hook.phase") != HookPostIteration {
+		t.Errorf("hook attributes = %+v", hook.Attributes)
+	}
+}
+
+func TestLoopTrace_ExportErrors(t *testing.T) {
+	collectSpans(t, http.StatusServiceUnavailable)
+	var reported []error
+	cfg := LoopConfig{ProjectDir: t.TempDir(), OnTelemetry: func(err error) { reported = append(reported, err) }}
+
+	trace := StartLoopTrace(&cfg)
+	trace.startIteration()
+	trace.endIteration(1, IterationTypeImplementation, "1", "claude", FileChangeSummary{}, nil)
+	trace.Finish(errors.New("stopped"))
+
+	if len(reported) != 2 {
+		t.Errorf("OnTelemetry got %v, want one error per export", reported)
+	}
+}
+
+func TestLoopTrace_Disabled(t *testing.T) {
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	cfg := LoopConfig{ProjectDir: t.TempDir()}
+
+	trace := StartLoopTrace(&cfg)
+	if trace != nil || cfg.trace != nil {
+		t.Fatal("StartLoopTrace() started a trace without an endpoint")
+	}
+	// The methods of a nil trace are no-ops.
+	trace.startIteration()
+	trace.addSpan(spanAgent, time.Now(), nil)
+	trace.Finish(nil)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -107,6 +108,80 @@ func TestLoadAutoPRD_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestAutoPRD_SaveKeepsBackup(t *testing.T) {
+	dir := t.TempDir()
+	prdPath := filepath.Join(dir, "prd.json")
+
+	prd := NewAutoPRD("test", "desc")
+	if err := prd.Save(prdPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	if _, err := os.Stat(prdPath + BackupSuffix); !os.IsNotExist(err) {
+		t.Error("expected no backup after the first save")
+	}
+	first, _ := os.ReadFile(prdPath)
+
+	prd.Tasks = append(prd.Tasks, AutoTask{ID: "1", Title: "Task", Status: TaskStatusPending})
+	if err := prd.Save(prdPath); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	backup, err := os.ReadFile(prdPath + BackupSuffix)
+	if err != nil {
+		t.Fatalf("expected backup: %v", err)
+	}
+	if string(backup) != string(first) {
+		t.Error("expected backup to hold the previous version")
+	}
+}
+
+func TestLoadAutoPRD_RecoversFromBackup(t *testing.T) {
+	tests := []struct {
+		name          string
+		backup        string
+		wantErr       bool
+		wantRecovered bool
+	}{
+		{name: "valid backup", backup: `{"version": "1.0", "tasks": [{"id": "1"}]}`, wantRecovered: true},
+		{name: "invalid backup", backup: "{also invalid", wantErr: true},
+		{name: "no backup", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			prdPath := filepath.Join(dir, "prd.json")
+			writeTestFile(t, prdPath, `{"version": "1.0", "tas`)
+			if tt.backup != "" {
+				writeTestFile(t, prdPath+BackupSuffix, tt.backup)
+			}
+
+			prd, err := LoadAutoPRD(prdPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LoadAutoPRD() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				if !strings.Contains(err.Error(), "failed to parse prd.json") {
+					t.Errorf("error = %v, want the prd.json parse error", err)
+				}
+				return
+			}
+			if prd.RecoveredFromBackup() != tt.wantRecovered || len(prd.Tasks) != 1 {
+				t.Errorf("recovered = %v, tasks = %d", prd.RecoveredFromBackup(), len(prd.Tasks))
+			}
+
+			// Saving repairs prd.json without overwriting the good backup.
+			if err := prd.Save(prdPath); err != nil {
+				t.Fatalf("Save failed: %v", err)
+			}
+			if backup, _ := os.ReadFile(prdPath + BackupSuffix); string(backup) != tt.backup {
+				t.Error("expected Save to keep the backup it recovered from")
+			}
+			if repaired, err := LoadAutoPRD(prdPath); err != nil || repaired.RecoveredFromBackup() {
+				t.Errorf("expected prd.json to be repaired, err = %v", err)
+			}
+		})
+	}
+}
+
 func TestLoadAutoPRD_NotFound(t *testing.T) {
 	_, err := LoadAutoPRD("/nonexistent/prd.json")
 	if err == nil {
@@ -0,0 +1,135 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// UserAutoSettingsFile holds one user's auto loop defaults, in the global
+// config directory (~/.config/samuel/auto.yaml).
+const UserAutoSettingsFile = "auto.yaml"
+
+// UserAutoSettings are personal defaults for the auto loop, shared by all
+// of a user's projects. They sit beneath the project: a value applies only
+// where flags and the project's prd.json config leave the setting unset,
+// so a project can still pin team-wide settings. Webhooks are the
+// exception: they are personal and are added to the project's.
+type UserAutoSettings struct {
+	AITool          string `yaml:"ai_tool,omitempty"`
+	MaxIterations   int    `yaml:"max_iterations,omitempty"`
+	Sandbox         string `yaml:"sandbox,omitempty"`
+	SandboxImage    string `yaml:"sandbox_image,omitempty"`
+	SandboxTemplate string `yaml:"sandbox_template,omitempty"`
+	ScoringStrategy string `yaml:"scoring_strategy,omitempty"`
+	// Webhooks receive the run digest when a run ends (see
+	// ReportConfig.Webhooks).
+	Webhooks []string `yaml:"webhooks,omitempty"`
+}
+
+// GetUserAutoSettingsPath returns the path of the user's auto defaults.
+func GetUserAutoSettingsPath() (string, error) {
+	dir, err := GetGlobalConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, UserAutoSettingsFile), nil
+}
+
+// LoadUserAutoSettings reads the user's auto defaults. A missing file
+// yields empty settings; an unreadable or invalid one is an error.
+func LoadUserAutoSettings() (*UserAutoSettings, error) {
+	path, err := GetUserAutoSettingsPath()
+	if err != nil {
+		return &UserAutoSettings{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &UserAutoSettings{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var settings UserAutoSettings
+	if err := yaml.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if errs := settings.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid %s: %s", path, strings.Join(errs, "; "))
+	}
+	return &settings, nil
+}
+
+// Validate returns a message for each invalid setting.
+func (s *UserAutoSettings) Validate() []string {
+	var errs []string
+	if s.AITool != "" && !IsValidAITool(s.AITool) {
+		errs = append(errs, fmt.Sprintf("ai_tool: %s (supported: %v)", s.AITool, GetSupportedAITools()))
+	}
+	if s.MaxIterations < 0 {
+		errs = append(errs, fmt.Sprintf("max_iterations: %d (must not be negative)", s.MaxIterations))
+	}
+	if s.Sandbox != "" && !IsValidSandboxMode(s.Sandbox) {
+		errs = append(errs, fmt.Sprintf("sandbox: %s (supported: %v)", s.Sandbox, GetSupportedSandboxModes()))
+	}
+	if s.ScoringStrategy != "" && !IsValidScoringStrategy(s.ScoringStrategy) {
+		errs = append(errs, fmt.Sprintf("scoring_strategy: %s (supported: %v)", s.ScoringStrategy, GetSupportedScoringStrategies()))
+	}
+	for i, hook := range s.Webhooks {
+		if err := validateWebhookURL(hook); err != nil {
+			errs = append(errs, fmt.Sprintf("webhooks[%d]: %v", i, err))
+		}
+	}
+	return errs
+}
+
+// ApplyTo fills the settings cfg leaves unset with the user's defaults.
+func (s *UserAutoSettings) ApplyTo(cfg *AutoConfig) {
+	if cfg.AITool == "" {
+		cfg.AITool = s.AITool
+	}
+	if cfg.MaxIterations == 0 {
+		cfg.MaxIterations = s.MaxIterations
+	}
+	if cfg.Sandbox == "" {
+		cfg.Sandbox = s.Sandbox
+	}
+	if cfg.SandboxImage == "" {
+		cfg.SandboxImage = s.SandboxImage
+	}
+	if cfg.SandboxTemplate == "" {
+		cfg.SandboxTemplate = s.SandboxTemplate
+	}
+	if cfg.ScoringStrategy == "" {
+		cfg.ScoringStrategy = s.ScoringStrategy
+	}
+}
+
+// ApplyWebhooks adds the user's webhooks to the run report of cfg. They
+// are kept out of prd.json, which is shared with the team.
+func (s *UserAutoSettings) ApplyWebhooks(cfg *AutoConfig) {
+	for _, hook := range s.Webhooks {
+		if cfg.Report == nil {
+			cfg.Report = &ReportConfig{}
+		}
+		if !slices.Contains(cfg.Report.Webhooks, hook) {
+			cfg.Report.Webhooks = append(cfg.Report.Webhooks, hook)
+		}
+	}
+}
+
+// validateWebhookURL accepts absolute http and https URLs. The error does
+// not repeat the URL, which often embeds a secret token.
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook URL (must be an http or https URL)")
+	}
+	return nil
+}
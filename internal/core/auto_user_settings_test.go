@@ -0,0 +1,101 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func writeUserAutoSettings(t *testing.T, content string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if content == "" {
+		return
+	}
+	dir := filepath.Join(home, ".config", "samuel")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, UserAutoSettingsFile), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadUserAutoSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *UserAutoSettings
+		wantErr string
+	}{
+		{name: "no file", want: &UserAutoSettings{}},
+		{
+			name:    "settings",
+			content: "ai_tool: codex\nsandbox: docker\nmax_iterations: 40\nwebhooks:\n  - https://hooks.example.com/abc\n",
+			want:    &UserAutoSettings{AITool: "codex", Sandbox: "docker", MaxIterations: 40, Webhooks: []string{"https://hooks.example.com/abc"}},
+		},
+		{name: "invalid yaml", content: "ai_tool: [", wantErr: "failed to parse"},
+		{name: "unknown tool", content: "ai_tool: vim\n", wantErr: "ai_tool: vim"},
+		{name: "unknown sandbox", content: "sandbox: vm\n", wantErr: "sandbox: vm"},
+		{name: "negative iterations", content: "max_iterations: -1\n", wantErr: "max_iterations"},
+		{name: "bad webhook", content: "webhooks: [\"hooks.example.com/secret\"]\n", wantErr: "webhooks[0]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeUserAutoSettings(t, tt.content)
+			got, err := LoadUserAutoSettings()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("LoadUserAutoSettings() error = %v, want %q", err, tt.wantErr)
+				}
+				if strings.Contains(err.Error(), "secret") {
+					t.Errorf("error leaks the webhook URL: %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadUserAutoSettings() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LoadUserAutoSettings() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserAutoSettings_ApplyTo(t *testing.T) {
+	user := &UserAutoSettings{
+		AITool: "codex", MaxIterations: 40, Sandbox: "docker", SandboxImage: "img",
+		ScoringStrategy: ScoringStrategyWSJF, Webhooks: []string{"https://hooks.example.com/a"},
+	}
+
+	t.Run("fills unset settings", func(t *testing.T) {
+		cfg := AutoConfig{}
+		user.ApplyTo(&cfg)
+		want := AutoConfig{AITool: "codex", MaxIterations: 40, Sandbox: "docker", SandboxImage: "img", ScoringStrategy: ScoringStrategyWSJF}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("ApplyTo() = %+v, want %+v", cfg, want)
+		}
+	})
+
+	t.Run("project settings win", func(t *testing.T) {
+		cfg := AutoConfig{AITool: "claude", MaxIterations: 10, Sandbox: SandboxNone}
+		user.ApplyTo(&cfg)
+		if cfg.AITool != "claude" || cfg.MaxIterations != 10 || cfg.Sandbox != SandboxNone {
+			t.Errorf("ApplyTo() overrode project settings: %+v", cfg)
+		}
+	})
+
+	t.Run("webhooks are added once", func(t *testing.T) {
+		cfg := AutoConfig{Report: &ReportConfig{Webhooks: []string{"https://team.example.com/hook"}}}
+		user.ApplyWebhooks(&cfg)
+		user.ApplyWebhooks(&cfg)
+		want := []string{"https://team.example.com/hook", "https://hooks.example.com/a"}
+		if !reflect.DeepEqual(cfg.Report.Webhooks, want) {
+			t.Errorf("Webhooks = %v, want %v", cfg.Report.Webhooks, want)
+		}
+	})
+}
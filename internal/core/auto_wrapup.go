@@ -0,0 +1,105 @@
+package core
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Wrap-up timing constants for time-boxed runs
+const (
+	// DefaultWrapUpReserve is the time kept back for the wrap-up iteration.
+	DefaultWrapUpReserve = 10 * time.Minute
+	// wrapUpReserveDivisor caps the reserve at a fraction of short budgets.
+	wrapUpReserveDivisor = 4
+)
+
+// GetWrapUpPromptTemplate returns the prompt used for the final iteration
+// of a time-boxed run. It asks the agent to stop starting new work and
+// leave the workspace in a resumable state.
+func GetWrapUpPromptTemplate() string {
+	return `# Wrap-Up Iteration Prompt
+
+You are running the FINAL iteration of a time-boxed autonomous run.
+The time budget is nearly exhausted. Do NOT start a new task.
+
+## Your Task
+
+1. **Assess the workspace**:
+   - Run ` + "`git status`" + ` to see uncommitted changes
+   - Read ` + "`.claude/auto/prd.json`" + ` to find any task marked "in_progress"
+
+2. **Save work in progress safely**:
+   - If the changes build and pass quality checks, commit them normally
+   - Otherwise commit them as WIP: ` + "`wip(scope): task ID - partial progress`" + `
+   - Never leave uncommitted changes or broken generated files behind
+
+3. **Update state**:
+   - Set finished tasks to "completed" and record their ` + "`commit_sha`" + `
+   - Set unfinished "in_progress" tasks back to "pending" so the next run resumes them
+
+4. **Document the hand-off**:
+   - Append to ` + "`.claude/auto/progress.md`" + ` what was done, what is left,
+     and where the next run should pick up
+   - Format: ` + "`[timestamp] [iteration:N] [task:ID] WRAP-UP: description`" + `
+
+## Rules
+
+- Do NOT begin any new task, refactor, or exploration
+- Keep this iteration short — only commit, update state, and document
+- Leave the working tree clean (` + "`git status`" + ` shows nothing to commit)
+`
+}
+
+// GenerateWrapUpPrompt creates the wrap-up prompt customized for a project.
+func GenerateWrapUpPrompt(config AutoConfig) string {
+	var sb strings.Builder
+	sb.WriteString(GetWrapUpPromptTemplate())
+	sb.WriteString("\n## Project-Specific Configuration\n\n")
+	fmt.Fprintf(&sb, "- **PRD File**: %s\n", filepath.Join(AutoDir, AutoPRDFile))
+	fmt.Fprintf(&sb, "- **Progress File**: %s\n", filepath.Join(AutoDir, AutoProgressFile))
+
+	if len(config.QualityChecks) > 0 {
+		sb.WriteString("\n### Quality Checks\n\n")
+		sb.WriteString("Run these before a normal (non-WIP) commit:\n\n")
+		sb.WriteString("```bash\n")
+		for _, check := range config.QualityChecks {
+			sb.WriteString(check + "\n")
+		}
+		sb.WriteString("```\n")
+	}
+
+	return sb.String()
+}
+
+// WrapUpReserve returns how much of a time budget to keep for the wrap-up
+// iteration: DefaultWrapUpReserve, or a quarter of short budgets.
+func WrapUpReserve(budget time.Duration) time.Duration {
+	if quarter := budget / wrapUpReserveDivisor; quarter < DefaultWrapUpReserve {
+		return quarter
+	}
+	return DefaultWrapUpReserve
+}
+
+// shouldWrapUp reports whether the next iteration must be the wrap-up.
+// It triggers once the remaining time drops below the reserve or below
+// the average iteration duration, whichever is larger.
+func (cfg LoopConfig) shouldWrapUp(now time.Time, avgIter time.Duration) bool {
+	if cfg.Deadline.IsZero() {
+		return false
+	}
+	threshold := cfg.WrapUpReserve
+	if avgIter > threshold {
+		threshold = avgIter
+	}
+	return cfg.Deadline.Sub(now) <= threshold
+}
+
+// averageDuration returns total/count, or zero when count is zero.
+func averageDuration(total time.Duration, count int) time.Duration {
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
@@ -0,0 +1,117 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWrapUpReserve(t *testing.T) {
+	tests := []struct {
+		name   string
+		budget time.Duration
+		want   time.Duration
+	}{
+		{"long budget uses default", 2 * time.Hour, DefaultWrapUpReserve},
+		{"exact boundary", 40 * time.Minute, DefaultWrapUpReserve},
+		{"short budget uses quarter", 20 * time.Minute, 5 * time.Minute},
+		{"zero budget", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WrapUpReserve(tt.budget); got != tt.want {
+				t.Errorf("WrapUpReserve(%v) = %v, want %v", tt.budget, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoopConfig_ShouldWrapUp(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		deadline time.Time
+		avgIter  time.Duration
+		want     bool
+	}{
+		{"no deadline", time.Time{}, time.Hour, false},
+		{"plenty of time", now.Add(time.Hour), time.Minute, false},
+		{"inside reserve", now.Add(5 * time.Minute), 0, true},
+		{"average iteration exceeds remaining", now.Add(20 * time.Minute), 30 * time.Minute, true},
+		{"deadline passed", now.Add(-time.Minute), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := LoopConfig{Deadline: tt.deadline, WrapUpReserve: DefaultWrapUpReserve}
+			if got := cfg.shouldWrapUp(now, tt.avgIter); got != tt.want {
+				t.Errorf("shouldWrapUp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAverageDuration(t *testing.T) {
+	if got := averageDuration(time.Minute, 0); got != 0 {
+		t.Errorf("averageDuration with zero count = %v, want 0", got)
+	}
+	if got := averageDuration(6*time.Minute, 3); got != 2*time.Minute {
+		t.Errorf("averageDuration = %v, want 2m", got)
+	}
+}
+
+func TestGenerateWrapUpPrompt(t *testing.T) {
+	prompt := GenerateWrapUpPrompt(AutoConfig{QualityChecks: []string{"go test ./..."}})
+
+	for _, want := range []string{
+		"# Wrap-Up Iteration Prompt",
+		"Do NOT start a new task",
+		"WRAP-UP:",
+		"go test ./...",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("wrap-up prompt missing %q", want)
+		}
+	}
+}
+
+func TestRunAutoLoop_WrapUpStopsLoop(t *testing.T) {
+	dir := t.TempDir()
+	prd := NewAutoPRD("test", "test project")
+	prd.Tasks = []AutoTask{
+		{ID: "1", Title: "task 1", Status: TaskStatusPending},
+		{ID: "2", Title: "task 2", Status: TaskStatusPending},
+	}
+	prdPath := filepath.Join(dir, AutoDir, AutoPRDFile)
+	if err := prd.Save(prdPath); err != nil {
+		t.Fatalf("failed to save prd: %v", err)
+	}
+
+	var iterTypes []string
+	cfg := LoopConfig{
+		ProjectDir:       dir,
+		PRDPath:          prdPath,
+		AITool:           "codex",
+		PromptPath:       filepath.Join(dir, "prompt.md"),
+		WrapUpPromptPath: filepath.Join(dir, "wrapup-prompt.md"),
+		MaxIterations:    10,
+		MaxConsecFails:   5,
+		Deadline:         time.Now().Add(time.Minute),
+		WrapUpReserve:    DefaultWrapUpReserve,
+		OnIterStart: func(iter int, iterType string) {
+			iterTypes = append(iterTypes, iterType)
+		},
+	}
+
+	err := RunAutoLoop(cfg)
+
+	if len(iterTypes) != 1 || iterTypes[0] != IterationTypeWrapUp {
+		t.Fatalf("expected a single wrap-up iteration, got %v", iterTypes)
+	}
+	// codex is not installed, so the wrap-up invocation itself fails
+	if err != nil && !strings.Contains(err.Error(), "wrap-up iteration failed") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Badge output formats
+const (
+	BadgeFormatSVG  = "svg"
+	BadgeFormatJSON = "json"
+)
+
+// Badge colors, matching the shields.io named colors.
+const (
+	BadgeColorHealthy  = "brightgreen"
+	BadgeColorOutdated = "yellow"
+	BadgeColorFailing  = "red"
+)
+
+// badgeHexColors maps the named colors to the hex values drawn in SVG.
+var badgeHexColors = map[string]string{
+	BadgeColorHealthy:  "#4c1",
+	BadgeColorOutdated: "#dfb317",
+	BadgeColorFailing:  "#e05d44",
+}
+
+// HealthBadge is a status badge for a project's Samuel setup.
+type HealthBadge struct {
+	Label   string
+	Message string
+	Color   string
+}
+
+// GetSupportedBadgeFormats returns the list of supported badge formats.
+func GetSupportedBadgeFormats() []string {
+	return []string{BadgeFormatSVG, BadgeFormatJSON}
+}
+
+// NewHealthBadge builds the badge from the number of failed doctor checks,
+// the installed framework version, and the latest published version.
+// Failures take precedence over freshness; an empty or unparsable latest
+// version means freshness is unknown and is not reported.
+func NewHealthBadge(failed int, version, latest string) HealthBadge {
+	badge := HealthBadge{Label: "samuel", Message: "healthy", Color: BadgeColorHealthy}
+	if version != "" {
+		badge.Message += " · v" + strings.TrimPrefix(version, "v")
+	}
+
+	switch {
+	case failed == 1:
+		badge.Message, badge.Color = "1 issue", BadgeColorFailing
+	case failed > 1:
+		badge.Message, badge.Color = fmt.Sprintf("%d issues", failed), BadgeColorFailing
+	case latest != "":
+		if cmp, err := CompareVersions(version, latest); err == nil && cmp < 0 {
+			badge.Message = fmt.Sprintf("outdated · v%s → v%s",
+				strings.TrimPrefix(version, "v"), strings.TrimPrefix(latest, "v"))
+			badge.Color = BadgeColorOutdated
+		}
+	}
+	return badge
+}
+
+// EndpointJSON renders the badge in the shields.io endpoint format, so a
+// served copy can back https://img.shields.io/endpoint?url=....
+func (b HealthBadge) EndpointJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(map[string]any{
+		"schemaVersion": 1,
+		"label":         b.Label,
+		"message":       b.Message,
+		"color":         b.Color,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// SVG renders the badge as a flat shields.io-style SVG. Text widths are
+// estimated, which is close enough for short labels in Verdana 11px.
+func (b HealthBadge) SVG() string {
+	lw, mw := badgeTextWidth(b.Label), badgeTextWidth(b.Message)
+	total := lw + mw
+	color := badgeHexColors[b.Color]
+	label, message := html.EscapeString(b.Label), html.EscapeString(b.Message)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`+"\n",
+		total, label, message)
+	fmt.Fprintf(&sb, "  <title>%s: %s</title>\n", label, message)
+	sb.WriteString(`  <linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>` + "\n")
+	fmt.Fprintf(&sb, `  <clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`+"\n", total)
+	fmt.Fprintf(&sb, `  <g clip-path="url(#r)"><rect width="%d" height="20" fill="#555"/><rect x="%d" width="%d" height="20" fill="%s"/><rect width="%d" height="20" fill="url(#s)"/></g>`+"\n",
+		lw, lw, mw, color, total)
+	sb.WriteString(`  <g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">` + "\n")
+	fmt.Fprintf(&sb, `    <text x="%d" y="14">%s</text>`+"\n", lw/2, label)
+	fmt.Fprintf(&sb, `    <text x="%d" y="14">%s</text>`+"\n", lw+mw/2, message)
+	sb.WriteString("  </g>\n</svg>\n")
+	return sb.String()
+}
+
+// badgeTextWidth estimates the width of a badge segment: about 7px per
+// character plus 10px of padding on each side.
+func badgeTextWidth(text string) int {
+	return len([]rune(text))*7 + 20
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNewHealthBadge(t *testing.T) {
+	tests := []struct {
+		name        string
+		failed      int
+		version     string
+		latest      string
+		wantMessage string
+		wantColor   string
+	}{
+		{"healthy and current", 0, "1.8.0", "1.8.0", "healthy · v1.8.0", BadgeColorHealthy},
+		{"healthy, latest unknown", 0, "1.8.0", "", "healthy · v1.8.0", BadgeColorHealthy},
+		{"healthy, ahead of latest", 0, "v1.9.0", "v1.8.0", "healthy · v1.9.0", BadgeColorHealthy},
+		{"outdated", 0, "1.7.0", "v1.8.0", "outdated · v1.7.0 → v1.8.0", BadgeColorOutdated},
+		{"unparsable version", 0, "dev", "1.8.0", "healthy · vdev", BadgeColorHealthy},
+		{"one issue", 1, "1.7.0", "1.8.0", "1 issue", BadgeColorFailing},
+		{"several issues", 3, "", "", "3 issues", BadgeColorFailing},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewHealthBadge(tt.failed, tt.version, tt.latest)
+			if b.Message != tt.wantMessage || b.Color != tt.wantColor {
+				t.Errorf("NewHealthBadge() = %q/%s, want %q/%s", b.Message, b.Color, tt.wantMessage, tt.wantColor)
+			}
+			if b.Label != "samuel" {
+				t.Errorf("Label = %q, want samuel", b.Label)
+			}
+		})
+	}
+}
+
+func TestHealthBadge_EndpointJSON(t *testing.T) {
+	data, err := NewHealthBadge(2, "1.0.0", "").EndpointJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		Label         string `json:"label"`
+		Message       string `json:"message"`
+		Color         string `json:"color"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if got.SchemaVersion != 1 || got.Label != "samuel" || got.Message != "2 issues" || got.Color != "red" {
+		t.Errorf("EndpointJSON() = %+v", got)
+	}
+}
+
+func TestHealthBadge_SVG(t *testing.T) {
+	svg := HealthBadge{Label: "samuel", Message: "a<b & \"c\"", Color: BadgeColorOutdated}.SVG()
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg"`,
+		`fill="#dfb317"`,
+		"a&lt;b &amp; &#34;c&#34;",
+		"</svg>\n",
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q:\n%s", want, svg)
+		}
+	}
+	if strings.Contains(svg, "a<b") {
+		t.Error("message was not escaped")
+	}
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Release channels for framework content.
+const (
+	ChannelStable = "stable"
+	ChannelBeta   = "beta"
+)
+
+// GetSupportedChannels returns the accepted release channels.
+func GetSupportedChannels() []string {
+	return []string{ChannelStable, ChannelBeta}
+}
+
+// ValidateChannel checks that channel is supported. An empty channel means
+// stable.
+func ValidateChannel(channel string) error {
+	if channel != "" && !slices.Contains(GetSupportedChannels(), channel) {
+		return fmt.Errorf("unsupported channel: %s (supported: %v)", channel, GetSupportedChannels())
+	}
+	return nil
+}
+
+// ResolveChannel returns the channel to use: override when set (e.g. from
+// --channel), else the configured channel, else stable.
+func ResolveChannel(override, configured string) (string, error) {
+	channel := override
+	if channel == "" {
+		channel = configured
+	}
+	if channel == "" {
+		return ChannelStable, nil
+	}
+	return channel, ValidateChannel(channel)
+}
+
+// GetLatestVersionForChannel fetches the latest version published on
+// channel. The beta channel includes prereleases, so it resolves to the
+// newest release of any kind. Returns "dev" if no releases exist.
+func (d *Downloader) GetLatestVersionForChannel(channel string) (string, error) {
+	if err := ValidateChannel(channel); err != nil {
+		return "", err
+	}
+	if channel == ChannelBeta {
+		version, _, err := d.client.GetLatestPrereleaseVersionOrBranch()
+		return version, err
+	}
+	return d.GetLatestVersion()
+}
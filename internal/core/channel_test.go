@@ -0,0 +1,30 @@
+package core
+
+import "testing"
+
+func TestResolveChannel(t *testing.T) {
+	tests := []struct {
+		name       string
+		override   string
+		configured string
+		want       string
+		wantErr    bool
+	}{
+		{name: "default_stable", want: ChannelStable},
+		{name: "configured", configured: ChannelBeta, want: ChannelBeta},
+		{name: "override_wins", override: ChannelStable, configured: ChannelBeta, want: ChannelStable},
+		{name: "unsupported_override", override: "nightly", wantErr: true},
+		{name: "unsupported_configured", configured: "nightly", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveChannel(tt.override, tt.configured)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ResolveChannel() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// ComponentPreview is a component's SKILL.md as shipped in a template,
+// read before the component is installed.
+type ComponentPreview struct {
+	Component *Component
+	Metadata  SkillMetadata
+	Body      string
+	// Files are the component's other files (references, scripts,
+	// assets), relative to its directory, in lexical order.
+	Files []PreviewFile
+}
+
+// PreviewFile is a file shipped with a component besides SKILL.md.
+type PreviewFile struct {
+	Path string
+	Size int64
+}
+
+// LoadComponentPreview reads component from the template at templatePath
+// (a cached release or layered overlay) without touching the project.
+func LoadComponentPreview(templatePath string, component *Component) (*ComponentPreview, error) {
+	dir := filepath.Join(templatePath, filepath.FromSlash(GetSourcePath(component.Path)))
+	content, err := os.ReadFile(filepath.Join(dir, "SKILL.md"))
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("%s has no SKILL.md in this template version", component.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	meta, body, err := ParseSkillMD(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", component.Name, err)
+	}
+
+	preview := &ComponentPreview{Component: component, Metadata: *meta, Body: body}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if rel == "SKILL.md" {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		preview.Files = append(preview.Files, PreviewFile{Path: filepath.ToSlash(rel), Size: info.Size()})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", component.Name, err)
+	}
+	return preview, nil
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadComponentPreview(t *testing.T) {
+	tmpl := t.TempDir()
+	component := FindLanguage("go")
+	dir := filepath.Join(tmpl, "template", ".claude", "skills", "go-guide")
+	writeTestFile(t, filepath.Join(dir, "SKILL.md"),
+		"---\nname: go-guide\ndescription: Go guardrails.\nlicense: MIT\n---\n\n# Go Guide\n\nUse gofmt.\n")
+	writeTestFile(t, filepath.Join(dir, "references", "testing.md"), "# Testing\n")
+	writeTestFile(t, filepath.Join(dir, "references", "errors.md"), "# Errors\n")
+
+	preview, err := LoadComponentPreview(tmpl, component)
+	if err != nil {
+		t.Fatalf("LoadComponentPreview() error = %v", err)
+	}
+	if preview.Metadata.Name != "go-guide" || preview.Metadata.License != "MIT" {
+		t.Errorf("metadata = %+v", preview.Metadata)
+	}
+	if !strings.Contains(preview.Body, "Use gofmt.") || strings.Contains(preview.Body, "description:") {
+		t.Errorf("body = %q", preview.Body)
+	}
+	if len(preview.Files) != 2 || preview.Files[0].Path != "references/errors.md" || preview.Files[1].Size != int64(len("# Testing\n")) {
+		t.Errorf("files = %+v", preview.Files)
+	}
+}
+
+func TestLoadComponentPreview_Errors(t *testing.T) {
+	tmpl := t.TempDir()
+	if _, err := LoadComponentPreview(tmpl, FindLanguage("rust")); err == nil || !strings.Contains(err.Error(), "no SKILL.md") {
+		t.Errorf("missing component: error = %v", err)
+	}
+
+	writeTestFile(t, filepath.Join(tmpl, "template", ".claude", "skills", "zig-guide", "SKILL.md"), "# No frontmatter\n")
+	if _, err := LoadComponentPreview(tmpl, FindLanguage("zig")); err == nil {
+		t.Error("SKILL.md without frontmatter: expected an error")
+	}
+}
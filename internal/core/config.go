@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/ar4mirez/samuel/internal/ui"
 	"gopkg.in/yaml.v3"
 )
 
@@ -21,7 +23,25 @@ type Config struct {
 	Version   string         `yaml:"version"`
 	Installed InstalledItems `yaml:"installed"`
 	Registry  string         `yaml:"registry,omitempty"`
+	Channel   string         `yaml:"channel,omitempty"`
+	Language  string         `yaml:"language,omitempty"`
+	Overlay   *OverlayConfig `yaml:"overlay,omitempty"`
+	Trust     *TrustPolicy   `yaml:"trust,omitempty"`
+	Markdown  *MarkdownYAML  `yaml:"markdown,omitempty"`
+	Skills    *SkillsYAML    `yaml:"skills,omitempty"`
 	Auto      *AutoYAML      `yaml:"auto,omitempty"`
+	GC        *GCConfig      `yaml:"gc,omitempty"`
+	Theme     *ui.Theme      `yaml:"theme,omitempty"`
+
+	// Embedded is set when init installed the template built into the CLI
+	// because the registry was unreachable. 'samuel update' replaces it.
+	Embedded bool `yaml:"embedded,omitempty"`
+
+	// UpdateCheck turns the daily update notice off when false.
+	UpdateCheck *bool `yaml:"update_check,omitempty"`
+
+	// SkillSources records where imported skills came from, by skill name.
+	SkillSources map[string]SkillSource `yaml:"skill_sources,omitempty"`
 }
 
 // AutoYAML represents the auto loop configuration in samuel.yaml
@@ -30,6 +50,26 @@ type AutoYAML struct {
 	AITool        string   `yaml:"ai_tool,omitempty"`
 	MaxIterations int      `yaml:"max_iterations,omitempty"`
 	QualityChecks []string `yaml:"quality_checks,omitempty"`
+	Storage       string   `yaml:"storage,omitempty"`
+}
+
+// UpdateChecksEnabled reports whether commands may show an update notice.
+func (c *Config) UpdateChecksEnabled() bool {
+	return c.UpdateCheck == nil || *c.UpdateCheck
+}
+
+// MarkdownYAML controls how installed markdown files are written
+type MarkdownYAML struct {
+	FinalNewline string `yaml:"final_newline,omitempty"`
+}
+
+// FinalNewline returns the configured markdown final newline policy,
+// defaulting to FinalNewlinePreserve.
+func (c *Config) FinalNewline() string {
+	if c == nil || c.Markdown == nil || c.Markdown.FinalNewline == "" {
+		return FinalNewlinePreserve
+	}
+	return c.Markdown.FinalNewline
 }
 
 // InstalledItems tracks what components are installed
@@ -61,14 +101,9 @@ func LoadConfig() (*Config, error) {
 
 // LoadConfigFrom loads config from a specific directory
 func LoadConfigFrom(dir string) (*Config, error) {
-	// Try primary config file
-	configPath := filepath.Join(dir, ConfigFileName)
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		// Try alternative config file
-		configPath = filepath.Join(dir, AltConfigFileName)
-		if _, err := os.Stat(configPath); os.IsNotExist(err) {
-			return nil, os.ErrNotExist
-		}
+	configPath := ConfigFilePath(dir)
+	if configPath == "" {
+		return nil, os.ErrNotExist
 	}
 
 	data, err := os.ReadFile(configPath)
@@ -84,31 +119,39 @@ func LoadConfigFrom(dir string) (*Config, error) {
 	return &config, nil
 }
 
-// Save writes the config to the specified directory
+// Save writes the config to the specified directory crash-safely (see
+// writeFileAtomic). No backup is kept: the config is checked into the
+// project, so version control already holds its previous version.
 func (c *Config) Save(dir string) error {
 	configPath := filepath.Join(dir, ConfigFileName)
 
-	data, err := yaml.Marshal(c)
+	out := *c
+	out.Installed = c.Installed.normalized()
+	data, err := yaml.Marshal(&out)
 	if err != nil {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0644)
+	return writeFileAtomic(configPath, data, 0644, false)
 }
 
 // ConfigExists checks if a config file exists in the directory
 func ConfigExists(dir string) bool {
-	configPath := filepath.Join(dir, ConfigFileName)
-	if _, err := os.Stat(configPath); err == nil {
-		return true
-	}
-
-	altConfigPath := filepath.Join(dir, AltConfigFileName)
-	if _, err := os.Stat(altConfigPath); err == nil {
-		return true
+	return ConfigFilePath(dir) != ""
+}
+
+// ConfigFilePath returns the config file to read in dir: the primary
+// name, then the hidden name, then a deprecated pre-rename name. It
+// returns "" when there is none.
+func ConfigFilePath(dir string) string {
+	names := append([]string{ConfigFileName, AltConfigFileName}, legacyConfigFileNames()...)
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
 	}
-
-	return false
+	return ""
 }
 
 // HasLanguage checks if a language is installed
@@ -218,6 +261,7 @@ func (c *Config) RemoveWorkflow(name string) {
 // RemoveSkill removes a skill from the installed list
 func (c *Config) RemoveSkill(name string) {
 	c.Installed.Skills = removeFromSlice(c.Installed.Skills, name)
+	delete(c.SkillSources, name)
 }
 
 func removeFromSlice(slice []string, item string) []string {
@@ -321,6 +365,17 @@ func EnsureCacheDir() (string, error) {
 var ValidConfigKeys = []string{
 	"version",
 	"registry",
+	"channel",
+	"language",
+	"update_check",
+	"overlay.registry",
+	"overlay.branch",
+	"trust.allowed_owners",
+	"trust.signing_key",
+	"markdown.final_newline",
+	"skills.order",
+	"skills.compact",
+	"skills.compact_budget",
 	"installed.languages",
 	"installed.frameworks",
 	"installed.workflows",
@@ -329,6 +384,7 @@ var ValidConfigKeys = []string{
 	"auto.ai_tool",
 	"auto.max_iterations",
 	"auto.quality_checks",
+	"auto.storage",
 }
 
 // GetValue retrieves a configuration value by key
@@ -341,6 +397,46 @@ func (c *Config) GetValue(key string) (any, error) {
 			return DefaultRegistry, nil
 		}
 		return c.Registry, nil
+	case "channel":
+		if c.Channel == "" {
+			return ChannelStable, nil
+		}
+		return c.Channel, nil
+	case "language":
+		if c.Language == "" {
+			return DefaultLanguage, nil
+		}
+		return c.Language, nil
+	case "update_check":
+		return c.UpdateChecksEnabled(), nil
+	case "overlay.registry":
+		if c.Overlay != nil {
+			return c.Overlay.Registry, nil
+		}
+		return "", nil
+	case "overlay.branch":
+		if c.Overlay != nil {
+			return c.Overlay.Branch, nil
+		}
+		return "", nil
+	case "trust.allowed_owners":
+		if c.Trust != nil {
+			return c.Trust.AllowedOwners, nil
+		}
+		return []string{}, nil
+	case "trust.signing_key":
+		if c.Trust != nil {
+			return c.Trust.SigningKey, nil
+		}
+		return "", nil
+	case "markdown.final_newline":
+		return c.FinalNewline(), nil
+	case "skills.order":
+		return c.SkillsOrder(), nil
+	case "skills.compact":
+		return c.CompactDigestEnabled(), nil
+	case "skills.compact_budget":
+		return c.CompactBudget(), nil
 	case "installed.languages":
 		return c.Installed.Languages, nil
 	case "installed.frameworks":
@@ -366,6 +462,11 @@ func (c *Config) GetValue(key string) (any, error) {
 			return c.Auto.QualityChecks, nil
 		}
 		return []string{}, nil
+	case "auto.storage":
+		if c.Auto != nil && c.Auto.Storage != "" {
+			return c.Auto.Storage, nil
+		}
+		return StorageFile, nil
 	default:
 		return nil, fmt.Errorf("unknown config key: %s", key)
 	}
@@ -378,6 +479,64 @@ func (c *Config) SetValue(key, value string) error {
 		c.Version = value
 	case "registry":
 		c.Registry = value
+	case "channel":
+		if err := ValidateChannel(value); err != nil {
+			return err
+		}
+		c.Channel = value
+	case "language":
+		if err := ValidateLanguage(value); err != nil {
+			return err
+		}
+		c.Language = value
+	case "update_check":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid update_check: %q (expected true or false)", value)
+		}
+		c.UpdateCheck = &enabled
+	case "overlay.registry":
+		c.setOverlay(func(o *OverlayConfig) { o.Registry = value })
+	case "overlay.branch":
+		c.setOverlay(func(o *OverlayConfig) { o.Branch = value })
+	case "trust.allowed_owners":
+		c.setTrust(func(t *TrustPolicy) { t.AllowedOwners = splitAndTrim(value) })
+	case "trust.signing_key":
+		if value != "" && !strings.HasPrefix(value, "SHA256:") {
+			return fmt.Errorf("invalid trust.signing_key: %q (expected an SSH key fingerprint, SHA256:...)", value)
+		}
+		c.setTrust(func(t *TrustPolicy) { t.SigningKey = value })
+	case "markdown.final_newline":
+		if err := ValidateFinalNewline(value); err != nil {
+			return err
+		}
+		c.Markdown = &MarkdownYAML{FinalNewline: value}
+	case "skills.order":
+		if err := ValidateSkillsOrder(value); err != nil {
+			return err
+		}
+		if c.Skills == nil {
+			c.Skills = &SkillsYAML{}
+		}
+		c.Skills.Order = value
+	case "skills.compact":
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid skills.compact: %q (expected true or false)", value)
+		}
+		if c.Skills == nil {
+			c.Skills = &SkillsYAML{}
+		}
+		c.Skills.Compact = enabled
+	case "skills.compact_budget":
+		budget, err := ParseCompactBudget(value)
+		if err != nil {
+			return err
+		}
+		if c.Skills == nil {
+			c.Skills = &SkillsYAML{}
+		}
+		c.Skills.CompactBudget = budget
 	case "installed.languages":
 		c.Installed.Languages = splitAndTrim(value)
 	case "installed.frameworks":
@@ -386,25 +545,84 @@ func (c *Config) SetValue(key, value string) error {
 		c.Installed.Workflows = splitAndTrim(value)
 	case "installed.skills":
 		c.Installed.Skills = splitAndTrim(value)
+	case "auto.storage":
+		if err := ValidateStorageBackend(value); err != nil {
+			return err
+		}
+		if c.Auto == nil {
+			c.Auto = &AutoYAML{}
+		}
+		c.Auto.Storage = value
 	default:
 		return fmt.Errorf("unknown config key: %s", key)
 	}
 	return nil
 }
 
+// setOverlay edits the overlay config, creating it when needed and
+// dropping it once it is empty.
+func (c *Config) setOverlay(edit func(o *OverlayConfig)) {
+	if c.Overlay == nil {
+		c.Overlay = &OverlayConfig{}
+	}
+	edit(c.Overlay)
+	if *c.Overlay == (OverlayConfig{}) {
+		c.Overlay = nil
+	}
+}
+
+// setTrust edits the trust policy, creating it when needed and dropping
+// it once it is empty.
+func (c *Config) setTrust(edit func(t *TrustPolicy)) {
+	if c.Trust == nil {
+		c.Trust = &TrustPolicy{}
+	}
+	edit(c.Trust)
+	if len(c.Trust.AllowedOwners) == 0 && c.Trust.SigningKey == "" && len(c.Trust.Pinned) == 0 {
+		c.Trust = nil
+	}
+}
+
 // GetAllValues returns all config values as a map
 func (c *Config) GetAllValues() map[string]any {
 	registry := c.Registry
 	if registry == "" {
 		registry = DefaultRegistry
 	}
+	channel := c.Channel
+	if channel == "" {
+		channel = ChannelStable
+	}
+	language := c.Language
+	if language == "" {
+		language = DefaultLanguage
+	}
+	overlay := OverlayConfig{}
+	if c.Overlay != nil {
+		overlay = *c.Overlay
+	}
+	trust := TrustPolicy{AllowedOwners: []string{}}
+	if c.Trust != nil {
+		trust = *c.Trust
+	}
 	return map[string]any{
-		"version":              c.Version,
-		"registry":             registry,
-		"installed.languages":  c.Installed.Languages,
-		"installed.frameworks": c.Installed.Frameworks,
-		"installed.workflows":  c.Installed.Workflows,
-		"installed.skills":     c.Installed.Skills,
+		"version":                c.Version,
+		"registry":               registry,
+		"channel":                channel,
+		"language":               language,
+		"update_check":           c.UpdateChecksEnabled(),
+		"overlay.registry":       overlay.Registry,
+		"overlay.branch":         overlay.Branch,
+		"trust.allowed_owners":   trust.AllowedOwners,
+		"trust.signing_key":      trust.SigningKey,
+		"markdown.final_newline": c.FinalNewline(),
+		"skills.order":           c.SkillsOrder(),
+		"skills.compact":         c.CompactDigestEnabled(),
+		"skills.compact_budget":  c.CompactBudget(),
+		"installed.languages":    c.Installed.Languages,
+		"installed.frameworks":   c.Installed.Frameworks,
+		"installed.workflows":    c.Installed.Workflows,
+		"installed.skills":       c.Installed.Skills,
 	}
 }
 
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigDefaultsFile holds the samuel.yaml defaults of a release, stored
+// under the template/ directory of the release archive.
+const ConfigDefaultsFile = "samuel.defaults.yaml"
+
+// Config merge strategies for conflicts between a user's customized value
+// and a changed upstream default.
+const (
+	ConfigStrategyPrompt   = "prompt"
+	ConfigStrategyMine     = "mine"
+	ConfigStrategyUpstream = "upstream"
+)
+
+// GetSupportedConfigStrategies returns the accepted --config-strategy values.
+func GetSupportedConfigStrategies() []string {
+	return []string{ConfigStrategyPrompt, ConfigStrategyMine, ConfigStrategyUpstream}
+}
+
+// MergeableConfigKeys are the settings whose defaults an update can change.
+// Installed languages, frameworks, and skills are always the user's choice.
+var MergeableConfigKeys = []string{
+	"registry",
+	"installed.workflows",
+	"auto.ai_tool",
+	"auto.max_iterations",
+	"auto.quality_checks",
+}
+
+// ConfigConflict is a setting the user customized whose upstream default
+// also changed.
+type ConfigConflict struct {
+	Key      string
+	Base     string
+	Mine     string
+	Upstream string
+}
+
+// ConfigMerge is the result of merging changed upstream defaults into a
+// project config.
+type ConfigMerge struct {
+	// Applied lists keys that took the new default because the project
+	// still used the old one.
+	Applied   []string
+	Conflicts []ConfigConflict
+}
+
+// LoadConfigDefaults reads the config defaults shipped in a template
+// directory. It returns the built-in defaults when the template has none.
+func LoadConfigDefaults(templatePath string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Join(templatePath, TemplatePrefix, ConfigDefaultsFile))
+	if os.IsNotExist(err) {
+		return NewConfig(""), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var defaults Config
+	if err := yaml.Unmarshal(data, &defaults); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ConfigDefaultsFile, err)
+	}
+	return &defaults, nil
+}
+
+// LoadReleaseConfigDefaults returns the config defaults of a release from
+// the download cache, or the built-in defaults if it is not cached.
+func LoadReleaseConfigDefaults(version string) (*Config, error) {
+	cachePath, err := GetCachePath()
+	if err != nil {
+		return NewConfig(""), nil
+	}
+	return LoadConfigDefaults(filepath.Join(cachePath, fmt.Sprintf("samuel-%s", version)))
+}
+
+// MergeConfigDefaults is a three-way merge of the mergeable settings: when
+// a default changed between base and upstream, mine takes the new value if
+// it still had the old one, and the key is reported as a conflict if it
+// was customized to something else.
+func MergeConfigDefaults(mine, base, upstream *Config) ConfigMerge {
+	var merge ConfigMerge
+	for _, key := range MergeableConfigKeys {
+		b, m, u := mergeValue(base, key), mergeValue(mine, key), mergeValue(upstream, key)
+		switch {
+		case u == b || m == u:
+		case m == b:
+			_ = mine.SetMergeValue(key, u)
+			merge.Applied = append(merge.Applied, key)
+		default:
+			merge.Conflicts = append(merge.Conflicts, ConfigConflict{Key: key, Base: b, Mine: m, Upstream: u})
+		}
+	}
+	return merge
+}
+
+// mergeValue formats a setting for comparison and display; lists are
+// comma-separated, as accepted by SetMergeValue.
+func mergeValue(c *Config, key string) string {
+	value, err := c.GetValue(key)
+	if err != nil {
+		return ""
+	}
+	if list, ok := value.([]string); ok {
+		return strings.Join(list, ", ")
+	}
+	return fmt.Sprint(value)
+}
+
+// SetMergeValue sets one of MergeableConfigKeys from its string form.
+func (c *Config) SetMergeValue(key, value string) error {
+	if !slices.Contains(MergeableConfigKeys, key) {
+		return fmt.Errorf("unsupported merge key: %s (supported: %v)", key, MergeableConfigKeys)
+	}
+	if !strings.HasPrefix(key, "auto.") {
+		return c.SetValue(key, value)
+	}
+	if c.Auto == nil {
+		c.Auto = &AutoYAML{}
+	}
+	switch key {
+	case "auto.ai_tool":
+		c.Auto.AITool = value
+	case "auto.max_iterations":
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid auto.max_iterations: %q", value)
+		}
+		c.Auto.MaxIterations = n
+	case "auto.quality_checks":
+		c.Auto.QualityChecks = splitAndTrim(value)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestMergeConfigDefaults(t *testing.T) {
+	newDefaults := func(workflows []string, maxIter int) *Config {
+		c := NewConfig("")
+		c.Installed.Workflows = workflows
+		if maxIter > 0 {
+			c.Auto = &AutoYAML{MaxIterations: maxIter}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name          string
+		mine          *Config
+		base          *Config
+		upstream      *Config
+		wantApplied   []string
+		wantConflicts []string
+		wantWorkflows []string
+	}{
+		{
+			name:          "unchanged_defaults",
+			mine:          newDefaults([]string{"create-prd"}, 0),
+			base:          newDefaults([]string{"all"}, 0),
+			upstream:      newDefaults([]string{"all"}, 0),
+			wantWorkflows: []string{"create-prd"},
+		},
+		{
+			name:          "default_taken_when_not_customized",
+			mine:          newDefaults([]string{"create-prd"}, 0),
+			base:          newDefaults([]string{"create-prd"}, 0),
+			upstream:      newDefaults([]string{"create-prd", "code-review"}, 0),
+			wantApplied:   []string{"installed.workflows"},
+			wantWorkflows: []string{"create-prd", "code-review"},
+		},
+		{
+			name:          "customized_value_conflicts",
+			mine:          newDefaults([]string{"security-audit"}, 10),
+			base:          newDefaults([]string{"create-prd"}, 0),
+			upstream:      newDefaults([]string{"create-prd", "code-review"}, 50),
+			wantConflicts: []string{"installed.workflows", "auto.max_iterations"},
+			wantWorkflows: []string{"security-audit"},
+		},
+		{
+			name:          "already_matches_upstream",
+			mine:          newDefaults([]string{"create-prd", "code-review"}, 0),
+			base:          newDefaults([]string{"create-prd"}, 0),
+			upstream:      newDefaults([]string{"create-prd", "code-review"}, 0),
+			wantWorkflows: []string{"create-prd", "code-review"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merge := MergeConfigDefaults(tt.mine, tt.base, tt.upstream)
+			if !slices.Equal(merge.Applied, tt.wantApplied) {
+				t.Errorf("Applied = %v, want %v", merge.Applied, tt.wantApplied)
+			}
+			var conflicts []string
+			for _, c := range merge.Conflicts {
+				conflicts = append(conflicts, c.Key)
+			}
+			if !slices.Equal(conflicts, tt.wantConflicts) {
+				t.Errorf("Conflicts = %v, want %v", conflicts, tt.wantConflicts)
+			}
+			if !slices.Equal(tt.mine.Installed.Workflows, tt.wantWorkflows) {
+				t.Errorf("Workflows = %v, want %v", tt.mine.Installed.Workflows, tt.wantWorkflows)
+			}
+		})
+	}
+}
+
+func TestSetMergeValue(t *testing.T) {
+	c := NewConfig("1.0.0")
+	if err := c.SetMergeValue("auto.max_iterations", "40"); err != nil {
+		t.Fatalf("SetMergeValue: %v", err)
+	}
+	if err := c.SetMergeValue("auto.quality_checks", "go test ./..., go vet ./..."); err != nil {
+		t.Fatalf("SetMergeValue: %v", err)
+	}
+	if c.Auto.MaxIterations != 40 || len(c.Auto.QualityChecks) != 2 {
+		t.Errorf("Auto = %+v, want 40 iterations and 2 checks", c.Auto)
+	}
+	if err := c.SetMergeValue("auto.max_iterations", "many"); err == nil {
+		t.Error("expected error for non-numeric max_iterations")
+	}
+	if err := c.SetMergeValue("installed.languages", "go"); err == nil {
+		t.Error("expected error for non-mergeable key")
+	}
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	dir := t.TempDir()
+	defaults, err := LoadConfigDefaults(dir)
+	if err != nil {
+		t.Fatalf("LoadConfigDefaults without file: %v", err)
+	}
+	if !slices.Equal(defaults.Installed.Workflows, []string{"all"}) {
+		t.Errorf("built-in workflows = %v, want [all]", defaults.Installed.Workflows)
+	}
+
+	createTemplateFile(t, dir, ConfigDefaultsFile, "installed:\n  workflows: [create-prd]\n")
+	defaults, err = LoadConfigDefaults(dir)
+	if err != nil {
+		t.Fatalf("LoadConfigDefaults: %v", err)
+	}
+	if !slices.Equal(defaults.Installed.Workflows, []string{"create-prd"}) {
+		t.Errorf("workflows = %v, want [create-prd]", defaults.Installed.Workflows)
+	}
+
+	createTemplateFile(t, dir, ConfigDefaultsFile, "{{bad")
+	if _, err := LoadConfigDefaults(dir); err == nil {
+		t.Error("expected error for invalid defaults file")
+	}
+}
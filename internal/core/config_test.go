@@ -97,6 +97,122 @@ func TestConfig_SetValue(t *testing.T) {
 			wantErr: false,
 			check:   func(c *Config) bool { return c.Registry == "https://new.example.com" },
 		},
+		{
+			key:     "channel",
+			value:   "beta",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.Channel == ChannelBeta },
+		},
+		{
+			key:     "channel",
+			value:   "nightly",
+			wantErr: true,
+		},
+		{
+			key:     "auto.storage",
+			value:   "sqlite",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.Auto != nil && c.Auto.Storage == StorageSQLite },
+		},
+		{
+			key:     "auto.storage",
+			value:   "postgres",
+			wantErr: true,
+		},
+		{
+			key:     "overlay.registry",
+			value:   "https://github.com/acme/samuel-overlay",
+			wantErr: false,
+			check: func(c *Config) bool {
+				return c.Overlay != nil && c.Overlay.Registry == "https://github.com/acme/samuel-overlay"
+			},
+		},
+		{
+			key:     "overlay.branch",
+			value:   "stable",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.Overlay != nil && c.Overlay.Branch == "stable" },
+		},
+		{
+			key:     "trust.allowed_owners",
+			value:   "acme, acme-labs",
+			wantErr: false,
+			check: func(c *Config) bool {
+				return c.Trust != nil && len(c.Trust.AllowedOwners) == 2 && c.Trust.AllowedOwners[1] == "acme-labs"
+			},
+		},
+		{
+			key:     "trust.signing_key",
+			value:   "SHA256:abc",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.Trust != nil && c.Trust.SigningKey == "SHA256:abc" },
+		},
+		{
+			key:     "trust.signing_key",
+			value:   "ABCD1234",
+			wantErr: true,
+		},
+		{
+			key:     "markdown.final_newline",
+			value:   "ensure",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.FinalNewline() == FinalNewlineEnsure },
+		},
+		{
+			key:     "markdown.final_newline",
+			value:   "always",
+			wantErr: true,
+		},
+		{
+			key:     "skills.order",
+			value:   "relevance",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.SkillsOrder() == SkillsOrderRelevance },
+		},
+		{
+			key:     "skills.order",
+			value:   "size",
+			wantErr: true,
+		},
+		{
+			key:     "skills.compact",
+			value:   "true",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.CompactDigestEnabled() && c.CompactBudget() == DefaultCompactBudget },
+		},
+		{
+			key:     "skills.compact_budget",
+			value:   "800",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.CompactBudget() == 800 },
+		},
+		{
+			key:     "skills.compact_budget",
+			value:   "50",
+			wantErr: true,
+		},
+		{
+			key:     "language",
+			value:   "pt-BR",
+			wantErr: false,
+			check:   func(c *Config) bool { return c.Language == "pt-BR" },
+		},
+		{
+			key:     "language",
+			value:   "Spanish",
+			wantErr: true,
+		},
+		{
+			key:     "update_check",
+			value:   "false",
+			wantErr: false,
+			check:   func(c *Config) bool { return !c.UpdateChecksEnabled() },
+		},
+		{
+			key:     "update_check",
+			value:   "sometimes",
+			wantErr: true,
+		},
 		{
 			key:     "installed.languages",
 			value:   "go,python,rust",
@@ -188,6 +304,17 @@ func TestValidConfigKeys(t *testing.T) {
 	expectedKeys := []string{
 		"version",
 		"registry",
+		"channel",
+		"language",
+		"update_check",
+		"overlay.registry",
+		"overlay.branch",
+		"trust.allowed_owners",
+		"trust.signing_key",
+		"markdown.final_newline",
+		"skills.order",
+		"skills.compact",
+		"skills.compact_budget",
 		"installed.languages",
 		"installed.frameworks",
 		"installed.workflows",
@@ -196,6 +323,7 @@ func TestValidConfigKeys(t *testing.T) {
 		"auto.ai_tool",
 		"auto.max_iterations",
 		"auto.quality_checks",
+		"auto.storage",
 	}
 
 	if len(ValidConfigKeys) != len(expectedKeys) {
@@ -734,3 +862,13 @@ registry: "https://example.com"
 		t.Errorf("config.Version = %q, want %q", config.Version, "2.0.0")
 	}
 }
+
+func TestSetValue_ClearingOverlayRemovesIt(t *testing.T) {
+	cfg := &Config{Overlay: &OverlayConfig{Registry: "https://github.com/acme/overlay"}}
+	if err := cfg.SetValue("overlay.registry", ""); err != nil {
+		t.Fatalf("SetValue returned error: %v", err)
+	}
+	if cfg.Overlay != nil {
+		t.Errorf("Overlay = %+v, want nil once cleared", cfg.Overlay)
+	}
+}
@@ -0,0 +1,207 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Markers delimiting a named framework block in CLAUDE.md and AGENTS.md.
+// Content between a BEGIN/END pair is owned by samuel and refreshed by
+// `samuel update core`; everything outside the blocks belongs to the user.
+const (
+	CoreBlockBeginPrefix = "<!-- SAMUEL:BEGIN "
+	CoreBlockEndPrefix   = "<!-- SAMUEL:END "
+	coreBlockSuffix      = " -->"
+)
+
+// Actions planned for a core file by PlanCoreUpdate.
+const (
+	CoreActionCreate    = "create"
+	CoreActionMerge     = "merge"
+	CoreActionReplace   = "replace"
+	CoreActionSkip      = "skip"
+	CoreActionUnchanged = "unchanged"
+)
+
+// CoreFileUpdate describes the planned update of one core file.
+type CoreFileUpdate struct {
+	Path    string
+	Action  string
+	Blocks  []string // managed blocks whose content changed (merge only)
+	Reason  string   // why the file is skipped
+	content []byte
+}
+
+// coreBlock locates the body of a named managed block: start is the
+// offset just after the BEGIN line and end the offset of the END marker.
+type coreBlock struct {
+	name  string
+	start int
+	end   int
+}
+
+// findCoreBlocks returns the well-formed managed blocks in content, in
+// document order. A BEGIN marker without a matching END is ignored.
+func findCoreBlocks(content string) []coreBlock {
+	var blocks []coreBlock
+	offset := 0
+	for {
+		idx := strings.Index(content[offset:], CoreBlockBeginPrefix)
+		if idx == -1 {
+			return blocks
+		}
+		lineStart := offset + idx
+		lineEnd := strings.Index(content[lineStart:], coreBlockSuffix)
+		if lineEnd == -1 {
+			return blocks
+		}
+		name := content[lineStart+len(CoreBlockBeginPrefix) : lineStart+lineEnd]
+		bodyStart := lineStart + lineEnd + len(coreBlockSuffix)
+		if strings.HasPrefix(content[bodyStart:], "\n") {
+			bodyStart++
+		}
+		endMarker := CoreBlockEndPrefix + name + coreBlockSuffix
+		endIdx := strings.Index(content[bodyStart:], endMarker)
+		if endIdx == -1 {
+			offset = bodyStart
+			continue
+		}
+		blocks = append(blocks, coreBlock{name: name, start: bodyStart, end: bodyStart + endIdx})
+		offset = bodyStart + endIdx + len(endMarker)
+	}
+}
+
+// MergeCoreBlocks replaces the body of every managed block in local with
+// the body of the same-named block in upstream, leaving all other local
+// content untouched. It returns the merged document and the names of the
+// blocks whose content changed. Blocks that exist on only one side are
+// left as they are.
+func MergeCoreBlocks(local, upstream string) (string, []string) {
+	bodies := make(map[string]string)
+	for _, b := range findCoreBlocks(upstream) {
+		bodies[b.name] = upstream[b.start:b.end]
+	}
+	return replaceCoreBlocks(local, bodies)
+}
+
+// replaceCoreBlocks sets the body of each managed block in local that has
+// an entry in bodies, returning the result and the changed blocks.
+func replaceCoreBlocks(local string, bodies map[string]string) (string, []string) {
+	var sb strings.Builder
+	var changed []string
+	last := 0
+	for _, b := range findCoreBlocks(local) {
+		body, ok := bodies[b.name]
+		if !ok || body == local[b.start:b.end] {
+			continue
+		}
+		sb.WriteString(local[last:b.start])
+		sb.WriteString(body)
+		last = b.end
+		changed = append(changed, b.name)
+	}
+	sb.WriteString(local[last:])
+	return sb.String(), changed
+}
+
+// keepSkillsBlock carries the generated skills block from local into
+// upstream so replacing a whole file does not drop the installed skills.
+func keepSkillsBlock(local, upstream string) string {
+	localBlock, ok := findSkillsBlock(local)
+	if !ok {
+		return upstream
+	}
+	upstreamBlock, ok := findSkillsBlock(upstream)
+	if !ok {
+		return upstream
+	}
+	return upstream[:upstreamBlock.start] +
+		local[localBlock.start:localBlock.end] +
+		upstream[upstreamBlock.end:]
+}
+
+// PlanCoreUpdate compares the core files in projectDir with those of the
+// template at templatePath, with the managed blocks translated into
+// language. Files with managed blocks are merged block by block; files
+// without them are only replaced when force is set. Instruction files
+// replaced wholesale keep their local skills block.
+func PlanCoreUpdate(projectDir, templatePath, language string, force bool) ([]CoreFileUpdate, error) {
+	var plan []CoreFileUpdate
+	for _, path := range CoreFiles {
+		upstream, err := os.ReadFile(filepath.Join(templatePath, TemplatePrefix, path))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", path, err)
+		}
+		localized, err := LocalizeCoreBlocks(string(upstream), templatePath, language)
+		if err != nil {
+			return nil, err
+		}
+		upstream = []byte(localized)
+
+		local, err := os.ReadFile(filepath.Join(projectDir, path))
+		if os.IsNotExist(err) {
+			plan = append(plan, CoreFileUpdate{Path: path, Action: CoreActionCreate, content: upstream})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		plan = append(plan, planCoreFile(path, string(local), string(upstream), force))
+	}
+	return plan, nil
+}
+
+// planCoreFile decides how a single existing core file is updated.
+func planCoreFile(path, local, upstream string, force bool) CoreFileUpdate {
+	update := CoreFileUpdate{Path: path}
+	if local == upstream {
+		update.Action = CoreActionUnchanged
+		return update
+	}
+
+	if len(findCoreBlocks(local)) > 0 && !force {
+		merged, changed := MergeCoreBlocks(local, upstream)
+		update.Action, update.Blocks = CoreActionMerge, changed
+		if len(changed) == 0 {
+			update.Action = CoreActionUnchanged
+		}
+		update.content = []byte(merged)
+		return update
+	}
+
+	if !force && len(findCoreBlocks(upstream)) > 0 {
+		update.Action = CoreActionSkip
+		update.Reason = "no managed blocks; use --force to replace"
+		return update
+	}
+
+	update.Action = CoreActionReplace
+	update.content = []byte(keepSkillsBlock(local, upstream))
+	return update
+}
+
+// ApplyCoreUpdate writes the planned content of every created, merged or
+// replaced core file into projectDir.
+func ApplyCoreUpdate(projectDir string, plan []CoreFileUpdate) error {
+	for _, u := range plan {
+		switch u.Action {
+		case CoreActionCreate, CoreActionMerge, CoreActionReplace:
+		default:
+			continue
+		}
+		dst := filepath.Join(projectDir, u.Path)
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", u.Path, err)
+		}
+		if err := os.WriteFile(dst, u.content, 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", u.Path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func coreDoc(custom, guardrails, stuck string) string {
+	return "# CLAUDE.md\n\n" + custom + "\n\n" +
+		"<!-- SAMUEL:BEGIN guardrails -->\n" + guardrails + "\n<!-- SAMUEL:END guardrails -->\n\n" +
+		"<!-- SKILLS_START -->\nskills\n<!-- SKILLS_END -->\n\n" +
+		"<!-- SAMUEL:BEGIN when-stuck -->\n" + stuck + "\n<!-- SAMUEL:END when-stuck -->\n"
+}
+
+func TestMergeCoreBlocks(t *testing.T) {
+	tests := []struct {
+		name        string
+		local       string
+		upstream    string
+		want        string
+		wantChanged []string
+	}{
+		{
+			name:        "replaces changed blocks and keeps custom content",
+			local:       coreDoc("my notes", "old rules", "old stuck"),
+			upstream:    coreDoc("template notes", "new rules", "new stuck"),
+			want:        coreDoc("my notes", "new rules", "new stuck"),
+			wantChanged: []string{"guardrails", "when-stuck"},
+		},
+		{
+			name:        "reports only blocks that differ",
+			local:       coreDoc("my notes", "rules", "old stuck"),
+			upstream:    coreDoc("template notes", "rules", "new stuck"),
+			want:        coreDoc("my notes", "rules", "new stuck"),
+			wantChanged: []string{"when-stuck"},
+		},
+		{
+			name:     "keeps local blocks missing upstream",
+			local:    "<!-- SAMUEL:BEGIN extra -->\nmine\n<!-- SAMUEL:END extra -->\n",
+			upstream: "<!-- SAMUEL:BEGIN other -->\ntheirs\n<!-- SAMUEL:END other -->\n",
+			want:     "<!-- SAMUEL:BEGIN extra -->\nmine\n<!-- SAMUEL:END extra -->\n",
+		},
+		{
+			name:     "ignores unterminated blocks",
+			local:    "<!-- SAMUEL:BEGIN guardrails -->\nmine\n",
+			upstream: "<!-- SAMUEL:BEGIN guardrails -->\ntheirs\n<!-- SAMUEL:END guardrails -->\n",
+			want:     "<!-- SAMUEL:BEGIN guardrails -->\nmine\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := MergeCoreBlocks(tt.local, tt.upstream)
+			if got != tt.want {
+				t.Errorf("MergeCoreBlocks() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if !reflect.DeepEqual(changed, tt.wantChanged) {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+		})
+	}
+}
+
+func TestPlanCoreUpdate(t *testing.T) {
+	upstream := coreDoc("template notes", "new rules", "stuck")
+	tests := []struct {
+		name       string
+		local      map[string]string
+		force      bool
+		wantAction map[string]string
+	}{
+		{
+			name:  "merges blocks and creates missing files",
+			local: map[string]string{"CLAUDE.md": coreDoc("mine", "old rules", "stuck")},
+			wantAction: map[string]string{
+				"CLAUDE.md":                CoreActionMerge,
+				"AGENTS.md":                CoreActionCreate,
+				".claude/skills/README.md": CoreActionCreate,
+			},
+		},
+		{
+			name: "skips files without blocks unless forced",
+			local: map[string]string{
+				"CLAUDE.md":                "# legacy\n",
+				"AGENTS.md":                coreDoc("mine", "new rules", "stuck"),
+				".claude/skills/README.md": "readme v1\n",
+			},
+			wantAction: map[string]string{
+				"CLAUDE.md":                CoreActionSkip,
+				"AGENTS.md":                CoreActionUnchanged,
+				".claude/skills/README.md": CoreActionReplace,
+			},
+		},
+		{
+			name: "force replaces whole files",
+			local: map[string]string{
+				"CLAUDE.md":                "# legacy\n",
+				"AGENTS.md":                coreDoc("mine", "old rules", "stuck"),
+				".claude/skills/README.md": "readme v2\n",
+			},
+			force: true,
+			wantAction: map[string]string{
+				"CLAUDE.md":                CoreActionReplace,
+				"AGENTS.md":                CoreActionReplace,
+				".claude/skills/README.md": CoreActionUnchanged,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmplDir, projectDir := t.TempDir(), t.TempDir()
+			createTemplateFile(t, tmplDir, "CLAUDE.md", upstream)
+			createTemplateFile(t, tmplDir, "AGENTS.md", upstream)
+			createTemplateFile(t, tmplDir, ".claude/skills/README.md", "readme v2\n")
+			for path, content := range tt.local {
+				writeTestFile(t, filepath.Join(projectDir, path), content)
+			}
+
+			plan, err := PlanCoreUpdate(projectDir, tmplDir, "", tt.force)
+			if err != nil {
+				t.Fatalf("PlanCoreUpdate() error: %v", err)
+			}
+			got := make(map[string]string)
+			for _, u := range plan {
+				got[u.Path] = u.Action
+			}
+			if !reflect.DeepEqual(got, tt.wantAction) {
+				t.Errorf("actions = %v, want %v", got, tt.wantAction)
+			}
+		})
+	}
+}
+
+func TestApplyCoreUpdate(t *testing.T) {
+	tmplDir, projectDir := t.TempDir(), t.TempDir()
+	upstream := strings.Replace(coreDoc("template", "new rules", "stuck"), "skills\n", "template skills\n", 1)
+	createTemplateFile(t, tmplDir, "CLAUDE.md", upstream)
+	createTemplateFile(t, tmplDir, "AGENTS.md", upstream)
+	writeTestFile(t, filepath.Join(projectDir, "CLAUDE.md"), coreDoc("mine", "old rules", "stuck"))
+	writeTestFile(t, filepath.Join(projectDir, "AGENTS.md"), "# legacy\n<!-- SKILLS_START -->\nskills\n<!-- SKILLS_END -->\n")
+	writeTestFile(t, filepath.Join(projectDir, ".claude/skills/go-guide/SKILL.md"), "untouched")
+
+	plan, err := PlanCoreUpdate(projectDir, tmplDir, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyCoreUpdate(projectDir, plan); err != nil {
+		t.Fatalf("ApplyCoreUpdate() error: %v", err)
+	}
+
+	claude, _ := os.ReadFile(filepath.Join(projectDir, "CLAUDE.md"))
+	if string(claude) != coreDoc("mine", "new rules", "stuck") {
+		t.Errorf("CLAUDE.md not merged:\n%s", claude)
+	}
+	agents, _ := os.ReadFile(filepath.Join(projectDir, "AGENTS.md"))
+	if string(agents) != "# legacy\n<!-- SKILLS_START -->\nskills\n<!-- SKILLS_END -->\n" {
+		t.Errorf("skipped AGENTS.md was modified:\n%s", agents)
+	}
+	skill, _ := os.ReadFile(filepath.Join(projectDir, ".claude/skills/go-guide/SKILL.md"))
+	if string(skill) != "untouched" {
+		t.Errorf("skill file was modified: %s", skill)
+	}
+
+	// Forcing replaces AGENTS.md but keeps its installed skills list.
+	plan, err = PlanCoreUpdate(projectDir, tmplDir, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyCoreUpdate(projectDir, plan); err != nil {
+		t.Fatal(err)
+	}
+	agents, _ = os.ReadFile(filepath.Join(projectDir, "AGENTS.md"))
+	if string(agents) != coreDoc("template", "new rules", "stuck") {
+		t.Errorf("forced AGENTS.md should be upstream with local skills:\n%s", agents)
+	}
+}
@@ -0,0 +1,215 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ar4mirez/samuel/template"
+)
+
+// DeinitBackupPrefix names the directory deinit saves the user's own
+// skills and instructions to, followed by a timestamp.
+const DeinitBackupPrefix = "samuel-deinit-backup-"
+
+// deinitGenerated lists the files and directories samuel writes besides
+// the components, relative to the project.
+var deinitGenerated = []string{
+	CompactAgentsFile,
+	AutoDir,
+	UpdateNotesDir,
+	PolicyAuditFile,
+	".claude/.samuel-index.json",
+}
+
+// DeinitPlan lists what removing samuel from a project deletes and what
+// belongs to the user. Paths are relative to the project, with forward
+// slashes.
+type DeinitPlan struct {
+	// Remove lists the files and directories samuel installed or
+	// generated. The config file comes last.
+	Remove []string
+	// UserSkills lists the skill directories that did not come from the
+	// registry: created, imported, or variants.
+	UserSkills []string
+	// Customized lists the instruction files with content of the user's
+	// outside the managed blocks.
+	Customized []string
+	// Kept lists what is left in place: folder instructions the user took
+	// over and the backups of 'samuel update'.
+	Kept []string
+}
+
+// PlanDeinit works out what removing samuel from projectDir deletes.
+// Samuel keeps no list of the files it writes, so the plan is derived
+// from the registry, the files samuel generates, and the markers in
+// generated content.
+func PlanDeinit(projectDir string) (*DeinitPlan, error) {
+	plan := &DeinitPlan{}
+	for _, path := range CoreFiles {
+		local, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(path)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		plan.Remove = append(plan.Remove, path)
+		if path != ".claude/skills/README.md" && isCustomizedCoreFile(path, string(local)) {
+			plan.Customized = append(plan.Customized, path)
+		}
+	}
+	if err := planDeinitSkills(projectDir, plan); err != nil {
+		return nil, err
+	}
+	for _, path := range deinitGenerated {
+		if _, err := os.Stat(filepath.Join(projectDir, filepath.FromSlash(path))); err == nil {
+			plan.Remove = append(plan.Remove, path)
+		}
+	}
+	if err := planDeinitFolderFiles(projectDir, plan); err != nil {
+		return nil, err
+	}
+	entries, _ := os.ReadDir(projectDir)
+	for _, e := range entries {
+		if e.IsDir() && strings.HasPrefix(e.Name(), BackupDirPrefix) {
+			plan.Kept = append(plan.Kept, e.Name())
+		}
+	}
+	if config := ConfigFilePath(projectDir); config != "" {
+		plan.Remove = append(plan.Remove, filepath.Base(config))
+	}
+	return plan, nil
+}
+
+// planDeinitSkills sorts the skill directories into registry skills,
+// which are removed, and the user's own.
+func planDeinitSkills(projectDir string, plan *DeinitPlan) error {
+	entries, err := os.ReadDir(filepath.Join(projectDir, ".claude", "skills"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read skills: %w", err)
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		path := ".claude/skills/" + e.Name()
+		if isRegistryPath(path) {
+			plan.Remove = append(plan.Remove, path)
+		} else {
+			plan.UserSkills = append(plan.UserSkills, path)
+		}
+	}
+	return nil
+}
+
+// isRegistryPath reports whether path is where a registry component is
+// installed.
+func isRegistryPath(path string) bool {
+	for _, components := range [][]Component{Languages, Frameworks, Workflows, Skills} {
+		if slices.ContainsFunc(components, func(c Component) bool { return c.Path == path }) {
+			return true
+		}
+	}
+	return false
+}
+
+// planDeinitFolderFiles finds the per-folder CLAUDE.md and AGENTS.md files
+// in the directories sync visits. Those still marked as generated are
+// removed; the others were taken over by the user and are kept.
+func planDeinitFolderFiles(projectDir string, plan *DeinitPlan) error {
+	ignore := LoadGitIgnore(projectDir)
+	return filepath.WalkDir(projectDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || path == projectDir {
+			return nil
+		}
+		rel, err := filepath.Rel(projectDir, path)
+		if err != nil {
+			return err
+		}
+		if ShouldSkipDir(d.Name()) || ignore.IgnoredDir(rel) {
+			return filepath.SkipDir
+		}
+		for _, name := range []string{"CLAUDE.md", "AGENTS.md"} {
+			content, err := os.ReadFile(filepath.Join(path, name))
+			if err != nil {
+				continue
+			}
+			file := filepath.ToSlash(filepath.Join(rel, name))
+			if IsAutoGenerated(string(content)) {
+				plan.Remove = append(plan.Remove, file)
+			} else {
+				plan.Kept = append(plan.Kept, file)
+			}
+		}
+		return nil
+	})
+}
+
+// isCustomizedCoreFile reports whether local has content of the user's:
+// anything outside the managed blocks that differs from the template
+// built into the CLI. Differences from another template version count as
+// customizations too, which errs on the side of keeping them.
+func isCustomizedCoreFile(path, local string) bool {
+	upstream, err := fs.ReadFile(template.Minimal, path)
+	if err != nil {
+		return true
+	}
+	return userContent(local) != userContent(string(upstream))
+}
+
+// userContent returns content without the bodies of the managed blocks
+// and the generated skills block, ignoring line endings and blank lines.
+func userContent(content string) string {
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	if block, ok := findSkillsBlock(content); ok {
+		content = content[:block.start] + content[block.end:]
+	}
+	bodies := make(map[string]string)
+	for _, b := range findCoreBlocks(content) {
+		bodies[b.name] = ""
+	}
+	content, _ = replaceCoreBlocks(content, bodies)
+	var lines []string
+	for _, line := range strings.Split(content, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ApplyDeinit removes what plan lists from projectDir. The user skills
+// and customized instruction files are copied to backupDir first; with
+// an empty backupDir they are deleted without a copy. The .claude and
+// .claude/skills directories are removed when nothing else is left in
+// them.
+func ApplyDeinit(projectDir string, plan *DeinitPlan, backupDir string) error {
+	if backupDir != "" {
+		for _, path := range append(slices.Clone(plan.UserSkills), plan.Customized...) {
+			src := filepath.Join(projectDir, filepath.FromSlash(path))
+			dst := filepath.Join(backupDir, filepath.FromSlash(path))
+			if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", path, err)
+			}
+			if err := copyDir(src, dst); err != nil {
+				return fmt.Errorf("failed to back up %s: %w", path, err)
+			}
+		}
+	}
+	for _, path := range append(slices.Clone(plan.UserSkills), plan.Remove...) {
+		if err := os.RemoveAll(filepath.Join(projectDir, filepath.FromSlash(path))); err != nil {
+			return fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+	}
+	for _, dir := range []string{filepath.Join(".claude", "skills"), ".claude"} {
+		os.Remove(filepath.Join(projectDir, dir)) // only succeeds when empty
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/template"
+)
+
+// setupDeinitProject writes an installed project: the template core files
+// with CLAUDE.md customized, a registry skill, a skill of the user's,
+// generated files, and folder instructions.
+func setupDeinitProject(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	for _, path := range CoreFiles {
+		content, err := fs.ReadFile(template.Minimal, path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path == "CLAUDE.md" {
+			content = append(content, "\n## Team notes\n\nDeploy on Fridays.\n"...)
+		}
+		writeTestFile(t, filepath.Join(dir, filepath.FromSlash(path)), string(content))
+	}
+	files := map[string]string{
+		".claude/skills/go-guide/SKILL.md":         "go",
+		".claude/skills/our-deploy/SKILL.md":       "deploy",
+		".claude/auto/prd.json":                    "{}",
+		"AGENTS-COMPACT.md":                        "compact",
+		"samuel.yaml":                              "version: 2.0.0\n",
+		"api/CLAUDE.md":                            autoGenMarker + " -->\n# api\n",
+		"web/CLAUDE.md":                            "# web, by hand\n",
+		".samuel-backup-20260101-120000/CLAUDE.md": "old",
+	}
+	for path, content := range files {
+		writeTestFile(t, filepath.Join(dir, filepath.FromSlash(path)), content)
+	}
+	return dir
+}
+
+func TestPlanDeinit(t *testing.T) {
+	dir := setupDeinitProject(t)
+	plan, err := PlanDeinit(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"CLAUDE.md", "AGENTS.md", ".claude/skills/go-guide", AutoDir, CompactAgentsFile, "api/CLAUDE.md"} {
+		if !slices.Contains(plan.Remove, want) {
+			t.Errorf("Remove = %v, missing %s", plan.Remove, want)
+		}
+	}
+	if last := plan.Remove[len(plan.Remove)-1]; last != "samuel.yaml" {
+		t.Errorf("last removal = %s, want the config file", last)
+	}
+	if !slices.Equal(plan.UserSkills, []string{".claude/skills/our-deploy"}) {
+		t.Errorf("UserSkills = %v", plan.UserSkills)
+	}
+	if !slices.Equal(plan.Customized, []string{"CLAUDE.md"}) {
+		t.Errorf("Customized = %v, want only CLAUDE.md", plan.Customized)
+	}
+	if !slices.Equal(plan.Kept, []string{"web/CLAUDE.md", ".samuel-backup-20260101-120000"}) {
+		t.Errorf("Kept = %v", plan.Kept)
+	}
+}
+
+func TestUserContent_IgnoresManagedBlocks(t *testing.T) {
+	upstream := coreDoc("", "upstream guardrails", "upstream stuck")
+	local := strings.ReplaceAll(coreDoc("", "older guardrails", "older stuck"), "\n", "\r\n")
+	if userContent(local) != userContent(upstream) {
+		t.Error("managed block bodies should not count as customizations")
+	}
+	if userContent(coreDoc("my rules", "g", "s")) == userContent(upstream) {
+		t.Error("content outside the managed blocks should count")
+	}
+}
+
+func TestApplyDeinit(t *testing.T) {
+	dir := setupDeinitProject(t)
+	plan, err := PlanDeinit(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backup := filepath.Join(t.TempDir(), "bundle")
+	if err := ApplyDeinit(dir, plan, backup); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"CLAUDE.md", ".claude", "samuel.yaml", "AGENTS-COMPACT.md", "api/CLAUDE.md"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); !os.IsNotExist(err) {
+			t.Errorf("%s should be removed", path)
+		}
+	}
+	for _, path := range []string{"web/CLAUDE.md", ".samuel-backup-20260101-120000"} {
+		if _, err := os.Stat(filepath.Join(dir, path)); err != nil {
+			t.Errorf("%s should be kept: %v", path, err)
+		}
+	}
+	claude, err := os.ReadFile(filepath.Join(backup, "CLAUDE.md"))
+	if err != nil || !strings.Contains(string(claude), "Deploy on Fridays.") {
+		t.Errorf("bundled CLAUDE.md = %q, %v", claude, err)
+	}
+	if _, err := os.Stat(filepath.Join(backup, ".claude", "skills", "our-deploy", "SKILL.md")); err != nil {
+		t.Errorf("user skill not bundled: %v", err)
+	}
+}
+
+func TestApplyDeinit_Purge(t *testing.T) {
+	dir := setupDeinitProject(t)
+	plan, err := PlanDeinit(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyDeinit(dir, plan, ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".claude")); !os.IsNotExist(err) {
+		t.Error(".claude should be removed with the user skills")
+	}
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Kinds of renamed names tracked by the deprecation registry.
+const (
+	DeprecatedBinary     = "binary"
+	DeprecatedCommand    = "command"
+	DeprecatedFlag       = "flag"
+	DeprecatedConfigFile = "config-file"
+	DeprecatedEnvVar     = "env-var"
+)
+
+// Deprecation maps an old name onto its replacement. Commands are written
+// as full command paths ("samuel old-name") and flags as a command path
+// followed by the flag ("samuel init --old-flag").
+type Deprecation struct {
+	Kind      string
+	Old       string
+	New       string
+	Since     string
+	RemovedIn string
+	// Rewrite is the text `samuel migrate` substitutes for Old in project
+	// scripts. It is empty when there is no safe mechanical rewrite.
+	Rewrite string
+}
+
+// Warning formats the deprecation as a single structured line.
+func (d Deprecation) Warning() string {
+	return fmt.Sprintf("DEPRECATED %s %q: use %q instead (deprecated in %s, removed in %s)",
+		d.Kind, d.Old, d.New, d.Since, d.RemovedIn)
+}
+
+// deprecations lists every renamed name still accepted or detected. Config
+// file entries must precede the binary entry so ".aicof.yaml" is rewritten
+// whole rather than as "aicof" followed by ".yaml".
+var deprecations = []Deprecation{
+	{Kind: DeprecatedConfigFile, Old: ".aicof.yaml", New: AltConfigFileName, Since: "2.0.0", RemovedIn: "3.0.0", Rewrite: AltConfigFileName},
+	{Kind: DeprecatedConfigFile, Old: "aicof.yaml", New: ConfigFileName, Since: "2.0.0", RemovedIn: "3.0.0", Rewrite: ConfigFileName},
+	{Kind: DeprecatedBinary, Old: "aicof", New: "samuel", Since: "2.0.0", RemovedIn: "3.0.0", Rewrite: "samuel"},
+	{Kind: DeprecatedEnvVar, Old: "AICOF_NO_COLOR", New: "NO_COLOR", Since: "2.0.0", RemovedIn: "3.0.0", Rewrite: "NO_COLOR"},
+	{Kind: DeprecatedEnvVar, Old: "AICOF_VERBOSE", New: "--verbose", Since: "2.0.0", RemovedIn: "3.0.0"},
+}
+
+// deprecationLookupEnv is replaced in tests.
+var deprecationLookupEnv = os.LookupEnv
+
+// GetDeprecations returns the deprecation registry.
+func GetDeprecations() []Deprecation {
+	return append([]Deprecation(nil), deprecations...)
+}
+
+// legacyConfigFileNames returns the pre-rename config file names that are
+// still read when no current config file exists.
+func legacyConfigFileNames() []string {
+	var names []string
+	for _, d := range deprecations {
+		if d.Kind == DeprecatedConfigFile {
+			names = append(names, d.Old)
+		}
+	}
+	return names
+}
+
+// DeprecationFinding is a deprecated name in use in a project or the
+// environment.
+type DeprecationFinding struct {
+	Deprecation Deprecation
+	// Location is the file path relative to the project, or the
+	// environment variable name.
+	Location string
+}
+
+// DetectDeprecatedSettings reports legacy config files in dir and legacy
+// environment variables that are set. Scripts are only scanned by
+// PlanMigration, which is too slow to run before every command.
+func DetectDeprecatedSettings(dir string) []DeprecationFinding {
+	var findings []DeprecationFinding
+	for _, d := range deprecations {
+		switch d.Kind {
+		case DeprecatedConfigFile:
+			if _, err := os.Stat(filepath.Join(dir, d.Old)); err == nil {
+				findings = append(findings, DeprecationFinding{Deprecation: d, Location: d.Old})
+			}
+		case DeprecatedEnvVar:
+			if _, ok := deprecationLookupEnv(d.Old); ok {
+				findings = append(findings, DeprecationFinding{Deprecation: d, Location: "$" + d.Old})
+			}
+		}
+	}
+	return findings
+}
+
+// containsDeprecatedName reports whether s mentions old as a whole name.
+func containsDeprecatedName(s, old string) bool {
+	_, n := replaceDeprecatedName(s, old, old)
+	return n > 0
+}
+
+// replaceDeprecatedName replaces whole-name occurrences of old in s and
+// returns the result with the number of replacements. A match must not be
+// preceded or followed by a letter, digit, '_' or '-', and must not be
+// preceded by '.', so "aicof" matches "./bin/aicof init" but not
+// "aicof-docs" or "my_aicof".
+func replaceDeprecatedName(s, old, replacement string) (string, int) {
+	var b strings.Builder
+	count, start := 0, 0
+	for {
+		i := strings.Index(s[start:], old)
+		if i < 0 {
+			break
+		}
+		i += start
+		end := i + len(old)
+		before, _ := utf8.DecodeLastRuneInString(s[:i])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if isDeprecatedNameRune(before) || before == '.' || isDeprecatedNameRune(after) {
+			b.WriteString(s[start : i+1])
+			start = i + 1
+			continue
+		}
+		b.WriteString(s[start:i])
+		b.WriteString(replacement)
+		start = end
+		count++
+	}
+	b.WriteString(s[start:])
+	return b.String(), count
+}
+
+func isDeprecatedNameRune(r rune) bool {
+	return r != utf8.RuneError && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-')
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplaceDeprecatedName(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     string
+		old       string
+		want      string
+		wantCount int
+	}{
+		{"command", "aicof init .", "aicof", "samuel init .", 1},
+		{"path", "./bin/aicof update && aicof doctor", "aicof", "./bin/samuel update && samuel doctor", 2},
+		{"module_path", "go install github.com/ar4mirez/aicof/cmd/aicof@latest", "aicof", "go install github.com/ar4mirez/samuel/cmd/samuel@latest", 2},
+		{"longer_name", "aicof-docs my_aicof aicof2", "aicof", "aicof-docs my_aicof aicof2", 0},
+		{"dotted_prefix", "cat .aicof.yaml", "aicof.yaml", "cat .aicof.yaml", 0},
+		{"env_var", "AICOF_NO_COLOR=1 MY_AICOF_NO_COLOR=1", "AICOF_NO_COLOR", "samuel=1 MY_AICOF_NO_COLOR=1", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n := replaceDeprecatedName(tt.input, tt.old, "samuel")
+			if got != tt.want || n != tt.wantCount {
+				t.Errorf("replaceDeprecatedName(%q, %q) = %q, %d; want %q, %d", tt.input, tt.old, got, n, tt.want, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestDeprecationWarning(t *testing.T) {
+	d := Deprecation{Kind: DeprecatedConfigFile, Old: "aicof.yaml", New: "samuel.yaml", Since: "2.0.0", RemovedIn: "3.0.0"}
+	want := `DEPRECATED config-file "aicof.yaml": use "samuel.yaml" instead (deprecated in 2.0.0, removed in 3.0.0)`
+	if got := d.Warning(); got != want {
+		t.Errorf("Warning() = %q, want %q", got, want)
+	}
+}
+
+func TestDetectDeprecatedSettings(t *testing.T) {
+	orig := deprecationLookupEnv
+	defer func() { deprecationLookupEnv = orig }()
+	deprecationLookupEnv = func(key string) (string, bool) {
+		return "1", key == "AICOF_VERBOSE"
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "aicof.yaml"), []byte("version: 1.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings := DetectDeprecatedSettings(dir)
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Location)
+	}
+	if strings.Join(got, ",") != "aicof.yaml,$AICOF_VERBOSE" {
+		t.Errorf("findings = %v, want aicof.yaml and $AICOF_VERBOSE", got)
+	}
+}
+
+func TestLoadConfigFrom_LegacyConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "aicof.yaml"), []byte("version: 1.8.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if !ConfigExists(dir) {
+		t.Fatal("ConfigExists should accept a legacy aicof.yaml")
+	}
+	config, err := LoadConfigFrom(dir)
+	if err != nil {
+		t.Fatalf("LoadConfigFrom returned error: %v", err)
+	}
+	if config.Version != "1.8.0" {
+		t.Errorf("Version = %q, want 1.8.0", config.Version)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ConfigFileName), []byte("version: 2.0.0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if config, _ := LoadConfigFrom(dir); config == nil || config.Version != "2.0.0" {
+		t.Errorf("samuel.yaml should take precedence over aicof.yaml, got %+v", config)
+	}
+}
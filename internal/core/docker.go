@@ -46,7 +46,7 @@ type DockerSandboxRunConfig struct {
 
 // GetSupportedSandboxModes returns the list of supported sandbox modes.
 func GetSupportedSandboxModes() []string {
-	return []string{SandboxNone, SandboxDocker, SandboxDockerSandbox}
+	return []string{SandboxNone, SandboxDocker, SandboxDockerSandbox, SandboxProcess}
 }
 
 // IsValidSandboxMode checks if the given mode is supported.
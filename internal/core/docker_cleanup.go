@@ -0,0 +1,134 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Labels attached to every container samuel starts, so stray containers
+// from interrupted runs can be found and removed later.
+const (
+	SandboxLabelManaged = "dev.samuel.managed"
+	SandboxLabelProject = "dev.samuel.project"
+	// sandboxNamePrefix prefixes docker sandbox VM names created by samuel.
+	sandboxNamePrefix = "samuel-"
+	// sandboxNameHashLen is the number of hex chars of the project hash.
+	sandboxNameHashLen = 8
+)
+
+// sandboxNameUnsafe matches characters not allowed in sandbox names.
+var sandboxNameUnsafe = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// dockerOutput runs a docker command and returns its combined output.
+// Swapped out in tests so no real daemon is needed.
+var dockerOutput = func(args ...string) ([]byte, error) {
+	return exec.Command("docker", args...).CombinedOutput()
+}
+
+// SandboxPruneOptions selects what PruneSandboxes removes.
+type SandboxPruneOptions struct {
+	ProjectDir string   // limit to one project; empty means all projects
+	Images     []string // images to remove after containers are gone
+	DryRun     bool     // report what would be removed without removing
+}
+
+// SandboxPruneResult reports what PruneSandboxes removed (or would remove).
+type SandboxPruneResult struct {
+	Containers []string
+	Sandboxes  []string
+	Images     []string
+	Errors     []string
+}
+
+// sandboxLabelArgs returns the --label flags for a container started
+// for projectDir.
+func sandboxLabelArgs(projectDir string) []string {
+	return []string{
+		"--label", SandboxLabelManaged + "=true",
+		"--label", SandboxLabelProject + "=" + projectDir,
+	}
+}
+
+// SandboxNameForProject returns the stable docker sandbox VM name used for
+// a project, e.g. "samuel-myapp-1a2b3c4d".
+func SandboxNameForProject(projectDir string) string {
+	base := sandboxNameUnsafe.ReplaceAllString(strings.ToLower(filepath.Base(projectDir)), "-")
+	base = strings.Trim(base, "-.")
+	if base == "" {
+		base = "project"
+	}
+	sum := sha256.Sum256([]byte(projectDir))
+	return sandboxNamePrefix + base + "-" + hex.EncodeToString(sum[:])[:sandboxNameHashLen]
+}
+
+// ListSandboxContainers returns the IDs of samuel-managed containers,
+// optionally limited to one project.
+func ListSandboxContainers(projectDir string) ([]string, error) {
+	args := []string{"ps", "-a", "-q", "--filter", "label=" + SandboxLabelManaged + "=true"}
+	if projectDir != "" {
+		args = append(args, "--filter", "label="+SandboxLabelProject+"="+projectDir)
+	}
+	out, err := dockerOutput(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sandbox containers: %s", strings.TrimSpace(string(out)))
+	}
+	return strings.Fields(string(out)), nil
+}
+
+// PruneSandboxes removes samuel-managed containers, the project's docker
+// sandbox VM, and optionally images. Individual removal failures are
+// collected in the result rather than aborting the prune.
+func PruneSandboxes(opts SandboxPruneOptions) (*SandboxPruneResult, error) {
+	containers, err := ListSandboxContainers(opts.ProjectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SandboxPruneResult{}
+	for _, id := range containers {
+		result.record(&result.Containers, id, opts.DryRun, "rm", "-f", id)
+	}
+	if opts.ProjectDir != "" {
+		name := SandboxNameForProject(opts.ProjectDir)
+		if sandboxExists(name) {
+			result.record(&result.Sandboxes, name, opts.DryRun, "sandbox", "rm", name)
+		}
+	}
+	for _, image := range opts.Images {
+		if IsValidSandboxImage(image) {
+			result.record(&result.Images, image, opts.DryRun, "image", "rm", image)
+		}
+	}
+	return result, nil
+}
+
+// record runs a removal command (unless dryRun) and files the outcome.
+func (r *SandboxPruneResult) record(list *[]string, item string, dryRun bool, args ...string) {
+	if !dryRun {
+		if out, err := dockerOutput(args...); err != nil {
+			r.Errors = append(r.Errors, fmt.Sprintf("%s: %s", item, strings.TrimSpace(string(out))))
+			return
+		}
+	}
+	*list = append(*list, item)
+}
+
+// sandboxExists reports whether a docker sandbox VM with the name exists.
+func sandboxExists(name string) bool {
+	out, err := dockerOutput("sandbox", "ls")
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		if slices.Contains(strings.Fields(line), name) {
+			return true
+		}
+	}
+	return false
+}
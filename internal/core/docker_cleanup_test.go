@@ -0,0 +1,131 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubDocker replaces dockerOutput with a fake that answers by the first
+// matching command prefix and records every invocation.
+func stubDocker(t *testing.T, responses map[string]string, failing map[string]bool) *[]string {
+	t.Helper()
+	orig := dockerOutput
+	t.Cleanup(func() { dockerOutput = orig })
+
+	var calls []string
+	dockerOutput = func(args ...string) ([]byte, error) {
+		call := strings.Join(args, " ")
+		calls = append(calls, call)
+		for prefix := range failing {
+			if strings.HasPrefix(call, prefix) {
+				return []byte("boom"), errors.New("exit status 1")
+			}
+		}
+		for prefix, out := range responses {
+			if strings.HasPrefix(call, prefix) {
+				return []byte(out), nil
+			}
+		}
+		return nil, nil
+	}
+	return &calls
+}
+
+func TestSandboxNameForProject(t *testing.T) {
+	name := SandboxNameForProject("/home/dev/My App!")
+	if !strings.HasPrefix(name, "samuel-my-app-") {
+		t.Errorf("name = %q, want samuel-my-app- prefix", name)
+	}
+	if len(name) != len("samuel-my-app-")+sandboxNameHashLen {
+		t.Errorf("name = %q has unexpected length", name)
+	}
+	if SandboxNameForProject("/a/app") == SandboxNameForProject("/b/app") {
+		t.Error("projects with the same base name should get distinct names")
+	}
+}
+
+func TestListSandboxContainers(t *testing.T) {
+	calls := stubDocker(t, map[string]string{"ps": "abc\ndef\n"}, nil)
+
+	ids, err := ListSandboxContainers("/proj")
+	if err != nil {
+		t.Fatalf("ListSandboxContainers returned error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "abc" || ids[1] != "def" {
+		t.Errorf("ids = %v, want [abc def]", ids)
+	}
+	if !strings.Contains((*calls)[0], "label="+SandboxLabelProject+"=/proj") {
+		t.Errorf("expected project label filter, got %q", (*calls)[0])
+	}
+}
+
+func TestListSandboxContainers_AllProjects(t *testing.T) {
+	calls := stubDocker(t, nil, nil)
+
+	if _, err := ListSandboxContainers(""); err != nil {
+		t.Fatalf("ListSandboxContainers returned error: %v", err)
+	}
+	if strings.Contains((*calls)[0], SandboxLabelProject) {
+		t.Errorf("all-projects listing should not filter by project: %q", (*calls)[0])
+	}
+}
+
+func TestPruneSandboxes(t *testing.T) {
+	name := SandboxNameForProject("/proj")
+	calls := stubDocker(t,
+		map[string]string{"ps": "abc\ndef", "sandbox ls": "NAME\n" + name + " running\n"},
+		map[string]bool{"rm -f def": true},
+	)
+
+	result, err := PruneSandboxes(SandboxPruneOptions{
+		ProjectDir: "/proj",
+		Images:     []string{"node:lts", "; rm -rf /"},
+	})
+	if err != nil {
+		t.Fatalf("PruneSandboxes returned error: %v", err)
+	}
+
+	if len(result.Containers) != 1 || result.Containers[0] != "abc" {
+		t.Errorf("Containers = %v, want [abc]", result.Containers)
+	}
+	if len(result.Errors) != 1 || !strings.HasPrefix(result.Errors[0], "def:") {
+		t.Errorf("Errors = %v, want failure for def", result.Errors)
+	}
+	if len(result.Sandboxes) != 1 || result.Sandboxes[0] != name {
+		t.Errorf("Sandboxes = %v, want [%s]", result.Sandboxes, name)
+	}
+	if len(result.Images) != 1 || result.Images[0] != "node:lts" {
+		t.Errorf("Images = %v, want only the valid image", result.Images)
+	}
+	for _, call := range *calls {
+		if strings.Contains(call, "rm -rf") {
+			t.Errorf("invalid image reached docker: %q", call)
+		}
+	}
+}
+
+func TestPruneSandboxes_DryRun(t *testing.T) {
+	calls := stubDocker(t, map[string]string{"ps": "abc"}, nil)
+
+	result, err := PruneSandboxes(SandboxPruneOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("PruneSandboxes returned error: %v", err)
+	}
+	if len(result.Containers) != 1 {
+		t.Errorf("Containers = %v, want [abc]", result.Containers)
+	}
+	for _, call := range *calls {
+		if strings.HasPrefix(call, "rm") {
+			t.Errorf("dry run should not remove anything, got %q", call)
+		}
+	}
+}
+
+func TestPruneSandboxes_ListError(t *testing.T) {
+	stubDocker(t, nil, map[string]bool{"ps": true})
+
+	if _, err := PruneSandboxes(SandboxPruneOptions{}); err == nil {
+		t.Error("expected error when listing containers fails")
+	}
+}
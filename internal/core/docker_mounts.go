@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// SandboxMount declares an extra mount for the docker sandbox. Source is
+// either a host path from the allowlist (may start with "~/") or the name
+// of a Docker-managed volume, e.g. a shared Go module cache.
+type SandboxMount struct {
+	Source   string `json:"source"`
+	Target   string `json:"target"`
+	ReadOnly bool   `json:"read_only,omitempty"`
+}
+
+// sandboxMountRule describes an allowlisted host path.
+type sandboxMountRule struct {
+	requireReadOnly bool // credentials and config must never be writable
+}
+
+// sandboxHostMountAllowlist lists the host paths (relative to the user's
+// home directory) that may be bind-mounted into the sandbox. Everything
+// else is refused so the whole home directory is never exposed.
+var sandboxHostMountAllowlist = map[string]sandboxMountRule{
+	".gitconfig":        {requireReadOnly: true},
+	".ssh/known_hosts":  {requireReadOnly: true},
+	".npmrc":            {requireReadOnly: true},
+	".netrc":            {requireReadOnly: true},
+	"go/pkg/mod":        {},
+	".cache/go-build":   {},
+	".npm":              {},
+	".cache/pip":        {},
+	".cargo/registry":   {},
+	".m2/repository":    {},
+	".gradle/caches":    {},
+	".cache/yarn":       {},
+	".local/share/pnpm": {},
+}
+
+// dockerVolumeNamePattern matches Docker named volumes.
+var dockerVolumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// GetAllowedSandboxHostMounts returns the allowlisted host paths, as
+// "~/"-prefixed paths, for help and error messages.
+func GetAllowedSandboxHostMounts() []string {
+	paths := make([]string, 0, len(sandboxHostMountAllowlist))
+	for p := range sandboxHostMountAllowlist {
+		paths = append(paths, "~/"+p)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// isNamedVolume reports whether a mount source refers to a Docker volume
+// rather than a host path.
+func (m SandboxMount) isNamedVolume() bool {
+	return dockerVolumeNamePattern.MatchString(m.Source)
+}
+
+// ValidateSandboxMount checks a mount against the allowlist and target rules.
+func ValidateSandboxMount(m SandboxMount) error {
+	if err := validateMountTarget(m.Target); err != nil {
+		return err
+	}
+	if m.isNamedVolume() {
+		return nil
+	}
+
+	rel, ok := homeRelativeMountPath(m.Source)
+	if !ok {
+		return fmt.Errorf("mount source %q must be a named volume or a path under ~/", m.Source)
+	}
+	rule, allowed := sandboxHostMountAllowlist[rel]
+	if !allowed {
+		return fmt.Errorf("mount source %q is not allowlisted (allowed: %v)",
+			m.Source, GetAllowedSandboxHostMounts())
+	}
+	if rule.requireReadOnly && !m.ReadOnly {
+		return fmt.Errorf("mount source %q must be read_only", m.Source)
+	}
+	return nil
+}
+
+// validateMountTarget requires an absolute container path that neither
+// shadows the workspace nor breaks docker's -v syntax.
+func validateMountTarget(target string) error {
+	if !strings.HasPrefix(target, "/") || target == "/" {
+		return fmt.Errorf("mount target %q must be an absolute path other than /", target)
+	}
+	if strings.ContainsAny(target, ":,") {
+		return fmt.Errorf("mount target %q must not contain ':' or ','", target)
+	}
+	clean := filepath.Clean(target)
+	if clean == DockerContainerMount || strings.HasPrefix(clean, DockerContainerMount+"/") {
+		return fmt.Errorf("mount target %q must not overlap %s", target, DockerContainerMount)
+	}
+	return nil
+}
+
+// homeRelativeMountPath converts "~/x" or an absolute path under the home
+// directory into "x". It returns false for anything outside the home
+// directory, including paths that escape it with "..".
+func homeRelativeMountPath(source string) (string, bool) {
+	if strings.ContainsAny(source, ":,") {
+		return "", false
+	}
+
+	rel, ok := strings.CutPrefix(source, "~/")
+	if !ok {
+		if !filepath.IsAbs(source) {
+			return "", false
+		}
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", false
+		}
+		if rel, err = filepath.Rel(home, source); err != nil {
+			return "", false
+		}
+	}
+
+	rel = filepath.ToSlash(filepath.Clean(rel))
+	if rel == "." || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", false
+	}
+	return rel, true
+}
+
+// BuildSandboxMountArgs validates mounts and returns the matching
+// "-v source:target[:ro]" docker arguments with "~/" expanded.
+func BuildSandboxMountArgs(mounts []SandboxMount) ([]string, error) {
+	var args []string
+	for i, m := range mounts {
+		if err := ValidateSandboxMount(m); err != nil {
+			return nil, fmt.Errorf("sandbox mount %d: %w", i, err)
+		}
+
+		source := m.Source
+		if !m.isNamedVolume() {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve home directory: %w", err)
+			}
+			rel, _ := homeRelativeMountPath(m.Source)
+			source = filepath.Join(home, filepath.FromSlash(rel))
+		}
+
+		spec := fmt.Sprintf("%s:%s", source, m.Target)
+		if m.ReadOnly {
+			spec += ":ro"
+		}
+		args = append(args, "-v", spec)
+	}
+	return args, nil
+}
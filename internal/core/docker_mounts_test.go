@@ -0,0 +1,99 @@
+package core
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateSandboxMount(t *testing.T) {
+	t.Setenv("HOME", "/home/dev")
+
+	tests := []struct {
+		name    string
+		mount   SandboxMount
+		wantErr string
+	}{
+		{"named volume cache", SandboxMount{Source: "samuel-gomod", Target: "/go/pkg/mod"}, ""},
+		{"allowlisted cache", SandboxMount{Source: "~/go/pkg/mod", Target: "/go/pkg/mod"}, ""},
+		{"absolute home path", SandboxMount{Source: "/home/dev/.npm", Target: "/npm"}, ""},
+		{"read-only secret", SandboxMount{Source: "~/.gitconfig", Target: "/etc/gitconfig", ReadOnly: true}, ""},
+		{"writable secret", SandboxMount{Source: "~/.gitconfig", Target: "/etc/gitconfig"}, "must be read_only"},
+		{"whole home", SandboxMount{Source: "~/", Target: "/home"}, "named volume or a path under ~/"},
+		{"not allowlisted", SandboxMount{Source: "~/.aws/credentials", Target: "/aws", ReadOnly: true}, "not allowlisted"},
+		{"escape home", SandboxMount{Source: "~/../other/.npm", Target: "/npm"}, "named volume or a path under ~/"},
+		{"outside home", SandboxMount{Source: "/etc/passwd", Target: "/passwd"}, "named volume or a path under ~/"},
+		{"relative target", SandboxMount{Source: "cache", Target: "cache"}, "absolute path"},
+		{"root target", SandboxMount{Source: "cache", Target: "/"}, "absolute path"},
+		{"shadows workspace", SandboxMount{Source: "cache", Target: "/workspace/node_modules"}, "overlap"},
+		{"colon in target", SandboxMount{Source: "cache", Target: "/a:/b"}, "must not contain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSandboxMount(tt.mount)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBuildSandboxMountArgs(t *testing.T) {
+	t.Setenv("HOME", "/home/dev")
+
+	args, err := BuildSandboxMountArgs([]SandboxMount{
+		{Source: "~/.gitconfig", Target: "/etc/gitconfig", ReadOnly: true},
+		{Source: "samuel-gomod", Target: "/go/pkg/mod"},
+	})
+	if err != nil {
+		t.Fatalf("BuildSandboxMountArgs returned error: %v", err)
+	}
+
+	want := []string{
+		"-v", filepath.Join("/home/dev", ".gitconfig") + ":/etc/gitconfig:ro",
+		"-v", "samuel-gomod:/go/pkg/mod",
+	}
+	if strings.Join(args, " ") != strings.Join(want, " ") {
+		t.Errorf("args = %v, want %v", args, want)
+	}
+}
+
+func TestBuildSandboxMountArgs_RejectsInvalid(t *testing.T) {
+	_, err := BuildSandboxMountArgs([]SandboxMount{
+		{Source: "ok-volume", Target: "/cache"},
+		{Source: "~/.ssh", Target: "/ssh", ReadOnly: true},
+	})
+	if err == nil || !strings.Contains(err.Error(), "sandbox mount 1") {
+		t.Errorf("expected error for mount 1, got %v", err)
+	}
+}
+
+func TestBuildDockerRunArgs_WithMounts(t *testing.T) {
+	mountArgs := []string{"-v", "samuel-gomod:/go/pkg/mod"}
+	args := buildDockerRunArgs("/proj", "golang:1.22", "codex", mountArgs, []string{"--auto"})
+
+	joined := strings.Join(args, " ")
+	if !strings.Contains(joined, "-v /proj:/workspace -v samuel-gomod:/go/pkg/mod") {
+		t.Errorf("mount args should follow the workspace mount: %v", args)
+	}
+	if args[len(args)-1] != "--auto" {
+		t.Errorf("agent args should remain last: %v", args)
+	}
+}
+
+func TestValidateAutoPRD_InvalidSandboxMount(t *testing.T) {
+	prd := NewAutoPRD("test", "desc")
+	prd.Config.SandboxMounts = []SandboxMount{{Source: "/etc", Target: "/etc-host"}}
+
+	errs := ValidateAutoPRD(prd)
+	if len(errs) != 1 || !strings.Contains(errs[0], "config.sandbox_mounts[0]") {
+		t.Errorf("ValidateAutoPRD() = %v, want one sandbox_mounts error", errs)
+	}
+}
@@ -11,8 +11,8 @@ import (
 
 func TestGetSupportedSandboxModes(t *testing.T) {
 	modes := GetSupportedSandboxModes()
-	if len(modes) != 3 {
-		t.Fatalf("expected 3 modes, got %d", len(modes))
+	if len(modes) != 4 {
+		t.Fatalf("expected 4 modes, got %d", len(modes))
 	}
 	if modes[0] != SandboxNone {
 		t.Errorf("expected first mode %q, got %q", SandboxNone, modes[0])
@@ -23,6 +23,9 @@ func TestGetSupportedSandboxModes(t *testing.T) {
 	if modes[2] != SandboxDockerSandbox {
 		t.Errorf("expected third mode %q, got %q", SandboxDockerSandbox, modes[2])
 	}
+	if modes[3] != SandboxProcess {
+		t.Errorf("expected fourth mode %q, got %q", SandboxProcess, modes[3])
+	}
 }
 
 func TestIsValidSandboxMode(t *testing.T) {
@@ -39,6 +42,7 @@ func TestIsValidSandboxMode(t *testing.T) {
 		{"Docker", true},
 		{"DOCKER-SANDBOX", true},
 		{"Docker-Sandbox", true},
+		{"process", true},
 		{"kubernetes", false},
 	}
 
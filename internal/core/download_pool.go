@@ -0,0 +1,97 @@
+package core
+
+import "sync"
+
+// MaxConcurrentDownloads bounds how many archives a Downloader fetches at
+// once when asked for several.
+var MaxConcurrentDownloads = 4
+
+// inflightGroup coalesces concurrent requests for the same key into one
+// call whose result every caller receives. A key is forgotten once its
+// call returns, so later requests go through the cache check again.
+type inflightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*inflightCall
+}
+
+type inflightCall struct {
+	done chan struct{}
+	path string
+	err  error
+}
+
+func (g *inflightGroup) do(key string, fn func() (string, error)) (string, error) {
+	g.mu.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		<-call.done
+		return call.path, call.err
+	}
+	if g.calls == nil {
+		g.calls = make(map[string]*inflightCall)
+	}
+	call := &inflightCall{done: make(chan struct{})}
+	g.calls[key] = call
+	g.mu.Unlock()
+
+	call.path, call.err = fn()
+	close(call.done)
+
+	g.mu.Lock()
+	delete(g.calls, key)
+	g.mu.Unlock()
+	return call.path, call.err
+}
+
+// forEachBounded calls fn for every index in [0, count) using at most
+// limit goroutines, and returns when all calls have finished.
+func forEachBounded(limit, count int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer func() { <-sem; wg.Done() }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// DownloadResult is the outcome of fetching one version.
+type DownloadResult struct {
+	Version string
+	Path    string
+	Err     error
+}
+
+// DownloadVersions fetches several versions into the cache concurrently,
+// at most MaxConcurrentDownloads at a time. Each distinct version is
+// fetched once even when listed repeatedly or requested by another caller
+// at the same time. Results follow the order of versions.
+func (d *Downloader) DownloadVersions(versions []string) []DownloadResult {
+	var unique []string
+	index := make(map[string]int)
+	for _, v := range versions {
+		if _, ok := index[v]; !ok {
+			index[v] = len(unique)
+			unique = append(unique, v)
+		}
+	}
+
+	fetched := make([]DownloadResult, len(unique))
+	forEachBounded(MaxConcurrentDownloads, len(unique), func(i int) {
+		path, err := d.DownloadVersion(unique[i])
+		fetched[i] = DownloadResult{Version: unique[i], Path: path, Err: err}
+	})
+
+	results := make([]DownloadResult, len(versions))
+	for i, v := range versions {
+		results[i] = fetched[index[v]]
+	}
+	return results
+}
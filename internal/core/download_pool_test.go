@@ -0,0 +1,127 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInflightGroup_CoalescesConcurrentCalls(t *testing.T) {
+	var g inflightGroup
+	var calls atomic.Int32
+	release := make(chan struct{})
+	fn := func() (string, error) {
+		calls.Add(1)
+		<-release
+		return "/cache/samuel-2.0.0", nil
+	}
+
+	const callers = 8
+	var started, wg sync.WaitGroup
+	paths := make([]string, callers)
+	started.Add(callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			started.Done()
+			paths[i], _ = g.do("version/2.0.0", fn)
+		}(i)
+	}
+	started.Wait()
+	// Give every caller time to join the in-flight call before it returns.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("fn called %d times for %d concurrent callers, want 1", got, callers)
+	}
+	for i, p := range paths {
+		if p != "/cache/samuel-2.0.0" {
+			t.Errorf("caller %d got %q", i, p)
+		}
+	}
+
+	// Once finished, the key is forgotten and a new call runs fn again.
+	before := calls.Load()
+	if _, err := g.do("version/2.0.0", func() (string, error) { calls.Add(1); return "", nil }); err != nil {
+		t.Fatal(err)
+	}
+	if calls.Load() != before+1 {
+		t.Error("completed call should not be reused")
+	}
+}
+
+func TestInflightGroup_SharesErrors(t *testing.T) {
+	var g inflightGroup
+	wantErr := errors.New("rate limited")
+	if _, err := g.do("k", func() (string, error) { return "", wantErr }); !errors.Is(err, wantErr) {
+		t.Errorf("err = %v, want %v", err, wantErr)
+	}
+}
+
+func TestForEachBounded(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		count int
+	}{
+		{"fewer_jobs_than_limit", 4, 2},
+		{"more_jobs_than_limit", 3, 20},
+		{"zero_limit_runs_serially", 0, 5},
+		{"no_jobs", 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var running, peak atomic.Int32
+			seen := make([]atomic.Bool, tt.count)
+			forEachBounded(tt.limit, tt.count, func(i int) {
+				n := running.Add(1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				seen[i].Store(true)
+				running.Add(-1)
+			})
+
+			limit := max(tt.limit, 1)
+			if int(peak.Load()) > limit {
+				t.Errorf("peak concurrency %d exceeds limit %d", peak.Load(), limit)
+			}
+			for i := range seen {
+				if !seen[i].Load() {
+					t.Errorf("job %d did not run", i)
+				}
+			}
+		})
+	}
+}
+
+func TestDownloadVersions_CachedAndDeduplicated(t *testing.T) {
+	cacheDir := t.TempDir()
+	for _, v := range []string{"1.9.0", "2.0.0"} {
+		if err := os.MkdirAll(filepath.Join(cacheDir, "samuel-"+v), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d := &Downloader{cachePath: cacheDir}
+
+	results := d.DownloadVersions([]string{"2.0.0", "1.9.0", "2.0.0"})
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	for i, want := range []string{"2.0.0", "1.9.0", "2.0.0"} {
+		r := results[i]
+		if r.Err != nil || r.Version != want || r.Path != filepath.Join(cacheDir, "samuel-"+want) {
+			t.Errorf("results[%d] = %+v, want cached %s", i, r, want)
+		}
+	}
+}
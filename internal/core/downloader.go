@@ -20,6 +20,8 @@ var MaxExtractedFileSize int64 = 100 * 1024 * 1024
 type Downloader struct {
 	client    *github.Client
 	cachePath string
+	// inflight coalesces concurrent fetches of the same archive.
+	inflight inflightGroup
 }
 
 // NewDownloader creates a new downloader
@@ -36,8 +38,15 @@ func NewDownloader() (*Downloader, error) {
 }
 
 // DownloadVersion downloads a specific version to the cache
-// If version is "dev", downloads from main branch
+// If version is "dev", downloads from main branch. Concurrent calls for the
+// same version share a single download.
 func (d *Downloader) DownloadVersion(version string) (string, error) {
+	return d.inflight.do("version/"+version, func() (string, error) {
+		return d.downloadVersion(version)
+	})
+}
+
+func (d *Downloader) downloadVersion(version string) (string, error) {
 	// Check if already cached (skip cache for dev version)
 	cacheDest := filepath.Join(d.cachePath, fmt.Sprintf("samuel-%s", version))
 	if version != github.DevVersion {
@@ -66,43 +75,52 @@ func (d *Downloader) DownloadVersion(version string) (string, error) {
 	}
 	defer reader.Close()
 
+	if err := cacheArchive(reader, cacheDest); err != nil {
+		return "", err
+	}
+	return cacheDest, nil
+}
+
+// cacheArchive extracts a GitHub tar.gz archive and moves its single
+// top-level directory to cacheDest.
+func cacheArchive(reader io.Reader, cacheDest string) error {
 	// Create temp directory for extraction
 	tempDir, err := os.MkdirTemp("", "samuel-download-*")
 	if err != nil {
-		return "", fmt.Errorf("failed to create temp directory: %w", err)
+		return fmt.Errorf("failed to create temp directory: %w", err)
 	}
 	defer os.RemoveAll(tempDir)
 
 	// Extract archive
 	if err := extractTarGz(reader, tempDir); err != nil {
-		return "", fmt.Errorf("failed to extract archive: %w", err)
+		return fmt.Errorf("failed to extract archive: %w", err)
 	}
 
 	// Find the extracted directory (GitHub adds repo-version prefix)
 	entries, err := os.ReadDir(tempDir)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	if len(entries) != 1 || !entries[0].IsDir() {
-		return "", fmt.Errorf("unexpected archive structure")
+		return fmt.Errorf("unexpected archive structure")
 	}
 
 	extractedDir := filepath.Join(tempDir, entries[0].Name())
 
 	// Move to cache
 	if err := os.MkdirAll(filepath.Dir(cacheDest), 0755); err != nil {
-		return "", err
+		return err
 	}
 
 	if err := os.Rename(extractedDir, cacheDest); err != nil {
 		// If rename fails (cross-device), copy instead
 		if err := copyDir(extractedDir, cacheDest); err != nil {
-			return "", fmt.Errorf("failed to cache download: %w", err)
+			return fmt.Errorf("failed to cache download: %w", err)
 		}
 	}
 
-	return cacheDest, nil
+	return nil
 }
 
 // GetLatestVersion fetches the latest version number
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/template"
+)
+
+// EmbeddedWorkflows are the workflows built into the CLI along with
+// CoreFiles. They must match the go:embed patterns of the template package.
+var EmbeddedWorkflows = []string{"create-prd", "generate-tasks", "code-review"}
+
+// EmbeddedTemplatePaths returns the component paths of the embedded
+// template: the core files and EmbeddedWorkflows.
+func EmbeddedTemplatePaths() []string {
+	return GetComponentPaths(nil, nil, EmbeddedWorkflows)
+}
+
+// EmbeddedTemplate writes the template built into the CLI to the cache and
+// returns it, for installing without network access. It is rewritten on
+// every call, since different builds may report the same version.
+func (d *Downloader) EmbeddedTemplate(version string) (*LayeredTemplate, error) {
+	dest := filepath.Join(d.cachePath, fmt.Sprintf("embedded-%s", version))
+	if err := os.RemoveAll(dest); err != nil {
+		return nil, fmt.Errorf("failed to clear embedded template: %w", err)
+	}
+	if err := writeFS(template.Minimal, filepath.Join(dest, filepath.FromSlash(TemplatePrefix))); err != nil {
+		return nil, fmt.Errorf("failed to write embedded template: %w", err)
+	}
+	return &LayeredTemplate{Path: dest}, nil
+}
+
+// writeFS copies every file of fsys under dir.
+func writeFS(fsys fs.FS, dir string) error {
+	return fs.WalkDir(fsys, ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data, 0644)
+	})
+}
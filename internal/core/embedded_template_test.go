@@ -0,0 +1,33 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEmbeddedTemplate(t *testing.T) {
+	d := &Downloader{cachePath: t.TempDir()}
+	tmpl, err := d.EmbeddedTemplate("1.2.3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A workflow listed in EmbeddedWorkflows but missing from the go:embed
+	// patterns would fail to extract here.
+	project := t.TempDir()
+	result, err := NewExtractor(tmpl.Path, project).Extract(EmbeddedTemplatePaths(), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := result.Err(); err != nil {
+		t.Fatalf("extracting the embedded template failed: %v", err)
+	}
+	for _, path := range []string{"CLAUDE.md", "AGENTS.md", ".claude/skills/README.md", ".claude/skills/create-prd/SKILL.md"} {
+		if _, err := os.Stat(filepath.Join(project, path)); err != nil {
+			t.Errorf("%s not installed: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(project, ".claude", "skills", "go-guide")); !os.IsNotExist(err) {
+		t.Error("the embedded template should hold no language guides")
+	}
+}
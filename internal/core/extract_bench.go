@@ -0,0 +1,189 @@
+package core
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path"
+	"slices"
+	"strings"
+)
+
+// Contents of the files in a synthetic benchmark archive: text compresses
+// like the prose of a real template, random does not compress at all.
+const (
+	BenchContentText   = "text"
+	BenchContentRandom = "random"
+)
+
+// GetSupportedBenchContents returns the supported archive contents.
+func GetSupportedBenchContents() []string {
+	return []string{BenchContentText, BenchContentRandom}
+}
+
+// Extraction benchmark modes: how an archive gets from the server into
+// the cache.
+const (
+	// ExtractModeBuffered downloads the archive to a file, verifies its
+	// checksum, then extracts the file.
+	ExtractModeBuffered = "buffered"
+	// ExtractModeStreaming extracts the response body as it arrives,
+	// hashing it on the way, as DownloadVersion does.
+	ExtractModeStreaming = "streaming"
+)
+
+// maxBenchArchiveBytes caps the file bytes of one synthetic archive, which
+// is built in memory.
+const maxBenchArchiveBytes = 2 << 30
+
+// benchArchiveRoot is the single top-level directory of a synthetic
+// archive, standing in for the repo-version prefix of GitHub archives.
+const benchArchiveRoot = "samuel-bench"
+
+// ExtractBenchConfig describes the synthetic archives of an extraction
+// benchmark and how they are fetched.
+type ExtractBenchConfig struct {
+	// Files is the number of files in each archive, of FileSize bytes each.
+	Files    int   `json:"files"`
+	FileSize int64 `json:"file_size"`
+	// Depth is how many directory levels the files are spread over.
+	Depth   int    `json:"depth"`
+	Content string `json:"content"`
+	// Archives is how many archives a run fetches, as a multi-version diff
+	// does; Parallel of them at once in the parallel modes.
+	Archives int `json:"archives"`
+	Parallel int `json:"parallel"`
+	// Runs is how often each mode runs; the median run is reported.
+	Runs int `json:"runs"`
+}
+
+// Validate checks the benchmark config.
+func (c ExtractBenchConfig) Validate() error {
+	switch {
+	case c.Files < 1:
+		return fmt.Errorf("invalid file count: %d (must be at least 1)", c.Files)
+	case c.FileSize < 1 || c.FileSize > MaxExtractedFileSize:
+		return fmt.Errorf("invalid file size: %d (must be between 1 and %d bytes)", c.FileSize, MaxExtractedFileSize)
+	case int64(c.Files)*c.FileSize > maxBenchArchiveBytes:
+		return fmt.Errorf("archive too large: %d files of %d bytes (at most %d bytes in total)", c.Files, c.FileSize, int64(maxBenchArchiveBytes))
+	case c.Depth < 0 || c.Depth > 10:
+		return fmt.Errorf("invalid depth: %d (must be between 0 and 10)", c.Depth)
+	case !slices.Contains(GetSupportedBenchContents(), c.Content):
+		return fmt.Errorf("invalid content: %s (supported: %v)", c.Content, GetSupportedBenchContents())
+	case c.Archives < 1:
+		return fmt.Errorf("invalid archive count: %d (must be at least 1)", c.Archives)
+	case c.Parallel < 1:
+		return fmt.Errorf("invalid parallelism: %d (must be at least 1)", c.Parallel)
+	case c.Runs < 1:
+		return fmt.Errorf("invalid run count: %d (must be at least 1)", c.Runs)
+	}
+	return nil
+}
+
+// ExtractBenchReport holds the results of an extraction benchmark with
+// what is needed to compare it with another run: the config, the archive
+// it produced and the machine it ran on.
+type ExtractBenchReport struct {
+	Config    ExtractBenchConfig `json:"config"`
+	Version   string             `json:"version,omitempty"`
+	GoVersion string             `json:"go_version"`
+	Platform  string             `json:"platform"`
+	CPUs      int                `json:"cpus"`
+	// ArchiveBytes is the compressed size of one archive and
+	// ExtractedBytes the file bytes it holds.
+	ArchiveBytes   int64                `json:"archive_bytes"`
+	ExtractedBytes int64                `json:"extracted_bytes"`
+	Results        []ExtractBenchResult `json:"results"`
+}
+
+// Result returns the result of mode at the given parallelism, or nil.
+func (r *ExtractBenchReport) Result(mode string, parallel int) *ExtractBenchResult {
+	for i := range r.Results {
+		if r.Results[i].Mode == mode && r.Results[i].Parallel == parallel {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+// LoadExtractBenchReport reads a report saved as JSON.
+func LoadExtractBenchReport(file string) (*ExtractBenchReport, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read benchmark results: %w", err)
+	}
+	var report ExtractBenchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("invalid benchmark results %s: %w", file, err)
+	}
+	return &report, nil
+}
+
+// SynthesizeArchive builds the benchmark archive in memory, laid out like
+// a GitHub archive under one top-level directory, and returns it with the
+// file bytes it holds. The content is seeded, so the same config yields
+// the same archive on every run and build.
+func SynthesizeArchive(cfg ExtractBenchConfig) ([]byte, int64, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: benchArchiveRoot + "/", Mode: 0755, Typeflag: tar.TypeDir}); err != nil {
+		return nil, 0, err
+	}
+	rng := rand.New(rand.NewSource(1))
+	content := make([]byte, cfg.FileSize)
+	for i := 0; i < cfg.Files; i++ {
+		fillBenchContent(content, cfg.Content, rng)
+		hdr := &tar.Header{Name: benchFilePath(i, cfg.Depth), Mode: 0644, Size: cfg.FileSize, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, 0, err
+		}
+		if _, err := tw.Write(content); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), int64(cfg.Files) * cfg.FileSize, nil
+}
+
+// benchFilePath spreads the files over depth levels of four directories
+// each.
+func benchFilePath(i, depth int) string {
+	parts := []string{benchArchiveRoot}
+	for level := 0; level < depth; level++ {
+		parts = append(parts, fmt.Sprintf("d%d", (i>>(2*level))&3))
+	}
+	return path.Join(append(parts, fmt.Sprintf("file-%05d.md", i))...)
+}
+
+// benchWords is the vocabulary of synthetic text; drawing words at random
+// compresses about as well as the prose of a real guide.
+var benchWords = strings.Fields(`the a to of and in for is on with use when not must each file test
+	function error return value input output project skill guide code review rule check path config
+	should never always before after changes commit validate handle keep under lines files package
+	module interface type struct string number list map default option flag command run build`)
+
+func fillBenchContent(content []byte, kind string, rng *rand.Rand) {
+	if kind == BenchContentRandom {
+		rng.Read(content)
+		return
+	}
+	for n := 0; n < len(content); {
+		word := benchWords[rng.Intn(len(benchWords))]
+		if rng.Intn(12) == 0 {
+			word += ".\n"
+		} else {
+			word += " "
+		}
+		n += copy(content[n:], word)
+	}
+}
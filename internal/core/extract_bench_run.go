@@ -0,0 +1,232 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ExtractBenchResult is the median run of one benchmark mode.
+type ExtractBenchResult struct {
+	Mode     string        `json:"mode"`
+	Parallel int           `json:"parallel"`
+	Total    time.Duration `json:"total_ns"`
+	// The phase times are summed over the archives of the run. Streaming
+	// overlaps the phases, so only its Total is measured.
+	Download time.Duration `json:"download_ns,omitempty"`
+	Verify   time.Duration `json:"verify_ns,omitempty"`
+	Extract  time.Duration `json:"extract_ns,omitempty"`
+	// MBPerSec is the extracted megabytes of all archives per second of
+	// Total.
+	MBPerSec float64 `json:"mb_per_sec"`
+}
+
+// extractBenchPhases are the per-phase times of one archive.
+type extractBenchPhases struct {
+	download, verify, extract time.Duration
+}
+
+// RunExtractBench synthesizes the archive described by cfg, serves it
+// over HTTP on the loopback interface and fetches it into a scratch cache
+// in every mode: buffered and streaming, one archive at a time and
+// cfg.Parallel at a time.
+func RunExtractBench(cfg ExtractBenchConfig) (*ExtractBenchReport, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	archive, extracted, err := SynthesizeArchive(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to synthesize archive: %w", err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start archive server: %w", err)
+	}
+	defer listener.Close()
+	go http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.ServeContent(w, r, "archive.tar.gz", time.Time{}, bytes.NewReader(archive))
+	}))
+	workDir, err := os.MkdirTemp("", "samuel-bench-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	report := &ExtractBenchReport{
+		Config: cfg, GoVersion: runtime.Version(), Platform: runtime.GOOS + "/" + runtime.GOARCH,
+		CPUs: runtime.NumCPU(), ArchiveBytes: int64(len(archive)), ExtractedBytes: extracted,
+	}
+	bench := extractBench{url: "http://" + listener.Addr().String() + "/archive.tar.gz", sum: sha256.Sum256(archive), dir: workDir}
+	levels := []int{1}
+	if cfg.Parallel > 1 {
+		levels = append(levels, cfg.Parallel)
+	}
+	for _, parallel := range levels {
+		for _, mode := range []string{ExtractModeBuffered, ExtractModeStreaming} {
+			result, err := bench.median(cfg, mode, parallel)
+			if err != nil {
+				return nil, fmt.Errorf("%s benchmark failed: %w", mode, err)
+			}
+			result.MBPerSec = float64(extracted) * float64(cfg.Archives) / (1 << 20) / result.Total.Seconds()
+			report.Results = append(report.Results, result)
+		}
+	}
+	return report, nil
+}
+
+// extractBench fetches the benchmark archive from url into dir.
+type extractBench struct {
+	url string
+	sum [sha256.Size]byte
+	dir string
+}
+
+// median runs a mode cfg.Runs times and returns the run of median total
+// time.
+func (b extractBench) median(cfg ExtractBenchConfig, mode string, parallel int) (ExtractBenchResult, error) {
+	runs := make([]ExtractBenchResult, cfg.Runs)
+	for i := range runs {
+		var err error
+		if runs[i], err = b.run(mode, parallel, cfg.Archives); err != nil {
+			return ExtractBenchResult{}, err
+		}
+	}
+	sort.Slice(runs, func(i, j int) bool { return runs[i].Total < runs[j].Total })
+	return runs[len(runs)/2], nil
+}
+
+// run fetches archives copies of the archive into a fresh cache directory,
+// parallel at a time.
+func (b extractBench) run(mode string, parallel, archives int) (ExtractBenchResult, error) {
+	runDir, err := os.MkdirTemp(b.dir, "run-*")
+	if err != nil {
+		return ExtractBenchResult{}, err
+	}
+	defer os.RemoveAll(runDir)
+
+	var mu sync.Mutex
+	var total extractBenchPhases
+	var firstErr error
+	start := time.Now()
+	forEachBounded(parallel, archives, func(i int) {
+		dest := filepath.Join(runDir, fmt.Sprintf("samuel-%d", i))
+		var phases extractBenchPhases
+		var err error
+		if mode == ExtractModeStreaming {
+			err = b.stream(dest)
+		} else {
+			phases, err = b.buffer(dest)
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		total.download += phases.download
+		total.verify += phases.verify
+		total.extract += phases.extract
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	})
+	return ExtractBenchResult{Mode: mode, Parallel: parallel, Total: time.Since(start),
+		Download: total.download, Verify: total.verify, Extract: total.extract}, firstErr
+}
+
+// buffer downloads the archive next to dest, verifies and extracts it,
+// timing each phase.
+func (b extractBench) buffer(dest string) (extractBenchPhases, error) {
+	var phases extractBenchPhases
+	file := dest + ".tar.gz"
+	start := time.Now()
+	if err := b.download(file); err != nil {
+		return phases, err
+	}
+	phases.download = time.Since(start)
+
+	start = time.Now()
+	f, err := os.Open(file)
+	if err != nil {
+		return phases, err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return phases, err
+	}
+	if err := b.verify(hash.Sum(nil)); err != nil {
+		return phases, err
+	}
+	phases.verify = time.Since(start)
+
+	start = time.Now()
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return phases, err
+	}
+	if err := cacheArchive(f, dest); err != nil {
+		return phases, err
+	}
+	phases.extract = time.Since(start)
+	return phases, nil
+}
+
+func (b extractBench) download(file string) error {
+	body, err := b.get()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, body); err != nil {
+		f.Close()
+		return fmt.Errorf("download failed: %w", err)
+	}
+	return f.Close()
+}
+
+// stream extracts the response body into dest while hashing it.
+func (b extractBench) stream(dest string) error {
+	body, err := b.get()
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	hash := sha256.New()
+	tee := io.TeeReader(body, hash)
+	if err := cacheArchive(tee, dest); err != nil {
+		return err
+	}
+	// Extraction stops at the end of the tar stream; hash the rest.
+	if _, err := io.Copy(io.Discard, tee); err != nil {
+		return fmt.Errorf("download failed: %w", err)
+	}
+	return b.verify(hash.Sum(nil))
+}
+
+func (b extractBench) get() (io.ReadCloser, error) {
+	resp, err := http.Get(b.url)
+	if err != nil {
+		return nil, fmt.Errorf("download failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download failed: %s", resp.Status)
+	}
+	return resp.Body, nil
+}
+
+func (b extractBench) verify(sum []byte) error {
+	if !bytes.Equal(sum, b.sum[:]) {
+		return fmt.Errorf("checksum mismatch: got %x, want %x", sum, b.sum)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package core
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testExtractBenchConfig() ExtractBenchConfig {
+	return ExtractBenchConfig{Files: 20, FileSize: 512, Depth: 2, Content: BenchContentText, Archives: 3, Parallel: 2, Runs: 1}
+}
+
+func TestExtractBenchConfig_Validate(t *testing.T) {
+	if err := testExtractBenchConfig().Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	for name, edit := range map[string]func(c *ExtractBenchConfig){
+		"no_files":   func(c *ExtractBenchConfig) { c.Files = 0 },
+		"huge_file":  func(c *ExtractBenchConfig) { c.FileSize = MaxExtractedFileSize + 1 },
+		"huge_total": func(c *ExtractBenchConfig) { c.Files, c.FileSize = 100, 64<<20 },
+		"deep":       func(c *ExtractBenchConfig) { c.Depth = 11 },
+		"content":    func(c *ExtractBenchConfig) { c.Content = "zeros" },
+		"parallel":   func(c *ExtractBenchConfig) { c.Parallel = 0 },
+		"runs":       func(c *ExtractBenchConfig) { c.Runs = 0 },
+	} {
+		cfg := testExtractBenchConfig()
+		edit(&cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: Validate() succeeded", name)
+		}
+	}
+}
+
+func TestSynthesizeArchive(t *testing.T) {
+	cfg := testExtractBenchConfig()
+	archive, extracted, err := SynthesizeArchive(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if extracted != 20*512 {
+		t.Errorf("extracted = %d", extracted)
+	}
+	again, _, _ := SynthesizeArchive(cfg)
+	if !bytes.Equal(archive, again) {
+		t.Error("archives of the same config differ")
+	}
+	cfg.Content = BenchContentRandom
+	random, _, _ := SynthesizeArchive(cfg)
+	if len(random) <= len(archive) {
+		t.Errorf("random archive (%d bytes) compressed better than text (%d bytes)", len(random), len(archive))
+	}
+
+	dest := filepath.Join(t.TempDir(), "cache")
+	if err := cacheArchive(bytes.NewReader(archive), dest); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "d3", "d1", "file-00007.md"))
+	if err != nil || len(data) != 512 || !strings.Contains(string(data), " ") {
+		t.Errorf("file-00007.md = %d bytes, %v", len(data), err)
+	}
+}
+
+func TestRunExtractBench(t *testing.T) {
+	report, err := RunExtractBench(testExtractBenchConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Results) != 4 || report.ExtractedBytes != 20*512 || report.ArchiveBytes == 0 {
+		t.Fatalf("report = %+v", report)
+	}
+	buffered := report.Result(ExtractModeBuffered, 2)
+	if buffered == nil || buffered.Download == 0 || buffered.Verify == 0 || buffered.Extract == 0 || buffered.MBPerSec <= 0 {
+		t.Errorf("buffered x2 = %+v", buffered)
+	}
+	if streaming := report.Result(ExtractModeStreaming, 1); streaming == nil || streaming.Total == 0 || streaming.Extract != 0 {
+		t.Errorf("streaming x1 = %+v", streaming)
+	}
+
+	cfg := testExtractBenchConfig()
+	cfg.Parallel = 1
+	if report, err := RunExtractBench(cfg); err != nil || len(report.Results) != 2 {
+		t.Errorf("RunExtractBench() with --parallel 1 = %v, %v", report, err)
+	}
+}
@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"syscall"
+
+	"github.com/ar4mirez/samuel/pkg/parse"
+)
+
+// ErrPathTraversal is returned for paths that escape their base directory.
+var ErrPathTraversal = errors.New("path traversal detected")
+
+// errSourceNotFound marks a requested path missing from the template.
+var errSourceNotFound = errors.New("source not found")
+
+// errPathConflict marks a destination whose type differs from the source,
+// such as a directory where the template has a file.
+var errPathConflict = errors.New("destination conflicts with template")
+
+// errStopExtract unwinds a directory walk under the fail-fast policy.
+var errStopExtract = errors.New("extraction stopped")
+
+// ExtractErrorKind categorizes why a path could not be extracted.
+type ExtractErrorKind string
+
+// Extract error kinds, in the order they are reported.
+const (
+	ExtractNotFound   ExtractErrorKind = "not-found"
+	ExtractPermission ExtractErrorKind = "permission"
+	ExtractConflict   ExtractErrorKind = "conflict"
+	ExtractTraversal  ExtractErrorKind = "traversal"
+	ExtractEncoding   ExtractErrorKind = "encoding"
+	ExtractIO         ExtractErrorKind = "io"
+)
+
+// GetExtractErrorKinds returns every error kind in reporting order.
+func GetExtractErrorKinds() []ExtractErrorKind {
+	return []ExtractErrorKind{ExtractNotFound, ExtractPermission, ExtractConflict, ExtractTraversal, ExtractEncoding, ExtractIO}
+}
+
+// ExtractPolicy decides whether extraction continues after a failed path.
+type ExtractPolicy int
+
+const (
+	// ExtractBestEffort extracts every path it can and reports all failures.
+	ExtractBestEffort ExtractPolicy = iota
+	// ExtractFailFast stops at the first failed path.
+	ExtractFailFast
+)
+
+// ExtractError is the failure to extract one path.
+type ExtractError struct {
+	// Path is relative to the destination directory.
+	Path string
+	Kind ExtractErrorKind
+	Err  error
+}
+
+func (e *ExtractError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Path, e.Err)
+}
+
+func (e *ExtractError) Unwrap() error {
+	return e.Err
+}
+
+// newExtractError wraps err for path, classifying it by cause.
+func newExtractError(path string, err error) *ExtractError {
+	kind := ExtractIO
+	switch {
+	case errors.Is(err, ErrPathTraversal):
+		kind = ExtractTraversal
+	case errors.Is(err, errPathConflict), errors.Is(err, syscall.ENOTDIR), errors.Is(err, syscall.EISDIR):
+		kind = ExtractConflict
+	case errors.Is(err, parse.ErrEncoding):
+		kind = ExtractEncoding
+	case errors.Is(err, fs.ErrPermission):
+		kind = ExtractPermission
+	case errors.Is(err, errSourceNotFound), errors.Is(err, fs.ErrNotExist):
+		kind = ExtractNotFound
+	}
+	return &ExtractError{Path: path, Kind: kind, Err: err}
+}
+
+// Failed reports whether any path could not be extracted.
+func (r *ExtractResult) Failed() bool {
+	return len(r.Errors) > 0
+}
+
+// Attempted returns how many files extraction handled, including failures.
+func (r *ExtractResult) Attempted() int {
+	return len(r.FilesCreated) + len(r.FilesSkipped) + len(r.FilesIgnored) + len(r.Errors)
+}
+
+// ErrorsByKind groups the failed paths by error kind.
+func (r *ExtractResult) ErrorsByKind() map[ExtractErrorKind][]*ExtractError {
+	groups := make(map[ExtractErrorKind][]*ExtractError)
+	for _, e := range r.Errors {
+		groups[e.Kind] = append(groups[e.Kind], e)
+	}
+	return groups
+}
+
+// Err returns nil when every path was extracted, otherwise an error
+// summarizing the failures by kind, so an incomplete install is never
+// reported as a success.
+func (r *ExtractResult) Err() error {
+	if !r.Failed() {
+		return nil
+	}
+	groups := r.ErrorsByKind()
+	var parts []string
+	for _, kind := range GetExtractErrorKinds() {
+		if n := len(groups[kind]); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, kind))
+		}
+	}
+	return fmt.Errorf("%d of %d files failed (%s)", len(r.Errors), r.Attempted(), strings.Join(parts, ", "))
+}
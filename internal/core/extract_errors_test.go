@@ -0,0 +1,123 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewExtractError_Kind(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want ExtractErrorKind
+	}{
+		{"missing source", errSourceNotFound, ExtractNotFound},
+		{"missing file", &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}, ExtractNotFound},
+		{"permission", fmt.Errorf("copy: %w", fs.ErrPermission), ExtractPermission},
+		{"conflict", fmt.Errorf("%w: a is a directory", errPathConflict), ExtractConflict},
+		{"traversal", fmt.Errorf("%w: ../x", ErrPathTraversal), ExtractTraversal},
+		{"other", errors.New("disk full"), ExtractIO},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newExtractError("a", tt.err).Kind; got != tt.want {
+				t.Errorf("Kind = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// setupConflictTemplate creates a template with three files where b.txt
+// cannot be written because the destination has a directory there.
+func setupConflictTemplate(t *testing.T) (string, string) {
+	t.Helper()
+	srcDir, destDir := t.TempDir(), t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt", "c.txt"} {
+		writeTestFile(t, filepath.Join(srcDir, TemplatePrefix, "docs", name), name)
+	}
+	if err := os.MkdirAll(filepath.Join(destDir, "docs", "b.txt"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return srcDir, destDir
+}
+
+func TestExtract_Policies(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      ExtractPolicy
+		paths       []string
+		wantCreated int
+		wantKinds   []ExtractErrorKind
+	}{
+		{
+			name:        "best effort continues past a conflict",
+			policy:      ExtractBestEffort,
+			paths:       []string{"docs", "missing.md"},
+			wantCreated: 2,
+			wantKinds:   []ExtractErrorKind{ExtractConflict, ExtractNotFound},
+		},
+		{
+			name:        "fail fast stops at the conflict",
+			policy:      ExtractFailFast,
+			paths:       []string{"docs", "missing.md"},
+			wantCreated: 1,
+			wantKinds:   []ExtractErrorKind{ExtractConflict},
+		},
+		{
+			name:      "traversal is refused",
+			policy:    ExtractBestEffort,
+			paths:     []string{"../outside.txt"},
+			wantKinds: []ExtractErrorKind{ExtractTraversal},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srcDir, destDir := setupConflictTemplate(t)
+			ext := NewExtractor(srcDir, destDir)
+			ext.SetPolicy(tt.policy)
+
+			result, err := ext.Extract(tt.paths, false)
+			if err != nil {
+				t.Fatalf("Extract() error = %v", err)
+			}
+			if len(result.FilesCreated) != tt.wantCreated {
+				t.Errorf("created %v, want %d files", result.FilesCreated, tt.wantCreated)
+			}
+			var kinds []ExtractErrorKind
+			for _, e := range result.Errors {
+				kinds = append(kinds, e.Kind)
+			}
+			if fmt.Sprint(kinds) != fmt.Sprint(tt.wantKinds) {
+				t.Errorf("error kinds = %v, want %v", kinds, tt.wantKinds)
+			}
+		})
+	}
+}
+
+func TestExtractResult_Err(t *testing.T) {
+	if err := (&ExtractResult{FilesCreated: []string{"a"}}).Err(); err != nil {
+		t.Errorf("Err() = %v, want nil", err)
+	}
+
+	result := &ExtractResult{
+		FilesCreated: []string{"a", "b"},
+		Errors: []*ExtractError{
+			newExtractError("c", errSourceNotFound),
+			newExtractError("d", fs.ErrPermission),
+			newExtractError("e", fs.ErrPermission),
+		},
+	}
+	err := result.Err()
+	if err == nil {
+		t.Fatal("Err() = nil for a partial failure")
+	}
+	if want := "3 of 5 files failed (1 not-found, 2 permission)"; !strings.Contains(err.Error(), want) {
+		t.Errorf("Err() = %q, want %q", err, want)
+	}
+}
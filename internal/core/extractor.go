@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -12,9 +13,17 @@ import (
 type Extractor struct {
 	sourcePath string
 	destPath   string
+	policy     ExtractPolicy
+	// finalNewline is the trailing newline policy for markdown files.
+	finalNewline string
+	// language selects the translation of the core instruction blocks.
+	language string
+	// ignore holds the destination project's .gitignore rules, loaded at
+	// the start of each Extract.
+	ignore *GitIgnore
 }
 
-// NewExtractor creates a new extractor
+// NewExtractor creates a new extractor with the best-effort policy
 func NewExtractor(sourcePath, destPath string) *Extractor {
 	return &Extractor{
 		sourcePath: sourcePath,
@@ -22,72 +31,114 @@ func NewExtractor(sourcePath, destPath string) *Extractor {
 	}
 }
 
+// SetPolicy chooses whether Extract stops at the first failed path
+func (e *Extractor) SetPolicy(policy ExtractPolicy) {
+	e.policy = policy
+}
+
+// SetFinalNewline chooses how markdown files end; see FinalNewlineEnsure
+func (e *Extractor) SetFinalNewline(policy string) {
+	e.finalNewline = policy
+}
+
+// SetLanguage chooses the translation of the managed blocks in the core
+// instruction files; see LocalizeCoreBlocks
+func (e *Extractor) SetLanguage(language string) {
+	e.language = language
+}
+
 // ExtractResult contains the result of an extraction
 type ExtractResult struct {
 	FilesCreated []string
 	DirsCreated  []string
 	FilesSkipped []string
-	Errors       []error
+	// FilesIgnored lists files not written because the project's
+	// .gitignore ignores them.
+	FilesIgnored []string
+	// Errors lists every path that could not be extracted; see Err.
+	Errors []*ExtractError
 }
 
 // Extract copies specific files from source to destination
 // The paths parameter contains destination paths (e.g., ".claude/skills/go-guide")
 // Source paths are calculated by prepending TemplatePrefix (e.g., "template/.claude/skills/go-guide")
+// Per-path failures are collected in the result rather than returned; the
+// returned error only reports that extraction could not start.
 func (e *Extractor) Extract(paths []string, force bool) (*ExtractResult, error) {
 	result := &ExtractResult{
 		FilesCreated: make([]string, 0),
 		DirsCreated:  make([]string, 0),
 		FilesSkipped: make([]string, 0),
-		Errors:       make([]error, 0),
+		FilesIgnored: make([]string, 0),
+		Errors:       make([]*ExtractError, 0),
 	}
 
 	// Create destination directory if it doesn't exist
 	if err := os.MkdirAll(e.destPath, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create destination directory: %w", err)
 	}
+	e.ignore = LoadGitIgnore(e.destPath)
 
 	for _, path := range paths {
-		// Source path includes template/ prefix, destination path does not
-		srcPath := filepath.Join(e.sourcePath, TemplatePrefix, path)
-		dstPath := filepath.Join(e.destPath, path)
-
-		// Check if source exists
-		srcInfo, err := os.Stat(srcPath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				result.Errors = append(result.Errors, fmt.Errorf("source not found: %s", path))
-				continue
+		if err := e.extractPath(path, result, force); err != nil {
+			if errors.Is(err, errStopExtract) || e.fail(result, path, err) {
+				break
 			}
-			result.Errors = append(result.Errors, err)
-			continue
 		}
+	}
 
-		// Handle directories
-		if srcInfo.IsDir() {
-			if err := e.extractDir(srcPath, dstPath, result, force); err != nil {
-				result.Errors = append(result.Errors, err)
-			}
-			continue
-		}
+	return result, nil
+}
 
-		// Handle files
-		if err := e.extractFile(srcPath, dstPath, result, force); err != nil {
-			result.Errors = append(result.Errors, err)
-		}
+// extractPath extracts one requested file or directory.
+func (e *Extractor) extractPath(path string, result *ExtractResult, force bool) error {
+	// Source path includes template/ prefix, destination path does not
+	srcPath, err := validateContainedPath(filepath.Join(e.sourcePath, TemplatePrefix), path)
+	if err != nil {
+		return err
+	}
+	dstPath, err := validateContainedPath(e.destPath, path)
+	if err != nil {
+		return err
 	}
 
-	return result, nil
+	srcInfo, err := os.Stat(srcPath)
+	if os.IsNotExist(err) {
+		return errSourceNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if srcInfo.IsDir() {
+		return e.extractDir(srcPath, dstPath, result, force)
+	}
+	return e.extractFile(srcPath, dstPath, result, force)
+}
+
+// fail records a failed path and reports whether extraction should stop.
+func (e *Extractor) fail(result *ExtractResult, path string, err error) bool {
+	result.Errors = append(result.Errors, newExtractError(filepath.ToSlash(path), err))
+	return e.policy == ExtractFailFast
 }
 
 // extractFile copies a single file
 func (e *Extractor) extractFile(srcPath, dstPath string, result *ExtractResult, force bool) error {
+	relPath, err := filepath.Rel(e.destPath, dstPath)
+	if err != nil {
+		return fmt.Errorf("failed to compute relative path for %s: %w", dstPath, err)
+	}
+	if e.ignore.Ignored(relPath) {
+		result.FilesIgnored = append(result.FilesIgnored, relPath)
+		return nil
+	}
+
 	// Check if destination exists
-	if _, err := os.Stat(dstPath); err == nil {
+	if info, err := os.Stat(dstPath); err == nil {
+		if info.IsDir() {
+			return fmt.Errorf("%w: %s is a directory", errPathConflict, relPath)
+		}
 		if !force {
-			relPath, err := filepath.Rel(e.destPath, dstPath)
-			if err != nil {
-				return fmt.Errorf("failed to compute relative path for %s: %w", dstPath, err)
-			}
 			result.FilesSkipped = append(result.FilesSkipped, relPath)
 			return nil
 		}
@@ -99,52 +150,74 @@ func (e *Extractor) extractFile(srcPath, dstPath string, result *ExtractResult,
 		return fmt.Errorf("failed to create directory %s: %w", parentDir, err)
 	}
 
-	// Copy file
-	if err := copyFile(srcPath, dstPath); err != nil {
+	// Copy file; markdown is normalized to BOM-free UTF-8 with LF endings
+	write := copyFile
+	if isMarkdownFile(srcPath) {
+		write = func(src, dst string) error { return copyMarkdown(src, dst, e.finalNewline, e.localizer(relPath)) }
+	}
+	if err := write(srcPath, dstPath); err != nil {
 		return fmt.Errorf("failed to copy %s: %w", srcPath, err)
 	}
 
-	relPath, err := filepath.Rel(e.destPath, dstPath)
-	if err != nil {
-		return fmt.Errorf("failed to compute relative path for %s: %w", dstPath, err)
-	}
 	result.FilesCreated = append(result.FilesCreated, relPath)
 
 	return nil
 }
 
-// extractDir recursively copies a directory
+// extractDir recursively copies a directory. A failed file is recorded and
+// the walk continues unless the policy is fail-fast, in which case
+// errStopExtract is returned.
 func (e *Extractor) extractDir(srcPath, dstPath string, result *ExtractResult, force bool) error {
-	// Create destination directory
-	if err := os.MkdirAll(dstPath, 0755); err != nil {
-		return fmt.Errorf("failed to create directory %s: %w", dstPath, err)
-	}
-
 	relDir, err := filepath.Rel(e.destPath, dstPath)
 	if err != nil {
 		return fmt.Errorf("failed to compute relative path for %s: %w", dstPath, err)
 	}
-	result.DirsCreated = append(result.DirsCreated, relDir)
 
-	return filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
+	// Create destination directory unless .gitignore excludes it; its
+	// files are still visited so they are reported as ignored.
+	if !e.ignore.IgnoredDir(relDir) {
+		if err := os.MkdirAll(dstPath, 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dstPath, err)
 		}
+		result.DirsCreated = append(result.DirsCreated, relDir)
+	}
 
+	return filepath.Walk(srcPath, func(path string, info os.FileInfo, err error) error {
 		// Calculate relative path from source
-		relPath, err := filepath.Rel(srcPath, path)
-		if err != nil {
-			return err
+		relPath, relErr := filepath.Rel(srcPath, path)
+		if relErr != nil {
+			return relErr
 		}
-
 		destPath := filepath.Join(dstPath, relPath)
 
-		if info.IsDir() {
-			return os.MkdirAll(destPath, info.Mode())
+		if err == nil {
+			err = e.extractEntry(path, destPath, filepath.Join(relDir, relPath), info, result, force)
+		}
+		if err == nil || errors.Is(err, filepath.SkipDir) {
+			return err
+		}
+		if e.fail(result, filepath.Join(relDir, relPath), err) {
+			return errStopExtract
+		}
+		if info != nil && info.IsDir() {
+			return filepath.SkipDir
 		}
+		return nil
+	})
+}
 
+// extractEntry handles one entry of a directory walk.
+func (e *Extractor) extractEntry(path, destPath, rel string, info os.FileInfo, result *ExtractResult, force bool) error {
+	if !info.IsDir() {
 		return e.extractFile(path, destPath, result, force)
-	})
+	}
+	if e.ignore.IgnoredDir(rel) {
+		return nil
+	}
+	if dst, err := os.Stat(destPath); err == nil && !dst.IsDir() {
+		return fmt.Errorf("%w: %s is a file", errPathConflict, rel)
+	}
+	return os.MkdirAll(destPath, info.Mode())
 }
 
 // ExtractAll extracts all framework files from the template/ directory
@@ -218,7 +291,7 @@ func validateContainedPath(baseDir, relativePath string) (string, error) {
 	fullPath := filepath.Clean(filepath.Join(cleanBase, relativePath))
 	// The resolved path must equal the base or be under it
 	if fullPath != cleanBase && !strings.HasPrefix(fullPath, cleanBase+string(os.PathSeparator)) {
-		return "", fmt.Errorf("path traversal detected: %q escapes base directory", relativePath)
+		return "", fmt.Errorf("%w: %q escapes base directory", ErrPathTraversal, relativePath)
 	}
 	return fullPath, nil
 }
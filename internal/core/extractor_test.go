@@ -454,6 +454,8 @@ func TestShouldSkip(t *testing.T) {
 		{".gitignore", true},
 		{"node_modules", true},
 		{"sub/node_modules/pkg", true},
+		{".claude/skills/build/SKILL.md", false},
+		{"template/dist/app.js", false},
 		{"CLAUDE.md", false},
 		{".claude/skills/go-guide", false},
 		{"src/main.go", false},
@@ -480,15 +482,29 @@ func TestNewExtractor_Getters(t *testing.T) {
 // createTemplateFile is a helper that creates a file inside source/template/path
 func createTemplateFile(t *testing.T, sourceDir, path, content string) {
 	t.Helper()
-	fullPath := filepath.Join(sourceDir, TemplatePrefix, path)
-	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+	writeTestFile(t, filepath.Join(sourceDir, TemplatePrefix, path), content)
+}
+
+// writeTestFile writes content to path, creating parent directories.
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		t.Fatal(err)
 	}
-	if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 		t.Fatal(err)
 	}
 }
 
+// writeTestFiles writes files, keyed by slash-separated path relative to
+// dir, with writeTestFile.
+func writeTestFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		writeTestFile(t, filepath.Join(dir, filepath.FromSlash(rel)), content)
+	}
+}
+
 func TestExtract_SingleFile(t *testing.T) {
 	srcDir := t.TempDir()
 	destDir := t.TempDir()
@@ -619,6 +635,34 @@ func TestExtract_Directory(t *testing.T) {
 	}
 }
 
+func TestExtract_SkipsGitIgnoredPaths(t *testing.T) {
+	srcDir := t.TempDir()
+	destDir := t.TempDir()
+	createTemplateFile(t, srcDir, "CLAUDE.md", "# Instructions")
+	createTemplateFile(t, srcDir, "AGENTS.md", "# Agents")
+	createTemplateFile(t, srcDir, ".claude/skills/go-guide/SKILL.md", "# Go Guide")
+	if err := os.WriteFile(filepath.Join(destDir, ".gitignore"), []byte("AGENTS.md\n.claude/skills/\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ext := NewExtractor(srcDir, destDir)
+	result, err := ext.Extract([]string{"CLAUDE.md", "AGENTS.md", ".claude/skills/go-guide"}, true)
+	if err != nil {
+		t.Fatalf("Extract: %v", err)
+	}
+	if len(result.FilesCreated) != 1 || result.FilesCreated[0] != "CLAUDE.md" {
+		t.Errorf("FilesCreated = %v, want [CLAUDE.md]", result.FilesCreated)
+	}
+	if len(result.FilesIgnored) != 2 {
+		t.Fatalf("FilesIgnored = %v, want 2 files", result.FilesIgnored)
+	}
+	for _, rel := range []string{"AGENTS.md", ".claude/skills"} {
+		if _, err := os.Stat(filepath.Join(destDir, rel)); !os.IsNotExist(err) {
+			t.Errorf("%s should not be written, stat err = %v", rel, err)
+		}
+	}
+}
+
 func TestExtractAll_WithFiles(t *testing.T) {
 	srcDir := t.TempDir()
 	destDir := t.TempDir()
@@ -635,6 +679,23 @@ func TestExtractAll_WithFiles(t *testing.T) {
 	}
 }
 
+func TestExtractAll_KeepsTemplateBuildDirs(t *testing.T) {
+	srcDir := t.TempDir()
+	destDir := t.TempDir()
+	for _, rel := range []string{".claude/skills/build/SKILL.md", ".claude/skills/vendor/SKILL.md", ".claude/skills/dist/SKILL.md"} {
+		createTemplateFile(t, srcDir, rel, "skill")
+	}
+
+	ext := NewExtractor(srcDir, destDir)
+	result, err := ext.ExtractAll(false)
+	if err != nil {
+		t.Fatalf("ExtractAll: %v", err)
+	}
+	if len(result.FilesCreated) != 3 {
+		t.Errorf("expected 3 files created, got %d: %v", len(result.FilesCreated), result.FilesCreated)
+	}
+}
+
 func TestExtractAll_SkipsGitAndNodeModules(t *testing.T) {
 	srcDir := t.TempDir()
 	destDir := t.TempDir()
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Artifact kinds that 'samuel gc' prunes.
+const (
+	GCBackups   = "backups"
+	GCEvents    = "events"
+	GCOverrides = "overrides"
+)
+
+// GetSupportedGCKinds returns the artifact kinds accepted by 'samuel gc --only'.
+func GetSupportedGCKinds() []string {
+	return []string{GCBackups, GCEvents, GCOverrides}
+}
+
+// BackupDirPrefix starts the name of each backup directory 'samuel update'
+// creates in the project root.
+const BackupDirPrefix = ".samuel-backup-"
+
+// RetentionPolicy limits how many artifacts of a kind are kept. An
+// artifact is removed when it is beyond the newest Keep, older than MaxAge
+// (e.g. "30d", "72h"), or would push the total past MaxSize (e.g. "500MB").
+// Zero values disable a limit. The newest artifact is always kept.
+type RetentionPolicy struct {
+	Keep    int    `yaml:"keep,omitempty"`
+	MaxAge  string `yaml:"max_age,omitempty"`
+	MaxSize string `yaml:"max_size,omitempty"`
+}
+
+// GCConfig is the gc section of samuel.yaml. Kinds left out use
+// DefaultRetentionPolicy.
+type GCConfig struct {
+	Backups   *RetentionPolicy `yaml:"backups,omitempty"`
+	Events    *RetentionPolicy `yaml:"events,omitempty"`
+	Overrides *RetentionPolicy `yaml:"overrides,omitempty"`
+}
+
+// DefaultRetentionPolicy returns the built-in policy for an artifact kind.
+func DefaultRetentionPolicy(kind string) RetentionPolicy {
+	switch kind {
+	case GCBackups:
+		return RetentionPolicy{Keep: 5, MaxAge: "90d", MaxSize: "500MB"}
+	case GCOverrides:
+		return RetentionPolicy{Keep: 1000, MaxAge: "365d", MaxSize: "10MB"}
+	}
+	return RetentionPolicy{Keep: 5000, MaxAge: "30d", MaxSize: "50MB"}
+}
+
+// Policy returns the configured policy for kind, or its default.
+func (g *GCConfig) Policy(kind string) RetentionPolicy {
+	var p *RetentionPolicy
+	if g != nil {
+		switch kind {
+		case GCBackups:
+			p = g.Backups
+		case GCEvents:
+			p = g.Events
+		case GCOverrides:
+			p = g.Overrides
+		}
+	}
+	if p == nil {
+		return DefaultRetentionPolicy(kind)
+	}
+	return *p
+}
+
+// GCArtifact is one backup directory or one entry of a JSONL log.
+type GCArtifact struct {
+	Kind    string
+	Path    string // relative to the project root
+	Line    int    // 1-based line of a log entry; 0 for a whole path
+	ModTime time.Time
+	Size    int64
+}
+
+// Location returns the artifact's path, with the line for log entries.
+func (a GCArtifact) Location() string {
+	if a.Line > 0 {
+		return fmt.Sprintf("%s:%d", a.Path, a.Line)
+	}
+	return a.Path
+}
+
+// ListGCArtifacts returns the artifacts of kind in the project, newest
+// first.
+func ListGCArtifacts(projectDir, kind string) ([]GCArtifact, error) {
+	if log, ok := gcLogFiles[kind]; ok {
+		return listLogEntries(projectDir, kind, log)
+	}
+	dir, prefix := projectDir, BackupDirPrefix
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []GCArtifact
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.Name(), prefix) || !entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		rel, _ := filepath.Rel(projectDir, path)
+		artifacts = append(artifacts, GCArtifact{Kind: kind, Path: filepath.ToSlash(rel), ModTime: info.ModTime(), Size: pathSize(path)})
+	}
+	slices.SortFunc(artifacts, func(a, b GCArtifact) int { return b.ModTime.Compare(a.ModTime) })
+	return artifacts, nil
+}
+
+func pathSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// SelectExpired returns the artifacts (sorted newest first) that the
+// policy removes at time now.
+func (p RetentionPolicy) SelectExpired(artifacts []GCArtifact, now time.Time) ([]GCArtifact, error) {
+	maxAge, err := ParseRetentionAge(p.MaxAge)
+	if err != nil {
+		return nil, err
+	}
+	maxSize, err := ParseByteSize(p.MaxSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []GCArtifact
+	var total int64
+	for i, a := range artifacts {
+		total += a.Size
+		if i == 0 {
+			continue
+		}
+		if (p.Keep > 0 && i >= p.Keep) || (maxAge > 0 && now.Sub(a.ModTime) > maxAge) || (maxSize > 0 && total > maxSize) {
+			expired = append(expired, a)
+			total -= a.Size
+		}
+	}
+	return expired, nil
+}
+
+// ParseRetentionAge parses a duration that may also use a "d" (day)
+// suffix. An empty string means no limit.
+func ParseRetentionAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid max_age: %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid max_age: %q", s)
+	}
+	return d, nil
+}
+
+// ParseByteSize parses sizes such as "500MB", "1GB", "64KB", or a plain
+// byte count, using powers of 1024. An empty string means no limit.
+func ParseByteSize(size string) (int64, error) {
+	s := strings.ToUpper(strings.TrimSpace(size))
+	if s == "" {
+		return 0, nil
+	}
+	multiplier := int64(1)
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1}} {
+		if strings.HasSuffix(s, unit.suffix) {
+			s, multiplier = strings.TrimSpace(strings.TrimSuffix(s, unit.suffix)), unit.size
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid max_size: %q", size)
+	}
+	return n * multiplier, nil
+}
+
+// GCResult lists what a collection removed, or would remove.
+type GCResult struct {
+	Removed    []GCArtifact
+	FreedBytes int64
+}
+
+// CollectGarbage applies each kind's retention policy in the project.
+// Expired log entries are dropped by rewriting the log. With dryRun
+// nothing is deleted.
+func CollectGarbage(projectDir string, cfg *GCConfig, kinds []string, now time.Time, dryRun bool) (*GCResult, error) {
+	result := &GCResult{}
+	for _, kind := range kinds {
+		artifacts, err := ListGCArtifacts(projectDir, kind)
+		if err != nil {
+			return result, fmt.Errorf("failed to list %s: %w", kind, err)
+		}
+		expired, err := cfg.Policy(kind).SelectExpired(artifacts, now)
+		if err != nil {
+			return result, fmt.Errorf("invalid %s retention policy: %w", kind, err)
+		}
+		if log, ok := gcLogFiles[kind]; ok {
+			if !dryRun && len(expired) > 0 {
+				if err := pruneLogEntries(projectDir, log, expired); err != nil {
+					return result, fmt.Errorf("failed to prune %s: %w", log, err)
+				}
+			}
+			result.Removed = append(result.Removed, expired...)
+			for _, a := range expired {
+				result.FreedBytes += a.Size
+			}
+			continue
+		}
+		for _, a := range expired {
+			if !dryRun {
+				if err := os.RemoveAll(filepath.Join(projectDir, filepath.FromSlash(a.Path))); err != nil {
+					return result, fmt.Errorf("failed to remove %s: %w", a.Path, err)
+				}
+			}
+			result.Removed = append(result.Removed, a)
+			result.FreedBytes += a.Size
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// gcLogFiles maps the log kinds 'samuel gc' prunes entry by entry to their
+// files, relative to the project root.
+var gcLogFiles = map[string]string{
+	GCEvents:    AutoDir + "/" + AutoEventLogFile,
+	GCOverrides: PolicyAuditFile,
+}
+
+// listLogEntries returns one artifact per entry of a JSONL log, newest
+// first. Lines without a readable timestamp are not listed, so they are
+// never pruned.
+func listLogEntries(projectDir, kind, log string) ([]GCArtifact, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(log)))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var artifacts []GCArtifact
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		var entry struct {
+			Timestamp string `json:"timestamp"`
+		}
+		if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &entry) != nil {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, entry.Timestamp)
+		if err != nil {
+			continue
+		}
+		artifacts = append(artifacts, GCArtifact{Kind: kind, Path: log, Line: i + 1, ModTime: ts, Size: int64(len(line)) + 1})
+	}
+	slices.SortStableFunc(artifacts, func(a, b GCArtifact) int {
+		if c := b.ModTime.Compare(a.ModTime); c != 0 {
+			return c
+		}
+		return b.Line - a.Line
+	})
+	return artifacts, nil
+}
+
+// pruneLogEntries rewrites a JSONL log without the expired entries.
+func pruneLogEntries(projectDir, log string, expired []GCArtifact) error {
+	path := filepath.Join(projectDir, filepath.FromSlash(log))
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	drop := make(map[int]bool, len(expired))
+	for _, a := range expired {
+		drop[a.Line] = true
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	kept := lines[:0]
+	for i, line := range lines {
+		if !drop[i+1] {
+			kept = append(kept, line)
+		}
+	}
+	return writeFileAtomic(path, bytes.Join(kept, []byte("\n")), 0644, false)
+}
@@ -0,0 +1,224 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRetentionAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "30d", want: 30 * 24 * time.Hour},
+		{in: "72h", want: 72 * time.Hour},
+		{in: "-1d", wantErr: true},
+		{in: "week", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseRetentionAge(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseRetentionAge(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: "512", want: 512},
+		{in: "64KB", want: 64 << 10},
+		{in: "500 mb", want: 500 << 20},
+		{in: "1GB", want: 1 << 30},
+		{in: "lots", wantErr: true},
+		{in: "-5MB", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseByteSize(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetentionPolicy_SelectExpired(t *testing.T) {
+	now := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	artifacts := []GCArtifact{
+		{Path: "a", ModTime: now.Add(-1 * time.Hour), Size: 100},
+		{Path: "b", ModTime: now.Add(-48 * time.Hour), Size: 100},
+		{Path: "c", ModTime: now.Add(-10 * 24 * time.Hour), Size: 100},
+		{Path: "d", ModTime: now.Add(-40 * 24 * time.Hour), Size: 100},
+	}
+	tests := []struct {
+		name    string
+		policy  RetentionPolicy
+		want    []string
+		wantErr bool
+	}{
+		{name: "no_limits", policy: RetentionPolicy{}, want: nil},
+		{name: "keep", policy: RetentionPolicy{Keep: 2}, want: []string{"c", "d"}},
+		{name: "max_age", policy: RetentionPolicy{MaxAge: "7d"}, want: []string{"c", "d"}},
+		{name: "max_size", policy: RetentionPolicy{MaxSize: "250B"}, want: []string{"c", "d"}},
+		{name: "newest_always_kept", policy: RetentionPolicy{Keep: 1, MaxAge: "1m", MaxSize: "10B"}, want: []string{"b", "c", "d"}},
+		{name: "combined", policy: RetentionPolicy{Keep: 3, MaxAge: "30d"}, want: []string{"d"}},
+		{name: "invalid_age", policy: RetentionPolicy{MaxAge: "soon"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired, err := tt.policy.SelectExpired(artifacts, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			var got []string
+			for _, a := range expired {
+				got = append(got, a.Path)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("expired = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGCConfig_Policy(t *testing.T) {
+	var nilConfig *GCConfig
+	if got := nilConfig.Policy(GCBackups); got != DefaultRetentionPolicy(GCBackups) {
+		t.Errorf("nil config policy = %+v, want default", got)
+	}
+	custom := &RetentionPolicy{Keep: 2}
+	cfg := &GCConfig{Events: custom}
+	if got := cfg.Policy(GCEvents); got != *custom {
+		t.Errorf("events policy = %+v, want %+v", got, *custom)
+	}
+	if got := cfg.Policy(GCOverrides); got != DefaultRetentionPolicy(GCOverrides) {
+		t.Errorf("overrides policy = %+v, want default", got)
+	}
+}
+
+// writeGCArtifact creates a file at rel under dir with the given age.
+func writeGCArtifact(t *testing.T, dir, rel string, age time.Duration, now time.Time) {
+	t.Helper()
+	path := filepath.Join(dir, filepath.FromSlash(rel))
+	writeTestFile(t, path, "artifact")
+	mtime := now.Add(-age)
+	if err := os.Chtimes(path, mtime, mtime); err != nil {
+		t.Fatal(err)
+	}
+	if top := strings.SplitN(rel, "/", 2)[0]; strings.HasPrefix(top, BackupDirPrefix) {
+		if err := os.Chtimes(filepath.Join(dir, top), mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// writeGCLog writes a JSONL log at rel under dir with one entry per age,
+// oldest first, followed by a line without a timestamp.
+func writeGCLog(t *testing.T, dir, rel string, now time.Time, ages ...time.Duration) {
+	t.Helper()
+	var b strings.Builder
+	for i := len(ages) - 1; i >= 0; i-- {
+		fmt.Fprintf(&b, "{\"timestamp\":%q,\"n\":%d}\n", now.Add(-ages[i]).UTC().Format(time.RFC3339), i)
+	}
+	b.WriteString("not json\n")
+	writeTestFile(t, filepath.Join(dir, filepath.FromSlash(rel)), b.String())
+}
+
+func TestCollectGarbage(t *testing.T) {
+	now := time.Now()
+	cfg := &GCConfig{
+		Backups: &RetentionPolicy{Keep: 1},
+		Events:  &RetentionPolicy{MaxAge: "7d"},
+	}
+	eventLog := AutoDir + "/" + AutoEventLogFile
+	setup := func(t *testing.T) string {
+		dir := t.TempDir()
+		writeGCArtifact(t, dir, BackupDirPrefix+"new/CLAUDE.md", time.Hour, now)
+		writeGCArtifact(t, dir, BackupDirPrefix+"old/CLAUDE.md", 48*time.Hour, now)
+		writeGCLog(t, dir, eventLog, now, time.Hour, 10*24*time.Hour)
+		writeGCLog(t, dir, PolicyAuditFile, now, 400*24*time.Hour, 500*24*time.Hour)
+		writeGCArtifact(t, dir, "notes.md", 100*24*time.Hour, now)
+		return dir
+	}
+	readLog := func(t *testing.T, dir, rel string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	t.Run("dry_run", func(t *testing.T) {
+		dir := setup(t)
+		before := readLog(t, dir, eventLog)
+		result, err := CollectGarbage(dir, cfg, GetSupportedGCKinds(), now, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Removed) != 3 {
+			t.Fatalf("removed = %+v, want the old backup, a stale event and an old override", result.Removed)
+		}
+		if _, err := os.Stat(filepath.Join(dir, BackupDirPrefix+"old")); err != nil {
+			t.Error("dry run should not delete the old backup")
+		}
+		if readLog(t, dir, eventLog) != before {
+			t.Error("dry run should not rewrite the event log")
+		}
+	})
+
+	t.Run("removes_expired", func(t *testing.T) {
+		dir := setup(t)
+		if _, err := CollectGarbage(dir, cfg, GetSupportedGCKinds(), now, false); err != nil {
+			t.Fatal(err)
+		}
+		for rel, want := range map[string]bool{
+			BackupDirPrefix + "new": true,
+			BackupDirPrefix + "old": false,
+			"notes.md":              true,
+		} {
+			_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(rel)))
+			if exists := err == nil; exists != want {
+				t.Errorf("%s exists = %v, want %v", rel, exists, want)
+			}
+		}
+		events := readLog(t, dir, eventLog)
+		if strings.Contains(events, `"n":1`) || !strings.Contains(events, `"n":0`) || !strings.Contains(events, "not json") {
+			t.Errorf("event log = %q, want only the stale entry dropped", events)
+		}
+		overrides := readLog(t, dir, PolicyAuditFile)
+		if strings.Contains(overrides, `"n":1`) || !strings.Contains(overrides, `"n":0`) {
+			t.Errorf("override log = %q, want the newest entry kept and the older one dropped", overrides)
+		}
+	})
+
+	t.Run("only_selected_kinds", func(t *testing.T) {
+		dir := setup(t)
+		result, err := CollectGarbage(dir, cfg, []string{GCEvents}, now, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.Removed) != 1 || result.Removed[0].Location() != eventLog+":1" {
+			t.Errorf("removed = %+v, want only the stale event", result.Removed)
+		}
+	})
+}
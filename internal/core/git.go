@@ -0,0 +1,183 @@
+package core
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// GitState describes whether a directory is ready for agent commits.
+type GitState struct {
+	IsRepo     bool
+	HasCommits bool
+	UserName   string
+	UserEmail  string
+}
+
+// InspectGitState reports the git state of dir. A missing git binary is
+// treated the same as a directory that is not a repository.
+func InspectGitState(dir string) GitState {
+	var state GitState
+	if _, err := runGit(dir, "rev-parse", "--is-inside-work-tree"); err != nil {
+		return state
+	}
+	state.IsRepo = true
+	state.HasCommits = GitHeadSHA(dir) != ""
+	state.UserName, _ = runGit(dir, "config", "user.name")
+	state.UserEmail, _ = runGit(dir, "config", "user.email")
+	return state
+}
+
+// HasUserIdentity reports whether commits can be authored in the repo.
+func (s GitState) HasUserIdentity() bool {
+	return s.UserName != "" && s.UserEmail != ""
+}
+
+// Problems lists what prevents the agent from committing, in fix order.
+func (s GitState) Problems() []string {
+	if !s.IsRepo {
+		return []string{"directory is not a git repository"}
+	}
+	var problems []string
+	if !s.HasUserIdentity() {
+		problems = append(problems, "git user.name and user.email are not configured")
+	}
+	if !s.HasCommits {
+		problems = append(problems, "repository has no commits")
+	}
+	return problems
+}
+
+// GitInit initializes a new repository in dir.
+func GitInit(dir string) error {
+	if out, err := runGit(dir, "init"); err != nil {
+		return fmt.Errorf("git init failed: %s", out)
+	}
+	return nil
+}
+
+// GitSetUserIdentity sets user.name and user.email in the repo's local config.
+func GitSetUserIdentity(dir, name, email string) error {
+	if out, err := runGit(dir, "config", "user.name", name); err != nil {
+		return fmt.Errorf("failed to set git user.name: %s", out)
+	}
+	if out, err := runGit(dir, "config", "user.email", email); err != nil {
+		return fmt.Errorf("failed to set git user.email: %s", out)
+	}
+	return nil
+}
+
+// GitInitialCommit creates an empty root commit so HEAD exists.
+func GitInitialCommit(dir, message string) error {
+	if out, err := runGit(dir, "commit", "--allow-empty", "-m", message); err != nil {
+		return fmt.Errorf("failed to create initial commit: %s", out)
+	}
+	return nil
+}
+
+// GitHeadSHA returns the current HEAD commit of dir, or "" if unavailable.
+func GitHeadSHA(dir string) string {
+	sha, err := runGit(dir, "rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return sha
+}
+
+// GitMergeBase returns the best common ancestor of ref and HEAD in dir.
+func GitMergeBase(dir, ref string) (string, error) {
+	out, err := runGit(dir, "merge-base", ref, "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("git merge-base %s HEAD failed: %s", ref, out)
+	}
+	return out, nil
+}
+
+// GitDiffShortStat summarizes changes between fromSHA and HEAD in dir,
+// e.g. "3 files changed, 40 insertions(+), 2 deletions(-)".
+func GitDiffShortStat(dir, fromSHA string) string {
+	if fromSHA == "" {
+		return ""
+	}
+	stat, err := runGit(dir, "diff", "--shortstat", fromSHA, "HEAD")
+	if err != nil {
+		return ""
+	}
+	return stat
+}
+
+// GitChangedPaths lists paths with uncommitted changes in dir, including
+// untracked files. Renames report the new path.
+func GitChangedPaths(dir string) ([]string, error) {
+	out, err := runGit(dir, "status", "--porcelain", "--untracked-files=all")
+	if err != nil {
+		return nil, fmt.Errorf("git status failed: %s", out)
+	}
+	var paths []string
+	for _, line := range strings.Split(out, "\n") {
+		if len(line) < 3 {
+			continue
+		}
+		// runGit trims the output, so the first line may have lost the
+		// leading space of its two-character status.
+		path := strings.TrimSpace(line[2:])
+		if _, renamed, ok := strings.Cut(path, " -> "); ok {
+			path = renamed
+		}
+		paths = append(paths, strings.Trim(path, `"`))
+	}
+	return paths, nil
+}
+
+// GitCommitSubjects returns the subjects of commits after fromSHA up to
+// HEAD, oldest first. An empty fromSHA covers all of HEAD's history.
+func GitCommitSubjects(dir, fromSHA string) ([]string, error) {
+	if GitHeadSHA(dir) == "" {
+		return nil, nil
+	}
+	rangeArg := "HEAD"
+	if fromSHA != "" {
+		rangeArg = fromSHA + "..HEAD"
+	}
+	out, err := runGit(dir, "log", "--reverse", "--format=%s", rangeArg)
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %s", out)
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// GitCommittedPaths lists the paths changed by commits after fromSHA up
+// to HEAD. An empty fromSHA covers all of HEAD's history.
+func GitCommittedPaths(dir, fromSHA string) ([]string, error) {
+	if GitHeadSHA(dir) == "" {
+		return nil, nil
+	}
+	if fromSHA == "" {
+		fromSHA = gitEmptyTree
+	}
+	out, err := runGit(dir, "diff", "--name-only", "--no-renames", fromSHA, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %s", out)
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// runGit runs git in dir and returns its trimmed combined output.
+func runGit(dir string, args ...string) (string, error) {
+	out, err := exec.Command("git", append([]string{"-C", dir}, args...)...).CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
+
+// runGitInput runs git in dir with input on stdin.
+func runGitInput(dir, input string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out)), err
+}
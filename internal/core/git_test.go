@@ -0,0 +1,92 @@
+package core
+
+import (
+	"os/exec"
+	"testing"
+)
+
+// requireGit skips tests that need a git binary.
+func requireGit(t *testing.T) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+}
+
+// isolateGitConfig keeps global and system git config out of the test.
+func isolateGitConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_GLOBAL", "/dev/null")
+}
+
+func TestGitState_Problems(t *testing.T) {
+	tests := []struct {
+		name  string
+		state GitState
+		want  int
+	}{
+		{"not a repo", GitState{}, 1},
+		{"fresh repo", GitState{IsRepo: true}, 2},
+		{"no commits", GitState{IsRepo: true, UserName: "a", UserEmail: "a@b"}, 1},
+		{"ready", GitState{IsRepo: true, HasCommits: true, UserName: "a", UserEmail: "a@b"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.state.Problems(); len(got) != tt.want {
+				t.Errorf("Problems() = %v, want %d problems", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInspectGitState_Lifecycle(t *testing.T) {
+	requireGit(t)
+	isolateGitConfig(t)
+	dir := t.TempDir()
+
+	if state := InspectGitState(dir); state.IsRepo {
+		t.Fatal("empty temp dir should not be a repo")
+	}
+
+	if err := GitInit(dir); err != nil {
+		t.Fatalf("GitInit: %v", err)
+	}
+	state := InspectGitState(dir)
+	if !state.IsRepo || state.HasCommits || state.HasUserIdentity() {
+		t.Fatalf("after init got %+v, want repo without commits or identity", state)
+	}
+
+	if err := GitSetUserIdentity(dir, "Dev", "dev@example.com"); err != nil {
+		t.Fatalf("GitSetUserIdentity: %v", err)
+	}
+	if err := GitInitialCommit(dir, "chore: initial commit"); err != nil {
+		t.Fatalf("GitInitialCommit: %v", err)
+	}
+
+	state = InspectGitState(dir)
+	if len(state.Problems()) != 0 {
+		t.Errorf("expected ready repo, got problems %v", state.Problems())
+	}
+	if state.UserName != "Dev" || state.UserEmail != "dev@example.com" {
+		t.Errorf("identity = %q <%q>", state.UserName, state.UserEmail)
+	}
+	if GitHeadSHA(dir) == "" {
+		t.Error("expected HEAD SHA after initial commit")
+	}
+}
+
+func TestGitDiffShortStat_NoSHA(t *testing.T) {
+	if got := GitDiffShortStat(t.TempDir(), ""); got != "" {
+		t.Errorf("GitDiffShortStat with empty SHA = %q, want empty", got)
+	}
+}
+
+func TestGitHeadSHA_NotARepo(t *testing.T) {
+	if got := GitHeadSHA(t.TempDir()); got != "" {
+		t.Errorf("GitHeadSHA outside a repo = %q, want empty", got)
+	}
+}
@@ -0,0 +1,150 @@
+package core
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// defaultSkipDirs are dependency, build, and cache directories that
+// project scans never descend into, whether or not .gitignore lists them.
+var defaultSkipDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, "target": true,
+	"dist": true, "build": true, "__pycache__": true, ".venv": true,
+	"venv": true, "coverage": true, ".next": true, ".nuxt": true,
+	".cache": true, ".gradle": true,
+}
+
+// IsDefaultSkipDir reports whether a directory name is on the built-in
+// skip list.
+func IsDefaultSkipDir(name string) bool {
+	return defaultSkipDirs[name]
+}
+
+// GitIgnore matches slash-separated paths, relative to the project root,
+// against the project's .gitignore and .git/info/exclude. Nested
+// .gitignore files and the global excludes file are not read. A nil
+// *GitIgnore ignores nothing.
+type GitIgnore struct {
+	rules []gitIgnoreRule
+}
+
+type gitIgnoreRule struct {
+	pattern  string
+	negate   bool
+	dirOnly  bool
+	anchored bool
+}
+
+// LoadGitIgnore reads the ignore rules of the project in projectDir.
+// Missing files contribute no rules.
+func LoadGitIgnore(projectDir string) *GitIgnore {
+	g := &GitIgnore{}
+	for _, name := range []string{filepath.Join(".git", "info", "exclude"), ".gitignore"} {
+		if data, err := os.ReadFile(filepath.Join(projectDir, name)); err == nil {
+			g.rules = append(g.rules, ParseGitIgnore(string(data)).rules...)
+		}
+	}
+	return g
+}
+
+// ParseGitIgnore parses .gitignore content.
+func ParseGitIgnore(content string) *GitIgnore {
+	g := &GitIgnore{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := gitIgnoreRule{}
+		if strings.HasPrefix(line, "!") {
+			rule.negate = true
+			line = line[1:]
+		}
+		line = strings.TrimPrefix(line, `\`)
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		// A slash anywhere but the end anchors the pattern to the root.
+		rule.anchored = strings.Contains(line, "/")
+		rule.pattern = strings.TrimPrefix(line, "/")
+		if rule.pattern != "" {
+			g.rules = append(g.rules, rule)
+		}
+	}
+	return g
+}
+
+// Match reports whether relPath itself is ignored. The last matching rule
+// wins, so later negations re-include earlier matches.
+func (g *GitIgnore) Match(relPath string, isDir bool) bool {
+	if g == nil {
+		return false
+	}
+	relPath = strings.Trim(filepath.ToSlash(relPath), "/")
+	ignored := false
+	for _, r := range g.rules {
+		if r.dirOnly && !isDir {
+			continue
+		}
+		target := relPath
+		if !r.anchored {
+			target = path.Base(relPath)
+		}
+		if matchGlobPath(r.pattern, target) {
+			ignored = !r.negate
+		}
+	}
+	return ignored
+}
+
+// Ignored reports whether the file at relPath is ignored, either directly
+// or because one of its parent directories is. Like git, a file inside an
+// ignored directory cannot be re-included by a negation.
+func (g *GitIgnore) Ignored(relPath string) bool {
+	if g == nil || len(g.rules) == 0 {
+		return false
+	}
+	parts := strings.Split(strings.Trim(filepath.ToSlash(relPath), "/"), "/")
+	for i := 1; i < len(parts); i++ {
+		if g.Match(strings.Join(parts[:i], "/"), true) {
+			return true
+		}
+	}
+	return g.Match(relPath, false)
+}
+
+// IgnoredDir is Ignored for a directory, so directory-only patterns such
+// as "build/" also apply to relPath itself.
+func (g *GitIgnore) IgnoredDir(relPath string) bool {
+	return g.Ignored(relPath) || g.Match(relPath, true)
+}
+
+// matchGlobPath matches a slash-separated path against a gitignore glob,
+// where "**" stands for any number of whole path segments.
+func matchGlobPath(pattern, name string) bool {
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchGlobSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], name[0]); err != nil || !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGitIgnore_Ignored(t *testing.T) {
+	ignore := ParseGitIgnore(`# build output
+*.log
+!keep.log
+/bin
+build/
+docs/**/*.tmp
+\#literal
+`)
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"app.log", true},
+		{"logs/app.log", true},
+		{"keep.log", false},
+		{"bin/tool", true},
+		{"cmd/bin/tool", false},
+		{"build", false}, // directory-only pattern, checked as a file
+		{"build/out.js", true},
+		{"web/build/out.js", true},
+		{"docs/a/b/page.tmp", true},
+		{"docs/page.tmp", true},
+		{"page.tmp", false},
+		{"#literal", true},
+		{"main.go", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := ignore.Ignored(tt.path); got != tt.want {
+				t.Errorf("Ignored(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitIgnore_IgnoredDir(t *testing.T) {
+	ignore := ParseGitIgnore("build/\n/out\n")
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"build", true},
+		{"pkg/build", true},
+		{"out", true},
+		{"pkg/out", false},
+		{"src", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := ignore.IgnoredDir(tt.path); got != tt.want {
+				t.Errorf("IgnoredDir(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitIgnore_Nil(t *testing.T) {
+	var ignore *GitIgnore
+	if ignore.Ignored("anything") || ignore.IgnoredDir("anything") {
+		t.Error("nil GitIgnore should ignore nothing")
+	}
+}
+
+func TestLoadGitIgnore(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".git", "info"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "info", "exclude"), []byte("local.txt\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte("*.env\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ignore := LoadGitIgnore(dir)
+	for _, path := range []string{"local.txt", "prod.env"} {
+		if !ignore.Ignored(path) {
+			t.Errorf("Ignored(%q) = false, want true", path)
+		}
+	}
+	if ignore.Ignored("main.go") {
+		t.Error("Ignored(main.go) = true, want false")
+	}
+}
+
+func TestIsDefaultSkipDir(t *testing.T) {
+	for _, name := range []string{".git", "node_modules", "vendor", "target", "dist", ".venv"} {
+		if !IsDefaultSkipDir(name) {
+			t.Errorf("IsDefaultSkipDir(%q) = false, want true", name)
+		}
+	}
+	for _, name := range []string{"src", "internal", "distribution"} {
+		if IsDefaultSkipDir(name) {
+			t.Errorf("IsDefaultSkipDir(%q) = true, want false", name)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// layoutDocs are the project files whose path references follow moves.
+var layoutDocs = []string{"CLAUDE.md", "AGENTS.md"}
+
+// LayoutResult reports what ApplyLayoutChanges did.
+type LayoutResult struct {
+	Moved   []LayoutMove
+	Renamed []LayoutRename
+	// Conflicts are paths left at their old location because the new
+	// location already holds a different file.
+	Conflicts []string
+	// Rewritten are the docs whose path references were updated.
+	Rewritten []string
+}
+
+// Changed reports whether anything was applied.
+func (r *LayoutResult) Changed() bool {
+	return len(r.Moved) > 0 || len(r.Renamed) > 0 || len(r.Rewritten) > 0
+}
+
+// ApplyLayoutChanges applies changes to the project in dir in order: it
+// moves files and directories (merging into existing directories), renames
+// components in config, and rewrites moved paths in CLAUDE.md and AGENTS.md.
+// Moves whose source is absent are skipped, so applying a change twice is
+// harmless. The caller saves config.
+func ApplyLayoutChanges(dir string, config *Config, changes []LayoutChange) (*LayoutResult, error) {
+	result := &LayoutResult{}
+	var moves []LayoutMove
+	for _, change := range changes {
+		for _, mv := range change.Moves {
+			moved, err := applyLayoutMove(dir, mv, result)
+			if err != nil {
+				return result, err
+			}
+			if moved {
+				result.Moved = append(result.Moved, mv)
+			}
+			moves = append(moves, mv)
+		}
+		for _, r := range change.Renames {
+			if config.renameComponent(r) {
+				result.Renamed = append(result.Renamed, r)
+			}
+		}
+	}
+	for _, doc := range layoutDocs {
+		rewritten, err := rewriteLayoutReferences(filepath.Join(dir, doc), moves)
+		if err != nil {
+			return result, err
+		}
+		if rewritten {
+			result.Rewritten = append(result.Rewritten, doc)
+		}
+	}
+	return result, nil
+}
+
+// applyLayoutMove performs one move and reports whether anything moved.
+func applyLayoutMove(dir string, mv LayoutMove, result *LayoutResult) (bool, error) {
+	from := filepath.Join(dir, filepath.FromSlash(mv.From))
+	to := filepath.Join(dir, filepath.FromSlash(mv.To))
+	if _, err := os.Lstat(from); os.IsNotExist(err) {
+		return false, nil
+	}
+	moved, err := movePath(from, to, dir, result)
+	if err != nil {
+		return moved, fmt.Errorf("failed to move %s to %s: %w", mv.From, mv.To, err)
+	}
+	return moved, nil
+}
+
+// movePath renames from to to. When to is an existing directory and from
+// is one too, their entries are merged; anything else already at to is a
+// conflict and from is left in place.
+func movePath(from, to, root string, result *LayoutResult) (bool, error) {
+	toInfo, err := os.Lstat(to)
+	if os.IsNotExist(err) {
+		if err := os.MkdirAll(filepath.Dir(to), 0755); err != nil {
+			return false, err
+		}
+		return true, os.Rename(from, to)
+	}
+	if err != nil {
+		return false, err
+	}
+	fromInfo, err := os.Lstat(from)
+	if err != nil {
+		return false, err
+	}
+	if !fromInfo.IsDir() || !toInfo.IsDir() {
+		if !sameFileContent(from, to) {
+			rel, _ := filepath.Rel(root, from)
+			result.Conflicts = append(result.Conflicts, filepath.ToSlash(rel))
+			return false, nil
+		}
+		return true, os.Remove(from)
+	}
+	return mergeDirs(from, to, root, result)
+}
+
+// mergeDirs moves the entries of from into the directory to and removes
+// from once it is empty.
+func mergeDirs(from, to, root string, result *LayoutResult) (bool, error) {
+	entries, err := os.ReadDir(from)
+	if err != nil {
+		return false, err
+	}
+	moved := false
+	for _, e := range entries {
+		m, err := movePath(filepath.Join(from, e.Name()), filepath.Join(to, e.Name()), root, result)
+		if err != nil {
+			return moved, err
+		}
+		moved = moved || m
+	}
+	if rest, err := os.ReadDir(from); err == nil && len(rest) == 0 {
+		return moved, os.Remove(from)
+	}
+	return moved, nil
+}
+
+func sameFileContent(a, b string) bool {
+	da, err := os.ReadFile(a)
+	if err != nil {
+		return false
+	}
+	return fileHasContent(b, da)
+}
+
+// renameComponent renames an installed component of r.Kind, keeping its
+// position in the list, and reports whether it was installed.
+func (c *Config) renameComponent(r LayoutRename) bool {
+	var list *[]string
+	switch r.Kind {
+	case ComponentLanguage:
+		list = &c.Installed.Languages
+	case ComponentFramework:
+		list = &c.Installed.Frameworks
+	case ComponentWorkflow:
+		list = &c.Installed.Workflows
+	case ComponentSkill:
+		list = &c.Installed.Skills
+		if source, ok := c.SkillSources[r.From]; ok {
+			delete(c.SkillSources, r.From)
+			c.SkillSources[r.To] = source
+		}
+	}
+	i := slices.Index(*list, r.From)
+	if i < 0 {
+		return false
+	}
+	if slices.Contains(*list, r.To) {
+		*list = slices.Delete(*list, i, i+1)
+	} else {
+		(*list)[i] = r.To
+	}
+	return true
+}
+
+// rewriteLayoutReferences replaces the old paths of moves in the file at
+// path with their new ones (see replaceLayoutPath). A missing file is left
+// alone.
+func rewriteLayoutReferences(path string, moves []LayoutMove) (bool, error) {
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) || len(moves) == 0 {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	content := string(data)
+	for _, mv := range moves {
+		content = replaceLayoutPath(content, mv.From, mv.To)
+	}
+	if content == string(data) {
+		return false, nil
+	}
+	if err := os.WriteFile(path, []byte(content), info.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("failed to rewrite %s: %w", filepath.Base(path), err)
+	}
+	return true, nil
+}
+
+// replaceLayoutPath replaces whole-path occurrences of from in content with
+// to. A match must not be preceded or followed by a character that would
+// continue the path name, so ".agent/skills/go" leaves
+// ".agent/skills/go-guide" alone; a trailing "/" or sentence period still
+// ends it. Occurrences already reading to are skipped, so replacing twice
+// changes nothing.
+func replaceLayoutPath(content, from, to string) string {
+	var b strings.Builder
+	for {
+		i := strings.Index(content, from)
+		if i < 0 {
+			b.WriteString(content)
+			return b.String()
+		}
+		end := i + len(from)
+		whole := (i == 0 || !isPathNameByte(content[i-1])) && endsPathName(content[end:])
+		if whole && !strings.HasPrefix(content[i:], to) {
+			b.WriteString(content[:i])
+			b.WriteString(to)
+		} else {
+			b.WriteString(content[:end])
+		}
+		content = content[end:]
+	}
+}
+
+// endsPathName reports whether rest, the text after a path, starts with
+// something that ends the path name.
+func endsPathName(rest string) bool {
+	if rest == "" {
+		return true
+	}
+	if rest[0] == '.' {
+		return len(rest) == 1 || !isPathNameByte(rest[1])
+	}
+	return !isPathNameByte(rest[0])
+}
+
+func isPathNameByte(c byte) bool {
+	return c == '-' || c == '_' || c == '.' ||
+		(c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LayoutManifestFile lists the repository layout changes of each release,
+// stored under the template/ directory of the release archive next to
+// ConfigDefaultsFile.
+const LayoutManifestFile = "samuel.layout.yaml"
+
+// Component kinds a LayoutRename can apply to.
+const (
+	ComponentLanguage  = "language"
+	ComponentFramework = "framework"
+	ComponentWorkflow  = "workflow"
+	ComponentSkill     = "skill"
+)
+
+// LayoutManifest is the path-mapping table shipped with the template.
+// 'samuel update' applies every change newer than the installed version,
+// so reorganizing the template never requires manual migration steps.
+type LayoutManifest struct {
+	Changes []LayoutChange `yaml:"changes"`
+}
+
+// LayoutChange is the set of moves and renames introduced by one release.
+type LayoutChange struct {
+	Version     string         `yaml:"version"`
+	Description string         `yaml:"description"`
+	Moves       []LayoutMove   `yaml:"moves,omitempty"`
+	Renames     []LayoutRename `yaml:"renames,omitempty"`
+}
+
+// LayoutMove relocates a file or directory, relative to the project root.
+// References to From in the project's CLAUDE.md and AGENTS.md are
+// rewritten to To.
+type LayoutMove struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// LayoutRename renames an installed component in samuel.yaml.
+type LayoutRename struct {
+	Kind string `yaml:"kind"`
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+// LoadLayoutManifest reads the layout manifest shipped in a template
+// directory. A template without one has no layout changes.
+func LoadLayoutManifest(templatePath string) (*LayoutManifest, error) {
+	data, err := os.ReadFile(filepath.Join(templatePath, TemplatePrefix, LayoutManifestFile))
+	if os.IsNotExist(err) {
+		return &LayoutManifest{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var m LayoutManifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", LayoutManifestFile, err)
+	}
+	if errs := m.Validate(); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid %s: %s", LayoutManifestFile, strings.Join(errs, "; "))
+	}
+	return &m, nil
+}
+
+// Validate returns a message for each malformed change.
+func (m *LayoutManifest) Validate() []string {
+	var errs []string
+	for i, c := range m.Changes {
+		if _, err := parseVersionParts(c.Version); err != nil {
+			errs = append(errs, fmt.Sprintf("changes[%d].version: %q is not a version", i, c.Version))
+		}
+		for j, mv := range c.Moves {
+			if !isProjectRelative(mv.From) || !isProjectRelative(mv.To) {
+				errs = append(errs, fmt.Sprintf("changes[%d].moves[%d]: paths must be relative and inside the project", i, j))
+			}
+		}
+		for j, r := range c.Renames {
+			if !isComponentKind(r.Kind) || r.From == "" || r.To == "" {
+				errs = append(errs, fmt.Sprintf("changes[%d].renames[%d]: needs kind (%s, %s, %s or %s), from and to",
+					i, j, ComponentLanguage, ComponentFramework, ComponentWorkflow, ComponentSkill))
+			}
+		}
+	}
+	return errs
+}
+
+// Pending returns the changes introduced after the installed version up
+// to and including the target version, oldest first.
+func (m *LayoutManifest) Pending(installed, target string) []LayoutChange {
+	var pending []LayoutChange
+	for _, c := range m.Changes {
+		after, err1 := CompareVersions(c.Version, installed)
+		upTo, err2 := CompareVersions(c.Version, target)
+		if err1 == nil && err2 == nil && after > 0 && upTo <= 0 {
+			pending = append(pending, c)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		cmp, _ := CompareVersions(pending[i].Version, pending[j].Version)
+		return cmp < 0
+	})
+	return pending
+}
+
+func isProjectRelative(path string) bool {
+	return path != "" && filepath.IsLocal(filepath.FromSlash(path))
+}
+
+func isComponentKind(kind string) bool {
+	switch kind {
+	case ComponentLanguage, ComponentFramework, ComponentWorkflow, ComponentSkill:
+		return true
+	}
+	return false
+}
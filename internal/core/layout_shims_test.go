@@ -0,0 +1,202 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadLayoutManifest(t *testing.T) {
+	t.Run("shipped manifest", func(t *testing.T) {
+		m, err := LoadLayoutManifest(filepath.Join("..", ".."))
+		if err != nil {
+			t.Fatalf("LoadLayoutManifest() error = %v", err)
+		}
+		if len(m.Changes) == 0 {
+			t.Error("expected the shipped manifest to list layout changes")
+		}
+	})
+
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+		want     int
+	}{
+		{name: "missing", want: 0},
+		{name: "valid", manifest: "changes:\n  - version: 2.1.0\n    moves:\n      - {from: a, to: b}\n", want: 1},
+		{name: "bad version", manifest: "changes:\n  - version: next\n", wantErr: "not a version"},
+		{name: "escaping path", manifest: "changes:\n  - version: 2.1.0\n    moves:\n      - {from: ../a, to: b}\n", wantErr: "inside the project"},
+		{name: "bad rename", manifest: "changes:\n  - version: 2.1.0\n    renames:\n      - {kind: tool, from: a, to: b}\n", wantErr: "needs kind"},
+		{name: "bad yaml", manifest: "changes: [", wantErr: "failed to parse"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tt.manifest != "" {
+				writeTestFile(t, filepath.Join(dir, TemplatePrefix, LayoutManifestFile), tt.manifest)
+			}
+			m, err := LoadLayoutManifest(dir)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || len(m.Changes) != tt.want {
+				t.Fatalf("LoadLayoutManifest() = %v, %v; want %d changes", m, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLayoutManifest_Pending(t *testing.T) {
+	m := &LayoutManifest{Changes: []LayoutChange{
+		{Version: "2.3.0"}, {Version: "2.0.0"}, {Version: "2.2.0"}, {Version: "2.4.0"},
+	}}
+	tests := []struct {
+		name, installed, target string
+		want                    []string
+	}{
+		{name: "between releases", installed: "2.0.0", target: "2.3.0", want: []string{"2.2.0", "2.3.0"}},
+		{name: "up to date", installed: "2.4.0", target: "2.4.0"},
+		{name: "prerelease target", installed: "2.2.0", target: "v2.4.0-beta.1", want: []string{"2.3.0", "2.4.0"}},
+		{name: "unparsable installed version", installed: "dev", target: "2.4.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, c := range m.Pending(tt.installed, tt.target) {
+				got = append(got, c.Version)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Pending() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLayoutChanges(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".agent/skills/go-guide/SKILL.md"), "go")
+	writeTestFile(t, filepath.Join(dir, ".agent/skills/api/SKILL.md"), "mine")
+	writeTestFile(t, filepath.Join(dir, ".agent/skills/same/SKILL.md"), "same")
+	writeTestFile(t, filepath.Join(dir, ".claude/skills/api/SKILL.md"), "theirs")
+	writeTestFile(t, filepath.Join(dir, ".claude/skills/same/SKILL.md"), "same")
+	writeTestFile(t, filepath.Join(dir, "CLAUDE.md"), "See .agent/skills/go-guide/SKILL.md\n")
+	config := &Config{Installed: InstalledItems{
+		Frameworks: []string{"nextjs", "react"},
+		Skills:     []string{"old-skill", "new-skill"},
+	}}
+	changes := []LayoutChange{{
+		Version: "2.0.0",
+		Moves:   []LayoutMove{{From: ".agent/skills", To: ".claude/skills"}},
+		Renames: []LayoutRename{
+			{Kind: ComponentFramework, From: "nextjs", To: "next"},
+			{Kind: ComponentSkill, From: "old-skill", To: "new-skill"},
+			{Kind: ComponentLanguage, From: "cobol", To: "cobol-85"},
+		},
+	}}
+
+	result, err := ApplyLayoutChanges(dir, config, changes)
+	if err != nil {
+		t.Fatalf("ApplyLayoutChanges() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, ".claude/skills/go-guide/SKILL.md")); string(data) != "go" {
+		t.Error("expected go-guide to move into .claude/skills")
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, ".claude/skills/api/SKILL.md")); string(data) != "theirs" {
+		t.Error("expected the existing api skill to be kept")
+	}
+	if !slices.Equal(result.Conflicts, []string{".agent/skills/api/SKILL.md"}) {
+		t.Errorf("Conflicts = %v", result.Conflicts)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".agent/skills/same")); !os.IsNotExist(err) {
+		t.Error("expected an identical duplicate to be removed from the old location")
+	}
+	if !slices.Equal(config.Installed.Frameworks, []string{"next", "react"}) ||
+		!slices.Equal(config.Installed.Skills, []string{"new-skill"}) {
+		t.Errorf("Installed = %+v", config.Installed)
+	}
+	if len(result.Renamed) != 2 || len(result.Moved) != 1 {
+		t.Errorf("Renamed = %v, Moved = %v", result.Renamed, result.Moved)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "CLAUDE.md")); string(data) != "See .claude/skills/go-guide/SKILL.md\n" {
+		t.Errorf("CLAUDE.md = %q", data)
+	}
+	if !slices.Equal(result.Rewritten, []string{"CLAUDE.md"}) {
+		t.Errorf("Rewritten = %v", result.Rewritten)
+	}
+
+	again, err := ApplyLayoutChanges(dir, config, changes)
+	if err != nil || again.Changed() {
+		t.Errorf("second ApplyLayoutChanges() = %+v, %v; want no changes", again, err)
+	}
+}
+
+func TestReplaceLayoutPath(t *testing.T) {
+	tests := []struct {
+		name, content, from, to, want string
+	}{
+		{name: "path", content: "See .agent/skills/go/SKILL.md", from: ".agent/skills/go", to: ".agent/skills/go-guide", want: "See .agent/skills/go-guide/SKILL.md"},
+		{name: "end of sentence", content: "Moved to .agent/skills/go.", from: ".agent/skills/go", to: ".agent/skills/go-guide", want: "Moved to .agent/skills/go-guide."},
+		{name: "idempotent suffix", content: "See .agent/skills/go-guide/", from: ".agent/skills/go", to: ".agent/skills/go-guide", want: "See .agent/skills/go-guide/"},
+		{name: "longer name", content: "`.agent/skills/golang`", from: ".agent/skills/go", to: ".agent/skills/go-guide", want: "`.agent/skills/golang`"},
+		{name: "file extension", content: ".agent/skills/go.md", from: ".agent/skills/go", to: ".agent/skills/go-guide", want: ".agent/skills/go.md"},
+		{name: "idempotent subdirectory", content: "docs/guides", from: "docs", to: "docs/guides", want: "docs/guides"},
+		{name: "several", content: "docs, docs/a and mydocs", from: "docs", to: "manual", want: "manual, manual/a and mydocs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replaceLayoutPath(tt.content, tt.from, tt.to); got != tt.want {
+				t.Errorf("replaceLayoutPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyLayoutChanges_ShippedManifestOn17Tree(t *testing.T) {
+	m, err := LoadLayoutManifest(filepath.Join("..", ".."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		".agent/language-guides/go.md":        "go guide",
+		".agent/language-guides/README.md":    "index",
+		".agent/framework-guides/gin.md":      "gin guide",
+		".agent/workflows/create-prd.md":      "create prd",
+		".agent/workflows/troubleshooting.md": "debug",
+		"CLAUDE.md": "Load .agent/language-guides/go.md and .agent/framework-guides/gin.md.\n" +
+			"Run @.agent/workflows/create-prd.md first.\n",
+	})
+	config := &Config{Version: "1.7.0"}
+
+	changes := m.Pending(config.Version, "2.0.0")
+	if _, err := ApplyLayoutChanges(dir, config, changes); err != nil {
+		t.Fatalf("ApplyLayoutChanges() error = %v", err)
+	}
+	for rel, want := range map[string]string{
+		".claude/skills/go-guide/SKILL.md":        "go guide",
+		".claude/skills/gin/SKILL.md":             "gin guide",
+		".claude/skills/create-prd/SKILL.md":      "create prd",
+		".claude/skills/troubleshooting/SKILL.md": "debug",
+		".agent/language-guides/README.md":        "index",
+	} {
+		if data, err := os.ReadFile(filepath.Join(dir, rel)); err != nil || string(data) != want {
+			t.Errorf("%s = %q, %v; want %q", rel, data, err, want)
+		}
+	}
+	want := "Load .claude/skills/go-guide/SKILL.md and .claude/skills/gin/SKILL.md.\n" +
+		"Run @.claude/skills/create-prd/SKILL.md first.\n"
+	if data, _ := os.ReadFile(filepath.Join(dir, "CLAUDE.md")); string(data) != want {
+		t.Errorf("CLAUDE.md = %q, want %q", data, want)
+	}
+
+	again, err := ApplyLayoutChanges(dir, config, changes)
+	if err != nil || again.Changed() {
+		t.Errorf("second ApplyLayoutChanges() = %+v, %v; want no changes", again, err)
+	}
+}
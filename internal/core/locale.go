@@ -0,0 +1,99 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// LocalesDir holds the translations shipped in the template, relative to
+// TemplatePrefix. A translation is one file per managed block of the core
+// instruction files: locales/<language>/<block>.md replaces the body of the
+// block <block> in CLAUDE.md and AGENTS.md. Text outside the managed
+// blocks and all skills are installed as written.
+const LocalesDir = "locales"
+
+// DefaultLanguage is the language the template is written in.
+const DefaultLanguage = "en"
+
+// languageRegex matches language tags such as "es", "pt-BR" or "zh-Hans".
+var languageRegex = regexp.MustCompile(`^[a-z]{2,3}(-[A-Za-z0-9]{2,8})?$`)
+
+// ValidateLanguage checks a configured language tag. Empty means English.
+func ValidateLanguage(language string) error {
+	if language != "" && !languageRegex.MatchString(language) {
+		return fmt.Errorf("invalid language: %q (expected a tag such as es or pt-BR)", language)
+	}
+	return nil
+}
+
+// AvailableLanguages lists the translations shipped in the template at
+// templatePath, sorted.
+func AvailableLanguages(templatePath string) []string {
+	entries, err := os.ReadDir(filepath.Join(templatePath, TemplatePrefix, LocalesDir))
+	if err != nil {
+		return nil
+	}
+	var languages []string
+	for _, entry := range entries {
+		if entry.IsDir() && languageRegex.MatchString(entry.Name()) {
+			languages = append(languages, entry.Name())
+		}
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// HasLanguage reports whether language is English, which needs no
+// translation, or is shipped in the template at templatePath.
+func HasLanguage(templatePath, language string) bool {
+	return language == "" || language == DefaultLanguage || slices.Contains(AvailableLanguages(templatePath), language)
+}
+
+// LocalizeCoreBlocks replaces the managed block bodies in content with
+// their translation into language from the template at templatePath.
+// Blocks without a translation stay as they are.
+func LocalizeCoreBlocks(content, templatePath, language string) (string, error) {
+	if language == "" || language == DefaultLanguage || ValidateLanguage(language) != nil {
+		return content, nil
+	}
+	dir := filepath.Join(templatePath, TemplatePrefix, LocalesDir, language)
+	bodies := make(map[string]string)
+	for _, block := range findCoreBlocks(content) {
+		data, err := os.ReadFile(filepath.Join(dir, block.name+".md"))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s translation of %s: %w", language, block.name, err)
+		}
+		body, err := NormalizeMarkdown(data, FinalNewlineEnsure)
+		if err != nil {
+			return "", fmt.Errorf("%s translation of %s: %w", language, block.name, err)
+		}
+		bodies[block.name] = string(body)
+	}
+	localized, _ := replaceCoreBlocks(content, bodies)
+	return localized, nil
+}
+
+// isCoreFile reports whether rel, relative to the project, is one of
+// CoreFiles.
+func isCoreFile(rel string) bool {
+	return slices.Contains(CoreFiles, strings.TrimPrefix(filepath.ToSlash(rel), "./"))
+}
+
+// localizer returns the translation step for the file rel, or nil when
+// it is not a core file or no language is set.
+func (e *Extractor) localizer(rel string) func(string) (string, error) {
+	if e.language == "" || !isCoreFile(rel) {
+		return nil
+	}
+	return func(content string) (string, error) {
+		return LocalizeCoreBlocks(content, e.sourcePath, e.language)
+	}
+}
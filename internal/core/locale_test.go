@@ -0,0 +1,113 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const localeTestDoc = "# Title\n" +
+	"<!-- SAMUEL:BEGIN rules -->\n## Rules\n<!-- SAMUEL:END rules -->\n" +
+	"Own notes\n" +
+	"<!-- SAMUEL:BEGIN stuck -->\n## When Stuck\n<!-- SAMUEL:END stuck -->\n"
+
+// writeLocaleTemplate creates a template with CLAUDE.md, a skill, and a
+// Spanish translation of the rules block only.
+func writeLocaleTemplate(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, TemplatePrefix, "CLAUDE.md"), localeTestDoc)
+	writeTestFile(t, filepath.Join(dir, TemplatePrefix, ".claude", "skills", "go-guide", "SKILL.md"),
+		"<!-- SAMUEL:BEGIN rules -->\n## Rules\n<!-- SAMUEL:END rules -->\n")
+	writeTestFile(t, filepath.Join(dir, TemplatePrefix, LocalesDir, "es", "rules.md"), "## Reglas\r\n")
+	if err := os.MkdirAll(filepath.Join(dir, TemplatePrefix, LocalesDir, "not a tag"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLocalizeCoreBlocks(t *testing.T) {
+	tmpl := writeLocaleTemplate(t)
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{"es", strings.Replace(localeTestDoc, "## Rules", "## Reglas", 1)},
+		{"", localeTestDoc},
+		{DefaultLanguage, localeTestDoc},
+		{"fr", localeTestDoc},
+		{"../es", localeTestDoc},
+	}
+	for _, tt := range tests {
+		t.Run(tt.language, func(t *testing.T) {
+			got, err := LocalizeCoreBlocks(localeTestDoc, tmpl, tt.language)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("LocalizeCoreBlocks() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAvailableLanguages(t *testing.T) {
+	tmpl := writeLocaleTemplate(t)
+	if got := AvailableLanguages(tmpl); !reflect.DeepEqual(got, []string{"es"}) {
+		t.Errorf("AvailableLanguages() = %v, want [es]", got)
+	}
+	for language, want := range map[string]bool{"": true, "en": true, "es": true, "fr": false} {
+		if got := HasLanguage(tmpl, language); got != want {
+			t.Errorf("HasLanguage(%q) = %v, want %v", language, got, want)
+		}
+	}
+}
+
+func TestValidateLanguage(t *testing.T) {
+	for _, language := range []string{"", "es", "pt-BR", "zh-Hans"} {
+		if err := ValidateLanguage(language); err != nil {
+			t.Errorf("ValidateLanguage(%q) = %v", language, err)
+		}
+	}
+	for _, language := range []string{"Spanish", "e", "es_ES", "../es"} {
+		if err := ValidateLanguage(language); err == nil {
+			t.Errorf("ValidateLanguage(%q) = nil, want error", language)
+		}
+	}
+}
+
+func TestExtract_LocalizesCoreFilesOnly(t *testing.T) {
+	tmpl, dest := writeLocaleTemplate(t), t.TempDir()
+	ext := NewExtractor(tmpl, dest)
+	ext.SetLanguage("es")
+	if _, err := ext.Extract([]string{"CLAUDE.md", ".claude/skills/go-guide"}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	claude, _ := os.ReadFile(filepath.Join(dest, "CLAUDE.md"))
+	if !strings.Contains(string(claude), "## Reglas") || !strings.Contains(string(claude), "## When Stuck") {
+		t.Errorf("CLAUDE.md = %q, want rules translated and stuck untouched", claude)
+	}
+	skill, _ := os.ReadFile(filepath.Join(dest, ".claude", "skills", "go-guide", "SKILL.md"))
+	if strings.Contains(string(skill), "Reglas") {
+		t.Errorf("skill was localized: %q", skill)
+	}
+}
+
+func TestPlanCoreUpdate_Language(t *testing.T) {
+	tmpl, project := writeLocaleTemplate(t), t.TempDir()
+	writeTestFile(t, filepath.Join(project, "CLAUDE.md"), localeTestDoc)
+
+	plan, err := PlanCoreUpdate(project, tmpl, "es", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan) != 1 || plan[0].Action != CoreActionMerge || !reflect.DeepEqual(plan[0].Blocks, []string{"rules"}) {
+		t.Fatalf("plan = %+v, want rules merged", plan)
+	}
+	if !strings.Contains(string(plan[0].content), "Own notes") {
+		t.Errorf("merged content lost local text: %q", plan[0].content)
+	}
+}
@@ -0,0 +1,184 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ProjectLockFile is the lock file samuel holds in the project root while
+// a command modifies the project.
+const ProjectLockFile = ".samuel.lock"
+
+// LockInfo identifies the process holding the project lock.
+type LockInfo struct {
+	PID      int       `json:"pid"`
+	Command  string    `json:"command"`
+	Hostname string    `json:"hostname,omitempty"`
+	Since    time.Time `json:"since"`
+}
+
+// LockHeldError is returned when another live process holds the lock.
+type LockHeldError struct {
+	Holder LockInfo
+}
+
+func (e *LockHeldError) Error() string {
+	return fmt.Sprintf("project is locked: held by PID %d (%s) since %s",
+		e.Holder.PID, e.Holder.Command, e.Holder.Since.Local().Format(time.DateTime))
+}
+
+// ProjectLock is a held project lock; call Release when done.
+type ProjectLock struct {
+	path string
+}
+
+// GetProjectLockPath returns the lock file path for a project.
+func GetProjectLockPath(projectDir string) string {
+	return filepath.Join(projectDir, ProjectLockFile)
+}
+
+// AcquireProjectLock takes the project lock for command. A lock left by a
+// process that is no longer running on this host is replaced; a lock held
+// by a live process yields a *LockHeldError.
+func AcquireProjectLock(projectDir, command string) (*ProjectLock, error) {
+	path := GetProjectLockPath(projectDir)
+	hostname, _ := os.Hostname()
+	info := LockInfo{PID: os.Getpid(), Command: command, Hostname: hostname, Since: time.Now().UTC()}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lock: %w", err)
+	}
+
+	for attempt := 0; attempt < 2; attempt++ {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			_, writeErr := f.Write(data)
+			if closeErr := f.Close(); writeErr == nil {
+				writeErr = closeErr
+			}
+			if writeErr != nil {
+				os.Remove(path)
+				return nil, fmt.Errorf("failed to write lock: %w", writeErr)
+			}
+			excludeFromGit(projectDir, ProjectLockFile)
+			return &ProjectLock{path: path}, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to create lock: %w", err)
+		}
+
+		holder, err := ReadProjectLock(projectDir)
+		if err != nil {
+			return nil, fmt.Errorf("%w (remove %s with --force-unlock)", err, ProjectLockFile)
+		}
+		if !holder.stale(hostname) {
+			return nil, &LockHeldError{Holder: *holder}
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove stale lock: %w", err)
+		}
+	}
+	return nil, fmt.Errorf("failed to acquire lock: %s keeps changing", ProjectLockFile)
+}
+
+// ReadProjectLock returns the current lock holder, or nil if the project
+// is not locked.
+func ReadProjectLock(projectDir string) (*LockInfo, error) {
+	data, err := os.ReadFile(GetProjectLockPath(projectDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock: %w", err)
+	}
+	var info LockInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, fmt.Errorf("invalid lock file: %w", err)
+	}
+	return &info, nil
+}
+
+// ForceUnlock removes the project lock regardless of who holds it and
+// returns the previous holder, if it could be read.
+func ForceUnlock(projectDir string) (*LockInfo, error) {
+	holder, _ := ReadProjectLock(projectDir)
+	if err := os.Remove(GetProjectLockPath(projectDir)); err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove lock: %w", err)
+	}
+	return holder, nil
+}
+
+// Release removes the lock file if this process still owns it, so a lock
+// that was force-unlocked and re-acquired elsewhere is left alone.
+func (l *ProjectLock) Release() error {
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return nil
+	}
+	var info LockInfo
+	if json.Unmarshal(data, &info) != nil || info.PID != os.Getpid() {
+		return nil
+	}
+	if err := os.Remove(l.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	return nil
+}
+
+// excludeFromGit lists name in .git/info/exclude so the lock is never
+// committed, e.g. by an agent staging everything during the auto loop.
+// This is best effort; projects that are not git repositories are skipped.
+func excludeFromGit(projectDir, name string) {
+	gitDir := filepath.Join(projectDir, ".git")
+	if info, err := os.Stat(gitDir); err != nil || !info.IsDir() {
+		return
+	}
+	excludePath := filepath.Join(gitDir, "info", "exclude")
+	existing, _ := os.ReadFile(excludePath)
+	for _, line := range strings.Split(string(existing), "\n") {
+		if strings.TrimSpace(line) == name || strings.TrimSpace(line) == "/"+name {
+			return
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(excludePath), 0755); err != nil {
+		return
+	}
+	f, err := os.OpenFile(excludePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		f.WriteString("\n")
+	}
+	f.WriteString("/" + name + "\n")
+}
+
+// stale reports whether the holder is known to have exited. Locks from
+// other hosts cannot be checked and are never considered stale.
+func (i LockInfo) stale(hostname string) bool {
+	if i.Hostname != hostname || i.PID == os.Getpid() {
+		return false
+	}
+	return !processAlive(i.PID)
+}
+
+// processAlive probes pid with signal 0. Only a definite "no such
+// process" counts as dead; platforms without signal support report alive.
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	err = p.Signal(syscall.Signal(0))
+	return !errors.Is(err, os.ErrProcessDone) && !errors.Is(err, syscall.ESRCH)
+}
@@ -0,0 +1,165 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeLockFile(t *testing.T, dir string, info LockInfo) {
+	t.Helper()
+	data, err := json.Marshal(info)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(GetProjectLockPath(dir), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAcquireProjectLock_AcquireAndRelease(t *testing.T) {
+	dir := t.TempDir()
+
+	lock, err := AcquireProjectLock(dir, "samuel update")
+	if err != nil {
+		t.Fatalf("AcquireProjectLock returned error: %v", err)
+	}
+
+	holder, err := ReadProjectLock(dir)
+	if err != nil || holder == nil {
+		t.Fatalf("ReadProjectLock = %v, %v; want holder", holder, err)
+	}
+	if holder.PID != os.Getpid() || holder.Command != "samuel update" {
+		t.Errorf("holder = %+v, want this process running samuel update", holder)
+	}
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	if _, err := os.Stat(GetProjectLockPath(dir)); !os.IsNotExist(err) {
+		t.Error("lock file should be removed on release")
+	}
+}
+
+func TestAcquireProjectLock_HeldByLiveProcess(t *testing.T) {
+	dir := t.TempDir()
+	hostname, _ := os.Hostname()
+	since := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	// The parent process (go test) is alive for the duration of the test.
+	writeLockFile(t, dir, LockInfo{PID: os.Getppid(), Command: "samuel auto start", Hostname: hostname, Since: since})
+
+	_, err := AcquireProjectLock(dir, "samuel update")
+
+	var held *LockHeldError
+	if !errors.As(err, &held) {
+		t.Fatalf("expected LockHeldError, got %v", err)
+	}
+	if held.Holder.PID != os.Getppid() || !strings.Contains(err.Error(), "samuel auto start") {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestAcquireProjectLock_ReplacesStaleLock(t *testing.T) {
+	dir := t.TempDir()
+	hostname, _ := os.Hostname()
+	// PIDs are bounded well below this on every supported platform.
+	writeLockFile(t, dir, LockInfo{PID: 1 << 30, Command: "samuel update", Hostname: hostname, Since: time.Now()})
+
+	lock, err := AcquireProjectLock(dir, "samuel add")
+	if err != nil {
+		t.Fatalf("stale lock should be replaced, got %v", err)
+	}
+	defer lock.Release()
+
+	holder, _ := ReadProjectLock(dir)
+	if holder == nil || holder.PID != os.Getpid() {
+		t.Errorf("holder = %+v, want this process", holder)
+	}
+}
+
+func TestAcquireProjectLock_OtherHostNeverStale(t *testing.T) {
+	dir := t.TempDir()
+	writeLockFile(t, dir, LockInfo{PID: 1 << 30, Command: "samuel update", Hostname: "elsewhere.invalid", Since: time.Now()})
+
+	var held *LockHeldError
+	if _, err := AcquireProjectLock(dir, "samuel add"); !errors.As(err, &held) {
+		t.Errorf("lock from another host should be respected, got %v", err)
+	}
+}
+
+func TestAcquireProjectLock_CorruptLock(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(GetProjectLockPath(dir), []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := AcquireProjectLock(dir, "samuel add")
+	if err == nil || !strings.Contains(err.Error(), "--force-unlock") {
+		t.Errorf("expected error pointing at --force-unlock, got %v", err)
+	}
+}
+
+func TestForceUnlock(t *testing.T) {
+	dir := t.TempDir()
+	writeLockFile(t, dir, LockInfo{PID: 42, Command: "samuel update"})
+
+	holder, err := ForceUnlock(dir)
+	if err != nil {
+		t.Fatalf("ForceUnlock returned error: %v", err)
+	}
+	if holder == nil || holder.PID != 42 {
+		t.Errorf("ForceUnlock holder = %+v, want PID 42", holder)
+	}
+	if _, err := os.Stat(GetProjectLockPath(dir)); !os.IsNotExist(err) {
+		t.Error("lock file should be removed")
+	}
+
+	if holder, err := ForceUnlock(dir); err != nil || holder != nil {
+		t.Errorf("ForceUnlock on unlocked project = %v, %v; want nil, nil", holder, err)
+	}
+}
+
+func TestRelease_LeavesForeignLock(t *testing.T) {
+	dir := t.TempDir()
+	lock, err := AcquireProjectLock(dir, "samuel update")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Another process force-unlocked and took over the lock.
+	writeLockFile(t, dir, LockInfo{PID: 42, Command: "samuel add"})
+
+	if err := lock.Release(); err != nil {
+		t.Fatalf("Release returned error: %v", err)
+	}
+	if _, err := os.Stat(GetProjectLockPath(dir)); err != nil {
+		t.Error("Release must not remove a lock held by another process")
+	}
+}
+
+func TestAcquireProjectLock_ExcludesFromGit(t *testing.T) {
+	dir := t.TempDir()
+	infoDir := filepath.Join(dir, ".git", "info")
+	if err := os.MkdirAll(infoDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(infoDir, "exclude"), []byte("*.log"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		lock, err := AcquireProjectLock(dir, "samuel update")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lock.Release()
+	}
+
+	content, _ := os.ReadFile(filepath.Join(infoDir, "exclude"))
+	if string(content) != "*.log\n/"+ProjectLockFile+"\n" {
+		t.Errorf("exclude = %q, want lock file appended once", content)
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ar4mirez/samuel/pkg/parse"
+)
+
+// Final newline policies for installed markdown files
+const (
+	// FinalNewlinePreserve keeps trailing newlines as the template has them.
+	FinalNewlinePreserve = "preserve"
+	// FinalNewlineEnsure ends every file with exactly one newline.
+	FinalNewlineEnsure = "ensure"
+	// FinalNewlineStrip removes all trailing newlines.
+	FinalNewlineStrip = "strip"
+)
+
+// GetSupportedFinalNewlinePolicies returns the accepted
+// markdown.final_newline values, default first.
+func GetSupportedFinalNewlinePolicies() []string {
+	return []string{FinalNewlinePreserve, FinalNewlineEnsure, FinalNewlineStrip}
+}
+
+// ValidateFinalNewline checks a markdown.final_newline value.
+func ValidateFinalNewline(policy string) error {
+	if !slices.Contains(GetSupportedFinalNewlinePolicies(), policy) {
+		return fmt.Errorf("invalid final newline policy: %s (supported: %v)", policy, GetSupportedFinalNewlinePolicies())
+	}
+	return nil
+}
+
+// isMarkdownFile reports whether path gets markdown normalization.
+func isMarkdownFile(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".md")
+}
+
+// NormalizeMarkdown returns content as UTF-8 without a byte order mark,
+// with LF line endings and the trailing newline policy asks for. An
+// unknown policy is treated as FinalNewlinePreserve. Content that is not
+// UTF-8 is rejected with parse.ErrEncoding.
+func NormalizeMarkdown(content []byte, policy string) ([]byte, error) {
+	text, err := parse.Normalize(string(content))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(applyFinalNewline(text, policy)), nil
+}
+
+func applyFinalNewline(text, policy string) string {
+	switch policy {
+	case FinalNewlineEnsure:
+		if trimmed := strings.TrimRight(text, "\n"); trimmed != "" {
+			return trimmed + "\n"
+		}
+		return text
+	case FinalNewlineStrip:
+		return strings.TrimRight(text, "\n")
+	default:
+		return text
+	}
+}
+
+// MarkdownIssues lists what NormalizeMarkdown would change in content.
+func MarkdownIssues(content []byte, policy string) []string {
+	text, err := parse.Normalize(string(content))
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var issues []string
+	if bytes.HasPrefix(content, []byte("\ufeff")) {
+		issues = append(issues, "starts with a UTF-8 byte order mark")
+	}
+	if bytes.Contains(content, []byte("\r")) {
+		issues = append(issues, "uses CRLF line endings")
+	}
+	if applyFinalNewline(text, policy) != text {
+		if policy == FinalNewlineStrip {
+			issues = append(issues, "ends with a newline")
+		} else {
+			issues = append(issues, "does not end with exactly one newline")
+		}
+	}
+	return issues
+}
+
+// SameContent reports whether a local file matches its template. For
+// markdown, differences that NormalizeMarkdown removes do not count.
+func SameContent(path string, local, upstream []byte) bool {
+	if bytes.Equal(local, upstream) {
+		return true
+	}
+	if !isMarkdownFile(path) {
+		return false
+	}
+	a, errA := NormalizeMarkdown(local, FinalNewlineEnsure)
+	b, errB := NormalizeMarkdown(upstream, FinalNewlineEnsure)
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
+
+// copyMarkdown writes the normalized markdown of src to dst with the
+// source file's mode, passing it through localize when that is set.
+func copyMarkdown(src, dst, policy string, localize func(string) (string, error)) error {
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+	normalized, err := NormalizeMarkdown(content, policy)
+	if err != nil {
+		return err
+	}
+	if localize != nil {
+		localized, err := localize(string(normalized))
+		if err != nil {
+			return err
+		}
+		normalized = []byte(localized)
+	}
+	return os.WriteFile(dst, normalized, info.Mode().Perm())
+}
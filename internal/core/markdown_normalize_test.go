@@ -0,0 +1,118 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/pkg/parse"
+)
+
+func TestNormalizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		policy  string
+		want    string
+	}{
+		{"bom and crlf", "\ufeff# Title\r\nBody\r\n", FinalNewlineEnsure, "# Title\nBody\n"},
+		{"adds missing newline", "# Title", FinalNewlineEnsure, "# Title\n"},
+		{"collapses trailing newlines", "# Title\n\n\n", FinalNewlineEnsure, "# Title\n"},
+		{"empty stays empty", "", FinalNewlineEnsure, ""},
+		{"unknown policy preserves", "# Title", "", "# Title"},
+		{"preserve", "# Title\r\n\r\n", FinalNewlinePreserve, "# Title\n\n"},
+		{"strip", "# Title\n\n", FinalNewlineStrip, "# Title"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMarkdown([]byte(tt.content), tt.policy)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("NormalizeMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NormalizeMarkdown([]byte("\xff\xfe#\x00"), FinalNewlineEnsure); !errors.Is(err, parse.ErrEncoding) {
+		t.Errorf("UTF-16 content: error = %v, want ErrEncoding", err)
+	}
+}
+
+func TestSameContent(t *testing.T) {
+	tests := []struct {
+		path     string
+		local    string
+		upstream string
+		want     bool
+	}{
+		{"CLAUDE.md", "# A\n", "\ufeff# A\r\n", true},
+		{"CLAUDE.md", "# A\n", "# A", true},
+		{"CLAUDE.md", "# A\n", "# B\n", false},
+		{"script.sh", "echo\n", "echo\r\n", false},
+	}
+	for _, tt := range tests {
+		if got := SameContent(tt.path, []byte(tt.local), []byte(tt.upstream)); got != tt.want {
+			t.Errorf("SameContent(%s, %q, %q) = %v, want %v", tt.path, tt.local, tt.upstream, got, tt.want)
+		}
+	}
+}
+
+func TestExtract_NormalizesMarkdown(t *testing.T) {
+	srcDir, destDir := t.TempDir(), t.TempDir()
+	writeTestFile(t, filepath.Join(srcDir, TemplatePrefix, "docs", "guide.md"), "\ufeff# Guide\r\n")
+	writeTestFile(t, filepath.Join(srcDir, TemplatePrefix, "docs", "run.sh"), "echo hi\r\n")
+	writeTestFile(t, filepath.Join(srcDir, TemplatePrefix, "docs", "bad.md"), "\xff\xfe#\x00")
+
+	ext := NewExtractor(srcDir, destDir)
+	ext.SetFinalNewline(FinalNewlineStrip)
+	result, err := ext.Extract([]string{"docs"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{"guide.md": "# Guide", "run.sh": "echo hi\r\n"} {
+		got, err := os.ReadFile(filepath.Join(destDir, "docs", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Kind != ExtractEncoding {
+		t.Errorf("errors = %v, want one encoding error for bad.md", result.Errors)
+	}
+}
+
+func TestLintSkillMarkdown(t *testing.T) {
+	skillDir := filepath.Join(t.TempDir(), "my-skill")
+	writeTestFile(t, filepath.Join(skillDir, "SKILL.md"), "\ufeff---\r\nname: my-skill\r\n---\r\n")
+	writeTestFile(t, filepath.Join(skillDir, "references", "clean.md"), "# Clean\n")
+	writeTestFile(t, filepath.Join(skillDir, "references", "utf16.md"), "\xff\xfe#\x00")
+
+	results, err := LintSkillMarkdown(skillDir, FinalNewlineEnsure, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %+v, want SKILL.md and references/utf16.md", results)
+	}
+	if results[0].Path != "SKILL.md" || !results[0].Fixed || !strings.Contains(strings.Join(results[0].Issues, ","), "CRLF") {
+		t.Errorf("SKILL.md result = %+v", results[0])
+	}
+	if results[1].Path != "references/utf16.md" || results[1].Fixed {
+		t.Errorf("utf16.md result = %+v, want reported but not fixed", results[1])
+	}
+
+	again, err := LintSkillMarkdown(skillDir, FinalNewlineEnsure, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != 1 {
+		t.Errorf("after --fix, results = %+v, want only the UTF-16 file", again)
+	}
+}
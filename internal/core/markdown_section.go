@@ -0,0 +1,96 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sectionHeading is one ATX heading outside code fences.
+type sectionHeading struct {
+	line  int
+	level int
+	title string
+	path  string
+}
+
+// markdownHeadings lists the headings of content with their heading paths,
+// e.g. "Guardrails > Testing", ignoring "#" lines inside code fences.
+func markdownHeadings(lines []string) []sectionHeading {
+	var headings []sectionHeading
+	var parents []string
+	inFence := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		level, title := markdownHeading(trimmed)
+		if inFence || level == 0 {
+			continue
+		}
+		if len(parents) >= level {
+			parents = parents[:level-1]
+		}
+		for len(parents) < level-1 {
+			parents = append(parents, "")
+		}
+		parents = append(parents, title)
+		headings = append(headings, sectionHeading{line: i, level: level, title: title, path: joinHeadingPath(parents)})
+	}
+	return headings
+}
+
+// MarkdownSectionText returns the section of content named name: its
+// heading line and everything up to the next heading of the same or a
+// higher level, so subsections are included. The name matches the end of a
+// heading path case-insensitively, so both "guardrails" and "Guardrails >
+// Testing" work without the document title. A name that matches several
+// headings is an error listing their paths.
+func MarkdownSectionText(content, name string) (string, error) {
+	lines := strings.Split(content, "\n")
+	headings := markdownHeadings(lines)
+
+	var matches []int
+	for i, h := range headings {
+		path, suffix := strings.ToLower(h.path), strings.ToLower(name)
+		if path == suffix || strings.HasSuffix(path, " > "+suffix) {
+			matches = append(matches, i)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("section not found: %s (sections: %s)", name, strings.Join(topSections(headings), ", "))
+	case 1:
+	default:
+		var paths []string
+		for _, i := range matches {
+			paths = append(paths, headings[i].path)
+		}
+		return "", fmt.Errorf("section %q is ambiguous; use one of: %s", name, strings.Join(paths, "; "))
+	}
+
+	start := headings[matches[0]]
+	end := len(lines)
+	for _, h := range headings[matches[0]+1:] {
+		if h.level <= start.level {
+			end = h.line
+			break
+		}
+	}
+	return strings.TrimRight(strings.Join(lines[start.line:end], "\n"), "\n") + "\n", nil
+}
+
+// topSections returns the titles of the highest-level headings.
+func topSections(headings []sectionHeading) []string {
+	top := 7
+	for _, h := range headings {
+		top = min(top, h.level)
+	}
+	var titles []string
+	for _, h := range headings {
+		if h.level == top {
+			titles = append(titles, h.title)
+		}
+	}
+	return titles
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"strings"
+	"testing"
+)
+
+const sectionTestDoc = `---
+name: go-guide
+---
+# Go Guide
+
+## Guardrails
+
+- Keep functions short
+
+### Testing
+
+- Table-driven tests
+
+` + "```sh\n# Not a heading\n```" + `
+
+## Patterns
+
+### Testing
+
+- Use t.TempDir
+`
+
+func TestMarkdownSectionText(t *testing.T) {
+	tests := []struct {
+		name    string
+		section string
+		want    string
+		wantErr string
+	}{
+		{
+			name:    "includes subsections",
+			section: "guardrails",
+			want:    "## Guardrails\n\n- Keep functions short\n\n### Testing\n\n- Table-driven tests\n\n```sh\n# Not a heading\n```\n",
+		},
+		{
+			name:    "heading path",
+			section: "Patterns > testing",
+			want:    "### Testing\n\n- Use t.TempDir\n",
+		},
+		{name: "ambiguous title", section: "Testing", wantErr: "Guardrails > Testing"},
+		{name: "fenced line", section: "Not a heading", wantErr: "sections: Go Guide"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarkdownSectionText(sectionTestDoc, tt.section)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("MarkdownSectionText() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("MarkdownSectionText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Migration step actions.
+const (
+	MigrationRename  = "rename"
+	MigrationRewrite = "rewrite"
+	MigrationManual  = "manual"
+)
+
+// migrationScriptPatterns are the project files scanned for deprecated
+// names, relative to the project root.
+var migrationScriptPatterns = []string{
+	"Makefile", "GNUmakefile", "justfile", "Taskfile.yml", "package.json",
+	".gitlab-ci.yml", ".pre-commit-config.yaml", "*.sh", "scripts/*.sh",
+	".github/workflows/*.yml", ".github/workflows/*.yaml",
+}
+
+// MigrationStep is one change `samuel migrate` makes, or asks the user to
+// make, to stop using a deprecated name.
+type MigrationStep struct {
+	Action      string
+	Deprecation Deprecation
+	// Path is the file to rename or rewrite relative to the project, or
+	// the environment variable for manual steps.
+	Path string
+	// Target is the new file name for renames.
+	Target string
+	// Lines are the 1-based lines of Path that mention the old name.
+	Lines []int
+	// Reason explains why a manual step cannot be applied automatically.
+	Reason string
+}
+
+// PlanMigration finds deprecated config files, environment variables, and
+// script references in dir and returns the steps that replace them.
+func PlanMigration(dir string) ([]MigrationStep, error) {
+	var steps []MigrationStep
+	current := ConfigFilePath(dir)
+	hasConfig := current != "" && !slices.Contains(legacyConfigFileNames(), filepath.Base(current))
+	for _, f := range DetectDeprecatedSettings(dir) {
+		d := f.Deprecation
+		switch {
+		case d.Kind == DeprecatedEnvVar:
+			steps = append(steps, MigrationStep{Action: MigrationManual, Deprecation: d, Path: f.Location,
+				Reason: fmt.Sprintf("unset %s and use %s instead", d.Old, d.New)})
+		case hasConfig:
+			steps = append(steps, MigrationStep{Action: MigrationManual, Deprecation: d, Path: f.Location,
+				Reason: fmt.Sprintf("a current config file already exists; merge %s into it and delete %s", d.Old, d.Old)})
+		default:
+			steps = append(steps, MigrationStep{Action: MigrationRename, Deprecation: d, Path: d.Old, Target: d.New})
+			hasConfig = true
+		}
+	}
+
+	scripts, err := findMigrationScripts(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, rel := range scripts {
+		data, err := os.ReadFile(filepath.Join(dir, rel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		steps = append(steps, planScriptRewrites(rel, string(data))...)
+	}
+	return steps, nil
+}
+
+func findMigrationScripts(dir string) ([]string, error) {
+	var scripts []string
+	for _, pattern := range migrationScriptPatterns {
+		matches, err := filepath.Glob(filepath.Join(dir, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("invalid script pattern %s: %w", pattern, err)
+		}
+		for _, match := range matches {
+			rel, err := filepath.Rel(dir, match)
+			if err != nil || slices.Contains(scripts, rel) {
+				continue
+			}
+			if info, err := os.Stat(match); err == nil && info.Mode().IsRegular() {
+				scripts = append(scripts, rel)
+			}
+		}
+	}
+	return scripts, nil
+}
+
+func planScriptRewrites(rel, content string) []MigrationStep {
+	var steps []MigrationStep
+	for _, d := range deprecations {
+		if d.Rewrite == "" {
+			continue
+		}
+		var hits []int
+		for i, line := range strings.Split(content, "\n") {
+			if containsDeprecatedName(line, d.Old) {
+				hits = append(hits, i+1)
+			}
+		}
+		if len(hits) > 0 {
+			steps = append(steps, MigrationStep{Action: MigrationRewrite, Deprecation: d, Path: rel, Lines: hits})
+		}
+		// Later entries must not match text this entry will rewrite.
+		content = rewriteDeprecatedName(content, d)
+	}
+	return steps
+}
+
+func rewriteDeprecatedName(content string, d Deprecation) string {
+	out, _ := replaceDeprecatedName(content, d.Old, d.Rewrite)
+	return out
+}
+
+// ApplyMigration performs the rename and rewrite steps in order. Manual
+// steps are skipped.
+func ApplyMigration(dir string, steps []MigrationStep) error {
+	for _, step := range steps {
+		path := filepath.Join(dir, step.Path)
+		switch step.Action {
+		case MigrationRename:
+			if err := os.Rename(path, filepath.Join(dir, step.Target)); err != nil {
+				return fmt.Errorf("failed to rename %s: %w", step.Path, err)
+			}
+		case MigrationRewrite:
+			info, err := os.Stat(path)
+			if err != nil {
+				return fmt.Errorf("failed to rewrite %s: %w", step.Path, err)
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to rewrite %s: %w", step.Path, err)
+			}
+			content := rewriteDeprecatedName(string(data), step.Deprecation)
+			if err := os.WriteFile(path, []byte(content), info.Mode().Perm()); err != nil {
+				return fmt.Errorf("failed to rewrite %s: %w", step.Path, err)
+			}
+		}
+	}
+	return nil
+}
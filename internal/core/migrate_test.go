@@ -0,0 +1,95 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPlanMigration(t *testing.T) {
+	orig := deprecationLookupEnv
+	defer func() { deprecationLookupEnv = orig }()
+	deprecationLookupEnv = func(string) (string, bool) { return "", false }
+
+	t.Run("renames_and_rewrites", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFiles(t, dir, map[string]string{
+			"aicof.yaml":               "version: 1.8.0\n",
+			"Makefile":                 "setup:\n\taicof init .\n\tcat aicof.yaml\n",
+			".github/workflows/ci.yml": "run: AICOF_NO_COLOR=1 aicof doctor\n",
+			"README.md":                "aicof is not a script\n",
+			"scripts/release.sh":       "echo done\n",
+		})
+
+		steps, err := PlanMigration(dir)
+		if err != nil {
+			t.Fatalf("PlanMigration returned error: %v", err)
+		}
+		var got []string
+		for _, s := range steps {
+			got = append(got, s.Action+":"+s.Path+":"+s.Deprecation.Old)
+		}
+		want := []string{
+			"rename:aicof.yaml:aicof.yaml",
+			"rewrite:Makefile:aicof.yaml",
+			"rewrite:Makefile:aicof",
+			"rewrite:" + filepath.Join(".github", "workflows", "ci.yml") + ":aicof",
+			"rewrite:" + filepath.Join(".github", "workflows", "ci.yml") + ":AICOF_NO_COLOR",
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("steps = %v\nwant %v", got, want)
+		}
+		if !slices.Equal(steps[2].Lines, []int{2}) {
+			t.Errorf("aicof lines = %v, want [2]; line 3 belongs to the config file rewrite", steps[2].Lines)
+		}
+	})
+
+	t.Run("conflicting_config_is_manual", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFiles(t, dir, map[string]string{
+			"samuel.yaml": "version: 2.0.0\n",
+			"aicof.yaml":  "version: 1.8.0\n",
+		})
+
+		steps, err := PlanMigration(dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(steps) != 1 || steps[0].Action != MigrationManual {
+			t.Errorf("steps = %+v, want one manual step", steps)
+		}
+	})
+}
+
+func TestApplyMigration(t *testing.T) {
+	orig := deprecationLookupEnv
+	defer func() { deprecationLookupEnv = orig }()
+	deprecationLookupEnv = func(string) (string, bool) { return "", false }
+
+	dir := t.TempDir()
+	writeTestFiles(t, dir, map[string]string{
+		".aicof.yaml": "version: 1.8.0\n",
+		"setup.sh":    "#!/bin/sh\naicof init --config .aicof.yaml\n",
+	})
+	steps, err := PlanMigration(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyMigration(dir, steps); err != nil {
+		t.Fatalf("ApplyMigration returned error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, AltConfigFileName)); err != nil {
+		t.Errorf("expected .aicof.yaml renamed to %s: %v", AltConfigFileName, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, "setup.sh"))
+	if want := "#!/bin/sh\nsamuel init --config .samuel.yaml\n"; string(data) != want {
+		t.Errorf("setup.sh = %q, want %q", data, want)
+	}
+
+	steps, _ = PlanMigration(dir)
+	if len(steps) != 0 {
+		t.Errorf("migration should be idempotent, second plan = %+v", steps)
+	}
+}
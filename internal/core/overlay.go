@@ -0,0 +1,282 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/github"
+)
+
+// Template layer names, reported for every file an install provides.
+const (
+	LayerBase    = "base"
+	LayerOverlay = "overlay"
+)
+
+// DefaultOverlayBranch is used when an overlay omits its branch.
+const DefaultOverlayBranch = "main"
+
+// overlayNamePattern restricts owner, repo, and branch names used in
+// cache directory names and archive URLs.
+var overlayNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// OverlayConfig points at a registry whose files are applied on top of
+// the base registry: a company fork that only carries its deltas.
+//
+// Commit, when set, pins downloads to that commit instead of the branch
+// head. It holds the commit whose signature was verified (see
+// RegistryIdentity) and is never saved.
+type OverlayConfig struct {
+	Registry string `yaml:"registry"`
+	Branch   string `yaml:"branch,omitempty"`
+	Commit   string `yaml:"-"`
+}
+
+// LayeredTemplate is an extraction source built from the base template
+// with an optional overlay applied on top.
+type LayeredTemplate struct {
+	// Path is the directory to extract from.
+	Path         string
+	overlayFiles []string
+	overrides    map[string]bool
+}
+
+// LayerOf reports which layer supplied the file at rel (slash-separated).
+func (t *LayeredTemplate) LayerOf(rel string) string {
+	rel = filepath.ToSlash(rel)
+	for _, f := range t.overlayFiles {
+		if f == rel {
+			return LayerOverlay
+		}
+	}
+	return LayerBase
+}
+
+// OverlayFiles returns every file supplied by the overlay, sorted.
+func (t *LayeredTemplate) OverlayFiles() []string {
+	return t.overlayFiles
+}
+
+// Overrides returns overlay files that replaced a base file, sorted.
+func (t *LayeredTemplate) Overrides() []string {
+	var result []string
+	for _, f := range t.overlayFiles {
+		if t.overrides[f] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// ParseGitHubRegistry extracts the owner and repository from an HTTPS
+// GitHub registry URL such as https://github.com/acme/samuel-overlay.
+func ParseGitHubRegistry(registry string) (string, string, error) {
+	u, err := url.Parse(registry)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid registry URL: %w", err)
+	}
+	if u.Scheme != "https" || u.Host != "github.com" {
+		return "", "", fmt.Errorf("registry must be an https://github.com URL, got %q", registry)
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("registry must be https://github.com/<owner>/<repo>, got %q", registry)
+	}
+	owner, repo := parts[0], strings.TrimSuffix(parts[1], ".git")
+	if !overlayNamePattern.MatchString(owner) || !overlayNamePattern.MatchString(repo) {
+		return "", "", fmt.Errorf("invalid owner or repository in %q", registry)
+	}
+	return owner, repo, nil
+}
+
+// ValidateOverlay checks an overlay config before anything is downloaded.
+func ValidateOverlay(cfg *OverlayConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	if _, _, err := ParseGitHubRegistry(cfg.Registry); err != nil {
+		return err
+	}
+	return ValidateOverlayBranch(cfg.Branch)
+}
+
+// ValidateOverlayBranch checks a branch name; empty selects the default.
+func ValidateOverlayBranch(branch string) error {
+	if branch != "" && (!overlayNamePattern.MatchString(branch) || strings.Contains(branch, "..")) {
+		return fmt.Errorf("invalid overlay branch: %q", branch)
+	}
+	return nil
+}
+
+// overlayBranch returns the configured branch or the default.
+func (c *OverlayConfig) overlayBranch() string {
+	if c.Branch == "" {
+		return DefaultOverlayBranch
+	}
+	return c.Branch
+}
+
+// overlayRef returns the verified commit when one is pinned, otherwise
+// the branch.
+func (c *OverlayConfig) overlayRef() string {
+	if c.Commit != "" {
+		return c.Commit
+	}
+	return c.overlayBranch()
+}
+
+// DownloadOverlay downloads the overlay into the cache: the pinned commit
+// when set, otherwise the branch head. Overlays track a branch rather
+// than releases, so the copy is always refreshed.
+func (d *Downloader) DownloadOverlay(cfg *OverlayConfig) (string, error) {
+	if err := ValidateOverlay(cfg); err != nil {
+		return "", err
+	}
+	if cfg.Commit != "" && !commitSHAPattern.MatchString(cfg.Commit) {
+		return "", fmt.Errorf("invalid overlay commit: %q", cfg.Commit)
+	}
+	owner, repo, _ := ParseGitHubRegistry(cfg.Registry)
+	key := overlayCacheKey(owner, repo, cfg.overlayRef())
+	return d.inflight.do("overlay/"+key, func() (string, error) {
+		return d.downloadOverlay(owner, repo, cfg, key)
+	})
+}
+
+func (d *Downloader) downloadOverlay(owner, repo string, cfg *OverlayConfig, key string) (string, error) {
+	cacheDest := filepath.Join(d.cachePath, "overlay-"+key)
+	if err := os.RemoveAll(cacheDest); err != nil {
+		return "", fmt.Errorf("failed to clear overlay cache: %w", err)
+	}
+
+	client := github.NewClient(owner, repo)
+	download := client.DownloadBranchArchive
+	if cfg.Commit != "" {
+		download = client.DownloadRefArchive
+	}
+	reader, _, err := download(cfg.overlayRef())
+	if err != nil {
+		return "", fmt.Errorf("failed to download overlay: %w", err)
+	}
+	defer reader.Close()
+
+	if err := cacheArchive(reader, cacheDest); err != nil {
+		return "", err
+	}
+	return cacheDest, nil
+}
+
+// ResolveTemplate downloads the base version and, when configured, the
+// overlay, and returns the layered extraction source. The two archives are
+// fetched concurrently.
+func (d *Downloader) ResolveTemplate(version string, overlay *OverlayConfig) (*LayeredTemplate, error) {
+	if overlay == nil || overlay.Registry == "" {
+		basePath, err := d.DownloadVersion(version)
+		if err != nil {
+			return nil, err
+		}
+		return &LayeredTemplate{Path: basePath}, nil
+	}
+
+	var basePath, overlayPath string
+	var baseErr, overlayErr error
+	forEachBounded(2, 2, func(i int) {
+		if i == 0 {
+			basePath, baseErr = d.DownloadVersion(version)
+		} else {
+			overlayPath, overlayErr = d.DownloadOverlay(overlay)
+		}
+	})
+	if baseErr != nil {
+		return nil, baseErr
+	}
+	if overlayErr != nil {
+		return nil, overlayErr
+	}
+	owner, repo, _ := ParseGitHubRegistry(overlay.Registry)
+	merged := filepath.Join(d.cachePath, fmt.Sprintf("layered-%s-%s",
+		version, overlayCacheKey(owner, repo, overlay.overlayRef())))
+	return BuildLayeredTemplate(basePath, overlayPath, merged)
+}
+
+func overlayCacheKey(owner, repo, branch string) string {
+	return strings.ReplaceAll(owner+"-"+repo+"-"+branch, "/", "_")
+}
+
+// BuildLayeredTemplate copies basePath into mergedPath and applies every
+// regular file under the overlay's template/ directory on top, recording
+// which files the overlay supplied. Symlinks in the overlay are ignored.
+func BuildLayeredTemplate(basePath, overlayPath, mergedPath string) (*LayeredTemplate, error) {
+	overlayTemplate := filepath.Join(overlayPath, TemplatePrefix)
+	if info, err := os.Stat(overlayTemplate); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("overlay has no %s directory", TemplatePrefix)
+	}
+	if err := os.RemoveAll(mergedPath); err != nil {
+		return nil, fmt.Errorf("failed to clear layered template: %w", err)
+	}
+	if err := copyDir(basePath, mergedPath); err != nil {
+		return nil, fmt.Errorf("failed to copy base template: %w", err)
+	}
+
+	t := &LayeredTemplate{Path: mergedPath, overrides: make(map[string]bool)}
+	err := filepath.WalkDir(overlayTemplate, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(overlayTemplate, path)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(mergedPath, TemplatePrefix, rel)
+		if _, statErr := os.Stat(dest); statErr == nil {
+			t.overrides[filepath.ToSlash(rel)] = true
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		t.overlayFiles = append(t.overlayFiles, filepath.ToSlash(rel))
+		return copyFile(path, dest)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply overlay: %w", err)
+	}
+
+	sort.Strings(t.overlayFiles)
+	return t, nil
+}
+
+// MergeOverlayPaths adds overlay-only files to the install paths. Files
+// already covered by a selected path need no entry, and files belonging to
+// a registry component that was not selected are left out, as is the
+// registry's policy file.
+func MergeOverlayPaths(paths []string, t *LayeredTemplate) []string {
+	var components []string
+	for _, group := range [][]Component{Languages, Frameworks, Workflows} {
+		for _, c := range group {
+			components = append(components, c.Path)
+		}
+	}
+
+	merged := append([]string{}, paths...)
+	for _, f := range t.OverlayFiles() {
+		if f != PolicyFile && !underAnyPath(f, paths) && !underAnyPath(f, components) {
+			merged = append(merged, f)
+		}
+	}
+	return merged
+}
+
+func underAnyPath(file string, paths []string) bool {
+	for _, p := range paths {
+		p = strings.TrimSuffix(filepath.ToSlash(p), "/")
+		if file == p || strings.HasPrefix(file, p+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseGitHubRegistry(t *testing.T) {
+	tests := []struct {
+		name      string
+		registry  string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{"plain", "https://github.com/acme/overlay", "acme", "overlay", false},
+		{"git_suffix", "https://github.com/acme/overlay.git", "acme", "overlay", false},
+		{"trailing_slash", "https://github.com/acme/overlay/", "acme", "overlay", false},
+		{"http", "http://github.com/acme/overlay", "", "", true},
+		{"other_host", "https://gitlab.com/acme/overlay", "", "", true},
+		{"missing_repo", "https://github.com/acme", "", "", true},
+		{"extra_segments", "https://github.com/acme/overlay/tree/main", "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, repo, err := ParseGitHubRegistry(tt.registry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGitHubRegistry(%q) error = %v, wantErr %v", tt.registry, err, tt.wantErr)
+			}
+			if owner != tt.wantOwner || repo != tt.wantRepo {
+				t.Errorf("ParseGitHubRegistry(%q) = %q, %q, want %q, %q", tt.registry, owner, repo, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
+
+func TestValidateOverlay(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *OverlayConfig
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"default_branch", &OverlayConfig{Registry: "https://github.com/acme/overlay"}, false},
+		{"named_branch", &OverlayConfig{Registry: "https://github.com/acme/overlay", Branch: "release/v2"}, false},
+		{"bad_registry", &OverlayConfig{Registry: "ftp://example.com"}, true},
+		{"traversal_branch", &OverlayConfig{Registry: "https://github.com/acme/overlay", Branch: "../main"}, true},
+		{"option_branch", &OverlayConfig{Registry: "https://github.com/acme/overlay", Branch: "-x"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateOverlay(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateOverlay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOverlayConfig_OverlayRef(t *testing.T) {
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	cfg := &OverlayConfig{Registry: "https://github.com/acme/overlay"}
+	if got := cfg.overlayRef(); got != DefaultOverlayBranch {
+		t.Errorf("overlayRef() = %q, want the default branch", got)
+	}
+	cfg.Commit = sha
+	if got := cfg.overlayRef(); got != sha {
+		t.Errorf("overlayRef() = %q, want the verified commit", got)
+	}
+	if overlayCacheKey("acme", "overlay", cfg.overlayRef()) == overlayCacheKey("acme", "overlay", cfg.overlayBranch()) {
+		t.Error("a pinned commit must not share the branch cache")
+	}
+
+	cfg.Commit = "../main"
+	d := &Downloader{cachePath: t.TempDir()}
+	if _, err := d.DownloadOverlay(cfg); err == nil || !strings.Contains(err.Error(), "invalid overlay commit") {
+		t.Errorf("DownloadOverlay() error = %v, want invalid overlay commit", err)
+	}
+}
+
+func TestBuildLayeredTemplate(t *testing.T) {
+	base, overlay := t.TempDir(), t.TempDir()
+	merged := filepath.Join(t.TempDir(), "merged")
+	createTemplateFile(t, base, "CLAUDE.md", "base rules")
+	createTemplateFile(t, base, "AGENTS.md", "base agents")
+	createTemplateFile(t, overlay, "CLAUDE.md", "company rules")
+	createTemplateFile(t, overlay, ".claude/skills/acme-style/SKILL.md", "acme")
+
+	tmpl, err := BuildLayeredTemplate(base, overlay, merged)
+	if err != nil {
+		t.Fatalf("BuildLayeredTemplate returned error: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(merged, TemplatePrefix, "CLAUDE.md"))
+	if string(content) != "company rules" {
+		t.Errorf("CLAUDE.md = %q, want overlay content", content)
+	}
+	content, _ = os.ReadFile(filepath.Join(merged, TemplatePrefix, "AGENTS.md"))
+	if string(content) != "base agents" {
+		t.Errorf("AGENTS.md = %q, want base content", content)
+	}
+
+	wantFiles := []string{".claude/skills/acme-style/SKILL.md", "CLAUDE.md"}
+	if !slices.Equal(tmpl.OverlayFiles(), wantFiles) {
+		t.Errorf("OverlayFiles() = %v, want %v", tmpl.OverlayFiles(), wantFiles)
+	}
+	if !slices.Equal(tmpl.Overrides(), []string{"CLAUDE.md"}) {
+		t.Errorf("Overrides() = %v, want [CLAUDE.md]", tmpl.Overrides())
+	}
+	if tmpl.LayerOf("CLAUDE.md") != LayerOverlay || tmpl.LayerOf("AGENTS.md") != LayerBase {
+		t.Errorf("LayerOf reported wrong layers: CLAUDE.md=%s AGENTS.md=%s",
+			tmpl.LayerOf("CLAUDE.md"), tmpl.LayerOf("AGENTS.md"))
+	}
+
+	if _, err := os.Stat(filepath.Join(base, TemplatePrefix, ".claude")); !os.IsNotExist(err) {
+		t.Error("building the layered template must not modify the base cache")
+	}
+}
+
+func TestBuildLayeredTemplate_NoTemplateDir(t *testing.T) {
+	base, overlay := t.TempDir(), t.TempDir()
+	createTemplateFile(t, base, "CLAUDE.md", "base")
+
+	if _, err := BuildLayeredTemplate(base, overlay, filepath.Join(t.TempDir(), "merged")); err == nil {
+		t.Error("expected error for overlay without a template directory")
+	}
+}
+
+func TestMergeOverlayPaths(t *testing.T) {
+	tmpl := &LayeredTemplate{overlayFiles: []string{
+		"CLAUDE.md",
+		".claude/skills/go-guide/SKILL.md",
+		".claude/skills/rust-guide/SKILL.md",
+		".claude/skills/acme-style/SKILL.md",
+	}}
+	paths := []string{"CLAUDE.md", ".claude/skills/go-guide"}
+
+	got := MergeOverlayPaths(paths, tmpl)
+
+	want := []string{"CLAUDE.md", ".claude/skills/go-guide", ".claude/skills/acme-style/SKILL.md"}
+	if !slices.Equal(got, want) {
+		t.Errorf("MergeOverlayPaths() = %v, want %v", got, want)
+	}
+	if len(paths) != 2 {
+		t.Errorf("MergeOverlayPaths modified its input: %v", paths)
+	}
+}
@@ -0,0 +1,248 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// PolicyFile is the organization policy a registry ships in its template/
+// directory. It is read from the overlay layer only and never installed.
+const PolicyFile = "policy.yaml"
+
+// PolicyAuditFile is the project file overridden policy violations are
+// appended to, one JSON object per line, for review in version control.
+const PolicyAuditFile = ".claude/policy-overrides.jsonl"
+
+// Policy rules, as named in policy.yaml and in violations.
+const (
+	PolicyRuleBannedSkills      = "banned_skills"
+	PolicyRuleRequiredWorkflows = "required_workflows"
+	PolicyRuleProtectedPaths    = "protected_paths"
+	PolicyRuleMinDoctorScore    = "min_doctor_score"
+)
+
+// OrgPolicy holds the organization-wide constraints a registry imposes on
+// the projects that use it as their overlay.
+type OrgPolicy struct {
+	// BannedSkills lists skill directory names that may not be installed.
+	BannedSkills []string `yaml:"banned_skills,omitempty"`
+	// RequiredWorkflows lists workflows every project must have installed.
+	RequiredWorkflows []string `yaml:"required_workflows,omitempty"`
+	// ProtectedPaths lists files, directories or glob patterns that must
+	// match the registry's version; local edits to them are violations.
+	ProtectedPaths []string `yaml:"protected_paths,omitempty"`
+	// MinDoctorScore is the share of passing 'samuel doctor' checks, in
+	// percent, a project needs before components are added or updated.
+	MinDoctorScore int `yaml:"min_doctor_score,omitempty"`
+
+	// Registry is the overlay registry the policy came from.
+	Registry string `yaml:"-"`
+}
+
+// PolicyViolation is one way an operation breaks the policy.
+type PolicyViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyOverride is an audit record of violations a user chose to override.
+type PolicyOverride struct {
+	Timestamp  string            `json:"timestamp"`
+	Command    string            `json:"command"`
+	User       string            `json:"user"`
+	Registry   string            `json:"registry"`
+	Reason     string            `json:"reason"`
+	Violations []PolicyViolation `json:"violations"`
+}
+
+// LoadOrgPolicy reads the policy supplied by the overlay of tmpl. It
+// returns nil when the overlay ships none; a policy.yaml in the base
+// template is ignored, since only an organization's registry sets policy.
+func LoadOrgPolicy(tmpl *LayeredTemplate, registry string) (*OrgPolicy, error) {
+	if tmpl == nil || tmpl.LayerOf(PolicyFile) != LayerOverlay {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(tmpl.Path, TemplatePrefix, PolicyFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry policy: %w", err)
+	}
+	var policy OrgPolicy
+	if err := yaml.Unmarshal(data, &policy); err != nil {
+		return nil, fmt.Errorf("invalid registry policy %s: %w", PolicyFile, err)
+	}
+	if err := policy.validate(); err != nil {
+		return nil, fmt.Errorf("invalid registry policy %s: %w", PolicyFile, err)
+	}
+	policy.Registry = registry
+	return &policy, nil
+}
+
+func (p *OrgPolicy) validate() error {
+	if p.MinDoctorScore < 0 || p.MinDoctorScore > 100 {
+		return fmt.Errorf("%s must be between 0 and 100, got %d", PolicyRuleMinDoctorScore, p.MinDoctorScore)
+	}
+	for _, name := range p.BannedSkills {
+		if errs := ValidateSkillName(name); len(errs) > 0 {
+			return fmt.Errorf("%s: %q: %s", PolicyRuleBannedSkills, name, errs[0])
+		}
+	}
+	for _, pattern := range p.ProtectedPaths {
+		if _, err := path.Match(strings.TrimSuffix(pattern, "/"), ""); err != nil || pattern == "" {
+			return fmt.Errorf("%s: invalid pattern %q", PolicyRuleProtectedPaths, pattern)
+		}
+	}
+	return nil
+}
+
+// IsBanned reports whether the skill, or the skill a variant belongs to,
+// is banned.
+func (p *OrgPolicy) IsBanned(skill string) bool {
+	name, _ := SplitSkillVariant(skill)
+	return p != nil && slices.Contains(p.BannedSkills, name)
+}
+
+// skillOfPath returns the skill directory a template path belongs to, or "".
+func skillOfPath(rel string) string {
+	rest, ok := strings.CutPrefix(filepath.ToSlash(rel), ".claude/skills/")
+	if !ok {
+		return ""
+	}
+	name, _, _ := strings.Cut(rest, "/")
+	return name
+}
+
+// CheckSkills returns a violation for each banned skill among the skills
+// an operation installs by name.
+func (p *OrgPolicy) CheckSkills(skills []string) []PolicyViolation {
+	var violations []PolicyViolation
+	for _, skill := range skills {
+		if p.IsBanned(skill) {
+			violations = append(violations, PolicyViolation{Rule: PolicyRuleBannedSkills,
+				Message: fmt.Sprintf("skill '%s' is banned", skill)})
+		}
+	}
+	return violations
+}
+
+// DropBannedSkills removes the files of banned skills from install paths,
+// except those of the skills in keep, and returns the remaining paths and
+// the skills left out.
+func (p *OrgPolicy) DropBannedSkills(paths, keep []string) (kept, dropped []string) {
+	for _, rel := range paths {
+		skill := skillOfPath(rel)
+		if skill == "" || !p.IsBanned(skill) || slices.Contains(keep, skill) {
+			kept = append(kept, rel)
+			continue
+		}
+		if !slices.Contains(dropped, skill) {
+			dropped = append(dropped, skill)
+		}
+	}
+	return kept, dropped
+}
+
+// CheckWorkflows returns a violation for each required workflow missing
+// from the installed ones; "all" installs every workflow.
+func (p *OrgPolicy) CheckWorkflows(installed []string) []PolicyViolation {
+	if p == nil || slices.Contains(installed, "all") {
+		return nil
+	}
+	var violations []PolicyViolation
+	for _, wf := range p.RequiredWorkflows {
+		if !slices.Contains(installed, wf) {
+			violations = append(violations, PolicyViolation{Rule: PolicyRuleRequiredWorkflows,
+				Message: fmt.Sprintf("workflow '%s' is required; install it with 'samuel add workflow %s'", wf, wf)})
+		}
+	}
+	return violations
+}
+
+// Protects reports whether rel, a project path, is or is under a path
+// matching a protected pattern.
+func (p *OrgPolicy) Protects(rel string) bool {
+	if p == nil {
+		return false
+	}
+	for _, pattern := range p.ProtectedPaths {
+		pattern = strings.TrimSuffix(pattern, "/")
+		for dir := filepath.ToSlash(rel); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if ok, _ := path.Match(pattern, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CheckProtectedFiles returns a violation for each protected file among
+// the template files under paths that differs from its copy in projectDir.
+// Files missing locally are fine: installing restores them.
+func (p *OrgPolicy) CheckProtectedFiles(templatePath, projectDir string, paths []string) []PolicyViolation {
+	if p == nil || len(p.ProtectedPaths) == 0 {
+		return nil
+	}
+	root := filepath.Join(templatePath, TemplatePrefix)
+	var violations []PolicyViolation
+	for _, base := range paths {
+		_ = filepath.WalkDir(filepath.Join(root, base), func(src string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(root, src)
+			if rel = filepath.ToSlash(rel); !p.Protects(rel) {
+				return nil
+			}
+			local, localErr := os.ReadFile(filepath.Join(projectDir, rel))
+			upstream, err := os.ReadFile(src)
+			if localErr == nil && err == nil && !SameContent(rel, local, upstream) {
+				violations = append(violations, PolicyViolation{Rule: PolicyRuleProtectedPaths,
+					Message: fmt.Sprintf("%s is protected and has local changes; restore the registry's version", rel)})
+			}
+			return nil
+		})
+	}
+	return violations
+}
+
+// CheckDoctorScore returns a violation when the doctor score, in percent,
+// is below the minimum.
+func (p *OrgPolicy) CheckDoctorScore(score int) []PolicyViolation {
+	if p == nil || score >= p.MinDoctorScore {
+		return nil
+	}
+	return []PolicyViolation{{Rule: PolicyRuleMinDoctorScore,
+		Message: fmt.Sprintf("doctor score is %d%%, below the required %d%%; run 'samuel doctor --fix'", score, p.MinDoctorScore)}}
+}
+
+// RecordPolicyOverride appends an override to the project's audit file.
+func RecordPolicyOverride(projectDir string, o PolicyOverride) error {
+	if o.Timestamp == "" {
+		o.Timestamp = time.Now().UTC().Format(time.RFC3339)
+	}
+	line, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	auditPath := filepath.Join(projectDir, PolicyAuditFile)
+	if err := os.MkdirAll(filepath.Dir(auditPath), 0755); err != nil {
+		return fmt.Errorf("failed to create audit directory: %w", err)
+	}
+	f, err := os.OpenFile(auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open policy audit file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to record policy override: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+package core
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const testPolicyRegistry = "https://github.com/acme/overlay"
+
+// layeredPolicyTemplate builds a layered template whose overlay ships the
+// given policy.yaml, or none when policy is empty.
+func layeredPolicyTemplate(t *testing.T, policy string) *LayeredTemplate {
+	t.Helper()
+	base, overlay := t.TempDir(), t.TempDir()
+	createTemplateFile(t, base, "CLAUDE.md", "base")
+	createTemplateFile(t, base, ".claude/skills/go-guide/SKILL.md", "go")
+	createTemplateFile(t, overlay, "AGENTS.md", "org agents")
+	if policy != "" {
+		createTemplateFile(t, overlay, PolicyFile, policy)
+	}
+	tmpl, err := BuildLayeredTemplate(base, overlay, filepath.Join(t.TempDir(), "merged"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tmpl
+}
+
+func TestLoadOrgPolicy(t *testing.T) {
+	tmpl := layeredPolicyTemplate(t, "banned_skills: [go-guide]\nrequired_workflows: [security-audit]\n"+
+		"protected_paths: [AGENTS.md, .claude/skills/security-*]\nmin_doctor_score: 80\n")
+	policy, err := LoadOrgPolicy(tmpl, testPolicyRegistry)
+	if err != nil {
+		t.Fatalf("LoadOrgPolicy() error = %v", err)
+	}
+	if !slices.Equal(policy.BannedSkills, []string{"go-guide"}) || policy.MinDoctorScore != 80 ||
+		len(policy.ProtectedPaths) != 2 || policy.Registry != testPolicyRegistry {
+		t.Errorf("LoadOrgPolicy() = %+v", policy)
+	}
+	if paths := MergeOverlayPaths(nil, tmpl); slices.Contains(paths, PolicyFile) {
+		t.Errorf("policy file is installed: %v", paths)
+	}
+}
+
+func TestLoadOrgPolicy_None(t *testing.T) {
+	policy, err := LoadOrgPolicy(layeredPolicyTemplate(t, ""), testPolicyRegistry)
+	if err != nil || policy != nil {
+		t.Errorf("LoadOrgPolicy() = %v, %v; want nil, nil", policy, err)
+	}
+
+	// A policy in the base template is not the organization's.
+	base := t.TempDir()
+	createTemplateFile(t, base, PolicyFile, "min_doctor_score: 90\n")
+	if policy, _ := LoadOrgPolicy(&LayeredTemplate{Path: base}, ""); policy != nil {
+		t.Errorf("base template policy was loaded: %+v", policy)
+	}
+}
+
+func TestLoadOrgPolicy_Invalid(t *testing.T) {
+	for _, content := range []string{
+		"min_doctor_score: 120\n",
+		"banned_skills: [Bad_Name]\n",
+		"protected_paths: ['[']\n",
+		"banned_skills: go-guide: x\n",
+	} {
+		if _, err := LoadOrgPolicy(layeredPolicyTemplate(t, content), testPolicyRegistry); err == nil {
+			t.Errorf("LoadOrgPolicy(%q) succeeded, want an error", content)
+		}
+	}
+}
+
+func TestOrgPolicy_Skills(t *testing.T) {
+	policy := &OrgPolicy{BannedSkills: []string{"react"}}
+	violations := policy.CheckSkills([]string{"go-guide", "react", "react@strict"})
+	if len(violations) != 2 || violations[0].Rule != PolicyRuleBannedSkills {
+		t.Errorf("CheckSkills() = %v", violations)
+	}
+
+	paths := []string{"CLAUDE.md", ".claude/skills/go-guide", ".claude/skills/react", ".claude/skills/react/SKILL.md"}
+	kept, dropped := policy.DropBannedSkills(paths, nil)
+	if !slices.Equal(kept, paths[:2]) || !slices.Equal(dropped, []string{"react"}) {
+		t.Errorf("DropBannedSkills() = %v, %v", kept, dropped)
+	}
+	if kept, _ := policy.DropBannedSkills(paths, []string{"react"}); len(kept) != len(paths) {
+		t.Errorf("DropBannedSkills() with react kept = %v", kept)
+	}
+
+	var none *OrgPolicy
+	if kept, dropped := none.DropBannedSkills(paths, nil); len(kept) != len(paths) || dropped != nil {
+		t.Errorf("nil policy dropped %v", dropped)
+	}
+}
+
+func TestOrgPolicy_CheckWorkflows(t *testing.T) {
+	policy := &OrgPolicy{RequiredWorkflows: []string{"security-audit", "code-review"}}
+	if v := policy.CheckWorkflows([]string{"all"}); v != nil {
+		t.Errorf("all workflows: %v", v)
+	}
+	v := policy.CheckWorkflows([]string{"code-review"})
+	if len(v) != 1 || !strings.Contains(v[0].Message, "samuel add workflow security-audit") {
+		t.Errorf("CheckWorkflows() = %v", v)
+	}
+}
+
+func TestOrgPolicy_Protects(t *testing.T) {
+	policy := &OrgPolicy{ProtectedPaths: []string{"AGENTS.md", ".claude/skills/security-*", ".github/"}}
+	for path, want := range map[string]bool{
+		"AGENTS.md":                          true,
+		".claude/skills/security-audit":      true,
+		".claude/skills/security-audit/x.md": true,
+		".github/workflows/ci.yml":           true,
+		"CLAUDE.md":                          false,
+		".claude/skills/go-guide/SKILL.md":   false,
+	} {
+		if got := policy.Protects(path); got != want {
+			t.Errorf("Protects(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestOrgPolicy_CheckProtectedFiles(t *testing.T) {
+	tmpl := layeredPolicyTemplate(t, "")
+	project := t.TempDir()
+	if err := os.WriteFile(filepath.Join(project, "AGENTS.md"), []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "CLAUDE.md"), []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &OrgPolicy{ProtectedPaths: []string{"AGENTS.md", ".claude/skills"}}
+	v := policy.CheckProtectedFiles(tmpl.Path, project, []string{"CLAUDE.md", "AGENTS.md", ".claude/skills/go-guide"})
+	if len(v) != 1 || !strings.HasPrefix(v[0].Message, "AGENTS.md is protected") {
+		t.Errorf("CheckProtectedFiles() = %v", v)
+	}
+}
+
+func TestOrgPolicy_CheckDoctorScore(t *testing.T) {
+	policy := &OrgPolicy{MinDoctorScore: 80}
+	if v := policy.CheckDoctorScore(80); v != nil {
+		t.Errorf("score at the minimum: %v", v)
+	}
+	if v := policy.CheckDoctorScore(75); len(v) != 1 || v[0].Rule != PolicyRuleMinDoctorScore {
+		t.Errorf("CheckDoctorScore(75) = %v", v)
+	}
+}
+
+func TestRecordPolicyOverride(t *testing.T) {
+	dir := t.TempDir()
+	for _, reason := range []string{"pilot project", "migration"} {
+		err := RecordPolicyOverride(dir, PolicyOverride{Command: "samuel add", Reason: reason,
+			Violations: []PolicyViolation{{Rule: PolicyRuleBannedSkills, Message: "skill 'react' is banned"}}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, PolicyAuditFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("audit file has %d lines, want 2", len(lines))
+	}
+	var record PolicyOverride
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Reason != "migration" || record.Timestamp == "" || len(record.Violations) != 1 {
+		t.Errorf("record = %+v", record)
+	}
+}
@@ -0,0 +1,168 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Dependency ecosystems whose lockfiles DetectProject reads.
+const (
+	EcosystemNPM      = "npm"
+	EcosystemGo       = "go"
+	EcosystemPython   = "python"
+	EcosystemRuby     = "ruby"
+	EcosystemComposer = "composer"
+)
+
+// DetectedComponent is a language or framework found in a project.
+type DetectedComponent struct {
+	Name string // registry name
+	Kind string // ComponentLanguage or ComponentFramework
+	// Version is the resolved version from a lockfile, when known.
+	Version string
+	// Source is the file it was detected from, relative to the project.
+	Source string
+}
+
+// ProjectDetection lists the languages and frameworks of a project, in
+// registry order.
+type ProjectDetection struct {
+	Languages  []DetectedComponent
+	Frameworks []DetectedComponent
+}
+
+// LanguageNames returns the names of the detected languages.
+func (d *ProjectDetection) LanguageNames() []string {
+	return componentNames(d.Languages)
+}
+
+// FrameworkNames returns the names of the detected frameworks.
+func (d *ProjectDetection) FrameworkNames() []string {
+	return componentNames(d.Frameworks)
+}
+
+func componentNames(components []DetectedComponent) []string {
+	names := make([]string, len(components))
+	for i, c := range components {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// languageMarkers maps files whose presence identifies a language.
+var languageMarkers = []struct {
+	file     string
+	language string
+}{
+	{"package.json", "typescript"}, {"tsconfig.json", "typescript"},
+	{"pyproject.toml", "python"}, {"requirements.txt", "python"}, {"poetry.lock", "python"}, {"setup.py", "python"},
+	{"go.mod", "go"},
+	{"Cargo.toml", "rust"},
+	{"build.gradle.kts", "kotlin"},
+	{"pom.xml", "java"}, {"build.gradle", "java"},
+	{"composer.json", "php"},
+	{"Package.swift", "swift"},
+	{"Gemfile", "ruby"},
+	{"pubspec.yaml", "dart"},
+	{"CMakeLists.txt", "cpp"},
+}
+
+// frameworkPackages maps dependencies to frameworks. A package ending in
+// "/" matches that module path with any major version suffix.
+var frameworkPackages = map[string]map[string]string{
+	EcosystemNPM: {"next": "nextjs", "react": "react", "express": "express"},
+	EcosystemGo: {
+		"github.com/gin-gonic/gin": "gin", "github.com/labstack/echo/": "echo", "github.com/gofiber/fiber/": "fiber",
+	},
+	EcosystemPython:   {"django": "django", "fastapi": "fastapi", "flask": "flask"},
+	EcosystemRuby:     {"rails": "rails", "sinatra": "sinatra", "hanami": "hanami"},
+	EcosystemComposer: {"laravel/framework": "laravel", "symfony/framework-bundle": "symfony", "johnpbloch/wordpress": "wordpress", "roots/wordpress": "wordpress"},
+}
+
+// frameworkSupersedes drops a framework that another one builds on: a
+// Next.js app always depends on React, but the nextjs guide is the one
+// that applies.
+var frameworkSupersedes = map[string]string{"nextjs": "react"}
+
+// DetectProject identifies the languages of the project in dir from marker
+// files, and its frameworks and their versions from the dependencies
+// resolved in its lockfiles (package-lock.json, yarn.lock, pnpm-lock.yaml,
+// go.mod, poetry.lock, Gemfile.lock and composer.lock). Only the project
+// root is inspected, and unreadable lockfiles are skipped.
+func DetectProject(dir string) *ProjectDetection {
+	d := &ProjectDetection{}
+	for _, m := range languageMarkers {
+		if _, err := os.Stat(filepath.Join(dir, m.file)); err == nil {
+			d.addLanguage(DetectedComponent{Name: m.language, Kind: ComponentLanguage, Source: m.file})
+		}
+	}
+	for _, lock := range lockfileParsers {
+		deps, err := lock.parse(filepath.Join(dir, lock.file))
+		if err != nil {
+			continue
+		}
+		d.addLanguage(DetectedComponent{Name: lock.language, Kind: ComponentLanguage, Source: lock.file})
+		for _, dep := range deps {
+			if name := frameworkForPackage(lock.ecosystem, dep.name); name != "" {
+				d.addFramework(DetectedComponent{Name: name, Kind: ComponentFramework, Version: dep.version, Source: lock.file})
+			}
+		}
+	}
+	d.Frameworks = slices.DeleteFunc(d.Frameworks, func(c DetectedComponent) bool {
+		for winner, loser := range frameworkSupersedes {
+			if c.Name == loser && slices.Contains(d.FrameworkNames(), winner) {
+				return true
+			}
+		}
+		return false
+	})
+	sortByRegistry(d.Languages, Languages)
+	sortByRegistry(d.Frameworks, Frameworks)
+	return d
+}
+
+func frameworkForPackage(ecosystem, pkg string) string {
+	for prefix, name := range frameworkPackages[ecosystem] {
+		if pkg == prefix || (strings.HasSuffix(prefix, "/") && isMajorVersionOf(pkg, prefix)) {
+			return name
+		}
+	}
+	return ""
+}
+
+// isMajorVersionOf reports whether pkg is the module prefix without a
+// version suffix or with a /vN suffix.
+func isMajorVersionOf(pkg, prefix string) bool {
+	if pkg == strings.TrimSuffix(prefix, "/") {
+		return true
+	}
+	rest, ok := strings.CutPrefix(pkg, prefix)
+	return ok && len(rest) > 1 && rest[0] == 'v' && strings.Trim(rest[1:], "0123456789") == ""
+}
+
+func (d *ProjectDetection) addLanguage(c DetectedComponent) {
+	if !slices.ContainsFunc(d.Languages, func(l DetectedComponent) bool { return l.Name == c.Name }) {
+		d.Languages = append(d.Languages, c)
+	}
+}
+
+// addFramework records c, keeping the first source that reported a
+// version.
+func (d *ProjectDetection) addFramework(c DetectedComponent) {
+	i := slices.IndexFunc(d.Frameworks, func(f DetectedComponent) bool { return f.Name == c.Name })
+	switch {
+	case i < 0:
+		d.Frameworks = append(d.Frameworks, c)
+	case d.Frameworks[i].Version == "" && c.Version != "":
+		d.Frameworks[i] = c
+	}
+}
+
+func sortByRegistry(components []DetectedComponent, registry []Component) {
+	index := func(name string) int {
+		return slices.IndexFunc(registry, func(r Component) bool { return r.Name == name })
+	}
+	slices.SortStableFunc(components, func(a, b DetectedComponent) int { return index(a.Name) - index(b.Name) })
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDetectProject(t *testing.T) {
+	tests := []struct {
+		name           string
+		files          map[string]string
+		wantLanguages  []string
+		wantFrameworks map[string]string // name -> version
+	}{
+		{
+			name:  "empty project",
+			files: map[string]string{"README.md": "# hi"},
+		},
+		{
+			name: "next supersedes react in package-lock v3",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"next": "^14.1.0", "react": "^18"}}`,
+				"package-lock.json": `{"lockfileVersion": 3, "packages": {
+					"": {"dependencies": {"next": "^14.1.0", "react": "^18"}},
+					"node_modules/next": {"version": "14.1.0"},
+					"node_modules/react": {"version": "18.2.0"},
+					"node_modules/express": {"version": "4.18.2"}}}`,
+			},
+			wantLanguages:  []string{"typescript"},
+			wantFrameworks: map[string]string{"nextjs": "14.1.0"},
+		},
+		{
+			name: "react without next in package-lock v1",
+			files: map[string]string{
+				"package.json":      `{"dependencies": {"react": "^18"}}`,
+				"package-lock.json": `{"lockfileVersion": 1, "dependencies": {"react": {"version": "18.2.0"}, "express": {"version": "4.18.2"}}}`,
+			},
+			wantLanguages:  []string{"typescript"},
+			wantFrameworks: map[string]string{"react": "18.2.0"},
+		},
+		{
+			name: "yarn classic",
+			files: map[string]string{
+				"package.json": `{"dependencies": {"express": "^4.18.0"}}`,
+				"yarn.lock":    "# yarn lockfile v1\n\n\"express@^4.18.0\", express@^4:\n  version \"4.18.2\"\n  resolved \"x\"\n\n\"@types/node@^20\":\n  version \"20.1.0\"\n",
+			},
+			wantLanguages:  []string{"typescript"},
+			wantFrameworks: map[string]string{"express": "4.18.2"},
+		},
+		{
+			name: "yarn berry without package.json filter",
+			files: map[string]string{
+				"yarn.lock": "__metadata:\n  version: 6\n\n\"next@npm:^14.0.0\":\n  version: 14.0.4\n",
+			},
+			wantLanguages:  []string{"typescript"},
+			wantFrameworks: map[string]string{"nextjs": "14.0.4"},
+		},
+		{
+			name: "pnpm v6 importers",
+			files: map[string]string{
+				"pnpm-lock.yaml": "lockfileVersion: '6.0'\nimporters:\n  .:\n    dependencies:\n      next:\n        specifier: ^14.1.0\n        version: 14.1.0(react@18.2.0)\n",
+			},
+			wantLanguages:  []string{"typescript"},
+			wantFrameworks: map[string]string{"nextjs": "14.1.0"},
+		},
+		{
+			name: "pnpm v5 top level",
+			files: map[string]string{
+				"pnpm-lock.yaml": "lockfileVersion: 5.4\ndependencies:\n  express: 4.18.2\n",
+			},
+			wantLanguages:  []string{"typescript"},
+			wantFrameworks: map[string]string{"express": "4.18.2"},
+		},
+		{
+			name: "go.mod direct requires only",
+			files: map[string]string{
+				"go.mod": "module example.com/app\n\ngo 1.22\n\nrequire github.com/labstack/echo/v4 v4.11.4\n\nrequire (\n\tgithub.com/gin-gonic/gin v1.9.1 // indirect\n\tgithub.com/gofiber/fiber/v2 v2.52.0\n)\n",
+			},
+			wantLanguages:  []string{"go"},
+			wantFrameworks: map[string]string{"echo": "v4.11.4", "fiber": "v2.52.0"},
+		},
+		{
+			name: "poetry fastapi not flask",
+			files: map[string]string{
+				"pyproject.toml": "[tool.poetry]\n",
+				"poetry.lock":    "[[package]]\nname = \"FastAPI\"\nversion = \"0.110.0\"\n\n[[package]]\nname = \"starlette\"\nversion = \"0.36.3\"\n",
+			},
+			wantLanguages:  []string{"python"},
+			wantFrameworks: map[string]string{"fastapi": "0.110.0"},
+		},
+		{
+			name: "Gemfile.lock direct dependencies",
+			files: map[string]string{
+				"Gemfile.lock": "GEM\n  remote: https://rubygems.org/\n  specs:\n    rails (7.1.3)\n      railties (= 7.1.3)\n    sinatra (4.0.0)\n\nPLATFORMS\n  ruby\n\nDEPENDENCIES\n  rails (~> 7.1)\n\nBUNDLED WITH\n   2.5.3\n",
+			},
+			wantLanguages:  []string{"ruby"},
+			wantFrameworks: map[string]string{"rails": "7.1.3"},
+		},
+		{
+			name: "composer.lock",
+			files: map[string]string{
+				"composer.lock": `{"packages": [{"name": "laravel/framework", "version": "v10.43.0"}, {"name": "symfony/console", "version": "v6.4.3"}]}`,
+			},
+			wantLanguages:  []string{"php"},
+			wantFrameworks: map[string]string{"laravel": "10.43.0"},
+		},
+		{
+			name: "polyglot in registry order",
+			files: map[string]string{
+				"go.mod":           "module x\n\nrequire github.com/gin-gonic/gin v1.9.1\n",
+				"package.json":     `{}`,
+				"composer.lock":    `{invalid`,
+				"requirements.txt": "flask\n",
+			},
+			wantLanguages:  []string{"typescript", "python", "go"},
+			wantFrameworks: map[string]string{"gin": "v1.9.1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				writeTestFile(t, filepath.Join(dir, name), content)
+			}
+
+			d := DetectProject(dir)
+			if got := d.LanguageNames(); !slices.Equal(got, tt.wantLanguages) && len(got)+len(tt.wantLanguages) > 0 {
+				t.Errorf("languages = %v, want %v", got, tt.wantLanguages)
+			}
+			got := map[string]string{}
+			for _, f := range d.Frameworks {
+				got[f.Name] = f.Version
+				if f.Kind != ComponentFramework || f.Source == "" {
+					t.Errorf("framework %s: kind %q, source %q", f.Name, f.Kind, f.Source)
+				}
+			}
+			if len(got) != len(tt.wantFrameworks) {
+				t.Fatalf("frameworks = %v, want %v", got, tt.wantFrameworks)
+			}
+			for name, version := range tt.wantFrameworks {
+				if v, ok := got[name]; !ok || v != version {
+					t.Errorf("framework %s = %q (found %v), want %q", name, v, ok, version)
+				}
+			}
+		})
+	}
+}
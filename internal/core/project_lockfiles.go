@@ -0,0 +1,271 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lockDep is a dependency resolved in a lockfile.
+type lockDep struct {
+	name    string
+	version string
+}
+
+// lockfileParsers read the lockfiles DetectProject knows, in order of
+// preference when a project has several for the same ecosystem.
+var lockfileParsers = []struct {
+	file      string
+	ecosystem string
+	language  string
+	parse     func(path string) ([]lockDep, error)
+}{
+	{"package-lock.json", EcosystemNPM, "typescript", parsePackageLock},
+	{"pnpm-lock.yaml", EcosystemNPM, "typescript", parsePnpmLock},
+	{"yarn.lock", EcosystemNPM, "typescript", parseYarnLock},
+	{"go.mod", EcosystemGo, "go", parseGoModRequires},
+	{"poetry.lock", EcosystemPython, "python", parsePoetryLock},
+	{"Gemfile.lock", EcosystemRuby, "ruby", parseGemfileLock},
+	{"composer.lock", EcosystemComposer, "php", parseComposerLock},
+}
+
+// parsePackageLock returns the direct dependencies recorded in an npm
+// lockfile (version 2 or later), or the top-level ones of a version 1 file
+// filtered by package.json.
+func parsePackageLock(path string) ([]lockDep, error) {
+	var lock struct {
+		Packages map[string]struct {
+			Version         string            `json:"version"`
+			Dependencies    map[string]string `json:"dependencies"`
+			DevDependencies map[string]string `json:"devDependencies"`
+		} `json:"packages"`
+		Dependencies map[string]struct {
+			Version string `json:"version"`
+		} `json:"dependencies"`
+	}
+	if err := readJSONFile(path, &lock); err != nil {
+		return nil, err
+	}
+	var deps []lockDep
+	if root, ok := lock.Packages[""]; ok {
+		for _, direct := range []map[string]string{root.Dependencies, root.DevDependencies} {
+			for name := range direct {
+				deps = append(deps, lockDep{name, lock.Packages["node_modules/"+name].Version})
+			}
+		}
+		return deps, nil
+	}
+	for name, dep := range lock.Dependencies {
+		deps = append(deps, lockDep{name, dep.Version})
+	}
+	return filterPackageJSONDeps(filepath.Dir(path), deps), nil
+}
+
+// parsePnpmLock returns the direct dependencies of the root importer.
+func parsePnpmLock(path string) ([]lockDep, error) {
+	type depMaps struct {
+		Dependencies    map[string]yaml.Node `yaml:"dependencies"`
+		DevDependencies map[string]yaml.Node `yaml:"devDependencies"`
+	}
+	var lock struct {
+		depMaps   `yaml:",inline"`
+		Importers map[string]depMaps `yaml:"importers"`
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &lock); err != nil {
+		return nil, err
+	}
+	root := lock.depMaps // lockfile v5 lists them at the top level
+	if importer, ok := lock.Importers["."]; ok {
+		root = importer
+	}
+	var deps []lockDep
+	for _, direct := range []map[string]yaml.Node{root.Dependencies, root.DevDependencies} {
+		for name, node := range direct {
+			version := node.Value
+			if node.Kind == yaml.MappingNode {
+				var entry struct{ Version string }
+				_ = node.Decode(&entry)
+				version = entry.Version
+			}
+			// Drop the peer suffix of "14.1.0(react@18.2.0)".
+			version, _, _ = strings.Cut(version, "(")
+			deps = append(deps, lockDep{name, version})
+		}
+	}
+	return deps, nil
+}
+
+// parseYarnLock returns the packages resolved in a yarn.lock (classic or
+// berry), filtered by package.json when there is one.
+func parseYarnLock(path string) ([]lockDep, error) {
+	var deps []lockDep
+	var current []string
+	err := scanLines(path, func(line string) {
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case !strings.HasPrefix(line, " ") && strings.HasSuffix(line, ":"):
+			current = nil
+			for _, spec := range strings.Split(strings.TrimSuffix(line, ":"), ",") {
+				spec = strings.Trim(strings.TrimSpace(spec), `"`)
+				if at := strings.LastIndex(spec, "@"); at > 0 {
+					current = append(current, spec[:at])
+				}
+			}
+		case strings.HasPrefix(strings.TrimSpace(line), "version") && current != nil:
+			version := strings.Trim(strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "version")), `:" `)
+			for _, name := range current {
+				deps = append(deps, lockDep{name, version})
+			}
+			current = nil
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return filterPackageJSONDeps(filepath.Dir(path), deps), nil
+}
+
+// parseGoModRequires returns the direct requirements of a go.mod.
+func parseGoModRequires(path string) ([]lockDep, error) {
+	var deps []lockDep
+	inBlock := false
+	err := scanLines(path, func(line string) {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "require (":
+			inBlock = true
+			return
+		case inBlock && line == ")":
+			inBlock = false
+			return
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimPrefix(line, "require ")
+		case !inBlock:
+			return
+		}
+		if strings.Contains(line, "// indirect") {
+			return
+		}
+		if fields := strings.Fields(line); len(fields) >= 2 {
+			deps = append(deps, lockDep{fields[0], fields[1]})
+		}
+	})
+	return deps, err
+}
+
+// parsePoetryLock returns the packages of a poetry.lock.
+func parsePoetryLock(path string) ([]lockDep, error) {
+	var deps []lockDep
+	err := scanLines(path, func(line string) {
+		switch {
+		case line == "[[package]]":
+			deps = append(deps, lockDep{})
+		case len(deps) > 0 && strings.HasPrefix(line, "name = "):
+			name := strings.Trim(strings.TrimPrefix(line, "name = "), `"`)
+			deps[len(deps)-1].name = strings.ReplaceAll(strings.ToLower(name), "_", "-")
+		case len(deps) > 0 && strings.HasPrefix(line, "version = "):
+			deps[len(deps)-1].version = strings.Trim(strings.TrimPrefix(line, "version = "), `"`)
+		}
+	})
+	return deps, err
+}
+
+// parseGemfileLock returns the gems listed under DEPENDENCIES with the
+// versions resolved under specs.
+func parseGemfileLock(path string) ([]lockDep, error) {
+	versions := map[string]string{}
+	var direct []string
+	section := ""
+	err := scanLines(path, func(line string) {
+		switch {
+		case !strings.HasPrefix(line, " "):
+			section = line
+		case section == "DEPENDENCIES" && !strings.HasPrefix(line, "   "):
+			name, _, _ := strings.Cut(strings.TrimSpace(line), " ")
+			direct = append(direct, strings.TrimSuffix(name, "!"))
+		case strings.HasPrefix(line, "    ") && !strings.HasPrefix(line, "     "):
+			name, version, ok := strings.Cut(strings.TrimSpace(line), " (")
+			if ok {
+				versions[name] = strings.TrimSuffix(version, ")")
+			}
+		}
+	})
+	deps := make([]lockDep, len(direct))
+	for i, name := range direct {
+		deps[i] = lockDep{name, versions[name]}
+	}
+	return deps, err
+}
+
+// parseComposerLock returns the packages of a composer.lock.
+func parseComposerLock(path string) ([]lockDep, error) {
+	type pkg struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+	var lock struct {
+		Packages    []pkg `json:"packages"`
+		PackagesDev []pkg `json:"packages-dev"`
+	}
+	if err := readJSONFile(path, &lock); err != nil {
+		return nil, err
+	}
+	var deps []lockDep
+	for _, p := range append(lock.Packages, lock.PackagesDev...) {
+		deps = append(deps, lockDep{p.Name, strings.TrimPrefix(p.Version, "v")})
+	}
+	return deps, nil
+}
+
+// filterPackageJSONDeps keeps the deps package.json in dir declares. Without
+// a readable package.json, all deps are kept.
+func filterPackageJSONDeps(dir string, deps []lockDep) []lockDep {
+	var manifest struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}
+	if err := readJSONFile(filepath.Join(dir, "package.json"), &manifest); err != nil {
+		return deps
+	}
+	var kept []lockDep
+	for _, dep := range deps {
+		_, prod := manifest.Dependencies[dep.name]
+		_, dev := manifest.DevDependencies[dep.name]
+		if prod || dev {
+			kept = append(kept, dep)
+		}
+	}
+	return kept
+}
+
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// scanLines calls fn with every line of the file at path, without line
+// endings.
+func scanLines(path string, fn func(line string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fn(strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	return scanner.Err()
+}
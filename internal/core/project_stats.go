@@ -0,0 +1,242 @@
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Project stats limits keep the generated file small enough to read in
+// every iteration without crowding the agent's context.
+const (
+	DefaultStatsRefreshInterval = 10
+	maxStatsFileSize            = 1 << 20
+	maxStatsKeyDirs             = 8
+	maxStatsEntryPoints         = 10
+	maxStatsTestDirs            = 10
+)
+
+// statsLanguages maps source file extensions to language names.
+var statsLanguages = map[string]string{
+	".go": "Go", ".py": "Python", ".ts": "TypeScript", ".tsx": "TypeScript",
+	".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript", ".cjs": "JavaScript",
+	".rs": "Rust", ".java": "Java", ".kt": "Kotlin", ".kts": "Kotlin",
+	".cs": "C#", ".php": "PHP", ".swift": "Swift", ".c": "C", ".h": "C",
+	".cpp": "C++", ".cc": "C++", ".hpp": "C++", ".rb": "Ruby", ".sql": "SQL",
+	".sh": "Shell", ".bash": "Shell", ".r": "R", ".dart": "Dart",
+	".ex": "Elixir", ".exs": "Elixir", ".scala": "Scala", ".vue": "Vue",
+	".svelte": "Svelte", ".lua": "Lua", ".zig": "Zig",
+}
+
+// statsEntryPoints are file names that conventionally start a program.
+var statsEntryPoints = map[string]bool{
+	"main.go": true, "main.py": true, "__main__.py": true, "manage.py": true,
+	"app.py": true, "main.rs": true, "lib.rs": true, "index.ts": true,
+	"index.js": true, "main.ts": true, "server.ts": true, "server.js": true,
+	"Program.cs": true, "main.c": true, "main.cpp": true, "Main.java": true,
+}
+
+// LanguageStat is the size of one language in the project.
+type LanguageStat struct {
+	Name  string
+	Files int
+	Lines int
+}
+
+// DirStat is the amount of source code under a top-level directory.
+type DirStat struct {
+	Path  string
+	Files int
+	Lines int
+}
+
+// ProjectStats is a snapshot of the project layout given to the agent so
+// it does not have to re-explore the tree every iteration.
+type ProjectStats struct {
+	GeneratedAt time.Time
+	Languages   []LanguageStat
+	KeyDirs     []DirStat
+	EntryPoints []string
+	TestDirs    []DirStat
+}
+
+// CollectProjectStats walks projectDir and summarizes its source code.
+func CollectProjectStats(projectDir string) (*ProjectStats, error) {
+	c := &statsCollector{
+		root:   projectDir,
+		ignore: LoadGitIgnore(projectDir),
+		langs:  make(map[string]*DirStat),
+		dirs:   make(map[string]*DirStat),
+		tests:  make(map[string]*DirStat),
+	}
+	if err := filepath.WalkDir(projectDir, c.visit); err != nil {
+		return nil, fmt.Errorf("failed to scan project: %w", err)
+	}
+
+	return &ProjectStats{
+		GeneratedAt: time.Now().UTC(),
+		Languages:   sortedLanguageStats(c.langs),
+		KeyDirs:     topDirStats(c.dirs, maxStatsKeyDirs),
+		EntryPoints: topEntryPoints(c.entries),
+		TestDirs:    topDirStats(c.tests, maxStatsTestDirs),
+	}, nil
+}
+
+// statsCollector accumulates per-file counts during the project walk.
+type statsCollector struct {
+	root    string
+	ignore  *GitIgnore
+	langs   map[string]*DirStat
+	dirs    map[string]*DirStat
+	tests   map[string]*DirStat
+	entries []string
+}
+
+// visit is the WalkDir callback. Unreadable paths are ignored so one bad
+// permission does not prevent the summary.
+func (c *statsCollector) visit(path string, d fs.DirEntry, err error) error {
+	if err != nil || path == c.root {
+		return nil
+	}
+	rel, _ := filepath.Rel(c.root, path)
+	rel = filepath.ToSlash(rel)
+	if d.IsDir() {
+		if skipStatsDir(d.Name()) || c.ignore.IgnoredDir(rel) {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+
+	lang, ok := statsLanguages[strings.ToLower(filepath.Ext(path))]
+	if !ok || c.ignore.Ignored(rel) {
+		return nil
+	}
+	lines, ok := countSourceLines(path)
+	if !ok {
+		return nil
+	}
+
+	addStat(c.langs, lang, lines)
+	if top, _, found := strings.Cut(rel, "/"); found {
+		addStat(c.dirs, top, lines)
+	}
+	if isTestSourceFile(rel) {
+		addStat(c.tests, filepath.ToSlash(filepath.Dir(rel)), 0)
+	}
+	if statsEntryPoints[d.Name()] {
+		c.entries = append(c.entries, rel)
+	}
+	return nil
+}
+
+// skipStatsDir skips hidden directories and the built-in dependency,
+// build, and cache directories, which say nothing about the project's own
+// code.
+func skipStatsDir(name string) bool {
+	return strings.HasPrefix(name, ".") || IsDefaultSkipDir(name)
+}
+
+// countSourceLines returns the line count of a file, skipping files too
+// large to be hand-written source.
+func countSourceLines(path string) (int, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.Size() > maxStatsFileSize {
+		return 0, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, false
+	}
+	lines := bytes.Count(data, []byte("\n"))
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		lines++
+	}
+	return lines, true
+}
+
+func addStat(stats map[string]*DirStat, key string, lines int) {
+	s, ok := stats[key]
+	if !ok {
+		s = &DirStat{Path: key}
+		stats[key] = s
+	}
+	s.Files++
+	s.Lines += lines
+}
+
+// isTestSourceFile recognizes test files by the common naming conventions
+// of the supported languages or by living under a test directory.
+func isTestSourceFile(rel string) bool {
+	base := filepath.Base(rel)
+	stem := strings.TrimSuffix(base, filepath.Ext(base))
+	switch {
+	case strings.HasSuffix(stem, "_test"), strings.HasPrefix(stem, "test_"),
+		strings.HasSuffix(stem, ".test"), strings.HasSuffix(stem, ".spec"),
+		strings.HasSuffix(stem, "Test"), strings.HasSuffix(stem, "Tests"):
+		return true
+	}
+	for _, part := range strings.Split(filepath.Dir(rel), "/") {
+		if part == "test" || part == "tests" || part == "__tests__" || part == "spec" {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedLanguageStats(langs map[string]*DirStat) []LanguageStat {
+	result := make([]LanguageStat, 0, len(langs))
+	for name, s := range langs {
+		result = append(result, LanguageStat{Name: name, Files: s.Files, Lines: s.Lines})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Lines != result[j].Lines {
+			return result[i].Lines > result[j].Lines
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// topDirStats orders directories by lines, then files, then path, and
+// keeps at most limit entries.
+func topDirStats(dirs map[string]*DirStat, limit int) []DirStat {
+	result := make([]DirStat, 0, len(dirs))
+	for _, s := range dirs {
+		result = append(result, *s)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Lines != b.Lines {
+			return a.Lines > b.Lines
+		}
+		if a.Files != b.Files {
+			return a.Files > b.Files
+		}
+		return a.Path < b.Path
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// topEntryPoints prefers shallow paths, which are usually the real entry
+// points rather than examples or fixtures.
+func topEntryPoints(entries []string) []string {
+	sort.Slice(entries, func(i, j int) bool {
+		di, dj := strings.Count(entries[i], "/"), strings.Count(entries[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return entries[i] < entries[j]
+	})
+	if len(entries) > maxStatsEntryPoints {
+		entries = entries[:maxStatsEntryPoints]
+	}
+	return entries
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GetProjectStatsPath returns the path of the generated project stats file.
+func GetProjectStatsPath(projectDir string) string {
+	return filepath.Join(projectDir, AutoDir, AutoContextDir, AutoProjectStatsFile)
+}
+
+// statsGeneratedPrefix starts the timestamp line of the stats file.
+const statsGeneratedPrefix = "_Generated by samuel at "
+
+// FormatProjectStats renders project stats as a compact markdown summary.
+func FormatProjectStats(stats *ProjectStats) string {
+	var sb strings.Builder
+	sb.WriteString("# Project Stats\n\n")
+	fmt.Fprintf(&sb, "%s%s. Regenerated during auto runs; do not edit._\n",
+		statsGeneratedPrefix, stats.GeneratedAt.Format(time.RFC3339))
+
+	sb.WriteString("\n## Languages\n\n")
+	if len(stats.Languages) == 0 {
+		sb.WriteString("No source files detected.\n")
+	} else {
+		sb.WriteString("| Language | Files | Lines |\n")
+		sb.WriteString("|----------|-------|-------|\n")
+		for _, l := range stats.Languages {
+			fmt.Fprintf(&sb, "| %s | %d | %d |\n", l.Name, l.Files, l.Lines)
+		}
+	}
+
+	writeStatsList(&sb, "Key Directories", stats.KeyDirs, func(d DirStat) string {
+		return fmt.Sprintf("`%s/` (%d files, %d lines)", d.Path, d.Files, d.Lines)
+	})
+	writeStatsList(&sb, "Entry Points", stats.EntryPoints, func(p string) string {
+		return "`" + p + "`"
+	})
+	writeStatsList(&sb, "Test Locations", stats.TestDirs, func(d DirStat) string {
+		return fmt.Sprintf("`%s` (%d test files)", d.Path, d.Files)
+	})
+	return sb.String()
+}
+
+func writeStatsList[T any](sb *strings.Builder, heading string, items []T, format func(T) string) {
+	if len(items) == 0 {
+		return
+	}
+	fmt.Fprintf(sb, "\n## %s\n\n", heading)
+	for _, item := range items {
+		sb.WriteString("- " + format(item) + "\n")
+	}
+}
+
+// WriteProjectStats scans projectDir and writes the stats file into the
+// auto context directory, returning the written path.
+func WriteProjectStats(projectDir string) (string, error) {
+	stats, err := CollectProjectStats(projectDir)
+	if err != nil {
+		return "", err
+	}
+
+	path := GetProjectStatsPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create context directory: %w", err)
+	}
+	content := []byte(FormatProjectStats(stats))
+	if unchangedExcept(path, content, statsGeneratedPrefix) {
+		return path, nil
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write project stats: %w", err)
+	}
+	return path, nil
+}
+
+// ShouldRefreshProjectStats reports whether the stats file is due for
+// regeneration before iteration iter. The first iteration uses the file
+// written at init. A negative interval disables refreshing; zero uses
+// DefaultStatsRefreshInterval.
+func ShouldRefreshProjectStats(iter, interval int) bool {
+	if interval < 0 || iter <= 1 {
+		return false
+	}
+	if interval == 0 {
+		interval = DefaultStatsRefreshInterval
+	}
+	return (iter-1)%interval == 0
+}
+
+// RefreshProjectStats regenerates the stats file before iteration iter when
+// it is due, or when it is missing because the loop was initialized before
+// stats existed. A negative interval disables it.
+func RefreshProjectStats(projectDir string, iter, interval int) error {
+	if interval < 0 {
+		return nil
+	}
+	_, statErr := os.Stat(GetProjectStatsPath(projectDir))
+	if !os.IsNotExist(statErr) && !ShouldRefreshProjectStats(iter, interval) {
+		return nil
+	}
+	_, err := WriteProjectStats(projectDir)
+	return err
+}
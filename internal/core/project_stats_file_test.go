@@ -0,0 +1,89 @@
+package core
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormatProjectStats(t *testing.T) {
+	stats := &ProjectStats{
+		GeneratedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Languages:   []LanguageStat{{Name: "Go", Files: 10, Lines: 1200}},
+		KeyDirs:     []DirStat{{Path: "internal", Files: 8, Lines: 1000}},
+		EntryPoints: []string{"cmd/samuel/main.go"},
+		TestDirs:    []DirStat{{Path: "internal/core", Files: 4}},
+	}
+
+	out := FormatProjectStats(stats)
+	for _, want := range []string{
+		"# Project Stats",
+		"2026-03-01T12:00:00Z",
+		"| Go | 10 | 1200 |",
+		"- `internal/` (8 files, 1000 lines)",
+		"- `cmd/samuel/main.go`",
+		"- `internal/core` (4 test files)",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatProjectStats_Empty(t *testing.T) {
+	out := FormatProjectStats(&ProjectStats{})
+	if !strings.Contains(out, "No source files detected.") {
+		t.Error("expected placeholder for empty project")
+	}
+	if strings.Contains(out, "## Entry Points") {
+		t.Error("empty sections should be omitted")
+	}
+}
+
+func TestShouldRefreshProjectStats(t *testing.T) {
+	tests := []struct {
+		name     string
+		iter     int
+		interval int
+		want     bool
+	}{
+		{"first iteration uses init stats", 1, 5, false},
+		{"due", 6, 5, true},
+		{"not due", 7, 5, false},
+		{"default interval", DefaultStatsRefreshInterval + 1, 0, true},
+		{"disabled", 6, -1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ShouldRefreshProjectStats(tt.iter, tt.interval); got != tt.want {
+				t.Errorf("ShouldRefreshProjectStats(%d, %d) = %v, want %v",
+					tt.iter, tt.interval, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshProjectStats(t *testing.T) {
+	dir := writeStatsTree(t, map[string]string{"main.go": "package main\n"})
+	path := GetProjectStatsPath(dir)
+
+	if err := RefreshProjectStats(dir, 2, -1); err != nil {
+		t.Fatalf("RefreshProjectStats returned error: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("disabled refresh should not write the stats file")
+	}
+
+	if err := RefreshProjectStats(dir, 2, 5); err != nil {
+		t.Fatalf("RefreshProjectStats returned error: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("missing stats file should be created: %v", err)
+	}
+	if !strings.Contains(string(content), "| Go | 1 | 1 |") {
+		t.Errorf("unexpected stats content:\n%s", content)
+	}
+}
@@ -0,0 +1,114 @@
+package core
+
+import "testing"
+
+// writeStatsTree creates files (path -> content) under a temp project dir.
+func writeStatsTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeTestFiles(t, dir, files)
+	return dir
+}
+
+func TestCollectProjectStats(t *testing.T) {
+	dir := writeStatsTree(t, map[string]string{
+		"cmd/app/main.go":               "package main\n\nfunc main() {}\n",
+		"internal/core/core.go":         "package core\n\nvar x = 1\nvar y = 2\n",
+		"internal/core/core_test.go":    "package core\n",
+		"web/index.ts":                  "export {}",
+		"web/__tests__/app.ts":          "test()\n",
+		"node_modules/dep/index.js":     "ignored\n",
+		".claude/auto/prd.json":         "{}\n",
+		"README.md":                     "# not source\n",
+		"scripts/build.sh":              "#!/bin/sh\necho hi\n",
+		"vendor/github.com/x/y/lib.go":  "package y\n",
+		".hidden/secret.go":             "package hidden\n",
+		"internal/core/testdata/in.txt": "data\n",
+	})
+
+	stats, err := CollectProjectStats(dir)
+	if err != nil {
+		t.Fatalf("CollectProjectStats returned error: %v", err)
+	}
+
+	if len(stats.Languages) != 3 || stats.Languages[0].Name != "Go" {
+		t.Fatalf("Languages = %+v, want Go first of 3", stats.Languages)
+	}
+	if got := stats.Languages[0]; got.Files != 3 || got.Lines != 8 {
+		t.Errorf("Go stats = %+v, want 3 files, 8 lines", got)
+	}
+	if stats.KeyDirs[0].Path != "internal" {
+		t.Errorf("KeyDirs[0] = %+v, want internal", stats.KeyDirs[0])
+	}
+	if len(stats.EntryPoints) != 2 || stats.EntryPoints[0] != "web/index.ts" {
+		t.Errorf("EntryPoints = %v, want shallow web/index.ts first", stats.EntryPoints)
+	}
+	if len(stats.TestDirs) != 2 {
+		t.Errorf("TestDirs = %+v, want internal/core and web/__tests__", stats.TestDirs)
+	}
+}
+
+func TestCollectProjectStats_Empty(t *testing.T) {
+	stats, err := CollectProjectStats(t.TempDir())
+	if err != nil {
+		t.Fatalf("CollectProjectStats returned error: %v", err)
+	}
+	if len(stats.Languages) != 0 || len(stats.KeyDirs) != 0 || len(stats.EntryPoints) != 0 {
+		t.Errorf("expected empty stats, got %+v", stats)
+	}
+}
+
+func TestCollectProjectStats_GitIgnore(t *testing.T) {
+	dir := writeStatsTree(t, map[string]string{
+		".gitignore":          "generated/\n*.pb.go\n",
+		"main.go":             "package main\n",
+		"api/api.pb.go":       "package api\n",
+		"generated/models.go": "package generated\n",
+		"dist/bundle.js":      "bundle()\n",
+	})
+
+	stats, err := CollectProjectStats(dir)
+	if err != nil {
+		t.Fatalf("CollectProjectStats returned error: %v", err)
+	}
+	if len(stats.Languages) != 1 || stats.Languages[0].Files != 1 {
+		t.Errorf("Languages = %+v, want only main.go counted", stats.Languages)
+	}
+}
+
+func TestIsTestSourceFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"internal/core/auto_test.go", true},
+		{"pkg/test_models.py", true},
+		{"src/app.spec.ts", true},
+		{"src/button.test.jsx", true},
+		{"src/main/java/UserServiceTest.java", true},
+		{"tests/helpers.py", true},
+		{"internal/core/auto.go", false},
+		{"src/testing.ts", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := isTestSourceFile(tt.path); got != tt.want {
+				t.Errorf("isTestSourceFile(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTopDirStats_Limit(t *testing.T) {
+	dirs := map[string]*DirStat{
+		"a": {Path: "a", Files: 1, Lines: 10},
+		"b": {Path: "b", Files: 2, Lines: 30},
+		"c": {Path: "c", Files: 1, Lines: 20},
+	}
+
+	got := topDirStats(dirs, 2)
+	if len(got) != 2 || got[0].Path != "b" || got[1].Path != "c" {
+		t.Errorf("topDirStats = %+v, want [b c]", got)
+	}
+}
@@ -0,0 +1,304 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// QA steps, in the order they run against each initialized fixture.
+const (
+	QAStepInit   = "init"
+	QAStepDoctor = "doctor"
+	QAStepSkills = "skills"
+	QAStepAuto   = "auto"
+)
+
+// GetQASteps returns the QA steps in the order they run.
+func GetQASteps() []string {
+	return []string{QAStepInit, QAStepDoctor, QAStepSkills, QAStepAuto}
+}
+
+// Outcomes of a QA step. A failed step skips the steps after it, since
+// they would only fail on its leftovers.
+const (
+	QAPass = "pass"
+	QAFail = "fail"
+	QASkip = "skip"
+)
+
+// DefaultQAStepTimeout bounds each command of a QA step; init may have to
+// download the template.
+const DefaultQAStepTimeout = 2 * time.Minute
+
+// qaOutputLines is how much of a failed command's output is kept.
+const qaOutputLines = 20
+
+// QAConfig configures a template QA run: every fixture project is
+// initialized with every template and checked.
+type QAConfig struct {
+	// Binary is the samuel executable the steps run, so each command gets
+	// a fresh process, exactly as a user would run it.
+	Binary    string
+	Projects  []string
+	Templates []string
+	// StepTimeout bounds each command; DefaultQAStepTimeout when zero.
+	StepTimeout time.Duration
+	// Keep leaves each case's project directory in place for inspection.
+	Keep bool
+	// Parallel is how many cases run at once; one when zero.
+	Parallel int
+	// StepProgress, when set, is called as each step of a case finishes,
+	// and Progress as each case finishes. With Parallel above one they are
+	// called from several goroutines at once.
+	StepProgress func(project, template string, step QAStepResult)
+	Progress     func(QACaseResult)
+}
+
+// QAStepResult is the outcome of one step of a case. Output holds the
+// tail of the output of the command that failed.
+type QAStepResult struct {
+	Step     string        `json:"step"`
+	Status   string        `json:"status"`
+	Duration time.Duration `json:"duration_ns"`
+	Output   string        `json:"output,omitempty"`
+}
+
+// QACaseResult is the outcome of one fixture initialized with one
+// template. Error is set when the fixture could not be set up at all.
+type QACaseResult struct {
+	Project  string         `json:"project"`
+	Template string         `json:"template"`
+	Steps    []QAStepResult `json:"steps,omitempty"`
+	Dir      string         `json:"dir,omitempty"`
+	Error    string         `json:"error,omitempty"`
+}
+
+// Passed reports whether every step of the case passed.
+func (c QACaseResult) Passed() bool {
+	if c.Error != "" || len(c.Steps) == 0 {
+		return false
+	}
+	for _, s := range c.Steps {
+		if s.Status != QAPass {
+			return false
+		}
+	}
+	return true
+}
+
+// QAReport is the pass/fail matrix of a QA run.
+type QAReport struct {
+	Version   string         `json:"version,omitempty"`
+	Projects  []string       `json:"projects"`
+	Templates []string       `json:"templates"`
+	Cases     []QACaseResult `json:"cases"`
+}
+
+// Case returns the result of project with template, or nil.
+func (r *QAReport) Case(project, template string) *QACaseResult {
+	for i := range r.Cases {
+		if r.Cases[i].Project == project && r.Cases[i].Template == template {
+			return &r.Cases[i]
+		}
+	}
+	return nil
+}
+
+// Failed returns the number of cases that did not pass.
+func (r *QAReport) Failed() int {
+	failed := 0
+	for _, c := range r.Cases {
+		if !c.Passed() {
+			failed++
+		}
+	}
+	return failed
+}
+
+// DiscoverQAProjects lists the fixture projects in dir: every
+// subdirectory not starting with a dot, sorted by name.
+func DiscoverQAProjects(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read projects directory: %w", err)
+	}
+	var projects []string
+	for _, e := range entries {
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
+			projects = append(projects, filepath.Join(dir, e.Name()))
+		}
+	}
+	if len(projects) == 0 {
+		return nil, fmt.Errorf("no fixture projects in %s (each subdirectory is one project)", dir)
+	}
+	return projects, nil
+}
+
+// Validate checks the QA config.
+func (c QAConfig) Validate() error {
+	if c.Binary == "" {
+		return fmt.Errorf("no samuel binary to run")
+	}
+	if len(c.Projects) == 0 {
+		return fmt.Errorf("no fixture projects")
+	}
+	if len(c.Templates) == 0 {
+		return fmt.Errorf("no templates")
+	}
+	if c.Parallel < 0 {
+		return fmt.Errorf("parallel must not be negative, got %d", c.Parallel)
+	}
+	for _, name := range c.Templates {
+		if FindTemplate(name) == nil {
+			return fmt.Errorf("unknown template: %s (supported: %v)", name, GetAllTemplateNames())
+		}
+	}
+	return nil
+}
+
+// qaExec runs one samuel command; replaced in tests.
+var qaExec = runQACommand
+
+// RunQA initializes a copy of every fixture project with every template,
+// up to cfg.Parallel cases at a time, and runs the QA steps against it.
+// Cases are reported in project then template order whatever order they
+// finish in. A failing case is recorded in the report; only an invalid
+// config returns an error.
+func RunQA(cfg QAConfig) (*QAReport, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if cfg.StepTimeout <= 0 {
+		cfg.StepTimeout = DefaultQAStepTimeout
+	}
+	report := &QAReport{Templates: cfg.Templates}
+	for _, project := range cfg.Projects {
+		name := filepath.Base(project)
+		if slices.Contains(report.Projects, name) {
+			return nil, fmt.Errorf("duplicate fixture project name: %s", name)
+		}
+		report.Projects = append(report.Projects, name)
+	}
+
+	type qaCase struct{ project, tmpl string }
+	var cases []qaCase
+	for _, project := range cfg.Projects {
+		for _, tmpl := range cfg.Templates {
+			cases = append(cases, qaCase{project, tmpl})
+		}
+	}
+	report.Cases = make([]QACaseResult, len(cases))
+	forEachBounded(cfg.Parallel, len(cases), func(i int) {
+		result := runQACase(cfg, cases[i].project, cases[i].tmpl)
+		report.Cases[i] = result
+		if cfg.Progress != nil {
+			cfg.Progress(result)
+		}
+	})
+	return report, nil
+}
+
+// runQACase copies project to a scratch directory and runs the steps
+// against it with tmpl.
+func runQACase(cfg QAConfig, project, tmpl string) QACaseResult {
+	result := QACaseResult{Project: filepath.Base(project), Template: tmpl}
+	root, err := os.MkdirTemp("", "samuel-qa-*")
+	if err != nil {
+		result.Error = fmt.Sprintf("failed to create scratch directory: %v", err)
+		return result
+	}
+	dir := filepath.Join(root, result.Project)
+	if cfg.Keep {
+		result.Dir = dir
+	} else {
+		defer os.RemoveAll(root)
+	}
+	if err := copyDir(project, dir); err != nil {
+		result.Error = fmt.Sprintf("failed to copy fixture: %v", err)
+		return result
+	}
+
+	failed := false
+	for _, step := range qaSteps(tmpl, dir) {
+		stepResult := QAStepResult{Step: step.name, Status: QASkip}
+		if !failed {
+			stepResult = runQAStep(cfg, step)
+			failed = stepResult.Status == QAFail
+		}
+		result.Steps = append(result.Steps, stepResult)
+		if cfg.StepProgress != nil {
+			cfg.StepProgress(result.Project, tmpl, stepResult)
+		}
+	}
+	return result
+}
+
+// qaStep is a QA step and the samuel commands it runs.
+type qaStep struct {
+	name     string
+	commands [][]string
+}
+
+// qaSteps returns the steps for a fixture copied to dir. The minimal
+// template installs from the copy built into the CLI when offline.
+func qaSteps(tmpl, dir string) []qaStep {
+	initArgs := []string{"init", dir, "--non-interactive", "--yes", "--template", tmpl}
+	if tmpl == "minimal" {
+		initArgs = []string{"init", dir, "--non-interactive", "--yes", "--minimal"}
+	}
+	return []qaStep{
+		{name: QAStepInit, commands: [][]string{initArgs}},
+		{name: QAStepDoctor, commands: [][]string{{"doctor", "--target", dir}}},
+		{name: QAStepSkills, commands: [][]string{{"skill", "validate", "--target", dir}}},
+		{name: QAStepAuto, commands: [][]string{
+			{"auto", "init", "--skip-git-check", "--yes", "--target", dir},
+			{"auto", "start", "--dry-run", "--yes", "--target", dir},
+		}},
+	}
+}
+
+// runQAStep runs the commands of step until one fails.
+func runQAStep(cfg QAConfig, step qaStep) QAStepResult {
+	result := QAStepResult{Step: step.name, Status: QAPass}
+	start := time.Now()
+	for _, args := range step.commands {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.StepTimeout)
+		out, err := qaExec(ctx, cfg.Binary, args)
+		if ctx.Err() == context.DeadlineExceeded {
+			err = fmt.Errorf("timed out after %s", cfg.StepTimeout)
+		}
+		cancel()
+		if err != nil {
+			result.Status = QAFail
+			result.Output = fmt.Sprintf("samuel %s: %v\n%s", strings.Join(args, " "), err, lastLines(out, qaOutputLines))
+			break
+		}
+	}
+	result.Duration = time.Since(start)
+	return result
+}
+
+// runQACommand runs the samuel binary with args and returns its combined
+// output. Color and the update check are turned off so the output reads
+// cleanly in a report.
+func runQACommand(ctx context.Context, binary string, args []string) (string, error) {
+	cmd := exec.CommandContext(ctx, binary, args...)
+	cmd.Env = append(os.Environ(), "NO_COLOR=1", "SAMUEL_NO_UPDATE_CHECK=1")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+// lastLines returns the last n lines of s.
+func lastLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiscoverQAProjects(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "web", "package.json"), "{}")
+	writeTestFile(t, filepath.Join(dir, "api", "go.mod"), "module api\n")
+	writeTestFile(t, filepath.Join(dir, ".git", "HEAD"), "ref: refs/heads/main\n")
+	writeTestFile(t, filepath.Join(dir, "README.md"), "# Examples\n")
+
+	projects, err := DiscoverQAProjects(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{filepath.Join(dir, "api"), filepath.Join(dir, "web")}
+	if strings.Join(projects, ",") != strings.Join(want, ",") {
+		t.Errorf("projects = %v, want %v", projects, want)
+	}
+	if _, err := DiscoverQAProjects(filepath.Join(dir, "api")); err == nil {
+		t.Error("expected an error for a directory without projects")
+	}
+}
+
+func TestQAConfig_Validate(t *testing.T) {
+	cfg := QAConfig{Binary: "samuel", Projects: []string{"examples/api"}, Templates: []string{"minimal"}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v", err)
+	}
+	cfg.Templates = []string{"minimal", "tiny"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "tiny") {
+		t.Errorf("Validate() with an unknown template = %v", err)
+	}
+}
+
+func TestRunQA(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, "api", "go.mod"), "module api\n")
+	writeTestFile(t, filepath.Join(dir, "web", "package.json"), "{}")
+
+	var commands []string
+	orig := qaExec
+	qaExec = func(ctx context.Context, binary string, args []string) (string, error) {
+		if args[0] != "init" {
+			commands = append(commands, strings.Join(args[:2], " "))
+			return "ok\n", nil
+		}
+		commands = append(commands, "init")
+		if _, err := os.Stat(filepath.Join(args[1], "go.mod")); err == nil && args[len(args)-1] == "starter" {
+			return "line 1\nline 2\n", fmt.Errorf("exit status 1")
+		}
+		return "ok\n", nil
+	}
+	t.Cleanup(func() { qaExec = orig })
+
+	report, err := RunQA(QAConfig{
+		Binary:    "samuel",
+		Projects:  []string{filepath.Join(dir, "api"), filepath.Join(dir, "web")},
+		Templates: []string{"minimal", "starter"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Cases) != 4 || report.Failed() != 1 {
+		t.Fatalf("cases = %d, failed = %d", len(report.Cases), report.Failed())
+	}
+	failed := report.Case("api", "starter")
+	if failed.Passed() || failed.Steps[0].Status != QAFail || failed.Steps[1].Status != QASkip {
+		t.Errorf("api/starter = %+v", failed)
+	}
+	if !strings.Contains(failed.Steps[0].Output, "exit status 1\nline 1\nline 2") {
+		t.Errorf("output = %q", failed.Steps[0].Output)
+	}
+	if !report.Case("web", "starter").Passed() || !report.Case("api", "minimal").Passed() {
+		t.Error("the other cases should pass")
+	}
+	if got := strings.Join(commands[:5], ", "); got != "init, doctor --target, skill validate, auto init, auto start" {
+		t.Errorf("commands = %s", got)
+	}
+
+	if _, err := RunQA(QAConfig{Binary: "samuel", Projects: []string{"a/api", "b/api"}, Templates: []string{"minimal"}}); err == nil {
+		t.Error("expected an error for projects with the same name")
+	}
+}
+
+func TestRunQA_Parallel(t *testing.T) {
+	dir := t.TempDir()
+	var projects []string
+	for _, name := range []string{"a", "b", "c"} {
+		writeTestFile(t, filepath.Join(dir, name, "README.md"), name)
+		projects = append(projects, filepath.Join(dir, name))
+	}
+
+	var mu sync.Mutex
+	running, peak := 0, 0
+	orig := qaExec
+	qaExec = func(ctx context.Context, binary string, args []string) (string, error) {
+		mu.Lock()
+		running++
+		peak = max(peak, running)
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return "ok\n", nil
+	}
+	t.Cleanup(func() { qaExec = orig })
+
+	var steps atomic.Int32
+	report, err := RunQA(QAConfig{
+		Binary:       "samuel",
+		Projects:     projects,
+		Templates:    []string{"minimal", "starter"},
+		Parallel:     2,
+		StepProgress: func(string, string, QAStepResult) { steps.Add(1) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if peak != 2 {
+		t.Errorf("peak concurrency = %d, want 2", peak)
+	}
+	if got := int(steps.Load()); got != 6*len(GetQASteps()) {
+		t.Errorf("step callbacks = %d, want %d", got, 6*len(GetQASteps()))
+	}
+	for i, c := range report.Cases {
+		if want := []string{"a", "a", "b", "b", "c", "c"}[i]; c.Project != want || !c.Passed() {
+			t.Errorf("case %d = %s/%s passed=%v, want project %s in order", i, c.Project, c.Template, c.Passed(), want)
+		}
+	}
+
+	if _, err := RunQA(QAConfig{Binary: "samuel", Projects: projects, Templates: []string{"minimal"}, Parallel: -1}); err == nil {
+		t.Error("expected an error for a negative parallel")
+	}
+}
@@ -386,6 +386,15 @@ func GetAllSkillNames() []string {
 	return getAllNames(Skills)
 }
 
+// GetAllTemplateNames returns all template names
+func GetAllTemplateNames() []string {
+	names := make([]string, len(Templates))
+	for i, t := range Templates {
+		names[i] = t.Name
+	}
+	return names
+}
+
 // GetLanguageSkills returns skills with category "language"
 func GetLanguageSkills() []Component {
 	var result []Component
@@ -0,0 +1,131 @@
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// Output formats for 'samuel registry dump'.
+const (
+	DumpFormatJSON = "json"
+	DumpFormatYAML = "yaml"
+)
+
+// GetSupportedDumpFormats returns the formats accepted by 'samuel registry dump'.
+func GetSupportedDumpFormats() []string {
+	return []string{DumpFormatJSON, DumpFormatYAML}
+}
+
+// Entry types that have no ComponentType of their own.
+const (
+	registryEntryCore = "core"
+	registryEntryFile = "file"
+)
+
+// RegistryEntry is one component of the effective registry and where its
+// files resolve from.
+type RegistryEntry struct {
+	Type        string   `json:"type" yaml:"type"`
+	Name        string   `json:"name" yaml:"name"`
+	Path        string   `json:"path" yaml:"path"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Category    string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Tags        []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// Source is the layer that defines the entry: LayerBase for the
+	// built-in registry, LayerOverlay for entries only the overlay has.
+	Source string `json:"source" yaml:"source"`
+	// Registry and Ref identify the repository and version or branch the
+	// entry is fetched from.
+	Registry string `json:"registry" yaml:"registry"`
+	Ref      string `json:"ref" yaml:"ref"`
+	// OverlayFiles lists files under Path that the overlay supplies.
+	OverlayFiles []string `json:"overlay_files,omitempty" yaml:"overlay_files,omitempty"`
+}
+
+// RegistryDump is the effective registry of a project.
+type RegistryDump struct {
+	Registry   string          `json:"registry" yaml:"registry"`
+	Version    string          `json:"version" yaml:"version"`
+	Overlay    *OverlayConfig  `json:"overlay,omitempty" yaml:"overlay,omitempty"`
+	Components []RegistryEntry `json:"components" yaml:"components"`
+}
+
+// DumpRegistry merges the built-in registry with the files the overlay in
+// tmpl supplies. Overlay files under a built-in component are attached to
+// it; overlay-only skill directories become skill entries and any other
+// overlay-only file becomes a file entry. tmpl may be nil when no overlay
+// is configured. An empty version resolves to the latest release.
+func DumpRegistry(registry, version string, overlay *OverlayConfig, tmpl *LayeredTemplate) *RegistryDump {
+	if registry == "" {
+		registry = DefaultRegistry
+	}
+	ref := version
+	if ref == "" {
+		ref = "latest"
+	}
+	dump := &RegistryDump{Registry: registry, Version: ref, Overlay: overlay}
+
+	for _, f := range CoreFiles {
+		dump.Components = append(dump.Components, RegistryEntry{Type: registryEntryCore, Name: f, Path: f})
+	}
+	groups := []struct {
+		kind       ComponentType
+		components []Component
+	}{
+		{ComponentTypeLanguage, Languages},
+		{ComponentTypeFramework, Frameworks},
+		{ComponentTypeWorkflow, Workflows},
+		{ComponentTypeSkill, Skills},
+	}
+	for _, g := range groups {
+		for _, c := range g.components {
+			dump.Components = append(dump.Components, RegistryEntry{
+				Type: string(g.kind), Name: c.Name, Path: c.Path, Description: c.Description,
+				Category: c.Category, Tags: c.Tags,
+			})
+		}
+	}
+	for i := range dump.Components {
+		dump.Components[i].Source, dump.Components[i].Registry, dump.Components[i].Ref = LayerBase, registry, ref
+	}
+
+	if tmpl != nil && overlay != nil {
+		dump.Components = append(dump.Components, attachOverlayFiles(dump.Components, tmpl.OverlayFiles(), overlay)...)
+	}
+	return dump
+}
+
+// attachOverlayFiles records overlay files on the base entries that cover
+// them and returns entries for the files no base entry covers.
+func attachOverlayFiles(entries []RegistryEntry, files []string, overlay *OverlayConfig) []RegistryEntry {
+	byPath := make(map[string]int)
+	var added []RegistryEntry
+	for _, f := range files {
+		covered := false
+		for i := range entries {
+			if underAnyPath(f, []string{entries[i].Path}) {
+				entries[i].OverlayFiles = append(entries[i].OverlayFiles, f)
+				covered = true
+			}
+		}
+		if covered {
+			continue
+		}
+
+		entry := RegistryEntry{Type: registryEntryFile, Name: f, Path: f}
+		if rest, ok := strings.CutPrefix(f, ".claude/skills/"); ok && strings.Contains(rest, "/") {
+			name := rest[:strings.Index(rest, "/")]
+			entry = RegistryEntry{Type: string(ComponentTypeSkill), Name: name, Path: ".claude/skills/" + name}
+		}
+		if i, ok := byPath[entry.Path]; ok {
+			added[i].OverlayFiles = append(added[i].OverlayFiles, f)
+			continue
+		}
+		entry.Source, entry.Registry, entry.Ref = LayerOverlay, overlay.Registry, overlay.overlayBranch()
+		entry.OverlayFiles = []string{f}
+		byPath[entry.Path] = len(added)
+		added = append(added, entry)
+	}
+	slices.SortFunc(added, func(a, b RegistryEntry) int { return strings.Compare(a.Path, b.Path) })
+	return added
+}
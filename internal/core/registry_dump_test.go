@@ -0,0 +1,79 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func findRegistryEntry(dump *RegistryDump, typ, name string) *RegistryEntry {
+	for i, e := range dump.Components {
+		if e.Type == typ && e.Name == name {
+			return &dump.Components[i]
+		}
+	}
+	return nil
+}
+
+func TestDumpRegistry_BuiltinOnly(t *testing.T) {
+	dump := DumpRegistry("", "", nil, nil)
+	if dump.Registry != DefaultRegistry || dump.Version != "latest" {
+		t.Errorf("dump = %s@%s, want %s@latest", dump.Registry, dump.Version, DefaultRegistry)
+	}
+	want := len(CoreFiles) + len(Languages) + len(Frameworks) + len(Workflows) + len(Skills)
+	if len(dump.Components) != want {
+		t.Errorf("got %d components, want %d", len(dump.Components), want)
+	}
+	for _, e := range dump.Components {
+		if e.Source != LayerBase || e.Ref != "latest" || len(e.OverlayFiles) > 0 {
+			t.Fatalf("entry %+v should come from the base registry only", e)
+		}
+	}
+	if e := findRegistryEntry(dump, "language", "go"); e == nil || e.Path != ".claude/skills/go-guide" {
+		t.Errorf("language go = %+v, want path .claude/skills/go-guide", e)
+	}
+}
+
+func TestDumpRegistry_Overlay(t *testing.T) {
+	overlay := &OverlayConfig{Registry: "https://github.com/acme/overlay"}
+	tmpl := &LayeredTemplate{overlayFiles: []string{
+		".claude/skills/acme-style/SKILL.md",
+		".claude/skills/acme-style/references/naming.md",
+		".claude/skills/go-guide/SKILL.md",
+		"CLAUDE.md",
+		"docs/acme.md",
+	}}
+	dump := DumpRegistry("https://github.com/ar4mirez/samuel", "2.0.0", overlay, tmpl)
+
+	tests := []struct {
+		typ, name    string
+		wantSource   string
+		wantRef      string
+		wantOverlaid []string
+	}{
+		{"core", "CLAUDE.md", LayerBase, "2.0.0", []string{"CLAUDE.md"}},
+		{"core", "AGENTS.md", LayerBase, "2.0.0", nil},
+		{"language", "go", LayerBase, "2.0.0", []string{".claude/skills/go-guide/SKILL.md"}},
+		{"skill", "go-guide", LayerBase, "2.0.0", []string{".claude/skills/go-guide/SKILL.md"}},
+		{"skill", "acme-style", LayerOverlay, DefaultOverlayBranch, []string{
+			".claude/skills/acme-style/SKILL.md", ".claude/skills/acme-style/references/naming.md",
+		}},
+		{"file", "docs/acme.md", LayerOverlay, DefaultOverlayBranch, []string{"docs/acme.md"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ+"_"+tt.name, func(t *testing.T) {
+			e := findRegistryEntry(dump, tt.typ, tt.name)
+			if e == nil {
+				t.Fatal("entry not found")
+			}
+			if e.Source != tt.wantSource || e.Ref != tt.wantRef {
+				t.Errorf("source/ref = %s/%s, want %s/%s", e.Source, e.Ref, tt.wantSource, tt.wantRef)
+			}
+			if !slices.Equal(e.OverlayFiles, tt.wantOverlaid) {
+				t.Errorf("OverlayFiles = %v, want %v", e.OverlayFiles, tt.wantOverlaid)
+			}
+		})
+	}
+	if e := findRegistryEntry(dump, "skill", "acme-style"); e != nil && e.Registry != overlay.Registry {
+		t.Errorf("overlay skill registry = %s, want %s", e.Registry, overlay.Registry)
+	}
+}
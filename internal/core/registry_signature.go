@@ -0,0 +1,84 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SSH signature armor, as written by ssh-keygen -Y sign and git.
+const (
+	sshSigBegin = "-----BEGIN SSH SIGNATURE-----"
+	sshSigEnd   = "-----END SSH SIGNATURE-----"
+	sshSigMagic = "SSHSIG"
+)
+
+// commitSHAPattern matches a full git commit SHA, which is used in archive
+// URLs and cache directory names.
+var commitSHAPattern = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+// verifyRegistrySignature checks that GitHub verified the signature on the
+// head of branch and that it was made with the required key. It returns
+// the signing key fingerprint and the SHA of the verified commit, which
+// is what must be downloaded: the branch may move after the check.
+func verifyRegistrySignature(client trustClient, branch, signingKey string) (string, string, error) {
+	v, err := client.GetCommitVerification(branch)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to check signature: %w", err)
+	}
+	if !v.Verified {
+		return "", "", fmt.Errorf("head of branch %s has no verified signature (GitHub: %s)", branch, v.Reason)
+	}
+	if !commitSHAPattern.MatchString(v.SHA) {
+		return "", "", fmt.Errorf("GitHub returned no valid commit SHA for branch %s", branch)
+	}
+	got, err := SSHSignatureFingerprint(v.Signature)
+	if err != nil {
+		return "", "", err
+	}
+	if got != signingKey {
+		return "", "", fmt.Errorf("head of branch %s is signed by %s, not trust.signing_key %s", branch, got, signingKey)
+	}
+	return got, v.SHA, nil
+}
+
+// SSHSignatureFingerprint returns the SHA256 fingerprint of the public key
+// embedded in an armored SSH signature, in the form ssh-keygen -l prints.
+func SSHSignatureFingerprint(armored string) (string, error) {
+	body, hasBegin := strings.CutPrefix(strings.TrimSpace(armored), sshSigBegin)
+	body, hasEnd := strings.CutSuffix(body, sshSigEnd)
+	if !hasBegin || !hasEnd {
+		return "", errors.New("commit is not signed with an SSH key; trust.signing_key requires SSH signatures")
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(body), ""))
+	if err != nil {
+		return "", fmt.Errorf("malformed SSH signature: %w", err)
+	}
+	// magic, uint32 version, then the public key as an SSH string
+	if !bytes.HasPrefix(blob, []byte(sshSigMagic)) || len(blob) < len(sshSigMagic)+4 {
+		return "", errors.New("malformed SSH signature: missing SSHSIG header")
+	}
+	key, err := readSSHString(blob[len(sshSigMagic)+4:])
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(key)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:]), nil
+}
+
+// readSSHString reads a length-prefixed string in SSH wire format.
+func readSSHString(b []byte) ([]byte, error) {
+	if len(b) < 4 {
+		return nil, errors.New("malformed SSH signature: truncated public key")
+	}
+	n := binary.BigEndian.Uint32(b)
+	if uint64(len(b)-4) < uint64(n) {
+		return nil, errors.New("malformed SSH signature: truncated public key")
+	}
+	return b[4 : 4+n], nil
+}
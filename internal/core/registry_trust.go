@@ -0,0 +1,137 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/ar4mirez/samuel/internal/github"
+)
+
+// TrustPolicy restricts which third-party registries may supply template
+// files. A registry is identified by a fingerprint of its GitHub owner and
+// repository IDs: these survive renames, but a look-alike or re-created
+// repository gets new ones, so a pinned fingerprint stops a typo-squatted
+// URL from silently injecting agent instructions.
+type TrustPolicy struct {
+	// AllowedOwners lists the GitHub users or organizations that may
+	// publish registries. Empty allows any owner.
+	AllowedOwners []string `yaml:"allowed_owners,omitempty"`
+	// SigningKey is the SSH key fingerprint (SHA256:...) that must have
+	// signed the head commit of the registry branch.
+	SigningKey string `yaml:"signing_key,omitempty"`
+	// Pinned maps registry URLs to the fingerprint accepted on first use.
+	Pinned map[string]string `yaml:"pinned,omitempty"`
+}
+
+// RegistryIdentity describes who publishes a registry, as GitHub reports it.
+type RegistryIdentity struct {
+	Registry    string
+	Owner       string
+	FullName    string
+	Fingerprint string
+	// Pinned is true when the fingerprint matched an existing pin; false
+	// means first use, and the caller decides whether to trust it.
+	Pinned bool
+	// SignedBy is the signing key fingerprint and Commit the SHA of the
+	// verified commit, both set when the policy requires a signature.
+	SignedBy string
+	Commit   string
+}
+
+// trustClient is the GitHub API surface used by trust checks.
+type trustClient interface {
+	GetRepository() (*github.Repository, error)
+	GetCommitVerification(ref string) (*github.CommitVerification, error)
+}
+
+// newTrustClient is replaced in tests to avoid the network.
+var newTrustClient = func(owner, repo string) trustClient {
+	return github.NewClient(owner, repo)
+}
+
+// RegistryFingerprint derives the pinned fingerprint from GitHub's numeric
+// owner and repository IDs.
+func RegistryFingerprint(ownerID, repoID int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("github.com/%d/%d", ownerID, repoID)))
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// registryPinKey normalizes a registry URL so equivalent spellings share a
+// pin. GitHub names are case-insensitive.
+func registryPinKey(owner, repo string) string {
+	return strings.ToLower("https://github.com/" + owner + "/" + repo)
+}
+
+// AllowsOwner reports whether owner may publish registries.
+func (p *TrustPolicy) AllowsOwner(owner string) bool {
+	if p == nil || len(p.AllowedOwners) == 0 {
+		return true
+	}
+	for _, allowed := range p.AllowedOwners {
+		if strings.EqualFold(allowed, owner) {
+			return true
+		}
+	}
+	return false
+}
+
+// Pin records the fingerprint accepted for a registry and returns the
+// policy, allocating one when p is nil.
+func (p *TrustPolicy) Pin(registry, fingerprint string) *TrustPolicy {
+	if p == nil {
+		p = &TrustPolicy{}
+	}
+	if p.Pinned == nil {
+		p.Pinned = make(map[string]string)
+	}
+	owner, repo, err := ParseGitHubRegistry(registry)
+	if err == nil {
+		registry = registryPinKey(owner, repo)
+	}
+	p.Pinned[registry] = fingerprint
+	return p
+}
+
+// VerifyRegistryTrust looks up who publishes the overlay registry and
+// checks it against the policy before anything is downloaded: the owner
+// must be allowed, a pinned fingerprint must still match, and the branch
+// head must carry the required signature. A nil policy only pins.
+func VerifyRegistryTrust(policy *TrustPolicy, overlay *OverlayConfig) (*RegistryIdentity, error) {
+	if err := ValidateOverlay(overlay); err != nil {
+		return nil, err
+	}
+	owner, repo, _ := ParseGitHubRegistry(overlay.Registry)
+	client := newTrustClient(owner, repo)
+	info, err := client.GetRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up registry %s: %w", overlay.Registry, err)
+	}
+
+	id := &RegistryIdentity{
+		Registry:    overlay.Registry,
+		Owner:       info.Owner.Login,
+		FullName:    info.FullName,
+		Fingerprint: RegistryFingerprint(info.Owner.ID, info.ID),
+	}
+	if !policy.AllowsOwner(id.Owner) {
+		return nil, fmt.Errorf("registry %s is published by %s, which is not in trust.allowed_owners %v",
+			overlay.Registry, id.Owner, policy.AllowedOwners)
+	}
+	if policy != nil {
+		if pin, ok := policy.Pinned[registryPinKey(owner, repo)]; ok {
+			if pin != id.Fingerprint {
+				return nil, fmt.Errorf("registry %s does not match its pinned fingerprint (pinned %s, now %s): "+
+					"the repository was replaced or the URL points somewhere else", overlay.Registry, pin, id.Fingerprint)
+			}
+			id.Pinned = true
+		}
+		if policy.SigningKey != "" {
+			if id.SignedBy, id.Commit, err = verifyRegistrySignature(client, overlay.overlayBranch(), policy.SigningKey); err != nil {
+				return nil, fmt.Errorf("registry %s: %w", overlay.Registry, err)
+			}
+		}
+	}
+	return id, nil
+}
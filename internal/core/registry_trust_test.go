@@ -0,0 +1,183 @@
+package core
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ar4mirez/samuel/internal/github"
+)
+
+// fakeTrustClient serves canned GitHub responses.
+type fakeTrustClient struct {
+	repo   *github.Repository
+	verify *github.CommitVerification
+	err    error
+}
+
+func (f *fakeTrustClient) GetRepository() (*github.Repository, error) {
+	return f.repo, f.err
+}
+
+func (f *fakeTrustClient) GetCommitVerification(string) (*github.CommitVerification, error) {
+	return f.verify, nil
+}
+
+func useFakeTrustClient(t *testing.T, f *fakeTrustClient) {
+	t.Helper()
+	orig := newTrustClient
+	newTrustClient = func(string, string) trustClient { return f }
+	t.Cleanup(func() { newTrustClient = orig })
+}
+
+func testRepository(login string, ownerID, repoID int64) *github.Repository {
+	repo := &github.Repository{ID: repoID, FullName: login + "/overlay"}
+	repo.Owner.Login, repo.Owner.ID = login, ownerID
+	return repo
+}
+
+func sshString(b []byte) []byte {
+	return append(binary.BigEndian.AppendUint32(nil, uint32(len(b))), b...)
+}
+
+// testSSHSignature builds an armored SSHSIG blob for a fresh ed25519 key
+// and returns it with the key's fingerprint.
+func testSSHSignature(t *testing.T) (string, string) {
+	t.Helper()
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyType := []byte("ssh-ed25519")
+	key := append(sshString(keyType), sshString(pub)...)
+	blob := append([]byte("SSHSIG"), 0, 0, 0, 1)
+	blob = append(blob, sshString(key)...)
+	blob = append(blob, sshString([]byte("git"))...)
+
+	sum := sha256.Sum256(key)
+	armored := sshSigBegin + "\n" + base64.StdEncoding.EncodeToString(blob) + "\n" + sshSigEnd
+	return armored, "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+func TestVerifyRegistryTrust(t *testing.T) {
+	const registry = "https://github.com/acme/overlay"
+	fingerprint := RegistryFingerprint(7, 42)
+	signature, keyFingerprint := testSSHSignature(t)
+	const sha = "0123456789abcdef0123456789abcdef01234567"
+	signed := &github.CommitVerification{SHA: sha, Verified: true, Reason: "valid", Signature: signature}
+
+	tests := []struct {
+		name       string
+		policy     *TrustPolicy
+		client     *fakeTrustClient
+		wantPinned bool
+		wantCommit string
+		wantErr    string
+	}{
+		{name: "no policy, first use", client: &fakeTrustClient{repo: testRepository("acme", 7, 42)}},
+		{
+			name:       "pin matches",
+			policy:     (*TrustPolicy)(nil).Pin("https://github.com/ACME/overlay.git", fingerprint),
+			client:     &fakeTrustClient{repo: testRepository("acme", 7, 42)},
+			wantPinned: true,
+		},
+		{
+			name:    "re-created repository",
+			policy:  (*TrustPolicy)(nil).Pin(registry, fingerprint),
+			client:  &fakeTrustClient{repo: testRepository("acme", 7, 99)},
+			wantErr: "pinned fingerprint",
+		},
+		{
+			name:   "owner allowed case-insensitively",
+			policy: &TrustPolicy{AllowedOwners: []string{"ACME"}},
+			client: &fakeTrustClient{repo: testRepository("acme", 7, 42)},
+		},
+		{
+			name:    "owner not allowed",
+			policy:  &TrustPolicy{AllowedOwners: []string{"acme-corp"}},
+			client:  &fakeTrustClient{repo: testRepository("acme", 7, 42)},
+			wantErr: "not in trust.allowed_owners",
+		},
+		{
+			name:       "signed by required key",
+			policy:     &TrustPolicy{SigningKey: keyFingerprint},
+			client:     &fakeTrustClient{repo: testRepository("acme", 7, 42), verify: signed},
+			wantCommit: sha,
+		},
+		{
+			name:   "verified commit without SHA",
+			policy: &TrustPolicy{SigningKey: keyFingerprint},
+			client: &fakeTrustClient{
+				repo:   testRepository("acme", 7, 42),
+				verify: &github.CommitVerification{Verified: true, Signature: signature},
+			},
+			wantErr: "no valid commit SHA",
+		},
+		{
+			name:    "signed by another key",
+			policy:  &TrustPolicy{SigningKey: "SHA256:other"},
+			client:  &fakeTrustClient{repo: testRepository("acme", 7, 42), verify: signed},
+			wantErr: "not trust.signing_key",
+		},
+		{
+			name:   "unsigned head",
+			policy: &TrustPolicy{SigningKey: keyFingerprint},
+			client: &fakeTrustClient{
+				repo:   testRepository("acme", 7, 42),
+				verify: &github.CommitVerification{Reason: "unsigned"},
+			},
+			wantErr: "no verified signature",
+		},
+		{
+			name:    "lookup fails",
+			client:  &fakeTrustClient{err: errors.New("acme/overlay not found")},
+			wantErr: "failed to look up registry",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useFakeTrustClient(t, tt.client)
+			id, err := VerifyRegistryTrust(tt.policy, &OverlayConfig{Registry: registry})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("VerifyRegistryTrust() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyRegistryTrust() error = %v", err)
+			}
+			if id.Fingerprint != fingerprint || id.Pinned != tt.wantPinned || id.Owner != "acme" || id.Commit != tt.wantCommit {
+				t.Errorf("VerifyRegistryTrust() = %+v", id)
+			}
+		})
+	}
+}
+
+func TestTrustPolicy_Pin(t *testing.T) {
+	p := (*TrustPolicy)(nil).Pin("https://github.com/Acme/Overlay", "SHA256:x")
+	if got := p.Pinned["https://github.com/acme/overlay"]; got != "SHA256:x" {
+		t.Errorf("Pinned = %v, want normalized key", p.Pinned)
+	}
+}
+
+func TestSSHSignatureFingerprint_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"pgp signature": "-----BEGIN PGP SIGNATURE-----\nabc\n-----END PGP SIGNATURE-----",
+		"bad base64":    sshSigBegin + "\n!!!\n" + sshSigEnd,
+		"wrong magic":   sshSigBegin + "\n" + base64.StdEncoding.EncodeToString([]byte("NOTSIG0000")) + "\n" + sshSigEnd,
+		"truncated key": sshSigBegin + "\n" + base64.StdEncoding.EncodeToString([]byte("SSHSIG\x00\x00\x00\x01\x00\x00\x00\x09ab")) + "\n" + sshSigEnd,
+	}
+	for name, sig := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := SSHSignatureFingerprint(sig); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// SandboxProcess runs the agent on the host under bubblewrap (Linux) or
+// sandbox-exec (macOS), for machines without Docker.
+const SandboxProcess = "process"
+
+// agentStatePaths are the home-relative paths each agent writes its own
+// configuration and session state to. They stay writable in process mode;
+// everything else outside the workspace and temp directories is read-only.
+var agentStatePaths = map[string][]string{
+	"claude": {".claude", ".claude.json"},
+	"amp":    {".config/amp", ".local/share/amp", ".cache/amp"},
+	"codex":  {".codex"},
+	"cursor": {".cursor", ".config/cursor"},
+}
+
+// ProcessSandbox describes one agent run in process mode.
+type ProcessSandbox struct {
+	WorkDir string   // the only project directory the agent may write to
+	Home    string   // home directory holding the agent's state paths
+	Offline bool     // block network access
+	Agent   string   // AI tool, selecting its writable state paths
+	Command []string // agent executable and arguments
+}
+
+// writablePaths returns the workspace followed by the agent's state paths.
+func (s ProcessSandbox) writablePaths() []string {
+	paths := []string{s.WorkDir}
+	if s.Home == "" {
+		return paths
+	}
+	for _, rel := range agentStatePaths[s.Agent] {
+		paths = append(paths, filepath.Join(s.Home, filepath.FromSlash(rel)))
+	}
+	return paths
+}
+
+// BuildBwrapArgs returns the bwrap arguments running s.Command with the
+// host filesystem read-only except for the writable paths, a private /tmp
+// and, when Offline is set, no network. State paths that do not exist are
+// skipped by --bind-try.
+func BuildBwrapArgs(s ProcessSandbox) []string {
+	args := []string{"--ro-bind", "/", "/", "--dev-bind", "/dev", "/dev", "--tmpfs", "/tmp"}
+	for i, p := range s.writablePaths() {
+		bind := "--bind-try"
+		if i == 0 {
+			bind = "--bind"
+		}
+		args = append(args, bind, p, p)
+	}
+	if s.Offline {
+		args = append(args, "--unshare-net")
+	}
+	args = append(args, "--die-with-parent", "--chdir", s.WorkDir, "--")
+	return append(args, s.Command...)
+}
+
+// seatbeltProfile allows everything but writes outside the paths passed as
+// W0, W1, ... parameters and the temp directories. Paths are parameters,
+// not part of the profile text, so they need no quoting.
+const seatbeltProfile = `(version 1)
+(allow default)
+(deny file-write*)
+(allow file-write*
+  (subpath "/private/tmp")
+  (subpath "/private/var/folders")
+  (subpath "/dev")
+%s)
+`
+
+// BuildSandboxExecArgs returns the sandbox-exec arguments running
+// s.Command with writes limited as in BuildBwrapArgs. Seatbelt matches
+// resolved paths, so symlinks are resolved first and paths that do not
+// exist are left out.
+func BuildSandboxExecArgs(s ProcessSandbox) []string {
+	var params, rules []string
+	for _, p := range s.writablePaths() {
+		resolved, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			continue
+		}
+		name := fmt.Sprintf("W%d", len(rules))
+		params = append(params, "-D", name+"="+resolved)
+		rules = append(rules, fmt.Sprintf("  (subpath (param %q))", name))
+	}
+	profile := fmt.Sprintf(seatbeltProfile, strings.Join(rules, "\n"))
+	if s.Offline {
+		profile += "(deny network*)\n"
+	}
+	args := append(params, "-p", profile)
+	return append(args, s.Command...)
+}
+
+// processSandboxCommand returns the command running s on this platform.
+func processSandboxCommand(s ProcessSandbox) (*exec.Cmd, error) {
+	switch runtime.GOOS {
+	case "linux":
+		return exec.Command("bwrap", BuildBwrapArgs(s)...), nil
+	case "darwin":
+		cmd := exec.Command("sandbox-exec", BuildSandboxExecArgs(s)...)
+		cmd.Dir = s.WorkDir
+		return cmd, nil
+	default:
+		return nil, fmt.Errorf("process sandbox is not supported on %s; use docker", runtime.GOOS)
+	}
+}
+
+// CheckProcessSandboxAvailable verifies the platform's sandbox tool is
+// installed and, for bubblewrap, that it may create namespaces.
+func CheckProcessSandboxAvailable() error {
+	switch runtime.GOOS {
+	case "linux":
+		if _, err := exec.LookPath("bwrap"); err != nil {
+			return fmt.Errorf("bwrap not found in PATH; install bubblewrap")
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if out, err := exec.CommandContext(ctx, "bwrap", "--ro-bind", "/", "/", "true").CombinedOutput(); err != nil {
+			return fmt.Errorf("bwrap cannot create a sandbox (unprivileged user namespaces may be disabled): %s",
+				strings.TrimSpace(string(out)))
+		}
+		return nil
+	case "darwin":
+		if _, err := exec.LookPath("sandbox-exec"); err != nil {
+			return fmt.Errorf("sandbox-exec not found in PATH")
+		}
+		return nil
+	default:
+		return fmt.Errorf("process sandbox is not supported on %s; use docker", runtime.GOOS)
+	}
+}
+
+func invokeAgentProcess(cfg LoopConfig) error {
+	args, err := GetAgentArgs(cfg.AITool, cfg.PromptPath)
+	if err != nil {
+		return fmt.Errorf("failed to build agent args: %w", err)
+	}
+	home, _ := os.UserHomeDir()
+	cmd, err := processSandboxCommand(ProcessSandbox{
+		WorkDir: cfg.ProjectDir,
+		Home:    home,
+		Offline: cfg.SandboxOffline,
+		Agent:   cfg.AITool,
+		Command: append([]string{cfg.AITool}, args...),
+	})
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestBuildBwrapArgs(t *testing.T) {
+	s := ProcessSandbox{
+		WorkDir: "/work/app",
+		Home:    "/home/dev",
+		Agent:   "codex",
+		Command: []string{"codex", "exec", "prompt"},
+	}
+	want := []string{
+		"--ro-bind", "/", "/", "--dev-bind", "/dev", "/dev", "--tmpfs", "/tmp",
+		"--bind", "/work/app", "/work/app",
+		"--bind-try", "/home/dev/.codex", "/home/dev/.codex",
+		"--die-with-parent", "--chdir", "/work/app", "--",
+		"codex", "exec", "prompt",
+	}
+	if got := BuildBwrapArgs(s); !slices.Equal(got, want) {
+		t.Errorf("BuildBwrapArgs() =\n%v\nwant\n%v", got, want)
+	}
+
+	s.Offline = true
+	if got := BuildBwrapArgs(s); !slices.Contains(got, "--unshare-net") {
+		t.Errorf("BuildBwrapArgs() offline = %v, want --unshare-net", got)
+	}
+}
+
+func TestBuildBwrapArgs_NoHome(t *testing.T) {
+	s := ProcessSandbox{WorkDir: "/work", Agent: "claude", Command: []string{"claude"}}
+	if got := BuildBwrapArgs(s); slices.Contains(got, "--bind-try") {
+		t.Errorf("BuildBwrapArgs() without home = %v, want no state binds", got)
+	}
+}
+
+func TestBuildSandboxExecArgs(t *testing.T) {
+	work := t.TempDir()
+	home := t.TempDir()
+	if err := os.Mkdir(filepath.Join(home, ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	s := ProcessSandbox{WorkDir: work, Home: home, Agent: "claude", Command: []string{"claude", "-p"}}
+
+	args := BuildSandboxExecArgs(s)
+	resolvedWork, _ := filepath.EvalSymlinks(work)
+	resolvedState, _ := filepath.EvalSymlinks(filepath.Join(home, ".claude"))
+	wantPrefix := []string{"-D", "W0=" + resolvedWork, "-D", "W1=" + resolvedState, "-p"}
+	if len(args) < len(wantPrefix)+3 || !slices.Equal(args[:len(wantPrefix)], wantPrefix) {
+		t.Fatalf("BuildSandboxExecArgs() = %v, want prefix %v (missing .claude.json skipped)", args, wantPrefix)
+	}
+	profile := args[len(wantPrefix)]
+	if !strings.Contains(profile, `(subpath (param "W1"))`) {
+		t.Errorf("profile does not allow W1:\n%s", profile)
+	}
+	if strings.Contains(profile, "deny network") {
+		t.Errorf("profile blocks network without Offline:\n%s", profile)
+	}
+	if got := args[len(wantPrefix)+1:]; !slices.Equal(got, s.Command) {
+		t.Errorf("command = %v, want %v", got, s.Command)
+	}
+
+	s.Offline = true
+	if profile := BuildSandboxExecArgs(s)[len(wantPrefix)]; !strings.Contains(profile, "(deny network*)") {
+		t.Errorf("offline profile does not block network:\n%s", profile)
+	}
+}
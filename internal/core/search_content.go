@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Search returns the lines of indexed skill content that contain every
+// word of query. Each query word matches indexed terms it is a prefix of,
+// so "migrat" finds "migration". Results are ordered by file and line and
+// restricted to skill when it is non-empty; limit <= 0 means no limit.
+// Line text is read from disk, so only matching files are opened.
+func (idx *SkillIndex) Search(projectDir, query, skill string, limit int) ([]ContentMatch, error) {
+	tokens := TokenizeSearchText(query)
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("query must contain a word of at least %d characters", minIndexTermLength)
+	}
+	slices.Sort(tokens)
+	tokens = slices.Compact(tokens)
+
+	hits := idx.linesWithPrefix(tokens[0])
+	for _, token := range tokens[1:] {
+		hits = intersectPostings(hits, idx.linesWithPrefix(token))
+	}
+
+	docs := make([]int, 0, len(hits))
+	for doc := range hits {
+		docs = append(docs, doc)
+	}
+	sort.Ints(docs)
+
+	var matches []ContentMatch
+	for _, d := range docs {
+		doc := idx.Docs[d]
+		if skill != "" && doc.Skill != skill {
+			continue
+		}
+		lines, err := readDocLines(projectDir, doc.Path)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range hits[d] {
+			if n > len(lines) {
+				continue
+			}
+			matches = append(matches, ContentMatch{Skill: doc.Skill, Path: doc.Path, Line: n, Text: strings.TrimSpace(lines[n-1])})
+			if limit > 0 && len(matches) >= limit {
+				return matches, nil
+			}
+		}
+	}
+	return matches, nil
+}
+
+func (idx *SkillIndex) linesWithPrefix(prefix string) map[int][]int {
+	out := make(map[int][]int)
+	for term, postings := range idx.Terms {
+		if !strings.HasPrefix(term, prefix) {
+			continue
+		}
+		for doc, lines := range postings {
+			out[doc] = append(out[doc], lines...)
+		}
+	}
+	for doc, lines := range out {
+		slices.Sort(lines)
+		out[doc] = slices.Compact(lines)
+	}
+	return out
+}
+
+func intersectPostings(a, b map[int][]int) map[int][]int {
+	out := make(map[int][]int)
+	for doc, lines := range a {
+		var both []int
+		for _, line := range lines {
+			if _, found := slices.BinarySearch(b[doc], line); found {
+				both = append(both, line)
+			}
+		}
+		if len(both) > 0 {
+			out[doc] = both
+		}
+	}
+	return out
+}
@@ -0,0 +1,223 @@
+package core
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// skillIndexVersion is bumped whenever the index format changes, so older
+// index files are rebuilt instead of misread.
+const skillIndexVersion = 1
+
+// minIndexTermLength drops one-character terms, which match almost every
+// line and bloat the index.
+const minIndexTermLength = 2
+
+// IndexedDoc is a skill file covered by the content index.
+type IndexedDoc struct {
+	// Path is relative to the project root, with forward slashes.
+	Path    string    `json:"path"`
+	Skill   string    `json:"skill"`
+	ModTime time.Time `json:"mod_time"`
+	Size    int64     `json:"size"`
+}
+
+// SkillIndex is an inverted index over the markdown files of installed
+// skills. Terms maps each lowercased term to the documents it appears in
+// (by position in Docs) and the 1-based lines within each document.
+type SkillIndex struct {
+	Version int                      `json:"version"`
+	BuiltAt time.Time                `json:"built_at"`
+	Docs    []IndexedDoc             `json:"docs"`
+	Terms   map[string]map[int][]int `json:"terms"`
+}
+
+// ContentMatch is one line of skill content matching a query.
+type ContentMatch struct {
+	Skill string `json:"skill"`
+	Path  string `json:"path"`
+	Line  int    `json:"line"`
+	Text  string `json:"text"`
+}
+
+// GetSkillIndexPath returns the path of the content index for a project.
+func GetSkillIndexPath(projectDir string) string {
+	return filepath.Join(projectDir, ".claude", ".samuel-index.json")
+}
+
+// TokenizeSearchText splits text into lowercased letter/digit terms of at
+// least two characters.
+func TokenizeSearchText(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	terms := fields[:0]
+	for _, f := range fields {
+		if len([]rune(f)) >= minIndexTermLength {
+			terms = append(terms, f)
+		}
+	}
+	return terms
+}
+
+// listSkillDocs returns the markdown files under .claude/skills.
+func listSkillDocs(projectDir string) ([]IndexedDoc, error) {
+	skillsDir := filepath.Join(projectDir, ".claude", "skills")
+	var docs []IndexedDoc
+	err := filepath.WalkDir(skillsDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == skillsDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(skillsDir, path)
+		projectRel, _ := filepath.Rel(projectDir, path)
+		docs = append(docs, IndexedDoc{
+			Path:    filepath.ToSlash(projectRel),
+			Skill:   strings.Split(filepath.ToSlash(rel), "/")[0],
+			ModTime: info.ModTime().UTC(),
+			Size:    info.Size(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan skills: %w", err)
+	}
+	return docs, nil
+}
+
+// BuildSkillIndex indexes every markdown file of the installed skills.
+func BuildSkillIndex(projectDir string) (*SkillIndex, error) {
+	docs, err := listSkillDocs(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	idx := &SkillIndex{Version: skillIndexVersion, BuiltAt: time.Now().UTC(), Docs: docs, Terms: make(map[string]map[int][]int)}
+	for i, doc := range docs {
+		lines, err := readDocLines(projectDir, doc.Path)
+		if err != nil {
+			return nil, err
+		}
+		for n, line := range lines {
+			for _, term := range TokenizeSearchText(line) {
+				postings := idx.Terms[term]
+				if postings == nil {
+					postings = make(map[int][]int)
+					idx.Terms[term] = postings
+				}
+				if l := postings[i]; len(l) == 0 || l[len(l)-1] != n+1 {
+					postings[i] = append(l, n+1)
+				}
+			}
+		}
+	}
+	return idx, nil
+}
+
+func readDocLines(projectDir, relPath string) ([]string, error) {
+	f, err := os.Open(filepath.Join(projectDir, filepath.FromSlash(relPath)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", relPath, err)
+	}
+	return lines, nil
+}
+
+// LoadSkillIndex reads the saved content index of a project.
+func LoadSkillIndex(projectDir string) (*SkillIndex, error) {
+	data, err := os.ReadFile(GetSkillIndexPath(projectDir))
+	if err != nil {
+		return nil, err
+	}
+	var idx SkillIndex
+	if err := json.Unmarshal(data, &idx); err != nil {
+		return nil, fmt.Errorf("failed to parse skill index: %w", err)
+	}
+	return &idx, nil
+}
+
+// Save writes the index into the project and keeps it out of git.
+func (idx *SkillIndex) Save(projectDir string) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to encode skill index: %w", err)
+	}
+	path := GetSkillIndexPath(projectDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create index directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write skill index: %w", err)
+	}
+	excludeFromGit(projectDir, ".claude/.samuel-index.json")
+	return nil
+}
+
+// Stale reports whether skill files were added, removed, or changed since
+// the index was built. It only stats files, so it is cheap to call before
+// every query.
+func (idx *SkillIndex) Stale(projectDir string) bool {
+	if idx.Version != skillIndexVersion {
+		return true
+	}
+	docs, err := listSkillDocs(projectDir)
+	if err != nil {
+		return true
+	}
+	return !slices.EqualFunc(docs, idx.Docs, func(a, b IndexedDoc) bool {
+		return a.Path == b.Path && a.Size == b.Size && a.ModTime.Equal(b.ModTime)
+	})
+}
+
+// OpenSkillIndex returns the project's content index, rebuilding and
+// saving it first if it is missing or stale.
+func OpenSkillIndex(projectDir string) (*SkillIndex, error) {
+	idx, err := LoadSkillIndex(projectDir)
+	if err == nil && !idx.Stale(projectDir) {
+		return idx, nil
+	}
+	idx, err = BuildSkillIndex(projectDir)
+	if err != nil {
+		return nil, err
+	}
+	return idx, idx.Save(projectDir)
+}
+
+// RefreshSkillIndex rebuilds the content index after skills change. It
+// does nothing for projects that have never built one.
+func RefreshSkillIndex(projectDir string) error {
+	if _, err := os.Stat(GetSkillIndexPath(projectDir)); err != nil {
+		return nil
+	}
+	idx, err := BuildSkillIndex(projectDir)
+	if err != nil {
+		return err
+	}
+	return idx.Save(projectDir)
+}
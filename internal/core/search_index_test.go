@@ -0,0 +1,133 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestTokenizeSearchText(t *testing.T) {
+	got := TokenizeSearchText("Use `go test ./...` for a Table-Driven test!")
+	want := []string{"use", "go", "test", "for", "table", "driven", "test"}
+	if !slices.Equal(got, want) {
+		t.Errorf("TokenizeSearchText = %v, want %v", got, want)
+	}
+}
+
+func TestSkillIndexSearch(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, filepath.Join(dir, ".claude", "skills"), map[string]string{
+		"go-guide/SKILL.md":              "# Go\nHandle errors with wrapping.\nRun database migrations first.\n",
+		"go-guide/references/testing.md": "Error handling in tests\nTable-driven tests\n",
+		"commit-message/SKILL.md":        "Write the error message in the imperative.\n",
+		"commit-message/notes.txt":       "error handling is not indexed here\n",
+	})
+
+	idx, err := BuildSkillIndex(dir)
+	if err != nil {
+		t.Fatalf("BuildSkillIndex returned error: %v", err)
+	}
+	if len(idx.Docs) != 3 {
+		t.Fatalf("indexed %d docs, want 3 markdown files: %+v", len(idx.Docs), idx.Docs)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		skill string
+		limit int
+		want  []string
+	}{
+		{"all_words_same_line", "error handling", "", 0, []string{".claude/skills/go-guide/references/testing.md:1"}},
+		{"prefix", "migrat", "", 0, []string{".claude/skills/go-guide/SKILL.md:3"}},
+		{"case_insensitive", "ERROR", "", 0, []string{
+			".claude/skills/commit-message/SKILL.md:1",
+			".claude/skills/go-guide/SKILL.md:2",
+			".claude/skills/go-guide/references/testing.md:1",
+		}},
+		{"skill_filter", "error", "commit-message", 0, []string{".claude/skills/commit-message/SKILL.md:1"}},
+		{"limit", "error", "", 1, []string{".claude/skills/commit-message/SKILL.md:1"}},
+		{"no_match", "kubernetes", "", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matches, err := idx.Search(dir, tt.query, tt.skill, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, m := range matches {
+				got = append(got, fmt.Sprintf("%s:%d", m.Path, m.Line))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := idx.Search(dir, "a !", "", 0); err == nil {
+		t.Error("expected error for a query without indexable words")
+	}
+}
+
+func TestOpenSkillIndex(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, filepath.Join(dir, ".claude", "skills"), map[string]string{"go-guide/SKILL.md": "alpha\n"})
+
+	idx, err := OpenSkillIndex(dir)
+	if err != nil {
+		t.Fatalf("OpenSkillIndex returned error: %v", err)
+	}
+	if _, err := os.Stat(GetSkillIndexPath(dir)); err != nil {
+		t.Fatalf("index should be saved: %v", err)
+	}
+	if idx.Stale(dir) {
+		t.Error("freshly built index should not be stale")
+	}
+
+	path := filepath.Join(dir, ".claude", "skills", "go-guide", "SKILL.md")
+	os.WriteFile(path, []byte("beta\n"), 0644)
+	os.Chtimes(path, time.Now().Add(time.Hour), time.Now().Add(time.Hour))
+	if !idx.Stale(dir) {
+		t.Error("index should be stale after a skill file changes")
+	}
+
+	idx, err = OpenSkillIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if matches, _ := idx.Search(dir, "beta", "", 0); len(matches) != 1 {
+		t.Errorf("reopened index should see new content, got %v", matches)
+	}
+}
+
+func TestRefreshSkillIndex(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFiles(t, filepath.Join(dir, ".claude", "skills"), map[string]string{"go-guide/SKILL.md": "alpha\n"})
+
+	if err := RefreshSkillIndex(dir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(GetSkillIndexPath(dir)); !os.IsNotExist(err) {
+		t.Fatal("RefreshSkillIndex must not create an index that was never built")
+	}
+
+	if _, err := OpenSkillIndex(dir); err != nil {
+		t.Fatal(err)
+	}
+	writeTestFiles(t, filepath.Join(dir, ".claude", "skills"), map[string]string{"rust-guide/SKILL.md": "gamma\n"})
+	if err := RefreshSkillIndex(dir); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := LoadSkillIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(idx.Docs) != 2 {
+		t.Errorf("refreshed index has %d docs, want 2", len(idx.Docs))
+	}
+}
@@ -5,28 +5,22 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
-	"unicode"
 
-	"gopkg.in/yaml.v3"
+	"github.com/ar4mirez/samuel/pkg/parse"
 )
 
 // Skill validation constants per Agent Skills specification
 const (
-	MaxSkillNameLength     = 64
-	MaxDescriptionLength   = 1024
-	MaxCompatibilityLength = 500
+	MaxSkillNameLength     = parse.MaxSkillNameLength
+	MaxDescriptionLength   = parse.MaxDescriptionLength
+	MaxCompatibilityLength = parse.MaxCompatibilityLength
 )
 
 // SkillMetadata represents SKILL.md frontmatter per Agent Skills spec
-type SkillMetadata struct {
-	Name          string            `yaml:"name"`
-	Description   string            `yaml:"description"`
-	License       string            `yaml:"license,omitempty"`
-	Compatibility string            `yaml:"compatibility,omitempty"`
-	AllowedTools  string            `yaml:"allowed-tools,omitempty"`
-	Metadata      map[string]string `yaml:"metadata,omitempty"`
-}
+type SkillMetadata = parse.SkillMetadata
 
 // SkillInfo contains parsed skill information
 type SkillInfo struct {
@@ -37,6 +31,7 @@ type SkillInfo struct {
 	HasScripts bool
 	HasRefs    bool
 	HasAssets  bool
+	HasTests   bool
 	Errors     []string
 }
 
@@ -53,131 +48,27 @@ func toTitleCase(s string) string {
 
 // ValidateSkillName checks name format per Agent Skills specification
 func ValidateSkillName(name string) []string {
-	var errors []string
-
-	if name == "" {
-		errors = append(errors, "name is required")
-		return errors
-	}
-
-	if len(name) > MaxSkillNameLength {
-		errors = append(errors, fmt.Sprintf("name exceeds %d character limit (%d chars)", MaxSkillNameLength, len(name)))
-	}
-
-	// Check for uppercase characters
-	if name != strings.ToLower(name) {
-		errors = append(errors, "name must be lowercase")
-	}
-
-	// Check for valid characters
-	for _, r := range name {
-		if !unicode.IsLower(r) && !unicode.IsDigit(r) && r != '-' {
-			errors = append(errors, "name may only contain lowercase letters, digits, and hyphens")
-			break
-		}
-	}
-
-	// Check start/end with hyphen
-	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
-		errors = append(errors, "name cannot start or end with a hyphen")
-	}
-
-	// Check for consecutive hyphens
-	if strings.Contains(name, "--") {
-		errors = append(errors, "name cannot contain consecutive hyphens")
-	}
-
-	return errors
+	return parse.ValidateSkillName(name)
 }
 
 // ValidateSkillDescription checks description per Agent Skills specification
 func ValidateSkillDescription(description string) []string {
-	var errors []string
-
-	if strings.TrimSpace(description) == "" {
-		errors = append(errors, "description is required")
-		return errors
-	}
-
-	if len(description) > MaxDescriptionLength {
-		errors = append(errors, fmt.Sprintf("description exceeds %d character limit (%d chars)", MaxDescriptionLength, len(description)))
-	}
-
-	return errors
+	return parse.ValidateSkillDescription(description)
 }
 
 // ValidateSkillCompatibility checks compatibility field per Agent Skills specification
 func ValidateSkillCompatibility(compatibility string) []string {
-	var errors []string
-
-	if compatibility != "" && len(compatibility) > MaxCompatibilityLength {
-		errors = append(errors, fmt.Sprintf("compatibility exceeds %d character limit (%d chars)", MaxCompatibilityLength, len(compatibility)))
-	}
-
-	return errors
+	return parse.ValidateSkillCompatibility(compatibility)
 }
 
 // ValidateSkillMetadata validates the complete frontmatter
 func ValidateSkillMetadata(meta SkillMetadata, dirName string) []string {
-	var errors []string
-
-	// Validate name
-	errors = append(errors, ValidateSkillName(meta.Name)...)
-
-	// Check name matches directory
-	if meta.Name != "" && dirName != "" && meta.Name != dirName {
-		errors = append(errors, fmt.Sprintf("skill name '%s' must match directory name '%s'", meta.Name, dirName))
-	}
-
-	// Validate description
-	errors = append(errors, ValidateSkillDescription(meta.Description)...)
-
-	// Validate compatibility (optional)
-	errors = append(errors, ValidateSkillCompatibility(meta.Compatibility)...)
-
-	return errors
+	return parse.ValidateSkillMetadata(meta, dirName)
 }
 
 // ParseSkillMD parses SKILL.md content and extracts frontmatter and body
 func ParseSkillMD(content string) (*SkillMetadata, string, error) {
-	lines := strings.Split(content, "\n")
-	if len(lines) == 0 {
-		return nil, "", fmt.Errorf("empty SKILL.md file")
-	}
-
-	// Check for frontmatter delimiter
-	if strings.TrimSpace(lines[0]) != "---" {
-		return nil, "", fmt.Errorf("SKILL.md must start with YAML frontmatter (---)")
-	}
-
-	// Find closing delimiter
-	var frontmatterEnd int
-	for i := 1; i < len(lines); i++ {
-		if strings.TrimSpace(lines[i]) == "---" {
-			frontmatterEnd = i
-			break
-		}
-	}
-
-	if frontmatterEnd == 0 {
-		return nil, "", fmt.Errorf("SKILL.md frontmatter not closed (missing ---)")
-	}
-
-	// Extract frontmatter
-	frontmatterContent := strings.Join(lines[1:frontmatterEnd], "\n")
-
-	var meta SkillMetadata
-	if err := yaml.Unmarshal([]byte(frontmatterContent), &meta); err != nil {
-		return nil, "", fmt.Errorf("invalid YAML frontmatter: %w", err)
-	}
-
-	// Extract body
-	body := ""
-	if frontmatterEnd+1 < len(lines) {
-		body = strings.Join(lines[frontmatterEnd+1:], "\n")
-	}
-
-	return &meta, strings.TrimSpace(body), nil
+	return parse.SkillMD(content)
 }
 
 // LoadSkillInfo loads and validates a skill from a directory
@@ -208,55 +99,28 @@ func LoadSkillInfo(skillDir string) (*SkillInfo, error) {
 	info.Metadata = *meta
 	info.Body = body
 
-	// Validate metadata
-	info.Errors = append(info.Errors, ValidateSkillMetadata(*meta, info.DirName)...)
+	// Validate metadata; a variant keeps the name of its skill
+	skill, variant := SplitSkillVariant(info.DirName)
+	info.Errors = append(info.Errors, ValidateSkillMetadata(*meta, skill)...)
+	if variant != "" {
+		info.Errors = append(info.Errors, ValidateSkillVariant(variant)...)
+	}
 
 	// Check optional directories
 	info.HasScripts = dirExists(filepath.Join(skillDir, "scripts"))
 	info.HasRefs = dirExists(filepath.Join(skillDir, "references"))
 	info.HasAssets = dirExists(filepath.Join(skillDir, "assets"))
+	info.HasTests = dirExists(filepath.Join(skillDir, SkillTestsDir))
+	info.Errors = append(info.Errors, ValidateSkillFixtures(skillDir)...)
 
 	return info, nil
 }
 
-// ScanSkillsDirectory scans a directory for skills and returns their info
+// ScanSkillsDirectory scans a directory for skills and returns their info.
+// Commands that look at the skills more than once should share a
+// SkillCache instead.
 func ScanSkillsDirectory(skillsDir string) ([]*SkillInfo, error) {
-	var skills []*SkillInfo
-
-	entries, err := os.ReadDir(skillsDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return skills, nil
-		}
-		return nil, fmt.Errorf("failed to read skills directory: %w", err)
-	}
-
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
-		}
-
-		// Skip hidden directories and special files
-		if strings.HasPrefix(entry.Name(), ".") {
-			continue
-		}
-
-		skillPath := filepath.Join(skillsDir, entry.Name())
-
-		// Check if SKILL.md exists
-		if _, err := os.Stat(filepath.Join(skillPath, "SKILL.md")); os.IsNotExist(err) {
-			continue
-		}
-
-		info, err := LoadSkillInfo(skillPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to load skill '%s': %w", entry.Name(), err)
-		}
-
-		skills = append(skills, info)
-	}
-
-	return skills, nil
+	return NewSkillCache(skillsDir).Skills()
 }
 
 // GenerateSkillsSection generates the "Available Skills" markdown section
@@ -271,24 +135,32 @@ func GenerateSkillsSection(skills []*SkillInfo) string {
 	sb.WriteString("| Skill | Description |\n")
 	sb.WriteString("|-------|-------------|\n")
 
-	for _, skill := range skills {
+	sorted := slices.Clone(skills)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Metadata.Name < sorted[j].Metadata.Name })
+	for _, skill := range sorted {
 		if len(skill.Errors) > 0 {
 			continue // Skip invalid skills
 		}
-		// Truncate description for table display
-		desc := skill.Metadata.Description
-		desc = strings.ReplaceAll(desc, "\n", " ")
-		if len(desc) > 80 {
-			desc = desc[:77] + "..."
-		}
-		fmt.Fprintf(&sb, "| %s | %s |\n", skill.Metadata.Name, desc)
+		fmt.Fprintf(&sb, "| %s | %s |\n", skillTableName(skill), skillTableDescription(skill))
 	}
 
-	sb.WriteString("\n**To use a skill**: Read `.claude/skills/<skill-name>/SKILL.md`\n")
+	sb.WriteString(skillsSectionFooter)
 
 	return sb.String()
 }
 
+const skillsSectionFooter = "\n**To use a skill**: Read `.claude/skills/<skill-name>/SKILL.md`\n"
+
+// skillTableDescription returns the description of a skill on one line,
+// truncated for table display.
+func skillTableDescription(skill *SkillInfo) string {
+	desc := strings.ReplaceAll(skill.Metadata.Description, "\n", " ")
+	if len(desc) > 80 {
+		desc = desc[:77] + "..."
+	}
+	return desc
+}
+
 // GetSkillTemplate returns the template content for a new SKILL.md file
 func GetSkillTemplate(name string) string {
 	return fmt.Sprintf(`---
@@ -375,43 +247,6 @@ func CreateSkillScaffold(skillsDir, name string) error {
 	return nil
 }
 
-// UpdateCLAUDEMDSkillsSection updates the skills section in CLAUDE.md
-func UpdateCLAUDEMDSkillsSection(claudeMDPath string, skills []*SkillInfo) error {
-	content, err := os.ReadFile(claudeMDPath)
-	if err != nil {
-		return fmt.Errorf("failed to read CLAUDE.md: %w", err)
-	}
-
-	skillsSection := GenerateSkillsSection(skills)
-	if skillsSection == "" {
-		return nil // No skills to add
-	}
-
-	contentStr := string(content)
-
-	// Look for skills marker comments
-	startMarker := "<!-- SKILLS_START -->"
-	endMarker := "<!-- SKILLS_END -->"
-
-	startIdx := strings.Index(contentStr, startMarker)
-	endIdx := strings.Index(contentStr, endMarker)
-
-	var newContent string
-	if startIdx != -1 && endIdx != -1 && endIdx > startIdx {
-		// Replace existing section
-		newContent = contentStr[:startIdx] +
-			startMarker + "\n" +
-			skillsSection +
-			contentStr[endIdx:]
-	} else {
-		// Skills section doesn't exist, don't add it automatically
-		// The user can add the markers manually if they want auto-updates
-		return nil
-	}
-
-	return os.WriteFile(claudeMDPath, []byte(newContent), 0644)
-}
-
 func dirExists(path string) bool {
 	info, err := os.Stat(path)
 	if err != nil {
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// SkillCache memoizes the parsed skills of one skills directory, so a
+// command that looks at them from several places reads and parses each
+// SKILL.md once. It is meant to live for a single command run: code that
+// writes to a skill afterwards must call Invalidate. A SkillCache is safe
+// for concurrent use.
+type SkillCache struct {
+	dir string
+
+	mu      sync.Mutex
+	byName  map[string]*SkillInfo
+	scanned []*SkillInfo
+	full    bool
+}
+
+// NewSkillCache returns an empty cache for the skills under skillsDir.
+func NewSkillCache(skillsDir string) *SkillCache {
+	return &SkillCache{dir: skillsDir, byName: make(map[string]*SkillInfo)}
+}
+
+// Dir returns the skills directory the cache reads from.
+func (c *SkillCache) Dir() string {
+	return c.dir
+}
+
+// Skills returns every skill in the directory, in directory order, with
+// the same filtering as ScanSkillsDirectory. Skills already loaded through
+// Skill are reused.
+func (c *SkillCache) Skills() ([]*SkillInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.full {
+		return c.scanned, nil
+	}
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read skills directory: %w", err)
+	}
+
+	var skills []*SkillInfo
+	for _, entry := range entries {
+		// Skip files, hidden directories and directories without a SKILL.md
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(c.dir, entry.Name(), "SKILL.md")); os.IsNotExist(err) {
+			continue
+		}
+		info, err := c.load(entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to load skill '%s': %w", entry.Name(), err)
+		}
+		skills = append(skills, info)
+	}
+
+	c.scanned, c.full = skills, true
+	return skills, nil
+}
+
+// Skill returns the named skill, loading it on first use. Like
+// LoadSkillInfo, a missing SKILL.md is reported in the result's Errors.
+func (c *SkillCache) Skill(name string) (*SkillInfo, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.load(name)
+}
+
+// load returns the cached skill or parses it. Callers hold c.mu.
+func (c *SkillCache) load(name string) (*SkillInfo, error) {
+	if info, ok := c.byName[name]; ok {
+		return info, nil
+	}
+	info, err := LoadSkillInfo(filepath.Join(c.dir, name))
+	if err != nil {
+		return nil, err
+	}
+	c.byName[name] = info
+	return info, nil
+}
+
+// Invalidate drops the named skills so the next lookup reads them from
+// disk again. With no names, the whole cache is cleared. The directory
+// listing is always rescanned, since a write may add or remove skills.
+func (c *SkillCache) Invalidate(names ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(names) == 0 {
+		c.byName = make(map[string]*SkillInfo)
+	}
+	for _, name := range names {
+		delete(c.byName, name)
+	}
+	c.scanned, c.full = nil, false
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeCacheSkill(t *testing.T, dir, name, description string) {
+	t.Helper()
+	content := "---\nname: " + name + "\ndescription: " + description + "\n---\n"
+	writeTestFile(t, filepath.Join(dir, name, "SKILL.md"), content)
+}
+
+func TestSkillCache_Memoizes(t *testing.T) {
+	dir := t.TempDir()
+	writeCacheSkill(t, dir, "skill-a", "First.")
+	writeCacheSkill(t, dir, "skill-b", "Second.")
+
+	cache := NewSkillCache(dir)
+	single, err := cache.Skill("skill-a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	skills, err := cache.Skills()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skills) != 2 {
+		t.Fatalf("Skills() returned %d skills, want 2", len(skills))
+	}
+	if skills[0] != single {
+		t.Error("Skills() reparsed a skill already loaded by Skill()")
+	}
+
+	// Edits on disk are not seen until the cache is invalidated.
+	writeCacheSkill(t, dir, "skill-a", "Changed.")
+	again, _ := cache.Skills()
+	if again[0].Metadata.Description != "First." {
+		t.Errorf("description = %q, want the cached value", again[0].Metadata.Description)
+	}
+}
+
+func TestSkillCache_Invalidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		names    []string
+		wantDesc string
+	}{
+		{name: "named skill", names: []string{"skill-a"}, wantDesc: "Changed."},
+		{name: "other skill", names: []string{"skill-b"}, wantDesc: "First."},
+		{name: "everything", wantDesc: "Changed."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeCacheSkill(t, dir, "skill-a", "First.")
+			cache := NewSkillCache(dir)
+			if _, err := cache.Skills(); err != nil {
+				t.Fatal(err)
+			}
+
+			writeCacheSkill(t, dir, "skill-a", "Changed.")
+			writeCacheSkill(t, dir, "skill-b", "Added.")
+			cache.Invalidate(tt.names...)
+
+			skills, err := cache.Skills()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(skills) != 2 {
+				t.Fatalf("Skills() returned %d skills after invalidation, want 2", len(skills))
+			}
+			if got := skills[0].Metadata.Description; got != tt.wantDesc {
+				t.Errorf("skill-a description = %q, want %q", got, tt.wantDesc)
+			}
+		})
+	}
+}
+
+func TestSkillCache_MissingDir(t *testing.T) {
+	cache := NewSkillCache(filepath.Join(t.TempDir(), "missing"))
+	skills, err := cache.Skills()
+	if err != nil || len(skills) != 0 {
+		t.Errorf("Skills() = %v, %v; want no skills and no error", skills, err)
+	}
+	if _, err := os.Stat(cache.Dir()); !os.IsNotExist(err) {
+		t.Error("Skills() must not create the directory")
+	}
+}
@@ -0,0 +1,181 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SkillDependsOnKey is the SKILL.md metadata key listing the skills a skill
+// builds on, comma-separated. Framework skills also depend on the guide of
+// their metadata language without declaring it.
+const SkillDependsOnKey = "depends-on"
+
+// Graph output formats
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+)
+
+// frameworkLanguages maps each registry framework to the language it is
+// built on.
+var frameworkLanguages = map[string]string{
+	"react": "typescript", "nextjs": "typescript", "express": "typescript",
+	"django": "python", "fastapi": "python", "flask": "python",
+	"gin": "go", "echo": "go", "fiber": "go",
+	"axum": "rust", "actix-web": "rust", "rocket": "rust",
+	"spring-boot-kotlin": "kotlin", "ktor": "kotlin", "android-compose": "kotlin",
+	"spring-boot-java": "java", "quarkus": "java", "micronaut": "java",
+	"aspnet-core": "csharp", "blazor": "csharp", "unity": "csharp",
+	"laravel": "php", "symfony": "php", "wordpress": "php",
+	"swiftui": "swift", "uikit": "swift", "vapor": "swift",
+	"rails": "ruby", "sinatra": "ruby", "hanami": "ruby",
+	"flutter": "dart", "shelf": "dart", "dart-frog": "dart",
+}
+
+// FrameworkLanguage returns the language a registry framework is built on,
+// or "" when it is unknown.
+func FrameworkLanguage(framework string) string {
+	return frameworkLanguages[framework]
+}
+
+// SkillGraphNode is a skill in a dependency graph.
+type SkillGraphNode struct {
+	Name     string
+	Category string
+}
+
+// SkillGraphEdge says From depends on To. Missing is set when To is not
+// part of the graph, e.g. a guide that is not installed.
+type SkillGraphEdge struct {
+	From    string
+	To      string
+	Missing bool
+}
+
+// SkillGraph is a skill dependency graph with nodes and edges sorted by
+// name.
+type SkillGraph struct {
+	Nodes []SkillGraphNode
+	Edges []SkillGraphEdge
+}
+
+// GetSupportedGraphFormats returns the list of supported graph formats.
+func GetSupportedGraphFormats() []string {
+	return []string{GraphFormatDOT, GraphFormatMermaid}
+}
+
+// SkillDependencies returns the skills info depends on: its depends-on
+// metadata plus, for framework skills, the guide of their language.
+func SkillDependencies(info *SkillInfo) []string {
+	meta := info.Metadata.Metadata
+	var deps []string
+	for _, name := range strings.Split(meta[SkillDependsOnKey], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			deps = append(deps, name)
+		}
+	}
+	if meta["category"] == "framework" && meta["language"] != "" {
+		deps = append(deps, LanguageToSkillName(meta["language"]))
+	}
+	return sortedUnique(slices.DeleteFunc(deps, func(d string) bool { return d == info.Metadata.Name }))
+}
+
+// BuildInstalledSkillGraph builds the graph of scanned skills. Skills
+// with validation errors are still included so broken links are visible.
+func BuildInstalledSkillGraph(skills []*SkillInfo) *SkillGraph {
+	deps := make(map[string][]string, len(skills))
+	var nodes []SkillGraphNode
+	for _, s := range skills {
+		nodes = append(nodes, SkillGraphNode{Name: s.Metadata.Name, Category: s.Metadata.Metadata["category"]})
+		deps[s.Metadata.Name] = SkillDependencies(s)
+	}
+	return newSkillGraph(nodes, deps)
+}
+
+// BuildRegistrySkillGraph builds the graph of every skill in the registry.
+func BuildRegistrySkillGraph() *SkillGraph {
+	deps := make(map[string][]string)
+	var nodes []SkillGraphNode
+	for _, s := range Skills {
+		nodes = append(nodes, SkillGraphNode{Name: s.Name, Category: s.Category})
+		if lang := FrameworkLanguage(s.Name); s.Category == "framework" && lang != "" {
+			deps[s.Name] = []string{LanguageToSkillName(lang)}
+		}
+	}
+	return newSkillGraph(nodes, deps)
+}
+
+func newSkillGraph(nodes []SkillGraphNode, deps map[string][]string) *SkillGraph {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	nodes = slices.CompactFunc(nodes, func(a, b SkillGraphNode) bool { return a.Name == b.Name })
+	known := make(map[string]bool, len(nodes))
+	for _, n := range nodes {
+		known[n.Name] = true
+	}
+
+	g := &SkillGraph{Nodes: nodes}
+	for _, n := range nodes {
+		for _, to := range deps[n.Name] {
+			g.Edges = append(g.Edges, SkillGraphEdge{From: n.Name, To: to, Missing: !known[to]})
+		}
+	}
+	return g
+}
+
+// FormatSkillGraph renders the graph as Graphviz DOT or a Mermaid
+// flowchart. Dependencies outside the graph are drawn dashed.
+func FormatSkillGraph(g *SkillGraph, format string) (string, error) {
+	switch format {
+	case GraphFormatDOT:
+		return formatSkillGraphDOT(g), nil
+	case GraphFormatMermaid:
+		return formatSkillGraphMermaid(g), nil
+	}
+	return "", fmt.Errorf("unsupported graph format: %s (supported: %v)", format, GetSupportedGraphFormats())
+}
+
+func formatSkillGraphDOT(g *SkillGraph) string {
+	var sb strings.Builder
+	sb.WriteString("digraph skills {\n  rankdir=LR;\n  node [shape=box];\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&sb, "  %q", n.Name)
+		if n.Category != "" {
+			fmt.Fprintf(&sb, " [tooltip=%q]", n.Category)
+		}
+		sb.WriteString(";\n")
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "  %q -> %q", e.From, e.To)
+		if e.Missing {
+			sb.WriteString(" [style=dashed]")
+		}
+		sb.WriteString(";\n")
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+func formatSkillGraphMermaid(g *SkillGraph) string {
+	var sb strings.Builder
+	sb.WriteString("flowchart LR\n")
+	for _, n := range g.Nodes {
+		fmt.Fprintf(&sb, "  %s[\"%s\"]\n", mermaidID(n.Name), n.Name)
+	}
+	for _, e := range g.Edges {
+		arrow := "-->"
+		if e.Missing {
+			arrow = "-.->"
+			fmt.Fprintf(&sb, "  %s[\"%s\"]\n", mermaidID(e.To), e.To)
+		}
+		fmt.Fprintf(&sb, "  %s %s %s\n", mermaidID(e.From), arrow, mermaidID(e.To))
+	}
+	return sb.String()
+}
+
+// mermaidID turns a skill name into a Mermaid node id; hyphens would be
+// read as part of an arrow and words like "end" are reserved.
+func mermaidID(name string) string {
+	return "skill_" + strings.ReplaceAll(name, "-", "_")
+}
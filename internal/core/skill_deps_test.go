@@ -0,0 +1,113 @@
+package core
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func depsSkill(name string, meta map[string]string) *SkillInfo {
+	return &SkillInfo{Metadata: SkillMetadata{Name: name, Description: "d", Metadata: meta}}
+}
+
+func TestSkillDependencies(t *testing.T) {
+	tests := []struct {
+		name  string
+		skill *SkillInfo
+		want  []string
+	}{
+		{"none", depsSkill("commit-message", nil), nil},
+		{"framework language", depsSkill("gin", map[string]string{"category": "framework", "language": "go"}),
+			[]string{"go-guide"}},
+		{"language guide has no implicit dep", depsSkill("go-guide", map[string]string{"category": "language", "language": "go"}),
+			nil},
+		{"declared, trimmed and sorted", depsSkill("api-client", map[string]string{SkillDependsOnKey: " testing , api-design,,"}),
+			[]string{"api-design", "testing"}},
+		{"declared plus language, deduplicated", depsSkill("nextjs", map[string]string{
+			"category": "framework", "language": "typescript", SkillDependsOnKey: "react, typescript-guide"}),
+			[]string{"react", "typescript-guide"}},
+		{"self reference dropped", depsSkill("loop", map[string]string{SkillDependsOnKey: "loop"}), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SkillDependencies(tt.skill); !slices.Equal(got, tt.want) {
+				t.Errorf("SkillDependencies() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildInstalledSkillGraph(t *testing.T) {
+	g := BuildInstalledSkillGraph([]*SkillInfo{
+		depsSkill("gin", map[string]string{"category": "framework", "language": "go"}),
+		depsSkill("go-guide", map[string]string{"category": "language"}),
+		depsSkill("django", map[string]string{"category": "framework", "language": "python"}),
+	})
+
+	var names []string
+	for _, n := range g.Nodes {
+		names = append(names, n.Name)
+	}
+	if !slices.Equal(names, []string{"django", "gin", "go-guide"}) {
+		t.Errorf("nodes = %v, want sorted names", names)
+	}
+	want := []SkillGraphEdge{
+		{From: "django", To: "python-guide", Missing: true},
+		{From: "gin", To: "go-guide"},
+	}
+	if !slices.Equal(g.Edges, want) {
+		t.Errorf("edges = %+v, want %+v", g.Edges, want)
+	}
+}
+
+func TestBuildRegistrySkillGraph(t *testing.T) {
+	g := BuildRegistrySkillGraph()
+	if len(g.Nodes) == 0 {
+		t.Fatal("registry graph has no nodes")
+	}
+	for _, fw := range GetFrameworkSkills() {
+		if FrameworkLanguage(fw.Name) == "" {
+			t.Errorf("framework skill %s has no language", fw.Name)
+		}
+	}
+	for _, e := range g.Edges {
+		if e.Missing {
+			t.Errorf("registry edge %s -> %s points outside the registry", e.From, e.To)
+		}
+	}
+	if !slices.Contains(g.Edges, SkillGraphEdge{From: "gin", To: "go-guide"}) {
+		t.Error("expected gin -> go-guide edge")
+	}
+}
+
+func TestFormatSkillGraph(t *testing.T) {
+	g := BuildInstalledSkillGraph([]*SkillInfo{
+		depsSkill("gin", map[string]string{"category": "framework", "language": "go"}),
+		depsSkill("end", nil),
+	})
+
+	tests := []struct {
+		format  string
+		want    []string
+		wantErr bool
+	}{
+		{format: GraphFormatDOT, want: []string{
+			"digraph skills {", `"gin" [tooltip="framework"];`, `"end";`, `"gin" -> "go-guide" [style=dashed];`}},
+		{format: GraphFormatMermaid, want: []string{
+			"flowchart LR", `skill_gin["gin"]`, `skill_end["end"]`, `skill_go_guide["go-guide"]`, "skill_gin -.-> skill_go_guide"}},
+		{format: "png", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			out, err := FormatSkillGraph(g, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FormatSkillGraph() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("output missing %q:\n%s", w, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package core
+
+import (
+	"slices"
+	"strings"
+)
+
+// MarkdownSection is a heading and the text up to the next heading of any
+// level. Key joins the headings of its parents, e.g. "Guardrails > Testing",
+// so identically named subsections stay distinct.
+type MarkdownSection struct {
+	Key   string
+	Level int
+	Body  string
+}
+
+// SkillDiff summarizes how one skill's guidance changed between versions.
+type SkillDiff struct {
+	Skill              string
+	Added              bool
+	Removed            bool
+	DescriptionChanged bool
+	SectionsAdded      []string
+	SectionsRemoved    []string
+	SectionsChanged    []string
+	GuardrailsAdded    []string
+	GuardrailsRemoved  []string
+	FilesAdded         []string
+	FilesRemoved       []string
+	FilesChanged       []string
+}
+
+// Empty reports whether the diff records no changes.
+func (d SkillDiff) Empty() bool {
+	return !d.Added && !d.Removed && !d.DescriptionChanged &&
+		len(d.SectionsAdded)+len(d.SectionsRemoved)+len(d.SectionsChanged) == 0 &&
+		len(d.GuardrailsAdded)+len(d.GuardrailsRemoved) == 0 &&
+		len(d.FilesAdded)+len(d.FilesRemoved)+len(d.FilesChanged) == 0
+}
+
+// ParseMarkdownSections splits markdown into sections at ATX headings,
+// ignoring "#" lines inside code fences. Text before the first heading is
+// returned as a section with an empty key.
+func ParseMarkdownSections(content string) []MarkdownSection {
+	var sections []MarkdownSection
+	var parents []string
+	current := MarkdownSection{}
+	var body []string
+	inFence := false
+	flush := func() {
+		current.Body = strings.TrimSpace(strings.Join(body, "\n"))
+		if current.Key != "" || current.Body != "" {
+			sections = append(sections, current)
+		}
+		body = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		level, title := markdownHeading(trimmed)
+		if inFence || level == 0 {
+			body = append(body, line)
+			continue
+		}
+		flush()
+		if len(parents) >= level {
+			parents = parents[:level-1]
+		}
+		for len(parents) < level-1 {
+			parents = append(parents, "")
+		}
+		parents = append(parents, title)
+		current = MarkdownSection{Key: joinHeadingPath(parents), Level: level}
+	}
+	flush()
+	return sections
+}
+
+func markdownHeading(line string) (int, string) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 || (len(line) > level && line[level] != ' ') {
+		return 0, ""
+	}
+	return level, strings.TrimSpace(strings.TrimRight(line[level:], "#"))
+}
+
+func joinHeadingPath(parents []string) string {
+	var parts []string
+	for _, p := range parents {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " > ")
+}
+
+// DiffSkillFiles compares two versions of a skill's files (relative path to
+// content). SKILL.md is compared section by section and its guardrail
+// bullets line by line; other files are compared as a whole. A nil map
+// stands for a skill that does not exist in that version.
+func DiffSkillFiles(skill string, before, after map[string]string) SkillDiff {
+	d := SkillDiff{Skill: skill, Added: before == nil && after != nil, Removed: before != nil && after == nil}
+	if d.Added || d.Removed {
+		return d
+	}
+
+	if before["SKILL.md"] != after["SKILL.md"] {
+		diffSkillMD(&d, before["SKILL.md"], after["SKILL.md"])
+		if d.Empty() {
+			// Only text outside any section changed.
+			d.FilesChanged = append(d.FilesChanged, "SKILL.md")
+		}
+	}
+	for _, name := range sortedKeys(after) {
+		old, ok := before[name]
+		switch {
+		case !ok:
+			d.FilesAdded = append(d.FilesAdded, name)
+		case old != after[name] && name != "SKILL.md":
+			d.FilesChanged = append(d.FilesChanged, name)
+		}
+	}
+	for _, name := range sortedKeys(before) {
+		if _, ok := after[name]; !ok {
+			d.FilesRemoved = append(d.FilesRemoved, name)
+		}
+	}
+	return d
+}
+
+// splitSkillMD is ParseSkillMD that treats a file without valid
+// frontmatter as all body.
+func splitSkillMD(content string) (*SkillMetadata, string) {
+	meta, body, err := ParseSkillMD(content)
+	if err != nil {
+		return nil, content
+	}
+	return meta, body
+}
+
+func diffSkillMD(d *SkillDiff, before, after string) {
+	oldMeta, oldBody := splitSkillMD(before)
+	newMeta, newBody := splitSkillMD(after)
+	if oldMeta != nil && newMeta != nil && oldMeta.Description != newMeta.Description {
+		d.DescriptionChanged = true
+	}
+
+	oldSections := sectionBodies(ParseMarkdownSections(oldBody))
+	newSections := sectionBodies(ParseMarkdownSections(newBody))
+	for _, key := range sortedKeys(newSections) {
+		old, ok := oldSections[key]
+		switch {
+		case !ok:
+			d.SectionsAdded = append(d.SectionsAdded, key)
+		case old != newSections[key]:
+			d.SectionsChanged = append(d.SectionsChanged, key)
+		}
+	}
+	for _, key := range sortedKeys(oldSections) {
+		if _, ok := newSections[key]; !ok {
+			d.SectionsRemoved = append(d.SectionsRemoved, key)
+		}
+	}
+
+	oldRules := guardrailBullets(oldBody)
+	newRules := guardrailBullets(newBody)
+	for _, rule := range newRules {
+		if !slices.Contains(oldRules, rule) {
+			d.GuardrailsAdded = append(d.GuardrailsAdded, rule)
+		}
+	}
+	for _, rule := range oldRules {
+		if !slices.Contains(newRules, rule) {
+			d.GuardrailsRemoved = append(d.GuardrailsRemoved, rule)
+		}
+	}
+}
+
+func sectionBodies(sections []MarkdownSection) map[string]string {
+	bodies := make(map[string]string, len(sections))
+	for _, s := range sections {
+		if s.Key != "" {
+			bodies[s.Key] = s.Body
+		}
+	}
+	return bodies
+}
+
+// guardrailBullets returns the list items of every section whose heading
+// path mentions guardrails, without their bullet or checkbox markers.
+func guardrailBullets(body string) []string {
+	var rules []string
+	for _, s := range ParseMarkdownSections(body) {
+		if !strings.Contains(strings.ToLower(s.Key), "guardrail") {
+			continue
+		}
+		for _, line := range strings.Split(s.Body, "\n") {
+			line = strings.TrimSpace(line)
+			if !strings.HasPrefix(line, "- ") && !strings.HasPrefix(line, "* ") {
+				continue
+			}
+			line = strings.TrimSpace(line[2:])
+			for _, box := range []string{"[ ]", "[x]", "[X]"} {
+				line = strings.TrimSpace(strings.TrimPrefix(line, box))
+			}
+			if line != "" && !slices.Contains(rules, line) {
+				rules = append(rules, line)
+			}
+		}
+	}
+	return rules
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
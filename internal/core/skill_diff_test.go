@@ -0,0 +1,99 @@
+package core
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseMarkdownSections(t *testing.T) {
+	content := "Intro text\n\n# Guide\n\nOverview\n\n## Guardrails\n\n- one\n\n### Testing\n\n```bash\n# not a heading\n```\n\n## Patterns\n\nbody\n"
+	sections := ParseMarkdownSections(content)
+
+	var keys []string
+	for _, s := range sections {
+		keys = append(keys, s.Key)
+	}
+	want := []string{"", "Guide", "Guide > Guardrails", "Guide > Guardrails > Testing", "Guide > Patterns"}
+	if !slices.Equal(keys, want) {
+		t.Fatalf("keys = %q, want %q", keys, want)
+	}
+	if sections[3].Body != "```bash\n# not a heading\n```" {
+		t.Errorf("Testing body = %q, want the fenced block", sections[3].Body)
+	}
+	if sections[2].Level != 2 {
+		t.Errorf("Guardrails level = %d, want 2", sections[2].Level)
+	}
+}
+
+func TestDiffSkillFiles(t *testing.T) {
+	oldSkill := "---\nname: go-guide\ndescription: Go guidelines\n---\n\n# Go Guide\n\n## Guardrails\n\n- ✓ Wrap errors\n- ✓ No globals\n\n## Legacy\n\nold\n\n## Testing\n\nuse testify\n"
+	newSkill := "---\nname: go-guide\ndescription: Go guidelines and patterns\n---\n\n# Go Guide\n\n## Guardrails\n\n- ✓ Wrap errors\n- ✓ Use context\n\n## Testing\n\ntable-driven tests\n\n## Concurrency\n\nchannels\n"
+
+	tests := []struct {
+		name   string
+		before map[string]string
+		after  map[string]string
+		check  func(t *testing.T, d SkillDiff)
+	}{
+		{
+			name:   "new_skill",
+			before: nil,
+			after:  map[string]string{"SKILL.md": newSkill},
+			check: func(t *testing.T, d SkillDiff) {
+				if !d.Added || d.Removed {
+					t.Errorf("Added = %v, Removed = %v, want added", d.Added, d.Removed)
+				}
+			},
+		},
+		{
+			name:   "removed_skill",
+			before: map[string]string{"SKILL.md": oldSkill},
+			after:  nil,
+			check: func(t *testing.T, d SkillDiff) {
+				if !d.Removed {
+					t.Error("expected Removed")
+				}
+			},
+		},
+		{
+			name:   "unchanged",
+			before: map[string]string{"SKILL.md": oldSkill},
+			after:  map[string]string{"SKILL.md": oldSkill},
+			check: func(t *testing.T, d SkillDiff) {
+				if !d.Empty() {
+					t.Errorf("expected empty diff, got %+v", d)
+				}
+			},
+		},
+		{
+			name:   "sections_and_guardrails",
+			before: map[string]string{"SKILL.md": oldSkill, "references/a.md": "a", "references/b.md": "b"},
+			after:  map[string]string{"SKILL.md": newSkill, "references/a.md": "a2", "references/c.md": "c"},
+			check: func(t *testing.T, d SkillDiff) {
+				if !d.DescriptionChanged {
+					t.Error("expected DescriptionChanged")
+				}
+				assertStrings(t, "SectionsAdded", d.SectionsAdded, []string{"Go Guide > Concurrency"})
+				assertStrings(t, "SectionsRemoved", d.SectionsRemoved, []string{"Go Guide > Legacy"})
+				assertStrings(t, "SectionsChanged", d.SectionsChanged, []string{"Go Guide > Guardrails", "Go Guide > Testing"})
+				assertStrings(t, "GuardrailsAdded", d.GuardrailsAdded, []string{"✓ Use context"})
+				assertStrings(t, "GuardrailsRemoved", d.GuardrailsRemoved, []string{"✓ No globals"})
+				assertStrings(t, "FilesAdded", d.FilesAdded, []string{"references/c.md"})
+				assertStrings(t, "FilesRemoved", d.FilesRemoved, []string{"references/b.md"})
+				assertStrings(t, "FilesChanged", d.FilesChanged, []string{"references/a.md"})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.check(t, DiffSkillFiles("go-guide", tt.before, tt.after))
+		})
+	}
+}
+
+func assertStrings(t *testing.T, field string, got, want []string) {
+	t.Helper()
+	if !slices.Equal(got, want) {
+		t.Errorf("%s = %q, want %q", field, got, want)
+	}
+}
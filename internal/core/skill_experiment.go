@@ -0,0 +1,147 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// SkillExperiment records when the variants of a skill started (and
+// stopped) being compared. Iteration events logged in between are tagged
+// with the variant active at the time (see RunningSkillExperiments).
+type SkillExperiment struct {
+	Started string `yaml:"started" json:"started"`
+	Stopped string `yaml:"stopped,omitempty" json:"stopped,omitempty"`
+}
+
+// Running reports whether the experiment has not been stopped.
+func (e *SkillExperiment) Running() bool {
+	return e != nil && e.Stopped == ""
+}
+
+// covers reports whether an event logged at timestamp falls within the
+// experiment. Both are RFC 3339 UTC times, which sort as strings.
+func (e *SkillExperiment) covers(timestamp string) bool {
+	return timestamp >= e.Started && (e.Stopped == "" || timestamp <= e.Stopped)
+}
+
+// SkillExperiment returns the experiment on skill, or nil.
+func (c *Config) SkillExperiment(skill string) *SkillExperiment {
+	if c == nil || c.Skills == nil {
+		return nil
+	}
+	return c.Skills.Experiments[skill]
+}
+
+// StartSkillExperiment starts comparing the variants of skill unless an
+// experiment on it is already running. A stopped experiment is replaced.
+func (c *Config) StartSkillExperiment(skill string, now time.Time) *SkillExperiment {
+	if exp := c.SkillExperiment(skill); exp.Running() {
+		return exp
+	}
+	if c.Skills == nil {
+		c.Skills = &SkillsYAML{}
+	}
+	if c.Skills.Experiments == nil {
+		c.Skills.Experiments = make(map[string]*SkillExperiment)
+	}
+	exp := &SkillExperiment{Started: now.UTC().Format(time.RFC3339)}
+	c.Skills.Experiments[skill] = exp
+	return exp
+}
+
+// StopSkillExperiment stops the running experiment on skill. The
+// experiment stays in the config so it can still be reported.
+func (c *Config) StopSkillExperiment(skill string, now time.Time) error {
+	exp := c.SkillExperiment(skill)
+	if !exp.Running() {
+		return fmt.Errorf("no experiment running on skill '%s'", skill)
+	}
+	exp.Stopped = now.UTC().Format(time.RFC3339)
+	return nil
+}
+
+// RunningSkillExperiments maps each skill with a running experiment in the
+// project to its active variant, for tagging iteration events. It is nil
+// when there are none or the config cannot be read.
+func RunningSkillExperiments(projectDir string) map[string]string {
+	config, err := LoadConfigFrom(projectDir)
+	if err != nil || config.Skills == nil {
+		return nil
+	}
+	var running map[string]string
+	for skill, exp := range config.Skills.Experiments {
+		if exp.Running() {
+			if running == nil {
+				running = make(map[string]string)
+			}
+			running[skill] = config.ActiveSkillVariant(skill)
+		}
+	}
+	return running
+}
+
+// VariantResult holds the auto-loop metrics recorded under one variant.
+// A task counts as completed under the variant of its last iteration.
+type VariantResult struct {
+	Variant      string `json:"variant"`
+	Iterations   int    `json:"iterations"`
+	Failed       int    `json:"failed"`
+	Tasks        int    `json:"tasks"`
+	Completed    int    `json:"completed"`
+	FilesChanged int    `json:"files_changed"`
+}
+
+// IterationsPerTask returns the iterations spent per completed task, or
+// zero when none was completed.
+func (r VariantResult) IterationsPerTask() float64 {
+	if r.Completed == 0 {
+		return 0
+	}
+	return float64(r.Iterations) / float64(r.Completed)
+}
+
+// SkillExperimentResults aggregates the events of the experiment on skill
+// by variant, in variant order. prd may be nil, leaving Completed zero.
+func SkillExperimentResults(exp *SkillExperiment, skill string, events []IterationEvent, prd *AutoPRD) []VariantResult {
+	results := make(map[string]*VariantResult)
+	lastVariant := make(map[string]string) // task ID -> variant of its last iteration
+	taskSeen := make(map[string]bool)      // variant + task ID
+	for _, e := range events {
+		variant, ok := e.Variants[skill]
+		if !ok || !exp.covers(e.Timestamp) {
+			continue
+		}
+		r := results[variant]
+		if r == nil {
+			r = &VariantResult{Variant: variant}
+			results[variant] = r
+		}
+		r.Iterations++
+		r.FilesChanged += e.Changes.Total()
+		if e.Error != "" {
+			r.Failed++
+		}
+		if e.Task != "" {
+			if !taskSeen[variant+"\x00"+e.Task] {
+				taskSeen[variant+"\x00"+e.Task] = true
+				r.Tasks++
+			}
+			lastVariant[e.Task] = variant
+		}
+	}
+	if prd != nil {
+		for id, variant := range lastVariant {
+			if t := prd.findTask(id); t != nil && t.Status == TaskStatusCompleted {
+				results[variant].Completed++
+			}
+		}
+	}
+
+	list := make([]VariantResult, 0, len(results))
+	for _, r := range results {
+		list = append(list, *r)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Variant < list[j].Variant })
+	return list
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSkillExperimentLifecycle(t *testing.T) {
+	config := &Config{}
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	exp := config.StartSkillExperiment("go-guide", start)
+	if !exp.Running() || exp.Started != "2026-03-01T09:00:00Z" {
+		t.Fatalf("StartSkillExperiment() = %+v", exp)
+	}
+	if again := config.StartSkillExperiment("go-guide", start.Add(time.Hour)); again != exp {
+		t.Error("starting a running experiment should keep it")
+	}
+
+	if err := config.StopSkillExperiment("go-guide", start.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if exp.Running() || exp.Stopped != "2026-03-01T11:00:00Z" {
+		t.Errorf("stopped experiment = %+v", exp)
+	}
+	if err := config.StopSkillExperiment("go-guide", start); err == nil {
+		t.Error("stopping a stopped experiment should fail")
+	}
+	if restarted := config.StartSkillExperiment("go-guide", start.Add(3*time.Hour)); restarted == exp {
+		t.Error("starting after a stop should begin a new experiment")
+	}
+}
+
+func TestRunningSkillExperiments(t *testing.T) {
+	dir := t.TempDir()
+	if got := RunningSkillExperiments(dir); got != nil {
+		t.Errorf("without config = %v, want nil", got)
+	}
+	config := NewConfig("1.0.0")
+	config.StartSkillExperiment("go-guide", time.Now())
+	config.SetActiveSkillVariant("go-guide", "experimental")
+	config.StartSkillExperiment("python-guide", time.Now())
+	_ = config.StopSkillExperiment("python-guide", time.Now())
+	if err := config.Save(dir); err != nil {
+		t.Fatal(err)
+	}
+	got := RunningSkillExperiments(dir)
+	if len(got) != 1 || got["go-guide"] != "experimental" {
+		t.Errorf("RunningSkillExperiments() = %v", got)
+	}
+}
+
+func TestSkillExperimentResults(t *testing.T) {
+	exp := &SkillExperiment{Started: "2026-03-01T09:00:00Z"}
+	tag := func(variant string) map[string]string { return map[string]string{"go-guide": variant} }
+	events := []IterationEvent{
+		{Timestamp: "2026-03-01T08:00:00Z", Task: "1", Variants: tag("default")}, // before the experiment
+		{Timestamp: "2026-03-01T09:10:00Z", Task: "1", Variants: tag("default"), Error: "exit status 1"},
+		{Timestamp: "2026-03-01T09:20:00Z", Task: "1", Variants: tag("default"), Changes: FileChangeSummary{Modified: 2}},
+		{Timestamp: "2026-03-01T10:00:00Z", Task: "2", Variants: tag("experimental"), Changes: FileChangeSummary{Added: 1}},
+		{Timestamp: "2026-03-01T10:10:00Z", Task: "3", Variants: tag("experimental")},
+		{Timestamp: "2026-03-01T10:20:00Z", Task: "4"}, // not tagged
+	}
+	prd := &AutoPRD{Tasks: []AutoTask{
+		{ID: "1", Status: TaskStatusCompleted},
+		{ID: "2", Status: TaskStatusCompleted},
+		{ID: "3", Status: TaskStatusPending},
+	}}
+
+	got := SkillExperimentResults(exp, "go-guide", events, prd)
+	want := []VariantResult{
+		{Variant: "default", Iterations: 2, Failed: 1, Tasks: 1, Completed: 1, FilesChanged: 2},
+		{Variant: "experimental", Iterations: 2, Tasks: 2, Completed: 1, FilesChanged: 1},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SkillExperimentResults() = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+	if got[0].IterationsPerTask() != 2 {
+		t.Errorf("IterationsPerTask() = %v, want 2", got[0].IterationsPerTask())
+	}
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Skill test fixture layout. Each case is a directory under the skill's
+// tests/ directory holding the request given to the agent and the output
+// expected from it. samuel scaffolds and validates cases but does not run
+// them against an agent.
+const (
+	SkillTestsDir       = "tests"
+	FixtureCaseFile     = "case.yaml"
+	FixtureInputFile    = "input.md"
+	FixtureExpectedFile = "expected.md"
+)
+
+// SkillFixtureCase is the case.yaml file of a fixture.
+type SkillFixtureCase struct {
+	Description string `yaml:"description"`
+}
+
+// SkillExample is an input/output pair from a SKILL.md Examples section.
+type SkillExample struct {
+	Title  string
+	Input  string
+	Output string
+}
+
+var exampleTitlePrefix = regexp.MustCompile(`(?i)^example\s*\d*\s*[:.-]?\s*`)
+
+// ExtractSkillExamples returns the examples under a "## Examples" heading.
+// Each "###" heading starts an example; the text after **Input**: and
+// **Output**: becomes its input and output, with a surrounding code fence
+// removed.
+func ExtractSkillExamples(body string) []SkillExample {
+	var examples []SkillExample
+	var title string
+	var input, output []string
+	var target *[]string
+	inSection, inFence := false, false
+	flush := func() {
+		if title != "" && (len(input) > 0 || len(output) > 0) {
+			examples = append(examples, SkillExample{Title: title, Input: joinExampleText(input), Output: joinExampleText(output)})
+		}
+		title, input, output, target = "", nil, nil, nil
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		switch {
+		case target != nil && (inFence || strings.HasPrefix(trimmed, "```")):
+			*target = append(*target, line)
+		case strings.HasPrefix(trimmed, "## "):
+			flush()
+			inSection = strings.HasPrefix(strings.ToLower(trimmed[3:]), "example")
+		case !inSection:
+		case strings.HasPrefix(trimmed, "### "):
+			flush()
+			title = strings.TrimSpace(trimmed[4:])
+		case strings.HasPrefix(trimmed, "**Input**:"):
+			target = &input
+			input = append(input, strings.TrimPrefix(trimmed, "**Input**:"))
+		case strings.HasPrefix(trimmed, "**Output**:"):
+			target = &output
+			output = append(output, strings.TrimPrefix(trimmed, "**Output**:"))
+		case target != nil:
+			*target = append(*target, line)
+		}
+	}
+	flush()
+	return examples
+}
+
+func joinExampleText(lines []string) string {
+	text := strings.TrimSpace(strings.Join(lines, "\n"))
+	if strings.HasPrefix(text, "```") && strings.HasSuffix(text, "```") && strings.Count(text, "\n") > 0 {
+		text = text[strings.Index(text, "\n")+1 : strings.LastIndex(text, "\n")]
+	}
+	return strings.TrimSpace(text)
+}
+
+// FixtureCaseName converts an example title such as "Example 1: Basic
+// Usage" into a case directory name such as "basic-usage".
+func FixtureCaseName(title string) string {
+	title = strings.ToLower(exampleTitlePrefix.ReplaceAllString(strings.TrimSpace(title), ""))
+	var b strings.Builder
+	for _, r := range title {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else if b.Len() > 0 && !strings.HasSuffix(b.String(), "-") {
+			b.WriteRune('-')
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// CreateSkillFixtures scaffolds tests/ for the skill at skillPath with one
+// case per SKILL.md example, or a single placeholder case when there are
+// none. Existing files are kept unless force is set. It returns the files
+// written, relative to skillPath.
+func CreateSkillFixtures(skillPath string, force bool) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(skillPath, "SKILL.md"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SKILL.md: %w", err)
+	}
+	_, body, err := ParseSkillMD(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	examples := ExtractSkillExamples(body)
+	if len(examples) == 0 {
+		examples = []SkillExample{{
+			Title:  "Basic usage",
+			Input:  "Describe the request a user would make that should activate this skill.",
+			Output: "Describe the response the agent should give when following this skill.",
+		}}
+	}
+
+	files := map[string]string{filepath.Join(SkillTestsDir, "README.md"): skillTestsReadme}
+	var used []string
+	for i, ex := range examples {
+		name := FixtureCaseName(ex.Title)
+		if name == "" || slices.Contains(used, name) {
+			name = fmt.Sprintf("example-%d", i+1)
+		}
+		used = append(used, name)
+
+		caseYAML, err := yaml.Marshal(SkillFixtureCase{Description: ex.Title})
+		if err != nil {
+			return nil, err
+		}
+		dir := filepath.Join(SkillTestsDir, name)
+		files[filepath.Join(dir, FixtureCaseFile)] = string(caseYAML)
+		files[filepath.Join(dir, FixtureInputFile)] = ex.Input + "\n"
+		files[filepath.Join(dir, FixtureExpectedFile)] = ex.Output + "\n"
+	}
+	return writeFixtureFiles(skillPath, files, force)
+}
+
+func writeFixtureFiles(skillPath string, files map[string]string, force bool) ([]string, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var written []string
+	for _, name := range names {
+		path := filepath.Join(skillPath, name)
+		if _, err := os.Stat(path); err == nil && !force {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return written, fmt.Errorf("failed to create %s: %w", filepath.Dir(name), err)
+		}
+		if err := os.WriteFile(path, []byte(files[name]), 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		written = append(written, name)
+	}
+	return written, nil
+}
+
+// ValidateSkillFixtures checks the tests/ directory of a skill, if it has
+// one: every case needs a non-empty input.md and expected.md, and a
+// case.yaml, when present, must parse.
+func ValidateSkillFixtures(skillPath string) []string {
+	testsDir := filepath.Join(skillPath, SkillTestsDir)
+	entries, err := os.ReadDir(testsDir)
+	if err != nil {
+		return nil
+	}
+
+	var errors []string
+	cases := 0
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		cases++
+		errors = append(errors, validateFixtureCase(filepath.Join(testsDir, entry.Name()), entry.Name())...)
+	}
+	if cases == 0 {
+		errors = append(errors, "tests/ has no fixture cases (run 'samuel skill fixtures' to scaffold them)")
+	}
+	return errors
+}
+
+func validateFixtureCase(dir, name string) []string {
+	var errors []string
+	for _, file := range []string{FixtureInputFile, FixtureExpectedFile} {
+		data, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil || strings.TrimSpace(string(data)) == "" {
+			errors = append(errors, fmt.Sprintf("fixture %s: missing or empty %s", name, file))
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, FixtureCaseFile))
+	if err != nil {
+		return errors
+	}
+	var c SkillFixtureCase
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		errors = append(errors, fmt.Sprintf("fixture %s: invalid %s: %v", name, FixtureCaseFile, err))
+	}
+	return errors
+}
+
+const skillTestsReadme = `# Skill Tests
+
+Each directory here is one test case for this skill:
+
+` + "```text" + `
+tests/
+└── <case>/
+    ├── case.yaml     # description of the case
+    ├── input.md      # the request given to the agent with the skill loaded
+    └── expected.md   # the output the agent should give
+` + "```" + `
+
+samuel does not run these cases against an agent; they record the expected
+behavior for review and for whichever harness runs them. Run
+` + "`samuel skill validate`" + ` to check that every case is complete.
+`
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExtractSkillExamples(t *testing.T) {
+	_, body, err := ParseSkillMD(GetSkillTemplate("my-skill"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	examples := ExtractSkillExamples(body)
+	if len(examples) != 1 {
+		t.Fatalf("got %d examples from the skill template, want 1: %+v", len(examples), examples)
+	}
+	want := SkillExample{Title: "Example 1: Basic Usage", Input: "User request example", Output: "Expected output"}
+	if examples[0] != want {
+		t.Errorf("example = %+v, want %+v", examples[0], want)
+	}
+}
+
+func TestExtractSkillExamples_MultiLineAndFencedHeadings(t *testing.T) {
+	body := "## Instructions\n\n### Not an example\n**Input**: ignored\n\n" +
+		"## Examples\n\n### Create a table\n\n**Input**: Add a users table\nwith an email column\n\n" +
+		"**Output**:\n```sql\n## not a heading\nCREATE TABLE users (email TEXT);\n```\n\n" +
+		"### Drop a table\n**Input**: Remove users\n**Output**: DROP TABLE users;\n\n## Notes\n\n### Tip\n**Input**: ignored\n"
+
+	examples := ExtractSkillExamples(body)
+	if len(examples) != 2 {
+		t.Fatalf("got %d examples, want 2: %+v", len(examples), examples)
+	}
+	if examples[0].Input != "Add a users table\nwith an email column" {
+		t.Errorf("input = %q", examples[0].Input)
+	}
+	if examples[0].Output != "## not a heading\nCREATE TABLE users (email TEXT);" {
+		t.Errorf("output = %q", examples[0].Output)
+	}
+	if examples[1].Output != "DROP TABLE users;" {
+		t.Errorf("inline output = %q", examples[1].Output)
+	}
+}
+
+func TestFixtureCaseName(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{"Example 1: Basic Usage", "basic-usage"},
+		{"Example 2 - Error handling!", "error-handling"},
+		{"Migrate   a  DB (Postgres)", "migrate-a-db-postgres"},
+		{"Example 3:", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := FixtureCaseName(tt.title); got != tt.want {
+				t.Errorf("FixtureCaseName(%q) = %q, want %q", tt.title, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateSkillFixtures(t *testing.T) {
+	skillPath := filepath.Join(t.TempDir(), "my-skill")
+	if err := os.MkdirAll(skillPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillPath, "SKILL.md"), []byte(GetSkillTemplate("my-skill")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := CreateSkillFixtures(skillPath, false)
+	if err != nil {
+		t.Fatalf("CreateSkillFixtures returned error: %v", err)
+	}
+	if len(written) != 4 {
+		t.Errorf("wrote %v, want README and three case files", written)
+	}
+	expected, _ := os.ReadFile(filepath.Join(skillPath, "tests", "basic-usage", FixtureExpectedFile))
+	if strings.TrimSpace(string(expected)) != "Expected output" {
+		t.Errorf("expected.md = %q", expected)
+	}
+	if errs := ValidateSkillFixtures(skillPath); len(errs) != 0 {
+		t.Errorf("generated fixtures should validate, got %v", errs)
+	}
+
+	if written, _ := CreateSkillFixtures(skillPath, false); len(written) != 0 {
+		t.Errorf("second run without force wrote %v", written)
+	}
+	if written, _ := CreateSkillFixtures(skillPath, true); len(written) != 4 {
+		t.Errorf("force should rewrite every file, wrote %v", written)
+	}
+}
+
+func TestValidateSkillFixtures(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{"no_tests_dir", nil, ""},
+		{"empty_tests_dir", map[string]string{"tests/README.md": "x"}, "no fixture cases"},
+		{"missing_expected", map[string]string{"tests/a/input.md": "hi"}, "fixture a: missing or empty expected.md"},
+		{"bad_case_yaml", map[string]string{"tests/a/input.md": "hi", "tests/a/expected.md": "hi", "tests/a/case.yaml": "description: [\n"}, "fixture a: invalid case.yaml"},
+		{"valid", map[string]string{"tests/a/input.md": "hi", "tests/a/expected.md": "hi", "tests/a/case.yaml": "description: hi\n"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skillPath := t.TempDir()
+			writeTestFiles(t, skillPath, tt.files)
+
+			errs := strings.Join(ValidateSkillFixtures(skillPath), "\n")
+			if tt.wantErr == "" && errs != "" {
+				t.Errorf("unexpected errors: %s", errs)
+			}
+			if !strings.Contains(errs, tt.wantErr) {
+				t.Errorf("errors = %q, want %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
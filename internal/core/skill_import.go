@@ -0,0 +1,273 @@
+package core
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ar4mirez/samuel/internal/github"
+)
+
+// Anthropic's public skills repository, the source of
+// "samuel skill import anthropic/<name>".
+const (
+	AnthropicSkillsOwner = "anthropics"
+	AnthropicSkillsRepo  = "skills"
+	// DefaultSkillImportRef is imported when no ref is given. The ref is
+	// resolved to a commit SHA, which is what gets recorded and fetched.
+	DefaultSkillImportRef = "main"
+)
+
+// skillImportPrefixes are the accepted spellings of the source prefix.
+var skillImportPrefixes = []string{"anthropic/", "anthropics/"}
+
+// skillImportRefPattern restricts refs used in API and archive URLs.
+var skillImportRefPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._/-]*$`)
+
+// skillLayoutDirs maps directory names used by upstream skills to the
+// Samuel equivalents (see CreateSkillScaffold).
+var skillLayoutDirs = map[string]string{
+	"reference": "references",
+	"docs":      "references",
+	"templates": "assets",
+	"resources": "assets",
+}
+
+// SkillSource records where an imported skill came from, in samuel.yaml
+// under skill_sources.
+type SkillSource struct {
+	Repository string `yaml:"repository"`
+	Path       string `yaml:"path"`
+	Ref        string `yaml:"ref"`
+	Commit     string `yaml:"commit"`
+	ImportedAt string `yaml:"imported_at"`
+}
+
+// AddImportedSkill registers an imported skill as installed and records
+// its source.
+func (c *Config) AddImportedSkill(name string, source SkillSource) {
+	c.AddSkill(name)
+	if c.SkillSources == nil {
+		c.SkillSources = make(map[string]SkillSource)
+	}
+	c.SkillSources[name] = source
+}
+
+// SkillImport is the result of importing a skill.
+type SkillImport struct {
+	Name   string
+	Source SkillSource
+	Info   *SkillInfo
+	// Adapted lists the layout changes made, e.g. "reference/ -> references/".
+	Adapted []string
+}
+
+// skillImportClient is the GitHub surface used by the importer.
+type skillImportClient interface {
+	GetCommitSHA(ref string) (string, error)
+	DownloadRefArchive(ref string) (io.ReadCloser, int64, error)
+}
+
+// newSkillImportClient is replaced in tests to avoid the network.
+var newSkillImportClient = func(owner, repo string) skillImportClient {
+	return github.NewClient(owner, repo)
+}
+
+// ParseSkillImportSource returns the skill name of a source such as
+// "anthropic/pdf".
+func ParseSkillImportSource(source string) (string, error) {
+	for _, prefix := range skillImportPrefixes {
+		if name, ok := strings.CutPrefix(source, prefix); ok {
+			if errs := ValidateSkillName(name); len(errs) > 0 {
+				return "", fmt.Errorf("invalid skill name %q: %s", name, strings.Join(errs, "; "))
+			}
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported skill source: %s (supported: anthropic/<name>)", source)
+}
+
+// ImportAnthropicSkill fetches the skill name from Anthropic's skills
+// repository at ref, adapts it to the Samuel layout, validates it and
+// installs it into projectDir/.claude/skills/<name>. An installed skill of
+// the same name is only replaced when force is set. Markdown is written
+// with the given final newline policy.
+func ImportAnthropicSkill(projectDir, name, ref, finalNewline string, force bool) (*SkillImport, error) {
+	if ref == "" {
+		ref = DefaultSkillImportRef
+	}
+	if !skillImportRefPattern.MatchString(ref) || strings.Contains(ref, "..") {
+		return nil, fmt.Errorf("invalid ref: %q", ref)
+	}
+	dest := filepath.Join(projectDir, ".claude", "skills", name)
+	if _, err := os.Stat(dest); err == nil && !force {
+		return nil, fmt.Errorf("skill '%s' already exists (use --force to replace it)", name)
+	}
+
+	tmp, err := os.MkdirTemp("", "samuel-skill-import-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	repoDir := filepath.Join(tmp, "repo")
+	commit, rel, err := fetchAnthropicSkill(ref, name, repoDir)
+	if err != nil {
+		return nil, err
+	}
+
+	staged := filepath.Join(tmp, "stage", name)
+	result, err := stageImportedSkill(filepath.Join(repoDir, rel), staged, finalNewline)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.RemoveAll(dest); err != nil {
+		return nil, fmt.Errorf("failed to replace skill: %w", err)
+	}
+	if err := copyDir(staged, dest); err != nil {
+		return nil, fmt.Errorf("failed to install skill: %w", err)
+	}
+
+	result.Name = name
+	result.Info.Path = dest
+	result.Source = SkillSource{
+		Repository: "github.com/" + AnthropicSkillsOwner + "/" + AnthropicSkillsRepo,
+		Path:       filepath.ToSlash(rel), Ref: ref, Commit: commit,
+		ImportedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	return result, nil
+}
+
+// fetchAnthropicSkill resolves ref to a commit, extracts the skills
+// repository at that commit into repoDir and returns the commit and the
+// skill's directory relative to repoDir.
+func fetchAnthropicSkill(ref, name, repoDir string) (string, string, error) {
+	client := newSkillImportClient(AnthropicSkillsOwner, AnthropicSkillsRepo)
+	commit, err := client.GetCommitSHA(ref)
+	if err != nil {
+		return "", "", err
+	}
+	if err := downloadSkillRepo(client, commit, repoDir); err != nil {
+		return "", "", err
+	}
+	rel, err := findImportedSkill(repoDir, name)
+	return commit, rel, err
+}
+
+// downloadSkillRepo extracts the repository archive at commit into dest.
+func downloadSkillRepo(client skillImportClient, commit, dest string) error {
+	reader, _, err := client.DownloadRefArchive(commit)
+	if err != nil {
+		return fmt.Errorf("failed to download skills repository: %w", err)
+	}
+	defer reader.Close()
+	return cacheArchive(reader, dest)
+}
+
+// findImportedSkill returns the directory, relative to repoDir, of the
+// skill name: the shallowest directory of that name holding a SKILL.md,
+// so both skills/<name> and <name> layouts work.
+func findImportedSkill(repoDir, name string) (string, error) {
+	var found []string
+	err := filepath.WalkDir(repoDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.IsDir() || path == repoDir {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if d.Name() == name {
+			if _, statErr := os.Stat(filepath.Join(path, "SKILL.md")); statErr == nil {
+				rel, _ := filepath.Rel(repoDir, path)
+				found = append(found, rel)
+				return filepath.SkipDir
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to scan skills repository: %w", err)
+	}
+	if len(found) == 0 {
+		return "", fmt.Errorf("skill '%s' not found in %s/%s", name, AnthropicSkillsOwner, AnthropicSkillsRepo)
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return strings.Count(found[i], string(os.PathSeparator)) < strings.Count(found[j], string(os.PathSeparator))
+	})
+	return found[0], nil
+}
+
+// stageImportedSkill copies the skill at src to dst, adapts its layout,
+// normalizes its markdown and validates the result.
+func stageImportedSkill(src, dst, finalNewline string) (*SkillImport, error) {
+	if err := copyDir(src, dst); err != nil {
+		return nil, fmt.Errorf("failed to copy skill: %w", err)
+	}
+	adapted, err := adaptSkillLayout(dst)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := LintSkillMarkdown(dst, finalNewline, true); err != nil {
+		return nil, fmt.Errorf("failed to normalize skill markdown: %w", err)
+	}
+	info, err := LoadSkillInfo(dst)
+	if err != nil {
+		return nil, err
+	}
+	if len(info.Errors) > 0 {
+		return nil, fmt.Errorf("imported skill is invalid: %s", strings.Join(info.Errors, "; "))
+	}
+	return &SkillImport{Info: info, Adapted: adapted}, nil
+}
+
+// adaptSkillLayout renames upstream directories to the Samuel layout and
+// rewrites relative markdown links to them. A directory is left alone
+// when its Samuel name is already taken.
+func adaptSkillLayout(skillDir string) ([]string, error) {
+	names := make([]string, 0, len(skillLayoutDirs))
+	for from := range skillLayoutDirs {
+		names = append(names, from)
+	}
+	sort.Strings(names)
+
+	var adapted []string
+	for _, from := range names {
+		to := skillLayoutDirs[from]
+		if !dirExists(filepath.Join(skillDir, from)) || dirExists(filepath.Join(skillDir, to)) {
+			continue
+		}
+		if err := os.Rename(filepath.Join(skillDir, from), filepath.Join(skillDir, to)); err != nil {
+			return nil, fmt.Errorf("failed to move %s/ to %s/: %w", from, to, err)
+		}
+		if err := rewriteSkillLinks(skillDir, from, to); err != nil {
+			return nil, err
+		}
+		adapted = append(adapted, from+"/ -> "+to+"/")
+	}
+	return adapted, nil
+}
+
+// rewriteSkillLinks points markdown links into from/ at to/ in every
+// markdown file of the skill.
+func rewriteSkillLinks(skillDir, from, to string) error {
+	replacer := strings.NewReplacer("]("+from+"/", "]("+to+"/", "](./"+from+"/", "](./"+to+"/", "`"+from+"/", "`"+to+"/")
+	return filepath.WalkDir(skillDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if updated := replacer.Replace(string(data)); updated != string(data) {
+			return os.WriteFile(path, []byte(updated), 0644)
+		}
+		return nil
+	})
+}
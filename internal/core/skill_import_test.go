@@ -0,0 +1,129 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeSkillImportClient serves one archive for one commit.
+type fakeSkillImportClient struct {
+	commit  string
+	archive []byte
+	fetched []string
+}
+
+func (c *fakeSkillImportClient) GetCommitSHA(ref string) (string, error) {
+	if ref == "missing" {
+		return "", errors.New("commit missing not found")
+	}
+	return c.commit, nil
+}
+
+func (c *fakeSkillImportClient) DownloadRefArchive(ref string) (io.ReadCloser, int64, error) {
+	c.fetched = append(c.fetched, ref)
+	return io.NopCloser(bytes.NewReader(c.archive)), int64(len(c.archive)), nil
+}
+
+func stubSkillImportClient(t *testing.T, files map[string]string) *fakeSkillImportClient {
+	t.Helper()
+	client := &fakeSkillImportClient{commit: "0123abcd", archive: createTarGzWithFiles(t, files).Bytes()}
+	orig := newSkillImportClient
+	newSkillImportClient = func(owner, repo string) skillImportClient { return client }
+	t.Cleanup(func() { newSkillImportClient = orig })
+	return client
+}
+
+func TestParseSkillImportSource(t *testing.T) {
+	for source, want := range map[string]string{"anthropic/pdf": "pdf", "anthropics/mcp-builder": "mcp-builder"} {
+		if got, err := ParseSkillImportSource(source); err != nil || got != want {
+			t.Errorf("ParseSkillImportSource(%q) = %q, %v, want %q", source, got, err, want)
+		}
+	}
+	for _, source := range []string{"pdf", "acme/pdf", "anthropic/", "anthropic/../pdf", "anthropic/PDF"} {
+		if _, err := ParseSkillImportSource(source); err == nil {
+			t.Errorf("ParseSkillImportSource(%q) = nil error, want error", source)
+		}
+	}
+}
+
+func TestImportAnthropicSkill(t *testing.T) {
+	client := stubSkillImportClient(t, map[string]string{
+		"skills-0123abcd/skills/pdf/SKILL.md":           "---\r\nname: pdf\r\ndescription: Work with PDFs\r\n---\r\n\r\nSee [forms](reference/forms.md).\r\n",
+		"skills-0123abcd/skills/pdf/reference/forms.md": "# Forms\n",
+		"skills-0123abcd/skills/pdf/scripts/fill.py":    "print('x')\n",
+		"skills-0123abcd/template/pdf/SKILL.md":         "---\nname: pdf\ndescription: deeper copy\n---\n",
+	})
+	project := t.TempDir()
+
+	result, err := ImportAnthropicSkill(project, "pdf", "", FinalNewlinePreserve, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(client.fetched, []string{"0123abcd"}) {
+		t.Errorf("fetched refs = %v, want the resolved commit", client.fetched)
+	}
+	want := SkillSource{Repository: "github.com/anthropics/skills", Path: "skills/pdf", Ref: "main", Commit: "0123abcd"}
+	result.Source.ImportedAt = ""
+	if result.Source != want {
+		t.Errorf("Source = %+v, want %+v", result.Source, want)
+	}
+	if !reflect.DeepEqual(result.Adapted, []string{"reference/ -> references/"}) || !result.Info.HasRefs || !result.Info.HasScripts {
+		t.Errorf("Adapted = %v, info = %+v", result.Adapted, result.Info)
+	}
+
+	skillMD, _ := os.ReadFile(filepath.Join(project, ".claude", "skills", "pdf", "SKILL.md"))
+	if !strings.Contains(string(skillMD), "](references/forms.md)") || strings.Contains(string(skillMD), "\r") {
+		t.Errorf("SKILL.md = %q, want normalized with rewritten link", skillMD)
+	}
+	if _, err := os.Stat(filepath.Join(project, ".claude", "skills", "pdf", "references", "forms.md")); err != nil {
+		t.Errorf("references/forms.md not installed: %v", err)
+	}
+
+	if _, err := ImportAnthropicSkill(project, "pdf", "", FinalNewlinePreserve, false); err == nil {
+		t.Error("second import without force should fail")
+	}
+}
+
+func TestImportAnthropicSkill_Errors(t *testing.T) {
+	stubSkillImportClient(t, map[string]string{
+		"skills-0123abcd/skills/bad/SKILL.md": "---\nname: other\ndescription: x\n---\n",
+	})
+	tests := []struct {
+		name, skill, ref, wantErr string
+	}{
+		{"not in repository", "pdf", "", "not found"},
+		{"invalid skill", "bad", "", "invalid"},
+		{"unresolved ref", "pdf", "missing", "missing"},
+		{"bad ref", "pdf", "../main", "invalid ref"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := t.TempDir()
+			_, err := ImportAnthropicSkill(project, tt.skill, tt.ref, FinalNewlinePreserve, false)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ImportAnthropicSkill() error = %v, want containing %q", err, tt.wantErr)
+			}
+			if _, statErr := os.Stat(filepath.Join(project, ".claude", "skills", tt.skill)); !os.IsNotExist(statErr) {
+				t.Error("failed import left a skill directory behind")
+			}
+		})
+	}
+}
+
+func TestConfig_AddImportedSkill(t *testing.T) {
+	cfg := &Config{}
+	cfg.AddImportedSkill("pdf", SkillSource{Commit: "abc"})
+	if !cfg.HasSkill("pdf") || cfg.SkillSources["pdf"].Commit != "abc" {
+		t.Errorf("config = %+v, want pdf installed with its source", cfg)
+	}
+	cfg.RemoveSkill("pdf")
+	if _, ok := cfg.SkillSources["pdf"]; ok {
+		t.Error("RemoveSkill kept the skill source")
+	}
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/ar4mirez/samuel/pkg/parse"
+)
+
+// SkillLintResult is one markdown file of a skill that is not normalized.
+type SkillLintResult struct {
+	// Path is relative to the skill directory, with forward slashes.
+	Path   string
+	Issues []string
+	// Fixed is set when the file was rewritten normalized.
+	Fixed bool
+}
+
+// LintSkillMarkdown checks every markdown file of the skill at skillPath
+// for the problems NormalizeMarkdown removes: a byte order mark, CRLF line
+// endings and a final newline that differs from policy, plus content that
+// is not UTF-8. With fix set, files that are UTF-8 are rewritten in place.
+func LintSkillMarkdown(skillPath, policy string, fix bool) ([]SkillLintResult, error) {
+	var results []SkillLintResult
+	err := filepath.WalkDir(skillPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !isMarkdownFile(path) {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		issues := MarkdownIssues(content, policy)
+		if len(issues) == 0 {
+			return nil
+		}
+
+		rel, _ := filepath.Rel(skillPath, path)
+		result := SkillLintResult{Path: filepath.ToSlash(rel), Issues: issues}
+		if fix {
+			fixed, err := fixMarkdownFile(path, content, policy)
+			if err != nil {
+				return err
+			}
+			result.Fixed = fixed
+		}
+		results = append(results, result)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to lint %s: %w", filepath.Base(skillPath), err)
+	}
+	return results, nil
+}
+
+// fixMarkdownFile rewrites path normalized. Content that is not UTF-8 is
+// left alone and reported as not fixed, since converting it would be a guess.
+func fixMarkdownFile(path string, content []byte, policy string) (bool, error) {
+	normalized, err := NormalizeMarkdown(content, policy)
+	if errors.Is(err, parse.ErrEncoding) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	return true, os.WriteFile(path, normalized, info.Mode().Perm())
+}
@@ -0,0 +1,230 @@
+package core
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// SkillPathsKey is the SKILL.md metadata key listing the files a skill
+// applies to, as comma-separated globs relative to the project root (for
+// example "migrations/**,*.sql"). A glob without a slash matches file
+// names in any directory.
+const SkillPathsKey = "paths"
+
+// Orders of the CLAUDE.md skills table (the skills.order config key).
+const (
+	SkillsOrderName      = "name"
+	SkillsOrderRelevance = "relevance"
+)
+
+// GetSupportedSkillsOrders returns the supported skills.order values.
+func GetSupportedSkillsOrders() []string {
+	return []string{SkillsOrderName, SkillsOrderRelevance}
+}
+
+// ValidateSkillsOrder checks a skills.order value.
+func ValidateSkillsOrder(order string) error {
+	if !slices.Contains(GetSupportedSkillsOrders(), order) {
+		return fmt.Errorf("invalid skills order: %s (supported: %v)", order, GetSupportedSkillsOrders())
+	}
+	return nil
+}
+
+// SkillsYAML controls the generated skills section of CLAUDE.md.
+// Variants maps a skill to the variant listed instead of it, and
+// Experiments records the skills whose variants are being compared.
+// Compact also writes the AGENTS-COMPACT.md digest, kept under
+// CompactBudget tokens.
+type SkillsYAML struct {
+	Order         string                      `yaml:"order,omitempty"`
+	Variants      map[string]string           `yaml:"variants,omitempty"`
+	Experiments   map[string]*SkillExperiment `yaml:"experiments,omitempty"`
+	Compact       bool                        `yaml:"compact,omitempty"`
+	CompactBudget int                         `yaml:"compact_budget,omitempty"`
+}
+
+// SkillsOrder returns the configured order of the skills table, defaulting
+// to SkillsOrderName.
+func (c *Config) SkillsOrder() string {
+	if c == nil || c.Skills == nil || c.Skills.Order == "" {
+		return SkillsOrderName
+	}
+	return c.Skills.Order
+}
+
+// extensionLanguages maps source file extensions to registry languages.
+var extensionLanguages = map[string]string{
+	".go": "go", ".py": "python", ".rs": "rust", ".java": "java", ".cs": "csharp",
+	".ts": "typescript", ".tsx": "typescript", ".js": "typescript", ".jsx": "typescript", ".mjs": "typescript", ".cjs": "typescript",
+	".kt": "kotlin", ".kts": "kotlin", ".php": "php", ".swift": "swift", ".rb": "ruby", ".dart": "dart",
+	".c": "cpp", ".h": "cpp", ".cc": "cpp", ".cpp": "cpp", ".hpp": "cpp",
+	".sql": "sql", ".sh": "shell", ".bash": "shell", ".zsh": "shell", ".r": "r",
+	".html": "html-css", ".css": "html-css", ".scss": "html-css", ".lua": "lua",
+	".s": "assembly", ".asm": "assembly", ".cu": "cuda", ".cuh": "cuda", ".sol": "solidity", ".zig": "zig",
+}
+
+// fileLanguage returns the registry language of a project file, from its
+// extension or, for manifests and lockfiles, its name.
+func fileLanguage(file string) string {
+	name := path.Base(file)
+	for _, m := range languageMarkers {
+		if m.file == name {
+			return m.language
+		}
+	}
+	for _, l := range lockfileParsers {
+		if l.file == name {
+			return l.language
+		}
+	}
+	return extensionLanguages[strings.ToLower(path.Ext(name))]
+}
+
+// ProjectSkillRelevance maps the skill of each detected language and
+// framework to the component it covers.
+func ProjectSkillRelevance(d *ProjectDetection) map[string]string {
+	relevance := map[string]string{}
+	for _, l := range d.Languages {
+		relevance[LanguageToSkillName(l.Name)] = l.Name
+	}
+	for _, f := range d.Frameworks {
+		relevance[FrameworkToSkillName(f.Name)] = f.Name
+	}
+	return relevance
+}
+
+// SkillMatch is an installed skill that applies to a change, with the
+// changed files that make it relevant.
+type SkillMatch struct {
+	Skill  string
+	Reason string
+	Files  []string
+}
+
+// RelevantSkills maps changed files (slash-separated, relative to the
+// project root) to the installed skills to consult for them: the guide of
+// each file's language, the skills of the project's detected frameworks
+// built on that language, and skills whose metadata paths match the file.
+// The skills touching the most files come first.
+func RelevantSkills(skills []*SkillInfo, detection *ProjectDetection, files []string) []SkillMatch {
+	installed := map[string]*SkillInfo{}
+	for _, s := range skills {
+		if len(s.Errors) == 0 {
+			installed[s.Metadata.Name] = s
+		}
+	}
+	matches := map[string]*SkillMatch{}
+	add := func(skill, reason, file string) {
+		if installed[skill] == nil {
+			return
+		}
+		m := matches[skill]
+		if m == nil {
+			m = &SkillMatch{Skill: skill, Reason: reason}
+			matches[skill] = m
+		}
+		if !slices.Contains(m.Files, file) {
+			m.Files = append(m.Files, file)
+		}
+	}
+	for _, file := range files {
+		if lang := fileLanguage(file); lang != "" {
+			add(LanguageToSkillName(lang), lang+" files", file)
+			for _, f := range detection.Frameworks {
+				if FrameworkLanguage(f.Name) == lang {
+					add(FrameworkToSkillName(f.Name), f.Name+" project ("+f.Source+")", file)
+				}
+			}
+		}
+		for _, s := range skills {
+			if glob := skillPathsMatch(s, file); glob != "" {
+				add(s.Metadata.Name, "paths: "+glob, file)
+			}
+		}
+	}
+	return sortSkillMatches(matches)
+}
+
+// skillPathsMatch returns the first glob of the skill's paths metadata
+// that matches file, or "".
+func skillPathsMatch(s *SkillInfo, file string) string {
+	for _, glob := range strings.Split(s.Metadata.Metadata[SkillPathsKey], ",") {
+		glob = strings.Trim(strings.TrimSpace(glob), "/")
+		if glob == "" {
+			continue
+		}
+		if !strings.Contains(glob, "/") {
+			if ok, _ := path.Match(glob, path.Base(file)); ok {
+				return glob
+			}
+		} else if matchGlobPath(glob, file) || matchGlobPath(glob+"/**", file) {
+			return glob
+		}
+	}
+	return ""
+}
+
+func sortSkillMatches(matches map[string]*SkillMatch) []SkillMatch {
+	result := make([]SkillMatch, 0, len(matches))
+	for _, m := range matches {
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Files) != len(result[j].Files) {
+			return len(result[i].Files) > len(result[j].Files)
+		}
+		return result[i].Skill < result[j].Skill
+	})
+	return result
+}
+
+// GenerateRelevantSkillsSection generates the skills section with the
+// skills of the project's stack first, tagged with the language or
+// framework they cover (see ProjectSkillRelevance). Versions are left out
+// so dependency bumps do not change the managed block.
+func GenerateRelevantSkillsSection(skills []*SkillInfo, relevance map[string]string) string {
+	var valid []*SkillInfo
+	for _, s := range skills {
+		if len(s.Errors) == 0 {
+			valid = append(valid, s)
+		}
+	}
+	if len(valid) == 0 {
+		return ""
+	}
+	sort.SliceStable(valid, func(i, j int) bool {
+		_, ri := relevance[valid[i].Metadata.Name]
+		_, rj := relevance[valid[j].Metadata.Name]
+		if ri != rj {
+			return ri
+		}
+		return valid[i].Metadata.Name < valid[j].Metadata.Name
+	})
+
+	var sb strings.Builder
+	sb.WriteString("## Available Skills\n\n")
+	sb.WriteString("Skills extend AI capabilities. Load a skill when task matches its description.\n")
+	sb.WriteString("Skills for this project's stack come first.\n\n")
+	sb.WriteString("| Skill | Description | Project |\n")
+	sb.WriteString("|-------|-------------|---------|\n")
+	for _, skill := range valid {
+		fmt.Fprintf(&sb, "| %s | %s | %s |\n", skillTableName(skill), skillTableDescription(skill), relevance[skill.Metadata.Name])
+	}
+	sb.WriteString(skillsSectionFooter)
+	return sb.String()
+}
+
+// generateProjectSkillsSection generates the skills section of the
+// project in dir in its configured order.
+// Of the variants of a skill, only the active one is listed.
+func generateProjectSkillsSection(dir string, skills []*SkillInfo) string {
+	config, _ := LoadConfigFrom(dir) // nil without samuel.yaml: the defaults
+	skills = SelectSkillVariants(skills, config)
+	if config.SkillsOrder() == SkillsOrderRelevance {
+		return GenerateRelevantSkillsSection(skills, ProjectSkillRelevance(DetectProject(dir)))
+	}
+	return GenerateSkillsSection(skills)
+}
@@ -0,0 +1,104 @@
+package core
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func relevanceTestSkill(name, paths string) *SkillInfo {
+	s := &SkillInfo{Metadata: SkillMetadata{Name: name, Description: name + " skill"}}
+	if paths != "" {
+		s.Metadata.Metadata = map[string]string{SkillPathsKey: paths}
+	}
+	return s
+}
+
+func TestRelevantSkills(t *testing.T) {
+	skills := []*SkillInfo{
+		relevanceTestSkill("go-guide", ""),
+		relevanceTestSkill("gin", ""),
+		relevanceTestSkill("typescript-guide", ""),
+		relevanceTestSkill("database-ops", "migrations/**, *.sql"),
+		{Metadata: SkillMetadata{Name: "python-guide"}, Errors: []string{"broken"}},
+	}
+	detection := &ProjectDetection{Frameworks: []DetectedComponent{{Name: "gin", Kind: ComponentFramework, Source: "go.mod"}}}
+	files := []string{
+		"cmd/server/main.go",
+		"internal/api/handler.go",
+		"go.mod",
+		"migrations/001_init.up",
+		"db/schema.sql",
+		"scripts/tool.py",
+		"README.md",
+	}
+
+	got := RelevantSkills(skills, detection, files)
+	want := []struct {
+		skill  string
+		reason string
+		files  int
+	}{
+		{"gin", "gin project (go.mod)", 3},
+		{"go-guide", "go files", 3},
+		{"database-ops", "paths: migrations/**", 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("RelevantSkills() = %+v, want %d matches", got, len(want))
+	}
+	for i, w := range want {
+		if got[i].Skill != w.skill || got[i].Reason != w.reason || len(got[i].Files) != w.files {
+			t.Errorf("match %d = %s (%s, %d files), want %s (%s, %d files)",
+				i, got[i].Skill, got[i].Reason, len(got[i].Files), w.skill, w.reason, w.files)
+		}
+	}
+	if !slices.Contains(got[2].Files, "db/schema.sql") {
+		t.Errorf("bare glob *.sql should match in any directory, got %v", got[2].Files)
+	}
+}
+
+func TestGenerateRelevantSkillsSection(t *testing.T) {
+	skills := []*SkillInfo{
+		relevanceTestSkill("api-design", ""),
+		relevanceTestSkill("go-guide", ""),
+		relevanceTestSkill("code-review", ""),
+	}
+	section := GenerateRelevantSkillsSection(skills, map[string]string{"go-guide": "go"})
+
+	rows := []string{"| go-guide | go-guide skill | go |", "| api-design | api-design skill |  |", "| code-review |"}
+	last := -1
+	for _, row := range rows {
+		i := strings.Index(section, row)
+		if i <= last {
+			t.Fatalf("section rows out of order or missing %q:\n%s", row, section)
+		}
+		last = i
+	}
+	if GenerateRelevantSkillsSection(nil, nil) != "" {
+		t.Error("empty skills should generate no section")
+	}
+}
+
+func TestUpdateSkillsSection_RelevanceOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, dir+"/samuel.yaml", "version: \"1.0.0\"\nskills:\n  order: relevance\n")
+	writeTestFile(t, dir+"/go.mod", "module example.com/x\n\ngo 1.21\n")
+	writeTestFile(t, dir+"/CLAUDE.md", "# Project\n\n"+SkillsStartMarker+"\n"+SkillsEndMarker+"\n")
+
+	skills := []*SkillInfo{relevanceTestSkill("api-design", ""), relevanceTestSkill("go-guide", "")}
+	if err := UpdateCLAUDEMDSkillsSection(dir+"/CLAUDE.md", skills); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(dir + "/CLAUDE.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.Contains(content, "| go-guide | go-guide skill | go |") {
+		t.Errorf("CLAUDE.md should tag go-guide as relevant:\n%s", content)
+	}
+	if strings.Index(content, "go-guide") > strings.Index(content, "api-design") {
+		t.Errorf("go-guide should be listed before api-design:\n%s", content)
+	}
+}
@@ -643,124 +643,6 @@ func TestCreateSkillScaffold(t *testing.T) {
 	})
 }
 
-func TestUpdateCLAUDEMDSkillsSection(t *testing.T) {
-	t.Run("replaces_existing_section", func(t *testing.T) {
-		dir := t.TempDir()
-		claudeMD := filepath.Join(dir, "CLAUDE.md")
-
-		original := `# Project
-
-Some content.
-
-<!-- SKILLS_START -->
-## Old Skills
-Old content.
-<!-- SKILLS_END -->
-
-More content.`
-		if err := os.WriteFile(claudeMD, []byte(original), 0644); err != nil {
-			t.Fatal(err)
-		}
-
-		skills := []*SkillInfo{
-			{
-				Metadata: SkillMetadata{
-					Name:        "new-skill",
-					Description: "New skill description.",
-				},
-			},
-		}
-
-		err := UpdateCLAUDEMDSkillsSection(claudeMD, skills)
-		if err != nil {
-			t.Fatalf("UpdateCLAUDEMDSkillsSection error: %v", err)
-		}
-
-		content, err := os.ReadFile(claudeMD)
-		if err != nil {
-			t.Fatal(err)
-		}
-		contentStr := string(content)
-
-		if !strings.Contains(contentStr, "new-skill") {
-			t.Error("updated content should contain new skill")
-		}
-		if strings.Contains(contentStr, "Old Skills") {
-			t.Error("old skills section should be replaced")
-		}
-		if !strings.Contains(contentStr, "More content.") {
-			t.Error("content after markers should be preserved")
-		}
-		if !strings.Contains(contentStr, "Some content.") {
-			t.Error("content before markers should be preserved")
-		}
-	})
-
-	t.Run("no_markers_does_nothing", func(t *testing.T) {
-		dir := t.TempDir()
-		claudeMD := filepath.Join(dir, "CLAUDE.md")
-
-		original := "# Project\n\nNo markers here."
-		if err := os.WriteFile(claudeMD, []byte(original), 0644); err != nil {
-			t.Fatal(err)
-		}
-
-		skills := []*SkillInfo{
-			{
-				Metadata: SkillMetadata{
-					Name:        "skill",
-					Description: "Desc.",
-				},
-			},
-		}
-
-		err := UpdateCLAUDEMDSkillsSection(claudeMD, skills)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-
-		content, err := os.ReadFile(claudeMD)
-		if err != nil {
-			t.Fatal(err)
-		}
-		if string(content) != original {
-			t.Error("content should remain unchanged without markers")
-		}
-	})
-
-	t.Run("empty_skills_does_nothing", func(t *testing.T) {
-		dir := t.TempDir()
-		claudeMD := filepath.Join(dir, "CLAUDE.md")
-
-		original := "<!-- SKILLS_START -->\n<!-- SKILLS_END -->"
-		if err := os.WriteFile(claudeMD, []byte(original), 0644); err != nil {
-			t.Fatal(err)
-		}
-
-		err := UpdateCLAUDEMDSkillsSection(claudeMD, nil)
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-
-		content, err := os.ReadFile(claudeMD)
-		if err != nil {
-			t.Fatal(err)
-		}
-		if string(content) != original {
-			t.Error("content should remain unchanged with empty skills")
-		}
-	})
-
-	t.Run("missing_file", func(t *testing.T) {
-		err := UpdateCLAUDEMDSkillsSection("/nonexistent/CLAUDE.md", []*SkillInfo{
-			{Metadata: SkillMetadata{Name: "x", Description: "y"}},
-		})
-		if err == nil {
-			t.Error("expected error for missing file")
-		}
-	})
-}
-
 func TestDirExists(t *testing.T) {
 	t.Run("existing_dir", func(t *testing.T) {
 		dir := t.TempDir()
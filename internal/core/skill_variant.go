@@ -0,0 +1,138 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// SkillVariantSeparator separates a skill from its variant label in the
+// directory name of a variant, e.g. "go-guide@experimental". A variant's
+// SKILL.md keeps the name of the skill it replaces, so adopting it is a
+// rename of the directory.
+const SkillVariantSeparator = "@"
+
+// SkillVariantDefault names the skill's own directory among its variants.
+const SkillVariantDefault = "default"
+
+// SplitSkillVariant splits a skill directory name into the skill name and
+// the variant label, which is empty for the skill's own directory.
+func SplitSkillVariant(dirName string) (skill, variant string) {
+	skill, variant, _ = strings.Cut(dirName, SkillVariantSeparator)
+	return skill, variant
+}
+
+// SkillVariantDir returns the directory name of a variant of skill.
+func SkillVariantDir(skill, variant string) string {
+	if variant == "" || variant == SkillVariantDefault {
+		return skill
+	}
+	return skill + SkillVariantSeparator + variant
+}
+
+// ValidateSkillVariant checks a variant label: it follows the skill name
+// rules and may not be the reserved SkillVariantDefault.
+func ValidateSkillVariant(variant string) []string {
+	if variant == SkillVariantDefault {
+		return []string{fmt.Sprintf("variant name '%s' is reserved for the skill itself", SkillVariantDefault)}
+	}
+	var errs []string
+	for _, e := range ValidateSkillName(variant) {
+		errs = append(errs, "variant "+e)
+	}
+	return errs
+}
+
+// SkillVariants returns the variant labels of skill installed among
+// skills, not counting the skill itself.
+func SkillVariants(skills []*SkillInfo, skill string) []string {
+	var variants []string
+	for _, s := range skills {
+		if name, variant := SplitSkillVariant(s.DirName); name == skill && variant != "" {
+			variants = append(variants, variant)
+		}
+	}
+	return variants
+}
+
+// ActiveSkillVariant returns the variant of skill selected by
+// skills.variants, or SkillVariantDefault.
+func (c *Config) ActiveSkillVariant(skill string) string {
+	if c == nil || c.Skills == nil || c.Skills.Variants[skill] == "" {
+		return SkillVariantDefault
+	}
+	return c.Skills.Variants[skill]
+}
+
+// SetActiveSkillVariant selects the variant of skill listed in the
+// generated skills section.
+func (c *Config) SetActiveSkillVariant(skill, variant string) {
+	if c.Skills == nil {
+		c.Skills = &SkillsYAML{}
+	}
+	if variant == SkillVariantDefault || variant == "" {
+		delete(c.Skills.Variants, skill)
+		return
+	}
+	if c.Skills.Variants == nil {
+		c.Skills.Variants = make(map[string]string)
+	}
+	c.Skills.Variants[skill] = variant
+}
+
+// SelectSkillVariants keeps one directory per skill: the variant active
+// in config, in place of the skill, when it is installed and valid.
+// Other variants, and variants of skills that are not installed, are
+// dropped.
+func SelectSkillVariants(skills []*SkillInfo, config *Config) []*SkillInfo {
+	active := make(map[string]*SkillInfo)
+	for _, s := range skills {
+		name, variant := SplitSkillVariant(s.DirName)
+		if variant != "" && variant == config.ActiveSkillVariant(name) && len(s.Errors) == 0 {
+			active[name] = s
+		}
+	}
+	var selected []*SkillInfo
+	for _, s := range skills {
+		name, variant := SplitSkillVariant(s.DirName)
+		switch {
+		case variant != "":
+			continue
+		case active[name] != nil:
+			selected = append(selected, active[name])
+		default:
+			selected = append(selected, s)
+		}
+	}
+	return selected
+}
+
+// skillTableName returns the name a skill is listed under in the skills
+// section: its directory for variants, so the agent reads the active one.
+func skillTableName(skill *SkillInfo) string {
+	if _, variant := SplitSkillVariant(skill.DirName); variant != "" {
+		return skill.DirName
+	}
+	return skill.Metadata.Name
+}
+
+// CreateSkillVariant copies the installed skill to its variant directory
+// as the starting point for rewritten guidance.
+func CreateSkillVariant(skillsDir, skill, variant string) error {
+	if errs := ValidateSkillVariant(variant); len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	src := filepath.Join(skillsDir, skill)
+	if _, err := os.Stat(filepath.Join(src, "SKILL.md")); err != nil {
+		return fmt.Errorf("skill '%s' is not installed; variants start from an installed skill", skill)
+	}
+	dest := filepath.Join(skillsDir, SkillVariantDir(skill, variant))
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("variant already exists: %s", dest)
+	}
+	if err := writeFS(os.DirFS(src), dest); err != nil {
+		return fmt.Errorf("failed to copy skill: %w", err)
+	}
+	return nil
+}
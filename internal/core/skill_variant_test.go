@@ -0,0 +1,102 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitSkillVariant(t *testing.T) {
+	tests := []struct {
+		dir, skill, variant string
+	}{
+		{"go-guide", "go-guide", ""},
+		{"go-guide@experimental", "go-guide", "experimental"},
+	}
+	for _, tt := range tests {
+		skill, variant := SplitSkillVariant(tt.dir)
+		if skill != tt.skill || variant != tt.variant {
+			t.Errorf("SplitSkillVariant(%q) = %q, %q, want %q, %q", tt.dir, skill, variant, tt.skill, tt.variant)
+		}
+	}
+	if got := SkillVariantDir("go-guide", SkillVariantDefault); got != "go-guide" {
+		t.Errorf("SkillVariantDir(default) = %q", got)
+	}
+}
+
+func TestLoadSkillInfo_Variant(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "go-guide@experimental")
+	writeTestFile(t, filepath.Join(dir, "SKILL.md"), "---\nname: go-guide\ndescription: Rewritten Go guide\n---\n")
+
+	info, err := LoadSkillInfo(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Errors) > 0 {
+		t.Errorf("variant keeping the skill name should be valid, got %v", info.Errors)
+	}
+
+	bad := filepath.Join(t.TempDir(), "go-guide@default")
+	writeTestFile(t, filepath.Join(bad, "SKILL.md"), "---\nname: go-guide\ndescription: x\n---\n")
+	if info, _ := LoadSkillInfo(bad); len(info.Errors) == 0 {
+		t.Error("the reserved default variant name should be rejected")
+	}
+}
+
+func TestSelectSkillVariants(t *testing.T) {
+	skills := []*SkillInfo{
+		{DirName: "go-guide", Metadata: SkillMetadata{Name: "go-guide", Description: "Go"}},
+		{DirName: "go-guide@experimental", Metadata: SkillMetadata{Name: "go-guide", Description: "New Go"}},
+		{DirName: "orphan@x", Metadata: SkillMetadata{Name: "orphan", Description: "x"}},
+		{DirName: "python-guide", Metadata: SkillMetadata{Name: "python-guide", Description: "Python"}},
+	}
+
+	got := SelectSkillVariants(skills, nil)
+	if len(got) != 2 || got[0].DirName != "go-guide" || got[1].DirName != "python-guide" {
+		t.Errorf("SelectSkillVariants() without config = %v", dirNames(got))
+	}
+
+	config := &Config{}
+	config.SetActiveSkillVariant("go-guide", "experimental")
+	got = SelectSkillVariants(skills, config)
+	if len(got) != 2 || got[0].DirName != "go-guide@experimental" {
+		t.Errorf("SelectSkillVariants() = %v, want the experimental variant", dirNames(got))
+	}
+	section := GenerateSkillsSection(got)
+	if !strings.Contains(section, "| go-guide@experimental | New Go |") {
+		t.Errorf("skills section should point at the active variant:\n%s", section)
+	}
+
+	config.SetActiveSkillVariant("go-guide", SkillVariantDefault)
+	if config.ActiveSkillVariant("go-guide") != SkillVariantDefault || len(config.Skills.Variants) != 0 {
+		t.Errorf("selecting the default should clear the entry, got %v", config.Skills.Variants)
+	}
+}
+
+func dirNames(skills []*SkillInfo) []string {
+	names := make([]string, len(skills))
+	for i, s := range skills {
+		names[i] = s.DirName
+	}
+	return names
+}
+
+func TestCreateSkillVariant(t *testing.T) {
+	skillsDir := t.TempDir()
+	writeTestFile(t, filepath.Join(skillsDir, "go-guide", "SKILL.md"), "---\nname: go-guide\ndescription: Go\n---\n")
+	writeTestFile(t, filepath.Join(skillsDir, "go-guide", "references", "style.md"), "# Style\n")
+
+	if err := CreateSkillVariant(skillsDir, "go-guide", "experimental"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(skillsDir, "go-guide@experimental", "references", "style.md")); err != nil {
+		t.Errorf("variant should copy the whole skill: %v", err)
+	}
+	if err := CreateSkillVariant(skillsDir, "go-guide", "experimental"); err == nil {
+		t.Error("creating an existing variant should fail")
+	}
+	if err := CreateSkillVariant(skillsDir, "rust-guide", "experimental"); err == nil {
+		t.Error("a variant of a missing skill should fail")
+	}
+}
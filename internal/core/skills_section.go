@@ -0,0 +1,121 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Markers delimiting the generated skills block in CLAUDE.md. The checksum
+// line records a hash of the generated content so later regenerations can
+// tell whether the block was edited by hand.
+const (
+	SkillsStartMarker    = "<!-- SKILLS_START -->"
+	SkillsEndMarker      = "<!-- SKILLS_END -->"
+	skillsChecksumPrefix = "<!-- SKILLS_CHECKSUM: "
+	skillsChecksumSuffix = " -->"
+)
+
+// ErrSkillsSectionModified is returned when the managed skills block has
+// been edited since it was generated and overwriting was not requested.
+var ErrSkillsSectionModified = errors.New("managed skills section was edited by hand")
+
+// skillsBlock locates the managed block within a CLAUDE.md document.
+type skillsBlock struct {
+	start    int
+	end      int
+	checksum string
+	body     string
+}
+
+// findSkillsBlock returns the managed block, or false if the markers are
+// missing or out of order.
+func findSkillsBlock(content string) (skillsBlock, bool) {
+	startIdx := strings.Index(content, SkillsStartMarker)
+	endIdx := strings.Index(content, SkillsEndMarker)
+	if startIdx == -1 || endIdx == -1 || endIdx < startIdx {
+		return skillsBlock{}, false
+	}
+
+	block := skillsBlock{start: startIdx, end: endIdx}
+	inner := strings.TrimPrefix(content[startIdx+len(SkillsStartMarker):endIdx], "\n")
+	if line, rest, found := strings.Cut(inner, "\n"); found && strings.HasPrefix(line, skillsChecksumPrefix) {
+		block.checksum = strings.TrimSuffix(strings.TrimPrefix(line, skillsChecksumPrefix), skillsChecksumSuffix)
+		inner = rest
+	}
+	block.body = inner
+	return block, true
+}
+
+// modified reports whether the body no longer matches its recorded
+// checksum. Blocks without a checksum predate verification and cannot be
+// checked, so they are treated as unmodified.
+func (b skillsBlock) modified() bool {
+	return b.checksum != "" && b.checksum != SkillsSectionChecksum(b.body)
+}
+
+// SkillsSectionChecksum returns the checksum recorded for generated
+// skills section content.
+func SkillsSectionChecksum(section string) string {
+	sum := sha256.Sum256([]byte(section))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsSkillsSectionModified reports whether the managed skills block in the
+// given CLAUDE.md was edited by hand since samuel last generated it.
+func IsSkillsSectionModified(claudeMDPath string) (bool, error) {
+	content, err := os.ReadFile(claudeMDPath)
+	if err != nil {
+		return false, fmt.Errorf("failed to read CLAUDE.md: %w", err)
+	}
+	block, ok := findSkillsBlock(string(content))
+	return ok && block.modified(), nil
+}
+
+// UpdateCLAUDEMDSkillsSection updates the skills section in CLAUDE.md.
+// It returns ErrSkillsSectionModified instead of discarding manual edits
+// made inside the managed block.
+func UpdateCLAUDEMDSkillsSection(claudeMDPath string, skills []*SkillInfo) error {
+	return updateSkillsSection(claudeMDPath, skills, false)
+}
+
+// OverwriteCLAUDEMDSkillsSection regenerates the skills section even when
+// the managed block was edited by hand.
+func OverwriteCLAUDEMDSkillsSection(claudeMDPath string, skills []*SkillInfo) error {
+	return updateSkillsSection(claudeMDPath, skills, true)
+}
+
+func updateSkillsSection(claudeMDPath string, skills []*SkillInfo, overwrite bool) error {
+	content, err := os.ReadFile(claudeMDPath)
+	if err != nil {
+		return fmt.Errorf("failed to read CLAUDE.md: %w", err)
+	}
+
+	skillsSection := generateProjectSkillsSection(filepath.Dir(claudeMDPath), skills)
+	if skillsSection == "" {
+		return nil // No skills to add
+	}
+
+	contentStr := string(content)
+	block, ok := findSkillsBlock(contentStr)
+	if !ok {
+		// Skills section doesn't exist, don't add it automatically
+		// The user can add the markers manually if they want auto-updates
+		return nil
+	}
+	if block.modified() && !overwrite {
+		return fmt.Errorf("%w: %s", ErrSkillsSectionModified, claudeMDPath)
+	}
+
+	newContent := contentStr[:block.start] +
+		SkillsStartMarker + "\n" +
+		skillsChecksumPrefix + SkillsSectionChecksum(skillsSection) + skillsChecksumSuffix + "\n" +
+		skillsSection +
+		contentStr[block.end:]
+
+	return os.WriteFile(claudeMDPath, []byte(newContent), 0644)
+}
@@ -0,0 +1,217 @@
+package core
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUpdateCLAUDEMDSkillsSection(t *testing.T) {
+	t.Run("replaces_existing_section", func(t *testing.T) {
+		dir := t.TempDir()
+		claudeMD := filepath.Join(dir, "CLAUDE.md")
+
+		original := `# Project
+
+Some content.
+
+<!-- SKILLS_START -->
+## Old Skills
+Old content.
+<!-- SKILLS_END -->
+
+More content.`
+		if err := os.WriteFile(claudeMD, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		skills := []*SkillInfo{
+			{
+				Metadata: SkillMetadata{
+					Name:        "new-skill",
+					Description: "New skill description.",
+				},
+			},
+		}
+
+		err := UpdateCLAUDEMDSkillsSection(claudeMD, skills)
+		if err != nil {
+			t.Fatalf("UpdateCLAUDEMDSkillsSection error: %v", err)
+		}
+
+		content, err := os.ReadFile(claudeMD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contentStr := string(content)
+
+		if !strings.Contains(contentStr, "new-skill") {
+			t.Error("updated content should contain new skill")
+		}
+		if strings.Contains(contentStr, "Old Skills") {
+			t.Error("old skills section should be replaced")
+		}
+		if !strings.Contains(contentStr, "More content.") {
+			t.Error("content after markers should be preserved")
+		}
+		if !strings.Contains(contentStr, "Some content.") {
+			t.Error("content before markers should be preserved")
+		}
+	})
+
+	t.Run("no_markers_does_nothing", func(t *testing.T) {
+		dir := t.TempDir()
+		claudeMD := filepath.Join(dir, "CLAUDE.md")
+
+		original := "# Project\n\nNo markers here."
+		if err := os.WriteFile(claudeMD, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		skills := []*SkillInfo{
+			{
+				Metadata: SkillMetadata{
+					Name:        "skill",
+					Description: "Desc.",
+				},
+			},
+		}
+
+		err := UpdateCLAUDEMDSkillsSection(claudeMD, skills)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		content, err := os.ReadFile(claudeMD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != original {
+			t.Error("content should remain unchanged without markers")
+		}
+	})
+
+	t.Run("empty_skills_does_nothing", func(t *testing.T) {
+		dir := t.TempDir()
+		claudeMD := filepath.Join(dir, "CLAUDE.md")
+
+		original := "<!-- SKILLS_START -->\n<!-- SKILLS_END -->"
+		if err := os.WriteFile(claudeMD, []byte(original), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		err := UpdateCLAUDEMDSkillsSection(claudeMD, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		content, err := os.ReadFile(claudeMD)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(content) != original {
+			t.Error("content should remain unchanged with empty skills")
+		}
+	})
+
+	t.Run("missing_file", func(t *testing.T) {
+		err := UpdateCLAUDEMDSkillsSection("/nonexistent/CLAUDE.md", []*SkillInfo{
+			{Metadata: SkillMetadata{Name: "x", Description: "y"}},
+		})
+		if err == nil {
+			t.Error("expected error for missing file")
+		}
+	})
+}
+
+func writeManagedCLAUDEMD(t *testing.T, skills []*SkillInfo) string {
+	t.Helper()
+	claudeMD := filepath.Join(t.TempDir(), "CLAUDE.md")
+	original := "# Project\n\n" + SkillsStartMarker + "\n" + SkillsEndMarker + "\n\nFooter.\n"
+	if err := os.WriteFile(claudeMD, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := UpdateCLAUDEMDSkillsSection(claudeMD, skills); err != nil {
+		t.Fatalf("initial generation failed: %v", err)
+	}
+	return claudeMD
+}
+
+func TestUpdateCLAUDEMDSkillsSection_RecordsChecksum(t *testing.T) {
+	skills := []*SkillInfo{{Metadata: SkillMetadata{Name: "a-skill", Description: "Does A."}}}
+	claudeMD := writeManagedCLAUDEMD(t, skills)
+
+	content, err := os.ReadFile(claudeMD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := skillsChecksumPrefix + SkillsSectionChecksum(GenerateSkillsSection(skills)) + skillsChecksumSuffix
+	if !strings.Contains(string(content), want) {
+		t.Errorf("checksum line %q missing from:\n%s", want, content)
+	}
+
+	modified, err := IsSkillsSectionModified(claudeMD)
+	if err != nil || modified {
+		t.Errorf("IsSkillsSectionModified = %v, %v; want false, nil", modified, err)
+	}
+
+	// Regenerating an untouched block is allowed.
+	more := append(skills, &SkillInfo{Metadata: SkillMetadata{Name: "b-skill", Description: "Does B."}})
+	if err := UpdateCLAUDEMDSkillsSection(claudeMD, more); err != nil {
+		t.Errorf("regeneration of unmodified block failed: %v", err)
+	}
+}
+
+func TestUpdateCLAUDEMDSkillsSection_ManualEdit(t *testing.T) {
+	skills := []*SkillInfo{{Metadata: SkillMetadata{Name: "a-skill", Description: "Does A."}}}
+	claudeMD := writeManagedCLAUDEMD(t, skills)
+
+	content, _ := os.ReadFile(claudeMD)
+	edited := strings.Replace(string(content), "Does A.", "Does A, but only on Tuesdays.", 1)
+	if err := os.WriteFile(claudeMD, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modified, err := IsSkillsSectionModified(claudeMD)
+	if err != nil || !modified {
+		t.Fatalf("IsSkillsSectionModified = %v, %v; want true, nil", modified, err)
+	}
+
+	err = UpdateCLAUDEMDSkillsSection(claudeMD, skills)
+	if !errors.Is(err, ErrSkillsSectionModified) {
+		t.Fatalf("expected ErrSkillsSectionModified, got %v", err)
+	}
+	after, _ := os.ReadFile(claudeMD)
+	if string(after) != edited {
+		t.Error("refused update must leave the file untouched")
+	}
+
+	if err := OverwriteCLAUDEMDSkillsSection(claudeMD, skills); err != nil {
+		t.Fatalf("OverwriteCLAUDEMDSkillsSection failed: %v", err)
+	}
+	after, _ = os.ReadFile(claudeMD)
+	if strings.Contains(string(after), "Tuesdays") || !strings.Contains(string(after), "Footer.") {
+		t.Errorf("overwrite should replace only the managed block, got:\n%s", after)
+	}
+	if modified, _ := IsSkillsSectionModified(claudeMD); modified {
+		t.Error("block should verify again after overwrite")
+	}
+}
+
+func TestIsSkillsSectionModified_NoChecksum(t *testing.T) {
+	claudeMD := filepath.Join(t.TempDir(), "CLAUDE.md")
+	legacy := SkillsStartMarker + "\n## Available Skills\nhand written\n" + SkillsEndMarker
+	if err := os.WriteFile(claudeMD, []byte(legacy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	modified, err := IsSkillsSectionModified(claudeMD)
+	if err != nil || modified {
+		t.Errorf("legacy block without checksum: got %v, %v; want false, nil", modified, err)
+	}
+	if _, err := IsSkillsSectionModified(filepath.Join(t.TempDir(), "missing.md")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
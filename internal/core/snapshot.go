@@ -0,0 +1,175 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DefaultSnapshotDir is where the committed generator snapshots live,
+// relative to the repository root.
+var DefaultSnapshotDir = filepath.Join("internal", "core", "testdata", "snapshots")
+
+// Snapshot is the output of one generator for the fixture inputs. Name is
+// a slash-separated path relative to the snapshot directory.
+type Snapshot struct {
+	Name    string
+	Content string
+}
+
+// snapshotRenderer renders a group of snapshots, using tmp as scratch space
+// for generators that write files.
+type snapshotRenderer func(tmp string) ([]Snapshot, error)
+
+// snapshotRenderers lists every generator covered by the snapshots.
+var snapshotRenderers = []snapshotRenderer{
+	renderPromptSnapshots,
+	renderSkillsSectionSnapshots,
+	renderSyncSnapshots,
+	renderScaffoldSnapshots,
+}
+
+// snapshotAutoConfig is the loop configuration the prompts are rendered
+// with. It enables every optional prompt section.
+var snapshotAutoConfig = AutoConfig{
+	AITool:          "claude",
+	MaxIterations:   25,
+	QualityChecks:   []string{"go test ./...", "go vet ./..."},
+	ScoringStrategy: ScoringStrategyWSJF,
+	PilotMode:       true,
+}
+
+// RenderSnapshots renders every generator from fixed fixture inputs and
+// returns the outputs sorted by name. The result depends only on the
+// generator code, so a change in a snapshot is a change in generated
+// output.
+func RenderSnapshots() ([]Snapshot, error) {
+	tmp, err := os.MkdirTemp("", "samuel-snapshot-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp directory: %w", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	var snaps []Snapshot
+	for i, render := range snapshotRenderers {
+		scratch := filepath.Join(tmp, fmt.Sprint(i))
+		if err := os.MkdirAll(scratch, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		group, err := render(scratch)
+		if err != nil {
+			return nil, err
+		}
+		snaps = append(snaps, group...)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Name < snaps[j].Name })
+	return snaps, nil
+}
+
+// renderPromptSnapshots renders the auto loop prompts.
+func renderPromptSnapshots(string) ([]Snapshot, error) {
+	pilot := &PilotConfig{DiscoverInterval: 5, MaxDiscoveryTasks: 10, Focus: "testing"}
+	return []Snapshot{
+		{Name: "auto/prompt.md", Content: GeneratePromptFile(snapshotAutoConfig)},
+		{Name: "auto/discovery-prompt.md", Content: GenerateDiscoveryPrompt(snapshotAutoConfig, pilot)},
+		{Name: "auto/wrapup-prompt.md", Content: GenerateWrapUpPrompt(snapshotAutoConfig)},
+		{Name: "auto/milestone-section.md", Content: GenerateMilestonePromptSection("v1.0")},
+	}, nil
+}
+
+// renderSkillsSectionSnapshots renders the managed skills section into a
+// minimal CLAUDE.md.
+func renderSkillsSectionSnapshots(tmp string) ([]Snapshot, error) {
+	skills := []*SkillInfo{
+		{Metadata: SkillMetadata{Name: "go-guide", Description: "Go guardrails, patterns and testing conventions."}},
+		{Metadata: SkillMetadata{Name: "api-design", Description: "REST API design. Use when adding or changing endpoints."}},
+	}
+	claudeMD := filepath.Join(tmp, "CLAUDE.md")
+	base := "# Project\n\nProject instructions.\n\n" + SkillsStartMarker + "\n" + SkillsEndMarker + "\n\n## Notes\n"
+	if err := os.WriteFile(claudeMD, []byte(base), 0644); err != nil {
+		return nil, fmt.Errorf("failed to write CLAUDE.md fixture: %w", err)
+	}
+	if err := UpdateCLAUDEMDSkillsSection(claudeMD, skills); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(claudeMD)
+	if err != nil {
+		return nil, err
+	}
+	return []Snapshot{
+		{Name: "claude-md/skills-section.md", Content: GenerateSkillsSection(skills)},
+		{Name: "claude-md/CLAUDE.md", Content: string(data)},
+	}, nil
+}
+
+// renderSyncSnapshots runs the per-folder CLAUDE.md and AGENTS.md sync on
+// a small fixture project.
+func renderSyncSnapshots(tmp string) ([]Snapshot, error) {
+	files := map[string]string{
+		"cmd/main.go":              "package main\n",
+		"internal/api/handler.go":  "package api\n",
+		"internal/api/api_test.go": "package api\n",
+		"web/src/app.ts":           "export {}\n",
+		"web/src/app.test.ts":      "export {}\n",
+	}
+	for rel, content := range files {
+		path := filepath.Join(tmp, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			return nil, err
+		}
+	}
+	result, err := SyncFolderCLAUDEMDs(SyncOptions{RootDir: tmp, MaxDepth: -1})
+	if err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return nil, fmt.Errorf("sync failed: %w", result.Errors[0])
+	}
+	return collectSnapshotFiles(tmp, "sync", func(rel string) bool {
+		name := filepath.Base(rel)
+		return name == "CLAUDE.md" || name == "AGENTS.md"
+	})
+}
+
+// renderScaffoldSnapshots renders the skill scaffold and the test fixtures
+// generated from its examples.
+func renderScaffoldSnapshots(tmp string) ([]Snapshot, error) {
+	if err := CreateSkillScaffold(tmp, "example-skill"); err != nil {
+		return nil, err
+	}
+	skillPath := filepath.Join(tmp, "example-skill")
+	if _, err := CreateSkillFixtures(skillPath, false); err != nil {
+		return nil, err
+	}
+	return collectSnapshotFiles(skillPath, "scaffold/skill", nil)
+}
+
+// collectSnapshotFiles returns the files under root accepted by keep (all
+// files when keep is nil) as snapshots named prefix/<relative path>.
+func collectSnapshotFiles(root, prefix string, keep func(rel string) bool) ([]Snapshot, error) {
+	var snaps []Snapshot
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil || (keep != nil && !keep(rel)) {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		snaps = append(snaps, Snapshot{Name: prefix + "/" + filepath.ToSlash(rel), Content: string(data)})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect %s output: %w", prefix, err)
+	}
+	return snaps, nil
+}
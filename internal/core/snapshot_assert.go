@@ -0,0 +1,67 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// UpdateSnapshotsEnv, when set to a non-empty value, makes the snapshot
+// assertions rewrite the golden files instead of comparing against them:
+//
+//	SAMUEL_UPDATE_SNAPSHOTS=1 go test ./internal/core -run Snapshot
+const UpdateSnapshotsEnv = "SAMUEL_UPDATE_SNAPSHOTS"
+
+// SnapshotTB is the part of testing.TB the snapshot assertions use, so
+// this package does not depend on the testing package.
+type SnapshotTB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+}
+
+// AssertSnapshot fails t when got differs from the golden file dir/name,
+// or writes got there when UpdateSnapshotsEnv is set.
+func AssertSnapshot(t SnapshotTB, dir, name, got string) {
+	t.Helper()
+	if os.Getenv(UpdateSnapshotsEnv) != "" {
+		if err := writeSnapshot(dir, Snapshot{Name: name, Content: got}); err != nil {
+			t.Fatalf("%v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("missing snapshot %s (run with %s=1 to create it): %v", name, UpdateSnapshotsEnv, err)
+		return
+	}
+	if string(want) != got {
+		t.Errorf("snapshot %s differs (run with %s=1 to accept):\n%s", name, UpdateSnapshotsEnv, LineDiff(string(want), got))
+	}
+}
+
+// AssertGeneratorSnapshots renders every generator and fails t for each
+// output that differs from, or is missing in, dir and for each golden
+// file nothing renders any more. With UpdateSnapshotsEnv set it rewrites
+// dir instead.
+func AssertGeneratorSnapshots(t SnapshotTB, dir string) {
+	t.Helper()
+	snaps, err := RenderSnapshots()
+	if err != nil {
+		t.Fatalf("failed to render snapshots: %v", err)
+		return
+	}
+	if os.Getenv(UpdateSnapshotsEnv) != "" {
+		if err := WriteSnapshots(dir, snaps); err != nil {
+			t.Fatalf("%v", err)
+		}
+		return
+	}
+	diffs, err := DiffSnapshots(dir, snaps)
+	if err != nil {
+		t.Fatalf("%v", err)
+		return
+	}
+	for _, d := range diffs {
+		t.Errorf("snapshot %s is %s (run with %s=1 to accept):\n%s", d.Name, d.Status, UpdateSnapshotsEnv, d.Diff)
+	}
+}
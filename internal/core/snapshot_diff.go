@@ -0,0 +1,173 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Snapshot comparison results.
+const (
+	SnapshotChanged = "changed"
+	SnapshotMissing = "missing" // rendered, but no golden file yet
+	SnapshotStale   = "stale"   // golden file no generator renders any more
+)
+
+// SnapshotDiff describes one snapshot that does not match its golden file.
+type SnapshotDiff struct {
+	Name   string
+	Status string
+	// Diff is a line diff from the golden file to the rendered output,
+	// set for changed snapshots.
+	Diff string
+}
+
+// DiffSnapshots compares snaps with the golden files in dir and returns
+// the differences sorted by name. A missing dir means every snapshot is
+// missing.
+func DiffSnapshots(dir string, snaps []Snapshot) ([]SnapshotDiff, error) {
+	golden, err := readSnapshotDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var diffs []SnapshotDiff
+	for _, snap := range snaps {
+		want, ok := golden[snap.Name]
+		delete(golden, snap.Name)
+		switch {
+		case !ok:
+			diffs = append(diffs, SnapshotDiff{Name: snap.Name, Status: SnapshotMissing})
+		case want != snap.Content:
+			diffs = append(diffs, SnapshotDiff{Name: snap.Name, Status: SnapshotChanged, Diff: LineDiff(want, snap.Content)})
+		}
+	}
+	for name := range golden {
+		diffs = append(diffs, SnapshotDiff{Name: name, Status: SnapshotStale})
+	}
+	sort.Slice(diffs, func(i, j int) bool { return diffs[i].Name < diffs[j].Name })
+	return diffs, nil
+}
+
+// WriteSnapshots replaces the golden files in dir with snaps.
+func WriteSnapshots(dir string, snaps []Snapshot) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to clear snapshots: %w", err)
+	}
+	for _, snap := range snaps {
+		if err := writeSnapshot(dir, snap); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeSnapshot writes the golden file of snap in dir.
+func writeSnapshot(dir string, snap Snapshot) error {
+	path := filepath.Join(dir, filepath.FromSlash(snap.Name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(snap.Content), 0644); err != nil {
+		return fmt.Errorf("failed to write snapshot %s: %w", snap.Name, err)
+	}
+	return nil
+}
+
+// readSnapshotDir returns the golden files in dir by snapshot name.
+func readSnapshotDir(dir string) (map[string]string, error) {
+	golden := make(map[string]string)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		golden[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read snapshots: %w", err)
+	}
+	return golden, nil
+}
+
+// LineDiff returns a line diff from want to got: removed lines start with
+// "-", added lines with "+", and up to two unchanged lines around each
+// change with " ". Hunks are headed "@@ line N @@" with the line number in
+// want.
+func LineDiff(want, got string) string {
+	a, b := strings.Split(want, "\n"), strings.Split(got, "\n")
+	ops := diffLineOps(a, b)
+
+	const context = 2
+	shown := make([]bool, len(ops))
+	for i, op := range ops {
+		if op.kind != ' ' {
+			for j := max(i-context, 0); j <= min(i+context, len(ops)-1); j++ {
+				shown[j] = true
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for i, op := range ops {
+		if !shown[i] {
+			continue
+		}
+		if i == 0 || !shown[i-1] {
+			fmt.Fprintf(&sb, "@@ line %d @@\n", op.line+1)
+		}
+		sb.WriteString(string(op.kind) + op.text + "\n")
+	}
+	return sb.String()
+}
+
+// lineOp is one line of an edit script: ' ' kept, '-' removed, '+' added.
+// line is the index in the old text of kept and removed lines, and of the
+// next old line for added ones.
+type lineOp struct {
+	kind byte
+	text string
+	line int
+}
+
+// diffLineOps computes a shortest edit script from a to b using the
+// longest common subsequence of lines.
+func diffLineOps(a, b []string) []lineOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []lineOp
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, lineOp{' ', a[i], i})
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, lineOp{'-', a[i], i})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', b[j], i})
+			j++
+		}
+	}
+	return ops
+}
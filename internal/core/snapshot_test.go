@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestGeneratorSnapshots guards the generated artifacts. After an
+// intended change to generated output, accept it with:
+//
+//	SAMUEL_UPDATE_SNAPSHOTS=1 go test ./internal/core -run TestGeneratorSnapshots
+func TestGeneratorSnapshots(t *testing.T) {
+	AssertGeneratorSnapshots(t, filepath.Join("testdata", "snapshots"))
+}
+
+func TestRenderSnapshots(t *testing.T) {
+	first, err := RenderSnapshots()
+	if err != nil {
+		t.Fatalf("RenderSnapshots() error = %v", err)
+	}
+	second, err := RenderSnapshots()
+	if err != nil {
+		t.Fatalf("RenderSnapshots() error = %v", err)
+	}
+	if !reflect.DeepEqual(first, second) {
+		t.Error("RenderSnapshots() is not deterministic")
+	}
+
+	names := make(map[string]bool)
+	for _, snap := range first {
+		if names[snap.Name] {
+			t.Errorf("duplicate snapshot %s", snap.Name)
+		}
+		names[snap.Name] = true
+	}
+	for _, want := range []string{"auto/prompt.md", "claude-md/CLAUDE.md", "sync/internal/api/AGENTS.md", "scaffold/skill/SKILL.md"} {
+		if !names[want] {
+			t.Errorf("missing snapshot %s", want)
+		}
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	golden := []Snapshot{
+		{Name: "a.md", Content: "one\ntwo\n"},
+		{Name: "dir/b.md", Content: "same\n"},
+		{Name: "old.md", Content: "gone\n"},
+	}
+	if err := WriteSnapshots(dir, golden); err != nil {
+		t.Fatal(err)
+	}
+
+	diffs, err := DiffSnapshots(dir, []Snapshot{
+		{Name: "a.md", Content: "one\n2\n"},
+		{Name: "dir/b.md", Content: "same\n"},
+		{Name: "new.md", Content: "new\n"},
+	})
+	if err != nil {
+		t.Fatalf("DiffSnapshots() error = %v", err)
+	}
+	want := []SnapshotDiff{
+		{Name: "a.md", Status: SnapshotChanged, Diff: "@@ line 1 @@\n one\n-two\n+2\n \n"},
+		{Name: "new.md", Status: SnapshotMissing},
+		{Name: "old.md", Status: SnapshotStale},
+	}
+	if !reflect.DeepEqual(diffs, want) {
+		t.Errorf("DiffSnapshots() = %+v, want %+v", diffs, want)
+	}
+
+	diffs, err = DiffSnapshots(filepath.Join(dir, "absent"), golden[:1])
+	if err != nil || len(diffs) != 1 || diffs[0].Status != SnapshotMissing {
+		t.Errorf("DiffSnapshots(absent dir) = %+v, %v, want one missing", diffs, err)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+		got  string
+		diff string
+	}{
+		{name: "equal", want: "a\nb", got: "a\nb", diff: ""},
+		{name: "added line", want: "a\nb", got: "a\nx\nb", diff: "@@ line 1 @@\n a\n+x\n b\n"},
+		{name: "removed line", want: "a\nb\nc", got: "a\nc", diff: "@@ line 1 @@\n a\n-b\n c\n"},
+		{
+			name: "separate hunks",
+			want: "1\n2\n3\n4\n5\n6\n7\n8\n9",
+			got:  "x\n2\n3\n4\n5\n6\n7\n8\ny",
+			diff: "@@ line 1 @@\n-1\n+x\n 2\n 3\n@@ line 7 @@\n 7\n 8\n-9\n+y\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LineDiff(tt.want, tt.got); got != tt.diff {
+				t.Errorf("LineDiff() = %q, want %q", got, tt.diff)
+			}
+		})
+	}
+}
+
+// recordingTB records assertion failures instead of failing the test.
+type recordingTB struct {
+	errors []string
+	fatal  bool
+}
+
+func (r *recordingTB) Helper() {}
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.Errorf(format, args...)
+	r.fatal = true
+}
+
+func TestAssertSnapshot(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "out.md"), []byte("hello\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(UpdateSnapshotsEnv, "")
+
+	tests := []struct {
+		name      string
+		snapshot  string
+		got       string
+		wantFail  bool
+		wantFatal bool
+	}{
+		{name: "match", snapshot: "out.md", got: "hello\n"},
+		{name: "differs", snapshot: "out.md", got: "bye\n", wantFail: true},
+		{name: "missing", snapshot: "none.md", got: "x", wantFail: true, wantFatal: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := &recordingTB{}
+			AssertSnapshot(rec, dir, tt.snapshot, tt.got)
+			if (len(rec.errors) > 0) != tt.wantFail || rec.fatal != tt.wantFatal {
+				t.Errorf("AssertSnapshot() errors = %v, fatal = %v", rec.errors, rec.fatal)
+			}
+		})
+	}
+
+	t.Run("update", func(t *testing.T) {
+		t.Setenv(UpdateSnapshotsEnv, "1")
+		rec := &recordingTB{}
+		AssertSnapshot(rec, dir, "sub/new.md", "fresh\n")
+		data, err := os.ReadFile(filepath.Join(dir, "sub", "new.md"))
+		if err != nil || string(data) != "fresh\n" || len(rec.errors) > 0 {
+			t.Errorf("update wrote %q, %v; errors %v", data, err, rec.errors)
+		}
+		if _, err := os.Stat(filepath.Join(dir, "out.md")); err != nil {
+			t.Errorf("update removed other snapshots: %v", err)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// sortedUnique returns list sorted and without duplicates. Generated files
+// use it for set-like lists so their order does not depend on the order in
+// which items were added.
+func sortedUnique(list []string) []string {
+	if len(list) == 0 {
+		return list
+	}
+	out := slices.Clone(list)
+	sort.Strings(out)
+	return slices.Compact(out)
+}
+
+// normalized returns a copy of the installed items with every list sorted.
+func (i InstalledItems) normalized() InstalledItems {
+	return InstalledItems{
+		Languages:  sortedUnique(i.Languages),
+		Frameworks: sortedUnique(i.Frameworks),
+		Workflows:  sortedUnique(i.Workflows),
+		Skills:     sortedUnique(i.Skills),
+	}
+}
+
+// normalizeLists sorts the set-like task lists. Task order and the order
+// of quality checks, files and acceptance criteria are meaningful and kept.
+func (p *AutoPRD) normalizeLists() {
+	for i := range p.Tasks {
+		p.Tasks[i].Labels = sortedUnique(p.Tasks[i].Labels)
+		p.Tasks[i].DependsOn = sortedUnique(p.Tasks[i].DependsOn)
+	}
+}
+
+func (p *AutoPRD) marshal() ([]byte, error) {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal prd.json: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// fileHasContent reports whether the file at path already holds content.
+func fileHasContent(path string, content []byte) bool {
+	existing, err := os.ReadFile(path)
+	return err == nil && bytes.Equal(existing, content)
+}
+
+// unchangedExcept reports whether the file at path holds content once
+// lines starting with volatilePrefix (such as a generation timestamp) are
+// ignored, so regenerating an unchanged file can leave it untouched.
+func unchangedExcept(path string, content []byte, volatilePrefix string) bool {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	strip := func(data []byte) string {
+		var kept strings.Builder
+		for _, line := range strings.SplitAfter(string(data), "\n") {
+			if !strings.HasPrefix(line, volatilePrefix) {
+				kept.WriteString(line)
+			}
+		}
+		return kept.String()
+	}
+	return strip(existing) == strip(content)
+}
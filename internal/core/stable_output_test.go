@@ -0,0 +1,160 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+// determinismCase describes one generated artifact. generate writes it into
+// dir, feeding its inputs in reverse order when reversed is set, and
+// returns the generated file. volatile masks values that legitimately
+// differ between projects (creation timestamps); regenerating in the same
+// project must not change a single byte.
+type determinismCase struct {
+	name     string
+	generate func(t *testing.T, dir string, reversed bool) string
+	volatile *regexp.Regexp
+}
+
+func inOrder(items []string, reversed bool) []string {
+	out := slices.Clone(items)
+	if reversed {
+		slices.Reverse(out)
+	}
+	return out
+}
+
+func determinismCases() []determinismCase {
+	return []determinismCase{
+		{name: "samuel.yaml", generate: func(t *testing.T, dir string, reversed bool) string {
+			cfg := NewConfig("1.0.0")
+			for _, lang := range inOrder([]string{"go", "python", "rust", "go"}, reversed) {
+				cfg.Installed.Languages = append(cfg.Installed.Languages, lang)
+			}
+			for _, skill := range inOrder([]string{"go-guide", "commit-message", "api-design"}, reversed) {
+				cfg.AddSkill(skill)
+			}
+			if err := cfg.Save(dir); err != nil {
+				t.Fatal(err)
+			}
+			return filepath.Join(dir, ConfigFileName)
+		}},
+		{name: "prd.json", volatile: regexp.MustCompile(`"(created|updated)_at": "[^"]*"`),
+			generate: func(t *testing.T, dir string, reversed bool) string {
+				path := GetAutoPRDPath(dir)
+				prd, err := LoadAutoPRD(path)
+				if err != nil {
+					prd = NewAutoPRD("demo", "")
+					prd.Tasks = []AutoTask{
+						{ID: "1", Title: "Schema", Status: TaskStatusPending},
+						{ID: "2", Title: "Migrations", Status: TaskStatusPending},
+						{ID: "3", Title: "API", Status: TaskStatusPending,
+							DependsOn: inOrder([]string{"1", "2"}, reversed),
+							Labels:    inOrder([]string{"backend", "api", "backend"}, reversed)},
+					}
+				}
+				if err := prd.Save(path); err != nil {
+					t.Fatal(err)
+				}
+				return path
+			}},
+		{name: "CLAUDE.md skills section", generate: func(t *testing.T, dir string, reversed bool) string {
+			path := filepath.Join(dir, "CLAUDE.md")
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				writeTestFile(t, path, "# Project\n\n"+SkillsStartMarker+"\n"+SkillsEndMarker+"\n")
+			}
+			var skills []*SkillInfo
+			for _, name := range inOrder([]string{"api-design", "commit-message", "go-guide"}, reversed) {
+				skills = append(skills, &SkillInfo{Metadata: SkillMetadata{Name: name, Description: "Does " + name + "."}})
+			}
+			if err := UpdateCLAUDEMDSkillsSection(path, skills); err != nil {
+				t.Fatal(err)
+			}
+			return path
+		}},
+		{name: "project stats", volatile: regexp.MustCompile(regexp.QuoteMeta(statsGeneratedPrefix) + `\S+`),
+			generate: func(t *testing.T, dir string, reversed bool) string {
+				for _, name := range inOrder([]string{"main.go", "util.go", "tool.py", "cmd/run.go"}, reversed) {
+					writeTestFile(t, filepath.Join(dir, name), "package main\n\nfunc f() {}\n")
+				}
+				path, err := WriteProjectStats(dir)
+				if err != nil {
+					t.Fatal(err)
+				}
+				return path
+			}},
+		{name: "folder CLAUDE.md", generate: func(t *testing.T, dir string, reversed bool) string {
+			for _, name := range inOrder([]string{"go.mod", "server.go", "client.go", "handler.py"}, reversed) {
+				writeTestFile(t, filepath.Join(dir, "pkg", name), "x\n")
+			}
+			if _, err := SyncFolderCLAUDEMDs(SyncOptions{RootDir: dir, MaxDepth: -1}); err != nil {
+				t.Fatal(err)
+			}
+			return filepath.Join(dir, "pkg", "CLAUDE.md")
+		}},
+	}
+}
+
+func readGenerated(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// TestGeneratedOutputIsDeterministic generates every artifact, waits for
+// the clock to move past timestamp resolution, and regenerates it: the
+// file must be byte-identical, and a project fed the same inputs in
+// reverse order must produce the same file.
+func TestGeneratedOutputIsDeterministic(t *testing.T) {
+	cases := determinismCases()
+	dirs := make([]string, len(cases))
+	first := make([]string, len(cases))
+	for i, tc := range cases {
+		dirs[i] = t.TempDir()
+		first[i] = readGenerated(t, tc.generate(t, dirs[i], false))
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	for i, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if again := readGenerated(t, tc.generate(t, dirs[i], false)); again != first[i] {
+				t.Errorf("regenerating changed the file:\n--- first\n%s\n--- again\n%s", first[i], again)
+			}
+
+			reversed := readGenerated(t, tc.generate(t, t.TempDir(), true))
+			want := first[i]
+			if tc.volatile != nil {
+				reversed = tc.volatile.ReplaceAllString(reversed, "")
+				want = tc.volatile.ReplaceAllString(want, "")
+			}
+			if reversed != want {
+				t.Errorf("input order changed the file:\n--- in order\n%s\n--- reversed\n%s", want, reversed)
+			}
+		})
+	}
+}
+
+func TestSortedUnique(t *testing.T) {
+	tests := []struct {
+		in   []string
+		want []string
+	}{
+		{nil, nil},
+		{[]string{}, []string{}},
+		{[]string{"b", "a", "b"}, []string{"a", "b"}},
+		{[]string{"a"}, []string{"a"}},
+	}
+	for _, tt := range tests {
+		if got := sortedUnique(tt.in); !slices.Equal(got, tt.want) {
+			t.Errorf("sortedUnique(%v) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
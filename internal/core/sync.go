@@ -196,6 +196,7 @@ func SyncFolderCLAUDEMDs(opts SyncOptions) (*SyncResult, error) {
 		return nil, fmt.Errorf("not a directory: %s", opts.RootDir)
 	}
 
+	ignore := LoadGitIgnore(opts.RootDir)
 	err = filepath.WalkDir(opts.RootDir, func(path string, d os.DirEntry, walkErr error) error {
 		if walkErr != nil {
 			return nil // skip inaccessible dirs
@@ -211,18 +212,19 @@ func SyncFolderCLAUDEMDs(opts SyncOptions) (*SyncResult, error) {
 
 		name := d.Name()
 
-		// Skip hidden directories and known skip dirs
-		if ShouldSkipDir(name) {
+		rel, relErr := filepath.Rel(opts.RootDir, path)
+		if relErr != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("%s: failed to compute relative path: %w", path, relErr))
+			return filepath.SkipDir
+		}
+
+		// Skip hidden directories, known skip dirs, and gitignored dirs
+		if ShouldSkipDir(name) || ignore.IgnoredDir(rel) {
 			return filepath.SkipDir
 		}
 
 		// Enforce depth limit
 		if opts.MaxDepth >= 0 {
-			rel, relErr := filepath.Rel(opts.RootDir, path)
-			if relErr != nil {
-				result.Errors = append(result.Errors, fmt.Errorf("%s: failed to compute relative path: %w", path, relErr))
-				return filepath.SkipDir
-			}
 			depth := len(strings.Split(rel, string(filepath.Separator)))
 			if depth > opts.MaxDepth {
 				return filepath.SkipDir
@@ -313,6 +315,12 @@ func AnalyzeFolder(dirPath string) (*FolderAnalysis, error) {
 		name := entry.Name()
 		ext := strings.ToLower(filepath.Ext(name))
 
+		// Skip the instruction files sync generates, so a rerun sees
+		// the same folder as the first run
+		if name == "CLAUDE.md" || name == "AGENTS.md" {
+			continue
+		}
+
 		// Count languages
 		if lang, ok := extensionLanguageMap[ext]; ok {
 			analysis.Languages[lang]++
@@ -442,6 +442,32 @@ func TestSyncFolderCLAUDEMDs_SkipHiddenDirs(t *testing.T) {
 	assertFileExists(t, filepath.Join(normal, "CLAUDE.md"))
 }
 
+func TestSyncFolderCLAUDEMDs_SkipGitIgnoredDirs(t *testing.T) {
+	root := t.TempDir()
+	generated := filepath.Join(root, "generated")
+	normal := filepath.Join(root, "src")
+	mustMkdir(t, generated)
+	mustMkdir(t, normal)
+	createFile(t, root, ".gitignore", "/generated/\n")
+	createFile(t, generated, "models.go", "package generated")
+	createFile(t, normal, "app.go", "package src")
+
+	result, err := SyncFolderCLAUDEMDs(SyncOptions{
+		RootDir:  root,
+		MaxDepth: -1,
+	})
+	if err != nil {
+		t.Fatalf("SyncFolderCLAUDEMDs() error = %v", err)
+	}
+
+	for _, f := range result.Created {
+		if strings.Contains(f, "generated") {
+			t.Errorf("should not create files in ignored dir: %s", f)
+		}
+	}
+	assertFileExists(t, filepath.Join(normal, "CLAUDE.md"))
+}
+
 func TestSyncFolderCLAUDEMDs_NestedDirs(t *testing.T) {
 	root := t.TempDir()
 	l1 := filepath.Join(root, "internal")
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// UpdateNotesDir is where 'samuel update' writes skill change summaries,
+// relative to the project root.
+const UpdateNotesDir = ".claude/.update-notes"
+
+// SkillSnapshot holds the markdown files of every installed skill: skill
+// directory name to file path (relative to the skill) to content.
+type SkillSnapshot map[string]map[string]string
+
+// GetUpdateNotesPath returns the notes file for an update to version.
+func GetUpdateNotesPath(projectDir, version string) string {
+	name := strings.NewReplacer("/", "-", `\`, "-").Replace(version)
+	return filepath.Join(projectDir, filepath.FromSlash(UpdateNotesDir), name+".md")
+}
+
+// SnapshotSkills reads the markdown files of every skill under
+// .claude/skills. Unreadable skills are left out.
+func SnapshotSkills(projectDir string) SkillSnapshot {
+	skillsDir := filepath.Join(projectDir, ".claude", "skills")
+	snapshot := SkillSnapshot{}
+	entries, err := os.ReadDir(skillsDir)
+	if err != nil {
+		return snapshot
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		if files, err := readSkillMarkdown(filepath.Join(skillsDir, entry.Name())); err == nil {
+			snapshot[entry.Name()] = files
+		}
+	}
+	return snapshot
+}
+
+func readSkillMarkdown(skillDir string) (map[string]string, error) {
+	files := map[string]string{}
+	err := filepath.WalkDir(skillDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(skillDir, path)
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	return files, err
+}
+
+// DiffSkillSnapshots returns the skills that changed between two
+// snapshots, sorted by name.
+func DiffSkillSnapshots(before, after SkillSnapshot) []SkillDiff {
+	names := sortedKeys(after)
+	for _, name := range sortedKeys(before) {
+		if _, ok := after[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var diffs []SkillDiff
+	for _, name := range names {
+		if d := DiffSkillFiles(name, before[name], after[name]); !d.Empty() {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs
+}
+
+// FormatUpdateNotes renders the skill changes of an update as markdown.
+func FormatUpdateNotes(fromVersion, toVersion string, diffs []SkillDiff, now time.Time) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Skill changes: %s → %s\n\n", fromVersion, toVersion)
+	fmt.Fprintf(&sb, "Generated by `samuel update` on %s. Review these guidance changes like a code change.\n", now.Format("2006-01-02"))
+
+	for _, d := range diffs {
+		switch {
+		case d.Added:
+			fmt.Fprintf(&sb, "\n## %s (new)\n", d.Skill)
+			continue
+		case d.Removed:
+			fmt.Fprintf(&sb, "\n## %s (removed)\n", d.Skill)
+			continue
+		}
+		fmt.Fprintf(&sb, "\n## %s\n\n", d.Skill)
+		if d.DescriptionChanged {
+			sb.WriteString("- Description changed\n")
+		}
+		writeNotesList(&sb, "Sections added", d.SectionsAdded, false)
+		writeNotesList(&sb, "Sections removed", d.SectionsRemoved, false)
+		writeNotesList(&sb, "Sections changed", d.SectionsChanged, false)
+		writeNotesList(&sb, "Guardrails added", d.GuardrailsAdded, true)
+		writeNotesList(&sb, "Guardrails removed", d.GuardrailsRemoved, true)
+		writeNotesList(&sb, "Files added", d.FilesAdded, false)
+		writeNotesList(&sb, "Files removed", d.FilesRemoved, false)
+		writeNotesList(&sb, "Files changed", d.FilesChanged, false)
+	}
+	return sb.String()
+}
+
+// writeNotesList writes a labelled list, inline or one item per line.
+func writeNotesList(sb *strings.Builder, label string, items []string, nested bool) {
+	if len(items) == 0 {
+		return
+	}
+	if !nested {
+		fmt.Fprintf(sb, "- %s: %s\n", label, strings.Join(items, ", "))
+		return
+	}
+	fmt.Fprintf(sb, "- %s:\n", label)
+	for _, item := range items {
+		fmt.Fprintf(sb, "  - %s\n", item)
+	}
+}
+
+// WriteUpdateNotes writes the notes for an update to version and returns
+// the file path.
+func WriteUpdateNotes(projectDir, version, content string) (string, error) {
+	path := GetUpdateNotesPath(projectDir, version)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create update notes directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return "", fmt.Errorf("failed to write update notes: %w", err)
+	}
+	return path, nil
+}
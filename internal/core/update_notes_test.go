@@ -0,0 +1,86 @@
+package core
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshotSkills(t *testing.T) {
+	dir := t.TempDir()
+	writeTestFile(t, filepath.Join(dir, ".claude", "skills", "go-guide", "SKILL.md"), "# Go")
+	writeTestFile(t, filepath.Join(dir, ".claude", "skills", "go-guide", "references", "patterns.md"), "patterns")
+	writeTestFile(t, filepath.Join(dir, ".claude", "skills", "go-guide", "scripts", "run.sh"), "echo")
+	writeTestFile(t, filepath.Join(dir, ".claude", "skills", "README.md"), "index")
+
+	snapshot := SnapshotSkills(dir)
+	if len(snapshot) != 1 {
+		t.Fatalf("snapshot has %d skills, want 1", len(snapshot))
+	}
+	files := snapshot["go-guide"]
+	if len(files) != 2 || files["references/patterns.md"] != "patterns" {
+		t.Errorf("go-guide files = %v, want SKILL.md and references/patterns.md", files)
+	}
+
+	if got := SnapshotSkills(t.TempDir()); len(got) != 0 {
+		t.Errorf("snapshot of project without skills = %v, want empty", got)
+	}
+}
+
+func TestDiffSkillSnapshots(t *testing.T) {
+	before := SkillSnapshot{
+		"go-guide":  {"SKILL.md": "# Go\n\n## Testing\n\nold\n"},
+		"old-skill": {"SKILL.md": "# Old"},
+		"same":      {"SKILL.md": "# Same"},
+	}
+	after := SkillSnapshot{
+		"go-guide":  {"SKILL.md": "# Go\n\n## Testing\n\nnew\n"},
+		"new-skill": {"SKILL.md": "# New"},
+		"same":      {"SKILL.md": "# Same"},
+	}
+
+	diffs := DiffSkillSnapshots(before, after)
+	var names []string
+	for _, d := range diffs {
+		names = append(names, d.Skill)
+	}
+	assertStrings(t, "skills", names, []string{"go-guide", "new-skill", "old-skill"})
+}
+
+func TestFormatUpdateNotes(t *testing.T) {
+	diffs := []SkillDiff{
+		{Skill: "go-guide", SectionsAdded: []string{"Go > Concurrency"}, GuardrailsAdded: []string{"Use context"}},
+		{Skill: "new-skill", Added: true},
+	}
+	notes := FormatUpdateNotes("1.0.0", "1.1.0", diffs, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+
+	for _, want := range []string{
+		"# Skill changes: 1.0.0 → 1.1.0",
+		"on 2026-03-01",
+		"## go-guide\n",
+		"- Sections added: Go > Concurrency",
+		"- Guardrails added:\n  - Use context",
+		"## new-skill (new)",
+	} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("notes missing %q:\n%s", want, notes)
+		}
+	}
+}
+
+func TestWriteUpdateNotes(t *testing.T) {
+	dir := t.TempDir()
+	path, err := WriteUpdateNotes(dir, "2.1.0", "# notes\n")
+	if err != nil {
+		t.Fatalf("WriteUpdateNotes: %v", err)
+	}
+	want := filepath.Join(dir, ".claude", ".update-notes", "2.1.0.md")
+	if path != want {
+		t.Errorf("path = %q, want %q", path, want)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# notes\n" {
+		t.Errorf("content = %q", data)
+	}
+}
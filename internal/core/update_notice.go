@@ -0,0 +1,93 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// UpdateCheckFile caches the last update check in the global config
+	// directory, so the network is asked at most once per interval.
+	UpdateCheckFile = "update-check.json"
+	// UpdateCheckInterval is how long a cached update check stays fresh.
+	UpdateCheckInterval = 24 * time.Hour
+	// NoUpdateCheckEnv disables update checks when set to any value.
+	NoUpdateCheckEnv = "SAMUEL_NO_UPDATE_CHECK"
+)
+
+// ciEnvVars are set by common CI systems, where an update notice is noise.
+var ciEnvVars = []string{"CI", "GITHUB_ACTIONS", "GITLAB_CI", "BUILDKITE", "CIRCLECI", "JENKINS_URL", "TF_BUILD"}
+
+// UpdateCheck is the cached result of looking up the latest version.
+type UpdateCheck struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Channel   string    `json:"channel"`
+	Latest    string    `json:"latest"`
+}
+
+// GetUpdateCheckPath returns the path of the update check cache.
+func GetUpdateCheckPath() (string, error) {
+	dir, err := GetGlobalConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, UpdateCheckFile), nil
+}
+
+// LoadUpdateCheck reads the update check cache. A missing or unreadable
+// cache returns nil, which is always due.
+func LoadUpdateCheck(path string) *UpdateCheck {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var check UpdateCheck
+	if err := json.Unmarshal(data, &check); err != nil {
+		return nil
+	}
+	return &check
+}
+
+// SaveUpdateCheck writes the update check cache.
+func SaveUpdateCheck(path string, check *UpdateCheck) error {
+	data, err := json.MarshalIndent(check, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// Due reports whether the check for channel should be repeated at now.
+func (c *UpdateCheck) Due(channel string, now time.Time) bool {
+	return c == nil || c.Channel != channel || now.Sub(c.CheckedAt) >= UpdateCheckInterval
+}
+
+// UpdateChecksDisabled reports whether the environment turns update
+// checks off: NoUpdateCheckEnv is set or the process runs in CI.
+func UpdateChecksDisabled() bool {
+	if os.Getenv(NoUpdateCheckEnv) != "" {
+		return true
+	}
+	for _, name := range ciEnvVars {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateNotice returns the one-line notice shown when latest is newer
+// than the installed version, or "" when there is nothing to report.
+// Versions that do not compare, such as "dev", produce no notice.
+func UpdateNotice(installed, latest string) string {
+	if cmp, err := CompareVersions(latest, installed); err != nil || cmp <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("Template %s is available (installed %s); run 'samuel update'", latest, installed)
+}
@@ -0,0 +1,76 @@
+package core
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUpdateCheck_Due(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		check   *UpdateCheck
+		channel string
+		want    bool
+	}{
+		{"no cache", nil, ChannelStable, true},
+		{"fresh", &UpdateCheck{CheckedAt: now.Add(-time.Hour), Channel: ChannelStable}, ChannelStable, false},
+		{"stale", &UpdateCheck{CheckedAt: now.Add(-25 * time.Hour), Channel: ChannelStable}, ChannelStable, true},
+		{"other channel", &UpdateCheck{CheckedAt: now, Channel: ChannelStable}, ChannelBeta, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.check.Due(tt.channel, now); got != tt.want {
+				t.Errorf("Due() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUpdateNotice(t *testing.T) {
+	tests := []struct {
+		installed string
+		latest    string
+		want      bool
+	}{
+		{"1.0.0", "1.1.0", true},
+		{"1.1.0", "1.1.0", false},
+		{"1.2.0", "1.1.0", false},
+		{"dev", "1.1.0", false},
+		{"1.0.0", "", false},
+	}
+	for _, tt := range tests {
+		if got := UpdateNotice(tt.installed, tt.latest); (got != "") != tt.want {
+			t.Errorf("UpdateNotice(%q, %q) = %q, want notice %v", tt.installed, tt.latest, got, tt.want)
+		}
+	}
+}
+
+func TestUpdateChecksDisabled(t *testing.T) {
+	for _, name := range append([]string{NoUpdateCheckEnv}, ciEnvVars...) {
+		t.Setenv(name, "")
+	}
+	if UpdateChecksDisabled() {
+		t.Fatal("UpdateChecksDisabled() = true with a clean environment")
+	}
+	t.Setenv("GITHUB_ACTIONS", "true")
+	if !UpdateChecksDisabled() {
+		t.Error("UpdateChecksDisabled() = false in CI")
+	}
+}
+
+func TestSaveLoadUpdateCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "samuel", UpdateCheckFile)
+	if LoadUpdateCheck(path) != nil {
+		t.Fatal("LoadUpdateCheck() of a missing file should be nil")
+	}
+	want := &UpdateCheck{CheckedAt: time.Now().UTC().Truncate(time.Second), Channel: ChannelBeta, Latest: "2.0.0"}
+	if err := SaveUpdateCheck(path, want); err != nil {
+		t.Fatal(err)
+	}
+	got := LoadUpdateCheck(path)
+	if got == nil || !got.CheckedAt.Equal(want.CheckedAt) || got.Channel != want.Channel || got.Latest != want.Latest {
+		t.Errorf("LoadUpdateCheck() = %+v, want %+v", got, want)
+	}
+}
@@ -20,6 +20,11 @@ const (
 	// Format: https://github.com/{owner}/{repo}/archive/refs/heads/{branch}.tar.gz
 	BranchArchiveURLTemplate = "https://github.com/%s/%s/archive/refs/heads/%s.tar.gz"
 
+	// RefArchiveURLTemplate is the template for downloading the archive of
+	// any ref, such as a commit SHA
+	// Format: https://github.com/{owner}/{repo}/archive/{ref}.tar.gz
+	RefArchiveURLTemplate = "https://github.com/%s/%s/archive/%s.tar.gz"
+
 	// LatestReleaseURLTemplate is the template for fetching latest release info
 	LatestReleaseURLTemplate = "https://api.github.com/repos/%s/%s/releases/latest"
 
@@ -62,6 +67,8 @@ type Release struct {
 	Body        string    `json:"body"`
 	PublishedAt time.Time `json:"published_at"`
 	TarballURL  string    `json:"tarball_url"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
 }
 
 // Tag represents a GitHub tag
@@ -111,18 +118,7 @@ func (c *Client) GetLatestVersionOrBranch() (version string, isBranch bool, err
 	if err != nil {
 		return "", false, err
 	}
-
-	if release != nil {
-		// We have a release
-		version := release.TagName
-		if len(version) > 0 && version[0] == 'v' {
-			version = version[1:]
-		}
-		return version, false, nil
-	}
-
-	// No releases - fall back to main branch
-	return DevVersion, true, nil
+	return versionOrBranch(release)
 }
 
 // GetTags fetches available tags
@@ -223,6 +219,35 @@ func (c *Client) DownloadBranchArchive(branch string) (io.ReadCloser, int64, err
 	return resp.Body, resp.ContentLength, nil
 }
 
+// DownloadRefArchive downloads the archive for a ref (branch, tag, or SHA)
+func (c *Client) DownloadRefArchive(ref string) (io.ReadCloser, int64, error) {
+	url := fmt.Sprintf(RefArchiveURLTemplate, c.owner, c.repo, ref)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	req.Header.Set("User-Agent", "samuel-cli")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to download archive: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("ref %s not found", ref)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, 0, fmt.Errorf("download failed: %s", resp.Status)
+	}
+
+	return resp.Body, resp.ContentLength, nil
+}
+
 // DownloadFile downloads a single file from the repository
 func (c *Client) DownloadFile(version, path string) ([]byte, error) {
 	// Use raw.githubusercontent.com for direct file access
@@ -549,3 +549,27 @@ func TestConstants(t *testing.T) {
 		t.Errorf("DevVersion = %q, want %q", DevVersion, "dev")
 	}
 }
+
+func TestDownloadRefArchive(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/testowner/testrepo/archive/0123abcd.tar.gz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("ref data"))
+	}))
+	defer server.Close()
+	client := newTestClient(server)
+
+	body, _, err := client.DownloadRefArchive("0123abcd")
+	if err != nil {
+		t.Fatalf("DownloadRefArchive() error = %v", err)
+	}
+	defer body.Close()
+	if data, _ := io.ReadAll(body); string(data) != "ref data" {
+		t.Errorf("body = %q, want %q", data, "ref data")
+	}
+	if _, _, err := client.DownloadRefArchive("missing"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("DownloadRefArchive(missing) error = %v, want not found", err)
+	}
+}
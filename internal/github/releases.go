@@ -0,0 +1,81 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ReleasesURLTemplate is the template for listing releases, newest first
+const ReleasesURLTemplate = "https://api.github.com/repos/%s/%s/releases"
+
+// GetReleases fetches the most recent releases, newest first
+func (c *Client) GetReleases() ([]Release, error) {
+	url := fmt.Sprintf(ReleasesURLTemplate, c.owner, c.repo)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "samuel-cli")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch releases: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GitHub API error: %s", resp.Status)
+	}
+
+	var releases []Release
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, fmt.Errorf("failed to parse releases: %w", err)
+	}
+
+	return releases, nil
+}
+
+// GetLatestPrerelease returns the newest published release, prerelease or
+// not. Returns nil without error if no releases exist
+func (c *Client) GetLatestPrerelease() (*Release, error) {
+	releases, err := c.GetReleases()
+	if err != nil {
+		return nil, err
+	}
+	for i := range releases {
+		if !releases[i].Draft {
+			return &releases[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetLatestPrereleaseVersionOrBranch is GetLatestVersionOrBranch including
+// prereleases
+func (c *Client) GetLatestPrereleaseVersionOrBranch() (version string, isBranch bool, err error) {
+	release, err := c.GetLatestPrerelease()
+	if err != nil {
+		return "", false, err
+	}
+	return versionOrBranch(release)
+}
+
+// versionOrBranch returns the release's version without its "v" prefix, or
+// the dev branch when release is nil
+func versionOrBranch(release *Release) (version string, isBranch bool, err error) {
+	if release != nil {
+		// We have a release
+		version := release.TagName
+		if len(version) > 0 && version[0] == 'v' {
+			version = version[1:]
+		}
+		return version, false, nil
+	}
+
+	// No releases - fall back to main branch
+	return DevVersion, true, nil
+}
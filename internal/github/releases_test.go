@@ -0,0 +1,77 @@
+package github
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetLatestPrereleaseVersionOrBranch(t *testing.T) {
+	tests := []struct {
+		name       string
+		handler    http.HandlerFunc
+		wantVer    string
+		wantBranch bool
+		wantErr    bool
+	}{
+		{
+			name: "newest_prerelease",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode([]Release{
+					{TagName: "v1.3.0-beta.1", Prerelease: true},
+					{TagName: "v1.2.0"},
+				})
+			},
+			wantVer: "1.3.0-beta.1",
+		},
+		{
+			name: "newest_is_stable",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode([]Release{{TagName: "v1.3.0"}, {TagName: "v1.3.0-rc.1", Prerelease: true}})
+			},
+			wantVer: "1.3.0",
+		},
+		{
+			name: "drafts_skipped",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode([]Release{{TagName: "v2.0.0", Draft: true}, {TagName: "v1.2.0"}})
+			},
+			wantVer: "1.2.0",
+		},
+		{
+			name: "no_releases_falls_back",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_ = json.NewEncoder(w).Encode([]Release{})
+			},
+			wantVer:    DevVersion,
+			wantBranch: true,
+		},
+		{
+			name: "api_error",
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+			client := newTestClient(server)
+
+			ver, isBranch, err := client.GetLatestPrereleaseVersionOrBranch()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ver != tt.wantVer {
+				t.Errorf("version = %q, want %q", ver, tt.wantVer)
+			}
+			if isBranch != tt.wantBranch {
+				t.Errorf("isBranch = %v, want %v", isBranch, tt.wantBranch)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// RepositoryURLTemplate is the template for fetching repository metadata
+const RepositoryURLTemplate = "https://api.github.com/repos/%s/%s"
+
+// CommitURLTemplate is the template for fetching a single commit by ref
+const CommitURLTemplate = "https://api.github.com/repos/%s/%s/commits/%s"
+
+// Repository is the subset of GitHub repository metadata used to identify
+// who publishes a repository. IDs survive renames and transfers, unlike
+// names.
+type Repository struct {
+	ID       int64  `json:"id"`
+	FullName string `json:"full_name"`
+	Owner    struct {
+		Login string `json:"login"`
+		ID    int64  `json:"id"`
+	} `json:"owner"`
+}
+
+// CommitVerification is GitHub's signature check for a commit. SHA is the
+// commit that was checked, so callers can fetch exactly that commit.
+type CommitVerification struct {
+	SHA       string `json:"-"`
+	Verified  bool   `json:"verified"`
+	Reason    string `json:"reason"`
+	Signature string `json:"signature"`
+}
+
+// GetRepository fetches the repository's metadata
+func (c *Client) GetRepository() (*Repository, error) {
+	var repo Repository
+	url := fmt.Sprintf(RepositoryURLTemplate, c.owner, c.repo)
+	if err := c.getJSON(url, &repo); err != nil {
+		return nil, fmt.Errorf("failed to fetch repository: %w", err)
+	}
+	return &repo, nil
+}
+
+// GetCommitVerification fetches the signature verification of the commit
+// at ref (a branch, tag, or SHA)
+func (c *Client) GetCommitVerification(ref string) (*CommitVerification, error) {
+	var commit struct {
+		SHA    string `json:"sha"`
+		Commit struct {
+			Verification CommitVerification `json:"verification"`
+		} `json:"commit"`
+	}
+	url := fmt.Sprintf(CommitURLTemplate, c.owner, c.repo, ref)
+	if err := c.getJSON(url, &commit); err != nil {
+		return nil, fmt.Errorf("failed to fetch commit %s: %w", ref, err)
+	}
+	commit.Commit.Verification.SHA = commit.SHA
+	return &commit.Commit.Verification, nil
+}
+
+// GetCommitSHA resolves ref (a branch, tag, or SHA) to a full commit SHA
+func (c *Client) GetCommitSHA(ref string) (string, error) {
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	url := fmt.Sprintf(CommitURLTemplate, c.owner, c.repo, ref)
+	if err := c.getJSON(url, &commit); err != nil {
+		return "", fmt.Errorf("failed to fetch commit %s: %w", ref, err)
+	}
+	if commit.SHA == "" {
+		return "", fmt.Errorf("commit %s has no SHA", ref)
+	}
+	return commit.SHA, nil
+}
+
+// getJSON performs an API GET request and decodes the JSON response into v
+func (c *Client) getJSON(url string, v any) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", "samuel-cli")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s/%s not found", c.owner, c.repo)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GitHub API error: %s", resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRepository(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   http.HandlerFunc
+		wantOwner string
+		wantID    int64
+		wantErr   bool
+	}{
+		{
+			name: "found",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/repos/testowner/testrepo" {
+					t.Errorf("path = %q", r.URL.Path)
+				}
+				fmt.Fprint(w, `{"id": 42, "full_name": "testowner/testrepo", "owner": {"login": "testowner", "id": 7}}`)
+			},
+			wantOwner: "testowner",
+			wantID:    42,
+		},
+		{
+			name:    "not found",
+			handler: func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNotFound) },
+			wantErr: true,
+		},
+		{
+			name:    "bad JSON",
+			handler: func(w http.ResponseWriter, r *http.Request) { fmt.Fprint(w, "{") },
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.handler)
+			defer server.Close()
+
+			repo, err := newTestClient(server).GetRepository()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if repo.ID != tt.wantID || repo.Owner.Login != tt.wantOwner || repo.Owner.ID != 7 {
+				t.Errorf("GetRepository() = %+v", repo)
+			}
+		})
+	}
+}
+
+func TestGetCommitVerification(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/testowner/testrepo/commits/main" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"sha": "abc", "commit": {"verification": {"verified": true, "reason": "valid", "signature": "sig"}}}`)
+	}))
+	defer server.Close()
+
+	v, err := newTestClient(server).GetCommitVerification("main")
+	if err != nil {
+		t.Fatalf("GetCommitVerification() error = %v", err)
+	}
+	if !v.Verified || v.Reason != "valid" || v.Signature != "sig" || v.SHA != "abc" {
+		t.Errorf("GetCommitVerification() = %+v", v)
+	}
+}
+
+func TestGetCommitSHA(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/testowner/testrepo/commits/v1.0" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		fmt.Fprint(w, `{"sha": "0123abcd", "commit": {}}`)
+	}))
+	defer server.Close()
+
+	sha, err := newTestClient(server).GetCommitSHA("v1.0")
+	if err != nil || sha != "0123abcd" {
+		t.Errorf("GetCommitSHA() = %q, %v, want 0123abcd", sha, err)
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !windows
+
+package ui
+
+import "os"
+
+// enableANSI reports whether the terminal f writes to processes escape
+// sequences, which every terminal outside Windows does.
+func enableANSI(f *os.File) bool {
+	return true
+}
+
+// consoleUnicode reports whether the terminal f writes to shows UTF-8.
+func consoleUnicode(f *os.File) bool {
+	return true
+}
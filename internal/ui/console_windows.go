@@ -0,0 +1,39 @@
+//go:build windows
+
+package ui
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// codePageUTF8 is the Windows code page identifier of UTF-8.
+const codePageUTF8 = 65001
+
+var procGetConsoleOutputCP = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetConsoleOutputCP")
+
+// enableANSI turns on escape sequence processing for the console f writes
+// to. Consoles before Windows 10 and legacy-mode ones refuse.
+func enableANSI(f *os.File) bool {
+	handle := windows.Handle(f.Fd())
+	var mode uint32
+	if err := windows.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING != 0 {
+		return true
+	}
+	mode |= windows.ENABLE_PROCESSED_OUTPUT | windows.ENABLE_VIRTUAL_TERMINAL_PROCESSING
+	return windows.SetConsoleMode(handle, mode) == nil
+}
+
+// consoleUnicode reports whether the console shows UTF-8 output; with any
+// other code page, symbols come out as mojibake.
+func consoleUnicode(f *os.File) bool {
+	if procGetConsoleOutputCP.Find() != nil {
+		return false
+	}
+	cp, _, _ := procGetConsoleOutputCP.Call()
+	return cp == codePageUTF8
+}
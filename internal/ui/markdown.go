@@ -0,0 +1,247 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*?)(\s+#+)?\s*$`)
+	mdRule      = regexp.MustCompile(`^\s*([-*_])(\s*[-*_]){2,}\s*$`)
+	mdQuote     = regexp.MustCompile(`^(\s*)>\s?(.*)$`)
+	mdListItem  = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdTableSep  = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdInline    = regexp.MustCompile("`[^`]+`|\\*\\*[^*]+\\*\\*|__[^_]+__|\\[[^\\]]+\\]\\([^)\\s]+\\)|\\*[^*\\s][^*]*\\*")
+	ansiEscapes = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+)
+
+// ruleWidth is the width of horizontal rules.
+const ruleWidth = 72
+
+// RenderMarkdown formats markdown for reading in a terminal: headings in
+// bold, bullets, quotes and rules drawn, fenced code indented behind a
+// gutter, tables aligned, and inline code, emphasis and links styled. Text
+// is styled by the stdout renderer and drawn with ASCII on terminals that
+// cannot show box characters. The markup is dropped even when colors are
+// off, so piped output reads as plain text. Lines are not wrapped; HTML is
+// left as it is.
+func RenderMarkdown(src string) string {
+	lines := strings.Split(strings.TrimRight(strings.ReplaceAll(src, "\r\n", "\n"), "\n"), "\n")
+	var sb strings.Builder
+	for i := 0; i < len(lines); {
+		switch {
+		case fenceMarker(lines[i]) != "":
+			i = renderCodeBlock(&sb, lines, i)
+		case isTableStart(lines, i):
+			i = renderTable(&sb, lines, i)
+		default:
+			sb.WriteString(renderMarkdownLine(lines[i]))
+			sb.WriteByte('\n')
+			i++
+		}
+	}
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
+
+// md styles markdown output with the stdout renderer.
+func md(style Style, text string) string {
+	return stdoutRenderer.Render(style, text)
+}
+
+// mdGlyph returns the box-drawing character box, or ascii on terminals
+// that cannot show it.
+func mdGlyph(box, ascii string) string {
+	if asciiSymbols {
+		return ascii
+	}
+	return box
+}
+
+// renderMarkdownLine renders a line outside code blocks and tables.
+func renderMarkdownLine(line string) string {
+	if m := mdHeading.FindStringSubmatch(line); m != nil {
+		return renderHeading(len(m[1]), m[2])
+	}
+	if mdRule.MatchString(line) {
+		return md(StyleDim, strings.Repeat(mdGlyph("─", "-"), ruleWidth))
+	}
+	if m := mdQuote.FindStringSubmatch(line); m != nil {
+		return m[1] + md(StyleDim, mdGlyph("│", "|")+" ") + renderInline(m[2])
+	}
+	if m := mdListItem.FindStringSubmatch(line); m != nil {
+		bullet := m[2]
+		if strings.ContainsAny(bullet, "-*+") {
+			bullet = mdGlyph("•", "*")
+		}
+		return m[1] + "  " + md(StyleInfo, bullet) + " " + renderInline(m[3])
+	}
+	return renderInline(line)
+}
+
+// renderHeading draws first and second level headings underlined in the
+// primary color and the others in bold.
+func renderHeading(level int, text string) string {
+	text = renderInline(text)
+	switch level {
+	case 1:
+		return md(StyleBold, md(StyleInfo, text)) + "\n" + md(StyleInfo, strings.Repeat(mdGlyph("═", "="), visibleWidth(text)))
+	case 2:
+		return md(StyleBold, md(StyleInfo, text)) + "\n" + md(StyleDim, strings.Repeat(mdGlyph("─", "-"), visibleWidth(text)))
+	default:
+		return md(StyleBold, text)
+	}
+}
+
+// renderInline styles code spans, bold and italic text, and links.
+// Code spans are matched first, so markup inside them is kept.
+func renderInline(s string) string {
+	return mdInline.ReplaceAllStringFunc(s, func(m string) string {
+		switch {
+		case m[0] == '`':
+			return md(StyleInfo, m[1:len(m)-1])
+		case strings.HasPrefix(m, "**"), strings.HasPrefix(m, "__"):
+			return md(StyleBold, m[2:len(m)-2])
+		case m[0] == '[':
+			text, url, _ := strings.Cut(m[1:len(m)-1], "](")
+			if url == text || strings.HasPrefix(url, "#") {
+				return md(StyleUnderline, text)
+			}
+			return md(StyleUnderline, text) + md(StyleDim, " ("+url+")")
+		default:
+			return md(StyleItalic, m[1:len(m)-1])
+		}
+	})
+}
+
+// fenceMarker returns the backticks or tildes opening a fenced code block
+// on line, or "".
+func fenceMarker(line string) string {
+	trimmed := strings.TrimSpace(line)
+	for _, c := range []string{"`", "~"} {
+		if n := len(trimmed) - len(strings.TrimLeft(trimmed, c)); n >= 3 {
+			return strings.Repeat(c, n)
+		}
+	}
+	return ""
+}
+
+// renderCodeBlock writes the fenced code block opening at lines[start]
+// behind a gutter, labeled with its language, and returns the index of
+// the line after it. An unclosed block runs to the end.
+func renderCodeBlock(sb *strings.Builder, lines []string, start int) int {
+	fence := fenceMarker(lines[start])
+	if lang := strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(lines[start]), fence[:1])); lang != "" {
+		sb.WriteString(md(StyleDim, "  "+mdGlyph("┌", "+")+" "+lang) + "\n")
+	}
+	i := start + 1
+	for ; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), fence) {
+			return i + 1
+		}
+		sb.WriteString(md(StyleDim, "  "+mdGlyph("│", "|")+" ") + lines[i] + "\n")
+	}
+	return i
+}
+
+// isTableStart reports whether a table starts at lines[i]: a row of cells
+// followed by a delimiter row.
+func isTableStart(lines []string, i int) bool {
+	return i+1 < len(lines) && strings.Contains(lines[i], "|") &&
+		strings.Contains(lines[i+1], "|") && mdTableSep.MatchString(lines[i+1])
+}
+
+// renderTable writes the table starting at lines[start] with aligned
+// columns and returns the index of the line after it.
+func renderTable(sb *strings.Builder, lines []string, start int) int {
+	aligns := tableCells(lines[start+1])
+	rows := [][]string{tableCells(lines[start])}
+	i := start + 2
+	for ; i < len(lines) && strings.Contains(lines[i], "|"); i++ {
+		rows = append(rows, tableCells(lines[i]))
+	}
+
+	widths := make([]int, len(rows[0]))
+	for _, row := range rows {
+		for c := range widths {
+			if c < len(row) {
+				row[c] = renderInline(row[c])
+				widths[c] = max(widths[c], visibleWidth(row[c]))
+			}
+		}
+	}
+	for r, row := range rows {
+		cells := make([]string, len(widths))
+		for c, w := range widths {
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			if r == 0 {
+				cell = md(StyleBold, cell)
+			}
+			cells[c] = padCell(cell, w, cellAlign(aligns, c))
+		}
+		sb.WriteString("  " + strings.TrimRight(strings.Join(cells, "  "), " ") + "\n")
+		if r == 0 {
+			sb.WriteString(md(StyleDim, "  "+tableRule(widths)) + "\n")
+		}
+	}
+	return i
+}
+
+// tableCells splits a table row into trimmed cells. "\|" is a literal pipe.
+func tableCells(row string) []string {
+	row = strings.TrimSpace(strings.ReplaceAll(row, `\|`, "\x00"))
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+	cells := strings.Split(row, "|")
+	for i, cell := range cells {
+		cells[i] = strings.ReplaceAll(strings.TrimSpace(cell), "\x00", "|")
+	}
+	return cells
+}
+
+// cellAlign returns "left", "right" or "center" for column c from the
+// cells of the delimiter row.
+func cellAlign(aligns []string, c int) string {
+	if c >= len(aligns) {
+		return "left"
+	}
+	a := aligns[c]
+	switch {
+	case strings.HasPrefix(a, ":") && strings.HasSuffix(a, ":"):
+		return "center"
+	case strings.HasSuffix(a, ":"):
+		return "right"
+	default:
+		return "left"
+	}
+}
+
+// padCell pads a possibly colored cell to width w.
+func padCell(cell string, w int, align string) string {
+	pad := w - visibleWidth(cell)
+	switch align {
+	case "right":
+		return strings.Repeat(" ", pad) + cell
+	case "center":
+		return strings.Repeat(" ", pad/2) + cell + strings.Repeat(" ", pad-pad/2)
+	default:
+		return cell + strings.Repeat(" ", pad)
+	}
+}
+
+// tableRule returns the line drawn under a table header.
+func tableRule(widths []int) string {
+	parts := make([]string, len(widths))
+	for i, w := range widths {
+		parts[i] = strings.Repeat(mdGlyph("─", "-"), w)
+	}
+	return strings.Join(parts, "  ")
+}
+
+// visibleWidth returns the number of characters s takes on screen,
+// ignoring color codes.
+func visibleWidth(s string) int {
+	return utf8.RuneCountInString(ansiEscapes.ReplaceAllString(s, ""))
+}
@@ -0,0 +1,63 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown_Plain(t *testing.T) {
+	useRenderers(t, PlainRenderer{}, PlainRenderer{})
+
+	src := "# Go Guide\n\nUse **gofmt** and `go vet`, see [docs](https://go.dev).\n\n" +
+		"## Rules\n\n- one\n  * nested\n1. first\n> quoted *note*\n\n---\n\n" +
+		"```go\nx := **not bold**\n```\n\n" +
+		"| Name | Lines |\n|------|------:|\n| a `b` | 5 |\n| longer | 120 |\n"
+	want := "Go Guide\n════════\n\n" +
+		"Use gofmt and go vet, see docs (https://go.dev).\n\n" +
+		"Rules\n─────\n\n  • one\n    • nested\n  1. first\n│ quoted note\n\n" +
+		strings.Repeat("─", ruleWidth) + "\n\n" +
+		"  ┌ go\n  │ x := **not bold**\n\n" +
+		"  Name    Lines\n  ──────  ─────\n  a b         5\n  longer    120\n"
+	if got := RenderMarkdown(src); got != want {
+		t.Errorf("RenderMarkdown() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdown_ASCII(t *testing.T) {
+	useRenderers(t, PlainRenderer{}, PlainRenderer{})
+	asciiSymbols = true
+	t.Cleanup(func() { asciiSymbols = false })
+
+	src := "# Title\n\n- item\n> quote\n\n***\n\n```sh\nls\n```\n\n| a |\n|---|\n| b |\n"
+	want := "Title\n=====\n\n  * item\n| quote\n\n" + strings.Repeat("-", ruleWidth) + "\n\n" +
+		"  + sh\n  | ls\n\n  a\n  -\n  b\n"
+	if got := RenderMarkdown(src); got != want {
+		t.Errorf("RenderMarkdown() =\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderMarkdown_UnclosedFence(t *testing.T) {
+	useRenderers(t, PlainRenderer{}, PlainRenderer{})
+
+	got := RenderMarkdown("~~~~\n# not a heading\n")
+	if got != "  │ # not a heading\n" {
+		t.Errorf("RenderMarkdown() = %q", got)
+	}
+}
+
+func TestRenderMarkdown_Colors(t *testing.T) {
+	useRenderers(t, ANSIRenderer{}, PlainRenderer{})
+
+	got := RenderMarkdown("| a | b |\n|:-:|---|\n| `x` | y |\n")
+	if !strings.Contains(got, "\x1b[") {
+		t.Errorf("expected color codes, got %q", got)
+	}
+	for _, line := range strings.Split(strings.TrimRight(got, "\n"), "\n") {
+		if w := visibleWidth(line); w != 6 {
+			t.Errorf("line %q is %d wide, want 6", line, w)
+		}
+	}
+	if got := RenderMarkdown("[top](#top) *note*\n"); !strings.Contains(got, "\x1b[4mtop") || !strings.Contains(got, "\x1b[3mnote") {
+		t.Errorf("expected underlined link and italic text, got %q", got)
+	}
+}
@@ -8,14 +8,6 @@ import (
 )
 
 var (
-	// Colors
-	successColor = color.New(color.FgGreen)
-	errorColor   = color.New(color.FgRed)
-	warnColor    = color.New(color.FgYellow)
-	infoColor    = color.New(color.FgCyan)
-	boldColor    = color.New(color.Bold)
-	dimColor     = color.New(color.Faint)
-
 	// Symbols
 	SuccessSymbol = "✓"
 	ErrorSymbol   = "✗"
@@ -28,30 +20,31 @@ var (
 // DisableColors turns off colored output
 func DisableColors() {
 	color.NoColor = true
+	stdoutRenderer, stderrRenderer = PlainRenderer{}, PlainRenderer{}
 }
 
 // Success prints a success message with green checkmark
 func Success(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	successColor.Fprintf(os.Stdout, "%s %s\n", SuccessSymbol, msg)
+	fmt.Fprintln(os.Stdout, stdoutRenderer.Render(StyleSuccess, withSymbol(SuccessSymbol, msg)))
 }
 
 // Error prints an error message with red X
 func Error(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	errorColor.Fprintf(os.Stderr, "%s %s\n", ErrorSymbol, msg)
+	fmt.Fprintln(os.Stderr, stderrRenderer.Render(StyleError, withSymbol(ErrorSymbol, msg)))
 }
 
 // Warn prints a warning message with yellow symbol
 func Warn(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	warnColor.Fprintf(os.Stdout, "%s %s\n", WarnSymbol, msg)
+	fmt.Fprintln(os.Stdout, stdoutRenderer.Render(StyleWarn, withSymbol(WarnSymbol, msg)))
 }
 
 // Info prints an info message with cyan arrow
 func Info(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	infoColor.Fprintf(os.Stdout, "%s %s\n", InfoSymbol, msg)
+	fmt.Fprintln(os.Stdout, stdoutRenderer.Render(StyleInfo, withSymbol(InfoSymbol, msg)))
 }
 
 // Print prints a plain message
@@ -62,19 +55,19 @@ func Print(format string, args ...interface{}) {
 // Bold prints bold text
 func Bold(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	boldColor.Fprintln(os.Stdout, msg)
+	fmt.Fprintln(os.Stdout, stdoutRenderer.Render(StyleBold, msg))
 }
 
 // Dim prints dimmed/faint text
 func Dim(format string, args ...interface{}) {
 	msg := fmt.Sprintf(format, args...)
-	dimColor.Fprintln(os.Stdout, msg)
+	fmt.Fprintln(os.Stdout, stdoutRenderer.Render(StyleDim, msg))
 }
 
 // Header prints a section header
 func Header(title string) {
 	fmt.Println()
-	boldColor.Println(title)
+	fmt.Println(stdoutRenderer.Render(StyleBold, title))
 	fmt.Println()
 }
 
@@ -100,7 +93,7 @@ func SuccessItem(indent int, format string, args ...interface{}) {
 		padding += "  "
 	}
 	msg := fmt.Sprintf(format, args...)
-	successColor.Fprintf(os.Stdout, "%s%s %s\n", padding, SuccessSymbol, msg)
+	fmt.Fprintln(os.Stdout, padding+stdoutRenderer.Render(StyleSuccess, withSymbol(SuccessSymbol, msg)))
 }
 
 // WarnItem prints a warning list item
@@ -110,7 +103,7 @@ func WarnItem(indent int, format string, args ...interface{}) {
 		padding += "  "
 	}
 	msg := fmt.Sprintf(format, args...)
-	warnColor.Fprintf(os.Stdout, "%s%s %s\n", padding, WarnSymbol, msg)
+	fmt.Fprintln(os.Stdout, padding+stdoutRenderer.Render(StyleWarn, withSymbol(WarnSymbol, msg)))
 }
 
 // ErrorItem prints an error list item
@@ -120,7 +113,13 @@ func ErrorItem(indent int, format string, args ...interface{}) {
 		padding += "  "
 	}
 	msg := fmt.Sprintf(format, args...)
-	errorColor.Fprintf(os.Stdout, "%s%s %s\n", padding, ErrorSymbol, msg)
+	fmt.Fprintln(os.Stdout, padding+stdoutRenderer.Render(StyleError, withSymbol(ErrorSymbol, msg)))
+}
+
+// PrintError prints a command's error to stderr as "Error: <err>", the
+// label in the error color.
+func PrintError(err error) {
+	fmt.Fprintf(os.Stderr, "%s %s\n", stderrRenderer.Render(StyleError, "Error:"), err)
 }
 
 // Table helpers for aligned output
@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// defaultPager is used when PAGER is unset. -F quits right away when the
+// content fits on one screen, -R keeps colors and -X leaves it on screen.
+const defaultPager = "less -FRX"
+
+// Page shows content through the user's pager ($PAGER, else less) when w
+// is a terminal. Otherwise, or when no pager can be run, content is
+// written to w directly.
+func Page(w io.Writer, content string) error {
+	f, ok := w.(*os.File)
+	if !ok || !term.IsTerminal(int(f.Fd())) {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+	args := strings.Fields(os.Getenv("PAGER"))
+	if len(args) == 0 {
+		args = strings.Fields(defaultPager)
+	}
+	if _, err := exec.LookPath(args[0]); err != nil {
+		_, err := io.WriteString(w, content)
+		return err
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = f
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,17 @@
+package ui
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPage_NotATerminal(t *testing.T) {
+	t.Setenv("PAGER", "false")
+	var buf bytes.Buffer
+	if err := Page(&buf, "line 1\nline 2\n"); err != nil {
+		t.Fatalf("Page() error = %v", err)
+	}
+	if buf.String() != "line 1\nline 2\n" {
+		t.Errorf("Page() wrote %q", buf.String())
+	}
+}
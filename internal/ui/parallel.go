@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// ParallelOutput serializes the output of concurrent workers so lines
+// never interleave mid-line. Each worker owns its lines: on a terminal they
+// are written as soon as they are complete, prefixed with the worker's
+// name; otherwise (pipes, CI logs) each worker's lines are held and
+// written as one block under its name when the worker finishes, so the
+// output reads as if the workers had run one after another.
+type ParallelOutput struct {
+	mu    sync.Mutex
+	w     io.Writer
+	r     Renderer
+	live  bool
+	width int
+}
+
+// NewParallelOutput writes to stdout with its renderer, live when stdout
+// is a terminal.
+func NewParallelOutput() *ParallelOutput {
+	p := NewParallelOutputTo(os.Stdout, term.IsTerminal(int(os.Stdout.Fd())))
+	p.r = stdoutRenderer
+	return p
+}
+
+// NewParallelOutputTo writes plain text to w, with prefixed live lines
+// when live is set and grouped blocks otherwise.
+func NewParallelOutputTo(w io.Writer, live bool) *ParallelOutput {
+	return &ParallelOutput{w: w, r: PlainRenderer{}, live: live}
+}
+
+// Worker returns the output of one worker. Create every worker before
+// starting them so live prefixes line up.
+func (p *ParallelOutput) Worker(name string) *Worker {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.width = max(p.width, len(name))
+	return &Worker{out: p, name: name}
+}
+
+// writeLines writes lines of w atomically with respect to other workers.
+func (p *ParallelOutput) writeLines(w *Worker, lines []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var buf bytes.Buffer
+	if p.live {
+		prefix := p.r.Render(StyleInfo, fmt.Sprintf("%-*s", p.width, w.name)) + " | "
+		for _, line := range lines {
+			buf.WriteString(prefix + line + "\n")
+		}
+	} else {
+		fmt.Fprintf(&buf, "\n%s:\n", w.name)
+		for _, line := range lines {
+			buf.WriteString("  " + line + "\n")
+		}
+	}
+	_, _ = p.w.Write(buf.Bytes())
+}
+
+// Worker is the output of one concurrent worker. It is an io.Writer, so a
+// subprocess's output can be attached to it, and it is safe for use by
+// several goroutines. Call Done when the worker finishes.
+type Worker struct {
+	out  *ParallelOutput
+	name string
+
+	mu      sync.Mutex
+	partial []byte
+	held    []string
+	done    bool
+}
+
+// Write adds p to the worker's output. Complete lines are emitted; a
+// trailing partial line waits for its newline or for Done.
+func (w *Worker) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.partial = append(w.partial, p...)
+	var lines []string
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		lines = append(lines, strings.TrimSuffix(string(w.partial[:i]), "\r"))
+		w.partial = w.partial[i+1:]
+	}
+	w.emit(lines)
+	return len(p), nil
+}
+
+// Printf writes one line.
+func (w *Worker) Printf(format string, args ...interface{}) {
+	w.line(StylePlain, "", format, args...)
+}
+
+// Success writes a line with the success symbol.
+func (w *Worker) Success(format string, args ...interface{}) {
+	w.line(StyleSuccess, SuccessSymbol, format, args...)
+}
+
+// Warn writes a line with the warning symbol.
+func (w *Worker) Warn(format string, args ...interface{}) {
+	w.line(StyleWarn, WarnSymbol, format, args...)
+}
+
+// Error writes a line with the error symbol.
+func (w *Worker) Error(format string, args ...interface{}) {
+	w.line(StyleError, ErrorSymbol, format, args...)
+}
+
+// Info writes a line with the info symbol.
+func (w *Worker) Info(format string, args ...interface{}) {
+	w.line(StyleInfo, InfoSymbol, format, args...)
+}
+
+func (w *Worker) line(style Style, symbol string, format string, args ...interface{}) {
+	msg := w.out.r.Render(style, withSymbol(symbol, fmt.Sprintf(format, args...)))
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.emit([]string{msg})
+}
+
+// emit writes lines now when live, else holds them for Done. Lines
+// written after Done are written straight away. Callers hold w.mu.
+func (w *Worker) emit(lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+	if w.out.live || w.done {
+		w.out.writeLines(w, lines)
+		return
+	}
+	w.held = append(w.held, lines...)
+}
+
+// Done flushes a trailing partial line and, for grouped output, writes the
+// worker's block. Calling it again is harmless.
+func (w *Worker) Done() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.done {
+		return
+	}
+	if len(w.partial) > 0 {
+		w.emit([]string{strings.TrimSuffix(string(w.partial), "\r")})
+		w.partial = nil
+	}
+	w.done = true
+	if len(w.held) > 0 {
+		w.out.writeLines(w, w.held)
+		w.held = nil
+	}
+}
@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// runWorkers writes lines from several workers concurrently, splitting
+// each line across two writes, and returns the combined output.
+func runWorkers(t *testing.T, live bool, workers, lines int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	out := NewParallelOutputTo(&buf, live)
+	ws := make([]*Worker, workers)
+	for i := range ws {
+		ws[i] = out.Worker(fmt.Sprintf("w%d", i))
+	}
+	var wg sync.WaitGroup
+	for i, w := range ws {
+		wg.Add(1)
+		go func(i int, w *Worker) {
+			defer wg.Done()
+			defer w.Done()
+			for j := 0; j < lines; j++ {
+				fmt.Fprintf(w, "worker %d ", i)
+				fmt.Fprintf(w, "line %d\n", j)
+			}
+			w.Success("worker %d done", i)
+		}(i, w)
+	}
+	wg.Wait()
+	return buf.String()
+}
+
+func TestParallelOutput_Live(t *testing.T) {
+	got := runWorkers(t, true, 4, 50)
+
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 4*51 {
+		t.Fatalf("got %d lines, want %d", len(lines), 4*51)
+	}
+	for _, line := range lines {
+		var prefix string
+		var worker, n int
+		if _, err := fmt.Sscanf(line, "%s | worker %d line %d", &prefix, &worker, &n); err != nil {
+			if !strings.HasSuffix(line, " done") {
+				t.Fatalf("scrambled line %q", line)
+			}
+			continue
+		}
+		if prefix != fmt.Sprintf("w%d", worker) {
+			t.Errorf("line %q has the prefix of another worker", line)
+		}
+	}
+}
+
+func TestParallelOutput_Grouped(t *testing.T) {
+	got := runWorkers(t, false, 3, 20)
+
+	for i := 0; i < 3; i++ {
+		var want strings.Builder
+		fmt.Fprintf(&want, "\nw%d:\n", i)
+		for j := 0; j < 20; j++ {
+			fmt.Fprintf(&want, "  worker %d line %d\n", i, j)
+		}
+		fmt.Fprintf(&want, "  %s worker %d done\n", SuccessSymbol, i)
+		if !strings.Contains(got, want.String()) {
+			t.Errorf("output lacks the contiguous block of w%d:\n%s", i, got)
+		}
+	}
+}
+
+func TestWorker_DoneFlushesPartialLine(t *testing.T) {
+	tests := []struct {
+		name string
+		live bool
+		want string
+	}{
+		{name: "live", live: true, want: "build | step 1\nbuild | no newline\n"},
+		{name: "grouped", live: false, want: "\nbuild:\n  step 1\n  no newline\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewParallelOutputTo(&buf, tt.live).Worker("build")
+			fmt.Fprint(w, "step 1\r\nno newline")
+			w.Done()
+			w.Done()
+			if buf.String() != tt.want {
+				t.Errorf("output = %q, want %q", buf.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"golang.org/x/term"
+)
+
+// Style is the kind of a piece of styled output.
+type Style int
+
+// Output styles. Success, Warn, Error and Info use the theme's colors.
+const (
+	StylePlain Style = iota
+	StyleSuccess
+	StyleWarn
+	StyleError
+	StyleInfo
+	StyleBold
+	StyleDim
+	StyleUnderline
+	StyleItalic
+)
+
+// Renderer styles output text for one stream. Messages go through a
+// Renderer instead of a color library, so plain output needs nothing but
+// the standard library.
+type Renderer interface {
+	Render(style Style, text string) string
+}
+
+// PlainRenderer renders text as is.
+type PlainRenderer struct{}
+
+// Render returns text unchanged.
+func (PlainRenderer) Render(_ Style, text string) string {
+	return text
+}
+
+// ANSIRenderer renders text in the theme's colors with ANSI escapes.
+type ANSIRenderer struct{}
+
+// Render wraps text in the escape codes of style.
+func (ANSIRenderer) Render(style Style, text string) string {
+	code := styleCodes[style]
+	if code == 0 || text == "" {
+		return text
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", code, text)
+}
+
+// styleCodes holds the SGR code of each style, 0 for none. ApplyTheme
+// sets the colors.
+var styleCodes = [...]int{
+	StyleSuccess:   int(color.FgGreen),
+	StyleWarn:      int(color.FgYellow),
+	StyleError:     int(color.FgRed),
+	StyleInfo:      int(color.FgCyan),
+	StyleBold:      int(color.Bold),
+	StyleDim:       int(color.Faint),
+	StyleUnderline: int(color.Underline),
+	StyleItalic:    int(color.Italic),
+}
+
+// Terminal describes what an output stream can display.
+type Terminal struct {
+	// Color is set when the stream shows ANSI color escapes.
+	Color bool
+	// Unicode is set when the stream shows non-ASCII symbols.
+	Unicode bool
+}
+
+// DetectTerminal reports what f can display. Redirected output gets
+// neither escapes nor anything but UTF-8. A terminal gets color unless
+// TERM is "dumb" or NO_COLOR is set; a Windows console is switched to ANSI
+// processing first and stays plain when it refuses, as older consoles do,
+// and gets ASCII symbols unless its code page is UTF-8.
+func DetectTerminal(f *os.File) Terminal {
+	if !term.IsTerminal(int(f.Fd())) {
+		return Terminal{Unicode: true}
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return Terminal{}
+	}
+	return Terminal{
+		Color:   os.Getenv("NO_COLOR") == "" && enableANSI(f),
+		Unicode: consoleUnicode(f),
+	}
+}
+
+// NewRenderer returns the renderer for a stream that can display t.
+func NewRenderer(t Terminal) Renderer {
+	if t.Color {
+		return ANSIRenderer{}
+	}
+	return PlainRenderer{}
+}
+
+var (
+	stdoutRenderer Renderer = PlainRenderer{}
+	stderrRenderer Renderer = PlainRenderer{}
+	// asciiSymbols replaces the icon set with ASCII symbols on terminals
+	// that cannot show the others.
+	asciiSymbols bool
+)
+
+func init() {
+	configureOutput()
+}
+
+// configureOutput picks the renderers for stdout and stderr from the
+// terminals they write to. ColoredTableRow still takes a fatih/color
+// color, so its switch follows stdout.
+func configureOutput() {
+	out, errOut := DetectTerminal(os.Stdout), DetectTerminal(os.Stderr)
+	stdoutRenderer, stderrRenderer = NewRenderer(out), NewRenderer(errOut)
+	color.NoColor = !out.Color
+	asciiSymbols = !out.Unicode
+	setSymbols(iconSet)
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// useRenderers sets the stdout and stderr renderers for a test.
+func useRenderers(t *testing.T, stdout, stderr Renderer) {
+	t.Helper()
+	origOut, origErr := stdoutRenderer, stderrRenderer
+	stdoutRenderer, stderrRenderer = stdout, stderr
+	t.Cleanup(func() { stdoutRenderer, stderrRenderer = origOut, origErr })
+}
+
+func TestANSIRenderer(t *testing.T) {
+	restoreTheme(t)
+	r := ANSIRenderer{}
+	if got := r.Render(StyleSuccess, "ok"); got != "\x1b[32mok\x1b[0m" {
+		t.Errorf("Render(success) = %q", got)
+	}
+	if got := r.Render(StylePlain, "ok"); got != "ok" {
+		t.Errorf("Render(plain) = %q", got)
+	}
+	if err := ApplyTheme(Theme{Success: "bright-green", Primary: "default"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := r.Render(StyleSuccess, "ok"); got != "\x1b[92mok\x1b[0m" {
+		t.Errorf("Render(success) with theme = %q", got)
+	}
+	if got := r.Render(StyleInfo, "ok"); got != "ok" {
+		t.Errorf("Render(info) with default color = %q", got)
+	}
+}
+
+func TestOutput_Renderers(t *testing.T) {
+	restoreTheme(t)
+	useRenderers(t, ANSIRenderer{}, PlainRenderer{})
+
+	if out := captureStdout(t, func() { WarnItem(1, "careful") }); out != "  \x1b[33m⚠ careful\x1b[0m\n" {
+		t.Errorf("WarnItem() = %q", out)
+	}
+	if out := captureStderr(t, func() { Error("failed") }); out != "✗ failed\n" {
+		t.Errorf("Error() = %q, want plain", out)
+	}
+
+	DisableColors()
+	if out := captureStdout(t, func() { Success("done") }); out != "✓ done\n" {
+		t.Errorf("Success() after DisableColors() = %q", out)
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	useRenderers(t, PlainRenderer{}, ANSIRenderer{})
+	out := captureStderr(t, func() { PrintError(errors.New("no such skill")) })
+	if out != "\x1b[31mError:\x1b[0m no such skill\n" {
+		t.Errorf("PrintError() = %q", out)
+	}
+}
+
+func TestDetectTerminal_Redirected(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	f, err := os.Create(filepath.Join(t.TempDir(), "out.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if got := DetectTerminal(f); got != (Terminal{Unicode: true}) {
+		t.Errorf("DetectTerminal(file) = %+v", got)
+	}
+	if _, ok := NewRenderer(Terminal{Unicode: true}).(PlainRenderer); !ok {
+		t.Error("NewRenderer() without color should be plain")
+	}
+	if _, ok := NewRenderer(Terminal{Color: true}).(ANSIRenderer); !ok {
+		t.Error("NewRenderer() with color should use ANSI escapes")
+	}
+}
+
+func TestApplyTheme_ASCIISymbols(t *testing.T) {
+	restoreTheme(t)
+	asciiSymbols = true
+	t.Cleanup(func() { asciiSymbols = false })
+
+	for icons, want := range map[string]string{IconsUnicode: "+", IconsEmoji: "+", IconsNone: ""} {
+		if err := ApplyTheme(Theme{Icons: icons}); err != nil {
+			t.Fatal(err)
+		}
+		if SuccessSymbol != want {
+			t.Errorf("%s icons on an ASCII terminal: SuccessSymbol = %q, want %q", icons, SuccessSymbol, want)
+		}
+	}
+}
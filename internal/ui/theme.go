@@ -0,0 +1,174 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/fatih/color"
+)
+
+// Theme presets.
+const (
+	ThemeDark  = "dark"
+	ThemeLight = "light"
+)
+
+// Icon sets for status symbols.
+const (
+	IconsUnicode = "unicode"
+	IconsASCII   = "ascii"
+	IconsEmoji   = "emoji"
+	IconsNone    = "none"
+)
+
+// Environment variables that override the configured theme.
+const (
+	EnvTheme        = "SAMUEL_THEME"
+	EnvThemePrimary = "SAMUEL_THEME_PRIMARY"
+	EnvThemeSuccess = "SAMUEL_THEME_SUCCESS"
+	EnvThemeWarn    = "SAMUEL_THEME_WARN"
+	EnvThemeError   = "SAMUEL_THEME_ERROR"
+	EnvThemeIcons   = "SAMUEL_THEME_ICONS"
+)
+
+// Theme configures output colors and status symbols. Preset picks the
+// base colors (dark suits dark terminals, light avoids yellow and cyan,
+// which are hard to read on white); the individual colors override it.
+// Empty fields keep the preset's value.
+type Theme struct {
+	Preset  string `yaml:"preset,omitempty"`
+	Primary string `yaml:"primary,omitempty"`
+	Success string `yaml:"success,omitempty"`
+	Warn    string `yaml:"warn,omitempty"`
+	Error   string `yaml:"error,omitempty"`
+	Icons   string `yaml:"icons,omitempty"`
+}
+
+var themeColors = map[string]color.Attribute{
+	"black": color.FgBlack, "red": color.FgRed, "green": color.FgGreen,
+	"yellow": color.FgYellow, "blue": color.FgBlue, "magenta": color.FgMagenta,
+	"cyan": color.FgCyan, "white": color.FgWhite,
+	"bright-black": color.FgHiBlack, "bright-red": color.FgHiRed, "bright-green": color.FgHiGreen,
+	"bright-yellow": color.FgHiYellow, "bright-blue": color.FgHiBlue, "bright-magenta": color.FgHiMagenta,
+	"bright-cyan": color.FgHiCyan, "bright-white": color.FgHiWhite,
+}
+
+var themePresets = map[string]Theme{
+	ThemeDark:  {Primary: "cyan", Success: "green", Warn: "yellow", Error: "red", Icons: IconsUnicode},
+	ThemeLight: {Primary: "blue", Success: "green", Warn: "magenta", Error: "red", Icons: IconsUnicode},
+}
+
+// iconSets lists success, error, warn, info, pending, and active symbols.
+var iconSets = map[string][6]string{
+	IconsUnicode: {"✓", "✗", "⚠", "→", "○", "●"},
+	IconsASCII:   {"+", "x", "!", ">", "o", "*"},
+	IconsEmoji:   {"✅", "❌", "⚠️", "👉", "⏳", "🔵"},
+	IconsNone:    {"", "", "", "", "", ""},
+}
+
+// GetSupportedThemeColors returns the accepted color names. "default"
+// uses the terminal's own foreground color.
+func GetSupportedThemeColors() []string {
+	names := []string{"default"}
+	for name := range themeColors {
+		names = append(names, name)
+	}
+	slices.Sort(names[1:])
+	return names
+}
+
+// GetSupportedThemePresets returns the accepted theme presets.
+func GetSupportedThemePresets() []string {
+	return []string{ThemeDark, ThemeLight}
+}
+
+// GetSupportedIconSets returns the accepted icon sets.
+func GetSupportedIconSets() []string {
+	return []string{IconsUnicode, IconsASCII, IconsEmoji, IconsNone}
+}
+
+// ThemeFromEnv returns t with any SAMUEL_THEME* environment overrides
+// applied.
+func ThemeFromEnv(t Theme) Theme {
+	for env, field := range map[string]*string{
+		EnvTheme: &t.Preset, EnvThemePrimary: &t.Primary, EnvThemeSuccess: &t.Success,
+		EnvThemeWarn: &t.Warn, EnvThemeError: &t.Error, EnvThemeIcons: &t.Icons,
+	} {
+		if v := os.Getenv(env); v != "" {
+			*field = v
+		}
+	}
+	return t
+}
+
+// resolve fills empty fields from the preset and validates the result.
+func (t Theme) resolve() (Theme, error) {
+	if t.Preset == "" {
+		t.Preset = ThemeDark
+	}
+	base, ok := themePresets[t.Preset]
+	if !ok {
+		return t, fmt.Errorf("unsupported theme preset: %s (supported: %v)", t.Preset, GetSupportedThemePresets())
+	}
+	fill := func(value *string, preset string) {
+		if *value == "" {
+			*value = preset
+		}
+	}
+	fill(&t.Primary, base.Primary)
+	fill(&t.Success, base.Success)
+	fill(&t.Warn, base.Warn)
+	fill(&t.Error, base.Error)
+	fill(&t.Icons, base.Icons)
+	for _, c := range []string{t.Primary, t.Success, t.Warn, t.Error} {
+		if _, ok := themeColors[c]; !ok && c != "default" {
+			return t, fmt.Errorf("unsupported theme color: %s (supported: %v)", c, GetSupportedThemeColors())
+		}
+	}
+	if _, ok := iconSets[t.Icons]; !ok {
+		return t, fmt.Errorf("unsupported icon set: %s (supported: %v)", t.Icons, GetSupportedIconSets())
+	}
+	return t, nil
+}
+
+// ApplyTheme switches output colors and symbols to t. It can be called at
+// any time, e.g. to pick up config changes during a long-running loop. An
+// invalid theme returns an error and leaves the current one in place.
+func ApplyTheme(t Theme) error {
+	t, err := t.resolve()
+	if err != nil {
+		return err
+	}
+	styleCodes[StyleInfo] = int(themeColors[t.Primary])
+	styleCodes[StyleSuccess] = int(themeColors[t.Success])
+	styleCodes[StyleWarn] = int(themeColors[t.Warn])
+	styleCodes[StyleError] = int(themeColors[t.Error])
+
+	iconSet = t.Icons
+	setSymbols(t.Icons)
+	return nil
+}
+
+// iconSet is the icon set of the current theme.
+var iconSet = IconsUnicode
+
+// setSymbols switches the status symbols to the named icon set, or to
+// ASCII ones on terminals that cannot show the others.
+func setSymbols(name string) {
+	if asciiSymbols && name != IconsNone {
+		name = IconsASCII
+	}
+	icons := iconSets[name]
+	SuccessSymbol, ErrorSymbol, WarnSymbol = icons[0], icons[1], icons[2]
+	InfoSymbol, PendingSymbol, ActiveSymbol = icons[3], icons[4], icons[5]
+}
+
+// withSymbol prefixes msg with symbol, or returns msg alone when the icon
+// set has no symbols.
+func withSymbol(symbol, msg string) string {
+	if symbol == "" {
+		return msg
+	}
+	return symbol + " " + msg
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+)
+
+// restoreTheme resets colors and symbols to the defaults after a test.
+func restoreTheme(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() {
+		if err := ApplyTheme(Theme{}); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+func TestApplyTheme(t *testing.T) {
+	tests := []struct {
+		name        string
+		theme       Theme
+		wantErr     bool
+		wantSuccess string
+		wantWarn    string
+	}{
+		{name: "defaults", theme: Theme{}, wantSuccess: "✓", wantWarn: "⚠"},
+		{name: "light_preset", theme: Theme{Preset: ThemeLight}, wantSuccess: "✓", wantWarn: "⚠"},
+		{name: "ascii_icons", theme: Theme{Icons: IconsASCII, Error: "bright-red"}, wantSuccess: "+", wantWarn: "!"},
+		{name: "no_icons", theme: Theme{Icons: IconsNone, Primary: "default"}, wantSuccess: "", wantWarn: ""},
+		{name: "unknown_preset", theme: Theme{Preset: "solarized"}, wantErr: true},
+		{name: "unknown_color", theme: Theme{Warn: "orange"}, wantErr: true},
+		{name: "unknown_icons", theme: Theme{Icons: "nerdfont"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restoreTheme(t)
+			if err := ApplyTheme(Theme{Icons: IconsASCII}); err != nil {
+				t.Fatal(err)
+			}
+			err := ApplyTheme(tt.theme)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				if SuccessSymbol != "+" {
+					t.Errorf("invalid theme changed symbols to %q", SuccessSymbol)
+				}
+				return
+			}
+			if SuccessSymbol != tt.wantSuccess || WarnSymbol != tt.wantWarn {
+				t.Errorf("symbols = %q %q, want %q %q", SuccessSymbol, WarnSymbol, tt.wantSuccess, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestThemeFromEnv(t *testing.T) {
+	t.Setenv(EnvTheme, ThemeLight)
+	t.Setenv(EnvThemeIcons, IconsNone)
+	got := ThemeFromEnv(Theme{Preset: ThemeDark, Success: "cyan", Icons: IconsEmoji})
+	want := Theme{Preset: ThemeLight, Success: "cyan", Icons: IconsNone}
+	if got != want {
+		t.Errorf("ThemeFromEnv() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSuccess_NoIcons(t *testing.T) {
+	orig := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = orig }()
+	restoreTheme(t)
+	if err := ApplyTheme(Theme{Icons: IconsNone}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() { Success("done") })
+	if out != "done\n" {
+		t.Errorf("Success() = %q, want %q", out, "done\n")
+	}
+	out = captureStdout(t, func() { WarnItem(1, "careful") })
+	if !strings.HasPrefix(out, "  careful") {
+		t.Errorf("WarnItem() = %q, want no symbol", out)
+	}
+}
@@ -0,0 +1,12 @@
+// Package parse holds Samuel's text parsers: SKILL.md frontmatter and its
+// validators, the generate-tasks markdown format, PRD titles and the
+// CLAUDE.md version marker.
+//
+// It is the stable, importable surface of those formats. Every parser
+// accepts arbitrary input: content is normalized first (UTF-8 byte order
+// mark removed, CRLF and CR line endings turned into LF), and malformed
+// input yields an error or an empty result rather than a panic. The fuzz
+// targets in this package exercise that contract; run them with
+//
+//	go test ./pkg/parse -fuzz FuzzSkillMD
+package parse
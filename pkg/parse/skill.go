@@ -0,0 +1,174 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Skill validation limits per the Agent Skills specification.
+const (
+	MaxSkillNameLength     = 64
+	MaxDescriptionLength   = 1024
+	MaxCompatibilityLength = 500
+)
+
+// MaxFrontmatterBytes caps the YAML frontmatter of a SKILL.md. Real
+// frontmatter is a few hundred bytes; the cap keeps a malformed file from
+// being handed to the YAML decoder whole.
+const MaxFrontmatterBytes = 64 << 10
+
+// Frontmatter errors returned by SkillMD.
+var (
+	ErrNoFrontmatter        = errors.New("SKILL.md must start with YAML frontmatter (---)")
+	ErrFrontmatterNotClosed = errors.New("SKILL.md frontmatter not closed (missing ---)")
+	ErrFrontmatterTooLarge  = fmt.Errorf("SKILL.md frontmatter exceeds %d bytes", MaxFrontmatterBytes)
+)
+
+// SkillMetadata represents SKILL.md frontmatter per Agent Skills spec
+type SkillMetadata struct {
+	Name          string            `yaml:"name"`
+	Description   string            `yaml:"description"`
+	License       string            `yaml:"license,omitempty"`
+	Compatibility string            `yaml:"compatibility,omitempty"`
+	AllowedTools  string            `yaml:"allowed-tools,omitempty"`
+	Metadata      map[string]string `yaml:"metadata,omitempty"`
+}
+
+// SkillMD parses SKILL.md content into its frontmatter and trimmed body.
+func SkillMD(content string) (*SkillMetadata, string, error) {
+	content, err := Normalize(content)
+	if err != nil {
+		return nil, "", fmt.Errorf("SKILL.md: %w", err)
+	}
+
+	first, rest, _ := strings.Cut(content, "\n")
+	if strings.TrimSpace(first) != "---" {
+		return nil, "", ErrNoFrontmatter
+	}
+
+	frontmatter, body, err := splitFrontmatter(rest)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var meta SkillMetadata
+	if err := yaml.Unmarshal([]byte(frontmatter), &meta); err != nil {
+		return nil, "", fmt.Errorf("invalid YAML frontmatter: %w", err)
+	}
+	return &meta, strings.TrimSpace(body), nil
+}
+
+// splitFrontmatter finds the closing delimiter line in the content after
+// the opening one, without scanning past MaxFrontmatterBytes.
+func splitFrontmatter(rest string) (string, string, error) {
+	offset := 0
+	for offset <= len(rest) {
+		if offset > MaxFrontmatterBytes {
+			return "", "", ErrFrontmatterTooLarge
+		}
+		line, _, found := strings.Cut(rest[offset:], "\n")
+		if strings.TrimSpace(line) == "---" {
+			body := ""
+			if end := offset + len(line) + 1; end < len(rest) {
+				body = rest[end:]
+			}
+			return rest[:max(offset-1, 0)], body, nil
+		}
+		if !found {
+			break
+		}
+		offset += len(line) + 1
+	}
+	return "", "", ErrFrontmatterNotClosed
+}
+
+// ValidateSkillName checks name format per Agent Skills specification
+func ValidateSkillName(name string) []string {
+	var errors []string
+
+	if name == "" {
+		errors = append(errors, "name is required")
+		return errors
+	}
+
+	if len(name) > MaxSkillNameLength {
+		errors = append(errors, fmt.Sprintf("name exceeds %d character limit (%d chars)", MaxSkillNameLength, len(name)))
+	}
+
+	// Check for uppercase characters
+	if name != strings.ToLower(name) {
+		errors = append(errors, "name must be lowercase")
+	}
+
+	// Check for valid characters
+	for _, r := range name {
+		if !unicode.IsLower(r) && !unicode.IsDigit(r) && r != '-' {
+			errors = append(errors, "name may only contain lowercase letters, digits, and hyphens")
+			break
+		}
+	}
+
+	// Check start/end with hyphen
+	if strings.HasPrefix(name, "-") || strings.HasSuffix(name, "-") {
+		errors = append(errors, "name cannot start or end with a hyphen")
+	}
+
+	// Check for consecutive hyphens
+	if strings.Contains(name, "--") {
+		errors = append(errors, "name cannot contain consecutive hyphens")
+	}
+
+	return errors
+}
+
+// ValidateSkillDescription checks description per Agent Skills specification
+func ValidateSkillDescription(description string) []string {
+	var errors []string
+
+	if strings.TrimSpace(description) == "" {
+		errors = append(errors, "description is required")
+		return errors
+	}
+
+	if len(description) > MaxDescriptionLength {
+		errors = append(errors, fmt.Sprintf("description exceeds %d character limit (%d chars)", MaxDescriptionLength, len(description)))
+	}
+
+	return errors
+}
+
+// ValidateSkillCompatibility checks compatibility field per Agent Skills specification
+func ValidateSkillCompatibility(compatibility string) []string {
+	var errors []string
+
+	if compatibility != "" && len(compatibility) > MaxCompatibilityLength {
+		errors = append(errors, fmt.Sprintf("compatibility exceeds %d character limit (%d chars)", MaxCompatibilityLength, len(compatibility)))
+	}
+
+	return errors
+}
+
+// ValidateSkillMetadata validates the complete frontmatter
+func ValidateSkillMetadata(meta SkillMetadata, dirName string) []string {
+	var errors []string
+
+	// Validate name
+	errors = append(errors, ValidateSkillName(meta.Name)...)
+
+	// Check name matches directory
+	if meta.Name != "" && dirName != "" && meta.Name != dirName {
+		errors = append(errors, fmt.Sprintf("skill name '%s' must match directory name '%s'", meta.Name, dirName))
+	}
+
+	// Validate description
+	errors = append(errors, ValidateSkillDescription(meta.Description)...)
+
+	// Validate compatibility (optional)
+	errors = append(errors, ValidateSkillCompatibility(meta.Compatibility)...)
+
+	return errors
+}
@@ -0,0 +1,116 @@
+package parse
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSkillMD_Malformed(t *testing.T) {
+	huge := "---\nname: big\ndescription: |\n" + strings.Repeat("  filler line\n", MaxFrontmatterBytes/10) + "---\n"
+
+	tests := []struct {
+		name     string
+		content  string
+		wantName string
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name:     "utf-8 BOM",
+			content:  "\ufeff---\nname: bom\ndescription: x\n---\nBody",
+			wantName: "bom",
+			wantBody: "Body",
+		},
+		{
+			name:     "CRLF line endings",
+			content:  "---\r\nname: crlf\r\ndescription: x\r\n---\r\n\r\nLine one\r\nLine two\r\n",
+			wantName: "crlf",
+			wantBody: "Line one\nLine two",
+		},
+		{
+			name:     "closing delimiter on the last line",
+			content:  "---\nname: last\ndescription: x\n---",
+			wantName: "last",
+		},
+		{name: "only the opening delimiter", content: "---", wantErr: ErrFrontmatterNotClosed},
+		{name: "huge frontmatter", content: huge, wantErr: ErrFrontmatterTooLarge},
+		{name: "utf-16", content: "\xff\xfe-\x00-\x00-\x00", wantErr: ErrEncoding},
+		{name: "invalid utf-8", content: "---\nname: \xc3\x28\n---\n", wantErr: ErrEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, body, err := SkillMD(tt.content)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("SkillMD() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SkillMD() error = %v", err)
+			}
+			if meta.Name != tt.wantName || body != tt.wantBody {
+				t.Errorf("SkillMD() = %q, %q; want %q, %q", meta.Name, body, tt.wantName, tt.wantBody)
+			}
+		})
+	}
+}
+
+func FuzzSkillMD(f *testing.F) {
+	for _, seed := range []string{
+		"---\nname: my-skill\ndescription: A skill.\n---\n\n# Body",
+		"---\r\nname: crlf\r\n---\r\n",
+		"\ufeff---\nname: bom\n---",
+		"---\nmetadata:\n  a: [1, 2\n---",
+		"---\n---",
+		"---",
+		"",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, content string) {
+		meta, body, err := SkillMD(content)
+		if err != nil {
+			return
+		}
+		if meta == nil {
+			t.Fatal("nil metadata without an error")
+		}
+		if strings.Contains(body, "\r") {
+			t.Errorf("body %q keeps a carriage return", body)
+		}
+		// Validation must accept whatever the parser produced.
+		ValidateSkillMetadata(*meta, "skill")
+	})
+}
+
+// specSkillName is the strictest name ValidateSkillName accepts.
+var specSkillName = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+func FuzzValidateSkillMetadata(f *testing.F) {
+	f.Add("my-skill", "Does one thing well.", "", "my-skill")
+	f.Add("My_Skill", "", "", "my-skill")
+	f.Add("-bad--name-", "   ", strings.Repeat("c", MaxCompatibilityLength+1), "")
+	f.Add(strings.Repeat("a", MaxSkillNameLength+1), strings.Repeat("d", MaxDescriptionLength+1), "go>=1.21", "other")
+	f.Add("", "", "", "")
+	f.Add("ünïcode", "\x00", "\xff", "ünïcode")
+	f.Fuzz(func(t *testing.T, name, description, compatibility, dirName string) {
+		meta := SkillMetadata{Name: name, Description: description, Compatibility: compatibility}
+		errs := ValidateSkillMetadata(meta, dirName)
+
+		if name == "" && !slices.Contains(errs, "name is required") {
+			t.Errorf("empty name accepted: %v", errs)
+		}
+		valid := specSkillName.MatchString(name) && len(name) <= MaxSkillNameLength &&
+			(dirName == "" || dirName == name) &&
+			strings.TrimSpace(description) != "" && len(description) <= MaxDescriptionLength &&
+			len(compatibility) <= MaxCompatibilityLength
+		if valid && len(errs) > 0 {
+			t.Errorf("valid metadata %+v in %q rejected: %v", meta, dirName, errs)
+		}
+	})
+}
@@ -0,0 +1,135 @@
+package parse
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrNoTasks is returned for task markdown without a single task line.
+var ErrNoTasks = errors.New("no valid tasks found in markdown")
+
+// taskLineRegex parses task lines from the generate-tasks skill output format:
+//
+//	"- [ ] 1.0 Task title [~3,000 tokens - Medium]"
+//
+// Groups: (1) indentation, (2) checkbox, (3) task ID, (4) title, (5) complexity
+var taskLineRegex = regexp.MustCompile(
+	`^(\s*)- \[([ xX])\]\s*(\d+\.\d+)\s+(.+?)(?:\s*\[~[\d,]+\s+tokens?\s*-\s*(\w+)\])?\s*$`,
+)
+
+// prdTitleRegex extracts the title from a PRD markdown H1 heading
+var prdTitleRegex = regexp.MustCompile(`^#\s+(.+)$`)
+
+// Task is one line of a generate-tasks markdown list.
+type Task struct {
+	ID    string
+	Title string
+	Done  bool
+	// Complexity is the lowercased annotation, such as "simple"; empty
+	// when the line has none.
+	Complexity string
+	// ParentID is the ID of the closest unindented task above an
+	// indented one.
+	ParentID string
+	// Details are the non-task lines indented under the task, trimmed,
+	// such as a description or acceptance criteria.
+	Details []string
+}
+
+// TaskLine parses a single task line. It reports false for any other
+// line, including a task without a title.
+func TaskLine(line string) (Task, bool) {
+	matches := taskLineRegex.FindStringSubmatch(line)
+	if matches == nil || strings.TrimSpace(matches[4]) == "" {
+		return Task{}, false
+	}
+	return Task{
+		ID:         matches[3],
+		Title:      strings.TrimSpace(matches[4]),
+		Done:       matches[2] == "x" || matches[2] == "X",
+		Complexity: strings.ToLower(strings.TrimSpace(matches[5])),
+	}, true
+}
+
+// TaskMarkdown parses the output of Samuel's generate-tasks skill:
+//
+//   - [ ] 1.0 Parent Task Title
+//   - [ ] 1.1 Sub-task description [~2,000 tokens - Simple]
+//
+// Other lines indented deeper than the task above them become its
+// Details; any other line is skipped and ends the task's details.
+func TaskMarkdown(content string) ([]Task, error) {
+	content, err := Normalize(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var tasks []Task
+	var currentParentID string
+	taskIndent := -1
+	for _, line := range strings.Split(content, "\n") {
+		task, ok := TaskLine(line)
+		if !ok {
+			trimmed := strings.TrimSpace(line)
+			switch {
+			case trimmed == "":
+			case taskIndent >= 0 && indentWidth(line) > taskIndent:
+				tasks[len(tasks)-1].Details = append(tasks[len(tasks)-1].Details, trimmed)
+			default:
+				taskIndent = -1
+			}
+			continue
+		}
+		taskIndent = indentWidth(line)
+		// Determine parent-child relationship from indentation
+		if isChildTask(line) {
+			task.ParentID = currentParentID
+		} else {
+			currentParentID = task.ID
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(tasks) == 0 {
+		return nil, ErrNoTasks
+	}
+	return tasks, nil
+}
+
+// indentWidth returns the width of line's leading whitespace, counting a
+// tab as four spaces.
+func indentWidth(line string) int {
+	width := 0
+	for _, r := range line {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// isChildTask checks if a task line is indented (child task)
+func isChildTask(line string) bool {
+	return len(line) > 0 && (line[0] == ' ' || line[0] == '\t')
+}
+
+// PRDTitle returns the text of the first H1 heading in PRD markdown, or
+// an empty string when there is none.
+func PRDTitle(content string) string {
+	content, err := Normalize(content)
+	if err != nil {
+		return ""
+	}
+	for _, line := range strings.Split(content, "\n") {
+		if matches := prdTitleRegex.FindStringSubmatch(strings.TrimSpace(line)); matches != nil {
+			return matches[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,112 @@
+package parse
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestTaskMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Task
+		wantErr error
+	}{
+		{
+			name:    "parent and child",
+			content: "- [ ] 1.0 Parent\n  - [x] 1.1 Child [~2,000 tokens - Simple]\n",
+			want: []Task{
+				{ID: "1.0", Title: "Parent"},
+				{ID: "1.1", Title: "Child", Done: true, Complexity: "simple", ParentID: "1.0"},
+			},
+		},
+		{
+			name:    "BOM and CRLF",
+			content: "\ufeff- [ ] 1.0 First\r\n\t- [ ] 1.1 Second\r\n",
+			want: []Task{
+				{ID: "1.0", Title: "First"},
+				{ID: "1.1", Title: "Second", ParentID: "1.0"},
+			},
+		},
+		{
+			name: "details",
+			content: "# Tasks\n\n- [ ] 1.0 Parent\n  Build the login form.\n  - [ ] 1.1 Child\n    - Acceptance: form submits\n\n    - Errors are shown\n" +
+				"  - [ ] 1.2 Plain\n## Relevant Files\n  - app.go\n",
+			want: []Task{
+				{ID: "1.0", Title: "Parent", Details: []string{"Build the login form."}},
+				{ID: "1.1", Title: "Child", ParentID: "1.0", Details: []string{"- Acceptance: form submits", "- Errors are shown"}},
+				{ID: "1.2", Title: "Plain", ParentID: "1.0"},
+			},
+		},
+		{name: "no tasks", content: "# Tasks\n\nNothing yet.", wantErr: ErrNoTasks},
+		{name: "invalid utf-8", content: "- [ ] 1.0 \xff", wantErr: ErrEncoding},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TaskMarkdown(tt.content)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TaskMarkdown() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("TaskMarkdown() = %+v, want %+v", got, tt.want)
+			}
+			for i := range got {
+				if !reflect.DeepEqual(got[i], tt.want[i]) {
+					t.Errorf("task %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPRDTitle(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"# User Auth\n\nBody", "User Auth"},
+		{"\ufeff# With BOM\r\n", "With BOM"},
+		{"## Not a title", ""},
+		{"\xfe\xff\x00#", ""},
+	}
+	for _, tt := range tests {
+		if got := PRDTitle(tt.content); got != tt.want {
+			t.Errorf("PRDTitle(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func FuzzTaskMarkdown(f *testing.F) {
+	for _, seed := range []string{
+		"- [ ] 1.0 Parent\n  - [ ] 1.1 Child [~2,000 tokens - Simple]",
+		"- [X] 2.3 Done\r\n",
+		"\ufeff- [ ] 1.0 Task",
+		"  - [ ] 9.9 Orphan child",
+		"",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, content string) {
+		tasks, err := TaskMarkdown(content)
+		if err != nil {
+			if len(tasks) != 0 {
+				t.Errorf("tasks %+v returned with error %v", tasks, err)
+			}
+			return
+		}
+		ids := map[string]bool{}
+		for _, task := range tasks {
+			if task.ID == "" || task.Title == "" {
+				t.Errorf("incomplete task %+v", task)
+			}
+			if task.ParentID != "" && !ids[task.ParentID] {
+				t.Errorf("task %s has parent %s that was not parsed before it", task.ID, task.ParentID)
+			}
+			ids[task.ID] = true
+		}
+		PRDTitle(content)
+		CLAUDEMDVersion(content)
+	})
+}
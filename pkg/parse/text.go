@@ -0,0 +1,36 @@
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrEncoding is returned for content that is not UTF-8.
+var ErrEncoding = errors.New("content is not valid UTF-8")
+
+const (
+	utf8BOM    = "\ufeff"
+	utf16LEBOM = "\xff\xfe"
+	utf16BEBOM = "\xfe\xff"
+)
+
+// Normalize prepares text for parsing: it drops a leading UTF-8 byte
+// order mark and converts CRLF and lone CR line endings to LF. UTF-16
+// content (recognized by its byte order mark) and invalid UTF-8 are
+// reported as ErrEncoding.
+func Normalize(content string) (string, error) {
+	if strings.HasPrefix(content, utf16LEBOM) || strings.HasPrefix(content, utf16BEBOM) {
+		return "", fmt.Errorf("%w: file is UTF-16 encoded, save it as UTF-8", ErrEncoding)
+	}
+	content = strings.TrimPrefix(content, utf8BOM)
+	if !utf8.ValidString(content) {
+		return "", ErrEncoding
+	}
+	if strings.Contains(content, "\r") {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+		content = strings.ReplaceAll(content, "\r", "\n")
+	}
+	return content, nil
+}
@@ -0,0 +1,21 @@
+package parse
+
+import "regexp"
+
+// claudeMDVersionRegexes match the version marker of CLAUDE.md, bold
+// form first.
+var claudeMDVersionRegexes = []*regexp.Regexp{
+	regexp.MustCompile(`\*\*Current Version\*\*:\s*(\d+\.\d+\.\d+)`),
+	regexp.MustCompile(`Current Version:\s*(\d+\.\d+\.\d+)`),
+}
+
+// CLAUDEMDVersion returns the framework version recorded in CLAUDE.md
+// content, or an empty string when it has none.
+func CLAUDEMDVersion(content string) string {
+	for _, re := range claudeMDVersionRegexes {
+		if matches := re.FindStringSubmatch(content); len(matches) > 1 {
+			return matches[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,84 @@
+package parse
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestCLAUDEMDVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "bold_version",
+			content: "**Current Version**: 1.2.3",
+			want:    "1.2.3",
+		},
+		{
+			name:    "plain_version",
+			content: "Current Version: 4.5.6",
+			want:    "4.5.6",
+		},
+		{
+			name:    "no_version",
+			content: "# Some content\nNo version here",
+			want:    "",
+		},
+		{
+			name:    "empty_content",
+			content: "",
+			want:    "",
+		},
+		{
+			name:    "version_in_multiline",
+			content: "# Header\n\n**Current Version**: 10.20.30\n\nOther stuff",
+			want:    "10.20.30",
+		},
+		{
+			name:    "bold_preferred_over_plain",
+			content: "**Current Version**: 1.0.0\nCurrent Version: 2.0.0",
+			want:    "1.0.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := CLAUDEMDVersion(tt.content)
+			if got != tt.want {
+				t.Errorf("CLAUDEMDVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+var semverOnly = regexp.MustCompile(`^\d+\.\d+\.\d+$`)
+
+func FuzzCLAUDEMDVersion(f *testing.F) {
+	for _, seed := range []string{
+		"**Current Version**: 1.2.3",
+		"Current Version: 4.5.6",
+		"# Header\r\n\r\n**Current Version**:\t10.20.30\r\n",
+		"**Current Version**: 1.0.0\nCurrent Version: 2.0.0",
+		"Current Version: 1.2",
+		"**Current Version**: v1.2.3",
+		"\ufeffCurrent Version: 0.0.0-beta.1",
+		"",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, content string) {
+		version := CLAUDEMDVersion(content)
+		if version == "" {
+			return
+		}
+		if !semverOnly.MatchString(version) {
+			t.Errorf("version %q is not MAJOR.MINOR.PATCH", version)
+		}
+		if !strings.Contains(content, version) {
+			t.Errorf("version %q does not occur in the content", version)
+		}
+	})
+}
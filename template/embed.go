@@ -0,0 +1,15 @@
+// Package template embeds a minimal copy of the framework template in the
+// CLI, so that 'samuel init --minimal' works without network access.
+//
+// The files are read from this directory at build time; keep the workflow
+// list in sync with core.EmbeddedWorkflows.
+package template
+
+import "embed"
+
+// Minimal holds the core files and a few planning workflows, laid out as
+// in the template directory.
+//
+//go:embed CLAUDE.md AGENTS.md .claude/skills/README.md
+//go:embed .claude/skills/create-prd .claude/skills/generate-tasks .claude/skills/code-review
+var Minimal embed.FS